@@ -0,0 +1,251 @@
+/*
+ * Fichier: analyze.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande analyze: analyses des nombres premiers du crible. analyze bias
+ * compte les nombres premiers jusqu'à -limit par classe de résidus modulo
+ * -mod et fait la course entre deux classes (biais de Tchebychev,
+ * primes.ChebyshevBias). analyze ap cherche les progressions arithmétiques
+ * parmi les valeurs de n trouvées par la recherche, ou lues dans un fichier de
+ * résultats JSON (primes.ArithmeticProgressions), et présente les plus
+ * longues. analyze unrepresented liste le complément des résultats: les
+ * nombres premiers ≡ 1 mod 4 qui ne sont pas de la forme p^2 + 4q^2 avec p et
+ * q premiers (primes.FindUnrepresented).
+ */
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// runAnalyze implémente la sous-commande analyze et aiguille vers l'analyse demandée.
+func runAnalyze(args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "bias":
+			return runAnalyzeBias(args[1:], stdout, stderr)
+		case "ap":
+			return runAnalyzeAP(args[1:], stdout, stderr)
+		case "unrepresented":
+			return runAnalyzeUnrepresented(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprint(stderr, tr(msgAnalyzeUsage))
+	if len(args) == 0 {
+		return fmt.Errorf("%w: analyze: analyse manquante", errInvalidFlags)
+	}
+	return fmt.Errorf("%w: analyze: analyse inconnue %q", errInvalidFlags, args[0])
+}
+
+// parseBiasClasses analyse -classes ("A,B"); vide, il retourne les classes m-1 et 1.
+func parseBiasClasses(s string, modulus int) (a, b int, err error) {
+	if s == "" {
+		return modulus - 1, 1, nil
+	}
+	first, second, ok := strings.Cut(s, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("valeur invalide %q (attendu A,B)", s)
+	}
+	if a, err = strconv.Atoi(strings.TrimSpace(first)); err == nil {
+		b, err = strconv.Atoi(strings.TrimSpace(second))
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("valeur invalide %q (attendu A,B)", s)
+	}
+	return a, b, nil
+}
+
+// runAnalyzeBias implémente analyze bias.
+func runAnalyzeBias(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("analyze bias", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	limitPtr := fs.Int("limit", 1_000_000, tr(msgFlagLimit))
+	modPtr := fs.Int("mod", 4, tr(msgFlagBiasMod))
+	classesPtr := fs.String("classes", "", tr(msgFlagBiasClasses))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgAnalyzeUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *limitPtr < 2 {
+		return fmt.Errorf("%w: analyze bias: -limit=%d (attendu >= 2)", errInvalidFlags, *limitPtr)
+	}
+	a, b, err := parseBiasClasses(*classesPtr, *modPtr)
+	if err != nil {
+		return fmt.Errorf("%w: analyze bias: -classes: %v", errInvalidFlags, err)
+	}
+	report, err := primes.ChebyshevBias(primes.SieveOfEratosthenes(*limitPtr), *modPtr, a, b)
+	if err != nil {
+		return fmt.Errorf("%w: analyze bias: %v", errInvalidFlags, err)
+	}
+
+	out := &errWriter{w: stdout}
+	total := report.ALeads + report.BLeads + report.Ties
+	fmt.Fprint(out, tr(msgBiasTitle, total, *limitPtr, report.Modulus))
+	for r, n := range report.Counts {
+		if n > 0 {
+			fmt.Fprintf(out, "  %d mod %d: %d (%.2f %%)\n", r, report.Modulus, n, 100*float64(n)/float64(total))
+		}
+	}
+	percent := func(n int) float64 { return 100 * float64(n) / float64(total) }
+	fmt.Fprint(out, tr(msgBiasRace, report.A, report.B, report.Modulus, percent(report.ALeads), percent(report.BLeads), percent(report.Ties)))
+	if report.FirstBLead != 0 {
+		fmt.Fprint(out, tr(msgBiasFirstLead, report.B, report.Modulus, report.FirstBLead))
+	} else {
+		fmt.Fprint(out, tr(msgBiasNoLead, report.B, report.Modulus, *limitPtr))
+	}
+	if report.MaxGap > 0 {
+		fmt.Fprint(out, tr(msgBiasMaxGap, report.MaxGap, report.A, report.Modulus, report.MaxGapAt))
+	}
+	return writeError(out)
+}
+
+// runAnalyzeAP implémente analyze ap.
+func runAnalyzeAP(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("analyze ap", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	limitPtr := fs.Int("limit", 1000, tr(msgFlagLimit))
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	kPtr := fs.Int("k", 3, tr(msgFlagAPK))
+	resultsPtr := fs.String("results", "", tr(msgFlagAPResults))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgAnalyzeUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *kPtr < 3 {
+		return fmt.Errorf("%w: analyze ap: -k=%d (attendu >= 3)", errInvalidFlags, *kPtr)
+	}
+
+	var values []int64
+	formName := *formPtr
+	if *resultsPtr != "" {
+		results, manifest, err := readResults(*resultsPtr)
+		if err != nil {
+			return err
+		}
+		if manifest != nil && manifest.Params["form"] != "" && !flagSet(fs, "form") {
+			formName = manifest.Params["form"]
+		}
+		for _, res := range results {
+			if res.NBig == nil { // Au-delà d'int64: hors de l'analyse.
+				values = append(values, res.N)
+			}
+		}
+	} else {
+		form, ok := primes.LookupForm(*formPtr)
+		if !ok {
+			return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, *formPtr, primes.FormNames())
+		}
+		opts := primes.Options{Limit: *limitPtr, Form: form, Workers: defaultWorkers()}
+		err := primes.Search(context.Background(), opts, func(res primes.Result) error {
+			values = append(values, res.N)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("analyze ap: %w", err) // ErrOverflow ou ErrInvalidOptions: codes 3 et 2.
+		}
+	}
+	report, err := primes.ArithmeticProgressions(context.Background(), values, *kPtr)
+	if err != nil {
+		return fmt.Errorf("%w: analyze ap: %v", errInvalidFlags, err)
+	}
+
+	out := &errWriter{w: stdout}
+	fmt.Fprint(out, tr(msgAPTitle, report.MinLen, countInt(report.Values), formName))
+	if report.MaxLen == 0 {
+		fmt.Fprint(out, tr(msgAPNone, report.MinLen))
+		return writeError(out)
+	}
+	for length := report.MinLen; length <= report.MaxLen; length++ {
+		fmt.Fprint(out, tr(msgAPCount, length, countInt(report.Counts[length])))
+	}
+	fmt.Fprint(out, tr(msgAPLongest, report.MaxLen))
+	for _, p := range report.Longest {
+		terms := make([]string, p.Len)
+		for i, v := range p.Terms() {
+			terms[i] = strconv.FormatInt(v, 10)
+		}
+		fmt.Fprint(out, tr(msgAPTerm, strings.Join(terms, ", "), p.Diff))
+	}
+	if more := report.Counts[report.MaxLen] - int64(len(report.Longest)); more > 0 {
+		fmt.Fprint(out, tr(msgAPMore, countInt(more)))
+	}
+	return writeError(out)
+}
+
+// runAnalyzeUnrepresented implémente analyze unrepresented.
+func runAnalyzeUnrepresented(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("analyze unrepresented", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	limitPtr := fs.Int64("limit", 100_000, tr(msgFlagUnrepLimit))
+	maxPtr := fs.Int("max", 20, tr(msgFlagUnrepMax))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgAnalyzeUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *limitPtr < 5 || *maxPtr < -1 {
+		return fmt.Errorf("%w: analyze unrepresented: -limit=%d, -max=%d (attendu -limit >= 5, -max >= -1)", errInvalidFlags, *limitPtr, *maxPtr)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	report, err := primes.FindUnrepresented(ctx, *limitPtr, *maxPtr)
+	if err != nil {
+		return errInterrupted
+	}
+
+	out := &errWriter{w: stdout}
+	unrepresented := report.Unrepresented()
+	fmt.Fprint(out, tr(msgUnrepTitle, countInt(report.Candidates), report.Limit, countInt(report.Represented), countInt(unrepresented), 100*float64(unrepresented)/float64(report.Candidates)))
+	fmt.Fprint(out, tr(msgUnrepReasons, countInt(report.OnlyX), countInt(report.OnlyY), countInt(report.NeitherPrimes)))
+	if len(report.Smallest) > 0 {
+		fmt.Fprint(out, tr(msgUnrepSmallest))
+	}
+	for _, u := range report.Smallest {
+		reason := tr(msgUnrepNeither)
+		switch {
+		case u.YPrime:
+			reason = tr(msgUnrepNotX)
+		case u.XPrime:
+			reason = tr(msgUnrepNotY)
+		}
+		fmt.Fprint(out, tr(msgUnrepTerm, u.N, u.X, u.Y, reason))
+	}
+	if more := unrepresented - int64(len(report.Smallest)); more > 0 && len(report.Smallest) > 0 {
+		fmt.Fprint(out, tr(msgAPMore, countInt(more)))
+	}
+	return writeError(out)
+}
@@ -0,0 +1,104 @@
+/*
+ * Fichier: analyze_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande analyze.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunAnalyzeBias valide analyze bias de bout en bout et le rejet des options invalides.
+func TestRunAnalyzeBias(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"analyze", "bias", "-limit", "30000", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("analyze bias: %v", err)
+	}
+	for _, expected := range []string{"3245 nombres premiers jusqu'à 30000 par classe de résidus mod 4:\n", "  2 mod 4: 1 (0.03 %)\n", "Première avance de 1 mod 4: en x = 26861.\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("sortie sans %q:\n%s", expected, out.String())
+		}
+	}
+
+	for _, args := range [][]string{
+		{"analyze"},
+		{"analyze", "drift"},
+		{"analyze", "bias", "-mod", "2"},
+		{"analyze", "bias", "-classes", "1"},
+		{"analyze", "bias", "-classes", "2,1"},
+		{"analyze", "bias", "-limit", "1"},
+		{"analyze", "ap", "-k", "2"},
+		{"analyze", "ap", "-form", "x^3"},
+	} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
+
+// TestRunAnalyzeAP valide analyze ap sur la recherche et sur un fichier de résultats.
+func TestRunAnalyzeAP(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"analyze", "ap", "-limit", "100", "-k", "4", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("analyze ap: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "Progressions arithmétiques maximales d'au moins 4 termes parmi ") || !strings.Contains(out.String(), "Plus longues (") {
+		t.Errorf("sortie inattendue:\n%s", out.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	doc := `{"results": [{"p": 1, "q": 1, "n": 5}, {"p": 1, "q": 1, "n": 11}, {"p": 1, "q": 1, "n": 17}, {"p": 1, "q": 1, "n": 23}, {"p": 1, "q": 1, "n": 41}], "manifest": {"params": {"form": "x^2+1"}}}`
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := run([]string{"analyze", "ap", "-results", path, "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("analyze ap -results: %v", err)
+	}
+	for _, expected := range []string{"parmi 5 valeurs distinctes de n (forme x^2+1):\n", "  4 termes: 1\n", "  5, 11, 17, 23 (raison 6)\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("sortie sans %q:\n%s", expected, out.String())
+		}
+	}
+	out.Reset()
+	if err := run([]string{"analyze", "ap", "-results", path, "-k", "5", "-lang", "fr"}, &out, io.Discard); err != nil || !strings.Contains(out.String(), "Aucune progression de 5 termes.\n") {
+		t.Errorf("analyze ap -k 5: %v\n%s", err, out.String())
+	}
+}
+
+// TestRunAnalyzeUnrepresented valide analyze unrepresented de bout en bout et le rejet des options
+// invalides.
+func TestRunAnalyzeUnrepresented(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"analyze", "unrepresented", "-limit", "1000", "-max", "3", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("analyze unrepresented: %v", err)
+	}
+	want := "80 nombres premiers ≡ 1 mod 4 jusqu'à 1000: 22 de la forme p^2 + 4q^2 avec p et q premiers, 58 non représentés (72.50 %)\n" +
+		"  x non premier: 18, y non premier: 31, aucun des deux premier: 9\n" +
+		"Plus petits non représentés (n = x^2 + 4y^2):\n" +
+		"  5 = 1^2 + 4·1^2 (aucun des deux premier)\n" +
+		"  13 = 3^2 + 4·1^2 (y non premier)\n" +
+		"  17 = 1^2 + 4·2^2 (x non premier)\n" +
+		"  ... et 55 autres\n"
+	if out.String() != want {
+		t.Errorf("sortie:\n%s\nattendu:\n%s", out.String(), want)
+	}
+
+	for _, args := range [][]string{
+		{"analyze", "unrepresented", "-limit", "4"},
+		{"analyze", "unrepresented", "-max", "-2"},
+	} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Fichier: autotune.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Réglage automatique (option -autotune) du nombre de workers et de la taille
+ * des lots. De courtes rafales de calibration sont exécutées sur un
+ * échantillon représentatif des nombres premiers, sur la machine réelle, et
+ * la configuration offrant le meilleur débit est retenue pour la recherche.
+ */
+package main
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// calibrationSampleSize est le nombre de nombres premiers de l'échantillon de calibration
+// (soit jusqu'à calibrationSampleSize² paires par rafale).
+const calibrationSampleSize = 512
+
+// tuneBatchCandidates sont les tailles de lots essayées par l'autotune.
+var tuneBatchCandidates = []int{1, 16, 64, 256}
+
+// tuneConfig est une configuration candidate et le débit mesuré pendant sa rafale.
+type tuneConfig struct {
+	Workers   int
+	BatchSize int
+	Rate      float64 // Paires testées par seconde.
+}
+
+// tuneWorkerCandidates retourne les nombres de workers essayés: les puissances de deux
+// jusqu'à numCPU, numCPU lui-même et 2·numCPU (utile lorsque les workers attendent le collecteur).
+func tuneWorkerCandidates(numCPU int) []int {
+	var candidates []int
+	for w := 1; w < numCPU; w *= 2 {
+		candidates = append(candidates, w)
+	}
+	candidates = append(candidates, numCPU, 2*numCPU)
+	return slices.Compact(candidates)
+}
+
+// calibrationSample extrait au plus size nombres premiers régulièrement espacés dans la liste,
+// afin que les candidats n testés pendant la calibration couvrent toute la plage de la recherche.
+func calibrationSample(primeList []int, size int) []int {
+	if len(primeList) <= size {
+		return primeList
+	}
+	sample := make([]int, size)
+	for i := range sample {
+		sample[i] = primeList[i*(len(primeList)-1)/(size-1)]
+	}
+	return sample
+}
+
+// measureBurst exécute une rafale de recherche d'au plus burst et retourne le débit en paires/s.
+func measureBurst(sample []int, primeTest string, workers, batchSize int, burst time.Duration) float64 {
+	ctx, cancel := context.WithTimeout(context.Background(), burst)
+	defer cancel()
+
+	start := time.Now()
+	var tested int64
+	opts := primes.Options{Primes: sample, PrimeTest: primeTest, Workers: workers, BatchSize: batchSize, OnProgress: func(pr primes.Progress) {
+		tested = pr.Tested
+	}}
+	// L'expiration de la rafale est l'issue attendue: l'erreur du contexte est ignorée.
+	primes.Search(ctx, opts, func(primes.Result) error { return nil })
+	elapsed := time.Since(start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(tested) / elapsed
+}
+
+// autotune mesure chaque combinaison de workers et de taille de lots et retourne la meilleure,
+// ainsi que l'ensemble des mesures. measure est measureBurst hors tests.
+func autotune(primeList []int, primeTest string, burst time.Duration, workerCandidates, batchCandidates []int,
+	measure func(sample []int, primeTest string, workers, batchSize int, burst time.Duration) float64) (tuneConfig, []tuneConfig) {
+	sample := calibrationSample(primeList, calibrationSampleSize)
+
+	var best tuneConfig
+	trials := make([]tuneConfig, 0, len(workerCandidates)*len(batchCandidates))
+	for _, workers := range workerCandidates {
+		for _, batchSize := range batchCandidates {
+			trial := tuneConfig{Workers: workers, BatchSize: batchSize, Rate: measure(sample, primeTest, workers, batchSize, burst)}
+			trials = append(trials, trial)
+			if len(trials) == 1 || trial.Rate > best.Rate {
+				best = trial
+			}
+		}
+	}
+	return best, trials
+}
@@ -0,0 +1,69 @@
+/*
+ * Fichier: autotune_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du réglage automatique des workers et de la taille des lots.
+ */
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestTuneWorkerCandidates valide la liste des nombres de workers essayés.
+func TestTuneWorkerCandidates(t *testing.T) {
+	testCases := map[int][]int{
+		1: {1, 2},
+		4: {1, 2, 4, 8},
+		6: {1, 2, 4, 6, 12},
+	}
+	for numCPU, expected := range testCases {
+		if got := tuneWorkerCandidates(numCPU); !reflect.DeepEqual(got, expected) {
+			t.Errorf("tuneWorkerCandidates(%d) = %v, attendu %v", numCPU, got, expected)
+		}
+	}
+}
+
+// TestCalibrationSample valide l'échantillonnage régulier des nombres premiers.
+func TestCalibrationSample(t *testing.T) {
+	primeList := primes.SieveOfEratosthenes(30) // 2 3 5 7 11 13 17 19 23 29
+	if got := calibrationSample(primeList, 20); !reflect.DeepEqual(got, primeList) {
+		t.Errorf("échantillon d'une petite liste = %v, attendu la liste entière", got)
+	}
+	if got := calibrationSample(primeList, 4); !reflect.DeepEqual(got, []int{2, 7, 17, 29}) {
+		t.Errorf("calibrationSample(.., 4) = %v, attendu [2 7 17 29]", got)
+	}
+}
+
+// TestAutotune valide le choix de la configuration la plus rapide.
+func TestAutotune(t *testing.T) {
+	measure := func(sample []int, primeTest string, workers, batchSize int, burst time.Duration) float64 {
+		// Débit fictif maximal pour 2 workers et des lots de 16.
+		return float64(1000 - (workers-2)*(workers-2)*10 - (batchSize-16)*(batchSize-16)/10)
+	}
+	best, trials := autotune(primes.SieveOfEratosthenes(100), "miller", time.Millisecond, []int{1, 2, 4}, []int{1, 16, 64}, measure)
+	if best.Workers != 2 || best.BatchSize != 16 {
+		t.Errorf("meilleure configuration = %+v, attendu workers=2, lots=16", best)
+	}
+	if len(trials) != 9 {
+		t.Errorf("%d mesures, attendu 9", len(trials))
+	}
+}
+
+// TestMeasureBurst valide qu'une rafale réelle mesure un débit positif et s'arrête à temps.
+func TestMeasureBurst(t *testing.T) {
+	start := time.Now()
+	rate := measureBurst(primes.SieveOfEratosthenes(20000), "miller", 2, 64, 20*time.Millisecond)
+	if rate <= 0 {
+		t.Errorf("débit mesuré = %f, attendu > 0", rate)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("la rafale a duré %s", elapsed)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ * Fichier: certify.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande certify: certificats de primalité ECPP (primes.ECPPProver)
+ * pour des entiers de plusieurs centaines de bits, donnés en arguments ou lus
+ * dans un fichier de résultats (-results: les n au-delà d'int64, ceux de
+ * -on-overflow promote-big), écrits en NDJSON (un certificat par ligne).
+ * -verify relit un tel fichier et vérifie chaque certificat sans le prouveur.
+ */
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// certifyValues prouve chaque valeur et écrit les certificats obtenus sur w. Le bilan de chaque
+// valeur est écrit sur status; le nombre de valeurs sans certificat est retourné.
+func certifyValues(prover primes.Prover, values []*big.Int, timeout time.Duration, w, status io.Writer) (failed int, err error) {
+	enc := json.NewEncoder(w)
+	for _, n := range values {
+		start := time.Now()
+		cert, perr := proveWithin(prover, n, timeout)
+		switch {
+		case perr == nil:
+			if err := enc.Encode(cert); err != nil {
+				return failed, fmt.Errorf("%w: %v", errIO, err)
+			}
+			fmt.Fprint(status, tr(msgCertifyProved, n, len(cert.Steps), time.Since(start).Round(time.Millisecond)))
+		case errors.Is(perr, primes.ErrNotPrime):
+			failed++
+			fmt.Fprint(status, tr(msgCertifyComposite, n))
+		case errors.Is(perr, primes.ErrNoProof), errors.Is(perr, context.DeadlineExceeded):
+			failed++
+			fmt.Fprint(status, tr(msgCertifyNoProof, n, perr))
+		default:
+			return failed, perr
+		}
+	}
+	return failed, nil
+}
+
+// proveWithin prouve n en au plus timeout (0: sans limite).
+func proveWithin(prover primes.Prover, n *big.Int, timeout time.Duration) (*primes.Certificate, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return prover.Prove(ctx, n)
+}
+
+// verifyCertificates vérifie les certificats NDJSON lus sur r et écrit un verdict par certificat
+// sur w; le nombre de certificats et celui des certificats invalides sont retournés.
+func verifyCertificates(r io.Reader, name string, w io.Writer) (total, invalid int, err error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var cert primes.Certificate
+		if err := dec.Decode(&cert); err == io.EOF {
+			return total, invalid, nil
+		} else if err != nil {
+			return total, invalid, fmt.Errorf("%w: %s: certificat %d: %v", errInvalidInput, name, total+1, err)
+		}
+		total++
+		if verr := cert.Verify(); verr != nil {
+			invalid++
+			fmt.Fprint(w, tr(msgCertifyInvalid, cert.N, verr))
+			continue
+		}
+		fmt.Fprint(w, tr(msgCertifyValid, cert.N, len(cert.Steps)))
+	}
+}
+
+// runCertify implémente la sous-commande certify. Une valeur sans certificat, ou un certificat
+// invalide avec -verify, retourne une erreur enveloppant errVerification.
+func runCertify(args []string, stdout, stderr io.Writer) (err error) {
+	fs := flag.NewFlagSet("certify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	resultsPtr := fs.String("results", "", tr(msgFlagCertifyResults))
+	outputPtr := fs.String("o", "", tr(msgFlagCertifyOutput))
+	verifyPtr := fs.String("verify", "", tr(msgFlagCertifyVerify))
+	timeoutPtr := fs.Duration("timeout", 0, tr(msgFlagCertifyTimeout))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgCertifyUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	sources := 0
+	for _, set := range []bool{fs.NArg() > 0, *resultsPtr != "", *verifyPtr != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		fs.Usage()
+		return fmt.Errorf("%w: certify: des valeurs de n, -results ou -verify sont requis (un seul des trois)", errInvalidFlags)
+	}
+	if *timeoutPtr < 0 {
+		return fmt.Errorf("%w: -timeout=%v (attendu >= 0)", errInvalidFlags, *timeoutPtr)
+	}
+
+	if *verifyPtr != "" {
+		f, err := os.Open(*verifyPtr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer f.Close()
+		out := &errWriter{w: stdout}
+		total, invalid, err := verifyCertificates(f, *verifyPtr, out)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, tr(msgCertifyVerifySummary, countInt(total), countInt(total-invalid), countInt(invalid)))
+		if err := writeError(out); err != nil {
+			return err
+		}
+		if invalid > 0 {
+			return fmt.Errorf("%w: certify: %d certificat(s) invalide(s) sur %d", errVerification, invalid, total)
+		}
+		return nil
+	}
+
+	var values []*big.Int
+	for _, arg := range fs.Args() {
+		n, ok := new(big.Int).SetString(arg, 10)
+		if !ok || n.Cmp(big.NewInt(2)) < 0 {
+			return fmt.Errorf("%w: certify: valeur invalide %q (attendu un entier décimal >= 2)", errInvalidFlags, arg)
+		}
+		values = append(values, n)
+	}
+	if *resultsPtr != "" {
+		results, _, err := readResults(*resultsPtr)
+		if err != nil {
+			return err
+		}
+		for _, res := range results {
+			// En deçà d'int64, la primalité de n est déjà exacte (primes.IsPrime).
+			if res.NBig != nil {
+				values = append(values, res.NBig)
+			}
+		}
+	}
+
+	var w io.Writer = stdout
+	if *outputPtr != "" {
+		f, err := os.Create(*outputPtr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("%w: %v", errIO, cerr)
+			}
+		}()
+		w = f
+	}
+	out := &errWriter{w: w}
+	failed, err := certifyValues(primes.ECPPProver{}, values, *timeoutPtr, out, stderr)
+	if err != nil {
+		return err
+	}
+	if err := writeError(out); err != nil {
+		return err
+	}
+	fmt.Fprint(stderr, tr(msgCertifySummary, countInt(len(values)), countInt(len(values)-failed), countInt(failed)))
+	if failed > 0 {
+		return fmt.Errorf("%w: certify: %d valeur(s) sans certificat sur %d", errVerification, failed, len(values))
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+ * Fichier: certify_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande certify: certificats écrits puis revérifiés,
+ * rejet d'un certificat altéré et d'un composé, preuve des n d'un fichier de
+ * résultats promote-big et codes de sortie.
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestRunCertify prouve deux premiers de Mersenne, revérifie les certificats écrits, puis rejette
+// un certificat altéré et un composé.
+func TestRunCertify(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+
+	dir := t.TempDir()
+	certs := filepath.Join(dir, "certs.ndjson")
+	m89 := "618970019642690137449562111"              // 2^89 - 1
+	m127 := "170141183460469231731687303715884105727" // 2^127 - 1
+	var stderr bytes.Buffer
+	if err := run([]string{"certify", "-o", certs, m89, m127}, io.Discard, &stderr); err != nil {
+		t.Fatalf("certify: %v\n%s", err, stderr.String())
+	}
+	for _, want := range []string{m127 + ": premier, certificat de", "2 entiers: 2 certifiés, 0 sans certificat"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("bilan sans %q:\n%s", want, stderr.String())
+		}
+	}
+
+	var stdout bytes.Buffer
+	if err := run([]string{"certify", "-verify", certs}, &stdout, io.Discard); err != nil {
+		t.Fatalf("certify -verify: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "2 certificats vérifiés: 2 valides, 0 invalides") {
+		t.Errorf("sortie de -verify:\n%s", stdout.String())
+	}
+
+	// Altération de l'abscisse du point de la première étape du second certificat.
+	data, err := os.ReadFile(certs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	var cert primes.Certificate
+	if err := json.Unmarshal([]byte(lines[1]), &cert); err != nil {
+		t.Fatal(err)
+	}
+	cert.Steps[0].X.Add(cert.Steps[0].X, big.NewInt(1))
+	altered, _ := json.Marshal(cert)
+	tampered := filepath.Join(dir, "altere.ndjson")
+	if err := os.WriteFile(tampered, []byte(lines[0]+"\n"+string(altered)+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if err := run([]string{"certify", "-verify", tampered}, &stdout, io.Discard); !errors.Is(err, errVerification) {
+		t.Errorf("certificat altéré: %v, attendu errVerification", err)
+	}
+	if !strings.Contains(stdout.String(), m127+": certificat invalide") {
+		t.Errorf("sortie de -verify:\n%s", stdout.String())
+	}
+
+	stderr.Reset()
+	composite := new(big.Int).Mul(big.NewInt(1<<61-1), big.NewInt(1<<31-1)).String()
+	if err := run([]string{"certify", composite}, io.Discard, &stderr); !errors.Is(err, errVerification) {
+		t.Errorf("composé: %v, attendu errVerification", err)
+	}
+	if !strings.Contains(stderr.String(), composite+": composé") {
+		t.Errorf("bilan du composé:\n%s", stderr.String())
+	}
+
+	bad := filepath.Join(dir, "invalide.ndjson")
+	if err := os.WriteFile(bad, []byte("{\"n\":\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"certify"}, exitInvalidFlags},
+		{[]string{"certify", "abc"}, exitInvalidFlags},
+		{[]string{"certify", "1"}, exitInvalidFlags},
+		{[]string{"certify", "-verify", certs, m89}, exitInvalidFlags},
+		{[]string{"certify", "-timeout", "-1s", m89}, exitInvalidFlags},
+		{[]string{"certify", "-verify", filepath.Join(dir, "absent.ndjson")}, exitIO},
+		{[]string{"certify", "-verify", bad}, exitInvalidInput},
+	} {
+		if got := exitCode(run(tc.args, io.Discard, io.Discard)); got != tc.code {
+			t.Errorf("%v -> code %d, attendu %d", tc.args, got, tc.code)
+		}
+	}
+}
+
+// TestRunCertifyResults prouve les n promus au-delà d'int64 d'un fichier de résultats.
+func TestRunCertifyResults(t *testing.T) {
+	var list strings.Builder
+	list.WriteString("3\n5\n7\n11\n")
+	for x, found := int64(1_600_000_000), 0; found < 3; x++ {
+		if primes.IsPrime(x) {
+			fmt.Fprintln(&list, x)
+			found++
+		}
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "primes.txt")
+	if err := os.WriteFile(path, []byte(list.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results := filepath.Join(dir, "res.ndjson")
+	if err := run([]string{"-primes-file", path, "-workers", "1", "-on-overflow", "promote-big", "-format", "ndjson", "-o", results}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("recherche: %v", err)
+	}
+	var stdout bytes.Buffer
+	if err := run([]string{"certify", "-results", results}, &stdout, io.Discard); err != nil {
+		t.Fatalf("certify -results: %v", err)
+	}
+	promoted := strings.Count(stdout.String(), "\n")
+	if promoted == 0 {
+		t.Fatal("aucun certificat pour les résultats promus")
+	}
+	dec := json.NewDecoder(&stdout)
+	for range promoted {
+		var cert primes.Certificate
+		if err := dec.Decode(&cert); err != nil {
+			t.Fatal(err)
+		}
+		if cert.N.IsInt64() {
+			t.Errorf("certificat de %v, qui tient dans un int64", cert.N)
+		}
+		if err := cert.Verify(); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * Fichier: check.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande check: vérification de paires (p, q) fournies par un tiers.
+ * Le fichier -input (CSV: p,q et, facultativement, la valeur n annoncée) est
+ * lu ligne à ligne; pour chaque paire, check vérifie que p et q sont premiers,
+ * calcule n exactement (au-delà d'int64 si nécessaire), le compare à la
+ * valeur annoncée et teste sa primalité. Un verdict est écrit par ligne, en
+ * CSV; le code de sortie vaut 5 si une paire est rejetée.
+ */
+package main
+
+import (
+	crand "crypto/rand"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// Verdicts de check, écrits tels quels dans la colonne verdict.
+const (
+	verdictPrime     = "prime"       // p, q et n premiers: paire valide.
+	verdictComposite = "composite"   // n composé.
+	verdictPNotPrime = "p-not-prime" // p n'est pas premier.
+	verdictQNotPrime = "q-not-prime" // q n'est pas premier.
+	verdictNMismatch = "n-mismatch"  // La valeur n annoncée diffère de celle de la forme.
+)
+
+// checkMaxFieldCount est le nombre maximal de champs d'une ligne: p, q et n annoncé.
+const checkMaxFieldCount = 3
+
+// checkPair est une ligne du fichier à vérifier.
+type checkPair struct {
+	p, q    int64
+	claimed *big.Int // Valeur n annoncée (nil: absente).
+}
+
+// parseCheckRecord analyse un enregistrement CSV: p,q[,n].
+func parseCheckRecord(record []string, line int) (checkPair, error) {
+	if len(record) < 2 || len(record) > checkMaxFieldCount {
+		return checkPair{}, fmt.Errorf("%w: ligne %d: %d champs (attendu p,q[,n])", errInvalidInput, line, len(record))
+	}
+	var pair checkPair
+	var err error
+	if pair.p, err = strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64); err == nil {
+		pair.q, err = strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64)
+	}
+	if err != nil || pair.p < 0 || pair.q < 0 {
+		return checkPair{}, fmt.Errorf("%w: ligne %d: paire invalide %q", errInvalidInput, line, strings.Join(record[:2], ","))
+	}
+	if len(record) == checkMaxFieldCount {
+		claimed, ok := new(big.Int).SetString(strings.TrimSpace(record[2]), 10)
+		if !ok {
+			return checkPair{}, fmt.Errorf("%w: ligne %d: n invalide %q", errInvalidInput, line, record[2])
+		}
+		pair.claimed = claimed
+	}
+	return pair, nil
+}
+
+// checkVerdict calcule n pour la paire et retourne le verdict: primalité de p et q, valeur annoncée,
+// puis primalité de n par isPrimeBig.
+func checkVerdict(pair checkPair, form primes.Form, isPrimeBig func(*big.Int) bool) (*big.Int, string) {
+	var n *big.Int
+	if bf, ok := form.(primes.BigForm); ok {
+		n = bf.EvalBig(pair.p, pair.q)
+	} else {
+		n = big.NewInt(form.Eval(pair.p, pair.q))
+	}
+	switch {
+	case !primes.IsPrime(pair.p):
+		return n, verdictPNotPrime
+	case !primes.IsPrime(pair.q):
+		return n, verdictQNotPrime
+	case pair.claimed != nil && pair.claimed.Cmp(n) != 0:
+		return n, verdictNMismatch
+	case !isPrimeBig(n):
+		return n, verdictComposite
+	}
+	return n, verdictPrime
+}
+
+// runCheck implémente la sous-commande check. Une paire rejetée retourne une erreur enveloppant
+// errVerification, après le verdict de toutes les lignes.
+func runCheck(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	inputPtr := fs.String("input", "", tr(msgFlagCheckInput))
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	witnessSourcePtr := fs.String("witness-source", "default", tr(msgFlagWitnessSource, strings.Join(witnessSources, ", ")))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgCheckUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *inputPtr == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: check: -input est requis", errInvalidFlags)
+	}
+	form, ok := primes.LookupForm(*formPtr)
+	if !ok {
+		return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, *formPtr, primes.FormNames())
+	}
+	var policy primes.MillerRabinPolicy
+	switch *witnessSourcePtr {
+	case "crypto":
+		policy.Rand = crand.Reader
+	case "default":
+	default:
+		return fmt.Errorf("%w: -witness-source=%q (attendu %v)", errInvalidFlags, *witnessSourcePtr, witnessSources)
+	}
+
+	f, err := os.Open(*inputPtr)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	out := &errWriter{w: stdout}
+	cw := csv.NewWriter(out)
+	cw.Write([]string{"p", "q", "n", "verdict"})
+	total, rejected := 0, 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", errInvalidInput, *inputPtr, err)
+		}
+		line, _ := r.FieldPos(0)
+		if total == 0 && strings.TrimSpace(record[0]) == "p" {
+			continue // En-tête p,q[,n].
+		}
+		pair, err := parseCheckRecord(record, line)
+		if err != nil {
+			return err
+		}
+		n, verdict := checkVerdict(pair, form, policy.IsPrimeBig)
+		cw.Write([]string{strconv.FormatInt(pair.p, 10), strconv.FormatInt(pair.q, 10), n.String(), verdict})
+		total++
+		if verdict != verdictPrime {
+			rejected++
+		}
+	}
+	cw.Flush()
+	if err := writeError(out); err != nil {
+		return err
+	}
+	fmt.Fprint(stderr, tr(msgCheckSummary, countInt(total), countInt(total-rejected), countInt(rejected)))
+	if rejected > 0 {
+		return fmt.Errorf("%w: check: %d paire(s) rejetée(s) sur %d", errVerification, rejected, total)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Fichier: check_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande check.
+ */
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRunCheck valide les verdicts, y compris au-delà d'int64, et les codes de sortie.
+func TestRunCheck(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	valid := write("valides.csv", "p,q,n\n# Résultats annoncés\n5,3,61\n7, 5\n7,1600000021,10240000268800001813\n")
+	var out bytes.Buffer
+	if err := run([]string{"check", "-input", valid}, &out, io.Discard); err != nil {
+		t.Fatalf("paires valides: %v", err)
+	}
+	want := "p,q,n,verdict\n5,3,61,prime\n7,5,149,prime\n7,1600000021,10240000268800001813,prime\n"
+	if out.String() != want {
+		t.Errorf("sortie = %q, attendu %q", out.String(), want)
+	}
+
+	claims := write("annonces.csv", "3,2\n4,3\n3,4\n3,5,110\n5,3,61\n")
+	out.Reset()
+	err := run([]string{"check", "-input", claims, "-witness-source", "crypto"}, &out, io.Discard)
+	if !errors.Is(err, errVerification) {
+		t.Errorf("paires rejetées: %v, attendu errVerification", err)
+	}
+	want = "p,q,n,verdict\n3,2,25,composite\n4,3,52,p-not-prime\n3,4,73,q-not-prime\n3,5,109,n-mismatch\n5,3,61,prime\n"
+	if out.String() != want {
+		t.Errorf("sortie = %q, attendu %q", out.String(), want)
+	}
+
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"check"}, exitInvalidFlags},
+		{[]string{"check", "-input", valid, "-form", "inconnue"}, exitInvalidFlags},
+		{[]string{"check", "-input", filepath.Join(dir, "absent.csv")}, exitIO},
+		{[]string{"check", "-input", write("invalide.csv", "5,3\n5,x\n")}, exitInvalidInput},
+		{[]string{"check", "-input", write("champs.csv", "5,3,61,0\n")}, exitInvalidInput},
+	} {
+		if got := exitCode(run(tc.args, io.Discard, io.Discard)); got != tc.code {
+			t.Errorf("%v -> code %d, attendu %d", tc.args, got, tc.code)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Fichier: checkpoint.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Format binaire des points de reprise (manifeste de campagne de chunks),
+ * indépendant de gob et de la disposition des structures Go: un cadre
+ * (signature "PNCK", version du contenu, longueur, contenu, CRC-32C) et des
+ * champs encodés en varints et chaînes préfixées par leur longueur. Les
+ * enregistrements sont eux aussi préfixés par leur longueur: un lecteur
+ * ignore les champs ajoutés en fin d'enregistrement par une version
+ * ultérieure. Toute altération du fichier est détectée par le CRC.
+ */
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+// checkpointMagic est la signature des fichiers de points de reprise.
+const checkpointMagic = "PNCK"
+
+// checkpointHeaderSize est la taille de l'en-tête du cadre: signature, version (uint16) et
+// longueur du contenu (uint32).
+const checkpointHeaderSize = len(checkpointMagic) + 2 + 4
+
+// checkpointCRC est la table du CRC-32 (polynôme de Castagnoli) qui termine le cadre.
+var checkpointCRC = crc32.MakeTable(crc32.Castagnoli)
+
+// errCheckpointTruncated signale un contenu plus court que ses champs.
+var errCheckpointTruncated = errors.New("point de reprise tronqué")
+
+// encodeCheckpoint encadre payload: signature, version, longueur, contenu puis CRC-32C de
+// l'ensemble (entiers gros-boutistes).
+func encodeCheckpoint(version uint16, payload []byte) []byte {
+	data := make([]byte, 0, checkpointHeaderSize+len(payload)+4)
+	data = append(data, checkpointMagic...)
+	data = binary.BigEndian.AppendUint16(data, version)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(payload)))
+	data = append(data, payload...)
+	return binary.BigEndian.AppendUint32(data, crc32.Checksum(data, checkpointCRC))
+}
+
+// decodeCheckpoint vérifie le cadre de data (signature, longueur, CRC) et retourne la version et
+// le contenu.
+func decodeCheckpoint(data []byte) (uint16, []byte, error) {
+	if len(data) < checkpointHeaderSize+4 || string(data[:len(checkpointMagic)]) != checkpointMagic {
+		return 0, nil, errors.New("signature de point de reprise absente")
+	}
+	header := data[len(checkpointMagic):checkpointHeaderSize]
+	version, length := binary.BigEndian.Uint16(header), binary.BigEndian.Uint32(header[2:])
+	if uint64(len(data)) != uint64(checkpointHeaderSize)+uint64(length)+4 {
+		return 0, nil, fmt.Errorf("%w: %d octets, %d annoncés", errCheckpointTruncated, len(data), checkpointHeaderSize+int(length)+4)
+	}
+	body, sum := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	if got := crc32.Checksum(body, checkpointCRC); got != sum {
+		return 0, nil, fmt.Errorf("point de reprise corrompu (CRC %08x, attendu %08x)", got, sum)
+	}
+	return version, body[checkpointHeaderSize:], nil
+}
+
+// checkpointEncoder construit le contenu d'un point de reprise champ par champ.
+type checkpointEncoder struct{ buf []byte }
+
+// putInt ajoute un entier signé (varint).
+func (e *checkpointEncoder) putInt(v int64) { e.buf = binary.AppendVarint(e.buf, v) }
+
+// putString ajoute une chaîne préfixée par sa longueur.
+func (e *checkpointEncoder) putString(s string) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// putTime ajoute un instant en nanosecondes UTC depuis l'époque Unix (0 pour l'instant nul).
+func (e *checkpointEncoder) putTime(t time.Time) {
+	if t.IsZero() {
+		e.putInt(0)
+		return
+	}
+	e.putInt(t.UnixNano())
+}
+
+// putRecord ajoute un enregistrement préfixé par sa longueur, dont fill écrit les champs.
+func (e *checkpointEncoder) putRecord(fill func(*checkpointEncoder)) {
+	var rec checkpointEncoder
+	fill(&rec)
+	e.putString(string(rec.buf))
+}
+
+// checkpointDecoder lit le contenu d'un point de reprise champ par champ. La première erreur est
+// mémorisée: les lectures suivantes retournent des valeurs nulles, et l'erreur est consultée une
+// fois le contenu lu.
+type checkpointDecoder struct {
+	data []byte
+	err  error
+}
+
+// int lit un entier signé (varint).
+func (d *checkpointDecoder) int() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.err = errCheckpointTruncated
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+// string lit une chaîne préfixée par sa longueur.
+func (d *checkpointDecoder) string() string {
+	if d.err != nil {
+		return ""
+	}
+	length, n := binary.Uvarint(d.data)
+	if n <= 0 || length > uint64(len(d.data)-n) {
+		d.err = errCheckpointTruncated
+		return ""
+	}
+	s := string(d.data[n : n+int(length)])
+	d.data = d.data[n+int(length):]
+	return s
+}
+
+// time lit un instant écrit par putTime, en UTC.
+func (d *checkpointDecoder) time() time.Time {
+	ns := d.int()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns).UTC()
+}
+
+// record lit un enregistrement écrit par putRecord, dont read lit les champs. Les champs que le
+// lecteur ne connaît pas, en fin d'enregistrement, sont ignorés.
+func (d *checkpointDecoder) record(read func(*checkpointDecoder)) {
+	rec := checkpointDecoder{data: []byte(d.string()), err: d.err}
+	read(&rec)
+	d.err = rec.err
+}
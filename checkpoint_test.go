@@ -0,0 +1,70 @@
+/*
+ * Fichier: checkpoint_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du format binaire des points de reprise: cadre, détection des
+ * altérations et lecture des champs.
+ */
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCheckpointFrame valide l'aller-retour du cadre et la détection de toute altération.
+func TestCheckpointFrame(t *testing.T) {
+	data := encodeCheckpoint(7, []byte("contenu"))
+	version, payload, err := decodeCheckpoint(data)
+	if err != nil || version != 7 || string(payload) != "contenu" {
+		t.Fatalf("decodeCheckpoint = %d, %q, %v", version, payload, err)
+	}
+	// Chaque octet modifié est détecté: signature, version, longueur, contenu ou CRC.
+	for i := range data {
+		altered := bytes.Clone(data)
+		altered[i] ^= 0x10
+		if _, _, err := decodeCheckpoint(altered); err == nil {
+			t.Errorf("octet %d modifié: altération non détectée", i)
+		}
+	}
+	if _, _, err := decodeCheckpoint(data[:len(data)-1]); !errors.Is(err, errCheckpointTruncated) {
+		t.Errorf("cadre tronqué: %v, attendu errCheckpointTruncated", err)
+	}
+	if _, _, err := decodeCheckpoint([]byte("{}")); err == nil {
+		t.Error("JSON accepté comme point de reprise")
+	}
+}
+
+// TestCheckpointFields valide l'encodage des champs, les champs inconnus en fin d'enregistrement et
+// les contenus tronqués.
+func TestCheckpointFields(t *testing.T) {
+	completed := time.Date(2026, 10, 16, 8, 30, 0, 123, time.UTC)
+	var e checkpointEncoder
+	e.putInt(-42)
+	e.putString("p^2+4q^2")
+	e.putRecord(func(r *checkpointEncoder) {
+		r.putTime(completed)
+		r.putTime(time.Time{})
+		r.putString("champ d'une version ultérieure")
+	})
+	e.putInt(1 << 40)
+
+	d := checkpointDecoder{data: e.buf}
+	i, s := d.int(), d.string()
+	var t1, t2 time.Time
+	d.record(func(r *checkpointDecoder) { t1, t2 = r.time(), r.time() })
+	last := d.int()
+	if d.err != nil || i != -42 || s != "p^2+4q^2" || !t1.Equal(completed) || !t2.IsZero() || last != 1<<40 || len(d.data) != 0 {
+		t.Errorf("lecture = %d, %q, %v, %v, %d, reste %d octets, %v", i, s, t1, t2, last, len(d.data), d.err)
+	}
+
+	d = checkpointDecoder{data: e.buf[:5]}
+	d.int()
+	if d.string(); !errors.Is(d.err, errCheckpointTruncated) {
+		t.Errorf("contenu tronqué: %v, attendu errCheckpointTruncated", d.err)
+	}
+}
@@ -0,0 +1,447 @@
+/*
+ * Fichier: chunks.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande chunks: campagne de recherche découpée en tranches nommées de
+ * la grille (p, q) (chunk-0000, chunk-0001...), chacune couvrant un intervalle
+ * de p et toutes les valeurs de q. Le répertoire de la campagne contient un
+ * manifeste (chunks.ckpt, point de reprise binaire versionné, voir
+ * checkpoint.go: paramètres, puis état, nombre de résultats et somme SHA-256
+ * de chaque tranche) et un fichier NDJSON de résultats par tranche. Le
+ * manifeste JSON des versions antérieures (chunks.json) est migré à la lecture.
+ * Une campagne interrompue reprend aux tranches en attente; une tranche peut
+ * être recalculée (-chunk) ou revérifiée (-verify) indépendamment des autres.
+ */
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// chunkManifestName est le nom du manifeste dans le répertoire de la campagne.
+const chunkManifestName = "chunks.ckpt"
+
+// chunkLegacyManifestName est le nom du manifeste JSON de la version 1, remplacé à la première
+// écriture.
+const chunkLegacyManifestName = "chunks.json"
+
+// chunkCampaignVersion est la version du format du manifeste de campagne: 1 pour le manifeste
+// JSON, 2 pour le point de reprise binaire.
+const chunkCampaignVersion = 2
+
+// États d'une tranche dans le manifeste.
+const (
+	chunkPending = "pending"
+	chunkDone    = "done"
+)
+
+// chunkCampaign est le manifeste d'une campagne: ses paramètres, fixés à la création, et ses tranches.
+type chunkCampaign struct {
+	Version   int          `json:"version"`
+	Limit     int          `json:"limit"`
+	Form      string       `json:"form"`
+	PrimeTest string       `json:"primetest"`
+	Pairs     string       `json:"pairs"`
+	Chunks    []chunkEntry `json:"chunks"`
+}
+
+// chunkEntry est une tranche de la campagne: les paires (p, q) de la grille avec p dans [PMin, PMax].
+type chunkEntry struct {
+	Name      string    `json:"name"`
+	PMin      int       `json:"p_min"`
+	PMax      int       `json:"p_max"`
+	Status    string    `json:"status"`
+	Results   int       `json:"results"`
+	SHA256    string    `json:"sha256,omitempty"`
+	Completed time.Time `json:"completed,omitzero"`
+}
+
+// file retourne le chemin du fichier de résultats de la tranche dans le répertoire dir.
+func (c chunkEntry) file(dir string) string { return filepath.Join(dir, c.Name+".ndjson") }
+
+// splitChunks découpe les p de primeList en au plus count tranches contiguës comptant le même
+// nombre de nombres premiers, à un près.
+func splitChunks(primeList []int, count int) []chunkEntry {
+	count = min(count, len(primeList))
+	chunks := make([]chunkEntry, count)
+	for i := range chunks {
+		lo, hi := i*len(primeList)/count, (i+1)*len(primeList)/count
+		chunks[i] = chunkEntry{
+			Name:   fmt.Sprintf("chunk-%04d", i),
+			PMin:   primeList[lo],
+			PMax:   primeList[hi-1],
+			Status: chunkPending,
+		}
+	}
+	return chunks
+}
+
+// encodeChunkCampaign encode le manifeste au format courant: paramètres, nombre de tranches, puis
+// un enregistrement par tranche.
+func encodeChunkCampaign(campaign chunkCampaign) []byte {
+	var e checkpointEncoder
+	e.putInt(int64(campaign.Limit))
+	e.putString(campaign.Form)
+	e.putString(campaign.PrimeTest)
+	e.putString(campaign.Pairs)
+	e.putInt(int64(len(campaign.Chunks)))
+	for _, c := range campaign.Chunks {
+		e.putRecord(func(r *checkpointEncoder) {
+			r.putString(c.Name)
+			r.putInt(int64(c.PMin))
+			r.putInt(int64(c.PMax))
+			r.putString(c.Status)
+			r.putInt(int64(c.Results))
+			r.putString(c.SHA256)
+			r.putTime(c.Completed)
+		})
+	}
+	return encodeCheckpoint(chunkCampaignVersion, e.buf)
+}
+
+// decodeChunkCampaign décode un manifeste écrit par encodeChunkCampaign. Un manifeste d'une
+// version ultérieure est refusé: ses champs pourraient changer de sens.
+func decodeChunkCampaign(data []byte) (chunkCampaign, error) {
+	version, payload, err := decodeCheckpoint(data)
+	if err != nil {
+		return chunkCampaign{}, err
+	}
+	if version != chunkCampaignVersion {
+		return chunkCampaign{}, fmt.Errorf("version %d du manifeste non prise en charge (attendu %d)", version, chunkCampaignVersion)
+	}
+	d := checkpointDecoder{data: payload}
+	campaign := chunkCampaign{Version: int(version), Limit: int(d.int()), Form: d.string(), PrimeTest: d.string(), Pairs: d.string()}
+	count := d.int()
+	if count < 0 || count > int64(len(payload)) {
+		return chunkCampaign{}, fmt.Errorf("%w: %d tranches annoncées", errCheckpointTruncated, count)
+	}
+	campaign.Chunks = make([]chunkEntry, count)
+	for i := range campaign.Chunks {
+		d.record(func(r *checkpointDecoder) {
+			campaign.Chunks[i] = chunkEntry{Name: r.string(), PMin: int(r.int()), PMax: int(r.int()), Status: r.string(), Results: int(r.int()), SHA256: r.string(), Completed: r.time()}
+		})
+	}
+	return campaign, d.err
+}
+
+// migrateChunkCampaign porte au format courant un manifeste lu dans une version antérieure, une
+// version après l'autre. Il sera réécrit au format courant à la prochaine écriture.
+func migrateChunkCampaign(campaign chunkCampaign) chunkCampaign {
+	for campaign.Version < chunkCampaignVersion {
+		switch campaign.Version {
+		case 1:
+			// 1 -> 2: mêmes champs; seul l'encodage change (JSON -> point de reprise binaire).
+		}
+		campaign.Version++
+	}
+	return campaign
+}
+
+// readChunkCampaign lit le manifeste de la campagne du répertoire dir, ou à défaut le manifeste JSON
+// de la version 1, migré; ok vaut false s'il n'existe pas.
+func readChunkCampaign(dir string) (campaign chunkCampaign, ok bool, err error) {
+	path := filepath.Join(dir, chunkManifestName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return readLegacyChunkCampaign(dir)
+	}
+	if err != nil {
+		return campaign, false, fmt.Errorf("%w: %v", errIO, err)
+	}
+	if campaign, err = decodeChunkCampaign(data); err != nil {
+		return campaign, false, fmt.Errorf("%w: manifeste %s illisible: %v", errInvalidInput, path, err)
+	}
+	return campaign, true, nil
+}
+
+// readLegacyChunkCampaign lit le manifeste JSON de la version 1 et le migre au format courant.
+func readLegacyChunkCampaign(dir string) (campaign chunkCampaign, ok bool, err error) {
+	path := filepath.Join(dir, chunkLegacyManifestName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return campaign, false, nil
+	}
+	if err != nil {
+		return campaign, false, fmt.Errorf("%w: %v", errIO, err)
+	}
+	if err := json.Unmarshal(data, &campaign); err != nil || campaign.Version != 1 {
+		return campaign, false, fmt.Errorf("%w: manifeste %s illisible (version %d, %v)", errInvalidInput, path, campaign.Version, err)
+	}
+	return migrateChunkCampaign(campaign), true, nil
+}
+
+// writeChunkCampaign écrit le manifeste de la campagne au format courant, de façon atomique, puis
+// supprime le manifeste JSON d'une version antérieure.
+func writeChunkCampaign(dir string, campaign chunkCampaign) error {
+	if err := writeFileAtomic(filepath.Join(dir, chunkManifestName), encodeChunkCampaign(campaign)); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	if err := os.Remove(filepath.Join(dir, chunkLegacyManifestName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
+
+// lockChunkCampaign verrouille la campagne du répertoire dir, sans attendre: une autre exécution
+// qui la traite déjà donne errIO.
+func lockChunkCampaign(dir string) (*fileLock, error) {
+	path := filepath.Join(dir, chunkManifestName)
+	lock, err := lockFile(path, false)
+	if errors.Is(err, errLocked) {
+		return nil, fmt.Errorf("%w: chunks: %s", errIO, tr(msgFileLocked, dir, path+lockSuffix))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	return lock, nil
+}
+
+// runChunk recherche les résultats de la tranche c et retourne leur contenu NDJSON, trié par p
+// puis q pour ne pas dépendre de l'ordre d'arrivée, ainsi que leur nombre.
+func runChunk(ctx context.Context, campaign chunkCampaign, c chunkEntry, primeList []int, workers int) ([]byte, int, error) {
+	form, _ := primes.LookupForm(campaign.Form)
+	pairs, _ := primes.LookupPairMode(campaign.Pairs)
+	opts := primes.Options{
+		Primes:    primeList,
+		PMin:      c.PMin,
+		PMax:      c.PMax,
+		PrimeTest: campaign.PrimeTest,
+		Workers:   workers,
+		Form:      form,
+		Pairs:     pairs,
+	}
+	return searchNDJSON(ctx, opts)
+}
+
+// searchNDJSON conduit la recherche opts et retourne le contenu NDJSON de ses résultats, triés par
+// p puis q, ainsi que leur nombre.
+func searchNDJSON(ctx context.Context, opts primes.Options) ([]byte, int, error) {
+	var results []primes.Result
+	if err := primes.Search(ctx, opts, func(res primes.Result) error {
+		results = append(results, res)
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	slices.SortFunc(results, func(a, b primes.Result) int {
+		return cmp.Or(cmp.Compare(a.P, b.P), cmp.Compare(a.Q, b.Q))
+	})
+	var buf bytes.Buffer
+	rw := &resultWriter{w: &buf, format: "ndjson"}
+	for _, res := range results {
+		rw.result(res)
+	}
+	return buf.Bytes(), len(results), nil
+}
+
+// sha256Hex retourne la somme SHA-256 de data en hexadécimal.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// runChunks implémente la sous-commande chunks.
+func runChunks(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("chunks", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dirPtr := fs.String("dir", "", tr(msgFlagChunksDir))
+	limitPtr := fs.Int("limit", 1000, tr(msgFlagLimit))
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	primeTestPtr := fs.String("primetest", "miller", tr(msgFlagPrimeTest))
+	pairsPtr := fs.String("pairs", primes.PairsAll.String(), tr(msgFlagPairs, strings.Join(primes.PairModeNames(), ", ")))
+	countPtr := fs.Int("chunks", 16, tr(msgFlagChunksCount))
+	namePtr := fs.String("chunk", "", tr(msgFlagChunkName))
+	verifyPtr := fs.Bool("verify", false, tr(msgFlagChunksVerify))
+	workersPtr := fs.Int("workers", defaultWorkers(), tr(msgFlagWorkers))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgChunksUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *dirPtr == "" {
+		fs.Usage()
+		return fmt.Errorf("%w: chunks: -dir est requis", errInvalidFlags)
+	}
+	if *workersPtr < 1 || *countPtr < 1 {
+		return fmt.Errorf("%w: chunks: -workers=%d, -chunks=%d (attendu >= 1)", errInvalidFlags, *workersPtr, *countPtr)
+	}
+
+	// --- Verrou: une seule exécution à la fois par campagne ---
+	var lock *fileLock
+	if _, err := os.Stat(*dirPtr); err == nil {
+		if lock, err = lockChunkCampaign(*dirPtr); err != nil {
+			return err
+		}
+		defer lock.Unlock()
+	}
+
+	// --- Manifeste: créé au premier lancement, ses paramètres font ensuite foi ---
+	campaign, exists, err := readChunkCampaign(*dirPtr)
+	if err != nil {
+		return err
+	}
+	if exists {
+		flagValues := map[string]string{"limit": fmt.Sprint(campaign.Limit), "form": campaign.Form, "primetest": campaign.PrimeTest, "pairs": campaign.Pairs, "chunks": fmt.Sprint(len(campaign.Chunks))}
+		var mismatch error
+		fs.Visit(func(f *flag.Flag) {
+			if v, ok := flagValues[f.Name]; ok && v != f.Value.String() && mismatch == nil {
+				mismatch = fmt.Errorf("%w: chunks: -%s=%s, la campagne de %s a été créée avec %s", errInvalidFlags, f.Name, f.Value, *dirPtr, v)
+			}
+		})
+		if mismatch != nil {
+			return mismatch
+		}
+	} else {
+		if *verifyPtr || *namePtr != "" {
+			return fmt.Errorf("%w: chunks: aucune campagne dans %s", errInvalidInput, *dirPtr)
+		}
+		campaign = chunkCampaign{Version: chunkCampaignVersion, Limit: *limitPtr, Form: *formPtr, PrimeTest: *primeTestPtr, Pairs: *pairsPtr}
+	}
+	form, ok := primes.LookupForm(campaign.Form)
+	if !ok {
+		return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, campaign.Form, primes.FormNames())
+	}
+	if _, ok := primes.LookupPairMode(campaign.Pairs); !ok {
+		return fmt.Errorf("%w: -pairs=%q (attendu l'un de %v)", errInvalidFlags, campaign.Pairs, primes.PairModeNames())
+	}
+	if !slices.Contains(primes.PrimalityTestNames(), campaign.PrimeTest) {
+		return fmt.Errorf("%w: -primetest=%q (attendu l'un de %v)", errInvalidFlags, campaign.PrimeTest, primes.PrimalityTestNames())
+	}
+	if err := primes.CheckFormLimit(form, campaign.Limit); err != nil {
+		return err
+	}
+	primeList := primes.SieveOfEratosthenes(campaign.Limit)
+	if !exists {
+		if len(primeList) == 0 {
+			return fmt.Errorf("%w: chunks: aucun nombre premier jusqu'à %d", errInvalidFlags, campaign.Limit)
+		}
+		if err := os.MkdirAll(*dirPtr, 0o755); err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		if lock == nil {
+			if lock, err = lockChunkCampaign(*dirPtr); err != nil {
+				return err
+			}
+			defer lock.Unlock()
+		}
+		campaign.Chunks = splitChunks(primeList, *countPtr)
+		if err := writeChunkCampaign(*dirPtr, campaign); err != nil {
+			return err
+		}
+	}
+	selected := campaign.Chunks
+	if *namePtr != "" {
+		i := slices.IndexFunc(campaign.Chunks, func(c chunkEntry) bool { return c.Name == *namePtr })
+		if i < 0 {
+			return fmt.Errorf("%w: chunks: tranche %q inconnue", errInvalidFlags, *namePtr)
+		}
+		selected = campaign.Chunks[i : i+1]
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	out := &errWriter{w: stdout}
+
+	// --- Vérification: recalcul des tranches terminées, comparé au manifeste et aux fichiers ---
+	if *verifyPtr {
+		mismatches := 0
+		for _, c := range selected {
+			if c.Status != chunkDone {
+				fmt.Fprint(out, tr(msgChunkSkipped, c.Name))
+				continue
+			}
+			data, n, err := runChunk(ctx, campaign, c, primeList, *workersPtr)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return errInterrupted
+				}
+				return err
+			}
+			var problems []string
+			if sum := sha256Hex(data); sum != c.SHA256 || n != c.Results {
+				problems = append(problems, tr(msgChunkRecomputed, n, sum))
+			}
+			if stored, err := os.ReadFile(c.file(*dirPtr)); err != nil {
+				problems = append(problems, err.Error())
+			} else if sha256Hex(stored) != c.SHA256 {
+				problems = append(problems, tr(msgChunkFileAltered, c.file(*dirPtr)))
+			}
+			if len(problems) > 0 {
+				mismatches++
+				fmt.Fprint(out, tr(msgChunkMismatch, c.Name, strings.Join(problems, "; ")))
+				continue
+			}
+			fmt.Fprint(out, tr(msgChunkVerified, c.Name, n))
+		}
+		if mismatches > 0 {
+			return fmt.Errorf("%w: %d tranche(s) en écart", errVerification, mismatches)
+		}
+		return writeError(out)
+	}
+
+	// --- Exécution: tranches en attente, ou la tranche demandée même si elle est terminée ---
+	for _, c := range selected {
+		if c.Status == chunkDone && *namePtr == "" {
+			continue
+		}
+		fmt.Fprint(out, tr(msgChunkRunning, c.Name, c.PMin, c.PMax))
+		data, n, err := runChunk(ctx, campaign, c, primeList, *workersPtr)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return errInterrupted // La tranche reste en attente; le manifeste reste cohérent.
+			}
+			return err
+		}
+		if err := writeFileAtomic(c.file(*dirPtr), data); err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		i := slices.IndexFunc(campaign.Chunks, func(e chunkEntry) bool { return e.Name == c.Name })
+		campaign.Chunks[i].Status = chunkDone
+		campaign.Chunks[i].Results = n
+		campaign.Chunks[i].SHA256 = sha256Hex(data)
+		campaign.Chunks[i].Completed = time.Now().UTC()
+		if err := writeChunkCampaign(*dirPtr, campaign); err != nil {
+			return err
+		}
+	}
+
+	// --- État de la campagne ---
+	done := 0
+	for _, c := range campaign.Chunks {
+		if c.Status == chunkDone {
+			done++
+			fmt.Fprint(out, tr(msgChunkDone, c.Name, c.PMin, c.PMax, c.Results, c.SHA256))
+		} else {
+			fmt.Fprint(out, tr(msgChunkPending, c.Name, c.PMin, c.PMax))
+		}
+	}
+	fmt.Fprint(out, tr(msgChunksSummary, done, len(campaign.Chunks)))
+	return writeError(out)
+}
@@ -0,0 +1,160 @@
+/*
+ * Fichier: chunks_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande chunks: découpage, reprise, recalcul et vérification.
+ */
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestSplitChunks vérifie que les tranches couvrent chaque p une seule fois, dans l'ordre.
+func TestSplitChunks(t *testing.T) {
+	primeList := primes.SieveOfEratosthenes(100) // 25 nombres premiers.
+	chunks := splitChunks(primeList, 4)
+	if len(chunks) != 4 || chunks[0].PMin != 2 || chunks[3].PMax != 97 || chunks[0].Name != "chunk-0000" {
+		t.Fatalf("tranches = %+v", chunks)
+	}
+	for i := 1; i < len(chunks); i++ {
+		if next := primeList[slices.Index(primeList, chunks[i-1].PMax)+1]; chunks[i].PMin != next {
+			t.Errorf("tranche %d commence à %d, attendu %d", i, chunks[i].PMin, next)
+		}
+	}
+	if got := splitChunks(primeList[:3], 10); len(got) != 3 {
+		t.Errorf("%d tranches pour 3 nombres premiers, attendu 3", len(got))
+	}
+}
+
+// TestRunChunks déroule une campagne: création, reprise d'une tranche remise en attente,
+// vérification, détection d'un fichier altéré puis recalcul de la tranche.
+func TestRunChunks(t *testing.T) {
+	dir := t.TempDir()
+	if err := runChunks([]string{"-dir", dir, "-limit", "100", "-chunks", "3"}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("création: %v", err)
+	}
+	campaign, ok, err := readChunkCampaign(dir)
+	if err != nil || !ok || len(campaign.Chunks) != 3 {
+		t.Fatalf("manifeste = %+v, %v, %v", campaign, ok, err)
+	}
+	total := 0
+	for _, c := range campaign.Chunks {
+		if c.Status != chunkDone {
+			t.Errorf("%s: état %q après la campagne", c.Name, c.Status)
+		}
+		total += c.Results
+	}
+	// Même nombre de résultats qu'une recherche complète.
+	expected := 0
+	if err := primes.Search(t.Context(), primes.Options{Limit: 100, Workers: 1}, func(primes.Result) error { expected++; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if total != expected {
+		t.Errorf("%d résultats par tranches, attendu %d", total, expected)
+	}
+
+	// Reprise: seule la tranche remise en attente est recalculée.
+	campaign.Chunks[1].Status = chunkPending
+	if err := writeChunkCampaign(dir, campaign); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	if err := runChunks([]string{"-dir", dir}, &out, io.Discard); err != nil {
+		t.Fatalf("reprise: %v", err)
+	}
+	if !strings.Contains(out.String(), "chunk-0001: ") || strings.Count(out.String(), "[") != 4 {
+		t.Errorf("reprise: sortie inattendue:\n%s", out.String())
+	}
+	if err := runChunks([]string{"-dir", dir, "-verify"}, io.Discard, io.Discard); err != nil {
+		t.Errorf("vérification: %v", err)
+	}
+
+	// Un fichier de résultats altéré est détecté, puis réparé par le recalcul de sa tranche.
+	f, err := os.OpenFile(campaign.Chunks[2].file(dir), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{\"p\":1,\"q\":1,\"n\":5}\n")
+	f.Close()
+	if err := runChunks([]string{"-dir", dir, "-verify"}, io.Discard, io.Discard); exitCode(err) != exitVerification {
+		t.Errorf("vérification d'un fichier altéré = %v, attendu un échec de vérification", err)
+	}
+	if err := runChunks([]string{"-dir", dir, "-chunk", "chunk-0002"}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("recalcul: %v", err)
+	}
+	if err := runChunks([]string{"-dir", dir, "-verify", "-chunk", "chunk-0002"}, io.Discard, io.Discard); err != nil {
+		t.Errorf("vérification après recalcul: %v", err)
+	}
+
+	// Les paramètres de la campagne ne changent pas en cours de route.
+	for _, args := range [][]string{{"-dir", dir, "-limit", "200"}, {"-dir", dir, "-chunk", "chunk-9999"}, {"-limit", "100"}} {
+		if err := runChunks(args, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+			t.Errorf("runChunks(%v) = %v, attendu des options invalides", args, err)
+		}
+	}
+}
+
+// TestChunkCampaignCheckpoint valide l'aller-retour du manifeste binaire et la migration du
+// manifeste JSON de la version 1.
+func TestChunkCampaignCheckpoint(t *testing.T) {
+	campaign := chunkCampaign{Version: chunkCampaignVersion, Limit: 100, Form: "p^2+4q^2", PrimeTest: "miller", Pairs: "all",
+		Chunks: []chunkEntry{
+			{Name: "chunk-0000", PMin: 2, PMax: 47, Status: chunkDone, Results: 12, SHA256: "ab12", Completed: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)},
+			{Name: "chunk-0001", PMin: 53, PMax: 97, Status: chunkPending},
+		}}
+	got, err := decodeChunkCampaign(encodeChunkCampaign(campaign))
+	if err != nil || !reflect.DeepEqual(got, campaign) {
+		t.Fatalf("aller-retour = %+v, %v", got, err)
+	}
+	data := encodeCheckpoint(chunkCampaignVersion+1, nil)
+	if _, err := decodeChunkCampaign(data); err == nil {
+		t.Error("manifeste d'une version ultérieure accepté")
+	}
+
+	// Un manifeste JSON de la version 1 est lu, migré, puis remplacé à la première écriture.
+	dir := t.TempDir()
+	legacy := `{"version":1,"limit":100,"form":"p^2+4q^2","primetest":"miller","pairs":"all","chunks":[` +
+		`{"name":"chunk-0000","p_min":2,"p_max":47,"status":"done","results":12,"sha256":"ab12","completed":"2026-10-16T09:00:00Z"},` +
+		`{"name":"chunk-0001","p_min":53,"p_max":97,"status":"pending","results":0}]}`
+	if err := os.WriteFile(filepath.Join(dir, chunkLegacyManifestName), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := readChunkCampaign(dir)
+	if err != nil || !ok || !reflect.DeepEqual(got, campaign) {
+		t.Fatalf("migration = %+v, %v, %v", got, ok, err)
+	}
+	if err := writeChunkCampaign(dir, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, chunkLegacyManifestName)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("manifeste JSON conservé après la migration: %v", err)
+	}
+
+	// Un manifeste altéré est refusé (code 8).
+	path := filepath.Join(dir, chunkManifestName)
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)/2] ^= 1
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runChunks([]string{"-dir", dir}, io.Discard, io.Discard); exitCode(err) != exitInvalidInput {
+		t.Errorf("manifeste altéré: %v, attendu le code %d", err, exitInvalidInput)
+	}
+}
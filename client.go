@@ -0,0 +1,466 @@
+/*
+ * Fichier: client.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande client: pilote un serveur serve par son API REST. submit
+ * soumet une recherche, status affiche l'état d'une recherche ou de toutes,
+ * results télécharge les résultats d'une recherche en NDJSON (toutes les pages,
+ * en suivant le curseur) et cancel annule une recherche. Les erreurs de l'API
+ * sont rendues avec le message du serveur. Le jeton d'accès du serveur (-token,
+ * ou la variable PRIMENUMBER_TOKEN) accompagne chaque requête, et une requête
+ * refusée pour débit dépassé (429) est renvoyée après l'attente indiquée.
+ * work fait de la machine un worker du serveur: il réserve des tranches,
+ * renouvelle leur bail pendant le calcul et en envoie les résultats, avec des
+ * reprises en cas d'erreur réseau (un renvoi n'est pas compté deux fois).
+ */
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// defaultServerURL est l'adresse du serveur par défaut, celle de serve sans -listen.
+const defaultServerURL = "http://localhost:8080"
+
+// clientTimeout borne la durée d'une requête du client.
+const clientTimeout = time.Minute
+
+// tokenEnv est la variable d'environnement du jeton d'accès par défaut du client: un jeton passé
+// par -token serait visible des autres utilisateurs de la machine dans la liste des processus.
+const tokenEnv = "PRIMENUMBER_TOKEN"
+
+// clientRateRetries borne les renvois d'une requête refusée pour débit dépassé (429).
+const clientRateRetries = 5
+
+// workCommitAttempts borne les envois des résultats d'une tranche par client work, en cas
+// d'erreur réseau ou du serveur.
+const workCommitAttempts = 5
+
+// serverClient interroge l'API REST d'un serveur.
+type serverClient struct {
+	base  string
+	token string // Jeton d'accès ("": aucun).
+	http  *http.Client
+}
+
+// newServerClient retourne le client du serveur d'adresse base (http://hôte:port), avec le jeton
+// d'accès token.
+func newServerClient(base, token string) *serverClient {
+	return &serverClient{base: strings.TrimSuffix(base, "/"), token: token, http: &http.Client{Timeout: clientTimeout}}
+}
+
+// do envoie la requête method sur path, avec le corps body s'il n'est pas nil (brut en NDJSON
+// pour un []byte, en JSON sinon), et décode la réponse dans out, sauf si elle est vide (204). Un
+// refus du serveur (4xx) enveloppe errInvalidFlags, et aussi errLeaseNotFound pour un bail qui
+// n'est plus actif (410); une erreur du serveur ou du réseau enveloppe errIO; le message est celui
+// du serveur.
+func (c *serverClient) do(method, path string, body, out any) error {
+	var data []byte
+	contentType := "application/json"
+	switch body := body.(type) {
+	case nil:
+	case []byte:
+		data, contentType = body, "application/x-ndjson"
+	default:
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(method, c.base+path, bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("%w: client: %v", errInvalidFlags, err)
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		if resp, err = c.http.Do(req); err != nil {
+			return fmt.Errorf("%w: client: %v", errIO, err)
+		}
+		retry, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if resp.StatusCode != http.StatusTooManyRequests || err != nil || attempt == clientRateRetries {
+			break
+		}
+		resp.Body.Close()
+		time.Sleep(time.Duration(max(retry, 1)) * time.Second)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) != nil || apiErr.Error == "" {
+			apiErr.Error = resp.Status
+		}
+		sentinel := errIO
+		if resp.StatusCode < 500 {
+			sentinel = errInvalidFlags
+		}
+		if resp.StatusCode == http.StatusGone {
+			return fmt.Errorf("%w: %w: client: %s %s: %s", sentinel, errLeaseNotFound, method, path, apiErr.Error)
+		}
+		return fmt.Errorf("%w: client: %s %s: %s", sentinel, method, path, apiErr.Error)
+	}
+	if resp.StatusCode == http.StatusNoContent || out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: client: %s %s: réponse illisible: %v", errIO, method, path, err)
+	}
+	return nil
+}
+
+// newClientFlags retourne les options de l'action name du client, avec celles communes à toutes
+// les actions (-server, -token, -lang), et le constructeur du client du serveur qu'elles désignent,
+// à appeler une fois les options analysées.
+func newClientFlags(name string, stderr io.Writer) (*flag.FlagSet, func() *serverClient) {
+	fs := flag.NewFlagSet("client "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	serverPtr := fs.String("server", defaultServerURL, tr(msgFlagClientServer))
+	tokenPtr := fs.String("token", "", tr(msgFlagClientToken))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgClientUsage))
+		fs.PrintDefaults()
+	}
+	return fs, func() *serverClient {
+		return newServerClient(*serverPtr, cmp.Or(*tokenPtr, os.Getenv(tokenEnv)))
+	}
+}
+
+// parseClientFlags analyse les options d'une action et vérifie son nombre d'arguments.
+func parseClientFlags(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() < minArgs || fs.NArg() > maxArgs {
+		fs.Usage()
+		return fmt.Errorf("%w: %s: %d argument(s) (attendu de %d à %d)", errInvalidFlags, fs.Name(), fs.NArg(), minArgs, maxArgs)
+	}
+	return nil
+}
+
+// printSearch écrit l'état d'une recherche sur une ligne, identifiant en tête.
+func printSearch(w io.Writer, rec serverSearch) {
+	fmt.Fprint(w, tr(msgClientSearch, rec.ID, rec.State, rec.ChunksDone, rec.Chunks, countInt(rec.Results), rec.Params.Limit, rec.Params.Form))
+	if rec.Error != "" {
+		fmt.Fprint(w, tr(msgClientSearchError, rec.Error))
+	}
+}
+
+// runClient implémente la sous-commande client.
+func runClient(args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "submit":
+			return runClientSubmit(args[1:], stdout, stderr)
+		case "status":
+			return runClientStatus(args[1:], stdout, stderr)
+		case "results":
+			return runClientResults(args[1:], stdout, stderr)
+		case "cancel":
+			return runClientCancel(args[1:], stdout, stderr)
+		case "work":
+			return runClientWork(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprint(stderr, tr(msgClientUsage))
+	if len(args) == 0 {
+		return fmt.Errorf("%w: client: action manquante", errInvalidFlags)
+	}
+	return fmt.Errorf("%w: client: action inconnue %q", errInvalidFlags, args[0])
+}
+
+// runClientSubmit implémente client submit: la recherche soumise est affichée.
+func runClientSubmit(args []string, stdout, stderr io.Writer) error {
+	fs, newClient := newClientFlags("submit", stderr)
+	var req submitRequest
+	params := &req.searchParams
+	fs.IntVar(&params.Limit, "limit", 1000, tr(msgFlagLimit))
+	fs.IntVar(&params.Above, "above", 0, tr(msgFlagClientAbove))
+	fs.StringVar(&params.Form, "form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	fs.StringVar(&params.PrimeTest, "primetest", "miller", tr(msgFlagPrimeTest))
+	fs.StringVar(&params.Pairs, "pairs", primes.PairsAll.String(), tr(msgFlagPairs, strings.Join(primes.PairModeNames(), ", ")))
+	fs.IntVar(&params.Chunks, "chunks", 16, tr(msgFlagClientChunks))
+	fs.IntVar(&params.Workers, "workers", 0, tr(msgFlagClientWorkers))
+	fs.StringVar(&req.OnDuplicate, "on-duplicate", "", tr(msgFlagClientOnDuplicate, strings.Join(duplicatePolicies, ", ")))
+	if err := parseClientFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	var rec serverSearch
+	if err := newClient().do(http.MethodPost, "/searches", req, &rec); err != nil {
+		return err
+	}
+	out := &errWriter{w: stdout}
+	printSearch(out, rec)
+	return writeError(out)
+}
+
+// runClientStatus implémente client status: l'état de la recherche donnée, ou de toutes.
+func runClientStatus(args []string, stdout, stderr io.Writer) error {
+	fs, newClient := newClientFlags("status", stderr)
+	jsonPtr := fs.Bool("json", false, tr(msgFlagClientJSON))
+	if err := parseClientFlags(fs, args, 0, 1); err != nil {
+		return err
+	}
+	client := newClient()
+	var list []serverSearch
+	if fs.NArg() == 1 {
+		var rec serverSearch
+		if err := client.do(http.MethodGet, "/searches/"+url.PathEscape(fs.Arg(0)), nil, &rec); err != nil {
+			return err
+		}
+		list = append(list, rec)
+	} else if err := client.do(http.MethodGet, "/searches", nil, &list); err != nil {
+		return err
+	}
+	out := &errWriter{w: stdout}
+	enc := json.NewEncoder(out)
+	for _, rec := range list {
+		if *jsonPtr {
+			enc.Encode(rec)
+		} else {
+			printSearch(out, rec)
+		}
+	}
+	return writeError(out)
+}
+
+// runClientResults implémente client results: les résultats de la recherche, en NDJSON, page
+// après page jusqu'à la dernière.
+func runClientResults(args []string, stdout, stderr io.Writer) (err error) {
+	fs, newClient := newClientFlags("results", stderr)
+	nMinPtr := fs.Int64("n-min", 0, tr(msgFlagClientNMin))
+	nMaxPtr := fs.Int64("n-max", 0, tr(msgFlagClientNMax))
+	pagePtr := fs.Int("page", defaultResultPage, tr(msgFlagClientPage, maxResultPage))
+	outputPtr := fs.String("o", "", tr(msgFlagClientOutput))
+	if err := parseClientFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+	query := url.Values{"limit": {strconv.Itoa(*pagePtr)}}
+	if *nMinPtr > 0 {
+		query.Set("n_min", strconv.FormatInt(*nMinPtr, 10))
+	}
+	if *nMaxPtr > 0 {
+		query.Set("n_max", strconv.FormatInt(*nMaxPtr, 10))
+	}
+
+	var w io.Writer = stdout
+	if *outputPtr != "" {
+		f, err := os.Create(*outputPtr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("%w: %v", errIO, cerr)
+			}
+		}()
+		w = f
+	}
+	out := &errWriter{w: w}
+	client := newClient()
+	path := "/searches/" + url.PathEscape(fs.Arg(0)) + "/results?"
+	total := 0
+	for {
+		var page resultPageResponse
+		if err := client.do(http.MethodGet, path+query.Encode(), nil, &page); err != nil {
+			return err
+		}
+		for _, res := range page.Results {
+			fmt.Fprintf(out, "%s\n", res)
+		}
+		total += len(page.Results)
+		if page.Next == "" {
+			break
+		}
+		query.Set("after", page.Next)
+	}
+	if err := writeError(out); err != nil {
+		return err
+	}
+	fmt.Fprint(stderr, tr(msgClientResults, countInt(total), fs.Arg(0)))
+	return nil
+}
+
+// runClientCancel implémente client cancel: la recherche annulée est affichée.
+func runClientCancel(args []string, stdout, stderr io.Writer) error {
+	fs, newClient := newClientFlags("cancel", stderr)
+	if err := parseClientFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+	var rec serverSearch
+	if err := newClient().do(http.MethodDelete, "/searches/"+url.PathEscape(fs.Arg(0)), nil, &rec); err != nil {
+		return err
+	}
+	out := &errWriter{w: stdout}
+	printSearch(out, rec)
+	return writeError(out)
+}
+
+// runClientWork implémente client work: la machine calcule des tranches des recherches du serveur
+// jusqu'à SIGINT ou SIGTERM (la tranche en cours est alors rendue à l'attente), ou, avec -once,
+// jusqu'à ce qu'il n'y en ait plus aucune à réserver.
+func runClientWork(args []string, stdout, stderr io.Writer) error {
+	fs, newClient := newClientFlags("work", stderr)
+	host, _ := os.Hostname()
+	namePtr := fs.String("name", cmp.Or(host, "worker"), tr(msgFlagClientWorkName))
+	workersPtr := fs.Int("workers", defaultWorkers(), tr(msgFlagClientWorkWorkers))
+	pollPtr := fs.Duration("poll", 5*time.Second, tr(msgFlagClientWorkPoll))
+	oncePtr := fs.Bool("once", false, tr(msgFlagClientWorkOnce))
+	if err := parseClientFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *namePtr == "" || *namePtr == localWorker || *workersPtr < 1 || *pollPtr <= 0 {
+		return fmt.Errorf("%w: client work: -name=%q, -workers=%d, -poll=%v (nom non vide et autre que %q, valeurs > 0)", errInvalidFlags, *namePtr, *workersPtr, *pollPtr, localWorker)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	w := &chunkWorker{client: newClient(), name: *namePtr, workers: *workersPtr, poll: *pollPtr, out: stdout}
+	for {
+		ok, err := w.work(ctx)
+		if ctx.Err() != nil {
+			return errInterrupted
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if *oncePtr {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return errInterrupted
+			case <-time.After(*pollPtr):
+			}
+		}
+	}
+}
+
+// chunkWorker est un worker distant du serveur (client work).
+type chunkWorker struct {
+	client  *serverClient
+	name    string
+	workers int           // Plafond de workers d'une tranche (le budget de la recherche s'applique aussi).
+	poll    time.Duration // Attente entre deux envois échoués des résultats.
+	out     io.Writer     // Journal des tranches calculées.
+
+	limit     int   // Limite du crible en cache.
+	primeList []int // Crible en cache, réutilisé tant que la limite ne change pas.
+}
+
+// work réserve une tranche, la calcule en renouvelant son bail, et en envoie les résultats; ok
+// vaut false s'il n'y avait aucune tranche à réserver. Une tranche dont le bail est perdu
+// (recherche annulée, bail échu et réattribué) est abandonnée sans erreur; à l'annulation de ctx,
+// elle est rendue à l'attente.
+func (w *chunkWorker) work(ctx context.Context) (ok bool, err error) {
+	var grant leaseGrant
+	if err := w.client.do(http.MethodPost, "/leases", leaseRequest{Worker: w.name}, &grant); err != nil {
+		return false, err
+	}
+	if grant.Lease == "" {
+		return false, nil
+	}
+	leasePath := "/leases/" + url.PathEscape(grant.Lease)
+	if w.primeList == nil || w.limit != grant.Params.Limit {
+		w.limit, w.primeList = grant.Params.Limit, primes.SieveOfEratosthenes(grant.Params.Limit)
+	}
+	params := grant.Params
+	params.Workers = min(params.Workers, w.workers)
+
+	chunkCtx, cancel := context.WithCancel(ctx)
+	renewDone := make(chan struct{})
+	go func() {
+		defer close(renewDone)
+		w.renew(chunkCtx, cancel, leasePath, time.Until(grant.Expires)/3)
+	}()
+	data, count, err := searchChunk(chunkCtx, params, grant.Chunk, w.primeList)
+	lost := chunkCtx.Err() != nil && ctx.Err() == nil
+	cancel()
+	<-renewDone
+	switch {
+	case ctx.Err() != nil:
+		w.client.do(http.MethodPost, leasePath+"/release", nil, nil)
+		return true, errInterrupted
+	case lost:
+		fmt.Fprint(w.out, tr(msgClientWorkLost, grant.Chunk.Name, grant.Search))
+		return true, nil
+	case err != nil:
+		w.client.do(http.MethodPost, leasePath+"/release", nil, nil)
+		return true, err
+	}
+
+	var resp commitResponse
+	for attempt := 1; ; attempt++ {
+		err = w.client.do(http.MethodPost, leasePath+"/results", data, &resp)
+		if err == nil || !errors.Is(err, errIO) || attempt == workCommitAttempts {
+			break
+		}
+		fmt.Fprint(w.out, tr(msgClientWorkRetry, grant.Chunk.Name, attempt, err))
+		select {
+		case <-ctx.Done():
+			return true, errInterrupted
+		case <-time.After(w.poll):
+		}
+	}
+	if errors.Is(err, errLeaseNotFound) {
+		fmt.Fprint(w.out, tr(msgClientWorkLost, grant.Chunk.Name, grant.Search))
+		return true, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	msg := msgClientWorkDone
+	if resp.Duplicate {
+		msg = msgClientWorkDuplicate
+	}
+	fmt.Fprint(w.out, tr(msg, grant.Chunk.Name, grant.Search, countInt(count)))
+	return true, nil
+}
+
+// renew renouvelle le bail de leasePath toutes les period jusqu'à l'annulation de ctx; un bail qui
+// n'est plus actif annule la tranche (cancel). Une erreur réseau passagère est ignorée: le
+// renouvellement suivant, avant l'échéance, la rattrape.
+func (w *chunkWorker) renew(ctx context.Context, cancel context.CancelFunc, leasePath string, period time.Duration) {
+	ticker := time.NewTicker(max(period, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.client.do(http.MethodPost, leasePath+"/renew", nil, nil); errors.Is(err, errLeaseNotFound) {
+				cancel()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Fichier: client_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande client contre un serveur de test: soumission,
+ * suivi, téléchargement des résultats sur plusieurs pages, annulation,
+ * worker distant (client work) et erreurs rendues par l'API.
+ */
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// TestRunClient soumet une recherche avec client submit, la suit avec client status jusqu'à la
+// fin, puis télécharge ses résultats sur plusieurs pages et les compare à une recherche directe.
+func TestRunClient(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	srv := newTestServer(t, 1, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.run(ctx)
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+
+	var out strings.Builder
+	if err := runClient([]string{"submit", "-server", ts.URL, "-limit", "300", "-chunks", "3"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	id := strings.Fields(out.String())[0]
+	if !strings.Contains(out.String(), "0/3 tranches") {
+		t.Errorf("soumission: %q", out.String())
+	}
+	if err := runClient([]string{"submit", "-server", ts.URL, "-limit", "300"}, io.Discard, io.Discard); !errors.Is(err, errInvalidFlags) {
+		t.Errorf("soumission identique: %v, attendu un refus", err)
+	}
+	var again strings.Builder
+	if err := runClient([]string{"submit", "-server", ts.URL, "-limit", "300", "-on-duplicate", "skip"}, &again, io.Discard); err != nil || !strings.HasPrefix(again.String(), id+" ") {
+		t.Errorf("soumission identique ignorée: %q, %v; attendu la recherche %s", again.String(), err, id)
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		out.Reset()
+		if err := runClient([]string{"status", "-server", ts.URL, "-json", id}, &out, io.Discard); err != nil {
+			t.Fatal(err)
+		}
+		var rec serverSearch
+		if err := json.Unmarshal([]byte(out.String()), &rec); err != nil {
+			t.Fatalf("status -json: %q: %v", out.String(), err)
+		}
+		if rec.finished() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("recherche non terminée: %+v", rec)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	path := filepath.Join(t.TempDir(), "res.ndjson")
+	var status strings.Builder
+	if err := runClient([]string{"results", "-server", ts.URL, "-page", "50", "-o", path, id}, io.Discard, &status); err != nil {
+		t.Fatal(err)
+	}
+	results, _, err := readResults(path)
+	if want := countResults(t, 300); err != nil || len(results) != want {
+		t.Fatalf("%d résultats téléchargés (%v), attendu %d", len(results), err, want)
+	}
+	if !strings.Contains(status.String(), "téléchargés") {
+		t.Errorf("bilan: %q", status.String())
+	}
+	nMin, nMax := results[10].N, results[20].N
+	out.Reset()
+	if err := runClient([]string{"results", "-server", ts.URL, "-n-min", strconv.FormatInt(nMin, 10), "-n-max", strconv.FormatInt(nMax, 10), id}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 11 {
+		t.Errorf("intervalle [%d, %d]: %d résultats, attendu 11", nMin, nMax, lines)
+	}
+
+	out.Reset()
+	if err := runClient([]string{"status", "-server", ts.URL}, &out, io.Discard); err != nil || !strings.HasPrefix(out.String(), id+"  done") {
+		t.Errorf("status: %q, %v", out.String(), err)
+	}
+	if err := runClient([]string{"cancel", "-server", ts.URL, id}, io.Discard, io.Discard); !errors.Is(err, errInvalidFlags) || !strings.Contains(err.Error(), "déjà terminée") {
+		t.Errorf("annulation d'une recherche terminée: %v", err)
+	}
+}
+
+// TestRunClientErrors vérifie les refus du client: action manquante ou inconnue, argument
+// manquant, paramètres refusés par le serveur, serveur injoignable.
+func TestRunClientErrors(t *testing.T) {
+	srv := newTestServer(t, 1, 1)
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	unreachable := httptest.NewServer(nil)
+	unreachable.Close()
+
+	tests := []struct {
+		args []string
+		want error
+	}{
+		{nil, errInvalidFlags},
+		{[]string{"pause"}, errInvalidFlags},
+		{[]string{"cancel", "-server", ts.URL}, errInvalidFlags},
+		{[]string{"submit", "-server", ts.URL, "-limit", "1"}, errInvalidFlags},
+		{[]string{"status", "-server", ts.URL, "inconnue"}, errInvalidFlags},
+		{[]string{"status", "-server", unreachable.URL}, errIO},
+	}
+	for _, tt := range tests {
+		if err := runClient(tt.args, io.Discard, io.Discard); !errors.Is(err, tt.want) {
+			t.Errorf("client %v: %v, attendu %v", tt.args, err, tt.want)
+		}
+	}
+}
+
+// TestRunClientWork fait calculer toutes les tranches d'une recherche par client work -once, sur un
+// serveur qui ne fait que coordonner, et compare les résultats à une recherche directe.
+func TestRunClientWork(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	srv := newTestServer(t, 1, 2)
+	srv.local = false
+	rec, _, err := srv.submit("", searchParams{Limit: 300, Chunks: 3}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+
+	var out strings.Builder
+	if err := runClient([]string{"work", "-server", ts.URL, "-name", "w1", "-workers", "1", "-once"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "résultats envoyés"); n != 3 {
+		t.Errorf("%d tranches calculées, attendu 3: %q", n, out.String())
+	}
+	got, err := srv.store.search(rec.ID)
+	if want := countResults(t, 300); err != nil || got.State != searchDone || got.Results != want {
+		t.Errorf("recherche = %+v, %v; attendu achevée avec %d résultats", got, err, want)
+	}
+	if err := runClient([]string{"work", "-server", ts.URL, "-name", localWorker}, io.Discard, io.Discard); !errors.Is(err, errInvalidFlags) {
+		t.Errorf("nom de worker réservé: %v", err)
+	}
+}
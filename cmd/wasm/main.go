@@ -0,0 +1,108 @@
+//go:build js && wasm
+
+/*
+ * Fichier: cmd/wasm/main.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Cible WebAssembly (GOOS=js GOARCH=wasm) exposant le moteur de recherche au
+ * navigateur via une fonction globale JavaScript:
+ *
+ *   startSearch(limit, opts, onResult) -> Promise<{count, primeCount, durationMs}>
+ *
+ * opts accepte les champs optionnels primeTest ('miller' ou 'trial'), workers et
+ * onProgress(tested, total). onResult reçoit {p, q, n}; n est transmis sous forme
+ * de chaîne car il peut dépasser la précision des nombres JavaScript (2^53).
+ *
+ * Compilation:
+ *   GOOS=js GOARCH=wasm go build -o cmd/wasm/primes.wasm ./cmd/wasm
+ *   cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" cmd/wasm/
+ */
+package main
+
+import (
+	"context"
+	"strconv"
+	"syscall/js"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// defaultWorkers est le nombre de workers par défaut: le runtime WebAssembly est mono-thread,
+// les goroutines servent ici surtout à rendre la main à la boucle d'événements du navigateur.
+const defaultWorkers = 2
+
+func main() {
+	js.Global().Set("startSearch", js.FuncOf(startSearch))
+	// Bloque indéfiniment pour garder les callbacks Go disponibles côté JavaScript.
+	select {}
+}
+
+// startSearch est l'implémentation Go de la fonction JavaScript startSearch.
+// La recherche s'exécute dans une goroutine; la Promise retournée est résolue à la fin.
+func startSearch(this js.Value, args []js.Value) any {
+	if len(args) < 3 || args[0].Type() != js.TypeNumber || args[2].Type() != js.TypeFunction {
+		return rejected("usage: startSearch(limit, opts, onResult)")
+	}
+	limit := args[0].Int()
+	opts := args[1]
+	onResult := args[2]
+
+	primeTest := "miller"
+	workers := defaultWorkers
+	var onProgress js.Value
+	if opts.Type() == js.TypeObject {
+		if v := opts.Get("primeTest"); v.Type() == js.TypeString {
+			primeTest = v.String()
+		}
+		if v := opts.Get("workers"); v.Type() == js.TypeNumber && v.Int() > 0 {
+			workers = v.Int()
+		}
+		if v := opts.Get("onProgress"); v.Type() == js.TypeFunction {
+			onProgress = v
+		}
+	}
+
+	var handler js.Func
+	handler = js.FuncOf(func(this js.Value, pargs []js.Value) any {
+		resolve := pargs[0]
+		go func() {
+			defer handler.Release()
+			start := time.Now()
+
+			primeList := primes.SieveOfEratosthenes(limit)
+			var progress primes.ProgressFunc
+			if !onProgress.IsUndefined() {
+				progress = func(pr primes.Progress) {
+					onProgress.Invoke(float64(pr.Tested), float64(pr.Total))
+				}
+			}
+			count := 0
+			opts := primes.Options{Primes: primeList, PrimeTest: primeTest, Workers: workers, OnProgress: progress}
+			primes.Search(context.Background(), opts, func(res primes.Result) error {
+				count++
+				onResult.Invoke(map[string]any{
+					"p": res.P,
+					"q": res.Q,
+					"n": strconv.FormatInt(res.N, 10),
+				})
+				return nil
+			})
+
+			resolve.Invoke(map[string]any{
+				"count":      count,
+				"primeCount": len(primeList),
+				"durationMs": float64(time.Since(start).Microseconds()) / 1000,
+			})
+		}()
+		return nil
+	})
+	return js.Global().Get("Promise").New(handler)
+}
+
+// rejected retourne une Promise JavaScript rejetée avec le message donné.
+func rejected(msg string) js.Value {
+	return js.Global().Get("Promise").Call("reject", js.Global().Get("Error").New(msg))
+}
@@ -0,0 +1,46 @@
+/*
+ * Fichier: compare.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Bilan de -compare dans le résumé: nombre de candidats et temps de chaque
+ * test de primalité comparé, puis accord des verdicts ou premiers désaccords.
+ */
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// formatComparison met en forme le bilan de la comparaison c.
+func formatComparison(c *primes.Comparison) string {
+	var b strings.Builder
+	b.WriteString(tr(msgCompareTitle))
+	for _, s := range c.Stats() {
+		var perCall float64
+		if s.Calls > 0 {
+			perCall = float64(s.Time.Nanoseconds()) / float64(s.Calls)
+		}
+		b.WriteString(tr(msgCompareLine, s.Name, s.Calls, s.Time.Round(time.Microsecond), perCall))
+	}
+	count, examples := c.Disagreements()
+	if count == 0 {
+		b.WriteString(tr(msgCompareAgree))
+		return b.String()
+	}
+	b.WriteString(tr(msgCompareDisagree, count, len(examples)))
+	names := c.Names()
+	for _, d := range examples {
+		verdicts := make([]string, len(d.Verdicts))
+		for i, v := range d.Verdicts {
+			verdicts[i] = fmt.Sprintf("%s=%t", names[i], v)
+		}
+		b.WriteString(fmt.Sprintf("    n=%d: %s\n", d.N, strings.Join(verdicts, " ")))
+	}
+	return b.String()
+}
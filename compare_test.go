@@ -0,0 +1,52 @@
+/*
+ * Fichier: compare_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du bilan de -compare.
+ */
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestFormatComparison vérifie les lignes par test et l'affichage des désaccords.
+func TestFormatComparison(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	c, err := primes.NewComparison([]string{"trial", "lucas"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.IsPrime(5471)
+	if got := formatComparison(c); !strings.Contains(got, "  trial    1 candidats") || !strings.Contains(got, "Verdicts identiques") {
+		t.Errorf("bilan sans désaccord inattendu:\n%s", got)
+	}
+	c.IsPrime(5459) // Pseudo-premier de Lucas fort.
+	if got := formatComparison(c); !strings.Contains(got, "DÉSACCORD sur 1 candidats") || !strings.Contains(got, "    n=5459: trial=false lucas=true\n") {
+		t.Errorf("désaccord absent du bilan:\n%s", got)
+	}
+}
+
+// TestRunCompare valide -compare de bout en bout et ses incompatibilités.
+func TestRunCompare(t *testing.T) {
+	var out strings.Builder
+	if err := run([]string{"-lang", "fr", "-limit", "30", "-compare", "trial, miller,bpsw"}, &out, io.Discard); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	if !strings.Contains(out.String(), "  bpsw     ") || !strings.Contains(out.String(), "Verdicts identiques") {
+		t.Errorf("bilan de la comparaison absent:\n%s", out.String())
+	}
+	for _, args := range [][]string{{"-compare", "miller"}, {"-compare", "miller,inconnu"}, {"-compare", "trial,miller", "-primetest", "bpsw"}} {
+		if err := run(args, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+			t.Errorf("run(%v) = %v, attendu des options invalides", args, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Fichier: config.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Validation croisée de la configuration de la recherche, avant tout travail:
+ * les combinaisons d'options valides une à une mais sans objet ensemble
+ * (limite sans nombre premier, région de paires vide, borne de n dans -where
+ * hors de la plage des valeurs possibles de la forme) sont refusées avec un
+ * diagnostic qui indique la correction, au lieu de produire une sortie vide.
+ * Tous les problèmes détectés sont signalés ensemble.
+ */
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// configSmallPrimes est le nombre de plus petits nombres premiers de la liste dont les paires
+// servent à calculer le plus petit n possible: les formes prédéfinies croissent en p et en q.
+const configSmallPrimes = 32
+
+// searchConfig rassemble les options de la recherche soumises à la validation croisée.
+type searchConfig struct {
+	limit  int
+	primes []int // Nombres premiers importés (-primes-file), nil pour le crible jusqu'à limit.
+	form   primes.Form
+	pairs  primes.PairMode
+	where  string
+}
+
+// nBounds retourne les bornes de n imposées par -where, pour les conditions de la forme
+// "n < X", "n <= X", "n == X", "n > X", "n >= X" (ou symétriques) reliées par && au premier niveau;
+// une expression avec ||, ! ou des parenthèses n'impose aucune borne. lo = 0 et hi = MaxInt64
+// quand aucune condition ne borne n.
+func nBounds(where string) (lo, hi int64) {
+	lo, hi = 0, math.MaxInt64
+	tokens, err := tokenizeWhere(where)
+	if err != nil {
+		return lo, hi
+	}
+	var conj [][]whereToken
+	start := 0
+	for i, tok := range tokens {
+		switch {
+		case tok.kind == whereOp && (tok.text == "||" || tok.text == "!" || tok.text == "(" || tok.text == ")"):
+			return 0, math.MaxInt64
+		case tok.kind == whereOp && tok.text == "&&", tok.kind == whereEOF:
+			conj = append(conj, tokens[start:i])
+			start = i + 1
+		}
+	}
+	mirror := map[string]string{"<": ">", "<=": ">=", ">": "<", ">=": "<=", "==": "=="}
+	for _, c := range conj {
+		if len(c) != 3 || c[1].kind != whereOp {
+			continue
+		}
+		op, value := c[1].text, c[2].value
+		switch {
+		case c[0].kind == whereIdent && c[0].text == "n" && c[2].kind == whereNumber:
+		case c[2].kind == whereIdent && c[2].text == "n" && c[0].kind == whereNumber:
+			op, value = mirror[op], c[0].value
+		default:
+			continue
+		}
+		switch op {
+		case "<":
+			hi = min(hi, value-1)
+		case "<=":
+			hi = min(hi, value)
+		case ">":
+			lo = max(lo, value+1)
+		case ">=":
+			lo = max(lo, value)
+		case "==":
+			lo, hi = max(lo, value), min(hi, value)
+		}
+	}
+	return lo, hi
+}
+
+// smallestPair retourne la paire de plus petit n de la région pairs parmi les plus petits
+// nombres premiers de list (ok = false si la région est vide).
+func smallestPair(list []int, form primes.Form, pairs primes.PairMode) (p, q int, n int64, ok bool) {
+	src := primes.NewGridSource(list[:min(len(list), configSmallPrimes)], pairs, 0, 0)
+	for job, more := src.Next(); more; job, more = src.Next() {
+		if v := form.Eval(int64(job.P), int64(job.Q)); !ok || v < n {
+			p, q, n, ok = job.P, job.Q, v, true
+		}
+	}
+	return p, q, n, ok
+}
+
+// diagnostics retourne les incohérences de la configuration, chacune avec sa correction, dans la
+// langue active.
+func (c searchConfig) diagnostics() []string {
+	list := c.primes
+	if list == nil {
+		if c.limit < 2 {
+			return []string{tr(msgConfigLimitEmpty, c.limit, c.limit)}
+		}
+		list = primes.SieveOfEratosthenes(min(c.limit, 1000))
+	}
+	if len(list) == 0 {
+		return []string{tr(msgConfigPrimesEmpty)}
+	}
+	p, q, minN, ok := smallestPair(list, c.form, c.pairs)
+	if !ok {
+		return []string{tr(msgConfigPairsEmpty, c.pairs, len(list), list[len(list)-1], primes.PairsAll)}
+	}
+	var diags []string
+	lo, hi := nBounds(c.where)
+	switch {
+	case lo > hi:
+		diags = append(diags, tr(msgConfigWhereContradict, c.where, lo, hi))
+	case hi < minN:
+		diags = append(diags, tr(msgConfigWhereTooSmall, c.where, hi, c.form.Name(), minN, p, q))
+	}
+	// Les formes croissent en p et q: n ne dépasse pas la valeur du plus grand nombre premier au carré
+	// de la grille (au-delà d'int64, -on-overflow décide: aucune borne).
+	maxPrime := int64(list[len(list)-1])
+	if bf, ok := c.form.(primes.BigForm); ok {
+		if maxN := bf.EvalBig(maxPrime, maxPrime); maxN.IsInt64() && lo > maxN.Int64() {
+			diags = append(diags, tr(msgConfigWhereTooLarge, c.where, lo, maxN.Int64(), maxPrime))
+		}
+	}
+	return diags
+}
+
+// checkSearchConfig retourne une erreur enveloppant errInvalidFlags qui énumère les incohérences
+// de c, ou nil.
+func checkSearchConfig(c searchConfig) error {
+	diags := c.diagnostics()
+	switch len(diags) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%w: %s", errInvalidFlags, diags[0])
+	}
+	return fmt.Errorf("%w: %s", errInvalidFlags, tr(msgConfigProblems, len(diags), strings.Join(diags, "\n  - ")))
+}
@@ -0,0 +1,108 @@
+/*
+ * Fichier: config_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la validation croisée de la configuration de la recherche.
+ */
+package main
+
+import (
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestNBounds vérifie l'extraction des bornes de n des conditions de -where.
+func TestNBounds(t *testing.T) {
+	for _, tc := range []struct {
+		where  string
+		lo, hi int64
+	}{
+		{"", 0, math.MaxInt64},
+		{"n < 100", 0, 99},
+		{"1e3 <= n && p % 4 == 1", 1000, math.MaxInt64},
+		{"n > 10 && n <= 50", 11, 50},
+		{"n == 41", 41, 41},
+		{"n < 100 || twin", 0, math.MaxInt64},
+		{"(n < 100)", 0, math.MaxInt64},
+		{"n + 1 < 100", 0, math.MaxInt64},
+	} {
+		if lo, hi := nBounds(tc.where); lo != tc.lo || hi != tc.hi {
+			t.Errorf("nBounds(%q) = [%d, %d], attendu [%d, %d]", tc.where, lo, hi, tc.lo, tc.hi)
+		}
+	}
+}
+
+// TestCheckSearchConfig vérifie les diagnostics de chaque incohérence, et l'absence de diagnostic
+// pour une configuration cohérente.
+func TestCheckSearchConfig(t *testing.T) {
+	base := searchConfig{limit: 30, form: primes.FormP2Plus4Q2, pairs: primes.PairsAll}
+	if err := checkSearchConfig(base); err != nil {
+		t.Errorf("configuration cohérente refusée: %v", err)
+	}
+	withWhere := base
+	withWhere.where = "n >= 41 && n < 5000"
+	if err := checkSearchConfig(withWhere); err != nil {
+		t.Errorf("-where possible refusé: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*searchConfig)
+		want   []string
+	}{
+		{"limite", func(c *searchConfig) { c.limit = 1 }, []string{"-limit=1", "choisir -limit >= 2"}},
+		{"liste vide", func(c *searchConfig) { c.primes = []int{} }, []string{"importée est vide"}},
+		{"région vide", func(c *searchConfig) { c.limit, c.pairs = 2, primes.PairsLess }, []string{"-pairs lt", "aucune paire"}},
+		{"n trop petit", func(c *searchConfig) { c.where = "n < 20" }, []string{"le plus petit n de la forme p^2+4q^2 est 20 (p=2, q=2)"}},
+		{"n trop grand", func(c *searchConfig) { c.where = "n > 5000" }, []string{"n ne dépasse pas 4205 jusqu'à 29", "relever -limit"}},
+		{"bornes contradictoires", func(c *searchConfig) { c.where = "n > 100 && n < 50" }, []string{"se contredisent"}},
+		{"plusieurs", func(c *searchConfig) { c.where = "n > 1e9 && n < 3" }, []string{"2 incohérences", "se contredisent", "n >= 1000000001 est impossible"}},
+	} {
+		c := base
+		tc.modify(&c)
+		err := checkSearchConfig(c)
+		if exitCode(err) != exitInvalidFlags {
+			t.Errorf("%s: erreur %v, attendu le code %d", tc.name, err, exitInvalidFlags)
+			continue
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: diagnostic sans %q: %v", tc.name, want, err)
+			}
+		}
+	}
+}
+
+// TestCheckSearchConfigEnglish vérifie que les diagnostics suivent la langue choisie.
+func TestCheckSearchConfigEnglish(t *testing.T) {
+	setLanguage(language.English)
+	defer setLanguage(defaultLanguage)
+	c := searchConfig{limit: 30, form: primes.FormP2Plus4Q2, pairs: primes.PairsAll, where: "n > 1e9 && n < 3"}
+	err := checkSearchConfig(c)
+	for _, want := range []string{"invalid options", "2 inconsistencies", "contradict each other", "n >= 1000000001 is impossible"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("diagnostic sans %q: %v", want, err)
+		}
+	}
+}
+
+// TestRunConfigValidation vérifie que la recherche refuse une configuration sans objet avant
+// tout travail.
+func TestRunConfigValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"-limit", "1"},
+		{"-limit", "2", "-pairs", "lt"},
+		{"-limit", "30", "-where", "n < 10"},
+	} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+/*
+ * Fichier: configfile.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Fichier d'options (-config) et rechargement à chaud. Le fichier contient
+ * des lignes "option = valeur" (noms des options de la ligne de commande,
+ * sans tiret; lignes vides et commentaires # ignorés) lues au démarrage
+ * comme une ligne de commande complémentaire: une option explicite l'emporte.
+ * À la réception de SIGHUP, le fichier est relu et le niveau du journal
+ * (-log-level), l'intervalle de la ligne de statistiques (-stats-interval) et
+ * le bridage CPU (-cpu-percent) changent sans redémarrer, donc sans perdre le
+ * crible, le cache des nombres premiers ni l'avancement de la recherche. Les
+ * autres options modifiées depuis le démarrage sont signalées et attendent le
+ * prochain démarrage; un fichier invalide laisse les réglages en place.
+ */
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// Niveaux du journal (-log-level), du plus discret au plus bavard: un message est écrit si son
+// niveau ne dépasse pas celui de l'exécution.
+const (
+	logLevelError int32 = iota // Aucun message d'état: seuls les résultats et l'erreur finale.
+	logLevelWarn               // Avertissements seulement (sortie en échec, désaccord, bridage impossible...).
+	logLevelInfo               // Tous les messages d'état (par défaut).
+)
+
+// logLevelNames sont les niveaux acceptés par -log-level, dans l'ordre des constantes.
+var logLevelNames = []string{"error", "warn", "info"}
+
+// parseLogLevel retourne le niveau du journal nommé name.
+func parseLogLevel(name string) (int32, error) {
+	if i := slices.Index(logLevelNames, name); i >= 0 {
+		return int32(i), nil
+	}
+	return 0, errors.New(tr(msgConfigLogLevel, name, logLevelNames))
+}
+
+// liveOptions sont les options appliquées à chaud au rechargement du fichier d'options.
+var liveOptions = []string{"log-level", "stats-interval", "cpu-percent"}
+
+// configValues sont les valeurs d'un fichier d'options par option, dans l'ordre du fichier
+// (plusieurs pour une option répétée, comme -sink).
+type configValues map[string][]string
+
+// readConfigFile lit le fichier d'options path. Une ligne mal formée ou une option inconnue de fs
+// retourne une erreur enveloppant errInvalidFlags, qui indique la ligne.
+func readConfigFile(path string, fs *flag.FlagSet) (configValues, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	defer f.Close()
+	values := configValues{}
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(text, "=")
+		name, value = strings.TrimLeft(strings.TrimSpace(name), "-"), strings.TrimSpace(value)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %s", errInvalidFlags, tr(msgConfigFileSyntax, path, line, text))
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		}
+		switch {
+		case name == "config" || name == "lang":
+			return nil, fmt.Errorf("%w: %s", errInvalidFlags, tr(msgConfigFileCommandLine, path, line, name))
+		case fs.Lookup(name) == nil:
+			return nil, fmt.Errorf("%w: %s", errInvalidFlags, tr(msgConfigFileUnknown, path, line, name))
+		}
+		values[name] = append(values[name], value)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	return values, nil
+}
+
+// applyConfigFile donne aux options de fs absentes de la ligne de commande les valeurs du fichier
+// d'options path, comme si elles y figuraient (elles sont marquées comme fixées, et un préréglage
+// ne les remplace pas). Elle retourne les valeurs lues, référence des rechargements.
+func applyConfigFile(fs *flag.FlagSet, path string) (configValues, error) {
+	values, err := readConfigFile(path, fs)
+	if err != nil {
+		return nil, err
+	}
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	var names []string
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if explicit[name] {
+			continue
+		}
+		for _, v := range values[name] {
+			if err := fs.Set(name, v); err != nil {
+				return nil, fmt.Errorf("%w: %s: -%s=%s: %v", errInvalidFlags, path, name, v, err)
+			}
+		}
+	}
+	return values, nil
+}
+
+// liveSettings sont les réglages modifiables pendant l'exécution, lus par les goroutines qui en
+// dépendent (le bridage CPU est porté par primes.Control).
+type liveSettings struct {
+	level         atomic.Int32
+	statsInterval atomic.Int64  // time.Duration; 0: pas de ligne de statistiques.
+	changed       chan struct{} // Réveille la ligne de statistiques après un changement d'intervalle.
+}
+
+// newLiveSettings retourne les réglages du démarrage.
+func newLiveSettings(level int32, statsInterval time.Duration) *liveSettings {
+	s := &liveSettings{changed: make(chan struct{}, 1)}
+	s.level.Store(level)
+	s.statsInterval.Store(int64(statsInterval))
+	return s
+}
+
+// logs indique si un message de niveau level est écrit.
+func (s *liveSettings) logs(level int32) bool { return level <= s.level.Load() }
+
+// interval retourne l'intervalle de la ligne de statistiques.
+func (s *liveSettings) interval() time.Duration { return time.Duration(s.statsInterval.Load()) }
+
+// setInterval change l'intervalle de la ligne de statistiques et en avertit son générateur.
+func (s *liveSettings) setInterval(d time.Duration) {
+	s.statsInterval.Store(int64(d))
+	select {
+	case s.changed <- struct{}{}:
+	default: // Un réveil est déjà en attente.
+	}
+}
+
+// configReloader relit le fichier d'options et applique ses options modifiables à chaud.
+type configReloader struct {
+	path    string
+	fs      *flag.FlagSet
+	initial configValues      // Valeurs du fichier au démarrage.
+	startup map[string]string // Valeurs effectives des options modifiables au démarrage.
+	live    *liveSettings
+	ctl     *primes.Control
+}
+
+// newConfigReloader prépare le rechargement de path; startup donne les valeurs effectives au
+// démarrage des options modifiables à chaud, reprises quand elles disparaissent du fichier.
+func newConfigReloader(path string, fs *flag.FlagSet, initial configValues, startup map[string]string, live *liveSettings, ctl *primes.Control) *configReloader {
+	return &configReloader{path: path, fs: fs, initial: initial, startup: startup, live: live, ctl: ctl}
+}
+
+// reload relit le fichier. Les options modifiables à chaud prennent la valeur du fichier, même si
+// la ligne de commande les fixait, ou reviennent à leur valeur du démarrage s'il ne les donne plus;
+// applied liste celles qui ont changé (-option=valeur). restart liste les autres options modifiées
+// depuis le démarrage, sans effet avant le prochain. En cas d'erreur, aucun réglage ne change.
+func (r *configReloader) reload() (applied, restart []string, err error) {
+	values, err := readConfigFile(r.path, r.fs)
+	if err != nil {
+		return nil, nil, err
+	}
+	value := func(name string) string {
+		if v := values[name]; len(v) > 0 {
+			return v[len(v)-1]
+		}
+		return r.startup[name]
+	}
+	level, err := parseLogLevel(value("log-level"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s: %v", errInvalidFlags, r.path, err)
+	}
+	interval, err := time.ParseDuration(value("stats-interval"))
+	if err != nil || interval < 0 {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidFlags, tr(msgConfigStatsInterval, r.path, value("stats-interval")))
+	}
+	percent, err := strconv.Atoi(value("cpu-percent"))
+	if err != nil || percent < 1 || percent > 100 {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidFlags, tr(msgConfigCPUPercent, r.path, value("cpu-percent")))
+	}
+
+	if level != r.live.level.Load() {
+		r.live.level.Store(level)
+		applied = append(applied, "-log-level="+logLevelNames[level])
+	}
+	if interval != r.live.interval() {
+		r.live.setInterval(interval)
+		applied = append(applied, "-stats-interval="+interval.String())
+	}
+	if percent != r.ctl.CPUPercent() {
+		r.ctl.SetCPUPercent(percent)
+		applied = append(applied, "-cpu-percent="+strconv.Itoa(percent))
+	}
+	for name, v := range values {
+		if !slices.Contains(liveOptions, name) && !slices.Equal(v, r.initial[name]) {
+			restart = append(restart, "-"+name)
+		}
+	}
+	for name := range r.initial {
+		if _, ok := values[name]; !ok && !slices.Contains(liveOptions, name) {
+			restart = append(restart, "-"+name)
+		}
+	}
+	slices.Sort(restart)
+	return applied, restart, nil
+}
@@ -0,0 +1,183 @@
+/*
+ * Fichier: configfile_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du fichier d'options (-config), du niveau du journal (-log-level) et
+ * du rechargement à chaud des options.
+ */
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// writeConfig écrit le fichier d'options path.
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newConfigFlags retourne un jeu d'options réduit, analysé sur args.
+func newConfigFlags(t *testing.T, args ...string) *flag.FlagSet {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("limit", 1000, "")
+	fs.String("format", "table", "")
+	fs.String("log-level", "info", "")
+	fs.Duration("stats-interval", 0, "")
+	fs.Int("cpu-percent", 100, "")
+	fs.Var(&sinkSpecList{}, "sink", "")
+	fs.String("config", "", "")
+	fs.String("lang", "", "")
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+// TestApplyConfigFile vérifie la lecture du fichier (commentaires, guillemets, option répétée),
+// la priorité de la ligne de commande et les erreurs signalées avec leur ligne.
+func TestApplyConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.conf")
+	writeConfig(t, path, "# recherche de nuit\n\nlimit = 5000\n-format = \"ndjson\"\nsink = csv:a.csv\nsink = pbz:b.pbz\n")
+	fs := newConfigFlags(t, "-limit", "200")
+	values, err := applyConfigFile(fs, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fs.Lookup("limit").Value.String(); got != "200" {
+		t.Errorf("-limit = %s, attendu 200 (ligne de commande)", got)
+	}
+	if got := fs.Lookup("format").Value.String(); got != "ndjson" || !flagSet(fs, "format") {
+		t.Errorf("-format = %s (fixé: %v), attendu ndjson fixé par le fichier", got, flagSet(fs, "format"))
+	}
+	if got := values["sink"]; !slices.Equal(got, []string{"csv:a.csv", "pbz:b.pbz"}) {
+		t.Errorf("-sink = %v", got)
+	}
+
+	for _, tc := range []struct{ content, want string }{
+		{"limit 5000\n", ":1:"},
+		{"# x\nunknown = 1\n", ":2: option inconnue -unknown"},
+		{"lang = fr\n", "ligne de commande"},
+		{"config = autre.conf\n", "ligne de commande"},
+		{"limit = beaucoup\n", "-limit=beaucoup"},
+	} {
+		writeConfig(t, path, tc.content)
+		_, err := applyConfigFile(newConfigFlags(t), path)
+		if !errors.Is(err, errInvalidFlags) || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%q: %v, attendu errInvalidFlags avec %q", tc.content, err, tc.want)
+		}
+	}
+	setLanguage(language.English)
+	writeConfig(t, path, "# x\nunknown = 1\n")
+	_, err = applyConfigFile(newConfigFlags(t), path)
+	setLanguage(defaultLanguage)
+	if err == nil || !strings.Contains(err.Error(), ":2: unknown option -unknown") {
+		t.Errorf("diagnostic en anglais: %v", err)
+	}
+	if _, err := applyConfigFile(newConfigFlags(t), filepath.Join(t.TempDir(), "absent.conf")); !errors.Is(err, errIO) {
+		t.Errorf("fichier absent: %v, attendu errIO", err)
+	}
+}
+
+// TestConfigReload vérifie l'application à chaud des options modifiables, le retour aux valeurs
+// du démarrage, le signalement des autres options et le maintien des réglages sur erreur.
+func TestConfigReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.conf")
+	writeConfig(t, path, "limit = 5000\nstats-interval = 0s\n")
+	fs := newConfigFlags(t, "-cpu-percent", "50")
+	initial, err := applyConfigFile(fs, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := newLiveSettings(logLevelInfo, 0)
+	ctl := primes.NewControl()
+	ctl.SetCPUPercent(50)
+	r := newConfigReloader(path, fs, initial, map[string]string{"log-level": "info", "stats-interval": "0s", "cpu-percent": "50"}, live, ctl)
+
+	if applied, restart, err := r.reload(); err != nil || len(applied) != 0 || len(restart) != 0 {
+		t.Errorf("fichier inchangé: %v %v %v", applied, restart, err)
+	}
+	// La valeur du fichier l'emporte sur la ligne de commande (-cpu-percent 50).
+	writeConfig(t, path, "limit = 9000\nformat = csv\nstats-interval = 30s\ncpu-percent = 10\nlog-level = warn\n")
+	applied, restart, err := r.reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"-log-level=warn", "-stats-interval=30s", "-cpu-percent=10"}; !slices.Equal(applied, want) {
+		t.Errorf("appliquées %v, attendu %v", applied, want)
+	}
+	if want := []string{"-format", "-limit"}; !slices.Equal(restart, want) {
+		t.Errorf("au prochain démarrage %v, attendu %v", restart, want)
+	}
+	if live.logs(logLevelInfo) || !live.logs(logLevelWarn) || live.interval() != 30*time.Second || ctl.CPUPercent() != 10 {
+		t.Errorf("réglages après rechargement: niveau %d, intervalle %v, CPU %d%%", live.level.Load(), live.interval(), ctl.CPUPercent())
+	}
+	select {
+	case <-live.changed:
+	default:
+		t.Error("changement d'intervalle non signalé à la ligne de statistiques")
+	}
+
+	// Valeur invalide: erreur, réglages inchangés.
+	for _, content := range []string{"cpu-percent = 0\n", "log-level = bavard\n", "stats-interval = -1s\n", "nouvelle = 1\n"} {
+		writeConfig(t, path, content)
+		if _, _, err := r.reload(); !errors.Is(err, errInvalidFlags) {
+			t.Errorf("%q: %v, attendu errInvalidFlags", content, err)
+		}
+	}
+	if ctl.CPUPercent() != 10 || live.interval() != 30*time.Second {
+		t.Errorf("réglages modifiés par un fichier invalide")
+	}
+
+	// Options retirées: retour aux valeurs du démarrage; -limit retiré attend le prochain démarrage.
+	writeConfig(t, path, "stats-interval = 0s\n")
+	applied, restart, err = r.reload()
+	if err != nil || !slices.Equal(applied, []string{"-log-level=info", "-stats-interval=0s", "-cpu-percent=50"}) || !slices.Equal(restart, []string{"-limit"}) {
+		t.Errorf("retour au démarrage: %v %v %v", applied, restart, err)
+	}
+}
+
+// TestRunConfigAndLogLevel vérifie de bout en bout le fichier d'options, sa priorité sur un
+// préréglage et le niveau du journal.
+func TestRunConfigAndLogLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.conf")
+	writeConfig(t, path, "limit = 100\nformat = ndjson\nlog-level = error\n")
+	var out, errOut bytes.Buffer
+	if err := run([]string{"-config", path, "-preset", "quick"}, &out, &errOut); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(resultLines(out.String()), `{"p":`) {
+		t.Errorf("sortie %.200q, attendu du NDJSON (fichier d'options prioritaire sur -preset quick)", out.String())
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("-log-level=error: messages d'état écrits:\n%s", errOut.String())
+	}
+
+	errOut.Reset()
+	if err := run([]string{"-config", path, "-log-level", "info"}, io.Discard, &errOut); err != nil || errOut.Len() == 0 {
+		t.Errorf("-log-level=info sur la ligne de commande: %v, %d octets de messages", err, errOut.Len())
+	}
+	if got := exitCode(run([]string{"-log-level", "bavard"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("-log-level=bavard -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+	if got := exitCode(run([]string{"-config", filepath.Join(t.TempDir(), "absent.conf")}, io.Discard, io.Discard)); got != exitIO {
+		t.Errorf("fichier d'options absent -> code %d, attendu %d", got, exitIO)
+	}
+}
@@ -0,0 +1,355 @@
+/*
+ * Fichier: convert.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande convert: réécrit un fichier de résultats de tout format lisible
+ * (json, ndjson, csv, pbz ou parquet) dans tout format de -format, par exemple
+ * pour relire en JSON ou en CSV un flux binaire compressé (-format pbz)
+ * archivé par une exécution à grande échelle, ou pour charger en Parquet des
+ * résultats textuels existants. Les formats sont déduits des extensions ou
+ * donnés par -from et -to; la sortie est une destination de -sink (fichier ou
+ * tcp://). Les résultats sont lus et réécrits au fil de l'eau, sans être
+ * gardés en mémoire (sauf en entrée JSON, document lu d'un bloc, et en sortie
+ * Parquet, par groupe de lignes). Le manifeste de l'entrée, s'il y en a un,
+ * est reporté dans la sortie.
+ */
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// convertFormats sont les formats lus par convert; les formats écrits sont ceux de -format
+// (resultFormats), tableau compris.
+var convertFormats = []string{"json", "ndjson", "csv", "pbz", "parquet"}
+
+// formatFromPath déduit le format d'un fichier de résultats de son extension ("" si inconnue).
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".csv":
+		return "csv"
+	case ".pbz", ".zst":
+		return "pbz"
+	case ".parquet":
+		return "parquet"
+	case ".txt":
+		return "table"
+	}
+	return ""
+}
+
+// readResultStream lit les résultats de r au format format et appelle fn pour chacun, dans
+// l'ordre; une erreur de fn arrête la lecture et est retournée telle quelle. name désigne
+// l'entrée dans les erreurs. Le manifeste de l'entrée, s'il y en a un, est transmis à onManifest
+// (si non nil) avant le premier résultat; l'heure de fin des formats qui la portent après les
+// résultats (csv, pbz, ndjson) le complète avant le retour.
+func readResultStream(r io.Reader, format, name string, onManifest func(*runManifest), fn func(jsonResult) error) error {
+	switch format {
+	case "json":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		results, manifest, err := decodeResults(data, name)
+		if err != nil {
+			return err
+		}
+		if manifest != nil && onManifest != nil {
+			onManifest(manifest)
+		}
+		for _, jr := range results {
+			if err := fn(jr); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "ndjson":
+		sc := bufio.NewScanner(r)
+		var manifest *runManifest
+		for line := 1; sc.Scan(); line++ {
+			text := strings.TrimSpace(sc.Text())
+			if text == "" {
+				continue
+			}
+			if strings.HasPrefix(text, ndjsonManifestPrefix) {
+				// La première ligne de manifeste est transmise, la dernière la complète (heure de fin).
+				first := manifest == nil
+				if first {
+					manifest = &runManifest{}
+				}
+				doc := struct {
+					Manifest *runManifest `json:"manifest"`
+				}{manifest}
+				if err := json.Unmarshal([]byte(text), &doc); err != nil {
+					return fmt.Errorf("%w: %s:%d: manifeste: %v", errInvalidInput, name, line, err)
+				}
+				if first && onManifest != nil {
+					onManifest(manifest)
+				}
+				continue
+			}
+			var jr jsonResult
+			if err := json.Unmarshal([]byte(text), &jr); err != nil {
+				return fmt.Errorf("%w: %s:%d: %v", errInvalidInput, name, line, err)
+			}
+			if err := fn(jr); err != nil {
+				return err
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		return nil
+	case "csv":
+		return readCSVResults(r, name, onManifest, fn)
+	case "pbz":
+		return readPBZ(r, onManifest, fn)
+	case "parquet":
+		return readParquet(r, name, onManifest, fn)
+	}
+	return fmt.Errorf("%w: format %q (attendu %v)", errInvalidFlags, format, convertFormats)
+}
+
+// commentRecorder transmet un texte ligne à ligne et retient ses lignes de commentaire ("#" en
+// début de ligne) dans comments; le lecteur csv les ignore ensuite (Comment), sans décaler les
+// numéros de ligne de ses erreurs.
+type commentRecorder struct {
+	br       *bufio.Reader
+	line     []byte // Reste de la ligne en cours.
+	comments []string
+}
+
+// Read implémente io.Reader.
+func (c *commentRecorder) Read(p []byte) (int, error) {
+	if len(c.line) == 0 {
+		line, err := c.br.ReadBytes('\n')
+		if len(line) == 0 {
+			return 0, err
+		}
+		if line[0] == '#' {
+			c.comments = append(c.comments, strings.TrimRight(string(line), "\r\n"))
+		}
+		c.line = line
+	}
+	n := copy(p, c.line)
+	c.line = c.line[n:]
+	return n, nil
+}
+
+// readCSVResults lit des résultats au format csv (en-tête csvResultHeader). Les colonnes sont
+// repérées par leur nom: seules p, q et n sont obligatoires. Le manifeste des lignes de
+// commentaire qui précèdent l'en-tête est transmis à onManifest (si non nil), puis complété de
+// l'heure de fin des commentaires qui suivent les lignes.
+func readCSVResults(r io.Reader, name string, onManifest func(*runManifest), fn func(jsonResult) error) error {
+	filter := &commentRecorder{br: bufio.NewReader(r)}
+	cr := csv.NewReader(filter)
+	cr.FieldsPerRecord = -1
+	cr.Comment = '#'
+	header, err := cr.Read()
+	var manifest *runManifest
+	if onManifest != nil {
+		if manifest = parseManifestComments(filter.comments); manifest != nil {
+			onManifest(manifest)
+		}
+	}
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", errInvalidInput, name, err)
+	}
+	column := map[string]int{}
+	for i, h := range header {
+		column[strings.TrimSpace(h)] = i
+	}
+	for _, required := range []string{"p", "q", "n"} {
+		if _, ok := column[required]; !ok {
+			return fmt.Errorf("%w: %s: colonne %q absente de l'en-tête %q", errInvalidInput, name, required, strings.Join(header, ","))
+		}
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			if m := parseManifestComments(filter.comments); manifest != nil && m != nil {
+				manifest.End = m.End
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", errInvalidInput, name, err)
+		}
+		line, _ := cr.FieldPos(0)
+		jr, err := parseCSVResult(record, column)
+		if err != nil {
+			return fmt.Errorf("%w: %s:%d: %v", errInvalidInput, name, line, err)
+		}
+		if err := fn(jr); err != nil {
+			return err
+		}
+	}
+}
+
+// parseCSVResult décode une ligne du format csv, de colonnes repérées par column.
+func parseCSVResult(record []string, column map[string]int) (jsonResult, error) {
+	field := func(name string) string {
+		if i, ok := column[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var jr jsonResult
+	var err error
+	if jr.P, err = strconv.Atoi(field("p")); err != nil {
+		return jr, fmt.Errorf("p: %v", err)
+	}
+	if jr.Q, err = strconv.Atoi(field("q")); err != nil {
+		return jr, fmt.Errorf("q: %v", err)
+	}
+	if jr.N, err = strconv.ParseInt(field("n"), 10, 64); err != nil {
+		return jr, fmt.Errorf("n: %v", err)
+	}
+	if s := field("n_big"); s != "" {
+		var ok bool
+		if jr.NBig, ok = new(big.Int).SetString(s, 10); !ok {
+			return jr, fmt.Errorf("n_big: %q n'est pas un entier", s)
+		}
+	}
+	if s := field("twin"); s != "" {
+		if jr.Twin, err = strconv.ParseBool(s); err != nil {
+			return jr, fmt.Errorf("twin: %v", err)
+		}
+	}
+	if s := field("found_at"); s != "" {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return jr, fmt.Errorf("found_at: %v", err)
+		}
+		jr.FoundAt = &t
+		if jr.TestNs, err = strconv.ParseInt(cmp.Or(field("test_ns"), "0"), 10, 64); err != nil {
+			return jr, fmt.Errorf("test_ns: %v", err)
+		}
+	}
+	return jr, nil
+}
+
+// runConvert implémente la sous-commande convert.
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fromPtr := fs.String("from", "", tr(msgFlagConvertFrom, strings.Join(convertFormats, ", ")))
+	toPtr := fs.String("to", "", tr(msgFlagConvertTo, strings.Join(resultFormats, ", ")))
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagConvertForm))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgConvertUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("%w: convert: un fichier d'entrée et un fichier de sortie sont requis", errInvalidFlags)
+	}
+	inPath, outPath := fs.Arg(0), fs.Arg(1)
+	from, to := cmp.Or(*fromPtr, formatFromPath(inPath)), cmp.Or(*toPtr, formatFromPath(outPath))
+	if !slices.Contains(convertFormats, from) {
+		return fmt.Errorf("%w: convert: format d'entrée %q pour %s (préciser -from parmi %v)", errInvalidFlags, from, inPath, convertFormats)
+	}
+	if !slices.Contains(resultFormats, to) {
+		return fmt.Errorf("%w: convert: format de sortie %q pour %s (préciser -to parmi %v)", errInvalidFlags, to, outPath, resultFormats)
+	}
+
+	var inFile *os.File
+	in := stdin
+	if inPath != "-" {
+		f, err := os.Open(inPath)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer f.Close()
+		inFile, in = f, f
+	}
+	// La sortie est une destination de -sink (fichier ou tcp://), ou la sortie standard.
+	var sink primes.ResultSink
+	var rw *resultWriter
+	var bw *bufio.Writer
+	if outPath == "-" {
+		bw = bufio.NewWriter(stdout)
+		rw = &resultWriter{w: &errWriter{w: bw}, format: to, formName: *formPtr}
+		sink = rw
+	} else {
+		s, err := openSink(sinkSpec{format: to, target: outPath}, *formPtr, &pluginSet{})
+		if err != nil {
+			return err
+		}
+		rw, sink = s.resultWriter, s
+	}
+	// La sortie commence au premier résultat (ou à la fin d'une entrée vide), une fois le manifeste
+	// de l'entrée connu: il est reporté tel quel, heure de fin comprise.
+	begun := false
+	begin := func() {
+		if !begun {
+			rw.begin()
+			begun = true
+		}
+	}
+	count := 0
+	err := readResultStream(in, from, inPath, func(m *runManifest) {
+		m.reread = true
+		rw.manifest = m
+	}, func(jr jsonResult) error {
+		begin()
+		count++
+		return sink.Write(jr.result())
+	})
+	begin()
+	if cerr := sink.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && bw != nil {
+		if ferr := bw.Flush(); ferr != nil {
+			err = fmt.Errorf("%w: %v", errIO, ferr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	sizes := ""
+	if inFile != nil && !strings.Contains(outPath, "://") {
+		inInfo, ierr := inFile.Stat()
+		outInfo, oerr := os.Stat(outPath)
+		if ierr == nil && oerr == nil {
+			sizes = tr(msgConvertSizes, formatBytes(inInfo.Size()), formatBytes(outInfo.Size()))
+		}
+	}
+	out := &errWriter{w: stderr}
+	fmt.Fprint(out, tr(msgConvertSummary, countInt(count), inPath, from, outPath, to, sizes))
+	return writeError(out)
+}
@@ -0,0 +1,114 @@
+/*
+ * Fichier: convert_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande convert.
+ */
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRunConvert écrit les résultats d'une recherche au format pbz, les convertit de proche en
+// proche dans chaque format puis revient au NDJSON, identique à celui de la recherche.
+func TestRunConvert(t *testing.T) {
+	dir := t.TempDir()
+	path := func(name string) string { return filepath.Join(dir, name) }
+	if err := run([]string{"-limit", "300", "-timing", "-format", "ndjson", "-o", path("ref.ndjson")}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"-limit", "300", "-format", "pbz", "-o", path("run.pbz")}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"convert", path("run.pbz"), path("run.ndjson")}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	ref, _ := os.ReadFile(path("ref.ndjson"))
+	if got, _ := os.ReadFile(path("run.ndjson")); len(got) == 0 || strings.Count(string(got), "\n") != strings.Count(string(ref), "\n") {
+		t.Fatalf("-format pbz relu: %d lignes, attendu %d", strings.Count(string(got), "\n"), strings.Count(string(ref), "\n"))
+	}
+
+	// Chaîne de conversions à partir de la sortie horodatée: aucun champ ne doit se perdre.
+	chain := []string{"ref.ndjson", "a.csv", "b.pbz", "c.parquet", "c.json", "d.data", "e.ndjson"}
+	for i := 1; i < len(chain); i++ {
+		args := []string{"convert", "-lang", "fr"}
+		if chain[i] == "d.data" {
+			args = append(args, "-to", "csv")
+		}
+		if chain[i-1] == "d.data" {
+			args = append(args, "-from", "csv")
+		}
+		var status strings.Builder
+		if err := run(append(args, path(chain[i-1]), path(chain[i])), io.Discard, &status); err != nil {
+			t.Fatalf("%s -> %s: %v", chain[i-1], chain[i], err)
+		}
+		if !strings.Contains(status.String(), " résultats convertis de ") {
+			t.Errorf("%s -> %s: bilan %q", chain[i-1], chain[i], status.String())
+		}
+	}
+	if got, _ := os.ReadFile(path("e.ndjson")); string(got) != string(ref) {
+		t.Errorf("après la chaîne de conversions:\n%s\nattendu:\n%s", got, ref)
+	}
+	if csv, _ := os.ReadFile(path("a.csv")); !strings.Contains(string(csv), "\n"+csvResultHeader+"\n") {
+		t.Errorf("CSV sans en-tête:\n%s", csv)
+	}
+
+	// Le manifeste d'une recherche traverse chaque format qui en porte un, heure de fin comprise.
+	if err := run([]string{"-limit", "100", "-format", "json", "-o", path("m.json")}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	_, want, err := readResults(path("m.json"))
+	if err != nil || want == nil {
+		t.Fatalf("manifeste de la recherche: %v, %v", want, err)
+	}
+	chain = []string{"m.json", "m.ndjson", "m.csv", "m.pbz", "m.parquet", "m2.json"}
+	for i := 1; i < len(chain); i++ {
+		if err := run([]string{"convert", path(chain[i-1]), path(chain[i])}, io.Discard, io.Discard); err != nil {
+			t.Fatalf("%s -> %s: %v", chain[i-1], chain[i], err)
+		}
+		_, got, err := readResults(path(chain[i]))
+		if err != nil || got == nil || got.RunID != want.RunID || got.Params["limit"] != "100" || !got.Start.Equal(want.Start) || !got.End.Equal(want.End) || !reflect.DeepEqual(got.Args, want.Args) {
+			t.Fatalf("%s: manifeste %+v, %v; attendu %+v", chain[i], got, err, want)
+		}
+	}
+
+	// Tableau en sortie, et diff entre formats différents.
+	if err := run([]string{"convert", "-form", "p^2+q^2", path("b.pbz"), path("t.txt")}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if table, _ := os.ReadFile(path("t.txt")); !strings.Contains(string(table), "n = p^2+q^2") {
+		t.Errorf("tableau sans l'en-tête de la forme:\n%s", table)
+	}
+	if err := run([]string{"diff", path("c.parquet"), path("run.pbz")}, io.Discard, io.Discard); err != nil {
+		t.Errorf("diff parquet pbz: %v", err)
+	}
+
+	os.WriteFile(path("bad.csv"), []byte("p,q\n3,5\n"), 0o644)
+	os.WriteFile(path("bad.pbz"), []byte("pas du zstd"), 0o644)
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"convert", path("ref.ndjson")}, exitInvalidFlags},
+		{[]string{"convert", path("ref.ndjson"), path("out.dat")}, exitInvalidFlags},
+		{[]string{"convert", "-from", "xml", path("ref.ndjson"), path("out.csv")}, exitInvalidFlags},
+		{[]string{"convert", path("absent.ndjson"), path("out.csv")}, exitIO},
+		{[]string{"convert", path("bad.csv"), path("out.ndjson")}, exitInvalidInput},
+		{[]string{"convert", path("bad.pbz"), path("out.ndjson")}, exitInvalidInput},
+		{[]string{"convert", path("bad.csv"), path("out.parquet")}, exitInvalidInput},
+		{[]string{"convert", path("t.txt"), path("out.csv")}, exitInvalidFlags},
+		{[]string{"convert", "-to", "ndjson", path("ref.ndjson"), "plugin://absent"}, exitIO},
+	} {
+		if got := exitCode(run(tc.args, io.Discard, io.Discard)); got != tc.code {
+			t.Errorf("%v -> code %d, attendu %d", tc.args[1:], got, tc.code)
+		}
+	}
+}
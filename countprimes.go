@@ -0,0 +1,71 @@
+/*
+ * Fichier: countprimes.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande count-primes: calcule π(x) par l'algorithme de Lagarias,
+ * Miller et Odlyzko (primes.PrimeCount), sans énumérer les nombres premiers,
+ * pour des valeurs de x bien au-delà de la portée du crible.
+ */
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// parseCountArg analyse un argument de count-primes, en notation entière ("1000000")
+// ou scientifique exacte ("1e12").
+func parseCountArg(s string) (int64, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < 0 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("valeur invalide %q", s)
+	}
+	return int64(f), nil
+}
+
+// runCountPrimes implémente la sous-commande count-primes.
+func runCountPrimes(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("count-primes", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgCountUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: count-primes: au moins une valeur de x est requise", errInvalidFlags)
+	}
+
+	values := make([]int64, fs.NArg())
+	for i, arg := range fs.Args() {
+		x, err := parseCountArg(arg)
+		if err != nil {
+			return fmt.Errorf("%w: count-primes: %v", errInvalidFlags, err)
+		}
+		values[i] = x
+	}
+
+	out := &errWriter{w: stdout}
+	for _, x := range values {
+		fmt.Fprintf(out, "π(%d) = %d\n", x, primes.PrimeCount(x))
+	}
+	return writeError(out)
+}
@@ -0,0 +1,51 @@
+/*
+ * Fichier: countprimes_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande count-primes.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// TestParseCountArg valide les notations entière et scientifique acceptées.
+func TestParseCountArg(t *testing.T) {
+	testCases := []struct {
+		arg      string
+		expected int64
+		valid    bool
+	}{
+		{"1000", 1000, true},
+		{"1e12", 1_000_000_000_000, true},
+		{"2.5e3", 2500, true},
+		{"1.5", 0, false},
+		{"-1e3", 0, false},
+		{"abc", 0, false},
+	}
+	for _, tc := range testCases {
+		got, err := parseCountArg(tc.arg)
+		if (err == nil) != tc.valid || got != tc.expected {
+			t.Errorf("parseCountArg(%q) = %d, %v", tc.arg, got, err)
+		}
+	}
+}
+
+// TestRunCountPrimes valide la sous-commande de bout en bout.
+func TestRunCountPrimes(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"count-primes", "100", "1e6"}, &out, io.Discard); err != nil {
+		t.Fatalf("count-primes: %v", err)
+	}
+	if want := "π(100) = 25\nπ(1000000) = 78498\n"; out.String() != want {
+		t.Errorf("sortie = %q, attendu %q", out.String(), want)
+	}
+	if got := exitCode(run([]string{"count-primes"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("sans argument -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
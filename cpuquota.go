@@ -0,0 +1,162 @@
+/*
+ * Fichier: cpuquota.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * CPU réellement disponibles pour dimensionner le pool de workers. Dans un
+ * conteneur (Kubernetes, Docker), runtime.NumCPU et GOMAXPROCS voient tous
+ * les cœurs de l'hôte alors que le quota CFS du cgroup n'en accorde qu'une
+ * fraction: autant de workers que de cœurs se disputent alors le quota et
+ * sont bridés par le noyau. Le quota est lu dans le cgroup du processus
+ * (cgroup v2: cpu.max; v1: cpu.cfs_quota_us et cpu.cfs_period_us), sous
+ * Linux seulement; l'option -cpu-quota le remplace ou l'ignore.
+ */
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// cpuQuotaModes sont les valeurs symboliques de -cpu-quota; un nombre de CPU (1.5, 4) fixe le quota.
+const (
+	cpuQuotaAuto = "auto" // Quota du cgroup, s'il y en a un.
+	cpuQuotaOff  = "off"  // Aucun quota: tous les CPU visibles.
+)
+
+// cpuBudget décrit les CPU disponibles pour les workers.
+type cpuBudget struct {
+	numCPU   int     // runtime.NumCPU().
+	maxProcs int     // runtime.GOMAXPROCS(0).
+	quota    float64 // Quota en CPU (0: aucun).
+	source   string  // Origine du quota: fichier du cgroup ou -cpu-quota.
+}
+
+// workers retourne le nombre de workers adapté au budget: les CPU visibles, bornés par GOMAXPROCS et
+// par le quota arrondi à l'entier supérieur (au moins un).
+func (b cpuBudget) workers() int {
+	n := max(min(b.numCPU, b.maxProcs), 1)
+	if b.quota > 0 {
+		n = min(n, max(int(math.Ceil(b.quota)), 1))
+	}
+	return n
+}
+
+// limited indique si le quota réduit le nombre de workers.
+func (b cpuBudget) limited() bool {
+	return b.workers() < max(min(b.numCPU, b.maxProcs), 1)
+}
+
+// newCPUBudget retourne le budget du processus selon mode (auto, off ou un nombre de CPU).
+func newCPUBudget(mode string) (cpuBudget, error) {
+	b := cpuBudget{numCPU: runtime.NumCPU(), maxProcs: runtime.GOMAXPROCS(0)}
+	switch mode {
+	case cpuQuotaAuto:
+		b.quota, b.source = systemCPUQuota()
+	case cpuQuotaOff:
+	default:
+		q, err := strconv.ParseFloat(mode, 64)
+		if err != nil || !(q > 0) || math.IsInf(q, 0) {
+			return b, fmt.Errorf("%w: -cpu-quota=%q (attendu %s, %s ou un nombre de CPU > 0)", errInvalidFlags, mode, cpuQuotaAuto, cpuQuotaOff)
+		}
+		b.quota, b.source = q, "-cpu-quota"
+	}
+	return b, nil
+}
+
+// defaultWorkers retourne le nombre de workers par défaut des sous-commandes: les CPU visibles,
+// bornés par le quota du cgroup.
+func defaultWorkers() int {
+	b, _ := newCPUBudget(cpuQuotaAuto)
+	return b.workers()
+}
+
+// parseCPUMax lit le contenu de cpu.max (cgroup v2): "QUOTA PÉRIODE" ou "max PÉRIODE".
+func parseCPUMax(data string) (float64, bool) {
+	fields := strings.Fields(data)
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, false
+	}
+	return quotaRatio(fields[0], fields[1])
+}
+
+// quotaRatio retourne quota/période en CPU, si le quota est fixé (positif).
+func quotaRatio(quota, period string) (float64, bool) {
+	q, errQ := strconv.ParseInt(strings.TrimSpace(quota), 10, 64)
+	p, errP := strconv.ParseInt(strings.TrimSpace(period), 10, 64)
+	if errQ != nil || errP != nil || q <= 0 || p <= 0 {
+		return 0, false
+	}
+	return float64(q) / float64(p), true
+}
+
+// cgroupPaths lit /proc/self/cgroup: le chemin du cgroup v2 (ligne "0::CHEMIN") et celui de la
+// hiérarchie v1 du contrôleur cpu.
+func cgroupPaths(fsys fs.FS) (v2, v1 string) {
+	f, err := fsys.Open("proc/self/cgroup")
+	if err != nil {
+		return "", ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		parts := strings.SplitN(sc.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			v2 = parts[2]
+		case strings.Contains(","+parts[1]+",", ",cpu,"):
+			v1 = parts[2]
+		}
+	}
+	return v2, v1
+}
+
+// cpuQuotaFS lit le quota CPU du processus dans fsys (la racine du système de fichiers): le plus
+// petit quota du cgroup et de ses ancêtres (cgroup v2), sinon celui de la hiérarchie v1 du
+// contrôleur cpu. Retourne le quota en CPU et le fichier lu, ou 0 sans quota.
+func cpuQuotaFS(fsys fs.FS) (float64, string) {
+	v2, v1 := cgroupPaths(fsys)
+	best, source := 0.0, ""
+	consider := func(q float64, ok bool, file string) {
+		if ok && (best == 0 || q < best) {
+			best, source = q, "/"+file
+		}
+	}
+	if v2 != "" {
+		// Dans un espace de noms de cgroup, le chemin est relatif à la racine montée: les
+		// ancêtres absents sont ignorés.
+		for dir := path.Join("sys/fs/cgroup", v2); ; dir = path.Dir(dir) {
+			file := path.Join(dir, "cpu.max")
+			if data, err := fs.ReadFile(fsys, file); err == nil {
+				q, ok := parseCPUMax(string(data))
+				consider(q, ok, file)
+			}
+			if dir == "sys/fs/cgroup" {
+				break
+			}
+		}
+	}
+	if best == 0 && v1 != "" {
+		for _, mount := range []string{"sys/fs/cgroup/cpu,cpuacct", "sys/fs/cgroup/cpu"} {
+			for _, dir := range []string{path.Join(mount, v1), mount} {
+				quota, errQ := fs.ReadFile(fsys, path.Join(dir, "cpu.cfs_quota_us"))
+				period, errP := fs.ReadFile(fsys, path.Join(dir, "cpu.cfs_period_us"))
+				if errQ == nil && errP == nil {
+					q, ok := quotaRatio(string(quota), string(period))
+					consider(q, ok, path.Join(dir, "cpu.cfs_quota_us"))
+				}
+			}
+		}
+	}
+	return best, source
+}
@@ -0,0 +1,18 @@
+//go:build linux
+
+/*
+ * Fichier: cpuquota_linux.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Lecture du quota CPU du cgroup du processus sous Linux.
+ */
+package main
+
+import "os"
+
+// systemCPUQuota retourne le quota CPU du cgroup du processus et le fichier lu (0: aucun quota).
+func systemCPUQuota() (float64, string) {
+	return cpuQuotaFS(os.DirFS("/"))
+}
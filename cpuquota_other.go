@@ -0,0 +1,16 @@
+//go:build !linux
+
+/*
+ * Fichier: cpuquota_other.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Hors Linux, pas de cgroup: tous les CPU visibles sont disponibles.
+ */
+package main
+
+// systemCPUQuota ne détecte aucun quota hors Linux.
+func systemCPUQuota() (float64, string) {
+	return 0, ""
+}
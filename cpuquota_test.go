@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseCPUMax(t *testing.T) {
+	tests := []struct {
+		data string
+		want float64
+		ok   bool
+	}{
+		{"200000 100000\n", 2, true},
+		{"150000 100000", 1.5, true},
+		{"max 100000\n", 0, false},
+		{"-1 100000", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseCPUMax(tt.data)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseCPUMax(%q) = %v, %v; attendu %v, %v", tt.data, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCPUQuotaFS(t *testing.T) {
+	tests := []struct {
+		name   string
+		fsys   fstest.MapFS
+		want   float64
+		source string
+	}{
+		{"cgroup v2", fstest.MapFS{
+			"proc/self/cgroup":                    {Data: []byte("0::/kubepods/pod1\n")},
+			"sys/fs/cgroup/kubepods/pod1/cpu.max": {Data: []byte("250000 100000\n")},
+		}, 2.5, "/sys/fs/cgroup/kubepods/pod1/cpu.max"},
+		{"cgroup v2, ancêtre plus strict", fstest.MapFS{
+			"proc/self/cgroup":                    {Data: []byte("0::/kubepods/pod1\n")},
+			"sys/fs/cgroup/kubepods/pod1/cpu.max": {Data: []byte("max 100000\n")},
+			"sys/fs/cgroup/kubepods/cpu.max":      {Data: []byte("50000 100000\n")},
+		}, 0.5, "/sys/fs/cgroup/kubepods/cpu.max"},
+		{"cgroup v2 sans quota", fstest.MapFS{
+			"proc/self/cgroup":      {Data: []byte("0::/\n")},
+			"sys/fs/cgroup/cpu.max": {Data: []byte("max 100000\n")},
+		}, 0, ""},
+		{"cgroup v1", fstest.MapFS{
+			"proc/self/cgroup": {Data: []byte("4:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n")},
+			"sys/fs/cgroup/cpu,cpuacct/docker/abc/cpu.cfs_quota_us":  {Data: []byte("300000\n")},
+			"sys/fs/cgroup/cpu,cpuacct/docker/abc/cpu.cfs_period_us": {Data: []byte("100000\n")},
+		}, 3, "/sys/fs/cgroup/cpu,cpuacct/docker/abc/cpu.cfs_quota_us"},
+		{"cgroup v1 sans quota", fstest.MapFS{
+			"proc/self/cgroup":                            {Data: []byte("3:cpu,cpuacct:/\n")},
+			"sys/fs/cgroup/cpu,cpuacct/cpu.cfs_quota_us":  {Data: []byte("-1\n")},
+			"sys/fs/cgroup/cpu,cpuacct/cpu.cfs_period_us": {Data: []byte("100000\n")},
+		}, 0, ""},
+		{"Sans cgroup", fstest.MapFS{}, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, source := cpuQuotaFS(tt.fsys)
+			if got != tt.want || source != tt.source {
+				t.Errorf("cpuQuotaFS = %v (%q); attendu %v (%q)", got, source, tt.want, tt.source)
+			}
+		})
+	}
+}
+
+func TestCPUBudgetWorkers(t *testing.T) {
+	tests := []struct {
+		b       cpuBudget
+		want    int
+		limited bool
+	}{
+		{cpuBudget{numCPU: 16, maxProcs: 16}, 16, false},
+		{cpuBudget{numCPU: 16, maxProcs: 16, quota: 2.5}, 3, true},
+		{cpuBudget{numCPU: 16, maxProcs: 16, quota: 0.2}, 1, true},
+		{cpuBudget{numCPU: 16, maxProcs: 4, quota: 8}, 4, false},
+		{cpuBudget{numCPU: 2, maxProcs: 2, quota: 8}, 2, false},
+	}
+	for _, tt := range tests {
+		if got := tt.b.workers(); got != tt.want {
+			t.Errorf("%+v.workers() = %d; attendu %d", tt.b, got, tt.want)
+		}
+		if got := tt.b.limited(); got != tt.limited {
+			t.Errorf("%+v.limited() = %v; attendu %v", tt.b, got, tt.limited)
+		}
+	}
+}
+
+func TestNewCPUBudget(t *testing.T) {
+	b, err := newCPUBudget("1.5")
+	if err != nil || b.quota != 1.5 || b.source != "-cpu-quota" {
+		t.Errorf("newCPUBudget(1.5) = %+v, %v", b, err)
+	}
+	if b, err := newCPUBudget(cpuQuotaOff); err != nil || b.quota != 0 {
+		t.Errorf("newCPUBudget(off) = %+v, %v", b, err)
+	}
+	for _, mode := range []string{"x", "0", "-2", "Inf", "NaN"} {
+		if _, err := newCPUBudget(mode); !errors.Is(err, errInvalidFlags) {
+			t.Errorf("newCPUBudget(%q): erreur %v, attendu errInvalidFlags", mode, err)
+		}
+	}
+}
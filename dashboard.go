@@ -0,0 +1,243 @@
+/*
+ * Fichier: dashboard.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tableau de bord web embarqué (option -dashboard). Une petite page HTML/JS,
+ * intégrée au binaire avec le paquet embed, affiche la progression, le débit,
+ * les dernières découvertes et les paramètres de l'exécution. Les données sont
+ * poussées au navigateur par un flux Server-Sent Events (SSE) sur /events.
+ */
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+//go:embed dashboard.html
+var dashboardHTML []byte
+
+// maxRecentResults borne le nombre de découvertes récentes conservées pour le tableau de bord.
+const maxRecentResults = 20
+
+// maxSnapshotResults borne le nombre de résultats écrits conservés pour les instantanés du socket
+// d'état (les plus récents, quelques Mio au plus): la sortie de l'exécution les contient tous.
+const maxSnapshotResults = 100_000
+
+// searchStats regroupe les compteurs partagés entre la recherche et les observateurs
+// (tableau de bord). Les compteurs sont atomiques car lus depuis les goroutines HTTP.
+// searchStats suit la recherche (primes.ProgressReporter): pairsTested à chaque état
+// d'avancement, final à la fin.
+type searchStats struct {
+	primes.NopReporter
+	totalPairs  int64
+	pairsTested atomic.Int64
+	primesFound atomic.Int64
+	decades     decadeCounts    // Résultats par ordre de grandeur de n.
+	final       primes.Progress // Dernier état, pour le résumé; lu après la fin de la recherche.
+}
+
+// OnBatchDone implémente primes.ProgressReporter.
+func (s *searchStats) OnBatchDone(pr primes.Progress) { s.pairsTested.Store(pr.Tested) }
+
+// OnFinish implémente primes.ProgressReporter.
+func (s *searchStats) OnFinish(sum primes.Summary) { s.final = sum.Progress }
+
+// runParams décrit les paramètres de l'exécution affichés par le tableau de bord.
+type runParams struct {
+	Limit      int    `json:"limit"`
+	Workers    int    `json:"workers"`
+	PrimeTest  string `json:"primeTest"`
+	PrimeCount int    `json:"primeCount"`
+	Form       string `json:"form"`
+	Filter     string `json:"filter,omitempty"`
+}
+
+// resultView est la représentation JSON d'un résultat pour le navigateur.
+type resultView struct {
+	P int   `json:"p"`
+	Q int   `json:"q"`
+	N int64 `json:"n"`
+}
+
+// dashboardSnapshot est l'état instantané envoyé à chaque événement SSE.
+type dashboardSnapshot struct {
+	Params      runParams    `json:"params"`
+	TotalPairs  int64        `json:"totalPairs"`
+	PairsTested int64        `json:"pairsTested"`
+	PrimesFound int64        `json:"primesFound"`
+	ElapsedSec  float64      `json:"elapsedSec"`
+	Recent      []resultView `json:"recent"`
+	Paused      bool         `json:"paused"`
+	Done        bool         `json:"done"`
+}
+
+// dashboard conserve l'état observable d'une exécution et le sert en HTTP. Il suit la recherche
+// (primes.ProgressReporter): découvertes récentes et fin de l'exécution.
+type dashboard struct {
+	primes.NopReporter
+	stats     *searchStats
+	params    runParams
+	startTime time.Time
+	interval  time.Duration
+	ctl       *primes.Control // Contrôle de la recherche, pour l'état de suspension (nil: inconnu).
+
+	mu       sync.Mutex
+	recent   []resultView
+	done     bool
+	retain   int          // Nombre de résultats écrits conservés pour les instantanés du socket d'état (0: aucun).
+	kept     []jsonResult // Les derniers résultats écrits, au plus retain, en anneau à partir de keptNext.
+	keptNext int
+	written  int64 // Résultats écrits depuis le début de l'exécution, si retain.
+}
+
+// newDashboard crée un tableau de bord pour les compteurs et paramètres donnés.
+func newDashboard(stats *searchStats, params runParams, startTime time.Time) *dashboard {
+	return &dashboard{
+		stats:     stats,
+		params:    params,
+		startTime: startTime,
+		interval:  time.Second,
+	}
+}
+
+// OnResult enregistre une découverte dans la liste des résultats récents (primes.ProgressReporter).
+func (d *dashboard) OnResult(res primes.Result) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.recent = append(d.recent, resultView{P: res.P, Q: res.Q, N: res.N})
+	if len(d.recent) > maxRecentResults {
+		d.recent = d.recent[len(d.recent)-maxRecentResults:]
+	}
+}
+
+// keepResult conserve un résultat écrit dans la sortie, si les résultats sont conservés (retain),
+// à la place du plus ancien une fois retain résultats conservés.
+func (d *dashboard) keepResult(res primes.Result) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.retain == 0 {
+		return
+	}
+	d.written++
+	if len(d.kept) < d.retain {
+		d.kept = append(d.kept, newJSONResult(res))
+		return
+	}
+	d.kept[d.keptNext] = newJSONResult(res)
+	d.keptNext = (d.keptNext + 1) % d.retain
+}
+
+// keptResults retourne les résultats conservés, du plus ancien au plus récent, et le nombre de
+// résultats écrits avant eux qui ne sont plus conservés.
+func (d *dashboard) keptResults() ([]jsonResult, int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	results := make([]jsonResult, 0, len(d.kept))
+	results = append(append(results, d.kept[d.keptNext:]...), d.kept[:d.keptNext]...)
+	return results, d.written - int64(len(results))
+}
+
+// OnFinish marque l'exécution comme terminée (primes.ProgressReporter).
+func (d *dashboard) OnFinish(primes.Summary) {
+	d.mu.Lock()
+	d.done = true
+	d.mu.Unlock()
+}
+
+// snapshot construit l'état instantané courant.
+func (d *dashboard) snapshot() dashboardSnapshot {
+	d.mu.Lock()
+	recent := make([]resultView, len(d.recent))
+	copy(recent, d.recent)
+	done := d.done
+	d.mu.Unlock()
+
+	return dashboardSnapshot{
+		Params:      d.params,
+		TotalPairs:  d.stats.totalPairs,
+		PairsTested: d.stats.pairsTested.Load(),
+		PrimesFound: d.stats.primesFound.Load(),
+		ElapsedSec:  time.Since(d.startTime).Seconds(),
+		Recent:      recent,
+		Paused:      d.ctl != nil && d.ctl.Paused(),
+		Done:        done,
+	}
+}
+
+// handler retourne le routeur HTTP du tableau de bord.
+func (d *dashboard) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", d.serveIndex)
+	mux.HandleFunc("/events", d.serveEvents)
+	return mux
+}
+
+// serveIndex sert la page HTML embarquée.
+func (d *dashboard) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(dashboardHTML)
+}
+
+// serveEvents pousse un instantané JSON à intervalle régulier (Server-Sent Events)
+// jusqu'à la déconnexion du client ou la fin de l'exécution.
+func (d *dashboard) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming non supporté", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		snap := d.snapshot()
+		data, err := json.Marshal(snap)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return
+		}
+		flusher.Flush()
+		if snap.Done {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startDashboard démarre le serveur HTTP du tableau de bord en arrière-plan.
+// L'écoute est ouverte immédiatement afin de signaler une adresse invalide avant la recherche.
+func startDashboard(addr string, d *dashboard) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{Addr: ln.Addr().String(), Handler: d.handler()}
+	go srv.Serve(ln)
+	return srv, nil
+}
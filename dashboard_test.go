@@ -0,0 +1,115 @@
+/*
+ * Fichier: dashboard_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du tableau de bord web: page embarquée, instantanés et flux SSE.
+ */
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestDashboardSnapshot valide les compteurs et la borne des résultats récents.
+func TestDashboardSnapshot(t *testing.T) {
+	stats := &searchStats{totalPairs: 100}
+	stats.pairsTested.Add(40)
+	stats.primesFound.Add(maxRecentResults + 5)
+	d := newDashboard(stats, runParams{Limit: 30, Workers: 2, PrimeTest: "miller"}, time.Now())
+
+	for i := 0; i < maxRecentResults+5; i++ {
+		d.OnResult(primes.Result{P: i, Q: i, N: int64(i)})
+	}
+
+	snap := d.snapshot()
+	if snap.PairsTested != 40 || snap.TotalPairs != 100 {
+		t.Errorf("compteurs = %d/%d, attendu 40/100", snap.PairsTested, snap.TotalPairs)
+	}
+	if len(snap.Recent) != maxRecentResults {
+		t.Fatalf("len(Recent) = %d, attendu %d", len(snap.Recent), maxRecentResults)
+	}
+	if snap.Recent[0].P != 5 {
+		t.Errorf("plus ancien résultat conservé p=%d, attendu 5", snap.Recent[0].P)
+	}
+	if snap.Done {
+		t.Errorf("Done = true avant OnFinish")
+	}
+}
+
+// TestDashboardKeptResults valide l'anneau borné des résultats conservés pour les instantanés.
+func TestDashboardKeptResults(t *testing.T) {
+	dash := newDashboard(&searchStats{}, runParams{}, time.Now())
+	dash.keepResult(primes.Result{P: 3, Q: 2, N: 25})
+	if kept, omitted := dash.keptResults(); len(kept) != 0 || omitted != 0 {
+		t.Errorf("sans retain: %v, %d omis", kept, omitted)
+	}
+
+	dash.retain = 3
+	for p := 3; p <= 13; p += 2 {
+		dash.keepResult(primes.Result{P: p, Q: 2})
+	}
+	kept, omitted := dash.keptResults()
+	want := []jsonResult{{P: 9, Q: 2}, {P: 11, Q: 2}, {P: 13, Q: 2}}
+	if !slices.Equal(kept, want) || omitted != 3 {
+		t.Errorf("keptResults = %v, %d omis; attendu %v, 3 omis", kept, omitted, want)
+	}
+}
+
+// TestDashboardIndex valide que la page HTML embarquée est servie.
+func TestDashboardIndex(t *testing.T) {
+	d := newDashboard(&searchStats{}, runParams{}, time.Now())
+	rec := httptest.NewRecorder()
+	d.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("statut = %d, attendu 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "EventSource") {
+		t.Errorf("la page ne contient pas le client SSE")
+	}
+}
+
+// TestDashboardEvents valide que le flux SSE émet un instantané et se termine avec l'exécution.
+func TestDashboardEvents(t *testing.T) {
+	stats := &searchStats{totalPairs: 4}
+	stats.pairsTested.Add(4)
+	d := newDashboard(stats, runParams{Limit: 3}, time.Now())
+	d.OnResult(primes.Result{P: 3, Q: 2, N: 25})
+	d.OnFinish(primes.Summary{})
+
+	srv := httptest.NewServer(d.handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET /events: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, attendu text/event-stream", ct)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("lecture de l'événement: %v", err)
+	}
+	var snap dashboardSnapshot
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &snap); err != nil {
+		t.Fatalf("décodage de l'événement: %v", err)
+	}
+	if !snap.Done || snap.PairsTested != 4 || len(snap.Recent) != 1 {
+		t.Errorf("instantané inattendu: %+v", snap)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Fichier: decades.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Comptes des résultats par ordre de grandeur (décade) de n, tenus pendant
+ * la recherche: la ligne de statistiques périodique les rappelle et le résumé
+ * de fin les présente en tableau, ce qui montre la croissance des découvertes
+ * sans traitement a posteriori.
+ */
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// decadeBig est l'indice de la décade des résultats au-delà d'int64 (OverflowPromote): les
+// valeurs d'int64 ont au plus 19 chiffres, décades 0 à 18.
+const decadeBig = 19
+
+// decadeCounts compte les résultats par décade: l'indice k compte les n de [10^k, 10^(k+1)[.
+// Alimenté par la collecte et lu par la ligne de statistiques depuis une autre goroutine.
+type decadeCounts [decadeBig + 1]atomic.Int64
+
+// decadeOf retourne la décade de n (0 pour n < 10), ou decadeBig au-delà d'int64.
+func decadeOf(res primes.Result) int {
+	if res.Big != nil {
+		return decadeBig
+	}
+	k := 0
+	for n := res.N; n >= 10; n /= 10 {
+		k++
+	}
+	return k
+}
+
+// add compte le résultat res.
+func (d *decadeCounts) add(res primes.Result) { d[decadeOf(res)].Add(1) }
+
+// snapshot retourne les comptes par décade et leur total.
+func (d *decadeCounts) snapshot() ([decadeBig + 1]int64, int64) {
+	var counts [decadeBig + 1]int64
+	var total int64
+	for k := range d {
+		counts[k] = d[k].Load()
+		total += counts[k]
+	}
+	return counts, total
+}
+
+// decadeLabel retourne le nom court d'une décade pour la ligne de statistiques.
+func decadeLabel(k int) string {
+	if k == decadeBig {
+		return ">2^63"
+	}
+	return fmt.Sprintf("10^%d", k)
+}
+
+// formatDecadeLine présente les décades non vides sur une ligne (ligne de statistiques); vide sans
+// résultat compté.
+func formatDecadeLine(d *decadeCounts) string {
+	counts, total := d.snapshot()
+	if total == 0 {
+		return ""
+	}
+	var items []string
+	for k, n := range counts {
+		if n > 0 {
+			items = append(items, fmt.Sprintf("%s:%d", decadeLabel(k), n))
+		}
+	}
+	return tr(msgStatsDecades, strings.Join(items, " "))
+}
+
+// formatDecadeTable présente les décades non vides en tableau, avec leur part des résultats
+// (résumé de fin); vide sans résultat compté.
+func formatDecadeTable(d *decadeCounts) string {
+	counts, total := d.snapshot()
+	if total == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(tr(msgDecadesTitle))
+	for k, n := range counts {
+		switch {
+		case n == 0:
+		case k == decadeBig:
+			b.WriteString(tr(msgDecadesBig, countInt(n), percentOf(n, total)))
+		default:
+			b.WriteString(tr(msgDecadesLine, k, k+1, countInt(n), percentOf(n, total)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+/*
+ * Fichier: decades_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des comptes de résultats par décade de n.
+ */
+package main
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestDecadeOf vérifie les bornes des décades, y compris au-delà d'int64.
+func TestDecadeOf(t *testing.T) {
+	for _, tc := range []struct {
+		res  primes.Result
+		want int
+	}{
+		{primes.Result{N: 5}, 0},
+		{primes.Result{N: 10}, 1},
+		{primes.Result{N: 99}, 1},
+		{primes.Result{N: 100}, 2},
+		{primes.Result{N: math.MaxInt64}, 18},
+		{primes.Result{N: math.MaxInt64, Big: new(big.Int).Lsh(big.NewInt(1), 70)}, decadeBig},
+	} {
+		if got := decadeOf(tc.res); got != tc.want {
+			t.Errorf("decadeOf(%v) = %d, attendu %d", tc.res.N, got, tc.want)
+		}
+	}
+}
+
+// TestDecadeFormats vérifie le tableau du résumé et la ligne de statistiques.
+func TestDecadeFormats(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	stats := &searchStats{totalPairs: 100}
+	if formatDecadeTable(&stats.decades) != "" || formatDecadeLine(&stats.decades) != "" {
+		t.Error("décades affichées sans résultat")
+	}
+	for _, n := range []int64{41, 61, 109, 149, 269, 2213} {
+		stats.decades.add(primes.Result{N: n})
+	}
+	stats.decades.add(primes.Result{N: math.MaxInt64, Big: new(big.Int).Lsh(big.NewInt(1), 64)})
+	stats.primesFound.Store(7)
+	wantTable := "Résultats par ordre de grandeur de n:\n" +
+		"  10^1 <= n < 10^2: 2 (28.6 %)\n" +
+		"  10^2 <= n < 10^3: 3 (42.9 %)\n" +
+		"  10^3 <= n < 10^4: 1 (14.3 %)\n" +
+		"  n > 2^63-1: 1 (14.3 %)\n"
+	if got := formatDecadeTable(&stats.decades); got != wantTable {
+		t.Errorf("tableau = %q, attendu %q", got, wantTable)
+	}
+
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	r := newStatsReporter(stats, start)
+	r.memory = func() int64 { return 1 << 20 }
+	stats.pairsTested.Store(50)
+	want := "[1s] 50 paires/s | 7 résultats | 50.0 % | mémoire 1.0 MiB\n  par décade: 10^1:2 10^2:3 10^3:1 >2^63:1\n"
+	if got := r.line(start.Add(time.Second)); got != want {
+		t.Errorf("ligne = %q, attendu %q", got, want)
+	}
+}
+
+// TestRunDecades vérifie le tableau du résumé de bout en bout: les décades comptent tous les
+// résultats.
+func TestRunDecades(t *testing.T) {
+	var status bytes.Buffer
+	if err := run([]string{"-limit", "10", "-lang", "fr"}, &status, &status); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// Limite 10: n = 41, 61, 109, 149.
+	for _, expected := range []string{"Résultats par ordre de grandeur de n:\n", "  10^1 <= n < 10^2: 2 (50.0 %)\n", "  10^2 <= n < 10^3: 2 (50.0 %)\n"} {
+		if !strings.Contains(status.String(), expected) {
+			t.Errorf("résumé sans %q:\n%s", expected, status.String())
+		}
+	}
+}
@@ -0,0 +1,185 @@
+/*
+ * Fichier: decompose.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande decompose: décompose un nombre premier n ≡ 1 mod 4 (ou 2) en
+ * somme de deux carrés n = x^2 + y^2 par l'algorithme de Cornacchia
+ * (ntheory.Cornacchia), représentation unique d'après Fermat. Les formes
+ * prédéfinies sont toutes des sommes de deux carrés (p^2 + (2q)^2,
+ * p^2 + (q^2)^2, p^2 + 1^2): la décomposition retrouve la paire (p, q) d'un
+ * résultat sans réévaluer la forme. Avec -results, chaque résultat d'un
+ * fichier JSON est ainsi revérifié de façon structurelle et indépendante
+ * (code de sortie 5 en cas d'écart).
+ */
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"github.com/agbru/PrimeNumber/primes/ntheory"
+)
+
+// squareForm relie une forme prédéfinie à sa décomposition n = p^2 + r^2.
+type squareForm struct {
+	root func(q int64) int64         // Racine r du second carré pour q.
+	q    func(r int64) (int64, bool) // q dont le second carré est r^2, s'il existe.
+}
+
+// squareForms sont les décompositions des formes prédéfinies.
+var squareForms = map[string]squareForm{
+	"p^2+4q^2": {
+		root: func(q int64) int64 { return 2 * q },
+		q:    func(r int64) (int64, bool) { return r / 2, r%2 == 0 },
+	},
+	"p^2+q^4": {
+		root: func(q int64) int64 { return q * q },
+		q: func(r int64) (int64, bool) {
+			q := int64(math.Sqrt(float64(r)))
+			return q, q*q == r
+		},
+	},
+	"x^2+1": {
+		root: func(q int64) int64 { return 1 },
+		q:    func(r int64) (int64, bool) { return 2, r == 1 }, // Seule la paire q = 2 est testée.
+	},
+}
+
+// decomposeSquares retourne la représentation n = x^2 + y^2 du nombre premier n, x impair et y
+// pair (x = y = 1 pour n = 2). L'erreur enveloppe errInvalidInput si n n'est pas premier ou n'est
+// pas une somme de deux carrés (n ≡ 3 mod 4).
+func decomposeSquares(n int64) (x, y int64, err error) {
+	if !primes.IsPrimeMillerRabin64(n) {
+		return 0, 0, fmt.Errorf("%w: %d n'est pas premier", errInvalidInput, n)
+	}
+	if n%4 == 3 {
+		return 0, 0, fmt.Errorf("%w: %d ≡ 3 mod 4 n'est pas une somme de deux carrés", errInvalidInput, n)
+	}
+	x, y, err = ntheory.Cornacchia(1, n)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %d: %v", errInvalidInput, n, err)
+	}
+	if x%2 == 0 {
+		x, y = y, x
+	}
+	return x, y, nil
+}
+
+// formPair retourne la paire de nombres premiers (p, q) de la forme dont n = x^2 + y^2 est la
+// valeur, si elle existe.
+func formPair(sf squareForm, x, y int64) (p, q int64, ok bool) {
+	for _, roots := range [][2]int64{{x, y}, {y, x}} {
+		q, ok := sf.q(roots[1])
+		if ok && primes.IsPrimeMillerRabin64(roots[0]) && primes.IsPrimeMillerRabin64(q) {
+			return roots[0], q, true
+		}
+	}
+	return 0, 0, false
+}
+
+// checkDecomposition revérifie un résultat (p, q, n): la décomposition de n en somme de deux carrés
+// doit être formée des racines p et r(q) de la forme.
+func checkDecomposition(sf squareForm, res jsonResult) error {
+	x, y, err := decomposeSquares(res.N)
+	if err != nil {
+		return fmt.Errorf("%w: (p=%d, q=%d): %v", errVerification, res.P, res.Q, err)
+	}
+	p, r := int64(res.P), sf.root(int64(res.Q))
+	if (x != p || y != r) && (x != r || y != p) {
+		return fmt.Errorf("%w: n=%d = %d^2 + %d^2, attendu %d^2 + %d^2 pour (p=%d, q=%d)", errVerification, res.N, x, y, p, r, res.P, res.Q)
+	}
+	return nil
+}
+
+// runDecompose implémente la sous-commande decompose.
+func runDecompose(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("decompose", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	resultsPtr := fs.String("results", "", tr(msgFlagDecomposeResults))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgDecomposeUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if (fs.NArg() == 0) == (*resultsPtr == "") {
+		fs.Usage()
+		return fmt.Errorf("%w: decompose: des valeurs de n ou -results sont requises (l'un ou l'autre)", errInvalidFlags)
+	}
+	values := make([]int64, fs.NArg())
+	for i, arg := range fs.Args() {
+		n, err := parseCountArg(arg)
+		if err != nil {
+			return fmt.Errorf("%w: decompose: %v", errInvalidFlags, err)
+		}
+		values[i] = n
+	}
+
+	out := &errWriter{w: stdout}
+	if *resultsPtr == "" {
+		sf, known := squareForms[*formPtr]
+		if !known {
+			return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, *formPtr, primes.FormNames())
+		}
+		for _, n := range values {
+			x, y, err := decomposeSquares(n)
+			switch {
+			case err != nil:
+				fmt.Fprintln(out, tr(msgDecomposeNone, n))
+			default:
+				line := fmt.Sprintf("%d = %d^2 + %d^2", n, x, y)
+				if p, q, ok := formPair(sf, x, y); ok {
+					line += fmt.Sprintf(" (%s: p=%d, q=%d)", *formPtr, p, q)
+				}
+				fmt.Fprintln(out, line)
+			}
+		}
+		return writeError(out)
+	}
+
+	results, manifest, err := readResults(*resultsPtr)
+	if err != nil {
+		return err
+	}
+	formName := *formPtr
+	if manifest != nil && manifest.Params["form"] != "" && !flagSet(fs, "form") {
+		formName = manifest.Params["form"]
+	}
+	sf, known := squareForms[formName]
+	if !known {
+		return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, formName, primes.FormNames())
+	}
+	checked, skipped, mismatches := 0, 0, 0
+	for _, res := range results {
+		if res.NBig != nil {
+			skipped++ // Au-delà d'int64: hors de portée de Cornacchia sur int64.
+			continue
+		}
+		checked++
+		if err := checkDecomposition(sf, res); err != nil {
+			mismatches++
+			fmt.Fprintln(out, err)
+		}
+	}
+	fmt.Fprint(out, tr(msgDecomposeSummary, countInt(checked), formName, countInt(mismatches), countInt(skipped)))
+	if err := writeError(out); err != nil {
+		return err
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("%w: %d résultat(s) sur %d sans la décomposition attendue", errVerification, mismatches, checked)
+	}
+	return nil
+}
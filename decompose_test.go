@@ -0,0 +1,71 @@
+/*
+ * Fichier: decompose_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande decompose.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestRunDecompose valide les décompositions, la paire retrouvée pour la forme et les valeurs sans
+// décomposition.
+func TestRunDecompose(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	var out bytes.Buffer
+	if err := run([]string{"decompose", "41", "13", "2", "43", "45"}, &out, io.Discard); err != nil {
+		t.Fatalf("decompose: %v", err)
+	}
+	want := "41 = 5^2 + 4^2 (p^2+4q^2: p=5, q=2)\n13 = 3^2 + 2^2\n2 = 1^2 + 1^2\n" + tr(msgDecomposeNone, 43) + "\n" + tr(msgDecomposeNone, 45) + "\n"
+	if out.String() != want {
+		t.Errorf("sortie = %q, attendu %q", out.String(), want)
+	}
+	out.Reset()
+	if err := run([]string{"decompose", "-form", "p^2+q^4", "257"}, &out, io.Discard); err != nil || out.String() != "257 = 1^2 + 16^2\n" {
+		t.Errorf("decompose -form p^2+q^4 257 = %q, %v", out.String(), err)
+	}
+	for _, args := range [][]string{{"decompose"}, {"decompose", "-results", "r.json", "41"}, {"decompose", "-form", "p^3", "41"}, {"decompose", "x"}} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("run(%v) -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
+
+// TestRunDecomposeResults vérifie les résultats d'une recherche par leur décomposition, pour chaque
+// forme prédéfinie, et détecte un résultat dont q a été altéré.
+func TestRunDecomposeResults(t *testing.T) {
+	dir := t.TempDir()
+	for _, form := range []string{"p^2+4q^2", "p^2+q^4", "x^2+1"} {
+		path := filepath.Join(dir, "resultats.json")
+		if err := run([]string{"-limit", "200", "-form", form, "-format", "json", "-o", path}, io.Discard, io.Discard); err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := run([]string{"decompose", "-results", path}, &out, io.Discard); err != nil {
+			t.Errorf("decompose -results (%s): %v\n%s", form, err, out.String())
+		}
+	}
+
+	// (p=5, q=2, n=41) devient (p=5, q=3, n=41): n ne se décompose pas en 5^2 + 6^2.
+	forged := filepath.Join(dir, "altere.json")
+	if err := os.WriteFile(forged, []byte(`[{"p":3,"q":5,"n":109},{"p":5,"q":3,"n":41}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	err := run([]string{"decompose", "-results", forged}, &out, io.Discard)
+	if got := exitCode(err); got != exitVerification || !strings.Contains(out.String(), "n=41 = 5^2 + 4^2") {
+		t.Errorf("résultat altéré: code %d (%v), sortie %q; attendu le code %d", got, err, out.String(), exitVerification)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Fichier: dedup_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des options -dedup et -sort.
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestRunDedup vérifie que -dedup laisse les résultats des formes prédéfinies inchangés (un n par
+// paire) et en compte les valeurs distinctes, quelle que soit la structure.
+func TestRunDedup(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	var plain bytes.Buffer
+	if err := run([]string{"-limit", "100", "-workers", "1", "-format", "ndjson", "-manifest=false"}, &plain, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	want := resultLines(plain.String())
+	for _, structure := range []string{"hash", "roaring"} {
+		var out, status bytes.Buffer
+		if err := run([]string{"-limit", "100", "-workers", "1", "-format", "ndjson", "-manifest=false", "-dedup", structure}, &out, &status); err != nil {
+			t.Fatalf("-dedup %s: %v", structure, err)
+		}
+		if got := resultLines(out.String()); got != want {
+			t.Errorf("-dedup %s: résultats différents de la recherche sans dédoublonnage", structure)
+		}
+		distinct := strings.Count(want, "\n") + 1
+		if summary := tr(msgDedupSummary, structure, countInt(distinct), countInt(0), ""); !strings.Contains(status.String(), strings.TrimSuffix(summary, ".\n")) {
+			t.Errorf("-dedup %s: résumé %q absent de:\n%s", structure, summary, status.String())
+		}
+	}
+	for _, args := range [][]string{{"-dedup", "bitset"}, {"-dedup", "hash", "-on-overflow", "promote-big"}} {
+		if err := run(append([]string{"-limit", "100"}, args...), io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+			t.Errorf("%v: %v, attendu le code %d", args, err, exitInvalidFlags)
+		}
+	}
+}
+
+// TestRunSort vérifie que -sort écrit les mêmes résultats que la recherche, par n croissant, y
+// compris quand les séries sont déversées sur disque, et que les fichiers temporaires sont supprimés.
+func TestRunSort(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	base := []string{"-limit", "200", "-workers", "2", "-format", "ndjson", "-manifest=false"}
+	var plain bytes.Buffer
+	if err := run(base, &plain, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Split(resultLines(plain.String()), "\n")
+	slices.Sort(want)
+	for _, extra := range [][]string{{"-sort"}, {"-sort", "-sort-memory", "500B"}, {"-sort", "-sort-memory", "500B", "-dedup", "sort"}} {
+		dir := t.TempDir()
+		var out, status bytes.Buffer
+		if err := run(slices.Concat(base, extra, []string{"-sort-dir", dir}), &out, &status); err != nil {
+			t.Fatalf("%v: %v", extra, err)
+		}
+		lines := strings.Split(resultLines(out.String()), "\n")
+		var prev int64
+		for i, line := range lines {
+			var r struct{ N int64 }
+			if err := json.Unmarshal([]byte(line), &r); err != nil || r.N < prev {
+				t.Fatalf("%v: ligne %d %q hors de l'ordre de n (précédent %d)", extra, i, line, prev)
+			}
+			prev = r.N
+		}
+		if slices.Sort(lines); !slices.Equal(lines, want) {
+			t.Errorf("%v: %d résultats, différents des %d de la recherche sans tri", extra, len(lines), len(want))
+		}
+		if !strings.Contains(status.String(), fmt.Sprintf("Sortie triée (-sort): %d résultats", countInt(len(want)))) {
+			t.Errorf("%v: résumé du tri absent de:\n%s", extra, status.String())
+		}
+		if spilled, _ := filepath.Glob(filepath.Join(dir, "*")); len(spilled) != 0 {
+			t.Errorf("%v: fichiers temporaires laissés: %v", extra, spilled)
+		}
+	}
+	for _, args := range [][]string{{"-dedup", "sort"}, {"-sort", "-top", "5"}, {"-sort", "-sort-memory", "x"}, {"-sort-dir", "."}} {
+		if err := run(append([]string{"-limit", "100"}, args...), io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+			t.Errorf("%v: %v, attendu le code %d", args, err, exitInvalidFlags)
+		}
+	}
+	missing := filepath.Join(t.TempDir(), "absent")
+	if err := run([]string{"-limit", "1000", "-sort", "-sort-memory", "100B", "-sort-dir", missing}, io.Discard, io.Discard); exitCode(err) != exitIO {
+		t.Errorf("-sort-dir absent: %v, attendu le code %d", err, exitIO)
+	}
+}
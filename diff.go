@@ -0,0 +1,162 @@
+/*
+ * Fichier: diff.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande diff: compare deux fichiers de résultats JSON (-format json)
+ * et liste les résultats présents dans l'un mais pas dans l'autre, triés par
+ * n, pour valider une refonte ou comparer deux algorithmes sur des paramètres
+ * identiques. Les paramètres des deux manifestes qui déterminent les résultats
+ * (limite, forme, filtre...) sont comparés et leurs écarts signalés.
+ */
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+// diffParams sont les paramètres du manifeste qui déterminent l'ensemble des résultats.
+var diffParams = []string{"limit", "form", "pairs", "filter", "twins", "primes-file"}
+
+// readResults lit un fichier de résultats JSON: document {"results", "manifest"} ou tableau brut
+// (-manifest=false), auquel cas le manifeste retourné est nil. Un fichier d'extension .ndjson,
+// .csv, .pbz ou .parquet est lu dans son format (voir convert), avec son manifeste s'il en porte un.
+func readResults(path string) ([]jsonResult, *runManifest, error) {
+	if format := formatFromPath(path); slices.Contains(convertFormats, format) && format != "json" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer f.Close()
+		var results []jsonResult
+		var manifest *runManifest
+		err = readResultStream(f, format, path, func(m *runManifest) { manifest = m }, func(jr jsonResult) error {
+			results = append(results, jr)
+			return nil
+		})
+		return results, manifest, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	return decodeResults(data, path)
+}
+
+// decodeResults décode le fichier de résultats JSON name, de contenu data (voir readResults).
+func decodeResults(data []byte, name string) ([]jsonResult, *runManifest, error) {
+	var err error
+	var doc struct {
+		Results  []jsonResult `json:"results"`
+		Manifest *runManifest `json:"manifest"`
+	}
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &doc.Results)
+	} else {
+		err = json.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s: %v", errInvalidInput, name, err)
+	}
+	return doc.Results, doc.Manifest, nil
+}
+
+// compareResults ordonne les résultats par n, puis par p et q.
+func compareResults(a, b jsonResult) int {
+	return cmp.Or(cmp.Compare(a.N, b.N), cmp.Compare(a.P, b.P), cmp.Compare(a.Q, b.Q))
+}
+
+// diffResults retourne, triés par n, les résultats propres à a et à b, et le nombre de résultats
+// communs. Le marquage des jumeaux et les mesures (-timing) n'entrent pas dans la comparaison; les
+// doublons sont ignorés.
+func diffResults(a, b []jsonResult) (onlyA, onlyB []jsonResult, common int) {
+	normalize := func(list []jsonResult) []jsonResult {
+		list = slices.Clone(list)
+		for i := range list {
+			list[i].Twin, list[i].FoundAt, list[i].TestNs = false, nil, 0
+		}
+		slices.SortFunc(list, compareResults)
+		return slices.Compact(list)
+	}
+	a, b = normalize(a), normalize(b)
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := compareResults(a[i], b[j]); {
+		case c < 0:
+			onlyA = append(onlyA, a[i])
+			i++
+		case c > 0:
+			onlyB = append(onlyB, b[j])
+			j++
+		default:
+			common++
+			i++
+			j++
+		}
+	}
+	return append(onlyA, a[i:]...), append(onlyB, b[j:]...), common
+}
+
+// runDiff implémente la sous-commande diff. Des fichiers différents retournent une erreur
+// enveloppant errVerification.
+func runDiff(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgDiffUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("%w: diff: deux fichiers sont requis", errInvalidFlags)
+	}
+	oldPath, newPath := fs.Arg(0), fs.Arg(1)
+	oldResults, oldManifest, err := readResults(oldPath)
+	if err != nil {
+		return err
+	}
+	newResults, newManifest, err := readResults(newPath)
+	if err != nil {
+		return err
+	}
+
+	out := &errWriter{w: stdout}
+	if oldManifest != nil && newManifest != nil {
+		for _, name := range diffParams {
+			if a, b := oldManifest.Params[name], newManifest.Params[name]; a != b {
+				fmt.Fprint(out, tr(msgDiffParamMismatch, name, a, b))
+			}
+		}
+	}
+	onlyOld, onlyNew, common := diffResults(oldResults, newResults)
+	for _, r := range onlyOld {
+		fmt.Fprintf(out, "- %-10d | %-10d | %d\n", r.P, r.Q, r.N)
+	}
+	for _, r := range onlyNew {
+		fmt.Fprintf(out, "+ %-10d | %-10d | %d\n", r.P, r.Q, r.N)
+	}
+	fmt.Fprint(out, tr(msgDiffSummary, common, len(onlyOld), oldPath, len(onlyNew), newPath))
+	if err := writeError(out); err != nil {
+		return err
+	}
+	if len(onlyOld)+len(onlyNew) > 0 {
+		return fmt.Errorf("%w: %s et %s diffèrent", errVerification, oldPath, newPath)
+	}
+	return nil
+}
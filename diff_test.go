@@ -0,0 +1,84 @@
+/*
+ * Fichier: diff_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande diff.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDiffResults valide la normalisation (ordre, jumeaux, mesures, doublons) et la séparation des résultats propres.
+func TestDiffResults(t *testing.T) {
+	found := time.Now()
+	a := []jsonResult{{P: 7, Q: 5, N: 149, FoundAt: &found, TestNs: 800}, {P: 5, Q: 2, N: 41, Twin: true}, {P: 3, Q: 5, N: 109}, {P: 3, Q: 5, N: 109, FoundAt: &found}}
+	b := []jsonResult{{P: 5, Q: 2, N: 41}, {P: 5, Q: 3, N: 61}, {P: 7, Q: 5, N: 149}}
+	onlyA, onlyB, common := diffResults(a, b)
+	if !slices.Equal(onlyA, []jsonResult{{P: 3, Q: 5, N: 109}}) || !slices.Equal(onlyB, []jsonResult{{P: 5, Q: 3, N: 61}}) || common != 2 {
+		t.Errorf("diffResults = %v, %v, %d; attendu [109], [61], 2", onlyA, onlyB, common)
+	}
+}
+
+// TestRunDiff valide la comparaison de bout en bout de fichiers produits par -format json.
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	small, large, raw := filepath.Join(dir, "10.json"), filepath.Join(dir, "30.json"), filepath.Join(dir, "brut.json")
+	for _, args := range [][]string{
+		{"-limit", "10", "-format", "json", "-o", small},
+		{"-limit", "30", "-format", "json", "-o", large, "-workers", "3"},
+		{"-limit", "10", "-format", "json", "-o", raw, "-manifest=false", "-primetest", "trial"},
+	} {
+		if err := run(args, io.Discard, io.Discard); err != nil {
+			t.Fatalf("run(%v): %v", args, err)
+		}
+	}
+
+	results, manifest, err := readResults(small)
+	if err != nil || manifest == nil || manifest.Params["limit"] != "10" || len(results) != 4 {
+		t.Fatalf("readResults = %v, %+v, %v", results, manifest, err)
+	}
+
+	// Même limite, algorithme différent et manifeste absent: identiques.
+	var out bytes.Buffer
+	if err := run([]string{"diff", small, raw}, &out, io.Discard); err != nil {
+		t.Errorf("diff identiques: %v\n%s", err, out.String())
+	}
+
+	out.Reset()
+	err = run([]string{"diff", large, small}, &out, io.Discard)
+	if got := exitCode(err); got != exitVerification {
+		t.Errorf("diff différents -> code %d (%v), attendu %d", got, err, exitVerification)
+	}
+	if !strings.Contains(out.String(), "-limit") || !strings.Contains(out.String(), "- 11         | 2          | 137\n") || strings.Contains(out.String(), "\n+ ") {
+		t.Errorf("sortie inattendue:\n%s", out.String())
+	}
+
+	bad := filepath.Join(dir, "invalide.json")
+	if err := os.WriteFile(bad, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		args     []string
+		expected int
+	}{
+		{[]string{"diff", small}, exitInvalidFlags},
+		{[]string{"diff", small, bad}, exitInvalidInput},
+		{[]string{"diff", small, filepath.Join(dir, "absent.json")}, exitIO},
+		{[]string{"-format", "xml"}, exitInvalidFlags},
+	} {
+		if got := exitCode(run(tc.args, io.Discard, io.Discard)); got != tc.expected {
+			t.Errorf("%v -> code %d, attendu %d", tc.args, got, tc.expected)
+		}
+	}
+}
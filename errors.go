@@ -0,0 +1,101 @@
+/*
+ * Fichier: errors.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Erreurs sentinelles du programme et codes de sortie associés, afin que
+ * les scripts d'automatisation puissent distinguer les issues d'une exécution.
+ */
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"sync"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// Codes de sortie du programme.
+const (
+	exitOK           = 0 // Recherche complète.
+	exitFailure      = 1 // Erreur non classée.
+	exitInvalidFlags = 2 // Options invalides (même code que le paquet flag).
+	exitOverflow     = 3 // Débordement de n détecté.
+	exitInterrupted  = 4 // Recherche interrompue; les résultats affichés sont partiels.
+	exitVerification = 5 // Vérification indépendante, signature ou diff en échec.
+	exitIO           = 6 // Erreur d'entrée/sortie (écriture des résultats, écoute réseau...).
+	exitMemory       = 7 // L'estimation mémoire dépasse le budget fixé par -max-memory.
+	exitInvalidInput = 8 // Données d'entrée invalides (liste de -primes-file, clé ou signature).
+)
+
+// Erreurs sentinelles, à envelopper avec fmt.Errorf("...: %w", err) pour conserver le contexte.
+var (
+	errInvalidFlags error = sentinelError(msgErrInvalidFlags)
+	errInterrupted  error = sentinelError(msgErrInterrupted)
+	errVerification error = sentinelError(msgErrVerification)
+	errIO           error = sentinelError(msgErrIO)
+	errMemoryBudget error = sentinelError(msgErrMemoryBudget)
+	errInvalidInput error = sentinelError(msgErrInvalidInput)
+)
+
+// sentinelError est une erreur sentinelle dont le texte vient du catalogue, traduit dans la
+// langue active quand il est lu: à l'enveloppement par fmt.Errorf ou à l'affichage, après le
+// choix de la langue par -lang.
+type sentinelError msgID
+
+func (e sentinelError) Error() string { return tr(msgID(e)) }
+
+// exitCode associe une erreur retournée par run à un code de sortie.
+func exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errInvalidFlags), errors.Is(err, primes.ErrInvalidOptions):
+		return exitInvalidFlags
+	case errors.Is(err, primes.ErrOverflow):
+		return exitOverflow
+	case errors.Is(err, errInterrupted):
+		return exitInterrupted
+	case errors.Is(err, errVerification):
+		return exitVerification
+	case errors.Is(err, errIO):
+		return exitIO
+	case errors.Is(err, errMemoryBudget):
+		return exitMemory
+	case errors.Is(err, errInvalidInput):
+		return exitInvalidInput
+	default:
+		return exitFailure
+	}
+}
+
+// errWriter enveloppe un io.Writer et mémorise la première erreur d'écriture,
+// ce qui évite de vérifier chaque appel à fmt.Fprint dans les boucles d'affichage.
+// Les écritures sont sérialisées: la ligne de statistiques périodique (-stats-interval)
+// partage la sortie standard avec le tableau des résultats.
+type errWriter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
+
+// Err retourne la première erreur d'écriture.
+func (ew *errWriter) Err() error {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+	return ew.err
+}
@@ -0,0 +1,120 @@
+/*
+ * Fichier: errors_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des erreurs sentinelles et des codes de sortie retournés par run.
+ */
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// failingWriter simule une sortie fermée.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("sortie fermée") }
+
+// TestExitCode valide l'association erreur -> code de sortie, y compris pour des erreurs enveloppées.
+func TestExitCode(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected int
+	}{
+		{nil, exitOK},
+		{fmt.Errorf("contexte: %w", primes.ErrInvalidOptions), exitInvalidFlags},
+		{fmt.Errorf("contexte: %w", errInvalidFlags), exitInvalidFlags},
+		{primes.CheckLimit(primes.MaxLimit + 1), exitOverflow},
+		{errInterrupted, exitInterrupted},
+		{fmt.Errorf("contexte: %w", errVerification), exitVerification},
+		{fmt.Errorf("contexte: %w", errIO), exitIO},
+		{fmt.Errorf("%w: 4 n'est pas premier", errInvalidInput), exitInvalidInput},
+		{errors.New("autre"), exitFailure},
+	}
+
+	for _, tc := range testCases {
+		if got := exitCode(tc.err); got != tc.expected {
+			t.Errorf("exitCode(%v) = %d, attendu %d", tc.err, got, tc.expected)
+		}
+	}
+}
+
+// TestSentinelLanguage vérifie que le texte des erreurs sentinelles suit la langue active, et que
+// errors.Is les distingue.
+func TestSentinelLanguage(t *testing.T) {
+	defer setLanguage(defaultLanguage)
+	setLanguage(language.English)
+	if got := fmt.Errorf("%w: -limit=1", errInvalidFlags).Error(); got != "invalid options: -limit=1" {
+		t.Errorf("en: %q", got)
+	}
+	setLanguage(language.French)
+	err := fmt.Errorf("%w: -limit=1", errInvalidFlags)
+	if got := err.Error(); got != "options invalides: -limit=1" {
+		t.Errorf("fr: %q", got)
+	}
+	if errors.Is(err, errInvalidInput) || !errors.Is(err, errInvalidFlags) {
+		t.Error("sentinelles confondues")
+	}
+}
+
+// TestRunExitCodes valide les codes de sortie de bout en bout.
+func TestRunExitCodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		stdout   io.Writer
+		expected int
+	}{
+		{"Succès", []string{"-limit", "30"}, io.Discard, exitOK},
+		{"Succès avec vérification", []string{"-limit", "30", "-verify", "-primetest", "trial"}, io.Discard, exitOK},
+		{"Aide", []string{"-h"}, io.Discard, exitOK},
+		{"Option inconnue", []string{"-nope"}, io.Discard, exitInvalidFlags},
+		{"Algorithme inconnu", []string{"-primetest", "inconnu"}, io.Discard, exitInvalidFlags},
+		{"Accélérateur de référence", []string{"-limit", "30", "-accel", "cpu", "-verify"}, io.Discard, exitOK},
+		{"Accélérateur inconnu", []string{"-accel", "fpga"}, io.Discard, exitInvalidFlags},
+		{"Test adaptatif", []string{"-limit", "30", "-error-bound", "1e-20", "-verify"}, io.Discard, exitOK},
+		{"Borne d'erreur invalide", []string{"-error-bound", "1"}, io.Discard, exitInvalidFlags},
+		{"Borne d'erreur sans test adaptatif", []string{"-error-bound", "1e-20", "-primetest", "trial"}, io.Discard, exitInvalidFlags},
+		{"Bridage CPU", []string{"-limit", "30", "-cpu-percent", "50"}, io.Discard, exitOK},
+		{"Bridage CPU invalide", []string{"-cpu-percent", "0"}, io.Discard, exitInvalidFlags},
+		{"Tampon de tâches invalide", []string{"-jobs-buffer", "-1"}, io.Discard, exitInvalidFlags},
+		{"Quota CPU invalide", []string{"-cpu-quota", "x"}, io.Discard, exitInvalidFlags},
+		{"Tampon de résultats invalide", []string{"-results-buffer", "-1"}, io.Discard, exitInvalidFlags},
+		{"Analyse des composés", []string{"-limit", "30", "-explain-composites", "5"}, io.Discard, exitOK},
+		{"Analyse des composés invalide", []string{"-explain-composites", "-1"}, io.Discard, exitInvalidFlags},
+		{"Débordement", []string{"-limit", "2000000000"}, io.Discard, exitOverflow},
+		{"Sortie fermée", []string{"-limit", "30"}, failingWriter{}, exitIO},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := run(tc.args, tc.stdout, io.Discard)
+			if got := exitCode(err); got != tc.expected {
+				t.Errorf("run(%v) -> code %d (%v), attendu %d", tc.args, got, err, tc.expected)
+			}
+		})
+	}
+}
+
+// TestVerifyResult valide la revérification indépendante d'un résultat.
+func TestVerifyResult(t *testing.T) {
+	if err := verifyResult(primes.Result{P: 5, Q: 2, N: 41}, primes.DefaultForm, nil, "miller"); err != nil {
+		t.Errorf("résultat valide rejeté: %v", err)
+	}
+	if err := verifyResult(primes.Result{P: 5, Q: 2, N: 43}, primes.DefaultForm, nil, "miller"); !errors.Is(err, errVerification) {
+		t.Errorf("n incohérent accepté: %v", err)
+	}
+	// 3^2 + 4*3^2 = 45 n'est pas premier.
+	if err := verifyResult(primes.Result{P: 3, Q: 3, N: 45}, primes.DefaultForm, nil, "trial"); !errors.Is(err, errVerification) || !strings.Contains(err.Error(), "45") {
+		t.Errorf("n composé accepté: %v", err)
+	}
+}
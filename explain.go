@@ -0,0 +1,77 @@
+/*
+ * Fichier: explain.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Option -explain: affichage pas à pas du test de Miller-Rabin (décomposition
+ * n - 1 = 2^s·d, bases essayées, suite des carrés et issue de chaque tour),
+ * pour un candidat donné ou pour chaque résultat d'une petite recherche.
+ * Destinée à l'enseignement.
+ */
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// explainResults est la valeur de -explain qui trace chaque résultat de la recherche.
+const explainResults = "results"
+
+// explainMaxLimit est la plus grande limite acceptée avec -explain results: la trace compte
+// une dizaine de lignes par résultat.
+const explainMaxLimit = 1000
+
+// parseExplainCandidate lit le candidat de -explain.
+func parseExplainCandidate(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: -explain=%q (attendu un entier ou %q)", errInvalidFlags, s, explainResults)
+	}
+	return n, nil
+}
+
+// formatMillerRabinTrace met en forme la trace du test de Miller-Rabin de n.
+func formatMillerRabinTrace(trace primes.MillerRabinTrace) string {
+	var b strings.Builder
+	b.WriteString(tr(msgExplainHeader, trace.N))
+	switch {
+	case trace.N < 2:
+		b.WriteString(tr(msgExplainBelowTwo))
+		return b.String()
+	case trace.N == 2 || trace.N == 3:
+		b.WriteString(tr(msgExplainSmallPrime))
+		return b.String()
+	case trace.N%2 == 0:
+		b.WriteString(tr(msgExplainEven))
+		return b.String()
+	}
+	b.WriteString(tr(msgExplainDecomposition, trace.N-1, trace.S, trace.D))
+	for _, round := range trace.Rounds {
+		steps := make([]string, len(round.Powers))
+		for i, x := range round.Powers {
+			if i == 0 {
+				steps[i] = fmt.Sprintf("a^d ≡ %d", x)
+			} else {
+				steps[i] = fmt.Sprintf("a^(2^%d·d) ≡ %d", i, x)
+			}
+		}
+		b.WriteString(tr(msgExplainRound, round.Base, strings.Join(steps, " → ")))
+		switch round.Outcome {
+		case primes.RoundOne:
+			b.WriteString(tr(msgExplainOne))
+		case primes.RoundMinusOne:
+			b.WriteString(tr(msgExplainMinusOne, trace.N-1))
+		case primes.RoundWitness:
+			b.WriteString(tr(msgExplainWitness, round.Base))
+		}
+	}
+	if trace.Prime {
+		b.WriteString(tr(msgExplainPrime, len(trace.Rounds)))
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+/*
+ * Fichier: explain_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'option -explain.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRunExplain valide la trace d'un candidat, celle de chaque résultat et le rejet des options invalides.
+func TestRunExplain(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-explain", "2047", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("-explain 2047: %v", err)
+	}
+	for _, expected := range []string{"n - 1 = 2046 = 2^s · d, s = 1, d = 1023\n", "  base a = 2: a^d ≡ 1\n", "3 est un témoin, n est composé.\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("sortie sans %q:\n%s", expected, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := run([]string{"-limit", "10", "-explain", "results", "-manifest=false", "-lang", "en"}, &out, io.Discard); err != nil {
+		t.Fatalf("-explain results: %v", err)
+	}
+	if got := strings.Count(out.String(), "Miller-Rabin test of n = "); got != 4 {
+		t.Errorf("%d traces, attendu une par résultat (4):\n%s", got, out.String())
+	}
+
+	for _, args := range [][]string{
+		{"-explain", "douze"},
+		{"-explain", "results", "-limit", "5000"},
+		{"-explain", "results", "-tui"},
+	} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ * Fichier: factor.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande factor: décomposition en facteurs premiers d'entiers int64
+ * (primes.Factor, méthode rho de Pollard-Brent).
+ */
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// formatFactors met en forme une décomposition triée, les facteurs répétés étant regroupés
+// en puissances: [2 2 3] donne "2^2 × 3".
+func formatFactors(factors []int64) string {
+	var parts []string
+	for i := 0; i < len(factors); {
+		j := i
+		for j < len(factors) && factors[j] == factors[i] {
+			j++
+		}
+		part := strconv.FormatInt(factors[i], 10)
+		if j-i > 1 {
+			part += "^" + strconv.Itoa(j-i)
+		}
+		parts = append(parts, part)
+		i = j
+	}
+	return strings.Join(parts, " × ")
+}
+
+// runFactor implémente la sous-commande factor.
+func runFactor(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("factor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgFactorUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: factor: au moins une valeur de N est requise", errInvalidFlags)
+	}
+
+	values := make([]int64, fs.NArg())
+	for i, arg := range fs.Args() {
+		n, err := parseCountArg(arg)
+		if err == nil && n < 2 {
+			err = fmt.Errorf("valeur %d < 2", n)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: factor: %v", errInvalidFlags, err)
+		}
+		values[i] = n
+	}
+
+	out := &errWriter{w: stdout}
+	for _, n := range values {
+		fmt.Fprintf(out, "%d = %s\n", n, formatFactors(primes.Factor(n)))
+	}
+	return writeError(out)
+}
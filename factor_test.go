@@ -0,0 +1,50 @@
+/*
+ * Fichier: factor_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande factor.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// TestFormatFactors valide le regroupement des facteurs répétés en puissances.
+func TestFormatFactors(t *testing.T) {
+	testCases := []struct {
+		factors  []int64
+		expected string
+	}{
+		{[]int64{13}, "13"},
+		{[]int64{2, 2, 3}, "2^2 × 3"},
+		{[]int64{7, 7, 73, 127, 337, 92737, 649657}, "7^2 × 73 × 127 × 337 × 92737 × 649657"},
+	}
+	for _, tc := range testCases {
+		if got := formatFactors(tc.factors); got != tc.expected {
+			t.Errorf("formatFactors(%v) = %q, attendu %q", tc.factors, got, tc.expected)
+		}
+	}
+}
+
+// TestRunFactor valide la sous-commande de bout en bout.
+func TestRunFactor(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"factor", "600851475143", "1e6", "97"}, &out, io.Discard); err != nil {
+		t.Fatalf("factor: %v", err)
+	}
+	expected := "600851475143 = 71 × 839 × 1471 × 6857\n1000000 = 2^6 × 5^6\n97 = 97\n"
+	if out.String() != expected {
+		t.Errorf("sortie = %q, attendu %q", out.String(), expected)
+	}
+
+	for _, args := range [][]string{{"factor"}, {"factor", "1"}, {"factor", "abc"}} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Fichier: filelock.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Verrous de fichiers entre exécutions: une campagne chunks n'est traitée
+ * que par une exécution à la fois, et les mises à jour du fichier de records
+ * se succèdent au lieu de s'écraser. Le verrou est exclusif et consultatif,
+ * posé sur un fichier compagnon (NOM.lock) plutôt que sur le fichier protégé,
+ * que les écritures atomiques remplacent par renommage. Il est libéré par
+ * Unlock ou, si l'exécution s'arrête brutalement, par le système à la
+ * fermeture du descripteur: un fichier .lock resté sur le disque ne bloque
+ * rien. L'appel système dépend de la plateforme (flock sous Unix, LockFileEx
+ * sous Windows, voir filelock_unix.go et filelock_windows.go); ailleurs,
+ * aucun verrou n'est posé (fileLocking vaut false).
+ */
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// lockSuffix est le suffixe du fichier compagnon qui porte le verrou.
+const lockSuffix = ".lock"
+
+// errLocked signale un verrou déjà tenu par une autre exécution.
+var errLocked = errors.New("verrou tenu par une autre exécution")
+
+// fileLock est un verrou exclusif tenu sur un fichier jusqu'à Unlock.
+type fileLock struct {
+	f *os.File
+}
+
+// lockFile pose un verrou exclusif sur le fichier compagnon de path (path + lockSuffix), créé
+// s'il n'existe pas. Avec wait, l'appel attend la libération du verrou; sinon, il échoue
+// aussitôt avec errLocked si une autre exécution le tient.
+func lockFile(path string, wait bool) (*fileLock, error) {
+	f, err := os.OpenFile(path+lockSuffix, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFD(f, wait); err != nil {
+		f.Close()
+		if errors.Is(err, errLocked) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("verrou %s: %w", f.Name(), err)
+	}
+	return &fileLock{f: f}, nil
+}
+
+// Unlock libère le verrou.
+func (l *fileLock) Unlock() error {
+	err := unlockFD(l.f)
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
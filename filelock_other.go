@@ -0,0 +1,23 @@
+//go:build !(unix && !aix && !solaris) && !windows
+
+/*
+ * Fichier: filelock_other.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sans flock(2) ni LockFileEx (AIX, Solaris, Plan 9, WebAssembly), aucun
+ * verrou n'est posé: le fichier compagnon est seulement créé.
+ */
+package main
+
+import "os"
+
+// fileLocking indique si lockFile pose réellement un verrou sur cette plateforme.
+const fileLocking = false
+
+// lockFD est sans effet.
+func lockFD(f *os.File, wait bool) error { return nil }
+
+// unlockFD est sans effet.
+func unlockFD(f *os.File) error { return nil }
@@ -0,0 +1,84 @@
+/*
+ * Fichier: filelock_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des verrous de fichiers: exclusion sans attente, attente jusqu'à la
+ * libération, et verrou d'une campagne chunks déjà traitée.
+ */
+package main
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLockFile vérifie qu'un verrou tenu refuse un second verrou sans attente et fait attendre
+// un verrou avec attente jusqu'à sa libération.
+func TestLockFile(t *testing.T) {
+	if !fileLocking {
+		t.Skip("aucun verrou de fichier sur cette plateforme")
+	}
+	path := filepath.Join(t.TempDir(), "records.json")
+	lock, err := lockFile(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lockFile(path, false); !errors.Is(err, errLocked) {
+		t.Fatalf("second verrou: %v, attendu errLocked", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		l, err := lockFile(path, true)
+		if err == nil {
+			err = l.Unlock()
+		}
+		acquired <- err
+	}()
+	select {
+	case err := <-acquired:
+		t.Fatalf("verrou obtenu pendant qu'un autre est tenu: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("verrou non obtenu après la libération")
+	}
+
+	lock, err = lockFile(path, false)
+	if err != nil {
+		t.Fatalf("verrou après libération: %v", err)
+	}
+	lock.Unlock()
+}
+
+// TestChunksLocked vérifie qu'une campagne traitée par une autre exécution est refusée.
+func TestChunksLocked(t *testing.T) {
+	if !fileLocking {
+		t.Skip("aucun verrou de fichier sur cette plateforme")
+	}
+	dir := t.TempDir()
+	lock, err := lockChunkCampaign(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runChunks([]string{"-dir", dir, "-limit", "50", "-chunks", "2"}, io.Discard, io.Discard); exitCode(err) != exitIO {
+		t.Errorf("campagne verrouillée: %v, attendu le code %d", err, exitIO)
+	}
+	lock.Unlock()
+	if err := runChunks([]string{"-dir", dir, "-limit", "50", "-chunks", "2"}, io.Discard, io.Discard); err != nil {
+		t.Errorf("campagne libérée: %v", err)
+	}
+}
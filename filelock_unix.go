@@ -0,0 +1,45 @@
+//go:build unix && !aix && !solaris
+
+/*
+ * Fichier: filelock_unix.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Verrous de fichiers sous Linux, macOS et les BSD: flock(2), attaché au
+ * descripteur ouvert (deux ouvertures du même fichier, même dans un seul
+ * processus, se verrouillent l'une l'autre) et libéré à sa fermeture.
+ */
+package main
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// fileLocking indique si lockFile pose réellement un verrou sur cette plateforme.
+const fileLocking = true
+
+// lockFD verrouille f en exclusivité; sans wait, un verrou déjà tenu donne errLocked.
+func lockFD(f *os.File, wait bool) error {
+	how := syscall.LOCK_EX
+	if !wait {
+		how |= syscall.LOCK_NB
+	}
+	for {
+		err := syscall.Flock(int(f.Fd()), how)
+		switch {
+		case errors.Is(err, syscall.EINTR):
+			continue
+		case errors.Is(err, syscall.EWOULDBLOCK):
+			return errLocked
+		}
+		return err
+	}
+}
+
+// unlockFD libère le verrou de f.
+func unlockFD(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,49 @@
+//go:build unix && !aix && !solaris
+
+/*
+ * Fichier: filelock_unix_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des verrous flock(2): compatibilité avec un flock posé par un autre
+ * programme (flock(1)), et libération à la fermeture du descripteur, comme
+ * à l'arrêt brutal d'une exécution.
+ */
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// TestLockFileFlock vérifie qu'un flock posé hors de lockFile est respecté, et qu'un verrou est
+// libéré à la fermeture de son descripteur sans Unlock.
+func TestLockFileFlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chunks.ckpt")
+	f, err := os.OpenFile(path+lockSuffix, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lockFile(path, false); !errors.Is(err, errLocked) {
+		t.Errorf("verrou posé par flock: %v, attendu errLocked", err)
+	}
+	f.Close()
+
+	lock, err := lockFile(path, false)
+	if err != nil {
+		t.Fatalf("verrou après fermeture: %v", err)
+	}
+	lock.f.Close() // Arrêt brutal: aucun Unlock.
+	lock, err = lockFile(path, false)
+	if err != nil {
+		t.Fatalf("verrou après arrêt brutal: %v", err)
+	}
+	lock.Unlock()
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+/*
+ * Fichier: filelock_windows.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Verrous de fichiers sous Windows: LockFileEx sur le premier octet du
+ * fichier, attaché au handle (deux ouvertures du même fichier se verrouillent
+ * l'une l'autre) et libéré à sa fermeture.
+ */
+package main
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// fileLocking indique si lockFile pose réellement un verrou sur cette plateforme.
+const fileLocking = true
+
+// lockFD verrouille f en exclusivité; sans wait, un verrou déjà tenu donne errLocked.
+func lockFD(f *os.File, wait bool) error {
+	flags := uint32(windows.LOCKFILE_EXCLUSIVE_LOCK)
+	if !wait {
+		flags |= windows.LOCKFILE_FAIL_IMMEDIATELY
+	}
+	err := windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, new(windows.Overlapped))
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errLocked
+	}
+	return err
+}
+
+// unlockFD libère le verrou de f.
+func unlockFD(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, new(windows.Overlapped))
+}
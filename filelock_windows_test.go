@@ -0,0 +1,51 @@
+//go:build windows
+
+/*
+ * Fichier: filelock_windows_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des verrous LockFileEx: compatibilité avec un verrou posé par un
+ * autre programme, et libération à la fermeture du handle, comme à l'arrêt
+ * brutal d'une exécution.
+ */
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+// TestLockFileEx vérifie qu'un verrou LockFileEx posé hors de lockFile est respecté, et qu'un
+// verrou est libéré à la fermeture de son handle sans Unlock.
+func TestLockFileEx(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chunks.ckpt")
+	f, err := os.OpenFile(path+lockSuffix, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags := uint32(windows.LOCKFILE_EXCLUSIVE_LOCK | windows.LOCKFILE_FAIL_IMMEDIATELY)
+	if err := windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, new(windows.Overlapped)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lockFile(path, false); !errors.Is(err, errLocked) {
+		t.Errorf("verrou posé par LockFileEx: %v, attendu errLocked", err)
+	}
+	f.Close()
+
+	lock, err := lockFile(path, false)
+	if err != nil {
+		t.Fatalf("verrou après fermeture: %v", err)
+	}
+	lock.f.Close() // Arrêt brutal: aucun Unlock.
+	lock, err = lockFile(path, false)
+	if err != nil {
+		t.Fatalf("verrou après arrêt brutal: %v", err)
+	}
+	lock.Unlock()
+}
@@ -0,0 +1,42 @@
+/*
+ * Fichier: first.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Option -first: la recherche s'arrête au premier résultat retenu (après
+ * -filter et -where), pour une démonstration ou un test de fumée. Les paires
+ * sont distribuées par couronnes de petits p et q d'abord
+ * (primes.ShellSource), par lots d'une paire, pour que ce résultat arrive au
+ * plus tôt; il est ensuite détaillé: revérification indépendante,
+ * décomposition en somme de deux carrés et trace de Miller-Rabin.
+ */
+package main
+
+import (
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// reportFirst détaille le résultat de -first, reçu after après le début de la recherche: sa
+// revérification indépendante (verifyResult), la trace de Miller-Rabin de n et, pour une forme
+// somme de deux carrés, sa décomposition par Cornacchia. Retourne l'échec de la revérification.
+func reportFirst(status func(string), res primes.Result, after time.Duration, form primes.Form, filter primes.Filter, primeTestAlgorithm string) error {
+	status(tr(msgFirstDetail, res.N, res.P, res.Q, form.Name(), after.Round(time.Microsecond)))
+	if err := verifyResult(res, form, filter, primeTestAlgorithm); err != nil {
+		status(err.Error() + "\n")
+		return err
+	}
+	status(tr(msgFirstVerified, independentTestName(primeTestAlgorithm), form.Name()))
+	if res.Big != nil {
+		return nil // Au-delà d'int64: ni trace ni décomposition sur int64.
+	}
+	if _, ok := squareForms[form.Name()]; ok {
+		if x, y, err := decomposeSquares(res.N); err == nil {
+			status(tr(msgFirstSquares, res.N, x, y))
+		}
+	}
+	status(formatMillerRabinTrace(primes.TraceMillerRabin(res.N)))
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Fichier: first_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'option -first.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRunFirst vérifie que -first ne produit qu'un résultat, le plus petit candidat de la grille
+// par couronnes, détaillé et revérifié, puis que -where et les options incompatibles sont respectées.
+func TestRunFirst(t *testing.T) {
+	var out, status bytes.Buffer
+	if err := run([]string{"-limit", "1000", "-first", "-workers", "2", "-format", "ndjson", "-lang", "fr"}, &out, &status); err != nil {
+		t.Fatalf("-first: %v\n%s", err, status.String())
+	}
+	if got := resultLines(out.String()); got != `{"p":5,"q":2,"n":41}` {
+		t.Errorf("résultats %q, attendu le seul (5, 2, 41)", got)
+	}
+	for _, expected := range []string{
+		"Premier résultat: n = 41 (p=5, q=2, forme p^2+4q^2) après ",
+		"Vérification indépendante (test trial): p, q et n sont premiers et n = p^2+4q^2 pour (p, q): confirmé\n",
+		"Somme de deux carrés (Cornacchia): 41 = 5^2 + 4^2\n",
+		"Test de Miller-Rabin de n = 41\n",
+	} {
+		if !strings.Contains(status.String(), expected) {
+			t.Errorf("statut sans %q:\n%s", expected, status.String())
+		}
+	}
+	if strings.Contains(status.String(), "interrompue") {
+		t.Errorf("arrêt au premier résultat présenté comme une interruption:\n%s", status.String())
+	}
+
+	out.Reset()
+	status.Reset()
+	if err := run([]string{"-limit", "1000", "-first", "-where", "n > 1000", "-format", "ndjson", "-lang", "fr"}, &out, &status); err != nil {
+		t.Fatalf("-first -where: %v", err)
+	}
+	if got := resultLines(out.String()); !strings.HasPrefix(got, "{") || strings.Contains(got, "\n") {
+		t.Errorf("-first -where: résultats %q, attendu un seul", got)
+	}
+
+	status.Reset()
+	if err := run([]string{"-limit", "10", "-first", "-where", "n > 200", "-format", "ndjson", "-lang", "fr"}, io.Discard, &status); err != nil || !strings.Contains(status.String(), "Aucun résultat: -first") {
+		t.Errorf("-first sans résultat retenu: %v\n%s", err, status.String())
+	}
+
+	for _, args := range [][]string{{"-first", "-top", "3"}, {"-first", "-reverse"}, {"-first", "-sort"}} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
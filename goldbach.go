@@ -0,0 +1,53 @@
+/*
+ * Fichier: goldbach.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande goldbach: vérifie que tout nombre pair de 4 à -limit est la
+ * somme de deux nombres premiers (primes.VerifyGoldbach). Un contre-exemple
+ * est signalé comme un échec de vérification.
+ */
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// runGoldbach implémente la sous-commande goldbach.
+func runGoldbach(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("goldbach", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	limitPtr := fs.Int("limit", 1_000_000, tr(msgFlagGoldbachLimit))
+	workersPtr := fs.Int("workers", defaultWorkers(), tr(msgFlagWorkers))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *limitPtr < 4 || *workersPtr < 1 {
+		return fmt.Errorf("%w: goldbach: -limit=%d, -workers=%d (attendu -limit >= 4, -workers >= 1)", errInvalidFlags, *limitPtr, *workersPtr)
+	}
+
+	out := &errWriter{w: stdout}
+	start := time.Now()
+	report := primes.VerifyGoldbach(*limitPtr, *workersPtr)
+	if report.Counterexample != 0 {
+		fmt.Fprint(out, tr(msgGoldbachCounterexample, report.Counterexample))
+		if err := writeError(out); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: goldbach: contre-exemple %d", errVerification, report.Counterexample)
+	}
+	fmt.Fprint(out, tr(msgGoldbachVerified, report.Verified, report.Limit, time.Since(start).Round(time.Millisecond)))
+	fmt.Fprint(out, tr(msgGoldbachHardest, report.MaxMinPrimeAt, report.MaxMinPrime, report.MaxMinPrimeAt-report.MaxMinPrime))
+	return writeError(out)
+}
@@ -0,0 +1,32 @@
+/*
+ * Fichier: goldbach_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande goldbach.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRunGoldbach valide la sous-commande de bout en bout.
+func TestRunGoldbach(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"goldbach", "-limit", "1000", "-workers", "2", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("goldbach -limit 1000: %v", err)
+	}
+	for _, expected := range []string{"les 499 nombres pairs de 4 à 1000", "992 = 73 + 919"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("sortie = %q, attendu %q", out.String(), expected)
+		}
+	}
+	if got := exitCode(run([]string{"goldbach", "-limit", "2"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("-limit 2 -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Fichier: golden_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de non-régression des formats de sortie par fichiers de référence:
+ * chaque cas exécute le programme à une petite limite, avec un seul worker pour
+ * un ordre des résultats déterministe, et compare la sortie (fichier -o ou
+ * sortie standard) à testdata/golden/<cas>.golden. Les champs variables du
+ * manifeste (identifiant, version, hôte, heures) et le répertoire temporaire
+ * sont normalisés. Après un changement de format délibéré, régénérer les
+ * références avec: go test -run TestGolden -update
+ */
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "réécrit les fichiers de référence de testdata/golden")
+
+// Champs du manifeste propres à chaque exécution ou à chaque machine.
+var (
+	goldenTextVolatile = regexp.MustCompile(`(?m)^# (run_id|version|go_version|host|start|end): .*$`)
+	goldenJSONVolatile = regexp.MustCompile(`"(run_id|version|go_version|host|start|end)":"[^"]*"`)
+	goldenCommit       = regexp.MustCompile(`(?m)^# commit: .*\n|"commit":"[^"]*",`)
+)
+
+// normalizeGolden remplace les champs variables du manifeste et le répertoire temporaire dir.
+func normalizeGolden(data []byte, dir string) []byte {
+	data = bytes.ReplaceAll(data, []byte(dir), []byte("$TMP"))
+	data = goldenCommit.ReplaceAll(data, nil)
+	data = goldenTextVolatile.ReplaceAll(data, []byte("# $1: *"))
+	return goldenJSONVolatile.ReplaceAll(data, []byte(`"$1":"*"`))
+}
+
+// TestGolden compare chaque format de sortie à son fichier de référence. Les arguments contenant
+// $OUT désignent le fichier de sortie comparé; sans $OUT, c'est la sortie standard.
+func TestGolden(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{"search-table", []string{"-limit", "30", "-workers", "1", "-twins", "-o", "$OUT"}},
+		{"search-json", []string{"-limit", "30", "-workers", "1", "-twins", "-format", "json", "-o", "$OUT"}},
+		{"search-json-raw", []string{"-limit", "30", "-workers", "1", "-format", "json", "-manifest=false", "-o", "$OUT"}},
+		{"search-form", []string{"-limit", "20", "-workers", "1", "-form", "x^2+1", "-filter", "safe", "-o", "$OUT"}},
+		{"list-primes-txt", []string{"list-primes", "-limit", "50", "-o", "$OUT"}},
+		{"list-primes-json", []string{"list-primes", "-limit", "50", "-format", "json", "-o", "$OUT"}},
+		{"list-primes-binary", []string{"list-primes", "-limit", "50", "-format", "binary", "-o", "$OUT"}},
+		{"min-q-table", []string{"min-q", "-limit", "30", "-workers", "1", "-o", "$OUT"}},
+		{"min-q-json", []string{"min-q", "-limit", "30", "-workers", "1", "-format", "json", "-o", "$OUT"}},
+		{"factor", []string{"factor", "600851475143", "1024", "97"}},
+		{"count-primes", []string{"count-primes", "1e6", "100"}},
+		{"nth-prime", []string{"nth-prime", "1", "1000"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			outPath := filepath.Join(dir, tc.name+".out")
+			// -lang suit le nom de l'éventuelle sous-commande.
+			at := 0
+			if !strings.HasPrefix(tc.args[0], "-") {
+				at = 1
+			}
+			args := slices.Insert(slices.Clone(tc.args), at, "-lang", "fr")
+			useFile := slices.Contains(args, "$OUT")
+			if i := slices.Index(args, "$OUT"); i >= 0 {
+				args[i] = outPath
+			}
+
+			var stdout bytes.Buffer
+			if err := run(args, &stdout, io.Discard); err != nil {
+				t.Fatalf("run(%v): %v", args, err)
+			}
+			got := stdout.Bytes()
+			if useFile {
+				var err error
+				if got, err = os.ReadFile(outPath); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got = normalizeGolden(got, dir)
+
+			goldenPath := filepath.Join("testdata", "golden", tc.name+".golden")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("référence absente (go test -run TestGolden -update): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("sortie différente de %s (go test -run TestGolden -update si le changement est voulu):\n--- obtenu\n%s\n--- attendu\n%s", goldenPath, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Fichier: listprimes.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande list-primes: exécute seulement le crible d'Eratosthène et
+ * écrit les nombres premiers jusqu'à la limite, sans la recherche des paires,
+ * au format texte (un par ligne), JSON (tableau) ou binaire (uint32 petit-boutiste).
+ * Les formats texte et JSON portent le manifeste de l'exécution (manifest.go),
+ * sauf avec -manifest=false; le format binaire reste brut.
+ */
+package main
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// primeFormats sont les formats de sortie acceptés par list-primes.
+var primeFormats = []string{"txt", "json", "binary"}
+
+// writePrimes écrit primeList sur w au format donné ("txt", "json" ou "binary"). Un manifeste
+// non nil précède la liste en commentaires (txt) ou l'enveloppe dans un objet (json).
+func writePrimes(w io.Writer, primeList []int, format string, manifest *runManifest) error {
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	switch format {
+	case "txt":
+		if manifest != nil {
+			manifest.writeHeader(bw, true)
+		}
+		for _, p := range primeList {
+			buf = strconv.AppendInt(buf[:0], int64(p), 10)
+			buf = append(buf, '\n')
+			bw.Write(buf)
+		}
+		if manifest != nil {
+			manifest.writeTrailer(bw)
+		}
+	case "json":
+		if manifest != nil {
+			manifest.finish()
+			header, err := json.Marshal(manifest)
+			if err != nil {
+				return err
+			}
+			bw.WriteString(`{"manifest":`)
+			bw.Write(header)
+			bw.WriteString(`,"primes":`)
+		}
+		bw.WriteByte('[')
+		for i, p := range primeList {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			bw.Write(strconv.AppendInt(buf[:0], int64(p), 10))
+		}
+		bw.WriteByte(']')
+		if manifest != nil {
+			bw.WriteByte('}')
+		}
+		bw.WriteByte('\n')
+	case "binary":
+		for _, p := range primeList {
+			bw.Write(binary.LittleEndian.AppendUint32(buf[:0], uint32(p)))
+		}
+	default:
+		return fmt.Errorf("format inconnu %q", format)
+	}
+	return bw.Flush()
+}
+
+// runListPrimes implémente la sous-commande list-primes.
+func runListPrimes(args []string, stdout, stderr io.Writer) (err error) {
+	start := time.Now()
+	fs := flag.NewFlagSet("list-primes", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	limitPtr := fs.Int("limit", 1000, tr(msgFlagListLimit))
+	formatPtr := fs.String("format", "txt", tr(msgFlagListFormat))
+	outputPtr := fs.String("o", "", tr(msgFlagListOutput))
+	manifestPtr := fs.Bool("manifest", true, tr(msgFlagManifest))
+	signPtr := fs.String("sign", "", tr(msgFlagSign))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if !slices.Contains(primeFormats, *formatPtr) {
+		return fmt.Errorf("%w: -format=%q (attendu %v)", errInvalidFlags, *formatPtr, primeFormats)
+	}
+	if *limitPtr < 0 || uint64(*limitPtr) > math.MaxUint32 {
+		return fmt.Errorf("%w: -limit=%d (attendu entre 0 et %d)", errInvalidFlags, *limitPtr, uint64(math.MaxUint32))
+	}
+
+	var signingKey ed25519.PrivateKey
+	if *signPtr != "" {
+		if *outputPtr == "" {
+			return fmt.Errorf("%w: -sign exige -o", errInvalidFlags)
+		}
+		if signingKey, err = loadSigningKey(*signPtr); err != nil {
+			return err
+		}
+	}
+
+	var w io.Writer = stdout
+	if *outputPtr != "" {
+		f, err := os.Create(*outputPtr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("%w: %v", errIO, cerr)
+			}
+			if err == nil && signingKey != nil {
+				err = signFile(*outputPtr, signingKey)
+			}
+		}()
+		w = f
+	}
+
+	var manifest *runManifest
+	if *manifestPtr {
+		manifest = newManifest("list-primes", args, fs, map[string]string{"sieve": "eratosthenes"}, start)
+	}
+	if err := writePrimes(w, primes.SieveOfEratosthenes(*limitPtr), *formatPtr, manifest); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Fichier: listprimes_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande list-primes et de ses formats de sortie.
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestWritePrimes valide les trois formats de sortie.
+func TestWritePrimes(t *testing.T) {
+	testCases := []struct {
+		format   string
+		expected []byte
+	}{
+		{"txt", []byte("2\n3\n5\n7\n")},
+		{"json", []byte("[2,3,5,7]\n")},
+		{"binary", binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(
+			binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, 2), 3), 5), 7)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writePrimes(&buf, []int{2, 3, 5, 7}, tc.format, nil); err != nil {
+				t.Fatalf("writePrimes: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tc.expected) {
+				t.Errorf("sortie = %q, attendu %q", buf.Bytes(), tc.expected)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := writePrimes(&buf, nil, "json", nil); err != nil || buf.String() != "[]\n" {
+		t.Errorf("liste vide en JSON = %q (%v), attendu \"[]\\n\"", buf.String(), err)
+	}
+}
+
+// TestRunListPrimes valide la sous-commande de bout en bout, vers un fichier et en cas d'options invalides.
+func TestRunListPrimes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "primes.txt")
+	if err := run([]string{"list-primes", "-limit", "30", "-o", path}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("list-primes: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("# run_id: ")) || !bytes.Contains(data, []byte("\n# param.limit: 30\n")) || !bytes.Contains(data, []byte("\n# end: ")) {
+		t.Errorf("manifeste absent ou incomplet: %q", data)
+	}
+	// Le fichier, manifeste compris, se relit tel quel avec -primes-file.
+	if got, err := readPrimesFile(path, "auto"); err != nil || !slices.Equal(got, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}) {
+		t.Errorf("relecture = %v (%v)", got, err)
+	}
+
+	if err := run([]string{"list-primes", "-limit", "30", "-o", path, "-manifest=false"}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("list-primes -manifest=false: %v", err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2\n3\n5\n7\n11\n13\n17\n19\n23\n29\n"; string(data) != want {
+		t.Errorf("fichier = %q, attendu %q", data, want)
+	}
+
+	if got := exitCode(run([]string{"list-primes", "-format", "xml"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("format inconnu -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+	if got := exitCode(run([]string{"list-primes", "-limit", "-1"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("limite négative -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Fichier: logfile.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Fichier journal avec rotation par taille et par âge (option -log-file),
+ * pour les longues campagnes. Le fichier courant est renommé avec un suffixe
+ * horodaté lors de la rotation et seules les sauvegardes les plus récentes
+ * sont conservées. Les résultats ne passent pas par le journal.
+ */
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rotationTimeFormat est le suffixe horodaté des fichiers journaux archivés.
+const rotationTimeFormat = "20060102-150405.000"
+
+// rotatingFile est un io.Writer qui écrit dans un fichier et le fait tourner
+// lorsqu'il dépasse maxSize octets ou maxAge d'ancienneté (0 désactive le critère).
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int // Nombre de fichiers archivés conservés (0: tous).
+	now        func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// openRotatingFile ouvre (en ajout) le fichier journal et prépare sa rotation.
+func openRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*rotatingFile, error) {
+	rf := &rotatingFile{path: path, maxSize: maxSize, maxAge: maxAge, maxBackups: maxBackups, now: time.Now}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// open ouvre le fichier courant; un fichier existant est repris là où il s'était arrêté.
+func (rf *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(rf.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rf.file, rf.size, rf.openedAt = f, info.Size(), rf.now()
+	return nil
+}
+
+// Write écrit p dans le fichier courant, après rotation si nécessaire.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.needsRotation(int64(len(p))) {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// needsRotation indique si l'écriture de n octets supplémentaires impose une rotation.
+// Un fichier vide n'est jamais tourné, pour qu'une écriture plus grande que maxSize reste possible.
+func (rf *rotatingFile) needsRotation(n int64) bool {
+	if rf.size == 0 {
+		return false
+	}
+	if rf.maxSize > 0 && rf.size+n > rf.maxSize {
+		return true
+	}
+	return rf.maxAge > 0 && rf.now().Sub(rf.openedAt) >= rf.maxAge
+}
+
+// rotate archive le fichier courant sous un nom horodaté, en ouvre un nouveau
+// et supprime les archives excédentaires.
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+	archive := fmt.Sprintf("%s.%s", rf.path, rf.now().Format(rotationTimeFormat))
+	if err := os.Rename(rf.path, archive); err != nil {
+		return err
+	}
+	if err := rf.open(); err != nil {
+		return err
+	}
+	return rf.prune()
+}
+
+// prune supprime les archives les plus anciennes au-delà de maxBackups.
+func (rf *rotatingFile) prune() error {
+	if rf.maxBackups <= 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(rf.path + ".*")
+	if err != nil {
+		return err
+	}
+	// Le suffixe horodaté se trie chronologiquement.
+	sort.Strings(backups)
+	for len(backups) > rf.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// Close ferme le fichier courant.
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Close()
+}
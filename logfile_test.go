@@ -0,0 +1,118 @@
+/*
+ * Fichier: logfile_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la rotation du fichier journal par taille, par âge et de la purge des archives.
+ */
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeClock fournit une horloge contrôlée par le test.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+// newTestRotatingFile ouvre un journal dans un répertoire temporaire avec une horloge contrôlée.
+func newTestRotatingFile(t *testing.T, maxSize int64, maxAge time.Duration, maxBackups int) (*rotatingFile, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{t: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	rf := &rotatingFile{path: filepath.Join(t.TempDir(), "logs", "run.log"), maxSize: maxSize, maxAge: maxAge, maxBackups: maxBackups, now: clock.now}
+	if err := rf.open(); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { rf.Close() })
+	return rf, clock
+}
+
+// backups retourne les fichiers archivés du journal.
+func backups(t *testing.T, rf *rotatingFile) []string {
+	t.Helper()
+	files, err := filepath.Glob(rf.path + ".*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// TestRotatingFileSize valide la rotation lorsque la taille maximale serait dépassée.
+func TestRotatingFileSize(t *testing.T) {
+	rf, clock := newTestRotatingFile(t, 10, 0, 0)
+
+	rf.Write([]byte("12345678"))
+	clock.t = clock.t.Add(time.Second)
+	rf.Write([]byte("abcd")) // 8 + 4 > 10: rotation avant l'écriture.
+
+	if n := len(backups(t, rf)); n != 1 {
+		t.Fatalf("%d archives, attendu 1", n)
+	}
+	data, _ := os.ReadFile(rf.path)
+	if string(data) != "abcd" {
+		t.Errorf("journal courant = %q, attendu %q", data, "abcd")
+	}
+}
+
+// TestRotatingFileAge valide la rotation lorsque le journal courant est trop ancien.
+func TestRotatingFileAge(t *testing.T) {
+	rf, clock := newTestRotatingFile(t, 0, time.Hour, 0)
+
+	rf.Write([]byte("a"))
+	clock.t = clock.t.Add(30 * time.Minute)
+	rf.Write([]byte("b"))
+	if n := len(backups(t, rf)); n != 0 {
+		t.Fatalf("%d archives avant l'âge maximal, attendu 0", n)
+	}
+	clock.t = clock.t.Add(31 * time.Minute)
+	rf.Write([]byte("c"))
+	if n := len(backups(t, rf)); n != 1 {
+		t.Errorf("%d archives après l'âge maximal, attendu 1", n)
+	}
+}
+
+// TestRotatingFilePrune valide que seules les maxBackups archives les plus récentes sont conservées.
+func TestRotatingFilePrune(t *testing.T) {
+	rf, clock := newTestRotatingFile(t, 1, 0, 2)
+
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		rf.Write([]byte(s))
+		clock.t = clock.t.Add(time.Second)
+	}
+
+	files := backups(t, rf)
+	if len(files) != 2 {
+		t.Fatalf("%d archives, attendu 2", len(files))
+	}
+	// Les archives restantes sont les deux plus récentes: "c" et "d".
+	for i, expected := range []string{"c", "d"} {
+		data, _ := os.ReadFile(files[i])
+		if string(data) != expected {
+			t.Errorf("archive %d = %q, attendu %q", i, data, expected)
+		}
+	}
+}
+
+// TestRotatingFileReopen valide qu'un journal existant est complété et non écrasé.
+func TestRotatingFileReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	if err := os.WriteFile(path, []byte("ancien\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rf, err := openRotatingFile(path, 1<<20, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rf.Write([]byte("nouveau\n"))
+	rf.Close()
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "ancien\nnouveau\n" {
+		t.Errorf("journal = %q", data)
+	}
+}
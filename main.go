@@ -13,259 +13,1530 @@
  * où 'p' et 'q' sont eux-mêmes des nombres premiers.
  *
  * Architecture de la solution:
+ * - Le cœur (crible, tests de primalité, recherche des paires) est dans le paquet
+ * primes, partagé par la CLI et par la cible WebAssembly (cmd/wasm).
  * - Utilisation d'un crible d'Eratosthène pour la génération efficace des nombres premiers initiaux.
  * - Implémentation d'un pool de workers (Worker Pool) avec des goroutines pour paralléliser
  * la recherche et tirer parti des processeurs multi-cœurs.
  * - Utilisation de canaux (channels) pour la distribution des tâches et la collecte des résultats
  * de manière concurrente et sécurisée.
+ * - Forme évaluée configurable (-form): p^2 + 4q^2 par défaut, ou toute forme enregistrée
+ * dans le paquet primes (interface primes.Form).
+ * - Région de la grille (p, q) énumérée (-pairs): grille complète, triangle p < q ou p <= q, p != q, diagonale.
+ * - Politique de débordement (-on-overflow): limite refusée, paires ignorées et comptées,
+ * candidats promus (sur uint64 jusqu'à 2^64, math/big au-delà), ou limite repoussée
+ * jusqu'à 2^64 avec des candidats testés sur uint64.
+ * - Filtres optionnels sur les résultats (-filter): nombres de Sophie Germain, nombres premiers sûrs.
+ * - Détection optionnelle des nombres premiers jumeaux (-twins) parmi les n trouvés.
+ * - Horodatage optionnel des résultats et durée du test de chaque candidat (-timing).
+ * - Préréglages quick, thorough et publication (-preset), surchargés par les options explicites.
+ * - Dédoublonnage optionnel des valeurs de n, par table de hachage ou bitmap roaring (-dedup).
+ * - Sortie triée par n au-delà de la mémoire, par tri externe avec fichiers temporaires (-sort).
+ * - Contrôle par sondage (-spot-check): revérification d'un échantillon aléatoire des résultats.
+ * - Arrêt au premier résultat (-first), petits p et q d'abord, détaillé et revérifié.
+ * - Recherche inverse (-reverse): nombres premiers n criblés puis décomposés par Cornacchia.
  * - Utilisation de types int64 et du paquet math/big pour garantir l'exactitude avec de grands nombres.
+ * - Tableau de bord web embarqué optionnel (-dashboard) alimenté par un flux Server-Sent Events.
+ * - Interface terminal interactive optionnelle (-tui) avec pause, reprise et arrêt.
+ * - Suspension et reprise par signaux (SIGUSR1/SIGUSR2) et socket d'état local
+ * (-status-socket) interrogé par la sous-commande status, qui peut aussi demander un instantané
+ * des résultats partiels et de la progression (status -snapshot) sans arrêter la recherche.
+ * - Import optionnel d'une liste externe de nombres premiers (-primes-file) à la place du crible.
+ * - Cache persistant optionnel des nombres premiers (-primes-cache), projeté en mémoire.
+ * - Emplacements par défaut propres à chaque système (valeur auto de -primes-cache et
+ * -status-socket) et verrous de fichiers entre exécutions (campagnes chunks, records).
+ * - Sous-commande list-primes exposant directement le crible (texte, JSON ou binaire).
+ * - Sous-commande count-primes calculant π(x) par l'algorithme LMO (Lagarias-Miller-Odlyzko), sans énumération.
+ * - Sous-commande factor (décomposition en facteurs premiers, méthode rho de Pollard-Brent).
+ * - Sous-commande nth-prime (n-ième nombre premier, via primes.NthPrime).
+ * - Sous-commande range (nombres premiers d'un intervalle [A, B] jusqu'à 2^64 - 1, via primes.Range).
+ * - Sous-commande decompose (somme de deux carrés par Cornacchia, vérification structurelle des résultats).
+ * - Sous-commande mersenne (test de Lucas-Lehmer de 2^P - 1).
+ * - Sous-commande primorial: primorielles N#, factorielles N! et nombres premiers N# ± 1, N! ± 1.
+ * - Analyse optionnelle des valeurs de n composées (-explain-composites): plus petit facteur premier.
+ * - Miller-Rabin adaptatif (-primetest adaptive, -error-bound): bases choisies selon la taille de n.
+ * - Bases aléatoires tirées de crypto/rand au-delà de 2^64 (-witness-source crypto).
+ * - Comparaison de tests de primalité sur les candidats de la recherche (-compare): accord et temps.
+ * - Accélérateur optionnel (-accel): marquage du crible et pré-filtre des candidats par petits
+ *   nombres premiers déchargés, sur GPU OpenCL avec l'étiquette de build gpu (paquet primes/gpu).
+ * - Trace pédagogique du test de Miller-Rabin (-explain): d'un candidat, ou de chaque résultat à petite limite.
+ * - Fichier de records optionnel (-records): plus grand n trouvé par forme, d'une exécution à l'autre.
+ * - Sous-commande goldbach (vérification de la conjecture de Goldbach jusqu'à une limite).
+ * - Sous-commande pseudoprimes (composés passant les tests de Fermat, Euler-Jacobi ou Miller fort).
+ * - Sous-commande analyze bias: biais de Tchebychev entre classes de résidus des nombres premiers du crible.
+ * - Sous-commande analyze ap: progressions arithmétiques parmi les valeurs de n trouvées.
+ * - Sous-commande analyze unrepresented: nombres premiers ≡ 1 mod 4 absents des résultats de p^2 + 4q^2.
+ * - Sous-commande chunks: campagne découpée en tranches de p reprenables, vérifiables une à une,
+ *   dont le manifeste est un point de reprise binaire versionné protégé par CRC.
+ * - Sous-commande serve: API REST des recherches, file avec plafond de recherches simultanées et
+ *   budget de workers par recherche, état et résultats dans une base embarquée (bbolt), résultats
+ *   paginés par curseur et filtrés par intervalle de n, baux persistés des workers distants,
+ *   jetons d'accès avec débit et recherches actives limités par jeton, détection des soumissions
+ *   identiques par empreinte des paramètres, recherches récurrentes planifiées (cron).
+ * - Sous-commande client: soumission, suivi, téléchargement des résultats et annulation des
+ *   recherches d'un serveur; worker distant (client work).
+ * - Sous-commande min-q: plus petit q donnant un n premier pour chaque p (tableau ou JSON).
+ * - Intégration systemd (sd_notify): READY=1 après le crible, WATCHDOG=1 depuis la collecte.
+ * - Budget mémoire optionnel (-max-memory): estimation préalable et ajustement de la file des tâches.
+ * - Réglage automatique optionnel (-autotune) du nombre de workers et de la taille des lots.
+ * - Taille des lots adaptative (-batch-target) d'après la durée observée des lots.
+ * - Chien de garde des workers (-stall-timeout): candidat bloqué signalé, abandonné avec -skip-stalled.
+ * - Capacités des canaux adaptées aux workers et aux lots, ou fixées (-jobs-buffer, -results-buffer).
+ * - Workers par défaut bornés par le quota CPU du cgroup (-cpu-quota).
+ * - Bridage CPU optionnel (-cpu-percent, -nice) pour une exécution en arrière-plan.
+ * - Manifeste d'exécution (identifiant, version, paramètres, algorithmes) joint aux sorties.
+ * - Fichier de résultats optionnel (-o), signé avec -sign (SHA-256 et ed25519, voir
+ * signature.go) et contrôlé par la sous-commande verify-signature.
+ * - Balayage de plusieurs limites en une exécution (-sweep): tableau des comptes N(x).
+ * - Répartition des résultats par classe de résidus (-residues).
+ * - Estimation de Monte-Carlo de la densité pour les très grandes limites (-sample, -seed).
+ * - Rapport HTML autonome optionnel (-report): manifeste, résumé, graphiques SVG, tableau paginé.
+ * - Statistiques par worker dans le résumé (lots, paires, résultats, temps d'occupation, déséquilibre).
+ * - Ligne de statistiques périodique optionnelle (-stats-interval): débit, résultats, avancement, mémoire.
+ * - Fichier d'options (-config) relu sur SIGHUP: niveau du journal (-log-level), ligne de statistiques
+ *   et bridage CPU modifiés sans redémarrer.
+ * - Résultats par décade de n dans la ligne de statistiques et le résumé.
+ * - Série temporelle optionnelle du rythme de découverte (-timeseries), en CSV ou JSON.
+ * - Bilan de l'exécution pour l'intégration continue (-summary-out en JSON, -summary-junit).
+ * - Résultats au format tableau, JSON ou NDJSON (-format), comparables par la sous-commande diff.
+ * - Formats CSV et pbz (protobuf délimité compressé par Zstandard), sous-commande convert entre formats.
+ * - Format Parquet; convert de tout format lisible vers tout format de sortie, par les destinations -sink.
+ * - Vérification de paires (p, q) fournies par un tiers (sous-commande check).
+ * - Certificats de primalité ECPP des n au-delà de 64 bits, vérifiables sans le prouveur (sous-commande certify).
+ * - Test de candidats lus sur l'entrée standard par le pool de workers (sous-commande stream).
+ * - Chiffres groupés selon la langue dans le tableau et le résumé, ou suffixes SI (-numbers).
+ * - Tableau aux colonnes dimensionnées d'après les données, en couleurs sur un terminal (-color).
+ * - Destinations supplémentaires des résultats (-sink): fichiers ou TCP, en échec indépendamment.
+ * - Greffons tiers (-plugin): formes et destinations fournies par un sous-processus (protocole JSON).
+ * - Filtre des résultats écrits par une expression sur p, q, n et twin (-where).
+ * - Validation croisée des options avant tout travail, avec diagnostics (limite, paires, bornes de n).
+ * - K premiers résultats d'un classement, gardés dans un tas et écrits en fin de recherche (-top, -by).
+ * - Codes de sortie distincts par type d'échec (voir errors.go) et arrêt propre sur Ctrl+C.
+ * - Messages traduits en anglais et en français (option -lang ou variables LANG/LC_*).
  */
 package main
 
 import (
+	"cmp"
+	"context"
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"log"
 	"math"
 	"math/big"
+	"math/rand/v2"
+	"os"
+	"os/signal"
 	"runtime"
-	"sync"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/PrimeNumber/primes"
+	_ "github.com/agbru/PrimeNumber/primes/gpu" // Accélérateur "gpu" (-accel), disponible avec -tags gpu.
 )
 
-// Job représente une tâche à effectuer par un worker: une paire (p, q) à tester.
-type Job struct {
-	p int
-	q int
-}
+// dedupStructures sont les structures d'ensemble acceptées par -dedup.
+var dedupStructures = []string{"none", "hash", "roaring", "sort"}
 
-// Result représente un résultat positif trouvé par un worker.
-// Le type de 'n' est int64 pour éviter les débordements (overflows).
-type Result struct {
-	p int
-	q int
-	n int64
-}
+// witnessSources sont les sources des bases aléatoires de Miller-Rabin acceptées par -witness-source.
+var witnessSources = []string{"default", "crypto"}
 
-// sieveOfEratosthenes génère tous les nombres premiers jusqu'à une limite donnée.
-// C'est une méthode beaucoup plus efficace que des tests de primalité individuels.
-func sieveOfEratosthenes(limit int) []int {
-	// Ajout d'une validation pour gérer les cas limites (négatifs, 0, 1)
-	// et prévenir les erreurs "index out of range".
-	if limit < 2 {
-		return nil
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprint(os.Stderr, tr(msgError, err))
 	}
+	os.Exit(exitCode(err))
+}
 
-	// Initialise un tableau de booléens pour marquer les nombres.
-	// `primes[i]` sera `true` si `i` n'est pas premier.
-	primesMarker := make([]bool, limit+1)
-	primesMarker[0], primesMarker[1] = true, true // 0 et 1 ne sont pas premiers.
+// run exécute le programme avec les arguments donnés et retourne une erreur
+// enveloppant l'une des erreurs sentinelles de errors.go en cas d'échec.
+func run(args []string, stdout, stderr io.Writer) (err error) {
+	startTime := time.Now()
+	out := &errWriter{w: stdout}
 
-	// Algorithme du crible.
-	for p := 2; p*p <= limit; p++ {
-		if !primesMarker[p] { // Si p est premier...
-			for i := p * p; i <= limit; i += p {
-				primesMarker[i] = true // ...marquer tous ses multiples comme non premiers.
-			}
+	// --- Langue des messages ---
+	// L'option -lang est lue avant l'analyse des options pour que leur aide soit elle aussi traduite.
+	langArg := langFromArgs(args)
+	setLanguage(selectLanguage(langArg, os.Getenv))
+
+	// --- Sous-commandes ---
+	if len(args) > 0 {
+		switch args[0] {
+		case "status":
+			return runStatus(args[1:], stdout, stderr)
+		case "list-primes":
+			return runListPrimes(args[1:], stdout, stderr)
+		case "count-primes":
+			return runCountPrimes(args[1:], stdout, stderr)
+		case "factor":
+			return runFactor(args[1:], stdout, stderr)
+		case "nth-prime":
+			return runNthPrime(args[1:], stdout, stderr)
+		case "range":
+			return runRange(args[1:], stdout, stderr)
+		case "decompose":
+			return runDecompose(args[1:], stdout, stderr)
+		case "mersenne":
+			return runMersenne(args[1:], stdout, stderr)
+		case "primorial":
+			return runPrimorial(args[1:], stdout, stderr)
+		case "goldbach":
+			return runGoldbach(args[1:], stdout, stderr)
+		case "pseudoprimes":
+			return runPseudoprimes(args[1:], stdout, stderr)
+		case "min-q":
+			return runMinQ(args[1:], stdout, stderr)
+		case "verify-signature":
+			return runVerifySignature(args[1:], stdout, stderr)
+		case "diff":
+			return runDiff(args[1:], stdout, stderr)
+		case "check":
+			return runCheck(args[1:], stdout, stderr)
+		case "certify":
+			return runCertify(args[1:], stdout, stderr)
+		case "stream":
+			return runStream(args[1:], os.Stdin, stdout, stderr)
+		case "analyze":
+			return runAnalyze(args[1:], stdout, stderr)
+		case "chunks":
+			return runChunks(args[1:], stdout, stderr)
+		case "convert":
+			return runConvert(args[1:], os.Stdin, stdout, stderr)
+		case "serve":
+			return runServe(args[1:], stdout, stderr)
+		case "client":
+			return runClient(args[1:], stdout, stderr)
 		}
 	}
 
-	// Collectionner les nombres premiers.
-	// Pré-allouer la slice de nombres premiers avec une capacité estimée pour réduire les réallocations.
-	// Théorème des nombres premiers: pi(x) ~ x / ln(x)
-	var estimatedPrimes int
-	if limit > 1 {
-		estimatedPrimes = int(float64(limit) / math.Log(float64(limit)))
+	// --- Configuration ---
+	fs := flag.NewFlagSet("PrimeNumber", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	searchLimitPtr := fs.Int("limit", 1000, tr(msgFlagLimit))
+	primeTestPtr := fs.String("primetest", "miller", tr(msgFlagPrimeTest))
+	accelPtr := fs.String("accel", "", tr(msgFlagAccel, strings.Join(primes.AcceleratorNames(), ", ")))
+	errorBoundPtr := fs.Float64("error-bound", primes.DefaultErrorBound, tr(msgFlagErrorBound))
+	comparePtr := fs.String("compare", "", tr(msgFlagCompare))
+	witnessSourcePtr := fs.String("witness-source", "default", tr(msgFlagWitnessSource, strings.Join(witnessSources, ", ")))
+	twinsPtr := fs.Bool("twins", false, tr(msgFlagTwins))
+	timingPtr := fs.Bool("timing", false, tr(msgFlagTiming))
+	dedupPtr := fs.String("dedup", "none", tr(msgFlagDedup, strings.Join(dedupStructures, ", ")))
+	sortPtr := fs.Bool("sort", false, tr(msgFlagSort))
+	sortMemoryPtr := fs.String("sort-memory", "64MiB", tr(msgFlagSortMemory))
+	sortDirPtr := fs.String("sort-dir", "", tr(msgFlagSortDir))
+	spotCheckPtr := fs.String("spot-check", "", tr(msgFlagSpotCheck))
+	reversePtr := fs.Bool("reverse", false, tr(msgFlagReverse))
+	explainPtr := fs.Int("explain-composites", 0, tr(msgFlagExplainComposites))
+	explainMRPtr := fs.String("explain", "", tr(msgFlagExplain, explainMaxLimit))
+	filterPtr := fs.String("filter", "", tr(msgFlagFilter, strings.Join(primes.FilterNames(), ", ")))
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	pairsPtr := fs.String("pairs", primes.PairsAll.String(), tr(msgFlagPairs, strings.Join(primes.PairModeNames(), ", ")))
+	onOverflowPtr := fs.String("on-overflow", primes.OverflowError.String(), tr(msgFlagOnOverflow, strings.Join(primes.OverflowPolicyNames(), ", ")))
+	dashboardPtr := fs.String("dashboard", "", tr(msgFlagDashboard))
+	tuiPtr := fs.Bool("tui", false, tr(msgFlagTUI))
+	verifyPtr := fs.Bool("verify", false, tr(msgFlagVerify))
+	logFilePtr := fs.String("log-file", "", tr(msgFlagLogFile))
+	logMaxSizePtr := fs.Int64("log-max-size", 100, tr(msgFlagLogMaxSize))
+	logMaxAgePtr := fs.Duration("log-max-age", 24*time.Hour, tr(msgFlagLogMaxAge))
+	logMaxBackupsPtr := fs.Int("log-max-backups", 7, tr(msgFlagLogMaxBackups))
+	logLevelPtr := fs.String("log-level", logLevelNames[logLevelInfo], tr(msgFlagLogLevel, strings.Join(logLevelNames, ", ")))
+	maxMemoryPtr := fs.String("max-memory", "", tr(msgFlagMaxMemory))
+	workersPtr := fs.Int("workers", defaultWorkers(), tr(msgFlagWorkers))
+	cpuQuotaPtr := fs.String("cpu-quota", cpuQuotaAuto, tr(msgFlagCPUQuota))
+	batchPtr := fs.Int("batch", primes.DefaultBatchSize, tr(msgFlagBatch))
+	batchTargetPtr := fs.Duration("batch-target", 0, tr(msgFlagBatchTarget))
+	stallTimeoutPtr := fs.Duration("stall-timeout", 0, tr(msgFlagStallTimeout))
+	skipStalledPtr := fs.Bool("skip-stalled", false, tr(msgFlagSkipStalled))
+	firstPtr := fs.Bool("first", false, tr(msgFlagFirst))
+	jobsBufferPtr := fs.Int("jobs-buffer", 0, tr(msgFlagJobsBuffer))
+	resultsBufferPtr := fs.Int("results-buffer", 0, tr(msgFlagResultsBuffer))
+	autotunePtr := fs.Bool("autotune", false, tr(msgFlagAutotune))
+	autotuneBurstPtr := fs.Duration("autotune-burst", 200*time.Millisecond, tr(msgFlagAutotuneBurst))
+	cpuPercentPtr := fs.Int("cpu-percent", 100, tr(msgFlagCPUPercent))
+	nicePtr := fs.Bool("nice", false, tr(msgFlagNice))
+	primesFilePtr := fs.String("primes-file", "", tr(msgFlagPrimesFile))
+	primesFileFormatPtr := fs.String("primes-file-format", "auto", tr(msgFlagPrimesFileFormat))
+	primesFileCheckPtr := fs.Int("primes-file-check", 100, tr(msgFlagPrimesFileCheck))
+	primesCachePtr := fs.String("primes-cache", "", tr(msgFlagPrimesCache))
+	statusSocketPtr := fs.String("status-socket", "", tr(msgFlagStatusSocket))
+	recordsPtr := fs.String("records", "", tr(msgFlagRecords))
+	manifestPtr := fs.Bool("manifest", true, tr(msgFlagManifest))
+	manifestVerbosePtr := fs.Bool("manifest-verbose", false, tr(msgFlagManifestVerbose))
+	outputPtr := fs.String("o", "", tr(msgFlagOutput))
+	formatPtr := fs.String("format", "table", tr(msgFlagResultFormat))
+	numbersPtr := fs.String("numbers", "grouped", tr(msgFlagNumbers))
+	colorPtr := fs.String("color", "auto", tr(msgFlagColor))
+	var sinkSpecs sinkSpecList
+	fs.Var(&sinkSpecs, "sink", tr(msgFlagSink))
+	var pluginCommands pluginList
+	fs.Var(&pluginCommands, "plugin", tr(msgFlagPlugin))
+	wherePtr := fs.String("where", "", tr(msgFlagWhere))
+	topPtr := fs.Int("top", 0, tr(msgFlagTop))
+	byPtr := fs.String("by", "n", tr(msgFlagBy))
+	sweepPtr := fs.String("sweep", "", tr(msgFlagSweep))
+	samplePtr := fs.Int64("sample", 0, tr(msgFlagSample))
+	seedPtr := fs.Uint64("seed", 0, tr(msgFlagSeed))
+	residuesPtr := fs.Bool("residues", false, tr(msgFlagResidues))
+	reportPtr := fs.String("report", "", tr(msgFlagReport))
+	timeSeriesPtr := fs.String("timeseries", "", tr(msgFlagTimeSeries))
+	summaryOutPtr := fs.String("summary-out", "", tr(msgFlagSummaryOut))
+	summaryJUnitPtr := fs.String("summary-junit", "", tr(msgFlagSummaryJUnit))
+	statsIntervalPtr := fs.Duration("stats-interval", 0, tr(msgFlagStatsInterval))
+	timeSeriesIntervalPtr := fs.Duration("timeseries-interval", time.Second, tr(msgFlagTimeSeriesInterval))
+	timeSeriesFormatPtr := fs.String("timeseries-format", "csv", tr(msgFlagTimeSeriesFormat))
+	signPtr := fs.String("sign", "", tr(msgFlagSign))
+	presetPtr := fs.String("preset", "", tr(msgFlagPreset, strings.Join(presetNames, ", ")))
+	configPtr := fs.String("config", "", tr(msgFlagConfig))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgUsage, fs.Name()))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	// Le fichier d'options, puis un préréglage, complètent les options absentes de la ligne de
+	// commande, avant leur lecture.
+	var configInitial configValues
+	if *configPtr != "" {
+		var err error
+		if configInitial, err = applyConfigFile(fs, *configPtr); err != nil {
+			return err
+		}
+	}
+	var presetApplied []string
+	if *presetPtr != "" {
+		var err error
+		if presetApplied, err = applyPreset(fs, *presetPtr); err != nil {
+			return err
+		}
 	}
-	primes := make([]int, 0, int(float64(estimatedPrimes)*1.2)+10)
 
-	for p := 2; p <= limit; p++ {
-		if !primesMarker[p] {
-			primes = append(primes, p)
+	if langArg != "" {
+		if tag, ok := matchLanguage(langArg); !ok {
+			fmt.Fprint(stderr, tr(msgUnsupportedLang, langArg, tag))
 		}
 	}
-	if len(primes) == 0 {
-		return nil
+
+	// --- Trace du test de Miller-Rabin d'un candidat (-explain N): ni crible ni recherche ---
+	if *explainMRPtr != "" && *explainMRPtr != explainResults {
+		n, err := parseExplainCandidate(*explainMRPtr)
+		if err != nil {
+			return err
+		}
+		out := &errWriter{w: stdout}
+		fmt.Fprint(out, formatMillerRabinTrace(primes.TraceMillerRabin(n)))
+		return writeError(out)
 	}
-	return primes
-}
 
-// isNPrimeAccordingToGreenSawhneyContext vérifie si un grand nombre est premier par division successive.
-// Ce nom reflète son utilisation dans le contexte de la vérification des nombres 'n' issus
-// de la formule p^2 + 4q^2 du théorème de Green-Sawhney.
-// L'algorithme sous-jacent reste la division par essais.
-// Nécessaire pour les résultats 'n' qui peuvent dépasser la limite du crible.
-// Utilise int64 pour la robustesse.
-func isNPrimeAccordingToGreenSawhneyContext(n int64) bool {
-	if n <= 1 {
-		return false
+	searchLimit := *searchLimitPtr
+	// --- Balayage de plusieurs limites: la recherche va jusqu'à la plus grande ---
+	var sweep *sweepCounts
+	if *sweepPtr != "" {
+		if flagSet(fs, "limit") {
+			return fmt.Errorf("%w: -sweep et -limit sont incompatibles", errInvalidFlags)
+		}
+		cutoffs, err := parseSweep(*sweepPtr)
+		if err != nil {
+			return fmt.Errorf("%w: -sweep=%q: %v", errInvalidFlags, *sweepPtr, err)
+		}
+		sweep = newSweepCounts(cutoffs)
+		searchLimit = cutoffs[len(cutoffs)-1]
 	}
-	if n <= 3 {
-		return true
+	primeTestAlgorithm := *primeTestPtr
+	if !slices.Contains(primes.PrimalityTestNames(), primeTestAlgorithm) {
+		return fmt.Errorf("%w: -primetest=%q (attendu l'un de %v)", errInvalidFlags, primeTestAlgorithm, primes.PrimalityTestNames())
 	}
-	if n%2 == 0 || n%3 == 0 {
-		return false
+	// -error-bound implique le test adaptatif, sauf -primetest contraire explicite.
+	policy := primes.MillerRabinPolicy{ErrorBound: *errorBoundPtr}
+	if err := policy.Validate(); err != nil || policy.ErrorBound == 0 {
+		return fmt.Errorf("%w: -error-bound=%g (attendu dans ]0, 1[)", errInvalidFlags, *errorBoundPtr)
 	}
-	// On vérifie les diviseurs de la forme 6k ± 1 jusqu'à sqrt(n).
-	limit := int64(math.Sqrt(float64(n)))
-	for i := int64(5); i <= limit; i = i + 6 {
-		if n%i == 0 || n%(i+2) == 0 {
-			return false
+	if flagSet(fs, "error-bound") && primeTestAlgorithm != "adaptive" {
+		if flagSet(fs, "primetest") {
+			return fmt.Errorf("%w: -error-bound exige -primetest adaptive (-primetest=%q)", errInvalidFlags, primeTestAlgorithm)
 		}
+		primeTestAlgorithm = "adaptive"
+	}
+	// Les bases aléatoires ne servent qu'au-delà de 2^64: candidats promus par -on-overflow promote-big.
+	switch *witnessSourcePtr {
+	case "crypto":
+		policy.Rand = crand.Reader
+	case "default":
+	default:
+		return fmt.Errorf("%w: -witness-source=%q (attendu %v)", errInvalidFlags, *witnessSourcePtr, witnessSources)
+	}
+	// -compare: tous les tests listés sur chaque candidat; le premier fait référence.
+	var comparison *primes.Comparison
+	if *comparePtr != "" {
+		if flagSet(fs, "primetest") || flagSet(fs, "error-bound") {
+			return fmt.Errorf("%w: -compare remplace -primetest et -error-bound", errInvalidFlags)
+		}
+		names := strings.Split(*comparePtr, ",")
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+		}
+		if comparison, err = primes.NewComparison(names); err != nil {
+			return fmt.Errorf("%w: -compare: %v", errInvalidFlags, err)
+		}
+		primeTestAlgorithm = names[0]
+	}
+	// -accel: crible et pré-filtre des candidats déchargés; le test final reste sur CPU.
+	var accel primes.Accelerator
+	if *accelPtr != "" {
+		if accel, err = primes.OpenAccelerator(*accelPtr); err != nil {
+			return fmt.Errorf("%w: -accel=%q: %v", errInvalidFlags, *accelPtr, err)
+		}
+		defer accel.Close()
+	}
+	// "auto": emplacements par défaut du système (voir paths.go).
+	*primesCachePtr = resolvePath(*primesCachePtr, defaultCacheDir)
+	*statusSocketPtr = resolvePath(*statusSocketPtr, defaultStatusSocket)
+	// --- Liste externe de nombres premiers: remplace le crible ---
+	// Sans -limit explicite, la limite est le plus grand nombre de la liste.
+	var importedPrimes []int
+	if *primesFilePtr != "" {
+		if *primesCachePtr != "" {
+			return fmt.Errorf("%w: -primes-file et -primes-cache sont incompatibles", errInvalidFlags)
+		}
+		if !slices.Contains(primesFileFormats, *primesFileFormatPtr) {
+			return fmt.Errorf("%w: -primes-file-format=%q (attendu %v)", errInvalidFlags, *primesFileFormatPtr, primesFileFormats)
+		}
+		list, err := readPrimesFile(*primesFilePtr, *primesFileFormatPtr)
+		if err != nil {
+			return err
+		}
+		if flagSet(fs, "limit") || sweep != nil {
+			list = truncatePrimes(list, searchLimit)
+		} else if len(list) > 0 {
+			searchLimit = list[len(list)-1]
+		}
+		if err := spotCheckPrimes(list, *primesFileCheckPtr); err != nil {
+			return err
+		}
+		importedPrimes = list
+	}
+	// --- Greffons: formes et destinations supplémentaires, connues avant -form et -sink ---
+	plugins := &pluginSet{}
+	if len(pluginCommands) > 0 {
+		if plugins, err = loadPlugins(pluginCommands); err != nil {
+			return err
+		}
+		defer plugins.close()
+	}
+	form, ok := primes.LookupForm(*formPtr)
+	if !ok {
+		form, ok = plugins.lookupForm(*formPtr)
+	}
+	if !ok {
+		return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, *formPtr, plugins.formNames())
+	}
+	pairMode, ok := primes.LookupPairMode(*pairsPtr)
+	if !ok {
+		return fmt.Errorf("%w: -pairs=%q (attendu l'un de %v)", errInvalidFlags, *pairsPtr, primes.PairModeNames())
+	}
+	var filter primes.Filter
+	if *filterPtr != "" {
+		if filter, ok = primes.LookupFilter(*filterPtr); !ok {
+			return fmt.Errorf("%w: -filter=%q (attendu l'un de %v)", errInvalidFlags, *filterPtr, primes.FilterNames())
+		}
+	}
+	var dedup primes.NSet
+	switch *dedupPtr {
+	case "hash":
+		dedup = primes.NewHashNSet()
+	case "roaring":
+		dedup = primes.NewRoaringNSet()
+	case "sort":
+		// Doublons écartés à la fusion de la sortie triée: aucun ensemble en mémoire.
+		if !*sortPtr {
+			return fmt.Errorf("%w: -dedup sort exige -sort", errInvalidFlags)
+		}
+	case "none":
+	default:
+		return fmt.Errorf("%w: -dedup=%q (attendu %v)", errInvalidFlags, *dedupPtr, dedupStructures)
+	}
+	var spot *spotChecker
+	if *spotCheckPtr != "" {
+		rate, err := parseSpotRate(*spotCheckPtr)
+		if err != nil {
+			return err
+		}
+		seed := *seedPtr
+		if seed == 0 {
+			seed = rand.Uint64()
+		}
+		spot = newSpotChecker(rate, seed)
+	}
+	onOverflow, ok := primes.LookupOverflowPolicy(*onOverflowPtr)
+	if !ok {
+		return fmt.Errorf("%w: -on-overflow=%q (attendu l'un de %v)", errInvalidFlags, *onOverflowPtr, primes.OverflowPolicyNames())
+	}
+	if onOverflow == primes.OverflowPromote || onOverflow == primes.OverflowUint64 {
+		// Ces options exigent la valeur exacte de n dans un int64.
+		for _, name := range []string{"filter", "explain", "residues", "report", "records", "sweep", "sample", "top", "where", "tui", "sink", "dashboard", "status-socket", "dedup"} {
+			if flagSet(fs, name) {
+				return fmt.Errorf("%w: -on-overflow %s et -%s sont incompatibles", errInvalidFlags, onOverflow, name)
+			}
+		}
+	}
+	switch {
+	case onOverflow == primes.OverflowError:
+		if err := primes.CheckFormLimit(form, searchLimit); err != nil {
+			return err
+		}
+	case onOverflow == primes.OverflowUint64:
+		if err := primes.CheckFormLimitUint64(form, searchLimit); err != nil {
+			return err
+		}
+	}
+	if onOverflow != primes.OverflowError && *samplePtr > 0 {
+		return fmt.Errorf("%w: -sample exige -on-overflow %s", errInvalidFlags, primes.OverflowError)
+	}
+	if *workersPtr < 1 || *batchPtr < 1 {
+		return fmt.Errorf("%w: -workers=%d, -batch=%d (attendu >= 1)", errInvalidFlags, *workersPtr, *batchPtr)
+	}
+	cpus, err := newCPUBudget(*cpuQuotaPtr)
+	if err != nil {
+		return err
+	}
+	if *jobsBufferPtr < 0 || *resultsBufferPtr < 0 {
+		return fmt.Errorf("%w: -jobs-buffer=%d, -results-buffer=%d (attendu >= 0)", errInvalidFlags, *jobsBufferPtr, *resultsBufferPtr)
+	}
+	if *explainPtr < 0 {
+		return fmt.Errorf("%w: -explain-composites=%d (attendu >= 0)", errInvalidFlags, *explainPtr)
+	}
+	if *cpuPercentPtr < 1 || *cpuPercentPtr > 100 {
+		return fmt.Errorf("%w: -cpu-percent=%d (attendu entre 1 et 100)", errInvalidFlags, *cpuPercentPtr)
+	}
+	cpuPercent := *cpuPercentPtr
+	if *nicePtr && !flagSet(fs, "cpu-percent") {
+		cpuPercent = niceCPUPercent
+	}
+	if !slices.Contains(resultFormats, *formatPtr) {
+		return fmt.Errorf("%w: -format=%q (attendu %v)", errInvalidFlags, *formatPtr, resultFormats)
+	}
+	if !slices.Contains(numberStyles, *numbersPtr) {
+		return fmt.Errorf("%w: -numbers=%q (attendu %v)", errInvalidFlags, *numbersPtr, numberStyles)
+	}
+	numberStyle = *numbersPtr
+	if !slices.Contains(colorModes, *colorPtr) {
+		return fmt.Errorf("%w: -color=%q (attendu %v)", errInvalidFlags, *colorPtr, colorModes)
+	}
+	if !slices.Contains(timeSeriesFormats, *timeSeriesFormatPtr) {
+		return fmt.Errorf("%w: -timeseries-format=%q (attendu %v)", errInvalidFlags, *timeSeriesFormatPtr, timeSeriesFormats)
+	}
+	if *timeSeriesIntervalPtr <= 0 {
+		return fmt.Errorf("%w: -timeseries-interval=%v (attendu > 0)", errInvalidFlags, *timeSeriesIntervalPtr)
+	}
+	if *statsIntervalPtr < 0 {
+		return fmt.Errorf("%w: -stats-interval=%v (attendu >= 0)", errInvalidFlags, *statsIntervalPtr)
+	}
+	logLevel, err := parseLogLevel(*logLevelPtr)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *batchTargetPtr < 0 {
+		return fmt.Errorf("%w: -batch-target=%v (attendu >= 0)", errInvalidFlags, *batchTargetPtr)
+	}
+	if *stallTimeoutPtr < 0 {
+		return fmt.Errorf("%w: -stall-timeout=%v (attendu >= 0)", errInvalidFlags, *stallTimeoutPtr)
+	}
+	if *skipStalledPtr && *stallTimeoutPtr == 0 {
+		return fmt.Errorf("%w: -skip-stalled exige -stall-timeout", errInvalidFlags)
+	}
+	if *firstPtr {
+		// -first arrête la recherche au premier résultat retenu: pas de classement, de tri ni de
+		// balayage de toute la grille.
+		for _, name := range []string{"reverse", "sample", "top", "sort", "sweep", "explain"} {
+			if flagSet(fs, name) {
+				return fmt.Errorf("%w: -first et -%s sont incompatibles", errInvalidFlags, name)
+			}
+		}
+	}
+	explainEach := *explainMRPtr == explainResults
+	if explainEach {
+		if searchLimit > explainMaxLimit {
+			return fmt.Errorf("%w: -explain %s exige une limite <= %d (limite %d)", errInvalidFlags, explainResults, explainMaxLimit, searchLimit)
+		}
+		for _, name := range []string{"tui", "sample", "top"} {
+			if flagSet(fs, name) {
+				return fmt.Errorf("%w: -explain %s et -%s sont incompatibles", errInvalidFlags, explainResults, name)
+			}
+		}
+	}
+	if *samplePtr < 0 {
+		return fmt.Errorf("%w: -sample=%d (attendu >= 0)", errInvalidFlags, *samplePtr)
+	}
+	if *samplePtr > 0 {
+		for _, name := range []string{"sweep", "tui", "o", "sink", "where", "top", "compare", "report", "autotune", "primes-cache"} {
+			if flagSet(fs, name) {
+				return fmt.Errorf("%w: -sample et -%s sont incompatibles", errInvalidFlags, name)
+			}
+		}
+	}
+	if *reversePtr {
+		// La recherche inverse ne teste pas de paires: ni test comparé, ni réglage des lots, ni
+		// analyse des composés; elle ne traite que la forme p^2+4q^2 sur int64.
+		if form != primes.FormP2Plus4Q2 || onOverflow != primes.OverflowError {
+			return fmt.Errorf("%w: -reverse exige -form %s et -on-overflow %s", errInvalidFlags, primes.FormP2Plus4Q2.Name(), primes.OverflowError)
+		}
+		for _, name := range []string{"compare", "autotune", "batch-target", "explain-composites", "sample", "stall-timeout"} {
+			if flagSet(fs, name) {
+				return fmt.Errorf("%w: -reverse et -%s sont incompatibles", errInvalidFlags, name)
+			}
+		}
+	}
+	var where *whereExpr
+	if *wherePtr != "" {
+		if where, err = parseWhere(*wherePtr); err != nil {
+			return fmt.Errorf("%w: -where: %v", errInvalidFlags, err)
+		}
+	}
+	// --- Validation croisée: combinaisons sans objet refusées avant tout travail ---
+	if err := checkSearchConfig(searchConfig{limit: searchLimit, primes: importedPrimes, form: form, pairs: pairMode, where: *wherePtr}); err != nil {
+		return err
+	}
+	if *topPtr < 0 {
+		return fmt.Errorf("%w: -top=%d (attendu >= 0)", errInvalidFlags, *topPtr)
+	}
+	if flagSet(fs, "by") && *topPtr == 0 {
+		return fmt.Errorf("%w: -by exige -top", errInvalidFlags)
+	}
+	topLess, err := parseTopBy(*byPtr)
+	if err != nil {
+		return fmt.Errorf("%w: -by: %v", errInvalidFlags, err)
+	}
+	sortRunSize := 0
+	if *sortPtr {
+		// -top classe déjà sa sortie; les traces de -explain results suivent chaque ligne à son arrivée.
+		if *topPtr > 0 || explainEach {
+			return fmt.Errorf("%w: -sort est incompatible avec -top et -explain %s", errInvalidFlags, explainResults)
+		}
+		budget, err := parseByteSize(*sortMemoryPtr)
+		if err != nil || budget == 0 {
+			return fmt.Errorf("%w: -sort-memory=%q", errInvalidFlags, *sortMemoryPtr)
+		}
+		sortRunSize = primes.SortRunSize(budget)
+	} else if flagSet(fs, "sort-memory") || flagSet(fs, "sort-dir") {
+		return fmt.Errorf("%w: -sort-memory et -sort-dir exigent -sort", errInvalidFlags)
+	}
+	var signingKey ed25519.PrivateKey
+	if *signPtr != "" {
+		if *outputPtr == "" {
+			return fmt.Errorf("%w: -sign exige -o", errInvalidFlags)
+		}
+		if signingKey, err = loadSigningKey(*signPtr); err != nil {
+			return err
+		}
+	}
+	var memoryBudget int64
+	if *maxMemoryPtr != "" {
+		budget, err := parseByteSize(*maxMemoryPtr)
+		if err != nil || budget == 0 {
+			return fmt.Errorf("%w: -max-memory=%q", errInvalidFlags, *maxMemoryPtr)
+		}
+		memoryBudget = budget
 	}
-	return true
-}
-
-// power64 calcule (base^exp) % mod de manière sûre avec math/big pour éviter les débordements.
-func power64(base, exp, mod int64) int64 {
-	bBase := big.NewInt(base)
-	bExp := big.NewInt(exp)
-	bMod := big.NewInt(mod)
 
-	// Le paquet math/big gère les grands nombres de manière sûre.
-	res := new(big.Int)
-	res.Exp(bBase, bExp, bMod)
+	// --- Journal: sur la sortie standard, ou dans un fichier avec rotation (-log-file) ---
+	// Les messages d'état passent par status; les résultats restent sur la sortie standard. Une
+	// sortie JSON ou NDJSON sur la sortie standard y reste seule: les messages d'état passent alors sur stderr.
+	var statusOut io.Writer = out
+	if *formatPtr != "table" && *outputPtr == "" {
+		statusOut = stderr
+	}
+	var logger *log.Logger
+	if *logFilePtr != "" {
+		logFile, err := openRotatingFile(*logFilePtr, *logMaxSizePtr<<20, *logMaxAgePtr, *logMaxBackupsPtr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer logFile.Close()
+		logger = log.New(logFile, "", log.LstdFlags)
+		defer func() {
+			if err != nil {
+				logger.Print(strings.TrimSpace(tr(msgError, err)))
+			}
+		}()
+	}
+	// Le niveau du journal (-log-level) et l'intervalle de la ligne de statistiques changent au
+	// rechargement du fichier d'options (SIGHUP).
+	live := newLiveSettings(logLevel, *statsIntervalPtr)
+	logAt := func(level int32, msg string) {
+		if !live.logs(level) {
+			return
+		}
+		if logger != nil {
+			if msg = strings.TrimSpace(msg); msg != "" {
+				logger.Print(msg)
+			}
+			return
+		}
+		fmt.Fprint(statusOut, msg)
+	}
+	status := func(msg string) { logAt(logLevelInfo, msg) }
+	warn := func(msg string) { logAt(logLevelWarn, msg) }
+	// Bilan d'intégration continue: écrit en dernier, avec le code de sortie, y compris en cas d'échec.
+	var summary *runSummary
+	if *summaryOutPtr != "" || *summaryJUnitPtr != "" {
+		summary = newRunSummary(args, startTime)
+		defer func() {
+			summary.finish(err, time.Now())
+			for _, w := range []struct {
+				path  string
+				write func(string, *runSummary) error
+			}{{*summaryOutPtr, writeSummary}, {*summaryJUnitPtr, writeSummaryJUnit}} {
+				if w.path == "" {
+					continue
+				}
+				if werr := w.write(w.path, summary); werr != nil {
+					if err == nil {
+						err = werr
+					}
+					continue
+				}
+				status(tr(msgSummaryWritten, w.path))
+			}
+		}()
+	}
+	separator := "-------------------------------------------------------------------\n"
+	if logger != nil {
+		separator = ""
+	}
 
-	return res.Int64()
-}
+	// --- Fichier de résultats (-o): reçoit le tableau et son manifeste à la place de la sortie standard ---
+	var resultsFile *os.File
+	if *outputPtr != "" {
+		if resultsFile, err = os.Create(*outputPtr); err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer resultsFile.Close()
+	}
+	// --- Destinations supplémentaires (-sink): ouvertes avant tout travail pour échouer tôt ---
+	var extraSinks []*outputSink
+	for _, spec := range sinkSpecs {
+		s, err := openSink(spec, form.Name(), plugins)
+		if err != nil {
+			return err
+		}
+		defer s.closer.Close() // Sans effet après Close, en fin de recherche.
+		extraSinks = append(extraSinks, s)
+	}
 
-// isPrimeMillerRabin64 implémente le test de primalité de Miller-Rabin.
-// Cette version est déterministe pour tous les nombres de type int64.
-// Elle utilise un ensemble de bases prédéfinies qui garantissent l'exactitude.
-func isPrimeMillerRabin64(n int64) bool {
-	if n < 2 {
-		return false
+	numWorkers := *workersPtr
+	if !flagSet(fs, "workers") {
+		numWorkers = cpus.workers()
 	}
-	if n == 2 || n == 3 {
-		return true
+	batchSize := *batchPtr
+	// Sous quota, GOMAXPROCS suit le nombre de CPU accordés (sauf variable GOMAXPROCS explicite):
+	// des threads en excès seraient bridés par le noyau.
+	if cpus.limited() && os.Getenv("GOMAXPROCS") == "" {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(cpus.workers()))
 	}
-	if n%2 == 0 {
-		return false
+	if cpus.quota > 0 {
+		status(tr(msgCPUQuota, cpus.quota, cpus.source, cpus.numCPU, cpus.workers(), runtime.GOMAXPROCS(0)))
 	}
 
-	// Écrire n-1 comme 2^s * d
-	d := n - 1
-	s := 0
-	for d%2 == 0 {
-		d /= 2
-		s++
+	if *presetPtr != "" {
+		status(tr(msgPresetApplied, *presetPtr, strings.Join(presetApplied, " ")))
+	}
+	status(tr(msgInit, searchLimit, numWorkers, primeTestAlgorithm))
+	if primeTestAlgorithm == "adaptive" {
+		status(tr(msgAdaptivePolicy, len(policy.Bases(0)), len(policy.Bases(math.MaxInt64)), policy.Rounds(), policy.ErrorBound))
 	}
+	status(separator)
 
-	// Bases de test qui rendent l'algorithme déterministe pour n < 2^64.
-	bases := []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
-	// Pour n < 3,317,044,064,279,371, les 12 premières bases suffisent.
+	// --- Mode arrière-plan: priorité abaissée et bridage CPU des workers ---
+	if *nicePtr {
+		if err := lowerPriority(); err != nil {
+			warn(tr(msgNiceWarning, err))
+		}
+	}
+	if cpuPercent < 100 {
+		status(tr(msgCPULimit, cpuPercent))
+	}
 
-	for _, a := range bases {
-		if a >= n-1 {
-			break
+	// --- Budget mémoire: refus avant tout travail si l'estimation le dépasse ---
+	if memoryBudget > 0 && *samplePtr == 0 {
+		est := primes.EstimateMemoryBuffers(searchLimit, numWorkers, batchSize, *jobsBufferPtr, *resultsBufferPtr)
+		status(tr(msgMemoryEstimate, formatBytes(est.Total()), formatBytes(est.Sieve), formatBytes(est.PrimeTable), formatBytes(est.Buffers), formatBytes(memoryBudget)))
+		if est.Total() > memoryBudget {
+			return fmt.Errorf("%w: %s > %s", errMemoryBudget, formatBytes(est.Total()), formatBytes(memoryBudget))
 		}
-		x := power64(a, d, n)
+	}
+
+	// --- Interruption (Ctrl+C, SIGTERM): annule le contexte de l'exécution, ce qui arrête
+	// proprement le crible ou la recherche (résultats partiels) ---
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
 
-		if x == 1 || x == n-1 {
-			continue
+	// --- Estimation de Monte-Carlo (-sample): ni crible ni recherche exhaustive ---
+	if *samplePtr > 0 {
+		seed := *seedPtr
+		if seed == 0 {
+			seed = rand.Uint64()
+		}
+		status(tr(msgSampleStart, *samplePtr, searchLimit, seed))
+		est, err := primes.SampleDensity(ctx, primes.Options{
+			Limit: searchLimit, Primes: importedPrimes, Workers: numWorkers,
+			PrimeTest: primeTestAlgorithm, Form: form, Pairs: pairMode, Filter: filter,
+		}, *samplePtr, seed)
+		if ctx.Err() != nil {
+			status(tr(msgInterrupted))
+			return errInterrupted
+		}
+		if err != nil {
+			return err
 		}
+		expected, low, high := est.Expected()
+		status(separator)
+		status(tr(msgSampleDensity, est.Density, est.Low, est.High, est.Hits, est.Samples))
+		status(tr(msgSampleExpected, expected, low, high, est.Pairs, est.PrimeCount))
+		return writeError(out)
+	}
 
-		isWitness := true
-		for r := 1; r < s; r++ {
-			x = power64(x, 2, n)
-			if x == n-1 {
-				isWitness = false
-				break
+	// --- Étape 1: Génération optimisée des nombres premiers ---
+	var primeList []int
+	switch {
+	case *primesFilePtr != "":
+		primeList = importedPrimes
+		status(tr(msgPrimesFileLoaded, *primesFilePtr))
+	case *primesCachePtr != "":
+		primeList = loadOrSievePrimes(*primesCachePtr, searchLimit, status)
+	case accel != nil:
+		status(tr(msgSievingAccel, accel))
+		var sieveErr error
+		if primeList, sieveErr = primes.SieveAccelerated(ctx, searchLimit, accel); sieveErr != nil {
+			if ctx.Err() == nil {
+				return fmt.Errorf("%w: %v", errIO, sieveErr)
 			}
+			status(tr(msgInterrupted))
+			return errInterrupted
 		}
+	default:
+		status(tr(msgSieving))
+		var sieveErr error
+		if primeList, sieveErr = primes.SieveOfEratosthenesContext(ctx, searchLimit); sieveErr != nil {
+			status(tr(msgInterrupted))
+			return errInterrupted
+		}
+	}
+	if len(primeList) == 0 {
+		status(tr(msgNoPrimes))
+		return writeError(out)
+	}
+	status(tr(msgPrimesFound, countInt(len(primeList)), groupedInt(searchLimit)))
+
+	// --- Service systemd: prêt une fois le crible calculé, puis chien de garde pendant la collecte ---
+	notifier := newSDNotifier(os.Getenv)
+	if err := notifier.notify("READY=1"); err != nil {
+		warn(tr(msgSDNotifyError, err))
+	}
+
+	// --- Réglage automatique des workers et des lots ---
+	var tuned *tuneConfig
+	if *autotunePtr {
+		workerCandidates := tuneWorkerCandidates(cpus.workers())
+		status(tr(msgAutotuneStart, len(workerCandidates)*len(tuneBatchCandidates), *autotuneBurstPtr))
+		best, _ := autotune(primeList, primeTestAlgorithm, *autotuneBurstPtr, workerCandidates, tuneBatchCandidates, measureBurst)
+		tuned = &best
+		numWorkers, batchSize = best.Workers, best.BatchSize
+	}
 
-		if isWitness {
-			return false // n est composé.
+	// --- Tampons des canaux: valeurs explicites, sinon adaptées aux workers et aux lots ---
+	jobsBuffer, jobsSource := *jobsBufferPtr, "-jobs-buffer"
+	if jobsBuffer == 0 {
+		jobsBuffer, jobsSource = primes.DefaultJobsBuffer(numWorkers, batchSize), tr(msgBufferAdaptive)
+	}
+	resultsBuffer, resultsSource := *resultsBufferPtr, "-results-buffer"
+	if resultsBuffer == 0 {
+		resultsBuffer, resultsSource = primes.DefaultResultsBuffer(numWorkers, batchSize), tr(msgBufferAdaptive)
+	}
+	status(tr(msgBuffers, jobsBuffer, jobsSource, resultsBuffer, resultsSource))
+	for _, pl := range plugins.plugins {
+		formNames := make([]string, len(pl.forms))
+		for i, f := range pl.forms {
+			formNames[i] = f.Name()
 		}
+		status(tr(msgPluginLoaded, pl.name, strings.Join(formNames, ", "), strings.Join(pl.sinks, ", ")))
 	}
 
-	return true // n est probablement (ici, certainement) premier.
-}
+	stats := &searchStats{totalPairs: pairMode.Count(len(primeList))}
 
-// worker est une fonction qui s'exécute dans une goroutine.
-// Elle reçoit des tâches (Jobs) depuis un canal, les traite,
-// et envoie les résultats positifs dans un autre canal.
-func worker(wg *sync.WaitGroup, jobs <-chan Job, results chan<- Result, primeTestAlgorithm string) {
-	defer wg.Done()
+	params := runParams{
+		Limit:      searchLimit,
+		Workers:    numWorkers,
+		PrimeTest:  primeTestAlgorithm,
+		PrimeCount: len(primeList),
+		Form:       form.Name(),
+		Filter:     *filterPtr,
+	}
+	if summary != nil {
+		summary.Params = &params
+	}
 
-	for job := range jobs {
-		p, q := int64(job.p), int64(job.q)
-		n := (p * p) + 4*(q*q)
+	ctl := primes.NewControl()
+	ctl.SetCPUPercent(cpuPercent)
 
-		var isNPrime bool
-		if primeTestAlgorithm == "miller" {
-			isNPrime = isPrimeMillerRabin64(n)
-		} else { // Par défaut: "trial"
-			isNPrime = isNPrimeAccordingToGreenSawhneyContext(n)
+	// --- Tableau de bord web et socket d'état optionnels ---
+	var dash *dashboard
+	if *dashboardPtr != "" || *statusSocketPtr != "" {
+		dash = newDashboard(stats, params, startTime)
+		dash.ctl = ctl
+		if *statusSocketPtr != "" { // Pour status -snapshot.
+			dash.retain = maxSnapshotResults
 		}
-
-		if isNPrime {
-			results <- Result{p: job.p, q: job.q, n: n}
+	}
+	if *dashboardPtr != "" {
+		srv, err := startDashboard(*dashboardPtr, dash)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errIO, strings.TrimSpace(tr(msgDashboardError, *dashboardPtr, err)))
 		}
+		defer srv.Close()
+		status(tr(msgDashboardURL, srv.Addr))
+	}
+	if *statusSocketPtr != "" {
+		ln, err := startStatusSocket(*statusSocketPtr, dash)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errIO, strings.TrimSpace(tr(msgStatusSocketError, *statusSocketPtr, err)))
+		}
+		defer ln.Close()
+		status(tr(msgStatusSocketReady, *statusSocketPtr))
 	}
-}
-
-func main() {
-	startTime := time.Now()
 
-	// --- Configuration ---
-	searchLimitPtr := flag.Int("limit", 1000, "Limite supérieure pour la recherche des nombres premiers p et q.")
-	primeTestPtr := flag.String("primetest", "miller", "Algorithme de test de primalité: 'trial' ou 'miller' (défaut).")
-	flag.Parse()
+	// --- Suspension (SIGUSR1) et reprise (SIGUSR2) de la distribution des tâches ---
+	defer notifyPauseResume(ctl, status)()
 
-	searchLimit := *searchLimitPtr
-	primeTestAlgorithm := *primeTestPtr
+	// --- Rechargement du fichier d'options (SIGHUP): journal, statistiques et bridage à chaud ---
+	if *configPtr != "" {
+		startup := map[string]string{"log-level": logLevelNames[logLevel], "stats-interval": statsIntervalPtr.String(), "cpu-percent": strconv.Itoa(cpuPercent)}
+		reloader := newConfigReloader(*configPtr, fs, configInitial, startup, live, ctl)
+		defer notifyReload(func() {
+			applied, restart, err := reloader.reload()
+			switch {
+			case err != nil:
+				warn(tr(msgConfigReloadFailed, err))
+				return
+			case len(applied) == 0:
+				status(tr(msgConfigReloadedNone, *configPtr))
+			default:
+				status(tr(msgConfigReloaded, *configPtr, strings.Join(applied, " ")))
+			}
+			if len(restart) > 0 {
+				warn(tr(msgConfigRestart, strings.Join(restart, " ")))
+			}
+		})()
+	}
 
-	numWorkers := runtime.NumCPU()
+	// --- Garde-fou mémoire pendant la recherche ---
+	if memoryBudget > 0 {
+		guard := newMemoryGuard(memoryBudget, ctl, jobsBuffer, status)
+		defer guard.release()
+		guardDone := make(chan struct{})
+		defer close(guardDone)
+		go guard.run(guardDone)
+	}
 
-	fmt.Printf("Initialisation avec searchLimit=%d, numWorkers=%d, primeTest='%s'\n", searchLimit, numWorkers, primeTestAlgorithm)
-	fmt.Println("-------------------------------------------------------------------")
+	// --- Interface terminal optionnelle ---
+	var ui *tea.Program
+	if *tuiPtr {
+		ui = newTUI(ctl, params, startTime)
+	}
+	// Les résultats vont dans le fichier -o, sinon sur la sortie standard hors TUI.
+	var results *errWriter
+	switch {
+	case resultsFile != nil:
+		results = &errWriter{w: resultsFile}
+	case ui == nil:
+		results = out
+	}
+	var rw *resultWriter
+	var sink primes.ResultSink // rw et les destinations -sink derrière des tampons bornés, ouverts au début de la recherche.
+	if results != nil {
+		rw = &resultWriter{w: results, format: *formatPtr, formName: form.Name()}
+		var terminal io.Writer = stdout
+		if resultsFile != nil {
+			terminal = resultsFile
+		}
+		rw.color = colorEnabled(*colorPtr, terminal, os.Getenv)
+		rw.timing = *timingPtr
+	}
+	// Colonnes du tableau dimensionnées pour la plus grande paire; les formes prédéfinies croissent avec p et q.
+	maxPrime := primeList[len(primeList)-1]
+	maxN := big.NewInt(form.Eval(int64(maxPrime), int64(maxPrime)))
+	if bf, ok := form.(primes.BigForm); ok {
+		maxN = bf.EvalBig(int64(maxPrime), int64(maxPrime)) // Exacte avec -on-overflow skip ou promote-big.
+	}
+	if rw != nil {
+		rw.sizeColumns(maxPrime, maxN)
+	}
+	for _, s := range extraSinks {
+		s.sizeColumns(maxPrime, maxN)
+		s.timing = *timingPtr
+	}
+	// Avec -records, les résultats qui battent le record de la forme sont mis en évidence.
+	if rw != nil && *recordsPtr != "" {
+		if rf, err := readRecords(*recordsPtr); err == nil { // Une erreur est signalée à la mise à jour, en fin de recherche.
+			rw.recordAbove = rf.Records[form.Name()].N
+		}
+	}
 
-	// --- Étape 1: Génération optimisée des nombres premiers ---
-	fmt.Println("Génération des nombres premiers avec le crible d'Eratosthène...")
-	primes := sieveOfEratosthenes(searchLimit)
-	if primes == nil {
-		fmt.Println("Aucun nombre premier trouvé dans la limite spécifiée.")
-		return
-	}
-	fmt.Printf("%d nombres premiers trouvés jusqu'à %d.\n\n", len(primes), searchLimit)
-
-	// --- Étape 2: Mise en place du Pool de Workers et des canaux ---
-	jobs := make(chan Job, len(primes))
-	results := make(chan Result, 100)
-	var wg sync.WaitGroup
-
-	// Démarrage des workers.
-	for w := 1; w <= numWorkers; w++ {
-		wg.Add(1)
-		go worker(&wg, jobs, results, primeTestAlgorithm)
-	}
-
-	// --- Étape 3: Distribution des tâches ---
-	go func() {
-		for _, p := range primes {
-			for _, q := range primes {
-				jobs <- Job{p: p, q: q}
+	var residues *residueCounts
+	if *residuesPtr {
+		residues = newResidueCounts()
+	}
+	var report *reportBuilder
+	if *reportPtr != "" {
+		report = newReportBuilder(searchLimit)
+	}
+	var verifyErr error
+	twinCount := 0
+	var best primes.Result   // Plus grand n trouvé, pour le fichier de records.
+	var firstFound time.Time // Découverte la plus précoce (-timing).
+	var first *primes.Result // Résultat retenu par -first, et instant de sa réception.
+	var firstAt time.Time
+	count := 0
+	kept := 0       // Résultats retenus par -where.
+	duplicates := 0 // Résultats écartés par -dedup.
+	onResult := func(res primes.Result) error {
+		if first != nil {
+			return nil // -first: les lots déjà distribués produisent encore des résultats, ignorés.
+		}
+		if dedup != nil && !dedup.Add(res.N) {
+			duplicates++
+			return nil
+		}
+		count++
+		stats.primesFound.Add(1)
+		stats.decades.add(res)
+		if !res.FoundAt.IsZero() && (firstFound.IsZero() || res.FoundAt.Before(firstFound)) {
+			firstFound = res.FoundAt
+		}
+		if res.N > best.N {
+			best = res
+		}
+		if res.Twin {
+			twinCount++
+		}
+		if sweep != nil {
+			sweep.add(res)
+		}
+		if residues != nil {
+			residues.add(res)
+		}
+		if report != nil {
+			report.add(res)
+		}
+		if *verifyPtr && verifyErr == nil {
+			if err := verifyResult(res, form, filter, primeTestAlgorithm); err != nil {
+				verifyErr = err
+				ctl.Stop()
 			}
 		}
-		close(jobs) // Ferme le canal, signale aux workers qu'il n'y a plus de tâches.
-	}()
+		if spot != nil {
+			spot.check(res, form, filter, primeTestAlgorithm)
+		}
+		// -where ne restreint que la sortie: résumé, tableau de bord, rapport et records portent
+		// sur tous les résultats.
+		if where != nil && !where.match(res) {
+			return nil
+		}
+		if *firstPtr {
+			first, firstAt = &res, time.Now()
+			ctl.Stop()
+		}
+		kept++
+		if dash != nil {
+			dash.keepResult(res)
+		}
+		if sink != nil {
+			if err := sink.Write(res); err != nil {
+				return err
+			}
+		}
+		if explainEach {
+			if sink != nil {
+				sink.Flush() // La trace suit la ligne de son résultat.
+			}
+			status(formatMillerRabinTrace(primes.TraceMillerRabin(res.N)))
+		}
+		return nil
+	}
+	// --- Analyse optionnelle des valeurs composées (affichées dans le tableau hors TUI) ---
+	var explain *primes.Explain
+	compositeCount := 0
+	if *explainPtr > 0 {
+		explain = &primes.Explain{Every: *explainPtr, OnComposite: func(c primes.Composite) {
+			compositeCount++
+			if rw != nil {
+				sink.Flush() // Les lignes du tableau restent dans l'ordre d'arrivée.
+				rw.composite(c)
+			}
+		}}
+	}
+	// Suivi de la recherche: statistiques (résumé), interface terminal, tableau de bord et chien de
+	// garde systemd, appelé par la boucle de collecte (une collecte bloquée n'envoie plus rien).
+	reporters := []primes.ProgressReporter{stats}
+	if ui != nil {
+		reporters = append(reporters, tuiReporter{ui: ui})
+	}
+	if dash != nil {
+		reporters = append(reporters, dash)
+	}
+	if notifier != nil {
+		reporters = append(reporters, notifier)
+	}
 
-	// --- Étape 4: Collecte des résultats ---
-	go func() {
-		wg.Wait() // Attend la fin de tous les workers.
-		close(results)
-	}()
+	// --- Étape 2: Recherche parallèle et collecte des résultats ---
+	searchOpts := primes.Options{
+		Primes:        primeList,
+		PrimeTest:     primeTestAlgorithm,
+		Workers:       numWorkers,
+		BatchSize:     batchSize,
+		BatchTarget:   *batchTargetPtr,
+		JobsBuffer:    jobsBuffer,
+		ResultsBuffer: resultsBuffer,
+		Form:          form,
+		Pairs:         pairMode,
+		OnOverflow:    onOverflow,
+		Filter:        filter,
+		Twins:         *twinsPtr,
+		Timing:        *timingPtr,
+		Explain:       explain,
+		Control:       ctl,
+		Reporter:      primes.MultiReporter(reporters...),
+		Accelerator:   accel,
+	}
+	if *firstPtr {
+		// Petits p et q d'abord, et lots d'une paire sauf -batch explicite: le premier résultat
+		// arrive au plus tôt.
+		searchOpts.Jobs = primes.NewShellSource(primeList, pairMode)
+		if !flagSet(fs, "batch") {
+			searchOpts.BatchSize = 1
+		}
+	}
+	if comparison != nil {
+		searchOpts.PrimeTestFunc = comparison.IsPrime
+	}
+	// Chien de garde: un candidat bloqué est signalé (et abandonné avec -skip-stalled).
+	var stalls *stallLog
+	if *stallTimeoutPtr > 0 {
+		stalls = &stallLog{form: form, logf: warn}
+		searchOpts.StallTimeout, searchOpts.OnStall, searchOpts.SkipStalled = *stallTimeoutPtr, stalls.onStall, *skipStalledPtr
+	}
+	if primeTestAlgorithm == "adaptive" || policy.Rand != nil {
+		searchOpts.BigPrimeTest = policy.IsPrimeBig
+	}
+	// --- Manifeste de l'exécution: accompagne les résultats et le rapport ---
+	var manifest *runManifest
+	if (*manifestPtr && (rw != nil || len(extraSinks) > 0)) || report != nil {
+		algorithms := map[string]string{"sieve": "eratosthenes", "primetest": primeTestAlgorithm, "form": form.Name()}
+		switch {
+		case *primesFilePtr != "":
+			algorithms["sieve"] = "primes-file"
+		case *primesCachePtr != "":
+			algorithms["sieve"] = "primes-cache"
+		}
+		if filter != nil {
+			algorithms["filter"] = filter.Name()
+		}
+		if comparison != nil {
+			algorithms["compare"] = strings.Join(comparison.Names(), ",")
+		}
+		if accel != nil {
+			algorithms["accel"] = accel.Name()
+		}
+		if *reversePtr {
+			algorithms["search"] = "reverse-cornacchia"
+		}
+		if tuned != nil {
+			algorithms["autotune"] = fmt.Sprintf("workers=%d batch=%d", tuned.Workers, tuned.BatchSize)
+		}
+		manifest = newManifest(fs.Name(), args, fs, algorithms, startTime)
+	}
+	if *manifestPtr && rw != nil {
+		rw.manifest = manifest
+		rw.manifestParams = *manifestVerbosePtr
+	}
+	if *manifestPtr {
+		for _, s := range extraSinks {
+			s.manifest = manifest
+		}
+	}
 
-	fmt.Printf("%-10s | %-10s | %-25s | %-s\n", "p", "q", "n = p^2 + 4q^2", "Vérification")
-	count := 0
-	for res := range results {
-		count++
-		fmt.Printf("%-10d | %-10d | %-25d | %s\n", res.p, res.q, res.n, "Trouvé!")
+	// --- Série temporelle du rythme de découverte ---
+	var series *timeSeries
+	if *timeSeriesPtr != "" {
+		if series, err = openTimeSeries(*timeSeriesPtr, *timeSeriesFormatPtr, stats, time.Now()); err != nil {
+			return err
+		}
+		series.run(*timeSeriesIntervalPtr)
+	}
+	// --- Ligne de statistiques périodique, arrêtée avant le résumé ---
+	stopStatsLine := func() {}
+	// Avec un fichier d'options, elle peut être activée par un rechargement.
+	if *statsIntervalPtr > 0 || *configPtr != "" {
+		statsDone, statsStopped := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(statsStopped)
+			newStatsReporter(stats, time.Now()).run(live, status, statsDone)
+		}()
+		stopStatsLine = func() {
+			close(statsDone)
+			<-statsStopped
+		}
 	}
 
+	var searchErr error
+	var searchDuration time.Duration
+	searchStart := time.Now()
+	// Les résultats passent par un tampon borné: une sortie lente freine les workers. Une sortie en
+	// erreur (disque plein, tube fermé...) arrête la recherche au lieu de la poursuivre à vide; avec
+	// plusieurs destinations, elle est seulement écartée, et la recherche s'arrête quand toutes ont échoué.
+	var sinks []primes.ResultSink
+	var sinkNames []string
+	if rw != nil {
+		rw.begin()
+		sinks = append(sinks, primes.NewBufferedSink(rw, primes.DefaultSinkBuffer))
+		sinkNames = append(sinkNames, cmp.Or(*outputPtr, "-"))
+	}
+	for i, s := range extraSinks {
+		s.begin()
+		sinks = append(sinks, primes.NewBufferedSink(s, primes.DefaultSinkBuffer))
+		sinkNames = append(sinkNames, sinkSpecs[i].String())
+	}
+	sinkFailures := 0
+	switch len(sinks) {
+	case 0:
+	case 1:
+		sink = sinks[0]
+	default:
+		fanOut := primes.NewFanOutSink(sinks...)
+		fanOut.OnError = func(i int, err error) {
+			sinkFailures++
+			warn(tr(msgSinkFailed, sinkNames[i], err))
+		}
+		sink = fanOut
+	}
+	// -top: seuls les K premiers résultats du classement, gardés dans un tas, sont écrits à la fin.
+	var top *primes.TopSink
+	if *topPtr > 0 && sink != nil {
+		top = primes.NewTopSink(sink, *topPtr, topLess)
+		sink = top
+	}
+	// -sort: tri externe, écrit à la fermeture.
+	var sorter *primes.SortSink
+	if *sortPtr && sink != nil {
+		sorter = primes.NewSortSink(sink, sortRunSize, *sortDirPtr, *dedupPtr == "sort")
+		sink = sorter
+	}
+	searchFunc := primes.Search
+	if *reversePtr {
+		searchFunc = primes.SearchReverse
+	}
+	if ui == nil {
+		searchErr = searchFunc(ctx, searchOpts, onResult)
+		searchDuration = time.Since(searchStart)
+	} else {
+		done := make(chan error, 1)
+		go func() {
+			err := searchFunc(ctx, searchOpts, onResult)
+			searchDuration = time.Since(searchStart)
+			done <- err
+			ui.Send(tuiDoneMsg{})
+		}()
+		if _, err := ui.Run(); err != nil {
+			fmt.Fprint(stderr, tr(msgTUIError, err))
+			ctl.Stop()
+		}
+		searchErr = <-done
+	}
+	stopStatsLine()
+	notifier.notify("STOPPING=1")
+	if sink != nil {
+		sink.Close() // Erreur d'écriture relue par writeError avant la fin.
+	}
+	if err := plugins.failure(); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	if sorter != nil && sorter.Err() != nil {
+		return fmt.Errorf("%w: -sort: %v", errIO, sorter.Err())
+	}
+	if series != nil {
+		if err := series.close(); err != nil {
+			return err
+		}
+	}
+	// L'annulation du contexte (signal) est un arrêt comme un autre: les résultats partiels sont résumés.
+	if searchErr != nil && !errors.Is(searchErr, context.Canceled) {
+		return searchErr
+	}
+	interrupted := (ctl.Stopped() && first == nil) || ctx.Err() != nil
+
 	// --- Finalisation ---
 	duration := time.Since(startTime)
-	fmt.Println("-------------------------------------------------------------------")
-	fmt.Printf("Recherche terminée. %d nombres premiers spéciaux trouvés.\n", count)
-	fmt.Printf("\nDurée totale de l'exécution: %s\n", duration)
+	status(separator)
+	if interrupted && verifyErr == nil {
+		status(tr(msgInterrupted))
+	}
+	status(tr(msgSummary, countInt(count)))
+	if where != nil {
+		status(tr(msgWhereSummary, countInt(kept), where))
+	}
+	if top != nil {
+		status(tr(msgTopSummary, countInt(len(top.Results())), countInt(top.Seen()), *byPtr))
+	}
+	if *twinsPtr {
+		status(tr(msgTwinSummary, countInt(twinCount)))
+	}
+	if sorter != nil {
+		status(tr(msgSortSummary, countInt(sorter.Written()), countInt(sorter.Duplicates()), sorter.Runs(), formatBytes(sorter.SpilledBytes())))
+	}
+	if dedup != nil {
+		status(tr(msgDedupSummary, *dedupPtr, countInt(dedup.Len()), countInt(duplicates), formatBytes(dedup.SizeBytes())))
+	}
+	if spot != nil {
+		status(tr(msgSpotCheckSummary, independentTestName(primeTestAlgorithm), spot.seed, countInt(spot.checked), countInt(spot.seen), spot.agreement()))
+		if spot.first != nil {
+			warn(tr(msgSpotCheckDisagreement, spot.first))
+		}
+	}
+	if !firstFound.IsZero() {
+		status(tr(msgFirstResult, firstFound.Sub(searchStart).Round(time.Microsecond)))
+	}
+	if *firstPtr {
+		if first == nil {
+			status(tr(msgFirstNone))
+		} else if err := reportFirst(status, *first, firstAt.Sub(searchStart), form, filter, primeTestAlgorithm); err != nil && verifyErr == nil {
+			verifyErr = err
+		}
+	}
+	if explain != nil {
+		status(tr(msgCompositeSummary, countInt(compositeCount)))
+	}
+	switch onOverflow {
+	case primes.OverflowSkip:
+		status(tr(msgOverflowSkipped, countInt(stats.final.Overflowed)))
+	case primes.OverflowPromote:
+		status(tr(msgOverflowPromoted, countInt(stats.final.Overflowed)))
+	case primes.OverflowUint64:
+		status(tr(msgOverflowUint64, countInt(stats.final.Overflowed)))
+	}
+	if s := stalls.summary(); s != "" {
+		warn(s)
+	}
+	if b := stats.final.Batches; b.Size > 0 {
+		status(tr(msgBatchTargetSummary, *batchTargetPtr, b.Size, b.Min, b.Max, b.Resizes))
+	}
+	if tuned != nil {
+		status(tr(msgAutotuneSummary, tuned.Workers, tuned.BatchSize, tuned.Rate))
+	}
+	if *reversePtr {
+		status(tr(msgReverseThroughput, countInt(math.Round(throughput(stats.pairsTested.Load(), searchDuration))), countInt(stats.pairsTested.Load()), searchDuration.Round(time.Millisecond)))
+	} else {
+		status(tr(msgThroughput, countInt(math.Round(throughput(stats.pairsTested.Load(), searchDuration))), countInt(stats.pairsTested.Load()), searchDuration.Round(time.Millisecond)))
+	}
+	if table := formatDecadeTable(&stats.decades); table != "" {
+		status(table)
+	}
+	if len(stats.final.Workers) > 1 {
+		status(formatWorkerStats(stats.final.Workers, searchDuration))
+	}
+	if comparison != nil {
+		status(formatComparison(comparison))
+	}
+	status(tr(msgDuration, duration))
+	if summary != nil {
+		summary.Counts = &summaryCounts{
+			Results: count, Kept: kept, Twins: twinCount, Duplicates: duplicates, Overflowed: stats.final.Overflowed,
+			PairsTested: stats.pairsTested.Load(), PairsTotal: stats.final.Total,
+			SearchSec: searchDuration.Seconds(), PairsPerSec: throughput(stats.pairsTested.Load(), searchDuration),
+		}
+		if stalls != nil {
+			summary.Counts.Stalls, summary.Counts.Skipped = stalls.counts()
+		}
+		if !firstFound.IsZero() {
+			sec := firstFound.Sub(searchStart).Seconds()
+			summary.Counts.FirstResultSec = &sec
+		}
+		if *verifyPtr || first != nil {
+			var failures int64
+			var detail string
+			if verifyErr != nil {
+				failures, detail = 1, verifyErr.Error()
+			}
+			summary.addCheck("verify", int64(count), failures, detail)
+		}
+		if spot != nil {
+			var detail string
+			if spot.first != nil {
+				detail = spot.first.Error()
+			}
+			summary.addCheck("spot-check", int64(spot.checked), int64(spot.disagreements), detail)
+		}
+		if comparison != nil {
+			var calls int64
+			if stats := comparison.Stats(); len(stats) > 0 {
+				calls = stats[0].Calls
+			}
+			disagreements, _ := comparison.Disagreements()
+			summary.addCheck("compare", calls, disagreements, "")
+		}
+		// Une paire abandonnée par le chien de garde rend les résultats incomplets.
+		if stalls != nil && *skipStalledPtr {
+			summary.addCheck("stall-timeout", stats.pairsTested.Load(), int64(summary.Counts.Skipped), strings.TrimSpace(stalls.summary()))
+		}
+	}
+	if sweep != nil {
+		fmt.Fprint(statusOut, tr(msgSweepTitle))
+		sweep.write(statusOut, primeList)
+	}
+	if residues != nil {
+		fmt.Fprint(statusOut, tr(msgResiduesTitle))
+		residues.write(statusOut)
+	}
+	if report != nil {
+		if err := writeReport(*reportPtr, report, manifest, reportSummary{
+			Form: form.Name(), Pairs: pairMode, PrimeCount: len(primeList), PairsTested: stats.pairsTested.Load(),
+			Duration: searchDuration, Twins: *twinsPtr, Interrupted: interrupted,
+		}); err != nil {
+			return err
+		}
+		status(tr(msgReportWritten, *reportPtr))
+	}
+
+	// --- Fichier de records: uniquement pour des résultats vérifiés ou non contestés ---
+	if *recordsPtr != "" && best.N > 0 && verifyErr == nil {
+		candidate := record{N: best.N, P: best.P, Q: best.Q, Time: time.Now().UTC(), Params: params}
+		previous, ok, beaten, err := updateRecord(*recordsPtr, form.Name(), candidate)
+		if err != nil {
+			return err
+		}
+		switch {
+		case beaten:
+			status(tr(msgNewRecord, best.N, best.P, best.Q, form.Name()))
+			if ok {
+				status(tr(msgPreviousRecord, previous.N, previous.Time.Format(time.RFC3339)))
+			}
+		default:
+			status(tr(msgRecordHeld, form.Name(), previous.N, previous.Time.Format(time.RFC3339)))
+		}
+	}
+
+	// --- Fermeture et signature du fichier de résultats (sortie complète et vérifiée uniquement) ---
+	if resultsFile != nil {
+		if err := writeError(results); err != nil {
+			return err
+		}
+		if err := resultsFile.Close(); err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		if signingKey != nil && !interrupted && verifyErr == nil {
+			if err := signFile(*outputPtr, signingKey); err != nil {
+				return err
+			}
+			status(tr(msgSignatureWritten, *outputPtr+signatureSuffix))
+		}
+	}
+
+	var disagreements int64
+	if comparison != nil {
+		disagreements, _ = comparison.Disagreements()
+	}
+	switch {
+	case verifyErr != nil:
+		return verifyErr
+	case disagreements > 0:
+		return fmt.Errorf("%w: les tests comparés divergent sur %d candidats", errVerification, disagreements)
+	case spot != nil && spot.disagreements > 0:
+		return fmt.Errorf("%w: le contrôle par sondage diverge sur %d des %d résultats revérifiés", errVerification, spot.disagreements, spot.checked)
+	case interrupted:
+		return errInterrupted
+	case sinkFailures > 0:
+		return fmt.Errorf("%w: %d destination(s) de résultats en échec", errIO, sinkFailures)
+	}
+	return writeError(out)
+}
+
+// niceCPUPercent est la part de CPU par worker retenue par -nice lorsque -cpu-percent n'est pas fourni.
+const niceCPUPercent = 25
+
+// flagSet indique si l'option name a été fournie explicitement sur la ligne de commande.
+func flagSet(fs *flag.FlagSet, name string) bool {
+	found := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			found = true
+		}
+	})
+	return found
+}
+
+// throughput retourne le débit en paires testées par seconde. La durée mesurée inclut les mises
+// en veille du bridage CPU: le débit reflète donc ce que la recherche produit réellement.
+func throughput(tested int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(tested) / elapsed.Seconds()
+}
+
+// writeError convertit l'éventuelle erreur d'écriture mémorisée en erreur d'entrée/sortie.
+func writeError(out *errWriter) error {
+	if err := out.Err(); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
+
+// verifyResult revérifie un résultat de façon indépendante: la valeur de n, la primalité
+// de p et q, celle de n avec l'autre algorithme que celui utilisé pour la recherche et,
+// le cas échéant, le filtre appliqué.
+func verifyResult(res primes.Result, form primes.Form, filter primes.Filter, primeTestAlgorithm string) error {
+	p, q := int64(res.P), int64(res.Q)
+	if res.Big != nil {
+		return verifyBigResult(res, form)
+	}
+	if res.N != form.Eval(p, q) {
+		return fmt.Errorf("%w: n=%d différent de %s pour (p=%d, q=%d)", errVerification, res.N, form.Name(), p, q)
+	}
+	independent := primes.IsPrimeTrialDivision
+	if primeTestAlgorithm == "trial" {
+		independent = primes.IsPrimeMillerRabin64
+	}
+	if !independent(p) || !independent(q) || !independent(res.N) {
+		return fmt.Errorf("%w: (p=%d, q=%d, n=%d) rejeté par le test indépendant", errVerification, p, q, res.N)
+	}
+	if filter != nil && !filter.Accept(res.N) {
+		return fmt.Errorf("%w: n=%d rejeté par le filtre %s", errVerification, res.N, filter.Name())
+	}
+	return nil
+}
+
+// verifyBigResult revérifie un résultat promu au-delà d'int64 (-on-overflow promote-big ou uint64): la
+// valeur exacte de n, la primalité de p et q, et celle de n (exacte jusqu'à 2^64, probable au-delà).
+func verifyBigResult(res primes.Result, form primes.Form) error {
+	p, q := int64(res.P), int64(res.Q)
+	bf, ok := form.(primes.BigForm)
+	if !ok || res.Big.Cmp(bf.EvalBig(p, q)) != 0 {
+		return fmt.Errorf("%w: n=%v différent de %s pour (p=%d, q=%d)", errVerification, res.Big, form.Name(), p, q)
+	}
+	if !primes.IsPrimeTrialDivision(p) || !primes.IsPrimeTrialDivision(q) || !primes.IsPrimeBig(res.Big) {
+		return fmt.Errorf("%w: (p=%d, q=%d, n=%v) rejeté par le test indépendant", errVerification, p, q, res.Big)
+	}
+	return nil
 }
@@ -0,0 +1,196 @@
+/*
+ * Fichier: manifest.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Manifeste d'exécution joint aux sorties (tableau de la recherche, list-primes,
+ * min-q): identifiant de l'exécution, version et commit du binaire, hôte,
+ * heures de début et de fin, paramètres complets et algorithmes retenus, pour
+ * que chaque fichier de résultats décrive seul comment le reproduire. Les
+ * formats texte le portent en lignes de commentaire "# clé: valeur", les
+ * formats JSON dans un champ "manifest".
+ */
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runManifest décrit une exécution et la façon de la reproduire.
+type runManifest struct {
+	RunID      string            `json:"run_id"`
+	Command    string            `json:"command"`
+	Version    string            `json:"version"`
+	Commit     string            `json:"commit,omitempty"`
+	GoVersion  string            `json:"go_version"`
+	Host       string            `json:"host"`
+	Start      time.Time         `json:"start"`
+	End        time.Time         `json:"end,omitzero"`
+	Args       []string          `json:"args"`
+	Params     map[string]string `json:"params"`
+	Algorithms map[string]string `json:"algorithms"`
+
+	reread bool // Relu d'un fichier de résultats (convert): l'heure de fin n'est pas remplacée.
+}
+
+// newManifest crée le manifeste de la commande command: fs fournit la valeur effective de
+// chaque option (y compris celles laissées par défaut), algorithms les choix d'algorithmes.
+func newManifest(command string, args []string, fs *flag.FlagSet, algorithms map[string]string, start time.Time) *runManifest {
+	m := &runManifest{
+		RunID:      rand.Text(),
+		Command:    command,
+		Version:    "(devel)",
+		GoVersion:  runtime.Version(),
+		Start:      start.UTC(),
+		Args:       slices.Clone(args),
+		Params:     map[string]string{},
+		Algorithms: algorithms,
+	}
+	if m.Args == nil {
+		m.Args = []string{}
+	}
+	m.Host, _ = os.Hostname()
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Version != "" {
+			m.Version = info.Main.Version
+		}
+		m.Commit = buildRevision(info.Settings)
+	}
+	fs.VisitAll(func(f *flag.Flag) { m.Params[f.Name] = f.Value.String() })
+	return m
+}
+
+// buildRevision retourne le commit VCS du binaire, suffixé de "+dirty" si l'arbre était modifié.
+func buildRevision(settings []debug.BuildSetting) string {
+	revision, modified := "", false
+	for _, s := range settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if revision != "" && modified {
+		revision += "+dirty"
+	}
+	return revision
+}
+
+// finish fixe l'heure de fin de l'exécution, sauf pour un manifeste relu (convert), qui garde
+// celle de l'exécution d'origine.
+func (m *runManifest) finish() {
+	if !m.reread {
+		m.End = time.Now().UTC()
+	}
+}
+
+// manifestEntry est un champ du manifeste sous forme clé, valeur.
+type manifestEntry struct{ Key, Value string }
+
+// entries retourne les champs du manifeste, clés des options et des algorithmes triées, sans l'heure de fin.
+func (m *runManifest) entries() []manifestEntry {
+	entries := []manifestEntry{{"run_id", m.RunID}, {"command", m.Command}, {"version", m.Version}}
+	if m.Commit != "" {
+		entries = append(entries, manifestEntry{"commit", m.Commit})
+	}
+	entries = append(entries,
+		manifestEntry{"go_version", m.GoVersion},
+		manifestEntry{"host", m.Host},
+		manifestEntry{"start", m.Start.Format(time.RFC3339Nano)},
+		manifestEntry{"args", fmt.Sprintf("%q", m.Args)})
+	for _, k := range slices.Sorted(maps.Keys(m.Params)) {
+		entries = append(entries, manifestEntry{"param." + k, m.Params[k]})
+	}
+	for _, k := range slices.Sorted(maps.Keys(m.Algorithms)) {
+		entries = append(entries, manifestEntry{"algorithm." + k, m.Algorithms[k]})
+	}
+	return entries
+}
+
+// writeHeader écrit le manifeste en lignes de commentaire "# clé: valeur", clés triées. Sans
+// params, la valeur de chaque option (param.*) est omise: la ligne args décrit alors seule la
+// commande, ce qui suffit à un lecteur humain.
+func (m *runManifest) writeHeader(w io.Writer, params bool) {
+	for _, e := range m.entries() {
+		if !params && strings.HasPrefix(e.Key, "param.") {
+			continue
+		}
+		fmt.Fprintln(w, strings.TrimSpace(fmt.Sprintf("# %s: %s", e.Key, e.Value)))
+	}
+}
+
+// parseManifestComments relit le manifeste écrit par writeHeader et writeTrailer parmi les lignes
+// de commentaire lines ("# clé: valeur"); nil si elles n'en portent pas (pas d'identifiant).
+func parseManifestComments(lines []string) *runManifest {
+	m := &runManifest{Args: []string{}, Params: map[string]string{}, Algorithms: map[string]string{}}
+	for _, line := range lines {
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "#"), ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case key == "run_id":
+			m.RunID = value
+		case key == "command":
+			m.Command = value
+		case key == "version":
+			m.Version = value
+		case key == "commit":
+			m.Commit = value
+		case key == "go_version":
+			m.GoVersion = value
+		case key == "host":
+			m.Host = value
+		case key == "start":
+			m.Start, _ = time.Parse(time.RFC3339Nano, value)
+		case key == "end":
+			m.End, _ = time.Parse(time.RFC3339Nano, value)
+		case key == "args":
+			m.Args = parseQuotedList(value)
+		case strings.HasPrefix(key, "param."):
+			m.Params[strings.TrimPrefix(key, "param.")] = value
+		case strings.HasPrefix(key, "algorithm."):
+			m.Algorithms[strings.TrimPrefix(key, "algorithm.")] = value
+		}
+	}
+	if m.RunID == "" {
+		return nil
+	}
+	return m
+}
+
+// parseQuotedList relit une liste écrite par %q (["a" "b"]); les éléments illisibles sont ignorés.
+func parseQuotedList(s string) []string {
+	list := []string{}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			break
+		}
+		v, _ := strconv.Unquote(quoted)
+		list = append(list, v)
+		s = s[len(quoted):]
+	}
+	return list
+}
+
+// writeTrailer termine le manifeste textuel par l'heure de fin, connue une fois la sortie écrite.
+func (m *runManifest) writeTrailer(w io.Writer) {
+	m.finish()
+	fmt.Fprintf(w, "# end: %s\n", m.End.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,97 @@
+/*
+ * Fichier: manifest_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du manifeste d'exécution joint aux sorties.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBuildRevision valide l'extraction du commit et le marquage d'un arbre modifié.
+func TestBuildRevision(t *testing.T) {
+	testCases := []struct {
+		settings []debug.BuildSetting
+		expected string
+	}{
+		{nil, ""},
+		{[]debug.BuildSetting{{Key: "vcs.revision", Value: "abc123"}, {Key: "vcs.modified", Value: "false"}}, "abc123"},
+		{[]debug.BuildSetting{{Key: "vcs.modified", Value: "true"}, {Key: "vcs.revision", Value: "abc123"}}, "abc123+dirty"},
+	}
+	for _, tc := range testCases {
+		if got := buildRevision(tc.settings); got != tc.expected {
+			t.Errorf("buildRevision(%v) = %q, attendu %q", tc.settings, got, tc.expected)
+		}
+	}
+}
+
+// TestSearchManifest valide que le tableau de la recherche est encadré par le manifeste, sauf avec
+// -manifest=false, et que la valeur de chaque option n'y figure qu'avec -manifest-verbose.
+func TestSearchManifest(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-limit", "30", "-primetest", "trial", "-filter", "safe"}, &out, io.Discard); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	for _, want := range []string{"\n# run_id: ", "\n# command: PrimeNumber\n", "\n# args: [\"-limit\" \"30\" ",
+		"\n# algorithm.sieve: eratosthenes\n", "\n# algorithm.primetest: trial\n", "\n# algorithm.filter: safe\n", "\n# end: "} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("sortie sans %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Index(out.String(), "# run_id: ") > strings.Index(out.String(), "| n = p^2+4q^2") {
+		t.Errorf("manifeste après l'en-tête du tableau:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "# param.") {
+		t.Errorf("valeurs des options sans -manifest-verbose:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run([]string{"-limit", "30", "-manifest-verbose"}, &out, io.Discard); err != nil {
+		t.Fatalf("run -manifest-verbose: %v", err)
+	}
+	for _, want := range []string{"\n# param.limit: 30\n", "\n# param.workers: "} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("-manifest-verbose: sortie sans %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := run([]string{"-limit", "30", "-manifest=false"}, &out, io.Discard); err != nil {
+		t.Fatalf("run -manifest=false: %v", err)
+	}
+	if strings.Contains(out.String(), "# ") {
+		t.Errorf("manifeste présent malgré -manifest=false:\n%s", out.String())
+	}
+}
+
+// TestParseManifestComments relit le manifeste textuel de writeHeader et writeTrailer, arguments
+// comportant espaces et guillemets compris.
+func TestParseManifestComments(t *testing.T) {
+	want := &runManifest{
+		RunID: "ABC", Command: "PrimeNumber", Version: "(devel)", Commit: "abc123", GoVersion: "go1.24", Host: "h",
+		Start:      time.Date(2026, 10, 16, 12, 0, 0, 123456789, time.UTC),
+		Args:       []string{"-limit", "100", `-form=p^2 + "q"`},
+		Params:     map[string]string{"limit": "100", "form": "p^2+4q^2"},
+		Algorithms: map[string]string{"primetest": "miller"},
+	}
+	var buf bytes.Buffer
+	want.writeHeader(&buf, true)
+	want.writeTrailer(&buf)
+	got := parseManifestComments(strings.Split(strings.TrimSpace(buf.String()), "\n"))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseManifestComments() = %+v, attendu %+v", got, want)
+	}
+	if got := parseManifestComments([]string{"# p: 3", "# note"}); got != nil {
+		t.Errorf("commentaires sans run_id: %+v, attendu nil", got)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Fichier: memguard.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Garde-fou mémoire (option -max-memory). L'empreinte de la recherche est
+ * estimée avant de commencer et l'exécution est refusée si elle dépasse le
+ * budget. Pendant la recherche, runtime.MemStats est échantillonné
+ * périodiquement et la file des tâches en attente est réduite lorsque
+ * l'utilisation approche du budget, puis rétablie lorsqu'elle redescend.
+ */
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+const (
+	// memoryHighWater est la fraction du budget au-delà de laquelle la file des tâches est réduite.
+	memoryHighWater = 0.9
+	// memoryLowWater est la fraction du budget en deçà de laquelle la file des tâches est rétablie.
+	memoryLowWater = 0.6
+	// memorySampleInterval est l'intervalle d'échantillonnage de runtime.MemStats.
+	memorySampleInterval = time.Second
+)
+
+// byteUnits associe les suffixes acceptés par parseByteSize à leur multiplicateur (puissances de 1024).
+var byteUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30}, {"tib", 1 << 40},
+	{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30}, {"tb", 1 << 40},
+	{"k", 1 << 10}, {"m", 1 << 20}, {"g", 1 << 30}, {"t", 1 << 40},
+	{"b", 1},
+}
+
+// parseByteSize analyse une taille mémoire telle que "512MiB", "2G" ou "1048576".
+// Les suffixes sont insensibles à la casse et interprétés en puissances de 1024.
+func parseByteSize(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	factor := int64(1)
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			factor = unit.factor
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("taille mémoire invalide %q", s)
+	}
+	return int64(n * float64(factor)), nil
+}
+
+// formatBytes formate une taille en octets avec l'unité binaire la plus adaptée.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// heapInUse retourne la mémoire actuellement utilisée par le tas et les piles des goroutines.
+func heapInUse() int64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return int64(m.HeapAlloc + m.StackInuse)
+}
+
+// memoryGuard ajuste la file des tâches en attente pour rester sous le budget mémoire.
+type memoryGuard struct {
+	budget    int64
+	ctl       *primes.Control
+	baseQueue int          // Capacité nominale de la file des tâches.
+	readMem   func() int64 // Mémoire utilisée; heapInUse hors tests.
+	logf      func(string) // Journalisation des ajustements.
+	prevLimit int64        // Limite mémoire souple du runtime avant l'application du budget.
+}
+
+// newMemoryGuard crée un garde-fou et applique le budget comme limite mémoire souple du runtime,
+// ce qui rend le ramasse-miettes plus agressif à l'approche du budget.
+func newMemoryGuard(budget int64, ctl *primes.Control, baseQueue int, logf func(string)) *memoryGuard {
+	g := &memoryGuard{budget: budget, ctl: ctl, baseQueue: max(baseQueue, 1), readMem: heapInUse, logf: logf}
+	g.prevLimit = debug.SetMemoryLimit(budget)
+	return g
+}
+
+// release rétablit la limite mémoire souple du runtime en vigueur avant le garde-fou.
+func (g *memoryGuard) release() {
+	debug.SetMemoryLimit(g.prevLimit)
+}
+
+// check échantillonne la mémoire utilisée et divise par deux (ou double) la limite de la file
+// des tâches selon que l'utilisation dépasse le seuil haut (ou redescend sous le seuil bas).
+func (g *memoryGuard) check() {
+	used := g.readMem()
+	limit := g.ctl.QueueLimit()
+	if limit == 0 {
+		limit = g.baseQueue
+	}
+
+	switch {
+	case float64(used) > memoryHighWater*float64(g.budget) && limit > 1:
+		limit = max(1, limit/2)
+		g.ctl.SetQueueLimit(limit)
+		g.logf(tr(msgMemoryDownshift, formatBytes(used), formatBytes(g.budget), limit))
+	case float64(used) < memoryLowWater*float64(g.budget) && limit < g.baseQueue:
+		limit = min(g.baseQueue, limit*2)
+		if limit == g.baseQueue {
+			g.ctl.SetQueueLimit(0)
+		} else {
+			g.ctl.SetQueueLimit(limit)
+		}
+		g.logf(tr(msgMemoryUpshift, formatBytes(used), limit))
+	}
+}
+
+// run échantillonne la mémoire à intervalle régulier jusqu'à la fermeture de done.
+func (g *memoryGuard) run(done <-chan struct{}) {
+	ticker := time.NewTicker(memorySampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			g.check()
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Fichier: memguard_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du garde-fou mémoire: analyse des tailles et ajustement de la file des tâches.
+ */
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestParseByteSize valide l'analyse des tailles mémoire.
+func TestParseByteSize(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected int64
+		wantErr  bool
+	}{
+		{"1048576", 1 << 20, false},
+		{"512MiB", 512 << 20, false},
+		{"2G", 2 << 30, false},
+		{"1.5 gb", 3 << 29, false},
+		{"64k", 64 << 10, false},
+		{"10B", 10, false},
+		{"beaucoup", 0, true},
+		{"-1M", 0, true},
+	}
+
+	for _, tc := range testCases {
+		got, err := parseByteSize(tc.in)
+		if (err != nil) != tc.wantErr || got != tc.expected {
+			t.Errorf("parseByteSize(%q) = %d, %v; attendu %d (erreur: %v)", tc.in, got, err, tc.expected, tc.wantErr)
+		}
+	}
+}
+
+// TestFormatBytes valide le formatage des tailles mémoire.
+func TestFormatBytes(t *testing.T) {
+	testCases := map[int64]string{
+		512:     "512 B",
+		1536:    "1.5 KiB",
+		5 << 20: "5.0 MiB",
+		3 << 30: "3.0 GiB",
+	}
+	for n, expected := range testCases {
+		if got := formatBytes(n); got != expected {
+			t.Errorf("formatBytes(%d) = %q, attendu %q", n, got, expected)
+		}
+	}
+}
+
+// TestMemoryGuardCheck valide la réduction puis le rétablissement de la file des tâches.
+func TestMemoryGuardCheck(t *testing.T) {
+	ctl := primes.NewControl()
+	used := int64(0)
+	g := &memoryGuard{budget: 1000, ctl: ctl, baseQueue: 8, readMem: func() int64 { return used }, logf: func(string) {}}
+
+	used = 950 // Au-delà du seuil haut.
+	g.check()
+	if ctl.QueueLimit() != 4 {
+		t.Fatalf("QueueLimit() = %d après dépassement, attendu 4", ctl.QueueLimit())
+	}
+	g.check()
+	if ctl.QueueLimit() != 2 {
+		t.Fatalf("QueueLimit() = %d après second dépassement, attendu 2", ctl.QueueLimit())
+	}
+
+	used = 700 // Entre les deux seuils: pas de changement.
+	g.check()
+	if ctl.QueueLimit() != 2 {
+		t.Fatalf("QueueLimit() = %d entre les seuils, attendu 2", ctl.QueueLimit())
+	}
+
+	used = 100 // Sous le seuil bas: rétablissement progressif.
+	g.check()
+	if ctl.QueueLimit() != 4 {
+		t.Fatalf("QueueLimit() = %d après baisse, attendu 4", ctl.QueueLimit())
+	}
+	g.check()
+	if ctl.QueueLimit() != 0 {
+		t.Errorf("QueueLimit() = %d après rétablissement complet, attendu 0 (pas de limite)", ctl.QueueLimit())
+	}
+}
+
+// TestRunMemoryBudget valide le refus de démarrer lorsque l'estimation dépasse le budget.
+func TestRunMemoryBudget(t *testing.T) {
+	if err := run([]string{"-limit", "10000000", "-max-memory", "1MiB"}, io.Discard, io.Discard); exitCode(err) != exitMemory {
+		t.Errorf("run avec budget insuffisant: %v, attendu le code %d", err, exitMemory)
+	}
+	if err := run([]string{"-limit", "100", "-max-memory", "64MiB"}, io.Discard, io.Discard); err != nil {
+		t.Errorf("run avec budget suffisant: %v", err)
+	}
+	if err := run([]string{"-max-memory", "x"}, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+		t.Errorf("run avec budget invalide: %v, attendu le code %d", err, exitInvalidFlags)
+	}
+}
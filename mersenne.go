@@ -0,0 +1,46 @@
+/*
+ * Fichier: mersenne.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande mersenne: teste la primalité de 2^P - 1 par le test de
+ * Lucas-Lehmer (primes.IsMersennePrime).
+ */
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// runMersenne implémente la sous-commande mersenne.
+func runMersenne(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("mersenne", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	exponentPtr := fs.Int("p", 0, tr(msgFlagMersenneP))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *exponentPtr < 2 {
+		return fmt.Errorf("%w: mersenne: -p=%d (attendu >= 2)", errInvalidFlags, *exponentPtr)
+	}
+
+	out := &errWriter{w: stdout}
+	start := time.Now()
+	verdict := tr(msgMersenneComposite)
+	if primes.IsMersennePrime(*exponentPtr) {
+		verdict = tr(msgMersennePrime)
+	}
+	fmt.Fprint(out, tr(msgMersenneResult, *exponentPtr, verdict, time.Since(start).Round(time.Microsecond)))
+	return writeError(out)
+}
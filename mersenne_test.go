@@ -0,0 +1,39 @@
+/*
+ * Fichier: mersenne_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande mersenne.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRunMersenne valide la sous-commande de bout en bout.
+func TestRunMersenne(t *testing.T) {
+	testCases := []struct {
+		p        string
+		expected string
+	}{
+		{"127", "2^127 - 1: premier"},
+		{"11", "2^11 - 1: composé"},
+	}
+	for _, tc := range testCases {
+		var out bytes.Buffer
+		if err := run([]string{"mersenne", "-p", tc.p, "-lang", "fr"}, &out, io.Discard); err != nil {
+			t.Fatalf("mersenne -p %s: %v", tc.p, err)
+		}
+		if !strings.HasPrefix(out.String(), tc.expected) {
+			t.Errorf("sortie = %q, attendu le préfixe %q", out.String(), tc.expected)
+		}
+	}
+	if got := exitCode(run([]string{"mersenne"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("sans -p -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
@@ -0,0 +1,1237 @@
+/*
+ * Fichier: messages.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Catalogue des messages affichés par le programme (aide, en-têtes, résumé,
+ * interface terminal) en anglais et en français. La langue est choisie par
+ * l'option -lang ou, à défaut, par les variables LC_ALL, LC_MESSAGES et LANG;
+ * le français reste la langue par défaut lorsque rien n'est configuré.
+ */
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// msgID identifie un message du catalogue.
+type msgID string
+
+// Identifiants des messages. Chaque identifiant doit avoir une traduction dans chaque langue supportée.
+const (
+	msgUsage                  msgID = "usage"
+	msgFlagLimit              msgID = "flag.limit"
+	msgFlagPrimeTest          msgID = "flag.primetest"
+	msgFlagAccel              msgID = "flag.accel"
+	msgFlagDashboard          msgID = "flag.dashboard"
+	msgFlagTUI                msgID = "flag.tui"
+	msgFlagLang               msgID = "flag.lang"
+	msgFlagVerify             msgID = "flag.verify"
+	msgFlagLogFile            msgID = "flag.logfile"
+	msgFlagLogMaxSize         msgID = "flag.logmaxsize"
+	msgFlagLogMaxAge          msgID = "flag.logmaxage"
+	msgFlagLogMaxBackups      msgID = "flag.logmaxbackups"
+	msgFlagMaxMemory          msgID = "flag.maxmemory"
+	msgMemoryEstimate         msgID = "memory.estimate"
+	msgMemoryDownshift        msgID = "memory.downshift"
+	msgMemoryUpshift          msgID = "memory.upshift"
+	msgFlagWorkers            msgID = "flag.workers"
+	msgFlagBatch              msgID = "flag.batch"
+	msgFlagAutotune           msgID = "flag.autotune"
+	msgFlagAutotuneBurst      msgID = "flag.autotuneburst"
+	msgAutotuneStart          msgID = "autotune.start"
+	msgAutotuneSummary        msgID = "autotune.summary"
+	msgFlagCPUPercent         msgID = "flag.cpupercent"
+	msgFlagNice               msgID = "flag.nice"
+	msgNiceWarning            msgID = "nice.warning"
+	msgCPULimit               msgID = "cpulimit"
+	msgThroughput             msgID = "throughput"
+	msgFlagStatusSocket       msgID = "flag.statussocket"
+	msgFlagStatusJSON         msgID = "flag.statusjson"
+	msgStatusSocketError      msgID = "statussocket.error"
+	msgStatusSocketReady      msgID = "statussocket.ready"
+	msgStatusUnreachable      msgID = "status.unreachable"
+	msgStatusRunning          msgID = "status.running"
+	msgStatusPaused           msgID = "status.paused"
+	msgStatusDone             msgID = "status.done"
+	msgStatusSummary          msgID = "status.summary"
+	msgStatusProgress         msgID = "status.progress"
+	msgSignalPaused           msgID = "signal.paused"
+	msgSignalResumed          msgID = "signal.resumed"
+	msgFlagListLimit          msgID = "flag.list.limit"
+	msgFlagListFormat         msgID = "flag.list.format"
+	msgFlagListOutput         msgID = "flag.list.output"
+	msgFlagPrimesCache        msgID = "flag.primescache"
+	msgCacheHit               msgID = "cache.hit"
+	msgCacheInvalid           msgID = "cache.invalid"
+	msgCacheWritten           msgID = "cache.written"
+	msgCacheWriteError        msgID = "cache.writeerror"
+	msgFlagPrimesFile         msgID = "flag.primesfile"
+	msgFlagPrimesFileFormat   msgID = "flag.primesfileformat"
+	msgFlagPrimesFileCheck    msgID = "flag.primesfilecheck"
+	msgPrimesFileLoaded       msgID = "primesfile.loaded"
+	msgCountUsage             msgID = "count.usage"
+	msgNthUsage               msgID = "nth.usage"
+	msgFlagForm               msgID = "flag.form"
+	msgFlagFilter             msgID = "flag.filter"
+	msgFlagTwins              msgID = "flag.twins"
+	msgTwinMark               msgID = "twin.mark"
+	msgTwinSummary            msgID = "twin.summary"
+	msgFlagMersenneP          msgID = "flag.mersenne.p"
+	msgMersennePrime          msgID = "mersenne.prime"
+	msgMersenneComposite      msgID = "mersenne.composite"
+	msgMersenneResult         msgID = "mersenne.result"
+	msgFlagGoldbachLimit      msgID = "flag.goldbach.limit"
+	msgGoldbachVerified       msgID = "goldbach.verified"
+	msgGoldbachHardest        msgID = "goldbach.hardest"
+	msgGoldbachCounterexample msgID = "goldbach.counterexample"
+	msgFlagExplainComposites  msgID = "flag.explain.composites"
+	msgCompositeMark          msgID = "composite.mark"
+	msgCompositeSummary       msgID = "composite.summary"
+	msgFactorUsage            msgID = "factor.usage"
+	msgFlagMinQLimit          msgID = "flag.minq.limit"
+	msgFlagMinQFormat         msgID = "flag.minq.format"
+	msgMinQNone               msgID = "minq.none"
+	msgFlagRecords            msgID = "flag.records"
+	msgNewRecord              msgID = "record.new"
+	msgPreviousRecord         msgID = "record.previous"
+	msgRecordHeld             msgID = "record.held"
+	msgFlagManifest           msgID = "flag.manifest"
+	msgFlagManifestVerbose    msgID = "flag.manifest.verbose"
+	msgFlagOutput             msgID = "flag.output"
+	msgFlagSign               msgID = "flag.sign"
+	msgFlagVerifyKey          msgID = "flag.verify.key"
+	msgFlagVerifySig          msgID = "flag.verify.sig"
+	msgVerifySignatureUsage   msgID = "verifysig.usage"
+	msgSignatureOK            msgID = "verifysig.ok"
+	msgChecksumOnly           msgID = "verifysig.checksum"
+	msgSignatureWritten       msgID = "sign.written"
+	msgFlagResultFormat       msgID = "flag.format"
+	msgDiffUsage              msgID = "diff.usage"
+	msgDiffParamMismatch      msgID = "diff.param"
+	msgDiffSummary            msgID = "diff.summary"
+	msgFlagSweep              msgID = "flag.sweep"
+	msgSweepTitle             msgID = "sweep.title"
+	msgFlagTimeSeries         msgID = "flag.timeseries"
+	msgFlagTimeSeriesInterval msgID = "flag.timeseries.interval"
+	msgFlagTimeSeriesFormat   msgID = "flag.timeseries.format"
+	msgFlagStatsInterval      msgID = "flag.stats.interval"
+	msgStatsLine              msgID = "stats.line"
+	msgFlagSample             msgID = "flag.sample"
+	msgFlagSeed               msgID = "flag.seed"
+	msgSampleStart            msgID = "sample.start"
+	msgSampleDensity          msgID = "sample.density"
+	msgSampleExpected         msgID = "sample.expected"
+	msgFlagResidues           msgID = "flag.residues"
+	msgResiduesTitle          msgID = "residues.title"
+	msgAnalyzeUsage           msgID = "analyze.usage"
+	msgFlagBiasMod            msgID = "flag.bias.mod"
+	msgFlagBiasClasses        msgID = "flag.bias.classes"
+	msgBiasTitle              msgID = "bias.title"
+	msgBiasRace               msgID = "bias.race"
+	msgBiasFirstLead          msgID = "bias.first_lead"
+	msgBiasNoLead             msgID = "bias.no_lead"
+	msgBiasMaxGap             msgID = "bias.max_gap"
+	msgFlagPairs              msgID = "flag.pairs"
+	msgFlagReport             msgID = "flag.report"
+	msgReportWritten          msgID = "report.written"
+	msgFlagExplain            msgID = "flag.explain"
+	msgExplainHeader          msgID = "explain.header"
+	msgExplainBelowTwo        msgID = "explain.below_two"
+	msgExplainSmallPrime      msgID = "explain.small_prime"
+	msgExplainEven            msgID = "explain.even"
+	msgExplainDecomposition   msgID = "explain.decomposition"
+	msgExplainRound           msgID = "explain.round"
+	msgExplainOne             msgID = "explain.one"
+	msgExplainMinusOne        msgID = "explain.minus_one"
+	msgExplainWitness         msgID = "explain.witness"
+	msgExplainPrime           msgID = "explain.prime"
+	msgFlagErrorBound         msgID = "flag.error_bound"
+	msgAdaptivePolicy         msgID = "adaptive.policy"
+	msgWorkerStatsTitle       msgID = "workers.title"
+	msgWorkerStatsLine        msgID = "workers.line"
+	msgWorkerImbalance        msgID = "workers.imbalance"
+	msgFlagSink               msgID = "flag.sink"
+	msgSinkFailed             msgID = "sink.failed"
+	msgFlagWhere              msgID = "flag.where"
+	msgWhereSummary           msgID = "where.summary"
+	msgFlagTop                msgID = "flag.top"
+	msgFlagBy                 msgID = "flag.by"
+	msgTopSummary             msgID = "top.summary"
+	msgChunksUsage            msgID = "chunks.usage"
+	msgFlagChunksDir          msgID = "flag.chunks.dir"
+	msgFlagChunksCount        msgID = "flag.chunks.count"
+	msgFlagChunkName          msgID = "flag.chunks.chunk"
+	msgFlagChunksVerify       msgID = "flag.chunks.verify"
+	msgChunkRunning           msgID = "chunks.running"
+	msgChunkDone              msgID = "chunks.done"
+	msgChunkPending           msgID = "chunks.pending"
+	msgChunksSummary          msgID = "chunks.summary"
+	msgChunkSkipped           msgID = "chunks.skipped"
+	msgChunkVerified          msgID = "chunks.verified"
+	msgChunkMismatch          msgID = "chunks.mismatch"
+	msgChunkRecomputed        msgID = "chunks.recomputed"
+	msgChunkFileAltered       msgID = "chunks.file_altered"
+	msgFlagCompare            msgID = "flag.compare"
+	msgCompareTitle           msgID = "compare.title"
+	msgCompareLine            msgID = "compare.line"
+	msgCompareAgree           msgID = "compare.agree"
+	msgCompareDisagree        msgID = "compare.disagree"
+	msgFlagPrimorialN         msgID = "flag.primorial.n"
+	msgFlagPrimorialSearch    msgID = "flag.primorial.search"
+	msgFlagPrimorialFactorial msgID = "flag.primorial.factorial"
+	msgPrimorialValue         msgID = "primorial.value"
+	msgPrimorialFound         msgID = "primorial.found"
+	msgPrimorialSummary       msgID = "primorial.summary"
+	msgFlagStatusSnapshot     msgID = "flag.status.snapshot"
+	msgStatusSnapshotError    msgID = "status.snapshot.error"
+	msgStatusSnapshotWritten  msgID = "status.snapshot.written"
+	msgStatusSnapshotOmitted  msgID = "status.snapshot.omitted"
+	msgSDNotifyError          msgID = "sdnotify.error"
+	msgFlagNumbers            msgID = "flag.numbers"
+	msgRecordMark             msgID = "record.mark"
+	msgFlagColor              msgID = "flag.color"
+	msgFlagOnOverflow         msgID = "flag.on_overflow"
+	msgOverflowSkipped        msgID = "overflow.skipped"
+	msgOverflowPromoted       msgID = "overflow.promoted"
+	msgOverflowUint64         msgID = "overflow.uint64"
+	msgFlagWitnessSource      msgID = "flag.witness_source"
+	msgCheckUsage             msgID = "check.usage"
+	msgFlagCheckInput         msgID = "flag.check_input"
+	msgCheckSummary           msgID = "check.summary"
+	msgCertifyUsage           msgID = "certify.usage"
+	msgFlagCertifyResults     msgID = "flag.certify.results"
+	msgFlagCertifyOutput      msgID = "flag.certify.output"
+	msgFlagCertifyVerify      msgID = "flag.certify.verify"
+	msgFlagCertifyTimeout     msgID = "flag.certify.timeout"
+	msgCertifyProved          msgID = "certify.proved"
+	msgCertifyComposite       msgID = "certify.composite"
+	msgCertifyNoProof         msgID = "certify.noproof"
+	msgCertifySummary         msgID = "certify.summary"
+	msgCertifyValid           msgID = "certify.valid"
+	msgCertifyInvalid         msgID = "certify.invalid"
+	msgCertifyVerifySummary   msgID = "certify.verify_summary"
+	msgServeUsage             msgID = "serve.usage"
+	msgFlagServeListen        msgID = "flag.serve.listen"
+	msgFlagServeDir           msgID = "flag.serve.dir"
+	msgFlagServeMaxConcurrent msgID = "flag.serve.max_concurrent"
+	msgFlagServeWorkers       msgID = "flag.serve.workers"
+	msgFlagServeLocal         msgID = "flag.serve.local"
+	msgFlagServeLeaseTTL      msgID = "flag.serve.lease_ttl"
+	msgFlagServeTokens        msgID = "flag.serve.tokens"
+	msgFlagServeRate          msgID = "flag.serve.rate"
+	msgFlagServeBurst         msgID = "flag.serve.burst"
+	msgFlagServeMaxSearches   msgID = "flag.serve.max_searches"
+	msgFlagServeOnDuplicate   msgID = "flag.serve.on_duplicate"
+	msgFlagServeSchedule      msgID = "flag.serve.schedule"
+	msgFlagServeConfig        msgID = "flag.serve.config"
+	msgServeListening         msgID = "serve.listening"
+	msgServeStopped           msgID = "serve.stopped"
+	msgServeSearchStarted     msgID = "serve.search_started"
+	msgServeSearchDone        msgID = "serve.search_done"
+	msgServeSearchFailed      msgID = "serve.search_failed"
+	msgServeError             msgID = "serve.error"
+	msgServeLeaseGranted      msgID = "serve.lease_granted"
+	msgServeLeasesExpired     msgID = "serve.leases_expired"
+	msgServeScheduleFired     msgID = "serve.schedule_fired"
+	msgServeScheduleBusy      msgID = "serve.schedule_busy"
+	msgClientUsage            msgID = "client.usage"
+	msgFlagClientServer       msgID = "flag.client.server"
+	msgFlagClientToken        msgID = "flag.client.token"
+	msgFlagClientOnDuplicate  msgID = "flag.client.on_duplicate"
+	msgFlagClientAbove        msgID = "flag.client.above"
+	msgFlagClientChunks       msgID = "flag.client.chunks"
+	msgFlagClientWorkers      msgID = "flag.client.workers"
+	msgFlagClientJSON         msgID = "flag.client.json"
+	msgFlagClientNMin         msgID = "flag.client.n_min"
+	msgFlagClientNMax         msgID = "flag.client.n_max"
+	msgFlagClientPage         msgID = "flag.client.page"
+	msgFlagClientOutput       msgID = "flag.client.output"
+	msgClientSearch           msgID = "client.search"
+	msgClientSearchError      msgID = "client.search_error"
+	msgClientResults          msgID = "client.results"
+	msgFlagClientWorkName     msgID = "flag.client.work.name"
+	msgFlagClientWorkWorkers  msgID = "flag.client.work.workers"
+	msgFlagClientWorkPoll     msgID = "flag.client.work.poll"
+	msgFlagClientWorkOnce     msgID = "flag.client.work.once"
+	msgClientWorkDone         msgID = "client.work.done"
+	msgClientWorkDuplicate    msgID = "client.work.duplicate"
+	msgClientWorkLost         msgID = "client.work.lost"
+	msgClientWorkRetry        msgID = "client.work.retry"
+	msgStreamUsage            msgID = "stream.usage"
+	msgFlagStreamAll          msgID = "flag.stream_all"
+	msgStreamSummary          msgID = "stream.summary"
+	msgFlagTiming             msgID = "flag.timing"
+	msgColumnFoundAt          msgID = "column.found_at"
+	msgColumnTestTime         msgID = "column.test_time"
+	msgFirstResult            msgID = "summary.first_result"
+	msgFlagDedup              msgID = "flag.dedup"
+	msgDedupSummary           msgID = "summary.dedup"
+	msgFlagPreset             msgID = "flag.preset"
+	msgPresetApplied          msgID = "preset.applied"
+	msgFlagSpotCheck          msgID = "flag.spotcheck"
+	msgSpotCheckSummary       msgID = "summary.spotcheck"
+	msgSpotCheckDisagreement  msgID = "summary.spotcheck.disagreement"
+	msgRangeUsage             msgID = "range.usage"
+	msgFlagRangeCount         msgID = "flag.range.count"
+	msgDecomposeUsage         msgID = "decompose.usage"
+	msgFlagDecomposeResults   msgID = "flag.decompose.results"
+	msgDecomposeNone          msgID = "decompose.none"
+	msgDecomposeSummary       msgID = "decompose.summary"
+	msgFlagReverse            msgID = "flag.reverse"
+	msgReverseThroughput      msgID = "throughput.reverse"
+	msgPseudoUsage            msgID = "pseudoprimes.usage"
+	msgFlagPseudoBase         msgID = "flag.pseudoprimes.base"
+	msgFlagPseudoLimit        msgID = "flag.pseudoprimes.limit"
+	msgFlagPseudoTest         msgID = "flag.pseudoprimes.test"
+	msgPseudoSummary          msgID = "pseudoprimes.summary"
+	msgFlagJobsBuffer         msgID = "flag.jobs-buffer"
+	msgFlagResultsBuffer      msgID = "flag.results-buffer"
+	msgBuffers                msgID = "buffers"
+	msgBufferAdaptive         msgID = "buffer.adaptive"
+	msgFlagAPK                msgID = "flag.ap-k"
+	msgFlagAPResults          msgID = "flag.ap-results"
+	msgAPTitle                msgID = "ap.title"
+	msgAPCount                msgID = "ap.count"
+	msgAPNone                 msgID = "ap.none"
+	msgAPLongest              msgID = "ap.longest"
+	msgAPTerm                 msgID = "ap.term"
+	msgAPMore                 msgID = "ap.more"
+	msgDecadesTitle           msgID = "decades.title"
+	msgDecadesLine            msgID = "decades.line"
+	msgDecadesBig             msgID = "decades.big"
+	msgStatsDecades           msgID = "stats.decades"
+	msgFlagCPUQuota           msgID = "flag.cpu-quota"
+	msgCPUQuota               msgID = "cpu.quota"
+	msgFlagSort               msgID = "flag.sort"
+	msgFlagSortMemory         msgID = "flag.sort-memory"
+	msgFlagSortDir            msgID = "flag.sort-dir"
+	msgSortSummary            msgID = "summary.sort"
+	msgFlagPlugin             msgID = "flag.plugin"
+	msgPluginLoaded           msgID = "plugin.loaded"
+	msgFlagUnrepMax           msgID = "flag.unrepresented-max"
+	msgUnrepTitle             msgID = "unrepresented.title"
+	msgUnrepReasons           msgID = "unrepresented.reasons"
+	msgUnrepSmallest          msgID = "unrepresented.smallest"
+	msgUnrepTerm              msgID = "unrepresented.term"
+	msgUnrepNotX              msgID = "unrepresented.not-x"
+	msgUnrepNotY              msgID = "unrepresented.not-y"
+	msgUnrepNeither           msgID = "unrepresented.neither"
+	msgFlagUnrepLimit         msgID = "flag.unrepresented-limit"
+	msgFlagBatchTarget        msgID = "flag.batch-target"
+	msgBatchTargetSummary     msgID = "batch-target.summary"
+	msgFlagFirst              msgID = "flag.first"
+	msgFirstDetail            msgID = "first.detail"
+	msgFirstVerified          msgID = "first.verified"
+	msgFirstSquares           msgID = "first.squares"
+	msgFirstNone              msgID = "first.none"
+	msgFlagSummaryOut         msgID = "flag.summary.out"
+	msgFlagSummaryJUnit       msgID = "flag.summary.junit"
+	msgSummaryWritten         msgID = "summary.written"
+	msgConvertUsage           msgID = "convert.usage"
+	msgFlagConvertFrom        msgID = "flag.convert.from"
+	msgFlagConvertTo          msgID = "flag.convert.to"
+	msgConvertSummary         msgID = "convert.summary"
+	msgConvertSizes           msgID = "convert.sizes"
+	msgFlagConvertForm        msgID = "flag.convert.form"
+	msgFlagLogLevel           msgID = "flag.log.level"
+	msgFlagConfig             msgID = "flag.config"
+	msgConfigReloaded         msgID = "config.reloaded"
+	msgConfigReloadedNone     msgID = "config.reloaded.none"
+	msgConfigReloadFailed     msgID = "config.reload.failed"
+	msgConfigRestart          msgID = "config.restart"
+	msgFlagStallTimeout       msgID = "flag.stall.timeout"
+	msgFlagSkipStalled        msgID = "flag.skip.stalled"
+	msgStall                  msgID = "stall"
+	msgStallSkipped           msgID = "stall.skipped"
+	msgStallSummary           msgID = "stall.summary"
+	msgError                  msgID = "error"
+	msgInit                   msgID = "init"
+	msgSieving                msgID = "sieving"
+	msgSievingAccel           msgID = "sieving.accel"
+	msgNoPrimes               msgID = "noPrimes"
+	msgPrimesFound            msgID = "primesFound"
+	msgDashboardError         msgID = "dashboard.error"
+	msgDashboardURL           msgID = "dashboard.url"
+	msgColumnCheck            msgID = "column.check"
+	msgFound                  msgID = "found"
+	msgTUIError               msgID = "tui.error"
+	msgInterrupted            msgID = "interrupted"
+	msgSummary                msgID = "summary"
+	msgDuration               msgID = "duration"
+	msgTUIRunning             msgID = "tui.running"
+	msgTUIDone                msgID = "tui.done"
+	msgTUIStopping            msgID = "tui.stopping"
+	msgTUIPaused              msgID = "tui.paused"
+	msgTUITitle               msgID = "tui.title"
+	msgTUIProgress            msgID = "tui.progress"
+	msgTUIFound               msgID = "tui.found"
+	msgTUIRate                msgID = "tui.rate"
+	msgTUIUtilization         msgID = "tui.utilization"
+	msgTUIKeysRunning         msgID = "tui.keys.running"
+	msgTUIKeysDone            msgID = "tui.keys.done"
+	msgUnsupportedLang        msgID = "lang.unsupported"
+	msgDefaultLangLabel       msgID = "lang.default"
+	msgErrInvalidFlags        msgID = "error.invalidflags"
+	msgErrInterrupted         msgID = "error.interrupted"
+	msgErrVerification        msgID = "error.verification"
+	msgErrIO                  msgID = "error.io"
+	msgErrMemoryBudget        msgID = "error.memorybudget"
+	msgErrInvalidInput        msgID = "error.invalidinput"
+	msgConfigLimitEmpty       msgID = "config.limitempty"
+	msgConfigPrimesEmpty      msgID = "config.primesempty"
+	msgConfigPairsEmpty       msgID = "config.pairsempty"
+	msgConfigWhereContradict  msgID = "config.wherecontradict"
+	msgConfigWhereTooSmall    msgID = "config.wheretoosmall"
+	msgConfigWhereTooLarge    msgID = "config.wheretoolarge"
+	msgConfigProblems         msgID = "config.problems"
+	msgConfigFileSyntax       msgID = "configfile.syntax"
+	msgConfigFileCommandLine  msgID = "configfile.commandline"
+	msgConfigFileUnknown      msgID = "configfile.unknown"
+	msgConfigLogLevel         msgID = "configfile.loglevel"
+	msgConfigStatsInterval    msgID = "configfile.statsinterval"
+	msgConfigCPUPercent       msgID = "configfile.cpupercent"
+	msgFileLocked             msgID = "filelock.locked"
+)
+
+// supportedLanguages liste les langues du catalogue; la première sert de repli pour une langue inconnue.
+var supportedLanguages = []language.Tag{language.English, language.French}
+
+// defaultLanguage est la langue utilisée lorsque ni -lang ni l'environnement ne précisent de langue.
+var defaultLanguage = language.French
+
+// catalog associe à chaque langue la chaîne de format (au sens de fmt) de chaque message.
+var catalog = map[language.Tag]map[msgID]string{
+	language.English: {
+		msgUsage:                  "Usage: %s [options]\n       %[1]s status -socket PATH [-json | -snapshot FILE]\n       %[1]s list-primes [-limit N] [-format txt|json|binary] [-o FILE]\n       %[1]s count-primes X [X...]\n       %[1]s factor N [N...]\n       %[1]s nth-prime N [N...]\n       %[1]s range [-count] A B\n       %[1]s decompose N [N...] | -results FILE.json\n       %[1]s mersenne -p P\n       %[1]s primorial -n N | -search LIMIT [-factorial]\n       %[1]s goldbach [-limit N]\n       %[1]s pseudoprimes [-base 2,3] [-limit N] [-test fermat|euler|strong]\n       %[1]s min-q [-limit N] [-form F] [-format table|json] [-o FILE]\n       %[1]s verify-signature [-key PUB.pem] FILE\n       %[1]s diff OLD.json NEW.json\n       %[1]s check -input PAIRS.csv [-form F] [-witness-source default|crypto]\n       %[1]s certify [-o CERTS.ndjson] N [N...] | -results FILE | -verify CERTS.ndjson\n       %[1]s stream [-form F] [-all] < CANDIDATES\n       %[1]s analyze bias [-limit N] [-mod M] [-classes A,B]\n       %[1]s analyze ap [-limit N] [-form F] [-k K] [-results FILE.json]\n       %[1]s analyze unrepresented [-limit N] [-max K]\n       %[1]s chunks -dir DIR [-limit N] [-chunks K] [-chunk NAME] [-verify]\n       %[1]s convert [-from F] [-to F] IN OUT\n       %[1]s serve -dir DIR [-listen ADDR] [-max-concurrent K] [-workers W]\n       %[1]s client submit|status|results|cancel [-server URL] [ID]\n\nSearches for primes n = p^2 + 4q^2 (or another form, see -form) where p and q are primes.\n\nOptions:\n",
+		msgFlagLimit:              "Upper bound for the primes p and q.",
+		msgFlagPrimeTest:          "Primality test algorithm: 'trial', 'miller' (default), 'auto' (chosen by size), 'adaptive' (Miller-Rabin bases chosen by the size of n), 'lucas' (strong Lucas, probabilistic), 'bpsw' (Baillie-PSW) or 'aks' (AKS, very slow, for teaching).",
+		msgFlagAccel:              "Offload the sieve marking and the small-prime prefilter of the candidates to an accelerator (%s); the final primality test stays on CPU. 'gpu' needs a build with -tags gpu and an OpenCL driver. Default: none.",
+		msgFlagDashboard:          "Listen address of the web dashboard (e.g. ':8080'). Disabled if empty.",
+		msgFlagTUI:                "Show an interactive terminal UI (pause, resume, stop) instead of the text table.",
+		msgFlagLang:               "Output language: 'en' or 'fr' (default: from LC_ALL, LC_MESSAGES or LANG, otherwise %s).",
+		msgFlagVerify:             "Re-check every result with the other primality test (exit code 5 on disagreement).",
+		msgFlagLogFile:            "Write status messages to this log file (with rotation) instead of standard output; results stay on standard output.",
+		msgFlagLogMaxSize:         "Maximum log file size in MiB before rotation (0: no limit).",
+		msgFlagLogMaxAge:          "Maximum age of the current log file before rotation (0: no limit).",
+		msgFlagLogMaxBackups:      "Number of rotated log files to keep (0: keep all).",
+		msgFlagMaxMemory:          "Memory budget (e.g. '512MiB', '2G'): refuse to start if the estimate exceeds it and shrink the job queue when usage approaches it.",
+		msgMemoryEstimate:         "Estimated memory: %s (sieve %s, prime table %s, buffers %s), budget %s.\n",
+		msgMemoryDownshift:        "Memory in use %s close to the budget %s: job queue reduced to %d.\n",
+		msgMemoryUpshift:          "Memory in use %s back under control: job queue raised to %d.\n",
+		msgFlagWorkers:            "Number of workers (default: number of CPUs).",
+		msgFlagBatch:              "Number of (p, q) pairs per batch sent to the workers.",
+		msgFlagAutotune:           "Calibrate the number of workers and the batch size on this machine before the search (overrides -workers and -batch).",
+		msgFlagAutotuneBurst:      "Duration of each autotune calibration burst.",
+		msgAutotuneStart:          "Autotune: calibrating %d configurations (%s each)...\n",
+		msgAutotuneSummary:        "Autotune: workers=%d, batch=%d (%.0f pairs/s during calibration).\n",
+		msgFlagCPUPercent:         "Maximum share of a CPU core used by each worker, in percent (1-100); workers sleep between batches to stay under it.",
+		msgFlagNice:               "Background mode: lower the process priority and limit workers to 25% CPU (unless -cpu-percent is given).",
+		msgNiceWarning:            "Warning: could not lower the process priority: %v\n",
+		msgCPULimit:               "CPU limit: %d%% per worker.\n",
+		msgThroughput:             "Throughput: %d pairs/s (%d pairs tested in %s).\n",
+		msgFlagStatusSocket:       "Path of the local UNIX socket exposing the progress of the search (queried with the status subcommand); auto picks the system's default location. Disabled if empty.",
+		msgFlagStatusJSON:         "Print the raw JSON snapshot.",
+		msgStatusSocketError:      "Unable to open the status socket %s: %v\n",
+		msgStatusSocketReady:      "Status available with: status -socket %s\n",
+		msgStatusUnreachable:      "no running instance on %s: %v",
+		msgStatusRunning:          "running",
+		msgStatusPaused:           "paused",
+		msgStatusDone:             "done",
+		msgStatusSummary:          "Search %s: limit=%d, workers=%d, test=%s\n",
+		msgStatusProgress:         "Progress: %d/%d pairs (%.1f%%), %d found, %s elapsed\n",
+		msgSignalPaused:           "Search paused (SIGUSR1); send SIGUSR2 to resume.\n",
+		msgSignalResumed:          "Search resumed (SIGUSR2).\n",
+		msgFlagListLimit:          "Upper bound of the primes to list.",
+		msgFlagListFormat:         "Output format: 'txt' (one per line), 'json' (array) or 'binary' (little-endian uint32).",
+		msgFlagListOutput:         "Output file (standard output if empty).",
+		msgFlagPrimesCache:        "Directory of the persistent prime cache: the sieve result is saved there and reused by later runs with the same limit; auto picks the user's cache directory. Disabled if empty.",
+		msgCacheHit:               "Primes loaded from cache %s.\n",
+		msgCacheInvalid:           "Cache %s ignored: %v\n",
+		msgCacheWritten:           "Primes saved to cache %s.\n",
+		msgCacheWriteError:        "Unable to write cache %s: %v\n",
+		msgFlagPrimesFile:         "File of precomputed primes used instead of the sieve (text: one per line; binary: little-endian uint32). Without -limit, the limit is the largest prime of the file.",
+		msgFlagPrimesFileFormat:   "Encoding of -primes-file: 'auto', 'txt' or 'binary'.",
+		msgFlagPrimesFileCheck:    "Number of entries of -primes-file spot-checked for primality (0: none).",
+		msgPrimesFileLoaded:       "Primes loaded from %s.\n",
+		msgCountUsage:             "Usage: count-primes [options] X [X...]\n\nPrints π(X), the number of primes up to X (e.g. 1000000 or 1e12), without enumerating them.\n\nOptions:\n",
+		msgNthUsage:               "Usage: nth-prime [options] N [N...]\n\nPrints the N-th prime (e.g. 1000000 or 1e9; nth-prime 1 prints 2).\n\nOptions:\n",
+		msgFlagForm:               "Form evaluated on each pair (p, q): %s.",
+		msgFlagFilter:             "Only report n passing this filter: %s. Disabled if empty.",
+		msgFlagTwins:              "Flag the n found that belong to a twin prime pair (n-2 or n+2 prime) and count them in the summary.",
+		msgTwinMark:               "(twin)",
+		msgTwinSummary:            "Of which %d belong to a twin prime pair (n-2 or n+2 prime).\n",
+		msgFlagMersenneP:          "Exponent P of the Mersenne number 2^P - 1 to test.",
+		msgMersennePrime:          "prime",
+		msgMersenneComposite:      "composite",
+		msgMersenneResult:         "2^%d - 1: %s (Lucas-Lehmer, %s)\n",
+		msgFlagGoldbachLimit:      "Largest even number to check",
+		msgGoldbachVerified:       "Goldbach: the %d even numbers from 4 to %d are sums of two primes (%s)\n",
+		msgGoldbachHardest:        "Largest minimal prime: %d = %d + %d\n",
+		msgGoldbachCounterexample: "Counterexample: %d is not a sum of two primes\n",
+		msgFlagExplainComposites:  "Report the smallest prime factor of rejected (composite) n values: 1 analyses all of them, N one out of N per worker (0: disabled).",
+		msgCompositeMark:          "Composite, factor %d",
+		msgCompositeSummary:       "%d composite values of n analysed.\n",
+		msgFactorUsage:            "Usage: factor [options] N [N...]\n\nPrints the prime factorization of N (2 <= N < 2^63, e.g. 600851475143 or 1e18), computed by trial division then Pollard's rho method with Brent's improvement.\n\nOptions:\n",
+		msgFlagMinQLimit:          "Upper bound for p and q",
+		msgFlagMinQFormat:         "Output format: table or json",
+		msgMinQNone:               "none",
+		msgFlagRecords:            "Records file (JSON) keeping the largest n found for each form across runs; a new record is announced.",
+		msgNewRecord:              "New record! n = %d (p = %d, q = %d) for %s.\n",
+		msgPreviousRecord:         "Previous record: n = %d (%s).\n",
+		msgRecordHeld:             "Record for %s unchanged: n = %d (%s).\n",
+		msgFlagManifest:           "Embed the run manifest (run ID, version, host, times, parameters, algorithms) in the output",
+		msgFlagManifestVerbose:    "List the value of every option (param.*) in the manifest of the table; the other formats always carry them",
+		msgFlagOutput:             "Write the results table (and its manifest) to this file instead of standard output.",
+		msgFlagSign:               "ed25519 private key (PEM, PKCS#8) used to sign the -o file: writes FILE.sig with its SHA-256 checksum and signature.",
+		msgFlagVerifyKey:          "ed25519 public key (PEM, PKIX) checking the signature; without it only the checksum is checked.",
+		msgFlagVerifySig:          "Detached signature file (default: FILE.sig).",
+		msgVerifySignatureUsage:   "Usage: verify-signature [options] FILE\n\nChecks the SHA-256 checksum and the ed25519 signature written by -sign for a result file.\n\nOptions:\n",
+		msgSignatureOK:            "%s: checksum and signature valid.\n",
+		msgChecksumOnly:           "%s: checksum valid (signature not checked: no -key).\n",
+		msgSignatureWritten:       "Signature written to %s.\n",
+		msgFlagResultFormat:       "Results format: table, json (JSON document read back by the diff subcommand), ndjson (one JSON object per line), csv, pbz (Zstandard-compressed stream of length-delimited protobuf messages) or parquet (columnar file); the convert subcommand rewrites one format into another.",
+		msgDiffUsage:              "Usage: diff [options] OLD.json NEW.json\n\nLists the results (sorted by n) present in only one of two result files: JSON (-format json), or ndjson, csv, pbz or parquet according to the extension. '-' for OLD, '+' for NEW. Exit code 5 if they differ.\n\nOptions:\n",
+		msgDiffParamMismatch:      "Warning: parameter -%s differs (%q vs %q); the results are not comparable.\n",
+		msgDiffSummary:            "%d common results, %d only in %s, %d only in %s.\n",
+		msgFlagSweep:              "Comma-separated cutoffs (e.g. '10^3,10^4,1e5'): one search up to the largest, then a table of the counts N(x) of pairs p, q <= x for each cutoff. Replaces -limit.",
+		msgSweepTitle:             "Counts N(x) by cutoff x (pairs p, q <= x):\n",
+		msgFlagTimeSeries:         "Append (time, elapsed, pairs tested, primes found) to this file every -timeseries-interval, to graph the discovery rate.",
+		msgFlagTimeSeriesInterval: "Sampling interval of -timeseries.",
+		msgFlagTimeSeriesFormat:   "Format of -timeseries: csv or json.",
+		msgFlagStatsInterval:      "Print a compact statistics line (rate, results, progress, memory) at this interval (e.g. '30s'; 0: disabled).",
+		msgStatsLine:              "[%v] %.0f pairs/s | %d results | %.1f%% | memory %s\n",
+		msgFlagSample:             "Monte Carlo estimate for limits too large to enumerate: test this many random (p, q) pairs in range instead of the full search, and report the estimated density with a 95% confidence interval (0 = disabled).",
+		msgFlagSeed:               "Random seed for -sample and -spot-check (0 = random seed, printed so the run can be reproduced).",
+		msgSampleStart:            "Sampling %d random pairs up to %d (seed %d)...\n",
+		msgSampleDensity:          "Estimated density: %.6g (95%% CI [%.6g, %.6g]), %d hits out of %d pairs.\n",
+		msgSampleExpected:         "Estimated count N(x): %.4g (95%% CI [%.4g, %.4g]) over %d pairs (π(x) = %d).\n",
+		msgFlagResidues:           "After the summary, tabulate the found n by residue class mod 4, 8 and 24, and p, q by residue class mod 4.",
+		msgResiduesTitle:          "Residue classes of the results:\n",
+		msgAnalyzeUsage:           "Usage: analyze bias|ap|unrepresented [options]\n\nAnalyses of the sieved primes and of the results:\n  bias   Chebyshev bias: counts of the primes up to -limit by residue class mod -mod, and race between two classes (by default mod-1 against 1, i.e. 3 against 1 mod 4).\n  ap     Arithmetic progressions of at least -k terms among the values of n found by the search up to -limit (or read from -results), and the longest ones (Green-Tao).\n  unrepresented  Primes ≡ 1 mod 4 up to -limit that are not of the form p^2 + 4q^2 with p and q prime (complement of the results), with their decomposition n = x^2 + 4y^2.\n\nOptions:\n",
+		msgFlagBiasMod:            "Modulus of the residue classes (>= 3).",
+		msgFlagBiasClasses:        "Classes A,B raced against each other, both coprime to -mod (default: mod-1,1).",
+		msgBiasTitle:              "%d primes up to %d by residue class mod %d:\n",
+		msgBiasRace:               "Race %d against %d mod %d: first ahead after %.2f %% of the primes, second ahead after %.2f %%, tied after %.2f %%.\n",
+		msgBiasFirstLead:          "First lead of %d mod %d: at x = %d.\n",
+		msgBiasNoLead:             "%d mod %d never leads up to %d.\n",
+		msgBiasMaxGap:             "Largest lead: %d in favor of %d mod %d (at x = %d).\n",
+		msgFlagPairs:              "Region of the (p, q) grid to enumerate: %s (all: full grid, lt: p < q, le: p <= q, ne: p != q, eq: p = q).",
+		msgFlagReport:             "Write a standalone HTML report to this file: run manifest, summary, charts and paginated results table.",
+		msgReportWritten:          "HTML report written to %s.\n",
+		msgFlagExplain:            "Print the Miller-Rabin test step by step for candidate N, or for each result with 'results' (limit <= %d)",
+		msgExplainHeader:          "Miller-Rabin test of n = %d\n",
+		msgExplainBelowTwo:        "  n < 2: not prime by definition.\n",
+		msgExplainSmallPrime:      "  n = 2 or 3: prime, no round needed.\n",
+		msgExplainEven:            "  n is even and greater than 2: composite.\n",
+		msgExplainDecomposition:   "  n - 1 = %d = 2^s · d, s = %d, d = %d\n",
+		msgExplainRound:           "  base a = %d: %s\n",
+		msgExplainOne:             "    a^d ≡ 1: consistent with a prime.\n",
+		msgExplainMinusOne:        "    reaches n - 1 = %d: consistent with a prime.\n",
+		msgExplainWitness:         "    never reaches n - 1: %d is a witness, n is composite.\n",
+		msgExplainPrime:           "  No witness among the %d bases tried: n is prime (these bases make the test exact below 2^64).\n",
+		msgFlagErrorBound:         "Maximum error probability of -primetest adaptive when no deterministic base set covers n (beyond 64 bits); implies -primetest adaptive",
+		msgAdaptivePolicy:         "Adaptive Miller-Rabin: %d to %d bases depending on n (exact on 64 bits); beyond 64 bits, %d random rounds for an error below %g.\n",
+		msgWorkerStatsTitle:       "Per-worker activity:\n",
+		msgWorkerStatsLine:        "  worker %d: %d batches, %d pairs (%.1f %%), %d results, busy %v (%.0f %% of the search)\n",
+		msgWorkerImbalance:        "  Imbalance: busy time from %v to %v, busiest worker at %.2f× the mean.\n",
+		msgFlagSink:               "Additional results destination, repeatable: format:target, with format table, json or ndjson and target a file or tcp://host:port. A failing destination is reported and dropped without stopping the others.",
+		msgSinkFailed:             "Warning: results destination %s dropped: %v\n",
+		msgFlagWhere:              "Only write results matching this expression over p, q, n and twin, e.g. \"n > 1e9 && p % 4 == 1\" (operators: || && == != < <= > >= + - * / % ! and parentheses).",
+		msgWhereSummary:           "Results written: %d (-where %v).\n",
+		msgFlagTop:                "Only write the K first results of the -by ranking, at the end of the search (0: all results, in arrival order).",
+		msgFlagBy:                 "Ranking of -top: field p, q, n or twin, optionally followed by :desc (largest first, default) or :asc (smallest first).",
+		msgTopSummary:             "Results written: %d of %d, ranked by %s (-top).\n",
+		msgChunksUsage:            "Usage: chunks -dir DIR [options]\n\nSplits the (p, q) grid into named chunks (ranges of p), recorded in DIR/chunks.ckpt (versioned binary checkpoint, CRC-protected; a chunks.json manifest from an earlier release is migrated) with their status, result count and SHA-256 checksum, and the results of each chunk in DIR/<chunk>.ndjson. The first run creates the campaign; later runs resume at pending chunks. -chunk re-runs a single chunk; -verify recomputes completed chunks and compares them with the manifest (exit code 5 on mismatch).\n\nOptions:\n",
+		msgFlagChunksDir:          "Campaign directory (manifest chunks.ckpt and one NDJSON results file per chunk).",
+		msgFlagChunksCount:        "Number of chunks when creating the campaign (ranges of p with the same number of primes).",
+		msgFlagChunkName:          "Only process this chunk (e.g. chunk-0003): re-run it even if completed, or verify only it with -verify.",
+		msgFlagChunksVerify:       "Recompute completed chunks and compare their results with the manifest checksums and result files, instead of running pending chunks.",
+		msgChunkRunning:           "%s: searching p in [%d, %d]...\n",
+		msgChunkDone:              "%s: p in [%d, %d], done, %d results, sha256 %s\n",
+		msgChunkPending:           "%s: p in [%d, %d], pending\n",
+		msgChunksSummary:          "%d/%d chunks completed.\n",
+		msgChunkSkipped:           "%s: pending, not verified.\n",
+		msgChunkVerified:          "%s: verified (%d results).\n",
+		msgChunkMismatch:          "%s: MISMATCH: %s\n",
+		msgChunkRecomputed:        "recomputed %d results, sha256 %s",
+		msgChunkFileAltered:       "%s differs from the manifest",
+		msgFlagCompare:            "Comma-separated primality tests (e.g. trial,miller,bpsw) all applied to every candidate: their verdicts are checked for agreement (exit code 5 otherwise) and their time reported. The first one decides the results.",
+		msgCompareTitle:           "Primality test comparison:\n",
+		msgCompareLine:            "  %-8s %d candidates, %v in total, %.0f ns per candidate\n",
+		msgCompareAgree:           "  Verdicts agree on every candidate.\n",
+		msgCompareDisagree:        "  DISAGREEMENT on %d candidates; the first %d:\n",
+		msgFlagPrimorialN:         "print N# (or N! with -factorial) and its number of digits",
+		msgFlagPrimorialSearch:    "list the primes N# ± 1 (or N! ± 1) for N up to this limit",
+		msgFlagPrimorialFactorial: "use factorials N! instead of primorials N#",
+		msgPrimorialValue:         "%s = %s (%d digits)\n",
+		msgPrimorialFound:         "%s %s 1 is prime (%d digits)\n",
+		msgPrimorialSummary:       "%d prime(s) of the form %s ± 1 for N <= %d (%s)\n",
+		msgFlagStatusSnapshot:     "ask the running search to write its partial results and progress to this JSON file, without stopping it",
+		msgStatusSnapshotError:    "cannot snapshot the run on %s: %v",
+		msgStatusSnapshotWritten:  "%d result(s) and progress written to %s\n",
+		msgStatusSnapshotOmitted:  "%d older result(s) omitted from the snapshot (all are in the run's output)\n",
+		msgSDNotifyError:          "systemd notification failed: %v\n",
+		msgFlagNumbers:            "Number display in the table and summary: 'plain', 'grouped' (thousands separators of the language) or 'si' (grouped, and large summary counts abbreviated: 1.2M); JSON output is not affected",
+		msgRecordMark:             "(new record)",
+		msgFlagColor:              "Colors in the result table: 'auto' (on a terminal, unless NO_COLOR is set), 'always' or 'never'",
+		msgFlagOnOverflow:         "Fate of candidates n exceeding int64: %s (error: refuse the limit, skip: ignore the pair and count it, promote-big: test n exactly, on 64-bit unsigned integers below 2^64 and with math/big beyond (probable primality), uint64: refuse the limit only if n can reach 2^64 and test n exactly on 64-bit unsigned integers).",
+		msgOverflowSkipped:        "%d pairs ignored: n exceeds int64 (-on-overflow skip).\n",
+		msgOverflowPromoted:       "%d candidates beyond int64 tested exactly (-on-overflow promote-big: on 64-bit unsigned integers below 2^64, probable primality on math/big beyond).\n",
+		msgOverflowUint64:         "%d candidates beyond int64 tested exactly on 64-bit unsigned integers (-on-overflow uint64).\n",
+		msgFlagWitnessSource:      "Source of random Miller-Rabin bases beyond 2^64 (%s): default (derived from n by math/big, predictable) or crypto (crypto/rand, for adversarial inputs). Below 2^64, the test is deterministic.",
+		msgCheckUsage:             "Usage: check -input FILE [options]\n\nVerifies (p, q) pairs supplied by a third party. FILE is a CSV file with one pair per line, p,q, optionally followed by the claimed value of n (lines starting with '#' and a p,q[,n] header are skipped). For each pair, checks that p and q are prime, computes n exactly (beyond int64 if needed), compares it with the claimed value and tests it. Writes one CSV verdict per pair on standard output: prime, composite, p-not-prime, q-not-prime or n-mismatch. Exit code 5 if a pair is rejected.\n\nOptions:\n",
+		msgFlagCheckInput:         "CSV file of the pairs to verify: p,q[,n].",
+		msgCheckSummary:           "%d pairs checked: %d valid, %d rejected.\n",
+		msgCertifyUsage:           "Usage: certify [options] N [N...] | -results FILE | -verify CERTS.ndjson\n\nProves the primality of integers of several hundred bits by elliptic curves (ECPP, Atkin-Morain method) and writes one certificate per integer in NDJSON. With -results, proves the n of a result file beyond int64 (-on-overflow promote-big). -verify checks the certificates of a file without the prover. Exit code 5 if an integer gets no certificate or a certificate is invalid.\n\nOptions:\n",
+		msgFlagCertifyResults:     "Result file (any format readable by convert) whose n beyond int64 are proved.",
+		msgFlagCertifyOutput:      "Certificate file (NDJSON, one certificate per line; default: standard output).",
+		msgFlagCertifyVerify:      "Check the certificates of the given NDJSON file instead of proving.",
+		msgFlagCertifyTimeout:     "Time limit of the proof of each integer (0: none).",
+		msgCertifyProved:          "%v: prime, certificate of %d step(s) (%v).\n",
+		msgCertifyComposite:       "%v: composite.\n",
+		msgCertifyNoProof:         "%v: no certificate: %v\n",
+		msgCertifySummary:         "%d integers: %d certified, %d without certificate.\n",
+		msgCertifyValid:           "%v: valid certificate (%d step(s)).\n",
+		msgCertifyInvalid:         "%v: invalid certificate: %v\n",
+		msgCertifyVerifySummary:   "%d certificates checked: %d valid, %d invalid.\n",
+		msgServeUsage:             "Usage: serve -dir DIR [-listen ADDR] [-max-concurrent K] [-workers W] [-local=false] [-lease-ttl D] [-tokens FILE] [-rate R] [-burst B] [-max-searches S] [-on-duplicate POLICY] [-schedule SPEC]... [-config FILE]\n\nServer mode: REST API to submit searches (POST /searches, JSON body {\"limit\": N, \"form\": ..., \"above\": A, \"workers\": W}), list them (GET /searches), follow one (GET /searches/{id}), read its results by pages (GET /searches/{id}/results?after=CURSOR&limit=1000&n_min=A&n_max=B) and cancel it (DELETE /searches/{id}). Submitted searches wait in a queue; at most -max-concurrent of them run at a time, each with its worker budget. Searches, chunks and results are kept in DIR/server.db: a restarted server resumes the running searches at their pending chunks. Remote workers (client work) lease chunks (POST /leases, JSON body {\"worker\": NAME}), renew their lease (POST /leases/{id}/renew) and send the results (POST /leases/{id}/results, NDJSON body); leases and acknowledged results are persisted, an expired lease returns its chunk to the pending ones, and results sent again are not counted twice. With -tokens, every request carries a token (Authorization: Bearer TOKEN); each token has its own rate limit and at most -max-searches active searches, and only cancels its own. A submission identical to a queued, running or done search (same limit, above, form, primetest and pairs) is refused, skipped (the existing search is returned) or appended under a new ID, according to -on-duplicate or the on_duplicate field of the submission. Each -schedule (or \"schedule = ...\" line of -config) submits a recurring search that pushes a frontier forward, e.g. \"nightly: 0 2 * * * extend=1000000\" searches 10^6 further every night at 02:00; the frontier advances once the search is done, and GET /schedules shows the schedules. Without -tokens, the server only listens on the loopback interface. Stops on SIGINT or SIGTERM.\n\nOptions:\n",
+		msgFlagServeListen:        "Listen address of the REST API.",
+		msgFlagServeDir:           "Server directory (database server.db, created if needed).",
+		msgFlagServeMaxConcurrent: "Maximum number of searches run at a time; the others wait in the queue.",
+		msgFlagServeWorkers:       "Worker budget of each search: default and maximum of the workers of a submission.",
+		msgFlagServeLocal:         "Also run the chunks on the server (false: coordinator of the remote workers only).",
+		msgFlagServeLeaseTTL:      "Duration of the leases of the remote workers, renewed during the computation; an expired lease returns its chunk to the pending ones.",
+		msgFlagServeTokens:        "Access token file: one \"NAME TOKEN\" line per token (at least 16 characters), # for comments. Required to listen beyond the loopback interface.",
+		msgFlagServeRate:          "Requests per second allowed per token (per client address without -tokens; 0: no limit).",
+		msgFlagServeBurst:         "Burst of requests allowed per token beyond -rate.",
+		msgFlagServeMaxSearches:   "Active searches (queued or running) per token (0: no limit).",
+		msgFlagServeOnDuplicate:   "Default policy for a submission identical to an existing search: %s.",
+		msgFlagServeSchedule:      "Recurring search \"NAME: MIN HOUR DAY MONTH WEEKDAY extend=N [from=F] [form=...] [primetest=...] [pairs=...] [chunks=K] [workers=W]\" (cron syntax, local time), repeatable.",
+		msgFlagServeConfig:        "Options file: one 'option = value' line per option (names without dash, # comments, schedule repeatable), applied unless given on the command line.",
+		msgServeListening:         "Server listening on http://%v (database %s).\n",
+		msgServeStopped:           "Server stopped.\n",
+		msgServeSearchStarted:     "search %s started (limit %d, %d chunks, %d workers)\n",
+		msgServeSearchDone:        "search %s done: %d results\n",
+		msgServeSearchFailed:      "search %s failed: %v\n",
+		msgServeError:             "server error: %v\n",
+		msgServeLeaseGranted:      "search %s: chunk %s leased to worker %s\n",
+		msgServeLeasesExpired:     "%d expired lease(s): their chunks are pending again\n",
+		msgServeScheduleFired:     "schedule %s: search %s submitted (pairs beyond %d, up to %d)\n",
+		msgServeScheduleBusy:      "schedule %s: search %s still %s, firing skipped\n",
+		msgClientUsage:            "Usage: client submit|status|results|cancel|work [options] [ID]\n\nDrives a server started by serve through its REST API:\n  submit   Submits a search (-limit, -form, -primetest, -pairs, -chunks, -workers, -above) and prints it.\n  status   Prints the state of search ID, or of all searches.\n  results  Downloads the results of search ID in NDJSON, following the pages to the last one (-n-min, -n-max, -o).\n  cancel   Cancels search ID.\n  work     Computes chunks of the server's searches as a remote worker (-name, -workers, -poll, -once), until SIGINT or SIGTERM.\n\nOptions:\n",
+		msgFlagClientServer:       "Address of the server (URL of serve's REST API).",
+		msgFlagClientToken:        "Access token of the server (default: environment variable PRIMENUMBER_TOKEN).",
+		msgFlagClientOnDuplicate:  "Policy if an identical search already exists: %s (default: the server's).",
+		msgFlagClientAbove:        "Frontier: only the pairs where p or q exceeds it are tested (extension of a search already run up to this limit; 0: none).",
+		msgFlagClientChunks:       "Number of chunks of the search (ranges of p with the same number of primes).",
+		msgFlagClientWorkers:      "Worker budget of the search (0: the server's budget).",
+		msgFlagClientJSON:         "Print each search as a JSON line.",
+		msgFlagClientNMin:         "Lower bound of the n downloaded (0: none).",
+		msgFlagClientNMax:         "Upper bound of the n downloaded (0: none).",
+		msgFlagClientPage:         "Results per request (at most %d).",
+		msgFlagClientOutput:       "Output file of the results (NDJSON; default: standard output).",
+		msgClientSearch:           "%s  %-9s  %d/%d chunks  %d results  (limit %d, %s)\n",
+		msgClientSearchError:      "  error: %s\n",
+		msgClientResults:          "%d results of search %s downloaded.\n",
+		msgFlagClientWorkName:     "Name of the worker in the server's leases and log (default: host name).",
+		msgFlagClientWorkWorkers:  "Maximum number of workers of a chunk (the search's budget also applies).",
+		msgFlagClientWorkPoll:     "Wait when no chunk is available, and between two failed sends of results.",
+		msgFlagClientWorkOnce:     "Stop when no chunk is available instead of waiting.",
+		msgClientWorkDone:         "chunk %s of search %s: %d results sent\n",
+		msgClientWorkDuplicate:    "chunk %s of search %s: %d results already acknowledged, not counted again\n",
+		msgClientWorkLost:         "chunk %s of search %s abandoned: lease lost (search cancelled or chunk reassigned)\n",
+		msgClientWorkRetry:        "chunk %s: send %d failed (%v), retrying\n",
+		msgStreamUsage:            "Usage: stream [options] < CANDIDATES\n\nTests candidates read from standard input, one per line, through the worker pool. A line is either a pair p,q (or p q), whose n is computed by the form, or a value of n alone (lines starting with '#' are skipped). By default, only prime candidates are written to standard output, in input order: n, or p,q,n for a pair. With -all, every line gets a CSV verdict, prime or composite. A summary is written to standard error.\n\nOptions:\n",
+		msgFlagStreamAll:          "Writes a verdict (prime or composite) for every line, not only the prime candidates.",
+		msgStreamSummary:          "%d candidates tested: %d prime.\n",
+		msgFlagTiming:             "Records for each result its discovery time and the duration of its candidate's test (table columns, found_at and test_ns in JSON), and reports the time to the first result.",
+		msgColumnFoundAt:          "Found at",
+		msgColumnTestTime:         "Test",
+		msgFirstResult:            "Time to first result: %v\n",
+		msgFlagDedup:              "Counts the distinct values of n and skips any result whose n was already found, with the chosen set structure: %s. roaring is far more compact than hash when results are dense, but costlier when they are sparse (a few values per block of 65,536); sort removes them while merging the sorted output (-sort), without a set in memory.",
+		msgDedupSummary:           "Deduplication (%s): %d distinct values of n, %d duplicates skipped, set of about %s.\n",
+		msgFlagPreset:             "Search preset filling in the options not given on the command line: %s. quick: -limit 1000; thorough: -limit 20000, every candidate checked by two tests (-compare miller,bpsw), -twins, progress line every 30s; publication: -limit 100000, -compare miller,bpsw, -twins, JSON with manifest, progress line every minute.",
+		msgPresetApplied:          "Preset %s: %s\n",
+		msgFlagSpotCheck:          "Re-checks a random sample of the results during the run with the independent test of -verify, at the given rate (percentage such as 0.1% or fraction such as 0.001), and reports the agreement of the sample (exit code 5 on disagreement).",
+		msgSpotCheckSummary:       "Spot check (%s test, seed %d): %d of %d results re-checked, agreement %.2f%%.\n",
+		msgSpotCheckDisagreement:  "First disagreement of the spot check: %v\n",
+		msgRangeUsage:             "Usage: range [options] A B\n\nPrints the primes of the interval [A, B] (up to 2^64 - 1, e.g. 1e12), one per line, by a segmented sieve seeded with the primes up to √B, without sieving from 2.\n\nOptions:\n",
+		msgFlagRangeCount:         "Prints only the number of primes of the interval.",
+		msgDecomposeUsage:         "Usage: decompose [options] N [N...]\n       decompose [options] -results FILE.json\n\nWrites each prime N ≡ 1 mod 4 as a sum of two squares N = x^2 + y^2 (Cornacchia's algorithm), with the pair (p, q) of the form when it exists. With -results, checks that the decomposition of every result of a JSON file is made of p and of the second root of the form (2q for p^2+4q^2): an independent structural verification (exit code 5 on mismatch).\n\nOptions:\n",
+		msgFlagDecomposeResults:   "JSON results file whose results are checked by their decomposition (the form of its manifest replaces -form).",
+		msgDecomposeNone:          "%d: not a sum of two squares (not prime, or prime ≡ 3 mod 4)",
+		msgDecomposeSummary:       "%d results checked by decomposition (form %s): %d mismatches, %d beyond int64 skipped.\n",
+		msgFlagReverse:            "Reverse search for p^2+4q^2: sieves the primes n up to the largest value of the form and keeps those whose Cornacchia decomposition n = p^2 + 4q^2 has prime p and q, instead of testing every pair. Same results as the forward search, sorted by n: an end-to-end cross-check.",
+		msgReverseThroughput:      "Throughput: %d integers/s (%d integers n sieved in %s, reverse search).\n",
+		msgPseudoUsage:            "Usage: pseudoprimes [options]\n\nPrints the composite numbers up to -limit that pass the Fermat, Euler-Jacobi or strong (Miller) test for every base of -base, one per line. Primality comes from the sieve.\n\nOptions:\n",
+		msgFlagPseudoBase:         "Bases of the test, comma separated (e.g. 2 or 2,3,5): a pseudoprime passes the test for all of them.",
+		msgFlagPseudoLimit:        "Largest number examined.",
+		msgFlagPseudoTest:         "Probable-prime test: %s.",
+		msgPseudoSummary:          "%d pseudoprimes (test %s, bases %s) up to %d.\n",
+		msgFlagJobsBuffer:         "Capacity of the jobs channel, in batches (0: adaptive, from -workers and -batch).",
+		msgFlagResultsBuffer:      "Capacity of the results channel (0: adaptive, from -workers and -batch).",
+		msgBuffers:                "Channel buffers: jobs %d batches (%s), results %d (%s)\n",
+		msgBufferAdaptive:         "adaptive",
+		msgFlagAPK:                "Minimum number of terms of the progressions counted (>= 3).",
+		msgFlagAPResults:          "JSON results file whose values of n are analyzed instead of running a search (the form of its manifest replaces -form).",
+		msgAPTitle:                "Maximal arithmetic progressions of at least %d terms among %s distinct values of n (form %s):\n",
+		msgAPCount:                "  %d terms: %s\n",
+		msgAPNone:                 "No progression of %d terms.\n",
+		msgAPLongest:              "Longest (%d terms):\n",
+		msgAPTerm:                 "  %s (difference %d)\n",
+		msgAPMore:                 "  ... and %s more\n",
+		msgDecadesTitle:           "Results by order of magnitude of n:\n",
+		msgDecadesLine:            "  10^%d <= n < 10^%d: %s (%.1f %%)\n",
+		msgDecadesBig:             "  n > 2^63-1: %s (%.1f %%)\n",
+		msgStatsDecades:           "  by decade: %s\n",
+		msgFlagCPUQuota:           "CPUs available to the workers: auto (CFS quota of the cgroup, under Linux), off (all visible CPUs) or a number of CPUs (e.g. 2.5); sizes -workers and GOMAXPROCS by default.",
+		msgCPUQuota:               "CPU quota: %.2f CPU (%s) out of %d visible CPUs: %d workers by default, GOMAXPROCS=%d\n",
+		msgFlagSort:               "Write the results sorted by increasing n (then p, q), at the end of the search: beyond -sort-memory, sorted runs are spilled to temporary files and merged (external merge sort).",
+		msgFlagSortMemory:         "Memory kept for the results of -sort before spilling to disk (e.g. 64MiB, 1GiB).",
+		msgFlagSortDir:            "Directory of the temporary files of -sort (default: system temporary directory).",
+		msgSortSummary:            "Sorted output (-sort): %d results written, %d duplicates of n removed, %d runs spilled (%s on disk).\n",
+		msgFlagPlugin:             "Start a plugin (command line, repeatable) that supplies forms (-form) and result destinations (-sink FORMAT:plugin://NAME) through a JSON protocol on its standard input and output.",
+		msgPluginLoaded:           "Plugin %s: forms [%s], destinations [%s]\n",
+		msgFlagUnrepMax:           "Number of smallest unrepresented primes listed (-1: all).",
+		msgUnrepTitle:             "%s primes ≡ 1 mod 4 up to %d: %s of the form p^2 + 4q^2 with p and q prime, %s unrepresented (%.2f %%)\n",
+		msgUnrepReasons:           "  x not prime: %s, y not prime: %s, neither prime: %s\n",
+		msgUnrepSmallest:          "Smallest unrepresented (n = x^2 + 4y^2):\n",
+		msgUnrepTerm:              "  %d = %d^2 + 4·%d^2 (%s)\n",
+		msgUnrepNotX:              "x not prime",
+		msgUnrepNotY:              "y not prime",
+		msgUnrepNeither:           "neither prime",
+		msgFlagUnrepLimit:         "Upper bound for the primes n examined.",
+		msgFlagBatchTarget:        "Target duration of a batch: the batch size adapts during the run to the observed cost of the pairs, starting from -batch (0: fixed size).",
+		msgBatchTargetSummary:     "Adaptive batches (target %v): %d pairs at the end, from %d to %d, %d adjustments\n",
+		msgFlagFirst:              "Stop at the first result (after -filter and -where) and print it with its verification details; small p and q are enumerated first.",
+		msgFirstDetail:            "First result: n = %d (p=%d, q=%d, form %s) after %s\n",
+		msgFirstVerified:          "Independent check (%s test): p, q and n are prime and n = %s for (p, q): confirmed\n",
+		msgFirstSquares:           "Sum of two squares (Cornacchia): %d = %d^2 + %d^2\n",
+		msgFirstNone:              "No result: -first found no qualifying prime\n",
+		msgFlagSummaryOut:         "Writes a summary of the run (counts, timings, verification status, exit code) as JSON to this file at the end, even on failure.",
+		msgFlagSummaryJUnit:       "Writes the same summary as a JUnit XML report to this file (one test case for the search and one per verification).",
+		msgSummaryWritten:         "Run summary written to %s\n",
+		msgConvertUsage:           "Usage: convert [options] IN OUT\n\nRewrites a results file in another format, so that results are not tied to the format chosen at run time. Read formats: json, ndjson, csv, pbz (Zstandard-compressed stream of length-delimited protobuf messages) and parquet (as written by -format parquet: PLAIN pages, required columns). Written formats: those of -format, table included. The formats are inferred from the extensions (.json, .ndjson or .jsonl, .csv, .pbz or .zst, .parquet, .txt for the table) or given by -from and -to. IN may be '-' (standard input); OUT may be '-' (standard output) or, as for -sink, tcp://host:port. The input manifest, if any, is carried over unchanged to every output format except ndjson.\n\nOptions:\n",
+		msgFlagConvertFrom:        "Input format (%s); inferred from the extension by default.",
+		msgFlagConvertTo:          "Output format (%s); inferred from the extension by default.",
+		msgConvertSummary:         "%s results converted from %s (%s) to %s (%s)%s\n",
+		msgConvertSizes:           ": %s -> %s",
+		msgFlagConvertForm:        "Form named in the header of the table format.",
+		msgFlagLogLevel:           "Level of the status messages: %s (error: results and final error only; warn: warnings only; info: all messages). Can be changed during the run by reloading -config (SIGHUP).",
+		msgFlagConfig:             "Options file: one 'option = value' line per option (names without dash, # comments), applied unless given on the command line. On SIGHUP the file is read again and -log-level, -stats-interval and -cpu-percent take its values without restarting the search; other changed options wait for the next start.",
+		msgConfigReloaded:         "Options reloaded from %s: %s\n",
+		msgConfigReloadedNone:     "Options reloaded from %s: no change\n",
+		msgConfigReloadFailed:     "Warning: options not reloaded, current settings kept: %v\n",
+		msgConfigRestart:          "Warning: changed options taking effect at the next start only: %s\n",
+		msgFlagStallTimeout:       "Worker watchdog: report any candidate whose primality test has been running for longer than this duration, with its (p, q) pair (e.g. '10m'; 0: disabled).",
+		msgFlagSkipStalled:        "With -stall-timeout: abandon the stalled pair and let a new worker carry on; its result is lost (listed in the summary) and its test keeps running in the background until it ends.",
+		msgStall:                  "Warning: worker %d has been stuck for %v on n = %s (p=%d, q=%d)\n",
+		msgStallSkipped:           "Warning: worker %d stalled for %v on n = %s (p=%d, q=%d): pair abandoned, a new worker carries on\n",
+		msgStallSummary:           "Warning: %s pair(s) abandoned by the watchdog (-skip-stalled), results incomplete: %s\n",
+		msgError:                  "Error: %v\n",
+		msgInit:                   "Initializing with searchLimit=%d, numWorkers=%d, primeTest='%s'\n",
+		msgSieving:                "Generating primes with the sieve of Eratosthenes...\n",
+		msgSievingAccel:           "Generating primes with the sieve of Eratosthenes, marking offloaded to %v...\n",
+		msgNoPrimes:               "No prime found within the given limit.\n",
+		msgPrimesFound:            "%d primes found up to %d.\n\n",
+		msgDashboardError:         "Unable to start the dashboard on %s: %v\n",
+		msgDashboardURL:           "Dashboard available at http://%s/\n\n",
+		msgColumnCheck:            "Check",
+		msgFound:                  "Found!",
+		msgTUIError:               "Terminal UI error: %v\n",
+		msgInterrupted:            "Search interrupted; results are partial.\n",
+		msgSummary:                "Search complete. %d special primes found.\n",
+		msgDuration:               "\nTotal execution time: %s\n",
+		msgTUIRunning:             "running",
+		msgTUIDone:                "finished",
+		msgTUIStopping:            "stopping…",
+		msgTUIPaused:              "paused",
+		msgTUITitle:               "n = p² + 4q² — limit=%d, workers=%d, test=%s — %s\n\n",
+		msgTUIProgress:            "Progress     %s %5.1f %%  (%d / %d pairs)\n",
+		msgTUIFound:               "Found        %d    Elapsed %s\n",
+		msgTUIRate:                "Throughput   %s %.0f pairs/s\n\n",
+		msgTUIUtilization:         "Worker utilization\n",
+		msgTUIKeysRunning:         "\n[p/space] pause/resume  [r] resume  [q] stop\n",
+		msgTUIKeysDone:            "\n[q] quit\n",
+		msgUnsupportedLang:        "Unsupported language %q, using %s.\n",
+		msgDefaultLangLabel:       "French",
+		msgErrInvalidFlags:        "invalid options",
+		msgErrInterrupted:         "search interrupted, partial results",
+		msgErrVerification:        "verification failed",
+		msgErrIO:                  "input/output error",
+		msgErrMemoryBudget:        "insufficient memory budget",
+		msgErrInvalidInput:        "invalid input data",
+		msgConfigLimitEmpty:       "-limit=%d: no prime p, q <= %d, the search would be empty; choose -limit >= 2",
+		msgConfigPrimesEmpty:      "the imported prime list is empty; check -primes-file or set -limit >= 2",
+		msgConfigPairsEmpty:       "the -pairs %s region holds no pair with %d prime(s) up to %d; raise -limit or choose -pairs %s",
+		msgConfigWhereContradict:  "-where %q: the bounds on n contradict each other (%d <= n <= %d); fix one of the conditions",
+		msgConfigWhereTooSmall:    "-where %q: n <= %d is impossible, the smallest n of the form %s is %d (p=%d, q=%d); raise the bound on n or remove it",
+		msgConfigWhereTooLarge:    "-where %q: n >= %d is impossible, n does not exceed %d up to %d; raise -limit or lower the bound on n",
+		msgConfigProblems:         "%d inconsistencies:\n  - %s",
+		msgConfigFileSyntax:       "%s:%d: %q (expected option = value)",
+		msgConfigFileCommandLine:  "%s:%d: -%s is only accepted on the command line",
+		msgConfigFileUnknown:      "%s:%d: unknown option -%s",
+		msgConfigLogLevel:         "-log-level=%q (expected one of %v)",
+		msgConfigStatsInterval:    "%s: -stats-interval=%q (expected a duration >= 0)",
+		msgConfigCPUPercent:       "%s: -cpu-percent=%q (expected between 1 and 100)",
+		msgFileLocked:             "%s is in use by another run (lock %s)",
+	},
+	language.French: {
+		msgUsage:                  "Utilisation: %s [options]\n       %[1]s status -socket CHEMIN [-json | -snapshot FICHIER]\n       %[1]s list-primes [-limit N] [-format txt|json|binary] [-o FICHIER]\n       %[1]s count-primes X [X...]\n       %[1]s factor N [N...]\n       %[1]s nth-prime N [N...]\n       %[1]s range [-count] A B\n       %[1]s decompose N [N...] | -results FICHIER.json\n       %[1]s mersenne -p P\n       %[1]s primorial -n N | -search LIMIT [-factorial]\n       %[1]s goldbach [-limit N]\n       %[1]s pseudoprimes [-base 2,3] [-limit N] [-test fermat|euler|strong]\n       %[1]s min-q [-limit N] [-form F] [-format table|json] [-o FICHIER]\n       %[1]s verify-signature [-key PUB.pem] FICHIER\n       %[1]s diff ANCIEN.json NOUVEAU.json\n       %[1]s check -input PAIRES.csv [-form F] [-witness-source default|crypto]\n       %[1]s certify [-o CERTS.ndjson] N [N...] | -results FICHIER | -verify CERTS.ndjson\n       %[1]s stream [-form F] [-all] < CANDIDATS\n       %[1]s analyze bias [-limit N] [-mod M] [-classes A,B]\n       %[1]s analyze ap [-limit N] [-form F] [-k K] [-results FICHIER.json]\n       %[1]s analyze unrepresented [-limit N] [-max K]\n       %[1]s chunks -dir RÉPERTOIRE [-limit N] [-chunks K] [-chunk NOM] [-verify]\n       %[1]s convert [-from F] [-to F] ENTRÉE SORTIE\n       %[1]s serve -dir RÉPERTOIRE [-listen ADRESSE] [-max-concurrent K] [-workers W]\n       %[1]s client submit|status|results|cancel [-server URL] [ID]\n\nRecherche les nombres premiers n = p^2 + 4q^2 (ou une autre forme, voir -form) où p et q sont premiers.\n\nOptions:\n",
+		msgFlagLimit:              "Limite supérieure pour la recherche des nombres premiers p et q.",
+		msgFlagPrimeTest:          "Algorithme de test de primalité: 'trial', 'miller' (défaut), 'auto' (choisi selon la taille), 'adaptive' (bases de Miller-Rabin choisies selon la taille de n), 'lucas' (Lucas fort, probabiliste), 'bpsw' (Baillie-PSW) ou 'aks' (AKS, très lent, pédagogique).",
+		msgFlagAccel:              "Décharge le marquage du crible et le pré-filtre des candidats par petits nombres premiers sur un accélérateur (%s); le test de primalité final reste sur CPU. 'gpu' exige une compilation avec -tags gpu et un pilote OpenCL. Défaut: aucun.",
+		msgFlagDashboard:          "Adresse d'écoute du tableau de bord web (ex: ':8080'). Désactivé si vide.",
+		msgFlagTUI:                "Affiche une interface terminal interactive (pause, reprise, arrêt) au lieu du tableau texte.",
+		msgFlagLang:               "Langue des messages: 'en' ou 'fr' (défaut: d'après LC_ALL, LC_MESSAGES ou LANG, sinon %s).",
+		msgFlagVerify:             "Revérifie chaque résultat avec l'autre test de primalité (code de sortie 5 en cas de désaccord).",
+		msgFlagLogFile:            "Écrit les messages d'état dans ce fichier journal (avec rotation) au lieu de la sortie standard; les résultats restent sur la sortie standard.",
+		msgFlagLogMaxSize:         "Taille maximale du journal en Mio avant rotation (0: sans limite).",
+		msgFlagLogMaxAge:          "Âge maximal du journal courant avant rotation (0: sans limite).",
+		msgFlagLogMaxBackups:      "Nombre de journaux archivés conservés (0: tous).",
+		msgFlagMaxMemory:          "Budget mémoire (ex: '512MiB', '2G'): refuse de démarrer si l'estimation le dépasse et réduit la file des tâches à son approche.",
+		msgMemoryEstimate:         "Mémoire estimée: %s (crible %s, table des premiers %s, tampons %s), budget %s.\n",
+		msgMemoryDownshift:        "Mémoire utilisée %s proche du budget %s: file des tâches réduite à %d.\n",
+		msgMemoryUpshift:          "Mémoire utilisée %s de nouveau maîtrisée: file des tâches portée à %d.\n",
+		msgFlagWorkers:            "Nombre de workers (défaut: nombre de processeurs).",
+		msgFlagBatch:              "Nombre de paires (p, q) par lot distribué aux workers.",
+		msgFlagAutotune:           "Calibre le nombre de workers et la taille des lots sur cette machine avant la recherche (remplace -workers et -batch).",
+		msgFlagAutotuneBurst:      "Durée de chaque rafale de calibration de l'autotune.",
+		msgAutotuneStart:          "Autotune: calibration de %d configurations (%s chacune)...\n",
+		msgAutotuneSummary:        "Autotune: workers=%d, lots=%d (%.0f paires/s pendant la calibration).\n",
+		msgFlagCPUPercent:         "Part maximale d'un cœur CPU utilisée par chaque worker, en pourcentage (1-100); les workers se mettent en veille entre les lots pour la respecter.",
+		msgFlagNice:               "Mode arrière-plan: abaisse la priorité du processus et limite les workers à 25 % du CPU (sauf si -cpu-percent est fourni).",
+		msgNiceWarning:            "Avertissement: impossible d'abaisser la priorité du processus: %v\n",
+		msgCPULimit:               "Limite CPU: %d %% par worker.\n",
+		msgThroughput:             "Débit: %d paires/s (%d paires testées en %s).\n",
+		msgFlagStatusSocket:       "Chemin du socket UNIX local exposant la progression de la recherche (interrogé par la sous-commande status); auto choisit l'emplacement par défaut du système. Désactivé si vide.",
+		msgFlagStatusJSON:         "Affiche l'instantané JSON brut.",
+		msgStatusSocketError:      "Impossible d'ouvrir le socket d'état %s: %v\n",
+		msgStatusSocketReady:      "État consultable avec: status -socket %s\n",
+		msgStatusUnreachable:      "aucune exécution en cours sur %s: %v",
+		msgStatusRunning:          "en cours",
+		msgStatusPaused:           "suspendue",
+		msgStatusDone:             "terminée",
+		msgStatusSummary:          "Recherche %s: limite=%d, workers=%d, test=%s\n",
+		msgStatusProgress:         "Progression: %d/%d paires (%.1f %%), %d trouvés, %s écoulées\n",
+		msgSignalPaused:           "Recherche suspendue (SIGUSR1); envoyer SIGUSR2 pour reprendre.\n",
+		msgSignalResumed:          "Recherche reprise (SIGUSR2).\n",
+		msgFlagListLimit:          "Borne supérieure des nombres premiers à lister.",
+		msgFlagListFormat:         "Format de sortie: 'txt' (un par ligne), 'json' (tableau) ou 'binary' (uint32 petit-boutiste).",
+		msgFlagListOutput:         "Fichier de sortie (sortie standard si vide).",
+		msgFlagPrimesCache:        "Répertoire du cache persistant des nombres premiers: le résultat du crible y est enregistré et réutilisé par les exécutions suivantes de même limite; auto choisit le répertoire de cache de l'utilisateur. Désactivé si vide.",
+		msgCacheHit:               "Nombres premiers chargés depuis le cache %s.\n",
+		msgCacheInvalid:           "Cache %s ignoré: %v\n",
+		msgCacheWritten:           "Nombres premiers enregistrés dans le cache %s.\n",
+		msgCacheWriteError:        "Impossible d'écrire le cache %s: %v\n",
+		msgFlagPrimesFile:         "Fichier de nombres premiers précalculés utilisé à la place du crible (texte: un par ligne; binaire: uint32 petit-boutistes). Sans -limit, la limite est le plus grand nombre du fichier.",
+		msgFlagPrimesFileFormat:   "Encodage de -primes-file: 'auto', 'txt' ou 'binary'.",
+		msgFlagPrimesFileCheck:    "Nombre d'entrées de -primes-file dont la primalité est vérifiée par sondage (0: aucune).",
+		msgPrimesFileLoaded:       "Nombres premiers chargés depuis %s.\n",
+		msgCountUsage:             "Utilisation: count-primes [options] X [X...]\n\nAffiche π(X), le nombre de nombres premiers jusqu'à X (ex: 1000000 ou 1e12), sans les énumérer.\n\nOptions:\n",
+		msgNthUsage:               "Utilisation: nth-prime [options] N [N...]\n\nAffiche le N-ième nombre premier (ex: 1000000 ou 1e9; nth-prime 1 affiche 2).\n\nOptions:\n",
+		msgFlagForm:               "Forme évaluée sur chaque paire (p, q): %s.",
+		msgFlagFilter:             "Ne remonte que les n satisfaisant ce filtre: %s. Désactivé si vide.",
+		msgFlagTwins:              "Signale les n trouvés appartenant à une paire de nombres premiers jumeaux (n-2 ou n+2 premier) et les compte dans le résumé.",
+		msgTwinMark:               "(jumeau)",
+		msgTwinSummary:            "Dont %d appartenant à une paire de nombres premiers jumeaux (n-2 ou n+2 premier).\n",
+		msgFlagMersenneP:          "Exposant P du nombre de Mersenne 2^P - 1 à tester.",
+		msgMersennePrime:          "premier",
+		msgMersenneComposite:      "composé",
+		msgMersenneResult:         "2^%d - 1: %s (Lucas-Lehmer, %s)\n",
+		msgFlagGoldbachLimit:      "Plus grand nombre pair à vérifier",
+		msgGoldbachVerified:       "Goldbach: les %d nombres pairs de 4 à %d sont sommes de deux nombres premiers (%s)\n",
+		msgGoldbachHardest:        "Plus grand nombre premier minimal: %d = %d + %d\n",
+		msgGoldbachCounterexample: "Contre-exemple: %d n'est pas la somme de deux nombres premiers\n",
+		msgFlagExplainComposites:  "Affiche le plus petit facteur premier des valeurs de n rejetées (composées): 1 les analyse toutes, N une sur N par worker (0: désactivé).",
+		msgCompositeMark:          "Composé, facteur %d",
+		msgCompositeSummary:       "%d valeurs de n composées analysées.\n",
+		msgFactorUsage:            "Utilisation: factor [options] N [N...]\n\nAffiche la décomposition en facteurs premiers de N (2 <= N < 2^63, ex: 600851475143 ou 1e18), calculée par division successive puis méthode rho de Pollard avec l'amélioration de Brent.\n\nOptions:\n",
+		msgFlagMinQLimit:          "Borne supérieure de p et q",
+		msgFlagMinQFormat:         "Format de sortie: table ou json",
+		msgMinQNone:               "aucun",
+		msgFlagRecords:            "Fichier de records (JSON) conservant d'une exécution à l'autre le plus grand n trouvé pour chaque forme; un nouveau record est annoncé.",
+		msgNewRecord:              "Nouveau record! n = %d (p = %d, q = %d) pour %s.\n",
+		msgPreviousRecord:         "Record précédent: n = %d (%s).\n",
+		msgRecordHeld:             "Record pour %s inchangé: n = %d (%s).\n",
+		msgFlagManifest:           "Joindre à la sortie le manifeste de l'exécution (identifiant, version, hôte, heures, paramètres, algorithmes)",
+		msgFlagManifestVerbose:    "Lister la valeur de chaque option (param.*) dans le manifeste du tableau; les autres formats la portent toujours",
+		msgFlagOutput:             "Écrit le tableau des résultats (et son manifeste) dans ce fichier au lieu de la sortie standard.",
+		msgFlagSign:               "Clé privée ed25519 (PEM, PKCS#8) signant le fichier -o: écrit FICHIER.sig avec sa somme SHA-256 et sa signature.",
+		msgFlagVerifyKey:          "Clé publique ed25519 (PEM, PKIX) contrôlant la signature; sans elle, seule la somme de contrôle est vérifiée.",
+		msgFlagVerifySig:          "Fichier de signature détachée (défaut: FICHIER.sig).",
+		msgVerifySignatureUsage:   "Utilisation: verify-signature [options] FICHIER\n\nContrôle la somme SHA-256 et la signature ed25519 écrites par -sign pour un fichier de résultats.\n\nOptions:\n",
+		msgSignatureOK:            "%s: somme de contrôle et signature valides.\n",
+		msgChecksumOnly:           "%s: somme de contrôle valide (signature non contrôlée: pas de -key).\n",
+		msgSignatureWritten:       "Signature écrite dans %s.\n",
+		msgFlagResultFormat:       "Format des résultats: table, json (document JSON relu par la sous-commande diff), ndjson (un objet JSON par ligne), csv, pbz (flux Zstandard de messages protobuf délimités) ou parquet (fichier en colonnes); la sous-commande convert réécrit un format dans un autre.",
+		msgDiffUsage:              "Utilisation: diff [options] ANCIEN.json NOUVEAU.json\n\nListe les résultats (triés par n) présents dans un seul de deux fichiers de résultats: JSON (-format json), ou ndjson, csv, pbz ou parquet selon l'extension. '-' pour ANCIEN, '+' pour NOUVEAU. Code de sortie 5 s'ils diffèrent.\n\nOptions:\n",
+		msgDiffParamMismatch:      "Attention: le paramètre -%s diffère (%q contre %q); les résultats ne sont pas comparables.\n",
+		msgDiffSummary:            "%d résultats communs, %d seulement dans %s, %d seulement dans %s.\n",
+		msgFlagSweep:              "Limites séparées par des virgules (ex: '10^3,10^4,1e5'): une recherche jusqu'à la plus grande, puis le tableau des comptes N(x) des paires p, q <= x pour chaque limite. Remplace -limit.",
+		msgSweepTitle:             "Comptes N(x) par limite x (paires p, q <= x):\n",
+		msgFlagTimeSeries:         "Ajoute (heure, temps écoulé, paires testées, résultats) à ce fichier toutes les -timeseries-interval, pour tracer le rythme de découverte.",
+		msgFlagTimeSeriesInterval: "Intervalle d'échantillonnage de -timeseries.",
+		msgFlagTimeSeriesFormat:   "Format de -timeseries: csv ou json.",
+		msgFlagStatsInterval:      "Affiche une ligne de statistiques compacte (débit, résultats, avancement, mémoire) à cet intervalle (ex: '30s'; 0: désactivé).",
+		msgStatsLine:              "[%v] %.0f paires/s | %d résultats | %.1f %% | mémoire %s\n",
+		msgFlagSample:             "Estimation de Monte-Carlo pour les limites trop grandes pour l'énumération: tester ce nombre de paires (p, q) tirées au hasard au lieu de la recherche complète, et afficher la densité estimée avec un intervalle de confiance à 95 % (0 = désactivé).",
+		msgFlagSeed:               "Graine aléatoire pour -sample et -spot-check (0 = graine aléatoire, affichée pour pouvoir reproduire l'exécution).",
+		msgSampleStart:            "Échantillonnage de %d paires aléatoires jusqu'à %d (graine %d)...\n",
+		msgSampleDensity:          "Densité estimée: %.6g (IC 95 %% [%.6g, %.6g]), %d succès sur %d paires.\n",
+		msgSampleExpected:         "Nombre estimé N(x): %.4g (IC 95 %% [%.4g, %.4g]) sur %d paires (π(x) = %d).\n",
+		msgFlagResidues:           "Après le résumé, répartir les n trouvés par classe de résidus mod 4, 8 et 24, et p, q par classe mod 4.",
+		msgResiduesTitle:          "Classes de résidus des résultats:\n",
+		msgAnalyzeUsage:           "Utilisation: analyze bias|ap|unrepresented [options]\n\nAnalyses des nombres premiers du crible et des résultats:\n  bias   Biais de Tchebychev: comptes des nombres premiers jusqu'à -limit par classe de résidus mod -mod, et course entre deux classes (par défaut mod-1 contre 1, soit 3 contre 1 mod 4).\n  ap     Progressions arithmétiques d'au moins -k termes parmi les valeurs de n trouvées par la recherche jusqu'à -limit (ou lues dans -results), et les plus longues (Green-Tao).\n  unrepresented  Nombres premiers ≡ 1 mod 4 jusqu'à -limit qui ne sont pas de la forme p^2 + 4q^2 avec p et q premiers (complément des résultats), avec leur décomposition n = x^2 + 4y^2.\n\nOptions:\n",
+		msgFlagBiasMod:            "Module des classes de résidus (>= 3).",
+		msgFlagBiasClasses:        "Classes A,B mises en course, premières avec -mod (par défaut: mod-1,1).",
+		msgBiasTitle:              "%d nombres premiers jusqu'à %d par classe de résidus mod %d:\n",
+		msgBiasRace:               "Course %d contre %d mod %d: la première en tête après %.2f %% des nombres premiers, la seconde après %.2f %%, égalité après %.2f %%.\n",
+		msgBiasFirstLead:          "Première avance de %d mod %d: en x = %d.\n",
+		msgBiasNoLead:             "%d mod %d ne mène jamais jusqu'à %d.\n",
+		msgBiasMaxGap:             "Plus grande avance: %d en faveur de %d mod %d (en x = %d).\n",
+		msgFlagPairs:              "Région de la grille (p, q) à énumérer: %s (all: grille complète, lt: p < q, le: p <= q, ne: p != q, eq: p = q).",
+		msgFlagReport:             "Écrire un rapport HTML autonome dans ce fichier: manifeste, résumé, graphiques et tableau paginé des résultats.",
+		msgReportWritten:          "Rapport HTML écrit dans %s.\n",
+		msgFlagExplain:            "Afficher pas à pas le test de Miller-Rabin du candidat N, ou de chaque résultat avec 'results' (limite <= %d)",
+		msgExplainHeader:          "Test de Miller-Rabin de n = %d\n",
+		msgExplainBelowTwo:        "  n < 2: non premier par définition.\n",
+		msgExplainSmallPrime:      "  n = 2 ou 3: premier, aucun tour nécessaire.\n",
+		msgExplainEven:            "  n est pair et plus grand que 2: composé.\n",
+		msgExplainDecomposition:   "  n - 1 = %d = 2^s · d, s = %d, d = %d\n",
+		msgExplainRound:           "  base a = %d: %s\n",
+		msgExplainOne:             "    a^d ≡ 1: compatible avec n premier.\n",
+		msgExplainMinusOne:        "    atteint n - 1 = %d: compatible avec n premier.\n",
+		msgExplainWitness:         "    n'atteint jamais n - 1: %d est un témoin, n est composé.\n",
+		msgExplainPrime:           "  Aucun témoin parmi les %d bases essayées: n est premier (ces bases rendent le test exact sous 2^64).\n",
+		msgFlagErrorBound:         "Probabilité d'erreur maximale de -primetest adaptive quand aucun ensemble de bases déterministe ne couvre n (au-delà de 64 bits); implique -primetest adaptive",
+		msgAdaptivePolicy:         "Miller-Rabin adaptatif: %d à %d bases selon n (exact sur 64 bits); au-delà de 64 bits, %d tours à bases aléatoires pour une erreur inférieure à %g.\n",
+		msgWorkerStatsTitle:       "Activité par worker:\n",
+		msgWorkerStatsLine:        "  worker %d: %d lots, %d paires (%.1f %%), %d résultats, occupé %v (%.0f %% de la recherche)\n",
+		msgWorkerImbalance:        "  Déséquilibre: temps d'occupation de %v à %v, worker le plus occupé à %.2f× la moyenne.\n",
+		msgFlagSink:               "Destination supplémentaire des résultats, répétable: format:cible, avec format table, json ou ndjson et cible un fichier ou tcp://hôte:port. Une destination en échec est signalée et écartée sans arrêter les autres.",
+		msgSinkFailed:             "Avertissement: destination de résultats %s écartée: %v\n",
+		msgFlagWhere:              "N'écrire que les résultats satisfaisant cette expression sur p, q, n et twin, par exemple \"n > 1e9 && p % 4 == 1\" (opérateurs: || && == != < <= > >= + - * / % ! et parenthèses).",
+		msgWhereSummary:           "Résultats écrits: %d (-where %v).\n",
+		msgFlagTop:                "N'écrire que les K premiers résultats du classement -by, en fin de recherche (0: tous les résultats, dans l'ordre d'arrivée).",
+		msgFlagBy:                 "Classement de -top: champ p, q, n ou twin, éventuellement suivi de :desc (plus grands d'abord, par défaut) ou :asc (plus petits d'abord).",
+		msgTopSummary:             "Résultats écrits: %d sur %d, classés par %s (-top).\n",
+		msgChunksUsage:            "Utilisation: chunks -dir RÉPERTOIRE [options]\n\nDécoupe la grille (p, q) en tranches nommées (intervalles de p), enregistrées dans RÉPERTOIRE/chunks.ckpt (point de reprise binaire versionné, protégé par CRC; un manifeste chunks.json d'une version antérieure est migré) avec leur état, leur nombre de résultats et leur somme SHA-256, et les résultats de chaque tranche dans RÉPERTOIRE/<tranche>.ndjson. Le premier lancement crée la campagne; les suivants reprennent aux tranches en attente. -chunk recalcule une seule tranche; -verify recalcule les tranches terminées et les compare au manifeste (code de sortie 5 en cas d'écart).\n\nOptions:\n",
+		msgFlagChunksDir:          "Répertoire de la campagne (manifeste chunks.ckpt et un fichier de résultats NDJSON par tranche).",
+		msgFlagChunksCount:        "Nombre de tranches à la création de la campagne (intervalles de p comptant le même nombre de nombres premiers).",
+		msgFlagChunkName:          "Ne traiter que cette tranche (par exemple chunk-0003): la recalculer même si elle est terminée, ou ne vérifier qu'elle avec -verify.",
+		msgFlagChunksVerify:       "Recalculer les tranches terminées et comparer leurs résultats aux sommes du manifeste et aux fichiers de résultats, au lieu d'exécuter les tranches en attente.",
+		msgChunkRunning:           "%s: recherche de p dans [%d, %d]...\n",
+		msgChunkDone:              "%s: p dans [%d, %d], terminée, %d résultats, sha256 %s\n",
+		msgChunkPending:           "%s: p dans [%d, %d], en attente\n",
+		msgChunksSummary:          "%d/%d tranches terminées.\n",
+		msgChunkSkipped:           "%s: en attente, non vérifiée.\n",
+		msgChunkVerified:          "%s: vérifiée (%d résultats).\n",
+		msgChunkMismatch:          "%s: ÉCART: %s\n",
+		msgChunkRecomputed:        "recalcul: %d résultats, sha256 %s",
+		msgChunkFileAltered:       "%s diffère du manifeste",
+		msgFlagCompare:            "Tests de primalité séparés par des virgules (par exemple trial,miller,bpsw), tous appliqués à chaque candidat: l'accord de leurs verdicts est vérifié (code de sortie 5 sinon) et leur temps rapporté. Le premier décide des résultats.",
+		msgCompareTitle:           "Comparaison des tests de primalité:\n",
+		msgCompareLine:            "  %-8s %d candidats, %v au total, %.0f ns par candidat\n",
+		msgCompareAgree:           "  Verdicts identiques sur tous les candidats.\n",
+		msgCompareDisagree:        "  DÉSACCORD sur %d candidats; les %d premiers:\n",
+		msgFlagPrimorialN:         "affiche N# (ou N! avec -factorial) et son nombre de chiffres",
+		msgFlagPrimorialSearch:    "liste les nombres premiers N# ± 1 (ou N! ± 1) pour N jusqu'à cette limite",
+		msgFlagPrimorialFactorial: "utilise les factorielles N! au lieu des primorielles N#",
+		msgPrimorialValue:         "%s = %s (%d chiffres)\n",
+		msgPrimorialFound:         "%s %s 1 est premier (%d chiffres)\n",
+		msgPrimorialSummary:       "%d nombre(s) premier(s) de la forme %s ± 1 pour N <= %d (%s)\n",
+		msgFlagStatusSnapshot:     "demande à la recherche en cours d'écrire ses résultats partiels et sa progression dans ce fichier JSON, sans l'interrompre",
+		msgStatusSnapshotError:    "instantané impossible de l'exécution sur %s: %v",
+		msgStatusSnapshotWritten:  "%d résultat(s) et progression écrits dans %s\n",
+		msgStatusSnapshotOmitted:  "%d résultat(s) plus ancien(s) omis de l'instantané (tous figurent dans la sortie de l'exécution)\n",
+		msgSDNotifyError:          "échec de la notification systemd: %v\n",
+		msgFlagNumbers:            "Présentation des nombres du tableau et du résumé: 'plain', 'grouped' (séparateurs des milliers de la langue) ou 'si' (groupés, et grands comptes du résumé abrégés: 1,2M); les sorties JSON ne sont pas concernées",
+		msgRecordMark:             "(nouveau record)",
+		msgFlagColor:              "Couleurs du tableau des résultats: 'auto' (sur un terminal, sauf si NO_COLOR est défini), 'always' ou 'never'",
+		msgFlagOnOverflow:         "Sort des candidats n dépassant int64: %s (error: refuser la limite, skip: ignorer la paire et la compter, promote-big: tester n exactement, sur 64 bits non signés sous 2^64 et sur math/big au-delà (primalité probable), uint64: ne refuser la limite que si n peut atteindre 2^64 et tester n exactement sur 64 bits non signés).",
+		msgOverflowSkipped:        "%d paires ignorées: n dépasse int64 (-on-overflow skip).\n",
+		msgOverflowPromoted:       "%d candidats au-delà d'int64 testés exactement (-on-overflow promote-big: sur 64 bits non signés sous 2^64, primalité probable sur math/big au-delà).\n",
+		msgOverflowUint64:         "%d candidats au-delà d'int64 testés exactement sur 64 bits non signés (-on-overflow uint64).\n",
+		msgFlagWitnessSource:      "Source des bases aléatoires de Miller-Rabin au-delà de 2^64 (%s): default (dérivées de n par math/big, prévisibles) ou crypto (crypto/rand, contre les entrées construites). Sous 2^64, le test est déterministe.",
+		msgCheckUsage:             "Utilisation: check -input FICHIER [options]\n\nVérifie des paires (p, q) fournies par un tiers. FICHIER est un fichier CSV d'une paire par ligne, p,q, suivie facultativement de la valeur annoncée de n (les lignes commençant par '#' et un en-tête p,q[,n] sont ignorés). Pour chaque paire, vérifie que p et q sont premiers, calcule n exactement (au-delà d'int64 si nécessaire), le compare à la valeur annoncée et teste sa primalité. Écrit un verdict CSV par paire sur la sortie standard: prime, composite, p-not-prime, q-not-prime ou n-mismatch. Code de sortie 5 si une paire est rejetée.\n\nOptions:\n",
+		msgFlagCheckInput:         "Fichier CSV des paires à vérifier: p,q[,n].",
+		msgCheckSummary:           "%d paires vérifiées: %d valides, %d rejetées.\n",
+		msgCertifyUsage:           "Utilisation: certify [options] N [N...] | -results FICHIER | -verify CERTS.ndjson\n\nProuve la primalité d'entiers de plusieurs centaines de bits par courbes elliptiques (ECPP, méthode d'Atkin-Morain) et écrit un certificat par entier en NDJSON. Avec -results, prouve les n au-delà d'int64 d'un fichier de résultats (-on-overflow promote-big). -verify vérifie les certificats d'un fichier sans le prouveur. Code de sortie 5 si un entier reste sans certificat ou si un certificat est invalide.\n\nOptions:\n",
+		msgFlagCertifyResults:     "Fichier de résultats (tout format lisible par convert) dont les n au-delà d'int64 sont prouvés.",
+		msgFlagCertifyOutput:      "Fichier des certificats (NDJSON, un certificat par ligne; par défaut: sortie standard).",
+		msgFlagCertifyVerify:      "Vérifie les certificats du fichier NDJSON donné au lieu de prouver.",
+		msgFlagCertifyTimeout:     "Durée maximale de la preuve de chaque entier (0: aucune).",
+		msgCertifyProved:          "%v: premier, certificat de %d étape(s) (%v).\n",
+		msgCertifyComposite:       "%v: composé.\n",
+		msgCertifyNoProof:         "%v: pas de certificat: %v\n",
+		msgCertifySummary:         "%d entiers: %d certifiés, %d sans certificat.\n",
+		msgCertifyValid:           "%v: certificat valide (%d étape(s)).\n",
+		msgCertifyInvalid:         "%v: certificat invalide: %v\n",
+		msgCertifyVerifySummary:   "%d certificats vérifiés: %d valides, %d invalides.\n",
+		msgServeUsage:             "Utilisation: serve -dir RÉPERTOIRE [-listen ADRESSE] [-max-concurrent K] [-workers W] [-local=false] [-lease-ttl D] [-tokens FICHIER] [-rate R] [-burst B] [-max-searches S] [-on-duplicate POLITIQUE] [-schedule SPEC]... [-config FICHIER]\n\nMode serveur: API REST pour soumettre des recherches (POST /searches, corps JSON {\"limit\": N, \"form\": ..., \"above\": A, \"workers\": W}), les lister (GET /searches), en suivre une (GET /searches/{id}), en lire les résultats par pages (GET /searches/{id}/results?after=CURSEUR&limit=1000&n_min=A&n_max=B) et l'annuler (DELETE /searches/{id}). Les recherches soumises attendent dans une file; au plus -max-concurrent d'entre elles s'exécutent à la fois, chacune avec son budget de workers. Recherches, tranches et résultats sont tenus dans RÉPERTOIRE/server.db: un serveur redémarré reprend les recherches en cours à leurs tranches en attente. Des workers distants (client work) réservent des tranches (POST /leases, corps JSON {\"worker\": NOM}), renouvellent leur bail (POST /leases/{id}/renew) et en envoient les résultats (POST /leases/{id}/results, corps NDJSON); baux et résultats validés sont persistés, un bail échu rend sa tranche à l'attente, et des résultats renvoyés ne sont pas comptés deux fois. Avec -tokens, chaque requête porte un jeton (Authorization: Bearer JETON); chaque jeton a son propre débit et au plus -max-searches recherches actives, et n'annule que les siennes. Une soumission identique à une recherche en file, en cours ou achevée (mêmes limit, above, form, primetest et pairs) est refusée, ignorée (la recherche existante est rendue) ou ajoutée sous un nouvel identifiant, selon -on-duplicate ou le champ on_duplicate de la soumission. Chaque -schedule (ou ligne \"schedule = ...\" de -config) soumet une recherche récurrente qui repousse une frontière, par exemple \"nuit: 0 2 * * * extend=1000000\" cherche 10^6 plus loin chaque nuit à 2 h; la frontière avance une fois la recherche achevée, et GET /schedules montre les planifications. Sans -tokens, le serveur n'écoute que sur la boucle locale. S'arrête sur SIGINT ou SIGTERM.\n\nOptions:\n",
+		msgFlagServeListen:        "Adresse d'écoute de l'API REST.",
+		msgFlagServeDir:           "Répertoire du serveur (base server.db, créée au besoin).",
+		msgFlagServeMaxConcurrent: "Nombre maximal de recherches exécutées à la fois; les autres attendent dans la file.",
+		msgFlagServeWorkers:       "Budget de workers de chaque recherche: défaut et maximum des workers d'une soumission.",
+		msgFlagServeLocal:         "Exécute aussi les tranches sur le serveur (false: coordinateur des workers distants seulement).",
+		msgFlagServeLeaseTTL:      "Durée des baux des workers distants, renouvelés pendant le calcul; un bail échu rend sa tranche à l'attente.",
+		msgFlagServeTokens:        "Fichier des jetons d'accès: une ligne \"NOM JETON\" par jeton (au moins 16 caractères), # pour les commentaires. Requis pour écouter au-delà de la boucle locale.",
+		msgFlagServeRate:          "Requêtes par seconde permises par jeton (par adresse du client sans -tokens; 0: sans limite).",
+		msgFlagServeBurst:         "Rafale de requêtes permise par jeton au-delà de -rate.",
+		msgFlagServeMaxSearches:   "Recherches actives (en file ou en cours) par jeton (0: sans limite).",
+		msgFlagServeOnDuplicate:   "Politique par défaut d'une soumission identique à une recherche existante: %s.",
+		msgFlagServeSchedule:      "Recherche récurrente \"NOM: MIN HEURE JOUR MOIS JOUR_SEMAINE extend=N [from=F] [form=...] [primetest=...] [pairs=...] [chunks=K] [workers=W]\" (syntaxe de cron, heure locale), répétable.",
+		msgFlagServeConfig:        "Fichier d'options: une ligne 'option = valeur' par option (noms sans tiret, commentaires #, schedule répétable), appliquées sauf si données sur la ligne de commande.",
+		msgServeListening:         "Serveur à l'écoute sur http://%v (base %s).\n",
+		msgServeStopped:           "Serveur arrêté.\n",
+		msgServeSearchStarted:     "recherche %s lancée (limite %d, %d tranches, %d workers)\n",
+		msgServeSearchDone:        "recherche %s terminée: %d résultats\n",
+		msgServeSearchFailed:      "recherche %s en échec: %v\n",
+		msgServeError:             "erreur du serveur: %v\n",
+		msgServeLeaseGranted:      "recherche %s: tranche %s réservée au worker %s\n",
+		msgServeLeasesExpired:     "%d bail(s) échu(s): leurs tranches retournent à l'attente\n",
+		msgServeScheduleFired:     "planification %s: recherche %s soumise (paires au-delà de %d, jusqu'à %d)\n",
+		msgServeScheduleBusy:      "planification %s: recherche %s encore %s, déclenchement ignoré\n",
+		msgClientUsage:            "Utilisation: client submit|status|results|cancel|work [options] [ID]\n\nPilote un serveur lancé par serve au moyen de son API REST:\n  submit   Soumet une recherche (-limit, -form, -primetest, -pairs, -chunks, -workers, -above) et l'affiche.\n  status   Affiche l'état de la recherche ID, ou de toutes les recherches.\n  results  Télécharge les résultats de la recherche ID en NDJSON, en suivant les pages jusqu'à la dernière (-n-min, -n-max, -o).\n  cancel   Annule la recherche ID.\n  work     Calcule des tranches des recherches du serveur en worker distant (-name, -workers, -poll, -once), jusqu'à SIGINT ou SIGTERM.\n\nOptions:\n",
+		msgFlagClientServer:       "Adresse du serveur (URL de l'API REST de serve).",
+		msgFlagClientToken:        "Jeton d'accès du serveur (par défaut: variable d'environnement PRIMENUMBER_TOKEN).",
+		msgFlagClientOnDuplicate:  "Politique si une recherche identique existe déjà: %s (par défaut: celle du serveur).",
+		msgFlagClientAbove:        "Frontière: seules les paires dont p ou q la dépasse sont testées (prolongation d'une recherche déjà menée jusqu'à cette limite; 0: aucune).",
+		msgFlagClientChunks:       "Nombre de tranches de la recherche (intervalles de p comptant le même nombre de nombres premiers).",
+		msgFlagClientWorkers:      "Budget de workers de la recherche (0: celui du serveur).",
+		msgFlagClientJSON:         "Affiche chaque recherche sur une ligne JSON.",
+		msgFlagClientNMin:         "Borne inférieure des n téléchargés (0: aucune).",
+		msgFlagClientNMax:         "Borne supérieure des n téléchargés (0: aucune).",
+		msgFlagClientPage:         "Résultats par requête (au plus %d).",
+		msgFlagClientOutput:       "Fichier de sortie des résultats (NDJSON; par défaut: sortie standard).",
+		msgClientSearch:           "%s  %-9s  %d/%d tranches  %d résultats  (limite %d, %s)\n",
+		msgClientSearchError:      "  erreur: %s\n",
+		msgClientResults:          "%d résultats de la recherche %s téléchargés.\n",
+		msgFlagClientWorkName:     "Nom du worker dans les baux et le journal du serveur (par défaut: nom de la machine).",
+		msgFlagClientWorkWorkers:  "Nombre maximal de workers d'une tranche (le budget de la recherche s'applique aussi).",
+		msgFlagClientWorkPoll:     "Attente quand aucune tranche n'est disponible, et entre deux envois de résultats échoués.",
+		msgFlagClientWorkOnce:     "S'arrête quand aucune tranche n'est disponible au lieu d'attendre.",
+		msgClientWorkDone:         "tranche %s de la recherche %s: %d résultats envoyés\n",
+		msgClientWorkDuplicate:    "tranche %s de la recherche %s: %d résultats déjà validés, non comptés à nouveau\n",
+		msgClientWorkLost:         "tranche %s de la recherche %s abandonnée: bail perdu (recherche annulée ou tranche réattribuée)\n",
+		msgClientWorkRetry:        "tranche %s: envoi %d échoué (%v), nouvel essai\n",
+		msgStreamUsage:            "Utilisation: stream [options] < CANDIDATS\n\nTeste des candidats lus sur l'entrée standard, un par ligne, par le pool de workers. Une ligne est soit une paire p,q (ou p q), dont n est calculé par la forme, soit une valeur de n seule (les lignes commençant par '#' sont ignorées). Par défaut, seuls les candidats premiers sont écrits sur la sortie standard, dans l'ordre de l'entrée: n, ou p,q,n pour une paire. Avec -all, chaque ligne reçoit un verdict CSV, prime ou composite. Un résumé est écrit sur la sortie d'erreur.\n\nOptions:\n",
+		msgFlagStreamAll:          "Écrit un verdict (prime ou composite) pour chaque ligne, pas seulement les candidats premiers.",
+		msgStreamSummary:          "%d candidats testés: %d premiers.\n",
+		msgFlagTiming:             "Enregistre pour chaque résultat l'heure de sa découverte et la durée du test de son candidat (colonnes du tableau, found_at et test_ns en JSON), et indique le délai du premier résultat.",
+		msgColumnFoundAt:          "Découverte",
+		msgColumnTestTime:         "Test",
+		msgFirstResult:            "Premier résultat après %v\n",
+		msgFlagDedup:              "Compte les valeurs de n distinctes et écarte tout résultat dont n a déjà été trouvé, avec la structure d'ensemble choisie: %s. roaring est bien plus compact que hash quand les résultats sont denses, mais plus coûteux quand ils sont épars (quelques valeurs par bloc de 65 536); sort les écarte à la fusion de la sortie triée (-sort), sans ensemble en mémoire.",
+		msgDedupSummary:           "Dédoublonnage (%s): %d valeurs de n distinctes, %d doublons écartés, ensemble d'environ %s.\n",
+		msgFlagPreset:             "Préréglage complétant les options absentes de la ligne de commande: %s. quick: -limit 1000; thorough: -limit 20000, chaque candidat vérifié par deux tests (-compare miller,bpsw), -twins, ligne de suivi toutes les 30s; publication: -limit 100000, -compare miller,bpsw, -twins, JSON avec manifeste, ligne de suivi toutes les minutes.",
+		msgPresetApplied:          "Préréglage %s: %s\n",
+		msgFlagSpotCheck:          "Revérifie pendant l'exécution un échantillon aléatoire des résultats avec le test indépendant de -verify, au taux donné (pourcentage comme 0.1% ou fraction comme 0.001), et donne l'accord de l'échantillon (code de sortie 5 en cas de désaccord).",
+		msgSpotCheckSummary:       "Contrôle par sondage (test %s, graine %d): %d résultats revérifiés sur %d, accord %.2f %%.\n",
+		msgSpotCheckDisagreement:  "Premier désaccord du contrôle par sondage: %v\n",
+		msgRangeUsage:             "Utilisation: range [options] A B\n\nAffiche les nombres premiers de l'intervalle [A, B] (jusqu'à 2^64 - 1, ex: 1e12), un par ligne, par un crible segmenté amorcé par les nombres premiers jusqu'à √B, sans cribler depuis 2.\n\nOptions:\n",
+		msgFlagRangeCount:         "N'affiche que le nombre de nombres premiers de l'intervalle.",
+		msgDecomposeUsage:         "Utilisation: decompose [options] N [N...]\n       decompose [options] -results FICHIER.json\n\nÉcrit chaque nombre premier N ≡ 1 mod 4 comme somme de deux carrés N = x^2 + y^2 (algorithme de Cornacchia), avec la paire (p, q) de la forme si elle existe. Avec -results, vérifie que la décomposition de chaque résultat d'un fichier JSON est formée de p et de la seconde racine de la forme (2q pour p^2+4q^2): une vérification structurelle indépendante (code de sortie 5 en cas d'écart).\n\nOptions:\n",
+		msgFlagDecomposeResults:   "Fichier de résultats JSON dont les résultats sont vérifiés par leur décomposition (la forme de son manifeste remplace -form).",
+		msgDecomposeNone:          "%d: pas une somme de deux carrés (non premier, ou premier ≡ 3 mod 4)",
+		msgDecomposeSummary:       "%d résultats vérifiés par décomposition (forme %s): %d écarts, %d au-delà d'int64 ignorés.\n",
+		msgFlagReverse:            "Recherche inverse pour p^2+4q^2: crible les nombres premiers n jusqu'à la plus grande valeur de la forme et retient ceux dont la décomposition de Cornacchia n = p^2 + 4q^2 a p et q premiers, au lieu de tester chaque paire. Mêmes résultats que la recherche directe, triés par n: un contrôle croisé de bout en bout.",
+		msgReverseThroughput:      "Débit: %d entiers/s (%d entiers n criblés en %s, recherche inverse).\n",
+		msgPseudoUsage:            "Utilisation: pseudoprimes [options]\n\nAffiche les nombres composés jusqu'à -limit qui passent le test de Fermat, d'Euler-Jacobi ou de Miller fort pour toutes les bases de -base, un par ligne. La primalité vient du crible.\n\nOptions:\n",
+		msgFlagPseudoBase:         "Bases du test, séparées par des virgules (ex: 2 ou 2,3,5): un pseudo-premier passe le test pour chacune.",
+		msgFlagPseudoLimit:        "Plus grand nombre examiné.",
+		msgFlagPseudoTest:         "Test de primalité probable: %s.",
+		msgPseudoSummary:          "%d pseudo-premiers (test %s, bases %s) jusqu'à %d.\n",
+		msgFlagJobsBuffer:         "Capacité du canal des tâches, en lots (0: adaptative, selon -workers et -batch).",
+		msgFlagResultsBuffer:      "Capacité du canal des résultats (0: adaptative, selon -workers et -batch).",
+		msgBuffers:                "Tampons des canaux: tâches %d lots (%s), résultats %d (%s)\n",
+		msgBufferAdaptive:         "adaptatif",
+		msgFlagAPK:                "Nombre minimal de termes des progressions comptées (>= 3).",
+		msgFlagAPResults:          "Fichier de résultats JSON dont les valeurs de n sont analysées au lieu de lancer une recherche (la forme de son manifeste remplace -form).",
+		msgAPTitle:                "Progressions arithmétiques maximales d'au moins %d termes parmi %s valeurs distinctes de n (forme %s):\n",
+		msgAPCount:                "  %d termes: %s\n",
+		msgAPNone:                 "Aucune progression de %d termes.\n",
+		msgAPLongest:              "Plus longues (%d termes):\n",
+		msgAPTerm:                 "  %s (raison %d)\n",
+		msgAPMore:                 "  ... et %s autres\n",
+		msgDecadesTitle:           "Résultats par ordre de grandeur de n:\n",
+		msgDecadesLine:            "  10^%d <= n < 10^%d: %s (%.1f %%)\n",
+		msgDecadesBig:             "  n > 2^63-1: %s (%.1f %%)\n",
+		msgStatsDecades:           "  par décade: %s\n",
+		msgFlagCPUQuota:           "CPU disponibles pour les workers: auto (quota CFS du cgroup, sous Linux), off (tous les CPU visibles) ou un nombre de CPU (ex: 2.5); dimensionne -workers et GOMAXPROCS par défaut.",
+		msgCPUQuota:               "Quota CPU: %.2f CPU (%s) sur %d CPU visibles: %d workers par défaut, GOMAXPROCS=%d\n",
+		msgFlagSort:               "Écrit les résultats triés par n croissant (puis p, q), en fin de recherche: au-delà de -sort-memory, des séries triées sont déversées dans des fichiers temporaires puis fusionnées (tri externe).",
+		msgFlagSortMemory:         "Mémoire gardée pour les résultats de -sort avant déversement sur disque (ex: 64MiB, 1GiB).",
+		msgFlagSortDir:            "Répertoire des fichiers temporaires de -sort (défaut: répertoire temporaire du système).",
+		msgSortSummary:            "Sortie triée (-sort): %d résultats écrits, %d doublons de n écartés, %d séries déversées (%s sur disque).\n",
+		msgFlagPlugin:             "Lance un greffon (ligne de commande, répétable) qui fournit des formes (-form) et des destinations de résultats (-sink FORMAT:plugin://NOM) par un protocole JSON sur son entrée et sa sortie standard.",
+		msgPluginLoaded:           "Greffon %s: formes [%s], destinations [%s]\n",
+		msgFlagUnrepMax:           "Nombre de plus petits nombres premiers non représentés listés (-1: tous).",
+		msgUnrepTitle:             "%s nombres premiers ≡ 1 mod 4 jusqu'à %d: %s de la forme p^2 + 4q^2 avec p et q premiers, %s non représentés (%.2f %%)\n",
+		msgUnrepReasons:           "  x non premier: %s, y non premier: %s, aucun des deux premier: %s\n",
+		msgUnrepSmallest:          "Plus petits non représentés (n = x^2 + 4y^2):\n",
+		msgUnrepTerm:              "  %d = %d^2 + 4·%d^2 (%s)\n",
+		msgUnrepNotX:              "x non premier",
+		msgUnrepNotY:              "y non premier",
+		msgUnrepNeither:           "aucun des deux premier",
+		msgFlagUnrepLimit:         "Limite supérieure des nombres premiers n examinés.",
+		msgFlagBatchTarget:        "Durée visée d'un lot: la taille des lots s'adapte pendant l'exécution au coût observé des paires, à partir de -batch (0: taille fixe).",
+		msgBatchTargetSummary:     "Lots adaptatifs (cible %v): %d paires à la fin, de %d à %d, %d ajustements\n",
+		msgFlagFirst:              "Arrête la recherche au premier résultat (après -filter et -where) et l'affiche avec le détail de sa vérification; les petits p et q sont énumérés d'abord.",
+		msgFirstDetail:            "Premier résultat: n = %d (p=%d, q=%d, forme %s) après %s\n",
+		msgFirstVerified:          "Vérification indépendante (test %s): p, q et n sont premiers et n = %s pour (p, q): confirmé\n",
+		msgFirstSquares:           "Somme de deux carrés (Cornacchia): %d = %d^2 + %d^2\n",
+		msgFirstNone:              "Aucun résultat: -first n'a trouvé aucun nombre premier retenu\n",
+		msgFlagSummaryOut:         "Écrit dans ce fichier, à la fin de l'exécution et même en cas d'échec, son bilan en JSON (comptes, durées, état des vérifications, code de sortie).",
+		msgFlagSummaryJUnit:       "Écrit le même bilan dans ce fichier au format XML de JUnit (un cas de test pour la recherche et un par vérification).",
+		msgSummaryWritten:         "Bilan de l'exécution écrit dans %s\n",
+		msgConvertUsage:           "Utilisation: convert [options] ENTRÉE SORTIE\n\nRéécrit un fichier de résultats dans un autre format, pour ne pas rester lié au format choisi à l'exécution. Formats lus: json, ndjson, csv, pbz (flux Zstandard de messages protobuf délimités) et parquet (tel qu'écrit par -format parquet: pages PLAIN, colonnes obligatoires). Formats écrits: ceux de -format, tableau compris. Les formats sont déduits des extensions (.json, .ndjson ou .jsonl, .csv, .pbz ou .zst, .parquet, .txt pour le tableau) ou donnés par -from et -to. ENTRÉE peut être '-' (entrée standard); SORTIE peut être '-' (sortie standard) ou, comme pour -sink, tcp://hôte:port. Le manifeste du fichier d'entrée, s'il en porte un, est reporté tel quel dans tous les formats de sortie sauf ndjson.\n\nOptions:\n",
+		msgFlagConvertFrom:        "Format d'entrée (%s); déduit de l'extension par défaut.",
+		msgFlagConvertTo:          "Format de sortie (%s); déduit de l'extension par défaut.",
+		msgConvertSummary:         "%s résultats convertis de %s (%s) vers %s (%s)%s\n",
+		msgConvertSizes:           ": %s -> %s",
+		msgFlagConvertForm:        "Forme nommée dans l'en-tête du format tableau.",
+		msgFlagLogLevel:           "Niveau des messages d'état: %s (error: résultats et erreur finale seulement; warn: avertissements seulement; info: tous les messages). Modifiable pendant l'exécution en rechargeant -config (SIGHUP).",
+		msgFlagConfig:             "Fichier d'options: une ligne 'option = valeur' par option (noms sans tiret, commentaires #), appliquées sauf si données sur la ligne de commande. Sur SIGHUP, le fichier est relu et -log-level, -stats-interval et -cpu-percent prennent ses valeurs sans redémarrer la recherche; les autres options modifiées attendent le prochain démarrage.",
+		msgConfigReloaded:         "Options rechargées depuis %s: %s\n",
+		msgConfigReloadedNone:     "Options rechargées depuis %s: aucun changement\n",
+		msgConfigReloadFailed:     "Avertissement: options non rechargées, réglages actuels conservés: %v\n",
+		msgConfigRestart:          "Avertissement: options modifiées prises en compte au prochain démarrage seulement: %s\n",
+		msgFlagStallTimeout:       "Chien de garde des workers: signaler tout candidat dont le test de primalité dure depuis plus que ce délai, avec sa paire (p, q) (ex: '10m'; 0: désactivé).",
+		msgFlagSkipStalled:        "Avec -stall-timeout: abandonner la paire bloquée et confier la suite à un nouveau worker; son résultat est perdu (rappelé dans le résumé) et son test se poursuit en arrière-plan jusqu'à sa fin.",
+		msgStall:                  "Avertissement: worker %d bloqué depuis %v sur n = %s (p=%d, q=%d)\n",
+		msgStallSkipped:           "Avertissement: worker %d bloqué depuis %v sur n = %s (p=%d, q=%d): paire abandonnée, un nouveau worker prend la suite\n",
+		msgStallSummary:           "Avertissement: %s paire(s) abandonnée(s) par le chien de garde (-skip-stalled), résultats incomplets: %s\n",
+		msgError:                  "Erreur: %v\n",
+		msgInit:                   "Initialisation avec searchLimit=%d, numWorkers=%d, primeTest='%s'\n",
+		msgSieving:                "Génération des nombres premiers avec le crible d'Eratosthène...\n",
+		msgSievingAccel:           "Génération des nombres premiers avec le crible d'Eratosthène, marquage déchargé sur %v...\n",
+		msgNoPrimes:               "Aucun nombre premier trouvé dans la limite spécifiée.\n",
+		msgPrimesFound:            "%d nombres premiers trouvés jusqu'à %d.\n\n",
+		msgDashboardError:         "Impossible de démarrer le tableau de bord sur %s: %v\n",
+		msgDashboardURL:           "Tableau de bord disponible sur http://%s/\n\n",
+		msgColumnCheck:            "Vérification",
+		msgFound:                  "Trouvé!",
+		msgTUIError:               "Erreur de l'interface terminal: %v\n",
+		msgInterrupted:            "Recherche interrompue; les résultats sont partiels.\n",
+		msgSummary:                "Recherche terminée. %d nombres premiers spéciaux trouvés.\n",
+		msgDuration:               "\nDurée totale de l'exécution: %s\n",
+		msgTUIRunning:             "en cours",
+		msgTUIDone:                "terminée",
+		msgTUIStopping:            "arrêt en cours…",
+		msgTUIPaused:              "en pause",
+		msgTUITitle:               "n = p² + 4q² — limite=%d, workers=%d, test=%s — %s\n\n",
+		msgTUIProgress:            "Progression  %s %5.1f %%  (%d / %d paires)\n",
+		msgTUIFound:               "Trouvés      %d    Durée %s\n",
+		msgTUIRate:                "Débit        %s %.0f paires/s\n\n",
+		msgTUIUtilization:         "Utilisation des workers\n",
+		msgTUIKeysRunning:         "\n[p/espace] pause/reprise  [r] reprendre  [q] arrêter\n",
+		msgTUIKeysDone:            "\n[q] quitter\n",
+		msgUnsupportedLang:        "Langue %q non supportée, utilisation de %s.\n",
+		msgDefaultLangLabel:       "français",
+		msgErrInvalidFlags:        "options invalides",
+		msgErrInterrupted:         "recherche interrompue, résultats partiels",
+		msgErrVerification:        "échec de la vérification",
+		msgErrIO:                  "erreur d'entrée/sortie",
+		msgErrMemoryBudget:        "budget mémoire insuffisant",
+		msgErrInvalidInput:        "données d'entrée invalides",
+		msgConfigLimitEmpty:       "-limit=%d: aucun nombre premier p, q <= %d, la recherche serait vide; choisir -limit >= 2",
+		msgConfigPrimesEmpty:      "la liste de nombres premiers importée est vide; vérifier -primes-file ou fixer -limit >= 2",
+		msgConfigPairsEmpty:       "la région -pairs %s ne contient aucune paire avec %d nombre(s) premier(s) jusqu'à %d; relever -limit ou choisir -pairs %s",
+		msgConfigWhereContradict:  "-where %q: les bornes de n se contredisent (%d <= n <= %d); corriger l'une des conditions",
+		msgConfigWhereTooSmall:    "-where %q: n <= %d est impossible, le plus petit n de la forme %s est %d (p=%d, q=%d); relever la borne de n ou la retirer",
+		msgConfigWhereTooLarge:    "-where %q: n >= %d est impossible, n ne dépasse pas %d jusqu'à %d; relever -limit ou abaisser la borne de n",
+		msgConfigProblems:         "%d incohérences:\n  - %s",
+		msgConfigFileSyntax:       "%s:%d: %q (attendu option = valeur)",
+		msgConfigFileCommandLine:  "%s:%d: -%s n'est accepté que sur la ligne de commande",
+		msgConfigFileUnknown:      "%s:%d: option inconnue -%s",
+		msgConfigLogLevel:         "-log-level=%q (attendu l'un de %v)",
+		msgConfigStatsInterval:    "%s: -stats-interval=%q (attendu une durée >= 0)",
+		msgConfigCPUPercent:       "%s: -cpu-percent=%q (attendu entre 1 et 100)",
+		msgFileLocked:             "%s est utilisé par une autre exécution (verrou %s)",
+	},
+}
+
+// currentLanguage est la langue active, fixée au démarrage par setLanguage.
+var currentLanguage = defaultLanguage
+
+// setLanguage active la langue donnée pour tous les messages suivants.
+func setLanguage(tag language.Tag) {
+	currentLanguage = tag
+}
+
+// tr retourne le message traduit dans la langue active, formaté avec les arguments donnés.
+// Un message absent de la langue active est cherché dans la langue de repli (anglais).
+func tr(id msgID, args ...any) string {
+	format, ok := catalog[currentLanguage][id]
+	if !ok {
+		format = catalog[supportedLanguages[0]][id]
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// matchLanguage retourne la langue supportée la plus proche de la valeur donnée
+// ("en", "fr_CA.UTF-8", ...). ok vaut false si la valeur ne correspond à aucune langue supportée.
+func matchLanguage(value string) (tag language.Tag, ok bool) {
+	// Les variables de locale POSIX utilisent '_' et un suffixe d'encodage ("fr_FR.UTF-8").
+	value, _, _ = strings.Cut(value, ".")
+	value, _, _ = strings.Cut(value, "@")
+	parsed, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+	if err != nil {
+		return supportedLanguages[0], false
+	}
+	matcher := language.NewMatcher(supportedLanguages)
+	_, index, confidence := matcher.Match(parsed)
+	if confidence == language.No {
+		return supportedLanguages[0], false
+	}
+	return supportedLanguages[index], true
+}
+
+// selectLanguage détermine la langue à partir de l'option -lang puis de l'environnement.
+// Les locales neutres "C" et "POSIX" sont ignorées.
+func selectLanguage(flagValue string, getenv func(string) string) language.Tag {
+	if flagValue != "" {
+		tag, _ := matchLanguage(flagValue)
+		return tag
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := getenv(name)
+		if value == "" || value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+			continue
+		}
+		tag, _ := matchLanguage(value)
+		return tag
+	}
+	return defaultLanguage
+}
+
+// langFromArgs extrait la valeur de l'option -lang des arguments de la ligne de commande.
+// Elle est lue avant flag.Parse afin de traduire les textes d'aide des options.
+func langFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "lang" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
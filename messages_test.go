@@ -0,0 +1,106 @@
+/*
+ * Fichier: messages_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du catalogue de messages et de la sélection de la langue.
+ */
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestCatalogComplete vérifie que chaque message existe dans toutes les langues supportées,
+// avec le même nombre de verbes de formatage.
+func TestCatalogComplete(t *testing.T) {
+	reference := catalog[supportedLanguages[0]]
+	for _, tag := range supportedLanguages {
+		messages := catalog[tag]
+		if len(messages) != len(reference) {
+			t.Errorf("%s: %d messages, attendu %d", tag, len(messages), len(reference))
+		}
+		for id, format := range reference {
+			translated, ok := messages[id]
+			if !ok {
+				t.Errorf("%s: message %q manquant", tag, id)
+				continue
+			}
+			if verbs(translated) != verbs(format) {
+				t.Errorf("%s: message %q a %d verbes, attendu %d", tag, id, verbs(translated), verbs(format))
+			}
+		}
+	}
+}
+
+// verbs compte les verbes de formatage d'une chaîne de format, hors "%%".
+func verbs(format string) int {
+	return strings.Count(format, "%") - 2*strings.Count(format, "%%")
+}
+
+// TestTr valide la traduction et le formatage des messages.
+func TestTr(t *testing.T) {
+	defer setLanguage(defaultLanguage)
+
+	setLanguage(language.English)
+	if got := tr(msgSummary, 3); got != "Search complete. 3 special primes found.\n" {
+		t.Errorf("tr(en) = %q", got)
+	}
+	setLanguage(language.French)
+	if got := tr(msgSummary, 3); got != "Recherche terminée. 3 nombres premiers spéciaux trouvés.\n" {
+		t.Errorf("tr(fr) = %q", got)
+	}
+}
+
+// TestSelectLanguage valide la priorité -lang > LC_ALL > LC_MESSAGES > LANG et les replis.
+func TestSelectLanguage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		flag     string
+		env      map[string]string
+		expected language.Tag
+	}{
+		{"Défaut sans configuration", "", nil, defaultLanguage},
+		{"Option -lang", "en", map[string]string{"LANG": "fr_FR.UTF-8"}, language.English},
+		{"Variante régionale", "fr-CA", nil, language.French},
+		{"LANG anglais", "", map[string]string{"LANG": "en_US.UTF-8"}, language.English},
+		{"LC_ALL prioritaire", "", map[string]string{"LC_ALL": "fr_FR.UTF-8", "LANG": "en_US.UTF-8"}, language.French},
+		{"LC_MESSAGES avant LANG", "", map[string]string{"LC_MESSAGES": "en_GB", "LANG": "fr_FR"}, language.English},
+		{"Locale C ignorée", "", map[string]string{"LC_ALL": "C.UTF-8", "LANG": "en_US"}, language.English},
+		{"Langue non supportée", "", map[string]string{"LANG": "xx"}, supportedLanguages[0]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getenv := func(name string) string { return tc.env[name] }
+			if got := selectLanguage(tc.flag, getenv); got != tc.expected {
+				t.Errorf("selectLanguage(%q) = %s, attendu %s", tc.flag, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestLangFromArgs valide l'extraction de -lang avant l'analyse complète des options.
+func TestLangFromArgs(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"-limit", "10"}, ""},
+		{[]string{"-lang=en"}, "en"},
+		{[]string{"--lang", "fr", "-tui"}, "fr"},
+		{[]string{"-limit=5", "-lang", "en"}, "en"},
+		{[]string{"--", "-lang=en"}, ""},
+		{[]string{"-language=en"}, ""},
+	}
+
+	for _, tc := range testCases {
+		if got := langFromArgs(tc.args); got != tc.expected {
+			t.Errorf("langFromArgs(%v) = %q, attendu %q", tc.args, got, tc.expected)
+		}
+	}
+}
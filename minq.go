@@ -0,0 +1,159 @@
+/*
+ * Fichier: minq.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande min-q: pour chaque nombre premier p jusqu'à la limite, le plus
+ * petit nombre premier q tel que n = forme(p, q) soit premier
+ * (primes.MinimalQ), sous forme de tableau ou de JSON, précédé du manifeste
+ * de l'exécution (manifest.go) sauf avec -manifest=false.
+ */
+package main
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// minQFormats sont les formats de sortie acceptés par min-q.
+var minQFormats = []string{"table", "json"}
+
+// minQRow est une ligne du rapport au format JSON; q et n valent null si aucun q ne convient.
+type minQRow struct {
+	P int    `json:"p"`
+	Q *int   `json:"q"`
+	N *int64 `json:"n"`
+}
+
+// writeMinQ écrit le rapport sur w au format donné ("table" ou "json"); formName titre la colonne n.
+// Un manifeste non nil encadre le tableau en commentaires ou enveloppe les lignes JSON dans un objet.
+func writeMinQ(w io.Writer, report []primes.MinQ, formName, format string, manifest *runManifest) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case "table":
+		if manifest != nil {
+			manifest.writeHeader(bw, true)
+		}
+		fmt.Fprintf(bw, "%-10s | %-10s | %-25s\n", "p", "q", "n = "+formName)
+		for _, row := range report {
+			if row.Q == 0 {
+				fmt.Fprintf(bw, "%-10d | %-10s | %-25s\n", row.P, "-", tr(msgMinQNone))
+				continue
+			}
+			fmt.Fprintf(bw, "%-10d | %-10d | %-25d\n", row.P, row.Q, row.N)
+		}
+		if manifest != nil {
+			manifest.writeTrailer(bw)
+		}
+	case "json":
+		rows := make([]minQRow, len(report))
+		for i, row := range report {
+			rows[i] = minQRow{P: row.P}
+			if row.Q != 0 {
+				rows[i].Q, rows[i].N = &report[i].Q, &report[i].N
+			}
+		}
+		var doc any = rows
+		if manifest != nil {
+			manifest.finish()
+			doc = struct {
+				Manifest *runManifest `json:"manifest"`
+				Rows     []minQRow    `json:"rows"`
+			}{manifest, rows}
+		}
+		if err := json.NewEncoder(bw).Encode(doc); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("format inconnu %q", format)
+	}
+	return bw.Flush()
+}
+
+// runMinQ implémente la sous-commande min-q.
+func runMinQ(args []string, stdout, stderr io.Writer) (err error) {
+	start := time.Now()
+	fs := flag.NewFlagSet("min-q", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	limitPtr := fs.Int("limit", 1000, tr(msgFlagMinQLimit))
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	primeTestPtr := fs.String("primetest", "miller", tr(msgFlagPrimeTest))
+	workersPtr := fs.Int("workers", defaultWorkers(), tr(msgFlagWorkers))
+	formatPtr := fs.String("format", "table", tr(msgFlagMinQFormat))
+	outputPtr := fs.String("o", "", tr(msgFlagListOutput))
+	manifestPtr := fs.Bool("manifest", true, tr(msgFlagManifest))
+	signPtr := fs.String("sign", "", tr(msgFlagSign))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if !slices.Contains(minQFormats, *formatPtr) {
+		return fmt.Errorf("%w: -format=%q (attendu %v)", errInvalidFlags, *formatPtr, minQFormats)
+	}
+	if !slices.Contains(primes.PrimalityTestNames(), *primeTestPtr) {
+		return fmt.Errorf("%w: -primetest=%q (attendu l'un de %v)", errInvalidFlags, *primeTestPtr, primes.PrimalityTestNames())
+	}
+	if *workersPtr < 1 {
+		return fmt.Errorf("%w: -workers=%d (attendu >= 1)", errInvalidFlags, *workersPtr)
+	}
+	form, ok := primes.LookupForm(*formPtr)
+	if !ok {
+		return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, *formPtr, primes.FormNames())
+	}
+	if err := primes.CheckFormLimit(form, *limitPtr); err != nil {
+		return err
+	}
+
+	var signingKey ed25519.PrivateKey
+	if *signPtr != "" {
+		if *outputPtr == "" {
+			return fmt.Errorf("%w: -sign exige -o", errInvalidFlags)
+		}
+		if signingKey, err = loadSigningKey(*signPtr); err != nil {
+			return err
+		}
+	}
+
+	var w io.Writer = stdout
+	if *outputPtr != "" {
+		f, err := os.Create(*outputPtr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errIO, err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("%w: %v", errIO, cerr)
+			}
+			if err == nil && signingKey != nil {
+				err = signFile(*outputPtr, signingKey)
+			}
+		}()
+		w = f
+	}
+
+	report := primes.MinimalQ(form, primes.SieveOfEratosthenes(*limitPtr), *primeTestPtr, *workersPtr)
+	var manifest *runManifest
+	if *manifestPtr {
+		algorithms := map[string]string{"sieve": "eratosthenes", "primetest": *primeTestPtr, "form": form.Name()}
+		manifest = newManifest("min-q", args, fs, algorithms, start)
+	}
+	if err := writeMinQ(w, report, form.Name(), *formatPtr, manifest); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Fichier: minq_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande min-q et de ses formats de sortie.
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestWriteMinQ valide les formats tableau et JSON, lignes sans q comprises.
+func TestWriteMinQ(t *testing.T) {
+	report := []primes.MinQ{{P: 2}, {P: 3, Q: 5, N: 109}}
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"table", "p          | q          | n = p^2+4q^2             \n" +
+			"2          | -          | aucun                    \n" +
+			"3          | 5          | 109                      \n"},
+		{"json", `[{"p":2,"q":null,"n":null},{"p":3,"q":5,"n":109}]` + "\n"},
+	}
+	defer setLanguage(defaultLanguage)
+	setLanguage(language.French)
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		if err := writeMinQ(&buf, report, "p^2+4q^2", tc.format, nil); err != nil {
+			t.Fatalf("writeMinQ(%s): %v", tc.format, err)
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("writeMinQ(%s) = %q, attendu %q", tc.format, buf.String(), tc.expected)
+		}
+	}
+}
+
+// TestRunMinQ valide la sous-commande de bout en bout et les options invalides.
+func TestRunMinQ(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"min-q", "-limit", "10", "-format", "json", "-workers", "2"}, &out, io.Discard); err != nil {
+		t.Fatalf("min-q: %v", err)
+	}
+	var doc struct {
+		Manifest runManifest     `json:"manifest"`
+		Rows     json.RawMessage `json:"rows"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("sortie JSON invalide: %v", err)
+	}
+	if m := doc.Manifest; m.Command != "min-q" || m.RunID == "" || m.Params["limit"] != "10" || m.Algorithms["primetest"] != "miller" || m.End.Before(m.Start) {
+		t.Errorf("manifeste = %+v", m)
+	}
+	expected := `[{"p":2,"q":null,"n":null},{"p":3,"q":5,"n":109},{"p":5,"q":2,"n":41},{"p":7,"q":5,"n":149}]`
+	if string(doc.Rows) != expected {
+		t.Errorf("lignes = %s, attendu %s", doc.Rows, expected)
+	}
+
+	for _, args := range [][]string{{"min-q", "-format", "xml"}, {"min-q", "-form", "inconnue"}, {"min-q", "-workers", "0"}} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !unix
+
+/*
+ * Fichier: mmap_other.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Hors Unix, les fichiers sont simplement lus en mémoire.
+ */
+package main
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile lit les size premiers octets de f. unmap est sans effet.
+func mapFile(f *os.File, size int) (data []byte, unmap func() error, err error) {
+	data = make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,26 @@
+//go:build unix
+
+/*
+ * Fichier: mmap_unix.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Projection en mémoire des fichiers en lecture seule sous Unix (cache des nombres premiers).
+ */
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile projette les size premiers octets de f en mémoire, en lecture seule.
+// unmap libère la projection; data ne doit plus être utilisé ensuite.
+func mapFile(f *os.File, size int) (data []byte, unmap func() error, err error) {
+	data, err = syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,55 @@
+/*
+ * Fichier: nthprime.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande nth-prime: affiche le n-ième nombre premier (primes.NthPrime).
+ */
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// runNthPrime implémente la sous-commande nth-prime.
+func runNthPrime(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("nth-prime", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgNthUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: nth-prime: au moins un rang N est requis", errInvalidFlags)
+	}
+
+	ranks := make([]int64, fs.NArg())
+	for i, arg := range fs.Args() {
+		n, err := parseCountArg(arg)
+		if err != nil || n < 1 {
+			return fmt.Errorf("%w: nth-prime: rang invalide %q", errInvalidFlags, arg)
+		}
+		ranks[i] = n
+	}
+
+	out := &errWriter{w: stdout}
+	for _, n := range ranks {
+		p, _ := primes.NthPrime(n)
+		fmt.Fprintf(out, "p(%d) = %d\n", n, p)
+	}
+	return writeError(out)
+}
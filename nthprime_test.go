@@ -0,0 +1,31 @@
+/*
+ * Fichier: nthprime_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande nth-prime.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// TestRunNthPrime valide la sous-commande de bout en bout.
+func TestRunNthPrime(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"nth-prime", "1", "1e6"}, &out, io.Discard); err != nil {
+		t.Fatalf("nth-prime: %v", err)
+	}
+	if want := "p(1) = 2\np(1000000) = 15485863\n"; out.String() != want {
+		t.Errorf("sortie = %q, attendu %q", out.String(), want)
+	}
+	for _, args := range [][]string{{"nth-prime"}, {"nth-prime", "0"}} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("run(%v) -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Fichier: numfmt.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Présentation des nombres dans les sorties lisibles (option -numbers): le
+ * tableau des résultats et le résumé de la recherche groupent les chiffres
+ * selon la langue active (1,234,567 en anglais, 1 234 567 en français, par
+ * golang.org/x/text/number), et le résumé peut abréger les grands comptes
+ * avec un suffixe SI (1.2M). Les formats machine (JSON, NDJSON) ne sont pas
+ * concernés.
+ */
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// numberStyles sont les présentations acceptées par -numbers.
+var numberStyles = []string{"plain", "grouped", "si"}
+
+// numberStyle est la présentation active, fixée au démarrage par l'option -numbers.
+var numberStyle = "grouped"
+
+// numberPrinters formatent les nombres selon chaque langue supportée. Un message.Printer peut
+// servir depuis plusieurs goroutines.
+var numberPrinters = func() map[language.Tag]*message.Printer {
+	printers := make(map[language.Tag]*message.Printer, len(supportedLanguages))
+	for _, tag := range supportedLanguages {
+		printers[tag] = message.NewPrinter(tag)
+	}
+	return printers
+}()
+
+// siSuffixes sont les suffixes SI des puissances de 1000, à partir de 1000.
+var siSuffixes = []string{"k", "M", "G", "T", "P", "E"}
+
+// groupedInt est un entier affiché exactement, chiffres groupés sauf en présentation plain. Il
+// s'utilise avec %d, largeur et alignement à gauche compris.
+type groupedInt int64
+
+// Format implémente fmt.Formatter.
+func (v groupedInt) Format(f fmt.State, verb rune) {
+	if numberStyle == "plain" {
+		padNumber(f, fmt.Sprint(int64(v)))
+		return
+	}
+	padNumber(f, numberPrinters[currentLanguage].Sprint(number.Decimal(int64(v))))
+}
+
+// countInt est un compte du résumé: groupé, ou abrégé avec un suffixe SI en présentation si
+// (au-delà de 9999, avec une décimale: 1.2M).
+type countInt int64
+
+// Format implémente fmt.Formatter.
+func (v countInt) Format(f fmt.State, verb rune) {
+	if numberStyle != "si" || v > -10000 && v < 10000 {
+		groupedInt(v).Format(f, verb)
+		return
+	}
+	scaled, suffix := float64(v), ""
+	for _, s := range siSuffixes {
+		if scaled > -1000 && scaled < 1000 {
+			break
+		}
+		scaled, suffix = scaled/1000, s
+	}
+	padNumber(f, numberPrinters[currentLanguage].Sprint(number.Decimal(scaled, number.MaxFractionDigits(1)))+suffix)
+}
+
+// groupedBig est un entier arbitraire (candidat promu au-delà d'int64, -on-overflow promote-big)
+// affiché exactement, groupé comme groupedInt.
+type groupedBig struct{ *big.Int }
+
+// Format implémente fmt.Formatter.
+func (v groupedBig) Format(f fmt.State, verb rune) {
+	if v.IsInt64() {
+		groupedInt(v.Int64()).Format(f, verb)
+		return
+	}
+	s := v.String()
+	if numberStyle != "plain" {
+		// golang.org/x/text/number ne formate pas les big.Int: le séparateur de la langue est
+		// inséré à la main, tous les trois chiffres.
+		sep := strings.Trim(numberPrinters[currentLanguage].Sprint(number.Decimal(1000)), "01")
+		digits := strings.TrimPrefix(s, "-")
+		var b strings.Builder
+		b.WriteString(s[:len(s)-len(digits)])
+		for i, d := range digits {
+			if i > 0 && (len(digits)-i)%3 == 0 {
+				b.WriteString(sep)
+			}
+			b.WriteRune(d)
+		}
+		s = b.String()
+	}
+	padNumber(f, s)
+}
+
+// padNumber écrit s complété à la largeur demandée, comptée en caractères: le séparateur des
+// milliers du français (espace insécable) occupe deux octets.
+func padNumber(f fmt.State, s string) {
+	width, ok := f.Width()
+	padding := ""
+	if n := utf8.RuneCountInString(s); ok && width > n {
+		padding = strings.Repeat(" ", width-n)
+	}
+	if f.Flag('-') {
+		fmt.Fprint(f, s, padding)
+	} else {
+		fmt.Fprint(f, padding, s)
+	}
+}
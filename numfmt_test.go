@@ -0,0 +1,74 @@
+/*
+ * Fichier: numfmt_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la présentation des nombres des sorties lisibles (option -numbers).
+ */
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// bigTen20 vaut 10^20, au-delà d'int64.
+var bigTen20 = new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)
+
+// TestNumberStyles valide le groupement selon la langue, l'abréviation SI et l'alignement.
+func TestNumberStyles(t *testing.T) {
+	defer func() { numberStyle = "grouped" }()
+	defer setLanguage(defaultLanguage)
+	testCases := []struct {
+		style  string
+		lang   language.Tag
+		format string
+		value  any
+		want   string
+	}{
+		{"grouped", language.English, "%d", groupedInt(1234567), "1,234,567"},
+		{"grouped", language.French, "%d", groupedInt(1234567), "1\u00a0234\u00a0567"},
+		{"grouped", language.French, "[%-8d]", groupedInt(12345), "[12\u00a0345  ]"},
+		{"grouped", language.English, "[%8d]", groupedInt(-12345), "[ -12,345]"},
+		{"plain", language.English, "%d", groupedInt(1234567), "1234567"},
+		{"plain", language.English, "%d", countInt(1234567), "1234567"},
+		{"si", language.English, "%d", groupedInt(1234567), "1,234,567"}, // Valeur exacte: jamais abrégée.
+		{"si", language.English, "%d", countInt(9999), "9,999"},
+		{"si", language.English, "%d", countInt(1234567), "1.2M"},
+		{"si", language.French, "%d", countInt(2500000000), "2,5G"},
+		{"si", language.English, "%d", countInt(-45000), "-45k"},
+		{"grouped", language.English, "%d", groupedBig{bigTen20}, "100,000,000,000,000,000,000"},
+		{"grouped", language.French, "[%-28d]", groupedBig{bigTen20}, "[100\u00a0000\u00a0000\u00a0000\u00a0000\u00a0000\u00a0000 ]"},
+		{"plain", language.English, "%d", groupedBig{bigTen20}, "100000000000000000000"},
+		{"grouped", language.English, "%d", groupedBig{big.NewInt(-1234)}, "-1,234"},
+	}
+	for _, tc := range testCases {
+		numberStyle = tc.style
+		setLanguage(tc.lang)
+		if got := fmt.Sprintf(tc.format, tc.value); got != tc.want {
+			t.Errorf("%s, %v: Sprintf(%q, %d) = %q, attendu %q", tc.style, tc.lang, tc.format, tc.value, got, tc.want)
+		}
+	}
+}
+
+// TestRunNumbers valide -numbers sur le tableau et le résumé, et le refus d'une présentation inconnue.
+func TestRunNumbers(t *testing.T) {
+	defer func() { numberStyle = "grouped" }()
+	var out bytes.Buffer
+	if err := run([]string{"-limit", "60", "-workers", "1", "-numbers", "plain", "-lang", "en"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "| 11261 ") || !strings.Contains(out.String(), "88 special primes found") {
+		t.Errorf("-numbers plain:\n%s", out.String())
+	}
+	if got := exitCode(run([]string{"-numbers", "roman"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("-numbers roman -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
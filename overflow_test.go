@@ -0,0 +1,124 @@
+/*
+ * Fichier: overflow_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'option -on-overflow sur une liste importée dépassant la limite
+ * de la forme.
+ */
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// resultLines retourne les lignes NDJSON d'une sortie, sans les messages d'état.
+func resultLines(out string) string {
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "{") && !strings.HasPrefix(line, ndjsonManifestPrefix) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TestRunOnOverflow valide les quatre politiques de débordement de bout en bout.
+func TestRunOnOverflow(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+
+	// Pour q >= 1.6e9, 4q^2 dépasse int64.
+	var list strings.Builder
+	list.WriteString("3\n5\n7\n11\n")
+	for x, found := int64(1_600_000_000), 0; found < 3; x++ {
+		if primes.IsPrime(x) {
+			fmt.Fprintln(&list, x)
+			found++
+		}
+	}
+	path := filepath.Join(t.TempDir(), "primes.txt")
+	if err := os.WriteFile(path, []byte(list.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	base := []string{"-primes-file", path, "-workers", "1", "-manifest=false"}
+
+	if got := exitCode(run(base, io.Discard, io.Discard)); got != exitOverflow {
+		t.Errorf("politique error -> code %d, attendu %d", got, exitOverflow)
+	}
+
+	var stdout bytes.Buffer
+	if err := run(append(base, "-on-overflow", "skip"), &stdout, io.Discard); err != nil {
+		t.Fatalf("-on-overflow skip: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "21 paires ignorées: n dépasse int64") {
+		t.Errorf("résumé sans le compte des paires ignorées:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := run(append(base, "-on-overflow", "promote-big", "-format", "ndjson"), &stdout, io.Discard); err != nil {
+		t.Fatalf("-on-overflow promote-big: %v", err)
+	}
+	if !strings.Contains(stdout.String(), `"n":9223372036854775807,"n_big":`) {
+		t.Errorf("aucun résultat promu en sortie:\n%s", stdout.String())
+	}
+
+	// Mêmes résultats avec des bases tirées de crypto/rand.
+	var crypto bytes.Buffer
+	if err := run(append(base, "-on-overflow", "promote-big", "-format", "ndjson", "-witness-source", "crypto"), &crypto, io.Discard); err != nil {
+		t.Fatalf("-witness-source crypto: %v", err)
+	}
+	if resultLines(crypto.String()) != resultLines(stdout.String()) {
+		t.Errorf("résultats différents avec -witness-source crypto:\n%s", crypto.String())
+	}
+
+	// Tous ces candidats tiennent dans un uint64: mêmes résultats avec -on-overflow uint64, mais la
+	// limite est vérifiée jusqu'à 2^64.
+	var wide bytes.Buffer
+	if err := run(append(base, "-on-overflow", "uint64", "-format", "ndjson"), &wide, io.Discard); err != nil {
+		t.Fatalf("-on-overflow uint64: %v", err)
+	}
+	if resultLines(wide.String()) != resultLines(stdout.String()) {
+		t.Errorf("résultats différents avec -on-overflow uint64:\n%s", wide.String())
+	}
+	beyond := filepath.Join(t.TempDir(), "beyond.txt")
+	os.WriteFile(beyond, []byte(list.String()+"1920767777\n"), 0o644) // Au-delà de primes.MaxLimitUint64.
+	if got := exitCode(run([]string{"-primes-file", beyond, "-primes-file-check", "0", "-on-overflow", "uint64"}, io.Discard, io.Discard)); got != exitOverflow {
+		t.Errorf("uint64 au-delà de sa limite -> code %d, attendu %d", got, exitOverflow)
+	}
+
+	// Revérification d'un résultat promu (sans -verify, dont la division par essais des n de
+	// l'ordre de 10^18 serait trop lente ici).
+	p, q := int64(7), int64(1_600_000_021)
+	res := primes.Result{P: int(p), Q: int(q), Big: primes.FormP2Plus4Q2.(primes.BigForm).EvalBig(p, q)}
+	if err := verifyResult(res, primes.FormP2Plus4Q2, nil, "miller"); err != nil {
+		t.Errorf("résultat promu rejeté: %v", err)
+	}
+	res.Big.Add(res.Big, big.NewInt(2))
+	if err := verifyResult(res, primes.FormP2Plus4Q2, nil, "miller"); !errors.Is(err, errVerification) {
+		t.Errorf("valeur promue erronée: %v, attendu errVerification", err)
+	}
+
+	for _, args := range [][]string{
+		{"-on-overflow", "promote-big", "-where", "n > 10"},
+		{"-on-overflow", "uint64", "-top", "3"},
+		{"-on-overflow", "sometimes"},
+		{"-witness-source", "dice"},
+	} {
+		if got := exitCode(run(append(base, args...), io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,501 @@
+/*
+ * Fichier: parquet.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Format Parquet des résultats (-format parquet, sous-commande convert), pour
+ * les outils d'analyse en colonnes (DuckDB, pandas, Spark). Un fichier a une
+ * colonne obligatoire par champ du format JSON (p, q, n, n_big, twin,
+ * found_at_ns, test_ns; valeur vide ou nulle si le champ est absent), en
+ * groupes de parquetRowGroupRows lignes, chaque colonne en une page PLAIN
+ * compressée par Zstandard. Les métadonnées sont codées à la main dans le
+ * protocole compact de Thrift: aucune dépendance Parquet n'est nécessaire.
+ * La lecture accepte les fichiers de cette forme (pages PLAIN, colonnes
+ * obligatoires, sans compression ou en Zstandard), pas les encodages par
+ * dictionnaire ni les colonnes facultatives qu'écrivent d'autres outils.
+ * Le manifeste de l'exécution est porté en JSON par les métadonnées
+ * clé-valeur du pied de fichier (clé primenumber.manifest).
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// parquetMagic encadre un fichier Parquet.
+const parquetMagic = "PAR1"
+
+// parquetRowGroupRows est le nombre de lignes d'un groupe: les résultats d'un groupe sont gardés
+// en mémoire jusqu'à son écriture.
+const parquetRowGroupRows = 1 << 17
+
+// parquetMaxPageBytes borne la taille décompressée d'une page lue, contre un fichier forgé dont une
+// petite page zstd se décompresserait en un bloc démesuré.
+const parquetMaxPageBytes = 256 << 20
+
+// Types physiques, répétition, encodages et compressions du format Parquet utilisés ici.
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetByteArray = 6
+
+	parquetRequired = 0
+	parquetUTF8     = 0 // Type converti des chaînes.
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetUncompressed = 0
+	parquetZstd         = 6
+
+	parquetDataPage = 0
+)
+
+// parquetColumn est une colonne du fichier de résultats.
+type parquetColumn struct {
+	name string
+	typ  int64
+}
+
+// parquetColumns sont les colonnes écrites, dans l'ordre.
+var parquetColumns = []parquetColumn{
+	{"p", parquetInt64},
+	{"q", parquetInt64},
+	{"n", parquetInt64},
+	{"n_big", parquetByteArray},
+	{"twin", parquetBoolean},
+	{"found_at_ns", parquetInt64},
+	{"test_ns", parquetInt64},
+}
+
+// parquetPlainValues code les valeurs de la colonne col des résultats rows en PLAIN.
+func parquetPlainValues(col string, rows []jsonResult) []byte {
+	var buf []byte
+	switch col {
+	case "twin":
+		buf = make([]byte, (len(rows)+7)/8)
+		for i, r := range rows {
+			if r.Twin {
+				buf[i/8] |= 1 << (i % 8)
+			}
+		}
+	case "n_big":
+		for _, r := range rows {
+			var s string
+			if r.NBig != nil {
+				s = r.NBig.String()
+			}
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(s)))
+			buf = append(buf, s...)
+		}
+	default:
+		for _, r := range rows {
+			var v int64
+			switch col {
+			case "p":
+				v = int64(r.P)
+			case "q":
+				v = int64(r.Q)
+			case "n":
+				v = r.N
+			case "found_at_ns":
+				if r.FoundAt != nil {
+					v = r.FoundAt.UnixNano()
+				}
+			case "test_ns":
+				v = r.TestNs
+			}
+			buf = binary.LittleEndian.AppendUint64(buf, uint64(v))
+		}
+	}
+	return buf
+}
+
+// parquetChunk situe une colonne d'un groupe dans le fichier.
+type parquetChunk struct {
+	offset, compressed, uncompressed int64
+}
+
+// parquetRowGroup décrit un groupe de lignes écrit.
+type parquetRowGroup struct {
+	rows   int64
+	chunks []parquetChunk
+}
+
+// parquetWriter écrit un fichier Parquet au fil de l'eau: chaque groupe complet est écrit, le
+// pied de fichier (schéma et position des colonnes) à la fermeture. Les erreurs d'écriture sont
+// retenues par w (*errWriter).
+type parquetWriter struct {
+	w      io.Writer
+	enc    *zstd.Encoder
+	offset int64
+	rows   []jsonResult
+	groups []parquetRowGroup
+}
+
+// newParquetWriter commence un fichier Parquet sur w.
+func newParquetWriter(w io.Writer) *parquetWriter {
+	enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1)) // Sans option invalide, NewWriter n'échoue pas.
+	pw := &parquetWriter{w: w, enc: enc}
+	pw.write([]byte(parquetMagic))
+	return pw
+}
+
+func (pw *parquetWriter) write(data []byte) {
+	pw.w.Write(data)
+	pw.offset += int64(len(data))
+}
+
+// add ajoute un résultat, et écrit le groupe de lignes s'il est complet.
+func (pw *parquetWriter) add(r jsonResult) {
+	pw.rows = append(pw.rows, r)
+	if len(pw.rows) == parquetRowGroupRows {
+		pw.flushGroup()
+	}
+}
+
+// flushGroup écrit les résultats en attente comme un groupe de lignes: une page par colonne.
+func (pw *parquetWriter) flushGroup() {
+	if len(pw.rows) == 0 {
+		return
+	}
+	group := parquetRowGroup{rows: int64(len(pw.rows))}
+	for _, col := range parquetColumns {
+		plain := parquetPlainValues(col.name, pw.rows)
+		data := pw.enc.EncodeAll(plain, nil)
+		var h thriftWriter
+		h.i32(1, parquetDataPage)
+		h.i32(2, int64(len(plain)))
+		h.i32(3, int64(len(data)))
+		h.beginStruct(5)
+		h.i32(1, int64(len(pw.rows)))
+		h.i32(2, parquetPlain)
+		h.i32(3, parquetRLE)
+		h.i32(4, parquetRLE)
+		h.endStruct()
+		header := h.finish()
+		chunk := parquetChunk{offset: pw.offset, compressed: int64(len(header) + len(data)), uncompressed: int64(len(header) + len(plain))}
+		pw.write(header)
+		pw.write(data)
+		group.chunks = append(group.chunks, chunk)
+	}
+	pw.groups = append(pw.groups, group)
+	pw.rows = pw.rows[:0]
+}
+
+// parquetManifestKey est la clé des métadonnées clé-valeur du fichier qui porte le manifeste.
+const parquetManifestKey = "primenumber.manifest"
+
+// close écrit le dernier groupe et le pied de fichier; manifest, s'il n'est pas vide, y est porté
+// en JSON dans les métadonnées clé-valeur (key_value_metadata, sous parquetManifestKey).
+func (pw *parquetWriter) close(manifest []byte) {
+	pw.flushGroup()
+	var m thriftWriter
+	m.i32(1, 1) // Version du format.
+	m.listHeader(2, len(parquetColumns)+1, thriftStruct)
+	m.beginElem()
+	m.binary(4, "schema")
+	m.i32(5, int64(len(parquetColumns)))
+	m.endStruct()
+	for _, col := range parquetColumns {
+		m.beginElem()
+		m.i32(1, col.typ)
+		m.i32(3, parquetRequired)
+		m.binary(4, col.name)
+		if col.typ == parquetByteArray {
+			m.i32(6, parquetUTF8)
+		}
+		m.endStruct()
+	}
+	var total int64
+	for _, g := range pw.groups {
+		total += g.rows
+	}
+	m.i64(3, total)
+	m.listHeader(4, len(pw.groups), thriftStruct)
+	for _, g := range pw.groups {
+		m.beginElem()
+		m.listHeader(1, len(g.chunks), thriftStruct)
+		var size int64
+		for i, c := range g.chunks {
+			col := parquetColumns[i]
+			size += c.uncompressed
+			m.beginElem()
+			m.i64(2, c.offset)
+			m.beginStruct(3)
+			m.i32(1, col.typ)
+			m.listHeader(2, 2, thriftI32)
+			m.listI32(parquetPlain)
+			m.listI32(parquetRLE)
+			m.listHeader(3, 1, thriftBinary)
+			m.listBinary(col.name)
+			m.i32(4, parquetZstd)
+			m.i64(5, g.rows)
+			m.i64(6, c.uncompressed)
+			m.i64(7, c.compressed)
+			m.i64(9, c.offset)
+			m.endStruct()
+			m.endStruct()
+		}
+		m.i64(2, size)
+		m.i64(3, g.rows)
+		m.endStruct()
+	}
+	if len(manifest) > 0 {
+		m.listHeader(5, 1, thriftStruct)
+		m.beginElem()
+		m.binary(1, parquetManifestKey)
+		m.binary(2, string(manifest))
+		m.endStruct()
+	}
+	m.binary(6, "PrimeNumber")
+	meta := m.finish()
+	pw.write(meta)
+	pw.write(binary.LittleEndian.AppendUint32(nil, uint32(len(meta))))
+	pw.write([]byte(parquetMagic))
+	pw.enc.Close()
+}
+
+// errParquetUnsupported signale un fichier Parquet valide mais hors de la forme lue ici.
+var errParquetUnsupported = fmt.Errorf("%w: fichier Parquet non pris en charge", errInvalidInput)
+
+// readParquet lit un fichier Parquet de résultats (lu en entier: le pied de fichier est à la fin)
+// et appelle fn pour chaque ligne, dans l'ordre; une erreur de fn arrête la lecture et est
+// retournée telle quelle. Les colonnes p, q et n sont obligatoires, les colonnes inconnues ignorées.
+// Le manifeste des métadonnées, s'il y en a un, est transmis à onManifest (si non nil) avant la
+// première ligne.
+func readParquet(r io.Reader, name string, onManifest func(*runManifest), fn func(jsonResult) error) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s: parquet: %s", errInvalidInput, name, fmt.Sprintf(format, args...))
+	}
+	if len(data) < 12 || string(data[:4]) != parquetMagic || string(data[len(data)-4:]) != parquetMagic {
+		return invalid("signature PAR1 absente")
+	}
+	metaLen := int64(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if metaLen > int64(len(data))-12 {
+		return invalid("pied de fichier de %d octets", metaLen)
+	}
+	meta, _, err := readThriftStruct(data[int64(len(data))-8-metaLen : len(data)-8])
+	if err != nil {
+		return invalid("métadonnées: %v", err)
+	}
+	for _, el := range meta.list(5) {
+		kv, _ := el.(thriftFields)
+		if string(kv.bytes(1)) != parquetManifestKey || onManifest == nil {
+			continue
+		}
+		var m runManifest
+		if err := json.Unmarshal(kv.bytes(2), &m); err != nil {
+			return invalid("manifeste: %v", err)
+		}
+		onManifest(&m)
+	}
+	required := map[string]bool{}
+	for _, el := range meta.list(2) {
+		if s, ok := el.(thriftFields); ok && s.int(3) == parquetRequired {
+			required[string(s.bytes(4))] = true
+		}
+	}
+	dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(parquetMaxPageBytes))
+	defer dec.Close()
+	for g, el := range meta.list(4) {
+		group, _ := el.(thriftFields)
+		// Le nombre de lignes annoncé n'est pas pris pour argent comptant: chaque colonne doit le
+		// retrouver dans ses pages, et les lignes ne sont allouées qu'une fois la première colonne
+		// décodée, à la mesure des valeurs réellement présentes.
+		count := group.int(3)
+		if count < 0 {
+			return invalid("groupe %d: %d lignes annoncées", g, count)
+		}
+		var rows []jsonResult
+		seen := map[string]bool{}
+		for _, ce := range group.list(1) {
+			chunk, _ := ce.(thriftFields)
+			cm := chunk.structure(3)
+			path := cm.list(3)
+			if len(path) != 1 {
+				continue
+			}
+			pathName, _ := path[0].([]byte)
+			col := string(pathName)
+			if !required[col] {
+				return fmt.Errorf("%w: %s: colonne %q facultative ou imbriquée", errParquetUnsupported, name, col)
+			}
+			values, err := readParquetColumn(data, cm, dec, int(count))
+			if err != nil {
+				return fmt.Errorf("%s: groupe %d, colonne %q: %w", name, g, col, err)
+			}
+			if rows == nil {
+				rows = make([]jsonResult, count)
+			}
+			if err := values.assign(col, rows); err != nil {
+				return invalid("groupe %d, colonne %q: %v", g, col, err)
+			}
+			seen[col] = true
+		}
+		for _, col := range []string{"p", "q", "n"} {
+			if !seen[col] && count > 0 {
+				return invalid("colonne %q absente", col)
+			}
+		}
+		for _, r := range rows {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parquetValues sont les valeurs décodées d'une colonne.
+type parquetValues struct {
+	ints  []int64
+	bools []bool
+	bytes [][]byte
+}
+
+// assign reporte les valeurs de la colonne col dans les résultats rows.
+func (v parquetValues) assign(col string, rows []jsonResult) error {
+	switch col {
+	case "p", "q", "n", "found_at_ns", "test_ns":
+		if v.ints == nil {
+			return fmt.Errorf("type physique inattendu")
+		}
+		for i, x := range v.ints {
+			switch col {
+			case "p":
+				rows[i].P = int(x)
+			case "q":
+				rows[i].Q = int(x)
+			case "n":
+				rows[i].N = x
+			case "found_at_ns":
+				if x != 0 {
+					t := time.Unix(0, x)
+					rows[i].FoundAt = &t
+				}
+			case "test_ns":
+				rows[i].TestNs = x
+			}
+		}
+	case "n_big":
+		for i, b := range v.bytes {
+			if len(b) == 0 {
+				continue
+			}
+			var ok bool
+			if rows[i].NBig, ok = new(big.Int).SetString(string(b), 10); !ok {
+				return fmt.Errorf("%q n'est pas un entier", b)
+			}
+		}
+	case "twin":
+		for i, b := range v.bools {
+			rows[i].Twin = b
+		}
+	}
+	return nil
+}
+
+// readParquetColumn décode les count valeurs d'une colonne, de métadonnées cm, page par page. Les
+// pages doivent tenir dans les octets de la colonne (total_compressed_size) et contenir les count
+// valeurs: un nombre de lignes annoncé plus grand que ce qu'elles portent est une erreur.
+func readParquetColumn(data []byte, cm thriftFields, dec *zstd.Decoder, count int) (parquetValues, error) {
+	var v parquetValues
+	typ, codec := cm.int(1), cm.int(4)
+	if codec != parquetUncompressed && codec != parquetZstd {
+		return v, fmt.Errorf("%w: compression %d", errParquetUnsupported, codec)
+	}
+	offset := cm.int(9)
+	end := offset + cm.int(7)
+	if offset < 0 || end < offset || end > int64(len(data)) {
+		return v, fmt.Errorf("%w: colonne hors du fichier", errInvalidInput)
+	}
+	for read := 0; read < count; {
+		if offset >= end {
+			return v, fmt.Errorf("%w: %d valeurs annoncées, %d dans les pages", errInvalidInput, count, read)
+		}
+		header, n, err := readThriftStruct(data[offset:end])
+		if err != nil {
+			return v, fmt.Errorf("%w: en-tête de page: %v", errInvalidInput, err)
+		}
+		offset += int64(n)
+		size := header.int(3)
+		if header.int(1) != parquetDataPage || size < 0 || offset+size > end {
+			return v, fmt.Errorf("%w: page de type %d", errParquetUnsupported, header.int(1))
+		}
+		page := data[offset : offset+size]
+		offset += size
+		dph := header.structure(5)
+		if dph.int(2) != parquetPlain {
+			return v, fmt.Errorf("%w: encodage %d", errParquetUnsupported, dph.int(2))
+		}
+		if codec == parquetZstd {
+			if page, err = dec.DecodeAll(page, nil); err != nil {
+				return v, fmt.Errorf("%w: %v", errInvalidInput, err)
+			}
+		}
+		values := int(dph.int(1))
+		if values <= 0 || read+values > count {
+			return v, fmt.Errorf("%w: %d valeurs dans la page", errInvalidInput, values)
+		}
+		if err := v.decodePlain(typ, page, values); err != nil {
+			return v, fmt.Errorf("%w: %v", errInvalidInput, err)
+		}
+		read += values
+	}
+	return v, nil
+}
+
+// decodePlain ajoute les count valeurs PLAIN de type typ de page.
+func (v *parquetValues) decodePlain(typ int64, page []byte, count int) error {
+	switch typ {
+	case parquetInt64, parquetInt32:
+		size := 8
+		if typ == parquetInt32 {
+			size = 4
+		}
+		if len(page) < count*size {
+			return fmt.Errorf("page tronquée")
+		}
+		for i := range count {
+			if size == 8 {
+				v.ints = append(v.ints, int64(binary.LittleEndian.Uint64(page[i*8:])))
+			} else {
+				v.ints = append(v.ints, int64(int32(binary.LittleEndian.Uint32(page[i*4:]))))
+			}
+		}
+	case parquetBoolean:
+		if len(page) < (count+7)/8 {
+			return fmt.Errorf("page tronquée")
+		}
+		for i := range count {
+			v.bools = append(v.bools, page[i/8]&(1<<(i%8)) != 0)
+		}
+	case parquetByteArray:
+		for range count {
+			if len(page) < 4 || int64(binary.LittleEndian.Uint32(page)) > int64(len(page)-4) {
+				return fmt.Errorf("page tronquée")
+			}
+			n := int(binary.LittleEndian.Uint32(page))
+			v.bytes = append(v.bytes, bytes.Clone(page[4:4+n]))
+			page = page[4+n:]
+		}
+	default:
+		return fmt.Errorf("%w: type physique %d", errParquetUnsupported, typ)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Fichier: parquet_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du format Parquet des résultats et du protocole compact de Thrift.
+ */
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParquetRoundTrip relit un fichier Parquet de plusieurs groupes de lignes, avec les champs
+// facultatifs (n_big, twin, timing).
+func TestParquetRoundTrip(t *testing.T) {
+	foundAt := time.Date(2026, 10, 16, 12, 0, 0, 987654321, time.Local)
+	huge, _ := new(big.Int).SetString("98765432109876543210", 10)
+	want := []jsonResult{
+		{P: 3, Q: 5, N: 109, Twin: true},
+		{P: 5, Q: 2, N: 41, FoundAt: &foundAt, TestNs: 1200},
+		{P: 7, Q: 1 << 40, N: math.MaxInt64, NBig: huge},
+	}
+	for i := len(want); i < parquetRowGroupRows+10; i++ {
+		want = append(want, jsonResult{P: i, Q: 2 * i, N: int64(i) * 7, Twin: i%3 == 0})
+	}
+	var buf bytes.Buffer
+	rw := &resultWriter{w: &buf, format: "parquet"}
+	rw.begin()
+	for _, jr := range want {
+		rw.result(jr.result())
+	}
+	rw.end()
+	if !bytes.HasPrefix(buf.Bytes(), []byte(parquetMagic)) || !bytes.HasSuffix(buf.Bytes(), []byte(parquetMagic)) {
+		t.Fatal("signature PAR1 absente")
+	}
+	var got []jsonResult
+	if err := readParquet(bytes.NewReader(buf.Bytes()), "test", nil, func(jr jsonResult) error {
+		got = append(got, jr)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%d lignes relues, attendu %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Fatalf("ligne %d: %+v, attendu %+v", i, got[i], want[i])
+		}
+	}
+
+	// Fichier vide: aucun groupe de lignes, mais un pied de fichier valide.
+	buf.Reset()
+	rw = &resultWriter{w: &buf, format: "parquet"}
+	rw.begin()
+	rw.end()
+	if err := readParquet(bytes.NewReader(buf.Bytes()), "vide", nil, func(jsonResult) error { return errors.New("ligne inattendue") }); err != nil {
+		t.Errorf("fichier vide: %v", err)
+	}
+
+	for name, data := range map[string][]byte{
+		"tronqué":       buf.Bytes()[:buf.Len()-3],
+		"sans pied":     []byte("PAR1\x00\x00\x00\x00PAR1"),
+		"pas parquet":   []byte("p,q,n\n3,5,109\n"),
+		"pied démesuré": append([]byte("PAR1xxxx"), 0xff, 0xff, 0xff, 0x7f, 'P', 'A', 'R', '1'),
+	} {
+		if err := readParquet(bytes.NewReader(data), name, nil, func(jsonResult) error { return nil }); !errors.Is(err, errInvalidInput) {
+			t.Errorf("%s: %v, attendu errInvalidInput", name, err)
+		}
+	}
+}
+
+// TestParquetCorruptRowCount vérifie qu'un nombre de lignes forgé (négatif, ou plus grand que ce
+// que portent les pages) est refusé sans allouer les lignes annoncées.
+func TestParquetCorruptRowCount(t *testing.T) {
+	for _, rows := range []int64{-1, 4, 1 << 40, math.MaxInt64} {
+		var buf bytes.Buffer
+		pw := newParquetWriter(&buf)
+		for i := range 3 {
+			pw.add(jsonResult{P: 3, Q: 5 + i, N: 109})
+		}
+		pw.flushGroup()
+		pw.groups[0].rows = rows
+		pw.close(nil)
+		err := readParquet(bytes.NewReader(buf.Bytes()), "forgé", nil, func(jsonResult) error { return nil })
+		if !errors.Is(err, errInvalidInput) {
+			t.Errorf("%d lignes annoncées: %v, attendu errInvalidInput", rows, err)
+		}
+	}
+}
+
+// TestThriftCompact vérifie la relecture de champs codés par écart ou par identifiant complet,
+// de listes longues et de structures imbriquées.
+func TestThriftCompact(t *testing.T) {
+	var w thriftWriter
+	w.i32(1, -7)
+	w.binary(4, "nom")
+	w.i64(40, math.MaxInt64) // Écart de plus de 15: identifiant complet.
+	w.listHeader(41, 20, thriftI32)
+	for i := range 20 {
+		w.listI32(int64(i))
+	}
+	w.beginStruct(42)
+	w.i32(2, 5)
+	w.endStruct()
+	data := w.finish()
+	f, n, err := readThriftStruct(append(data, 0xAA))
+	if err != nil || n != len(data) {
+		t.Fatalf("readThriftStruct: %d octets, %v, attendu %d", n, err, len(data))
+	}
+	if f.int(1) != -7 || string(f.bytes(4)) != "nom" || f.int(40) != math.MaxInt64 || len(f.list(41)) != 20 || f.list(41)[19] != int64(19) || f.structure(42).int(2) != 5 {
+		t.Errorf("champs relus %v", f)
+	}
+	if _, _, err := readThriftStruct(data[:len(data)-2]); !errors.Is(err, errThriftCorrupt) {
+		t.Errorf("structure tronquée: %v, attendu errThriftCorrupt", err)
+	}
+}
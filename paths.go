@@ -0,0 +1,49 @@
+/*
+ * Fichier: paths.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Emplacements par défaut, propres à chaque système, choisis par la valeur
+ * "auto" de -primes-cache, -status-socket et status -socket: le cache des
+ * nombres premiers dans le répertoire de cache de l'utilisateur, le socket
+ * d'état dans un répertoire d'exécution court (voir paths_unix.go et
+ * paths_other.go), pour rester sous la longueur maximale des chemins de
+ * socket.
+ */
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// autoPath est la valeur des options de chemin qui choisit l'emplacement par défaut du système.
+const autoPath = "auto"
+
+// appDirName est le nom du sous-répertoire du programme dans les répertoires de l'utilisateur.
+const appDirName = "PrimeNumber"
+
+// statusSocketName est le nom du socket d'état par défaut.
+const statusSocketName = "primenumber.sock"
+
+// defaultCacheDir retourne le répertoire par défaut du cache des nombres premiers: PrimeNumber
+// dans le répertoire de cache de l'utilisateur ($XDG_CACHE_HOME ou ~/.cache sous Linux,
+// ~/Library/Caches sous macOS, %LocalAppData% sous Windows), ou à défaut dans le répertoire
+// temporaire.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, appDirName)
+}
+
+// resolvePath remplace autoPath par l'emplacement par défaut def(); les autres valeurs sont
+// rendues telles quelles.
+func resolvePath(value string, def func() string) string {
+	if value == autoPath {
+		return def()
+	}
+	return value
+}
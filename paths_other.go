@@ -0,0 +1,23 @@
+//go:build !unix
+
+/*
+ * Fichier: paths_other.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Socket d'état par défaut hors Unix: dans le répertoire temporaire, propre
+ * à l'utilisateur sous Windows (%TEMP%, par exemple
+ * C:\Users\NOM\AppData\Local\Temp).
+ */
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// defaultStatusSocket retourne le chemin par défaut du socket d'état.
+func defaultStatusSocket() string {
+	return filepath.Join(os.TempDir(), statusSocketName)
+}
@@ -0,0 +1,55 @@
+/*
+ * Fichier: paths_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des emplacements par défaut ("auto") du cache et du socket d'état.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDefaultPaths vérifie la résolution de "auto" et des emplacements utilisables: le socket
+// d'état sous la longueur maximale de la plateforme, le cache sous le nom du programme.
+func TestDefaultPaths(t *testing.T) {
+	if got := resolvePath("run.sock", defaultStatusSocket); got != "run.sock" {
+		t.Errorf("chemin explicite remplacé: %s", got)
+	}
+	if got := resolvePath(autoPath, defaultStatusSocket); got != defaultStatusSocket() {
+		t.Errorf("auto = %s, attendu %s", got, defaultStatusSocket())
+	}
+	if sock := defaultStatusSocket(); !filepath.IsAbs(sock) || checkSocketPath(sock) != nil {
+		t.Errorf("socket par défaut inutilisable: %s", sock)
+	}
+	if dir := defaultCacheDir(); !filepath.IsAbs(dir) || filepath.Base(dir) != appDirName {
+		t.Errorf("cache par défaut = %s", dir)
+	}
+}
+
+// TestRunPrimesCacheAuto vérifie que -primes-cache=auto écrit le cache dans le répertoire de
+// cache de l'utilisateur.
+func TestRunPrimesCacheAuto(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", home) // Linux et BSD.
+	t.Setenv("HOME", home)           // macOS: ~/Library/Caches.
+	t.Setenv("LocalAppData", home)   // Windows.
+	var stderr bytes.Buffer
+	if err := run([]string{"-limit", "30", "-workers", "1", "-primes-cache", autoPath, "-lang", "fr"}, io.Discard, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	path := primeCachePath(defaultCacheDir(), 30)
+	if !strings.HasPrefix(path, home) {
+		t.Fatalf("cache hors du répertoire de l'utilisateur: %s", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("cache non écrit: %v\n%s", err, stderr.String())
+	}
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+/*
+ * Fichier: paths_unix.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Socket d'état par défaut sous Unix: dans $XDG_RUNTIME_DIR, répertoire
+ * privé de la session (Linux avec systemd), sinon dans /tmp sous un nom
+ * propre à l'utilisateur. /tmp est préféré à os.TempDir: sous macOS,
+ * $TMPDIR est un chemin de près de 50 octets, proche de la limite de 103.
+ */
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultStatusSocket retourne le chemin par défaut du socket d'état.
+func defaultStatusSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, statusSocketName)
+	}
+	return filepath.Join("/tmp", fmt.Sprintf("primenumber-%d.sock", os.Getuid()))
+}
@@ -0,0 +1,46 @@
+//go:build unix
+
+/*
+ * Fichier: paths_unix_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du socket d'état par défaut sous Unix: $XDG_RUNTIME_DIR, repli dans
+ * /tmp, et interrogation par status -socket auto.
+ */
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestDefaultStatusSocketUnix vérifie le choix de $XDG_RUNTIME_DIR, le repli dans /tmp sous un
+// nom propre à l'utilisateur, et qu'une exécution et status se retrouvent avec "auto".
+func TestDefaultStatusSocketUnix(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if got, want := defaultStatusSocket(), fmt.Sprintf("/tmp/primenumber-%d.sock", os.Getuid()); got != want {
+		t.Errorf("sans XDG_RUNTIME_DIR: %s, attendu %s", got, want)
+	}
+
+	dir := filepath.Dir(shortSocketPath(t))
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	if got, want := defaultStatusSocket(), filepath.Join(dir, statusSocketName); got != want {
+		t.Errorf("avec XDG_RUNTIME_DIR: %s, attendu %s", got, want)
+	}
+	stats := &searchStats{totalPairs: 10}
+	stats.pairsTested.Add(4)
+	ln, err := startStatusSocket(resolvePath(autoPath, defaultStatusSocket), newDashboard(stats, runParams{Limit: 30, Workers: 1}, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	snap, err := queryStatus(resolvePath(autoPath, defaultStatusSocket))
+	if err != nil || snap.PairsTested != 4 {
+		t.Errorf("status -socket auto: %+v, %v", snap, err)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !unix
+
+/*
+ * Fichier: pausesignals_other.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Hors Unix, SIGUSR1 et SIGUSR2 n'existent pas: la suspension reste
+ * accessible par l'interface terminal (-tui).
+ */
+package main
+
+import "github.com/agbru/PrimeNumber/primes"
+
+// notifyPauseResume est sans effet hors Unix.
+func notifyPauseResume(ctl *primes.Control, logf func(string)) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,50 @@
+//go:build unix
+
+/*
+ * Fichier: pausesignals_unix.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Suspension et reprise d'une recherche par signaux sous Unix:
+ * SIGUSR1 suspend la distribution des tâches, SIGUSR2 la reprend.
+ */
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// notifyPauseResume relie SIGUSR1 et SIGUSR2 à ctl. La fonction retournée cesse l'écoute
+// et attend la fin de la goroutine d'écoute.
+func notifyPauseResume(ctl *primes.Control, logf func(string)) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1, syscall.SIGUSR2)
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case sig := <-sigCh:
+				if sig == syscall.SIGUSR1 {
+					ctl.Pause()
+					logf(tr(msgSignalPaused))
+				} else {
+					ctl.Resume()
+					logf(tr(msgSignalResumed))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,47 @@
+//go:build unix
+
+/*
+ * Fichier: pausesignals_unix_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la suspension et de la reprise par SIGUSR1/SIGUSR2.
+ */
+package main
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// waitFor attend que cond soit vraie, au plus une seconde.
+func waitFor(cond func() bool) bool {
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
+
+// TestPauseResumeSignals valide que SIGUSR1 suspend et SIGUSR2 reprend la recherche.
+func TestPauseResumeSignals(t *testing.T) {
+	ctl := primes.NewControl()
+	stop := notifyPauseResume(ctl, func(string) {})
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	if !waitFor(ctl.Paused) {
+		t.Fatalf("SIGUSR1 n'a pas suspendu la recherche")
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	if !waitFor(func() bool { return !ctl.Paused() }) {
+		t.Fatalf("SIGUSR2 n'a pas repris la recherche")
+	}
+}
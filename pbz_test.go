@@ -0,0 +1,167 @@
+/*
+ * Fichier: pbz_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du format binaire compressé des résultats (-format pbz).
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"github.com/klauspost/compress/zstd"
+)
+
+// TestPBZRoundTrip vérifie qu'un flux pbz relit exactement les résultats écrits, y compris les
+// champs facultatifs (n_big, twin, timing) et des écarts négatifs.
+func TestPBZRoundTrip(t *testing.T) {
+	foundAt := time.Date(2026, 10, 16, 12, 0, 0, 123456789, time.Local)
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	want := []jsonResult{
+		{P: 3, Q: 5, N: 109},
+		{P: 3, Q: 19, N: 1453, Twin: true},
+		{P: 5, Q: 2, N: 41, FoundAt: &foundAt, TestNs: 1500},
+		{P: 7, Q: 1 << 40, N: math.MaxInt64, NBig: huge},
+		{P: 2, Q: 3, N: 0},
+	}
+	var buf bytes.Buffer
+	rw := &resultWriter{w: &buf, format: "pbz"}
+	rw.begin()
+	for _, jr := range want {
+		if err := rw.Write(jr.result()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var got []jsonResult
+	if err := readPBZ(bytes.NewReader(buf.Bytes()), nil, func(jr jsonResult) error {
+		got = append(got, jr)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("relu %+v\nattendu %+v", got, want)
+	}
+
+	stop := errors.New("arrêt")
+	if err := readPBZ(bytes.NewReader(buf.Bytes()), nil, func(jsonResult) error { return stop }); err != stop {
+		t.Errorf("erreur de fn: %v, attendu %v", err, stop)
+	}
+	if err := readPBZ(bytes.NewReader(buf.Bytes()[:buf.Len()/2]), nil, func(jsonResult) error { return nil }); !errors.Is(err, errInvalidInput) {
+		t.Errorf("flux tronqué: %v, attendu errInvalidInput", err)
+	}
+}
+
+// TestPBZUnknownFields vérifie que les champs d'une version ultérieure du schéma sont ignorés, et
+// qu'un message mal formé est rejeté.
+func TestPBZUnknownFields(t *testing.T) {
+	var s pbzState
+	msg := appendPBZVarint(nil, pbzFieldP, zigzag(11))
+	msg = binary.AppendUvarint(msg, 9<<3|pbzWireBytes)
+	msg = append(msg, 2, 'a', 'b')
+	msg = binary.AppendUvarint(msg, 10<<3|pbzWireFixed64)
+	msg = append(msg, make([]byte, 8)...)
+	msg = appendPBZVarint(msg, pbzFieldQ, zigzag(2))
+	if r, _, err := s.decodePBZResult(msg); err != nil || r.P != 11 || r.Q != 2 {
+		t.Errorf("champs inconnus: %+v, %v", r, err)
+	}
+	if _, _, err := s.decodePBZResult([]byte{pbzFieldNBig<<3 | pbzWireBytes, 5, 1}); !errors.Is(err, errPBZCorrupt) {
+		t.Errorf("longueur hors du message: %v, attendu errPBZCorrupt", err)
+	}
+	for _, v := range []int64{0, 1, -1, 63, -64, math.MaxInt64, math.MinInt64} {
+		if got := unzigzag(zigzag(v)); got != v {
+			t.Errorf("unzigzag(zigzag(%d)) = %d", v, got)
+		}
+	}
+}
+
+// TestPBZManifest vérifie que le manifeste encadre le flux (premier et dernier messages, heure
+// de fin dans le second) sans s'ajouter aux résultats relus.
+func TestPBZManifest(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("limit", 10, "")
+	var buf bytes.Buffer
+	rw := &resultWriter{w: &buf, format: "pbz", manifest: newManifest("test", []string{"-limit=10"}, fs, nil, time.Now())}
+	rw.begin()
+	rw.result(primes.Result{P: 5, Q: 2, N: 41})
+	rw.end()
+
+	zr, err := zstd.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s pbzState
+	var manifests []runManifest
+	var results int
+	for len(data) > 0 {
+		size, k := binary.Uvarint(data)
+		r, manifest, err := s.decodePBZResult(data[k : k+int(size)])
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = data[k+int(size):]
+		if manifest == nil {
+			if r.N != 41 {
+				t.Errorf("résultat %+v, attendu n = 41", r)
+			}
+			results++
+			continue
+		}
+		var m runManifest
+		if err := json.Unmarshal(manifest, &m); err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, m)
+	}
+	if results != 1 || len(manifests) != 2 || manifests[0].Params["limit"] != "10" || !manifests[0].End.IsZero() || manifests[1].End.IsZero() {
+		t.Errorf("%d résultats, manifestes %+v: attendu un résultat encadré de deux manifestes, heure de fin dans le second", results, manifests)
+	}
+
+	var got []jsonResult
+	if err := readPBZ(bytes.NewReader(buf.Bytes()), nil, func(jr jsonResult) error {
+		got = append(got, jr)
+		return nil
+	}); err != nil || len(got) != 1 {
+		t.Errorf("relu %+v, %v: attendu le seul résultat", got, err)
+	}
+}
+
+// TestPBZSize vérifie le gain de place annoncé: un ordre de grandeur sur le NDJSON d'une recherche.
+func TestPBZSize(t *testing.T) {
+	var ndjson, pbz bytes.Buffer
+	nw := &resultWriter{w: &ndjson, format: "ndjson"}
+	pw := &resultWriter{w: &pbz, format: "pbz"}
+	pw.begin()
+	if err := primes.Search(t.Context(), primes.Options{Limit: 3000}, func(res primes.Result) error {
+		nw.result(res)
+		pw.result(res)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	pw.end()
+	if ratio := float64(ndjson.Len()) / float64(pbz.Len()); ratio < 8 {
+		t.Errorf("pbz %d octets pour %d en NDJSON: rapport %.1f, attendu environ 10", pbz.Len(), ndjson.Len(), ratio)
+	}
+}
@@ -0,0 +1,445 @@
+/*
+ * Fichier: plugins.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Greffons (option -plugin, répétable): des programmes tiers qui fournissent
+ * des formes et des destinations de résultats sans modifier le programme.
+ * Un greffon est lancé comme sous-processus et dialogue en JSON, un message
+ * par ligne, sur son entrée et sa sortie standard; le protocole ne dépend ni
+ * du système ni du langage du greffon. Après la poignée de main, qui fixe la
+ * version du protocole et déclare les formes et destinations, le programme
+ * envoie des requêtes: évaluation d'une paire (eval, prune) pour une forme
+ * distante, blocs de sortie (write) et fermeture (close) pour une
+ * destination. Une forme déclarée par ses coefficients (quadratique en p et
+ * q) est évaluée sans aller-retour avec le greffon.
+ */
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// Protocole des greffons.
+const (
+	pluginProtocol = "primenumber-plugin" // Identifiant échangé à la poignée de main.
+	pluginVersion  = 1                    // Version du protocole parlée par le programme.
+)
+
+// pluginTimeout borne la poignée de main et l'arrêt d'un greffon.
+const pluginTimeout = 5 * time.Second
+
+// pluginList est la valeur de -plugin, répétable (flag.Value): une ligne de commande par greffon.
+type pluginList []string
+
+func (l *pluginList) String() string { return strings.Join(*l, ",") }
+
+func (l *pluginList) Set(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("commande de greffon vide")
+	}
+	*l = append(*l, value)
+	return nil
+}
+
+// pluginRequest est un message du programme vers un greffon.
+type pluginRequest struct {
+	Op       string `json:"op"` // hello, eval, prune, write, close ou bye.
+	Protocol string `json:"protocol,omitempty"`
+	Versions []int  `json:"versions,omitempty"`
+	Form     string `json:"form,omitempty"`
+	P        int64  `json:"p,omitempty"`
+	Q        int64  `json:"q,omitempty"`
+	Sink     string `json:"sink,omitempty"`
+	Data     string `json:"data,omitempty"`
+}
+
+// pluginReply est une réponse d'un greffon: poignée de main, valeur de n (eval), verdict
+// d'élagage (prune) ou erreur.
+type pluginReply struct {
+	Protocol string           `json:"protocol"`
+	Version  int              `json:"version"`
+	Name     string           `json:"name"`
+	Forms    []pluginFormSpec `json:"forms"`
+	Sinks    []string         `json:"sinks"`
+	N        int64            `json:"n"`
+	Prune    bool             `json:"prune"`
+	Error    string           `json:"error"`
+}
+
+// pluginFormSpec est une forme déclarée par un greffon. Avec Coefficients (a, b, c, d, e, f), la
+// forme est n = a·p² + b·pq + c·q² + d·p + e·q + f, évaluée localement; sans, chaque paire est
+// évaluée par le greffon (eval), et élaguée par lui si Prune.
+type pluginFormSpec struct {
+	Name         string  `json:"name"`
+	Coefficients []int64 `json:"coefficients,omitempty"`
+	MaxLimit     int     `json:"max_limit,omitempty"`
+	Prune        bool    `json:"prune,omitempty"`
+}
+
+// plugin est un greffon lancé. Les requêtes sont sérialisées: une forme distante évalue une paire
+// à la fois, quel que soit le nombre de workers.
+type plugin struct {
+	command string
+	name    string
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	enc     *json.Encoder
+	dec     *json.Decoder
+	forms   []primes.Form
+	sinks   []string
+
+	mu  sync.Mutex
+	err error // Première erreur de communication: les requêtes suivantes échouent aussitôt.
+}
+
+// startPlugin lance le greffon command (programme et arguments séparés par des espaces) et
+// conduit la poignée de main. La sortie d'erreur du greffon est celle du processus: ses
+// diagnostics ne se mêlent pas aux messages d'état.
+func startPlugin(command string) (*plugin, error) {
+	args := strings.Fields(command)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: -plugin %q: %v", errIO, command, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: -plugin %q: %v", errIO, command, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%w: -plugin %q: %v", errIO, command, err)
+	}
+	pl := &plugin{command: command, cmd: cmd, stdin: stdin, enc: json.NewEncoder(stdin), dec: json.NewDecoder(bufio.NewReader(stdout))}
+	type handshake struct {
+		reply pluginReply
+		err   error
+	}
+	done := make(chan handshake, 1)
+	go func() {
+		reply, err := pl.call(pluginRequest{Op: "hello", Protocol: pluginProtocol, Versions: []int{pluginVersion}})
+		done <- handshake{reply, err}
+	}()
+	var hs handshake
+	select {
+	case hs = <-done:
+	case <-time.After(pluginTimeout):
+		cmd.Process.Kill()
+		hs = <-done
+		hs.err = fmt.Errorf("pas de réponse à la poignée de main en %v", pluginTimeout)
+	}
+	if hs.err == nil {
+		hs.err = pl.accept(hs.reply)
+	}
+	if hs.err != nil {
+		pl.close()
+		return nil, fmt.Errorf("%w: -plugin %q: %v", errInvalidInput, command, hs.err)
+	}
+	return pl, nil
+}
+
+// accept vérifie la réponse à la poignée de main et retient les formes et destinations déclarées.
+func (pl *plugin) accept(reply pluginReply) error {
+	if reply.Protocol != pluginProtocol || reply.Version != pluginVersion {
+		return fmt.Errorf("protocole %q version %d (attendu %q version %d)", reply.Protocol, reply.Version, pluginProtocol, pluginVersion)
+	}
+	pl.name = cmp.Or(reply.Name, pl.command)
+	for _, spec := range reply.Forms {
+		f, err := newPluginForm(pl, spec)
+		if err != nil {
+			return err
+		}
+		pl.forms = append(pl.forms, f)
+	}
+	for _, name := range reply.Sinks {
+		if name == "" {
+			return errors.New("destination sans nom")
+		}
+	}
+	pl.sinks = reply.Sinks
+	return nil
+}
+
+// call envoie req et lit la réponse; une réponse d'erreur est retournée comme erreur.
+func (pl *plugin) call(req pluginRequest) (pluginReply, error) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	if pl.err != nil {
+		return pluginReply{}, pl.err
+	}
+	var reply pluginReply
+	if err := pl.enc.Encode(req); err != nil {
+		pl.err = fmt.Errorf("greffon %s: %s: %v", pl.name, req.Op, err)
+		return reply, pl.err
+	}
+	if err := pl.dec.Decode(&reply); err != nil {
+		pl.err = fmt.Errorf("greffon %s: réponse à %s: %v", pl.name, req.Op, err)
+		return reply, pl.err
+	}
+	if reply.Error != "" {
+		return reply, fmt.Errorf("greffon %s: %s: %s", pl.name, req.Op, reply.Error)
+	}
+	return reply, nil
+}
+
+// send envoie req sans attendre de réponse (write).
+func (pl *plugin) send(req pluginRequest) error {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	if pl.err != nil {
+		return pl.err
+	}
+	if err := pl.enc.Encode(req); err != nil {
+		pl.err = fmt.Errorf("greffon %s: %s: %v", pl.name, req.Op, err)
+	}
+	return pl.err
+}
+
+// fail retient err comme première erreur du greffon, pour les formes dont l'évaluation ne peut pas
+// retourner d'erreur.
+func (pl *plugin) fail(err error) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	if pl.err == nil {
+		pl.err = err
+	}
+}
+
+// failure retourne la première erreur de communication avec le greffon.
+func (pl *plugin) failure() error {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	return pl.err
+}
+
+// close demande l'arrêt du greffon (bye), ferme son entrée et attend sa fin, au plus pluginTimeout.
+func (pl *plugin) close() error {
+	pl.send(pluginRequest{Op: "bye"})
+	pl.stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- pl.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(pluginTimeout):
+		pl.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+// pluginSet regroupe les greffons d'une exécution.
+type pluginSet struct{ plugins []*plugin }
+
+// loadPlugins lance les greffons commands. Une forme qui porte le nom d'une forme prédéfinie ou
+// d'un autre greffon est refusée.
+func loadPlugins(commands []string) (*pluginSet, error) {
+	set := &pluginSet{}
+	seen := map[string]bool{}
+	for _, command := range commands {
+		pl, err := startPlugin(command)
+		if err != nil {
+			set.close()
+			return nil, err
+		}
+		set.plugins = append(set.plugins, pl)
+		for _, f := range pl.forms {
+			if _, builtin := primes.LookupForm(f.Name()); builtin || seen[f.Name()] {
+				set.close()
+				return nil, fmt.Errorf("%w: -plugin %q: forme %q déjà définie", errInvalidInput, command, f.Name())
+			}
+			seen[f.Name()] = true
+		}
+	}
+	return set, nil
+}
+
+// lookupForm retourne la forme name d'un greffon.
+func (s *pluginSet) lookupForm(name string) (primes.Form, bool) {
+	for _, pl := range s.plugins {
+		for _, f := range pl.forms {
+			if f.Name() == name {
+				return f, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// formNames retourne les formes enregistrées et celles des greffons, triées.
+func (s *pluginSet) formNames() []string {
+	names := primes.FormNames()
+	for _, pl := range s.plugins {
+		for _, f := range pl.forms {
+			names = append(names, f.Name())
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// sinkWriter retourne l'écrivain de la destination name d'un greffon (cible plugin://name de -sink).
+func (s *pluginSet) sinkWriter(name string) (io.WriteCloser, error) {
+	for _, pl := range s.plugins {
+		if slices.Contains(pl.sinks, name) {
+			return &pluginSinkWriter{pl: pl, name: name}, nil
+		}
+	}
+	return nil, fmt.Errorf("aucun greffon ne déclare la destination %q", name)
+}
+
+// failure retourne la première erreur de communication d'un greffon.
+func (s *pluginSet) failure() error {
+	for _, pl := range s.plugins {
+		if err := pl.failure(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close arrête les greffons.
+func (s *pluginSet) close() {
+	for _, pl := range s.plugins {
+		pl.close()
+	}
+	s.plugins = nil
+}
+
+// pluginSinkWriter transmet la sortie d'une destination -sink à un greffon, par blocs (write);
+// Close attend l'accusé de la fermeture (close), une seule fois.
+type pluginSinkWriter struct {
+	pl     *plugin
+	name   string
+	closed bool
+}
+
+func (w *pluginSinkWriter) Write(p []byte) (int, error) {
+	if err := w.pl.send(pluginRequest{Op: "write", Sink: w.name, Data: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *pluginSinkWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	_, err := w.pl.call(pluginRequest{Op: "close", Sink: w.name})
+	return err
+}
+
+// newPluginForm construit la forme déclarée par spec: locale si elle a des coefficients, distante sinon.
+func newPluginForm(pl *plugin, spec pluginFormSpec) (primes.Form, error) {
+	switch {
+	case spec.Name == "":
+		return nil, errors.New("forme sans nom")
+	case spec.MaxLimit < 0:
+		return nil, fmt.Errorf("forme %q: max_limit=%d", spec.Name, spec.MaxLimit)
+	case spec.Coefficients == nil:
+		return &remoteForm{pl: pl, spec: spec}, nil
+	case len(spec.Coefficients) != 6:
+		return nil, fmt.Errorf("forme %q: %d coefficients (attendu a, b, c, d, e, f)", spec.Name, len(spec.Coefficients))
+	}
+	f := &quadraticForm{name: spec.Name, coef: [6]int64(spec.Coefficients)}
+	// Au-delà de 1, chaque terme est majoré par |coefficient|·L²: L² ≤ MaxInt64 / Σ|coefficients|.
+	var sum uint64
+	for _, c := range f.coef {
+		abs := uint64(c)
+		if c < 0 {
+			abs = -abs
+		}
+		if sum += abs; sum < abs {
+			sum = math.MaxUint64 // Débordement: aucune limite sûre au-delà de 0.
+		}
+	}
+	f.maxLimit = math.MaxInt32
+	if sum > 0 {
+		f.maxLimit = int(math.Sqrt(float64(math.MaxInt64 / sum)))
+	}
+	if spec.MaxLimit > 0 {
+		f.maxLimit = min(f.maxLimit, spec.MaxLimit)
+	}
+	return f, nil
+}
+
+// quadraticForm est une forme déclarée par ses coefficients: n = a·p² + b·pq + c·q² + d·p + e·q + f.
+type quadraticForm struct {
+	name     string
+	coef     [6]int64
+	maxLimit int
+}
+
+func (f *quadraticForm) Name() string { return f.name }
+func (f *quadraticForm) Eval(p, q int64) int64 {
+	c := f.coef
+	return c[0]*p*p + c[1]*p*q + c[2]*q*q + c[3]*p + c[4]*q + c[5]
+}
+func (f *quadraticForm) Prune(p, q int64) bool { return false }
+func (f *quadraticForm) MaxLimit() int         { return f.maxLimit }
+func (f *quadraticForm) EvalBig(p, q int64) *big.Int {
+	bp, bq := big.NewInt(p), big.NewInt(q)
+	monomials := [6]*big.Int{
+		new(big.Int).Mul(bp, bp), new(big.Int).Mul(bp, bq), new(big.Int).Mul(bq, bq), bp, bq, big.NewInt(1),
+	}
+	n := new(big.Int)
+	for i, m := range monomials {
+		n.Add(n, m.Mul(m, big.NewInt(f.coef[i])))
+	}
+	return n
+}
+func (f *quadraticForm) String() string { return f.name }
+
+// remoteForm est une forme évaluée par son greffon, une paire par requête. Une erreur de
+// communication est retenue par le greffon (voir pluginSet.failure): la paire est alors écartée.
+type remoteForm struct {
+	pl   *plugin
+	spec pluginFormSpec
+}
+
+func (f *remoteForm) Name() string { return f.spec.Name }
+func (f *remoteForm) Eval(p, q int64) int64 {
+	reply, err := f.pl.call(pluginRequest{Op: "eval", Form: f.spec.Name, P: p, Q: q})
+	if err != nil {
+		f.pl.fail(err)
+		return 0 // Jamais premier.
+	}
+	return reply.N
+}
+func (f *remoteForm) Prune(p, q int64) bool {
+	if !f.spec.Prune {
+		return false
+	}
+	reply, err := f.pl.call(pluginRequest{Op: "prune", Form: f.spec.Name, P: p, Q: q})
+	if err != nil {
+		f.pl.fail(err)
+		return true
+	}
+	return reply.Prune
+}
+
+// MaxLimit implémente primes.LimitedForm: la limite déclarée, sinon aucune.
+func (f *remoteForm) MaxLimit() int {
+	if f.spec.MaxLimit > 0 {
+		return f.spec.MaxLimit
+	}
+	return math.MaxInt
+}
+func (f *remoteForm) String() string { return f.spec.Name }
@@ -0,0 +1,167 @@
+/*
+ * Fichier: plugins_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des greffons (option -plugin). Le binaire de test sert lui-même de
+ * greffon: relancé avec PRIMENUMBER_TEST_PLUGIN, il parle le protocole au
+ * lieu d'exécuter les tests.
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestMain exécute le greffon de test quand le binaire est lancé par -plugin.
+func TestMain(m *testing.M) {
+	if mode := os.Getenv("PRIMENUMBER_TEST_PLUGIN"); mode != "" {
+		os.Exit(runTestPlugin(mode, os.Stdin, os.Stdout))
+	}
+	os.Exit(m.Run())
+}
+
+// runTestPlugin est un greffon: la forme p^2+q^2 déclarée par ses coefficients, la même évaluée à
+// distance (remote), et une destination audit écrite dans le fichier PRIMENUMBER_TEST_PLUGIN_OUT.
+// mode v2 annonce une version inconnue du protocole.
+func runTestPlugin(mode string, stdin io.Reader, stdout io.Writer) int {
+	dec, enc := json.NewDecoder(stdin), json.NewEncoder(stdout)
+	var audit strings.Builder
+	for {
+		var req pluginRequest
+		if err := dec.Decode(&req); err != nil {
+			return 0
+		}
+		switch req.Op {
+		case "hello":
+			version := pluginVersion
+			if mode == "v2" {
+				version = 2
+			}
+			enc.Encode(map[string]any{
+				"protocol": pluginProtocol, "version": version, "name": "test",
+				"forms": []pluginFormSpec{{Name: "p^2+q^2", Coefficients: []int64{1, 0, 1, 0, 0, 0}}, {Name: "remote", Prune: true}},
+				"sinks": []string{"audit"},
+			})
+		case "eval":
+			enc.Encode(map[string]any{"n": req.P*req.P + req.Q*req.Q})
+		case "prune":
+			enc.Encode(map[string]any{"prune": req.P%2 == req.Q%2}) // p et q de même parité: n pair.
+		case "write":
+			audit.WriteString(req.Data)
+		case "close":
+			err := os.WriteFile(os.Getenv("PRIMENUMBER_TEST_PLUGIN_OUT"), []byte(audit.String()), 0o644)
+			if err != nil {
+				enc.Encode(map[string]any{"error": err.Error()})
+			} else {
+				enc.Encode(map[string]any{})
+			}
+		case "bye":
+			return 0
+		default:
+			enc.Encode(map[string]any{"error": "opération inconnue " + req.Op})
+		}
+	}
+}
+
+// testPluginCommand retourne la commande qui lance le binaire de test comme greffon.
+func testPluginCommand(t *testing.T, mode string) string {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil || strings.ContainsAny(exe, " \t") {
+		t.Skipf("binaire de test inutilisable comme greffon: %q, %v", exe, err)
+	}
+	t.Setenv("PRIMENUMBER_TEST_PLUGIN", mode)
+	return exe
+}
+
+// TestRunPlugin vérifie qu'une forme déclarée par ses coefficients et la même forme évaluée par le
+// greffon donnent les mêmes résultats, et que la destination du greffon reçoit la sortie NDJSON.
+func TestRunPlugin(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	command := testPluginCommand(t, "1")
+	auditFile := filepath.Join(t.TempDir(), "audit.ndjson")
+	t.Setenv("PRIMENUMBER_TEST_PLUGIN_OUT", auditFile)
+
+	base := []string{"-plugin", command, "-limit", "60", "-workers", "2", "-sort", "-format", "ndjson", "-manifest=false"}
+	var local, status bytes.Buffer
+	if err := run(append(base, "-form", "p^2+q^2"), &local, &status); err != nil {
+		t.Fatalf("-form p^2+q^2: %v\n%s", err, status.String())
+	}
+	if !strings.Contains(status.String(), "Greffon test: formes [p^2+q^2, remote], destinations [audit]") {
+		t.Errorf("greffon non annoncé:\n%s", status.String())
+	}
+	want := resultLines(local.String())
+	if !strings.Contains(want, `"n":13}`) || strings.Contains(want, `"n":8}`) {
+		t.Errorf("résultats de p^2+q^2 inattendus:\n%s", want)
+	}
+	var remote bytes.Buffer
+	if err := run(append(base, "-form", "remote", "-sink", "ndjson:plugin://audit"), &remote, io.Discard); err != nil {
+		t.Fatalf("-form remote: %v", err)
+	}
+	if got := strings.ReplaceAll(resultLines(remote.String()), "remote", "p^2+q^2"); got != want {
+		t.Errorf("forme distante:\n%s\nattendu:\n%s", got, want)
+	}
+	audit, err := os.ReadFile(auditFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resultLines(string(audit)); got != resultLines(remote.String()) {
+		t.Errorf("destination du greffon:\n%s\nattendu:\n%s", got, resultLines(remote.String()))
+	}
+}
+
+// TestRunPluginErrors vérifie les échecs de lancement et de poignée de main, et les noms inconnus.
+func TestRunPluginErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "absent")
+	if err := run([]string{"-plugin", missing, "-limit", "10"}, io.Discard, io.Discard); exitCode(err) != exitIO {
+		t.Errorf("greffon absent: %v, attendu le code %d", err, exitIO)
+	}
+	command := testPluginCommand(t, "v2")
+	if err := run([]string{"-plugin", command, "-limit", "10"}, io.Discard, io.Discard); exitCode(err) != exitInvalidInput {
+		t.Errorf("version inconnue: %v, attendu le code %d", err, exitInvalidInput)
+	}
+	t.Setenv("PRIMENUMBER_TEST_PLUGIN", "1")
+	if err := run([]string{"-plugin", command, "-limit", "10", "-form", "inconnue"}, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+		t.Errorf("forme inconnue: %v, attendu le code %d", err, exitInvalidFlags)
+	}
+	if err := run([]string{"-plugin", command, "-limit", "10", "-sink", "ndjson:plugin://inconnue"}, io.Discard, io.Discard); exitCode(err) != exitIO {
+		t.Errorf("destination inconnue: %v, attendu le code %d", err, exitIO)
+	}
+	if err := run([]string{"-plugin", command + " " + command, "-limit", "10", "-form", "p^2+q^2"}, io.Discard, io.Discard); err != nil {
+		t.Errorf("arguments du greffon: %v", err)
+	}
+	if err := run([]string{"-plugin", command, "-plugin", command, "-limit", "10"}, io.Discard, io.Discard); exitCode(err) != exitInvalidInput {
+		t.Errorf("forme déclarée deux fois: %v, attendu le code %d", err, exitInvalidInput)
+	}
+}
+
+// TestQuadraticForm compare l'évaluation exacte d'une forme déclarée par coefficients à
+// l'évaluation sur int64, et vérifie sa limite sans débordement.
+func TestQuadraticForm(t *testing.T) {
+	f, err := newPluginForm(nil, pluginFormSpec{Name: "q", Coefficients: []int64{2, -3, 5, 7, -11, 13}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	qf := f.(*quadraticForm)
+	for _, pq := range [][2]int64{{2, 3}, {101, 7}, {int64(qf.maxLimit), int64(qf.maxLimit)}} {
+		if got, want := qf.EvalBig(pq[0], pq[1]), qf.Eval(pq[0], pq[1]); !got.IsInt64() || got.Int64() != want {
+			t.Errorf("(%d, %d): EvalBig = %v, Eval = %d", pq[0], pq[1], got, want)
+		}
+	}
+	for _, spec := range []pluginFormSpec{{}, {Name: "x", Coefficients: []int64{1, 2}}, {Name: "x", MaxLimit: -1}} {
+		if _, err := newPluginForm(nil, spec); err == nil {
+			t.Errorf("%+v accepté", spec)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Fichier: presets.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Préréglages de la recherche (option -preset): des combinaisons cohérentes
+ * d'options (ordre de grandeur de la limite, test de primalité, double
+ * vérification, format de sortie, suivi) pour démarrer sans connaître toutes
+ * les options. Un préréglage ne fixe que des valeurs par défaut: toute option
+ * explicite de la ligne de commande l'emporte.
+ */
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+)
+
+// presetSetting est une option fixée par un préréglage.
+type presetSetting struct {
+	flag, value string
+}
+
+// searchPresets associe chaque préréglage à ses options, dans l'ordre de presetNames.
+var searchPresets = map[string][]presetSetting{
+	// Premier essai: quelques secondes au plus, tableau à l'écran.
+	"quick": {{"limit", "1000"}, {"primetest", "miller"}, {"format", "table"}},
+	// Vérification croisée de chaque candidat par deux tests, jumeaux et ligne de suivi.
+	"thorough": {{"limit", "20000"}, {"compare", "miller,bpsw"}, {"twins", "true"}, {"stats-interval", "30s"}},
+	// Résultats destinés à être publiés: JSON avec manifeste, double test, jumeaux et suivi.
+	"publication": {{"limit", "100000"}, {"compare", "miller,bpsw"}, {"twins", "true"}, {"format", "json"}, {"manifest", "true"}, {"stats-interval", "1m"}},
+}
+
+// presetNames sont les préréglages acceptés par -preset.
+var presetNames = []string{"quick", "thorough", "publication"}
+
+// presetOverrides liste, pour une option d'un préréglage, les options explicites qui l'écartent:
+// un test choisi par -primetest ou -error-bound remplace la vérification croisée (-compare).
+var presetOverrides = map[string][]string{
+	"compare": {"primetest", "error-bound"},
+}
+
+// applyPreset donne aux options de fs non fixées sur la ligne de commande les valeurs du préréglage
+// name et retourne celles qui ont été appliquées (-option=valeur). Les valeurs sont posées sans
+// marquer les options comme fixées: les règles qui dépendent de la présence d'une option (-sweep et
+// -limit, par exemple) ne voient que la ligne de commande.
+func applyPreset(fs *flag.FlagSet, name string) ([]string, error) {
+	settings, ok := searchPresets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: -preset=%q (attendu l'un de %v)", errInvalidFlags, name, presetNames)
+	}
+	var applied []string
+	for _, s := range settings {
+		if flagSet(fs, s.flag) || slices.ContainsFunc(presetOverrides[s.flag], func(o string) bool { return flagSet(fs, o) }) {
+			continue
+		}
+		if err := fs.Lookup(s.flag).Value.Set(s.value); err != nil {
+			return nil, fmt.Errorf("-preset %s: -%s=%s: %w", name, s.flag, s.value, err)
+		}
+		applied = append(applied, "-"+s.flag+"="+s.value)
+	}
+	return applied, nil
+}
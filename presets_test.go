@@ -0,0 +1,82 @@
+/*
+ * Fichier: presets_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des préréglages de la recherche (-preset).
+ */
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestApplyPreset vérifie que les options explicites l'emportent sur le préréglage, qu'un test
+// explicite écarte la vérification croisée et que les options posées ne sont pas marquées.
+func TestApplyPreset(t *testing.T) {
+	for _, name := range presetNames {
+		if _, ok := searchPresets[name]; !ok {
+			t.Errorf("préréglage %q sans options", name)
+		}
+	}
+	newFlags := func(args ...string) *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Int("limit", 1000, "")
+		fs.String("primetest", "miller", "")
+		fs.Float64("error-bound", 1e-30, "")
+		fs.String("compare", "", "")
+		fs.Bool("twins", false, "")
+		fs.Duration("stats-interval", 0, "")
+		fs.String("format", "table", "")
+		fs.Bool("manifest", true, "")
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return fs
+	}
+
+	fs := newFlags("-limit", "50")
+	applied, err := applyPreset(fs, "publication")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"-compare=miller,bpsw", "-twins=true", "-format=json", "-manifest=true", "-stats-interval=1m"}
+	if !slices.Equal(applied, want) || fs.Lookup("limit").Value.String() != "50" || fs.Lookup("format").Value.String() != "json" {
+		t.Errorf("publication avec -limit 50: %v, attendu %v", applied, want)
+	}
+	if flagSet(fs, "format") {
+		t.Error("option du préréglage marquée comme fixée sur la ligne de commande")
+	}
+
+	fs = newFlags("-primetest", "bpsw")
+	if applied, _ := applyPreset(fs, "thorough"); slices.Contains(applied, "-compare=miller,bpsw") || fs.Lookup("compare").Value.String() != "" {
+		t.Errorf("thorough avec -primetest: %v, -compare attendu vide", applied)
+	}
+	if _, err := applyPreset(newFlags(), "fast"); exitCode(err) != exitInvalidFlags {
+		t.Errorf("préréglage inconnu: %v, attendu le code %d", err, exitInvalidFlags)
+	}
+}
+
+// TestRunPreset valide un préréglage de bout en bout et l'annonce des options appliquées.
+func TestRunPreset(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	var out bytes.Buffer
+	if err := run([]string{"-preset", "quick", "-limit", "30", "-workers", "1"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), tr(msgPresetApplied, "quick", "-primetest=miller -format=table")) {
+		t.Errorf("annonce du préréglage absente:\n%s", out.String())
+	}
+	if err := run([]string{"-preset", "exhaustive"}, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+		t.Errorf("-preset exhaustive: %v, attendu le code %d", err, exitInvalidFlags)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ * Fichier: primecache.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Cache persistant des nombres premiers (option -primes-cache). Après le
+ * crible, la liste est écrite dans un fichier binaire compact, nommé d'après
+ * la limite et l'algorithme de crible; les exécutions suivantes le projettent
+ * en mémoire (mmap), le valident et évitent ainsi de refaire le crible.
+ *
+ * Format (petit-boutiste): en-tête primeCacheHeader de 44 octets, suivi de
+ * Count nombres premiers encodés en uint32 (même encodage que list-primes
+ * -format binary). Checksum est le CRC-32 (Castagnoli) des données.
+ */
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+const (
+	// sieveAlgorithm identifie l'algorithme ayant produit la liste; il fait partie de la clé du cache.
+	sieveAlgorithm = "eratosthenes"
+	// primeCacheVersion est incrémentée à chaque changement incompatible du format.
+	primeCacheVersion = 1
+)
+
+// primeCacheMagic identifie un fichier de cache des nombres premiers.
+var primeCacheMagic = [4]byte{'P', 'N', 'P', 'C'}
+
+// crcTable est la table CRC-32 Castagnoli utilisée pour la somme de contrôle des données.
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// primeCacheHeader est l'en-tête d'un fichier de cache.
+type primeCacheHeader struct {
+	Magic     [4]byte
+	Version   uint32
+	Algorithm [16]byte
+	Limit     uint64
+	Count     uint64
+	Checksum  uint32
+}
+
+// primeCacheHeaderSize est la taille encodée de primeCacheHeader.
+var primeCacheHeaderSize = binary.Size(primeCacheHeader{})
+
+// errCacheInvalid signale un fichier de cache absent du bon format ou incohérent.
+var errCacheInvalid = errors.New("cache invalide")
+
+// primeCachePath retourne le chemin du fichier de cache pour une limite dans le répertoire dir.
+func primeCachePath(dir string, limit int) string {
+	return filepath.Join(dir, fmt.Sprintf("primes-%s-%d.bin", sieveAlgorithm, limit))
+}
+
+// newPrimeCacheHeader construit l'en-tête attendu pour une limite et un nombre de premiers.
+func newPrimeCacheHeader(limit, count int) primeCacheHeader {
+	h := primeCacheHeader{Magic: primeCacheMagic, Version: primeCacheVersion, Limit: uint64(limit), Count: uint64(count)}
+	copy(h.Algorithm[:], sieveAlgorithm)
+	return h
+}
+
+// decodePrimes décode une suite de uint32 petit-boutistes en vérifiant qu'elle est
+// strictement croissante et bornée par limit (et par math.MaxInt sur les plateformes 32 bits).
+func decodePrimes(data []byte, limit uint64) ([]int, error) {
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("taille %d non multiple de 4", len(data))
+	}
+	primeList := make([]int, len(data)/4)
+	limit = min(limit, math.MaxInt)
+	prev := -1
+	for i := range primeList {
+		v := uint64(binary.LittleEndian.Uint32(data[4*i:]))
+		if v > limit || int(v) <= prev {
+			return nil, fmt.Errorf("entrée %d (%d) non croissante ou au-delà de la limite %d", i, v, limit)
+		}
+		primeList[i], prev = int(v), int(v)
+	}
+	return primeList, nil
+}
+
+// readPrimeCache lit et valide le fichier de cache path pour la limite donnée.
+func readPrimeCache(path string, limit int) ([]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < int64(primeCacheHeaderSize) {
+		return nil, fmt.Errorf("%w: fichier tronqué", errCacheInvalid)
+	}
+
+	data, unmap, err := mapFile(f, int(info.Size()))
+	if err != nil {
+		return nil, err
+	}
+	defer unmap()
+
+	var h primeCacheHeader
+	binary.Read(bytes.NewReader(data[:primeCacheHeaderSize]), binary.LittleEndian, &h)
+	payload := data[primeCacheHeaderSize:]
+	want := newPrimeCacheHeader(limit, len(payload)/4)
+	switch {
+	case h.Magic != want.Magic || h.Version != want.Version:
+		return nil, fmt.Errorf("%w: format ou version inconnus", errCacheInvalid)
+	case h.Algorithm != want.Algorithm || h.Limit != want.Limit:
+		return nil, fmt.Errorf("%w: clé différente (limite %d)", errCacheInvalid, h.Limit)
+	case h.Count != want.Count || len(payload)%4 != 0:
+		return nil, fmt.Errorf("%w: %d nombres annoncés pour %d octets", errCacheInvalid, h.Count, len(payload))
+	case crc32.Checksum(payload, crcTable) != h.Checksum:
+		return nil, fmt.Errorf("%w: somme de contrôle incorrecte", errCacheInvalid)
+	}
+	primeList, err := decodePrimes(payload, uint64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCacheInvalid, err)
+	}
+	return primeList, nil
+}
+
+// writePrimeCache écrit primeList dans le fichier de cache path. Le fichier est d'abord
+// écrit sous un nom temporaire puis renommé, pour qu'une lecture concurrente ne voie
+// jamais un cache partiel.
+func writePrimeCache(path string, limit int, primeList []int) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	// L'en-tête est réécrit une fois la somme de contrôle des données connue.
+	h := newPrimeCacheHeader(limit, len(primeList))
+	if _, err := f.Seek(int64(primeCacheHeaderSize), io.SeekStart); err != nil {
+		return err
+	}
+	crc := crc32.New(crcTable)
+	if err := writePrimes(io.MultiWriter(f, crc), primeList, "binary", nil); err != nil {
+		return err
+	}
+	h.Checksum = crc.Sum32()
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := binary.Write(f, binary.LittleEndian, &h); err != nil {
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// loadOrSievePrimes retourne les nombres premiers jusqu'à limit depuis le cache du répertoire dir,
+// ou les calcule avec le crible puis met le cache à jour. Les problèmes de cache ne sont pas
+// bloquants: ils sont signalés par logf et la liste est recalculée.
+func loadOrSievePrimes(dir string, limit int, logf func(string)) []int {
+	path := primeCachePath(dir, limit)
+	primeList, err := readPrimeCache(path, limit)
+	if err == nil {
+		logf(tr(msgCacheHit, path))
+		return primeList
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		logf(tr(msgCacheInvalid, path, err))
+	}
+
+	logf(tr(msgSieving))
+	primeList = primes.SieveOfEratosthenes(limit)
+	if err := writePrimeCache(path, limit, primeList); err != nil {
+		logf(tr(msgCacheWriteError, path, err))
+	} else {
+		logf(tr(msgCacheWritten, path))
+	}
+	return primeList
+}
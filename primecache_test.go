@@ -0,0 +1,114 @@
+/*
+ * Fichier: primecache_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du cache persistant des nombres premiers: aller-retour, validation
+ * des fichiers corrompus et repli sur le crible.
+ */
+package main
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestPrimeCacheRoundTrip valide qu'un cache écrit puis relu restitue la liste du crible.
+func TestPrimeCacheRoundTrip(t *testing.T) {
+	for _, limit := range []int{1, 2, 1000} {
+		path := primeCachePath(t.TempDir(), limit)
+		expected := primes.SieveOfEratosthenes(limit)
+		if err := writePrimeCache(path, limit, expected); err != nil {
+			t.Fatalf("writePrimeCache(%d): %v", limit, err)
+		}
+		got, err := readPrimeCache(path, limit)
+		if err != nil {
+			t.Fatalf("readPrimeCache(%d): %v", limit, err)
+		}
+		if len(got) != len(expected) || !slices.Equal(got, expected) {
+			t.Errorf("limite %d: %d nombres relus, attendu %d", limit, len(got), len(expected))
+		}
+	}
+}
+
+// TestPrimeCacheInvalid valide le rejet des caches corrompus, tronqués ou d'une autre limite.
+func TestPrimeCacheInvalid(t *testing.T) {
+	path := primeCachePath(t.TempDir(), 100)
+	if err := writePrimeCache(path, 100, primes.SieveOfEratosthenes(100)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name  string
+		data  []byte
+		limit int
+	}{
+		{"Autre limite", data, 200},
+		{"Tronqué", data[:len(data)-2], 100},
+		{"En-tête tronqué", data[:10], 100},
+		{"Données modifiées", append(slices.Clone(data[:len(data)-1]), data[len(data)-1]^1), 100},
+		{"Magique incorrect", append([]byte("XXXX"), data[4:]...), 100},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := os.WriteFile(path, tc.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := readPrimeCache(path, tc.limit); !errors.Is(err, errCacheInvalid) {
+				t.Errorf("readPrimeCache = %v, attendu errCacheInvalid", err)
+			}
+		})
+	}
+}
+
+// TestLoadOrSievePrimes valide la création du cache au premier appel et sa réutilisation ensuite.
+func TestLoadOrSievePrimes(t *testing.T) {
+	dir := t.TempDir()
+	var logs []string
+	logf := func(msg string) { logs = append(logs, msg) }
+
+	first := loadOrSievePrimes(dir, 500, logf)
+	if _, err := os.Stat(primeCachePath(dir, 500)); err != nil {
+		t.Fatalf("cache non créé: %v", err)
+	}
+	logs = nil
+	second := loadOrSievePrimes(dir, 500, logf)
+	if !slices.Equal(first, second) {
+		t.Errorf("liste relue différente de la liste du crible")
+	}
+	if len(logs) != 1 || logs[0] != tr(msgCacheHit, primeCachePath(dir, 500)) {
+		t.Errorf("journal du second appel = %q, attendu un seul chargement depuis le cache", logs)
+	}
+}
+
+// TestDecodePrimes valide le rejet des listes non croissantes ou dépassant la limite.
+func TestDecodePrimes(t *testing.T) {
+	encode := func(values ...uint32) []byte {
+		var b []byte
+		for _, v := range values {
+			b = append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+		}
+		return b
+	}
+	if _, err := decodePrimes(encode(2, 3, 5), 10); err != nil {
+		t.Errorf("liste valide rejetée: %v", err)
+	}
+	if _, err := decodePrimes(encode(2, 5, 3), 10); err == nil {
+		t.Errorf("liste non croissante acceptée")
+	}
+	if _, err := decodePrimes(encode(2, 11), 10); err == nil {
+		t.Errorf("liste au-delà de la limite acceptée")
+	}
+	if _, err := decodePrimes([]byte{1, 2, 3}, 10); err == nil {
+		t.Errorf("taille non multiple de 4 acceptée")
+	}
+}
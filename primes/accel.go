@@ -0,0 +1,186 @@
+/*
+ * Fichier: accel.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Déchargement optionnel des deux étapes massivement parallèles de la
+ * recherche: le marquage des multiples du crible (par segments) et le
+ * pré-filtre des candidats par petits nombres premiers. Un Accelerator
+ * reçoit un segment à marquer ou un lot de candidats, et rend les marques ou
+ * les candidats survivants; le test de primalité final des survivants reste
+ * fait par les workers sur CPU. L'accélérateur "cpu" est l'implémentation de
+ * référence, toujours disponible; les autres s'enregistrent
+ * (RegisterAccelerator), comme "gpu" du paquet primes/gpu (OpenCL, avec
+ * l'étiquette de build gpu et cgo). Les résultats d'une recherche sont les
+ * mêmes avec ou sans accélérateur.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// PrefilterBound est la borne des petits nombres premiers du pré-filtre: un candidat divisible par
+// un nombre premier inférieur à PrefilterBound (autre que lui-même) est composé sans autre test.
+const PrefilterBound = 1024
+
+// accelSegmentSize est le nombre d'entiers d'un segment du crible accéléré.
+const accelSegmentSize = 1 << 24
+
+// ErrNoAccelerator signale un accélérateur absent de cette compilation ou sans périphérique.
+var ErrNoAccelerator = errors.New("primes: accélérateur indisponible")
+
+// prefilterPrimes sont les nombres premiers inférieurs à PrefilterBound.
+var prefilterPrimes = SieveOfEratosthenes(PrefilterBound - 1)
+
+// PrefilterPrimes retourne les nombres premiers inférieurs à PrefilterBound, diviseurs du
+// pré-filtre.
+func PrefilterPrimes() []int { return slices.Clone(prefilterPrimes) }
+
+// Accelerator décharge le marquage du crible et le pré-filtre des candidats. Ses méthodes peuvent
+// être appelées par plusieurs workers à la fois.
+type Accelerator interface {
+	// Name est le nom de l'accélérateur, tel qu'accepté par OpenAccelerator.
+	Name() string
+	// MarkSegment marque composite[i] pour chaque entier base+i multiple de l'un des nombres
+	// premiers de sievePrimes, à partir de son carré. Les autres marques sont laissées telles
+	// quelles.
+	MarkSegment(composite []bool, base int, sievePrimes []int) error
+	// Prefilter met keep[i] à false si ns[i] a un facteur premier inférieur à PrefilterBound et
+	// différent de ns[i]; les autres valeurs de keep sont laissées telles quelles.
+	Prefilter(ns []int64, keep []bool) error
+	// Close libère le périphérique.
+	Close() error
+}
+
+// accelerators associe les accélérateurs enregistrés à leur constructeur.
+var (
+	acceleratorsMu sync.Mutex
+	accelerators   = map[string]func() (Accelerator, error){
+		"cpu": func() (Accelerator, error) { return cpuAccelerator{}, nil },
+	}
+)
+
+// RegisterAccelerator enregistre le constructeur open de l'accélérateur name, en général depuis
+// la fonction init de son paquet. Un nom déjà enregistré est remplacé.
+func RegisterAccelerator(name string, open func() (Accelerator, error)) {
+	acceleratorsMu.Lock()
+	defer acceleratorsMu.Unlock()
+	accelerators[name] = open
+}
+
+// AcceleratorNames retourne les noms des accélérateurs enregistrés, triés.
+func AcceleratorNames() []string {
+	acceleratorsMu.Lock()
+	defer acceleratorsMu.Unlock()
+	names := make([]string, 0, len(accelerators))
+	for name := range accelerators {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// OpenAccelerator ouvre l'accélérateur nommé name. L'erreur enveloppe ErrInvalidOptions pour un
+// nom inconnu, ErrNoAccelerator s'il n'est pas disponible.
+func OpenAccelerator(name string) (Accelerator, error) {
+	acceleratorsMu.Lock()
+	open, ok := accelerators[name]
+	acceleratorsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: accélérateur %q (attendu l'un de %v)", ErrInvalidOptions, name, AcceleratorNames())
+	}
+	return open()
+}
+
+// cpuAccelerator est l'accélérateur de référence, sur CPU.
+type cpuAccelerator struct{}
+
+func (cpuAccelerator) Name() string { return "cpu" }
+
+func (cpuAccelerator) MarkSegment(composite []bool, base int, sievePrimes []int) error {
+	for _, p := range sievePrimes {
+		if start := segmentStart(base, p); start < len(composite) {
+			markMultiples(composite, start, p)
+		}
+	}
+	return nil
+}
+
+func (cpuAccelerator) Prefilter(ns []int64, keep []bool) error {
+	for i, n := range ns {
+		if hasSmallFactor(n) {
+			keep[i] = false
+		}
+	}
+	return nil
+}
+
+func (cpuAccelerator) Close() error { return nil }
+
+// segmentStart retourne l'indice, dans le segment commençant à base, du premier multiple de p à
+// marquer: max(p², premier multiple de p >= base), ou math.MaxInt si p² déborde.
+func segmentStart(base, p int) int {
+	if p > math.MaxInt/p {
+		return math.MaxInt
+	}
+	first := max(p*p, (base+p-1)/p*p)
+	return first - base
+}
+
+// hasSmallFactor indique si n a un facteur premier inférieur à PrefilterBound et différent de n.
+func hasSmallFactor(n int64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range prefilterPrimes {
+		if int64(p) >= n {
+			return false
+		}
+		if n%int64(p) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SieveAccelerated est SieveOfEratosthenesContext dont le marquage est déchargé sur acc, segment
+// par segment: les nombres premiers jusqu'à √limit sont criblés sur CPU, puis chaque segment de
+// accelSegmentSize entiers est marqué par acc et collecté. Retourne ctx.Err() si ctx est annulé
+// entre deux segments, ou l'erreur de acc.
+func SieveAccelerated(ctx context.Context, limit int, acc Accelerator) ([]int, error) {
+	if limit < 2 {
+		return nil, ctx.Err()
+	}
+	sievePrimes, err := SieveOfEratosthenesContext(ctx, int(isqrt(int64(limit))))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]int, 0, EstimatePrimeCount(limit))
+	composite := make([]bool, min(accelSegmentSize, limit+1))
+	for base := 0; base <= limit; base += accelSegmentSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		segment := composite[:min(accelSegmentSize, limit+1-base)]
+		clear(segment)
+		if err := acc.MarkSegment(segment, base, sievePrimes); err != nil {
+			return nil, fmt.Errorf("accélérateur %s: %w", acc.Name(), err)
+		}
+		for i, c := range segment {
+			if !c && base+i >= 2 {
+				list = append(list, base+i)
+			}
+		}
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list, nil
+}
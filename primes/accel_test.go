@@ -0,0 +1,171 @@
+/*
+ * Fichier: accel_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'accélérateur de référence "cpu": marquage des segments, crible
+ * accéléré, pré-filtre par petits nombres premiers, et recherche accélérée
+ * identique à la recherche sur CPU seul (résultats et valeurs composées).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// countingAccelerator compte les candidats soumis à l'accélérateur de référence, ou échoue.
+type countingAccelerator struct {
+	cpuAccelerator
+	marked, filtered atomic.Int64
+	fail             error
+}
+
+func (a *countingAccelerator) MarkSegment(composite []bool, base int, sievePrimes []int) error {
+	a.marked.Add(int64(len(composite)))
+	return a.cpuAccelerator.MarkSegment(composite, base, sievePrimes)
+}
+
+func (a *countingAccelerator) Prefilter(ns []int64, keep []bool) error {
+	if a.fail != nil {
+		return a.fail
+	}
+	a.filtered.Add(int64(len(ns)))
+	return a.cpuAccelerator.Prefilter(ns, keep)
+}
+
+// TestMarkSegment compare le marquage de segments quelconques au test de primalité.
+func TestMarkSegment(t *testing.T) {
+	sievePrimes := SieveOfEratosthenes(100)
+	for _, base := range []int{0, 1, 97, 1000, 9_000} {
+		composite := make([]bool, 1000)
+		if err := (cpuAccelerator{}).MarkSegment(composite, base, sievePrimes); err != nil {
+			t.Fatal(err)
+		}
+		for i, c := range composite {
+			if n := base + i; n >= 2 && c == IsPrime(int64(n)) {
+				t.Fatalf("base %d: %d marqué %v", base, n, c)
+			}
+		}
+	}
+}
+
+// TestSieveAccelerated compare le crible accéléré au crible d'Ératosthène.
+func TestSieveAccelerated(t *testing.T) {
+	acc := &countingAccelerator{}
+	for _, limit := range []int{0, 1, 2, 3, 100, 65_536, 1_000_003} {
+		got, err := SieveAccelerated(context.Background(), limit, acc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := SieveOfEratosthenes(limit); !slices.Equal(got, want) {
+			t.Errorf("limite %d: %d nombres premiers, attendu %d", limit, len(got), len(want))
+		}
+	}
+	if acc.marked.Load() == 0 {
+		t.Error("marquage non déchargé")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SieveAccelerated(ctx, 1000, acc); !errors.Is(err, context.Canceled) {
+		t.Errorf("crible annulé: %v", err)
+	}
+}
+
+// TestPrefilter vérifie que le pré-filtre n'écarte que les valeurs ayant un petit facteur premier
+// autre qu'elles-mêmes, et laisse les autres marques intactes.
+func TestPrefilter(t *testing.T) {
+	var ns []int64
+	for n := int64(-3); n < 5000; n++ {
+		ns = append(ns, n)
+	}
+	ns = append(ns, 1021*1031, 1031*1033, 1<<62+1, 9_223_372_036_854_775_783)
+	keep := make([]bool, len(ns))
+	for i := range keep {
+		keep[i] = true
+	}
+	keep[len(keep)-1] = false // Marque existante: laissée telle quelle.
+	if err := (cpuAccelerator{}).Prefilter(ns, keep); err != nil {
+		t.Fatal(err)
+	}
+	for i, n := range ns[:len(ns)-1] {
+		f := SmallestFactor(n)
+		if want := f == 0 || f == n || f >= PrefilterBound; keep[i] != want {
+			t.Errorf("%d (plus petit facteur %d): gardé %v, attendu %v", n, f, keep[i], want)
+		}
+	}
+	if keep[len(keep)-1] {
+		t.Error("marque existante effacée")
+	}
+}
+
+// TestSearchAccelerated compare la recherche accélérée à la recherche sur CPU seul, et vérifie la
+// transmission d'une erreur de l'accélérateur.
+func TestSearchAccelerated(t *testing.T) {
+	for _, opts := range []Options{
+		{Limit: 2000, Workers: 3},
+		{Limit: 1000, Form: FormP2PlusQ4, Twins: true, Workers: 2},
+		{Limit: 500, PrimeTest: "trial", Pairs: PairsLess, Workers: 1},
+	} {
+		want := collect(t, Search, opts)
+		acc := &countingAccelerator{}
+		opts.Accelerator = acc
+		if got := collect(t, Search, opts); !slices.Equal(got, want) {
+			t.Errorf("%+v: %d résultats accélérés, attendu %d", opts, len(got), len(want))
+		}
+		if acc.marked.Load() == 0 || acc.filtered.Load() == 0 {
+			t.Errorf("%+v: %d entiers marqués, %d candidats pré-filtrés", opts, acc.marked.Load(), acc.filtered.Load())
+		}
+	}
+
+	// L'analyse des valeurs composées voit les mêmes valeurs, avec leur plus petit facteur.
+	composites := func(acc Accelerator) map[int64]int64 {
+		factors := make(map[int64]int64)
+		opts := Options{Limit: 300, Workers: 2, Accelerator: acc, Explain: &Explain{Every: 1, OnComposite: func(c Composite) { factors[c.N] = c.Factor }}}
+		collect(t, Search, opts)
+		return factors
+	}
+	want, got := composites(nil), composites(cpuAccelerator{})
+	if len(got) != len(want) || len(want) == 0 {
+		t.Fatalf("%d valeurs composées, attendu %d", len(got), len(want))
+	}
+	for n, f := range want {
+		if got[n] != f {
+			t.Errorf("%d: facteur %d, attendu %d", n, got[n], f)
+		}
+	}
+
+	boom := errors.New("périphérique perdu")
+	err := Search(context.Background(), Options{Primes: []int{3, 5, 7}, Accelerator: &countingAccelerator{fail: boom}}, func(Result) error { return nil })
+	if !errors.Is(err, boom) {
+		t.Errorf("erreur de l'accélérateur: %v", err)
+	}
+}
+
+// TestOpenAccelerator vérifie l'ouverture par nom et l'enregistrement d'un accélérateur.
+func TestOpenAccelerator(t *testing.T) {
+	acc, err := OpenAccelerator("cpu")
+	if err != nil || acc.Name() != "cpu" {
+		t.Fatalf("cpu: %v, %v", acc, err)
+	}
+	acc.Close()
+	if _, err := OpenAccelerator("fpga"); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("accélérateur inconnu: %v", err)
+	}
+	RegisterAccelerator("test-absent", func() (Accelerator, error) { return nil, ErrNoAccelerator })
+	defer func() {
+		acceleratorsMu.Lock()
+		delete(accelerators, "test-absent")
+		acceleratorsMu.Unlock()
+	}()
+	if !slices.Contains(AcceleratorNames(), "test-absent") {
+		t.Errorf("noms = %v", AcceleratorNames())
+	}
+	if _, err := OpenAccelerator("test-absent"); !errors.Is(err, ErrNoAccelerator) {
+		t.Errorf("accélérateur absent: %v", err)
+	}
+}
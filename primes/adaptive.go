@@ -0,0 +1,138 @@
+/*
+ * Fichier: adaptive.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Miller-Rabin adaptatif: le nombre de tours dépend de la taille de n. Sur
+ * 64 bits, le plus petit ensemble de bases connu pour être déterministe
+ * jusqu'à n est utilisé (une seule base sous 2047, quatre sous 3,2·10^9...);
+ * au-delà, le nombre de tours à bases aléatoires est choisi pour garantir une
+ * probabilité d'erreur inférieure à une borne donnée (4^-k pour k tours). Les
+ * bases aléatoires peuvent être tirées d'une source cryptographique, pour
+ * résister aux entrées construites contre les bases prévisibles de math/big.
+ */
+package primes
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+)
+
+// DefaultErrorBound est la borne d'erreur par défaut du test adaptatif au-delà de 64 bits.
+const DefaultErrorBound = 1e-30
+
+// deterministicBounds associe à chaque borne le nombre de premières bases de millerRabinBases
+// qui suffisent en deçà (plus petits pseudo-premiers forts ψ_k, Jaeschke 1993,
+// Zhang et Tang 2003, Sorenson et Webster 2015).
+var deterministicBounds = []struct {
+	below uint64
+	bases int
+}{
+	{2047, 1},
+	{1_373_653, 2},
+	{25_326_001, 3},
+	{3_215_031_751, 4},
+	{2_152_302_898_747, 5},
+	{3_474_749_660_383, 6},
+	{341_550_071_728_321, 7},
+	{3_825_123_056_546_413_051, 9},
+}
+
+// MillerRabinPolicy choisit les tours du test de Miller-Rabin selon la taille de n.
+type MillerRabinPolicy struct {
+	// ErrorBound est la probabilité d'erreur maximale tolérée quand aucun ensemble de bases
+	// déterministe n'est connu (n >= 2^64); 0: DefaultErrorBound.
+	ErrorBound float64
+	// Rand est la source des bases aléatoires au-delà de 64 bits (ex: crypto/rand.Reader). nil:
+	// les bases de big.Int.ProbablyPrime, pseudo-aléatoires et dérivées de n, donc prévisibles
+	// par qui construit l'entrée. Une erreur de lecture fait paniquer IsPrimeBig.
+	Rand io.Reader
+}
+
+// Bases retourne le plus petit préfixe de bases qui rend le test exact pour n.
+func (MillerRabinPolicy) Bases(n int64) []int64 {
+	for _, b := range deterministicBounds {
+		if uint64(n) < b.below {
+			return millerRabinBases[:b.bases]
+		}
+	}
+	return millerRabinBases
+}
+
+// Rounds retourne le nombre de tours à bases aléatoires qui garantit une erreur inférieure à
+// ErrorBound: chaque tour laisse passer un composé avec une probabilité d'au plus 1/4.
+func (p MillerRabinPolicy) Rounds() int {
+	bound := p.ErrorBound
+	if bound <= 0 {
+		bound = DefaultErrorBound
+	}
+	return max(1, int(math.Ceil(-math.Log(bound)/math.Log(4))))
+}
+
+// Validate vérifie que la borne d'erreur est dans [0, 1[.
+func (p MillerRabinPolicy) Validate() error {
+	if p.ErrorBound < 0 || p.ErrorBound >= 1 || math.IsNaN(p.ErrorBound) {
+		return fmt.Errorf("%w: borne d'erreur %g (attendu dans [0, 1[)", ErrInvalidOptions, p.ErrorBound)
+	}
+	return nil
+}
+
+// IsPrime indique si n est premier, avec le plus petit ensemble de bases déterministe pour n.
+// Le résultat est exact pour tout int64.
+func (p MillerRabinPolicy) IsPrime(n int64) bool {
+	return millerRabin64(n, p.Bases(n))
+}
+
+// IsPrimeBig indique si n est premier: exact lorsque n tient dans un uint64, avec une erreur
+// inférieure à ErrorBound au-delà (Rounds tours à bases aléatoires, tirées de Rand ou par
+// big.Int.ProbablyPrime, complétés par le test de Baillie-PSW).
+func (p MillerRabinPolicy) IsPrimeBig(n *big.Int) bool {
+	if n.IsInt64() {
+		return p.IsPrime(n.Int64())
+	}
+	if n.IsUint64() {
+		return IsPrimeUint64(n.Uint64())
+	}
+	if n.Sign() <= 0 {
+		return false
+	}
+	if p.Rand == nil {
+		return n.ProbablyPrime(p.Rounds())
+	}
+	if n.Bit(0) == 0 {
+		return false
+	}
+	span := new(big.Int).Sub(n, big.NewInt(3)) // Bases dans [2, n-2].
+	for range p.Rounds() {
+		a, err := rand.Int(p.Rand, span)
+		if err != nil {
+			panic(fmt.Sprintf("primes: source des bases aléatoires: %v", err))
+		}
+		if !millerRabinBig(n, a.Add(a, big.NewInt(2))) {
+			return false
+		}
+	}
+	return n.ProbablyPrime(0)
+}
+
+// millerRabinBig exécute un tour de Miller-Rabin de n (impair, > 3) pour la base a; retourne
+// false si a est un témoin (n composé).
+func millerRabinBig(n, a *big.Int) bool {
+	nm1 := new(big.Int).Sub(n, big.NewInt(1))
+	s := nm1.TrailingZeroBits()
+	d := new(big.Int).Rsh(nm1, s)
+	x := new(big.Int).Exp(a, d, n)
+	if x.Cmp(big.NewInt(1)) == 0 || x.Cmp(nm1) == 0 {
+		return true
+	}
+	for range s - 1 {
+		if x.Mul(x, x).Mod(x, n); x.Cmp(nm1) == 0 {
+			return true
+		}
+	}
+	return false
+}
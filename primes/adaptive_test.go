@@ -0,0 +1,86 @@
+/*
+ * Fichier: adaptive_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du test de Miller-Rabin adaptatif.
+ */
+package primes
+
+import (
+	"crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"testing/iotest"
+)
+
+// TestMillerRabinPolicy vérifie que les ensembles de bases réduits restent exacts, y compris sur les
+// pseudo-premiers forts qui bornent chacun d'eux, et le nombre de tours au-delà de 64 bits.
+func TestMillerRabinPolicy(t *testing.T) {
+	var policy MillerRabinPolicy
+	for n := int64(-2); n < 100000; n++ {
+		if got := policy.IsPrime(n); got != IsPrimeMillerRabin64(n) {
+			t.Fatalf("IsPrime(%d) = %v, IsPrimeMillerRabin64 = %v", n, got, !got)
+		}
+	}
+	for _, b := range deterministicBounds {
+		n := int64(b.below)
+		if policy.IsPrime(n) || len(policy.Bases(n)) <= b.bases || len(policy.Bases(n-1)) != b.bases {
+			t.Errorf("ψ = %d: IsPrime %v, %d bases (en deçà: %d), attendu composé et plus de %d bases",
+				n, policy.IsPrime(n), len(policy.Bases(n)), len(policy.Bases(n-1)), b.bases)
+		}
+	}
+	for _, n := range []int64{2147483647, 1_000_000_007, 9_223_372_036_854_775_783} {
+		if !policy.IsPrime(n) {
+			t.Errorf("IsPrime(%d) = false, attendu premier", n)
+		}
+	}
+	if got := len(policy.Bases(math.MaxInt64)); got != len(millerRabinBases) {
+		t.Errorf("Bases(MaxInt64): %d bases, attendu %d", got, len(millerRabinBases))
+	}
+
+	for _, tc := range []struct {
+		bound  float64
+		rounds int
+	}{{0, 50}, {1e-30, 50}, {0.25, 1}, {0.5, 1}, {1.0 / 65536, 8}} {
+		if got := (MillerRabinPolicy{ErrorBound: tc.bound}).Rounds(); got != tc.rounds {
+			t.Errorf("Rounds(%g) = %d, attendu %d", tc.bound, got, tc.rounds)
+		}
+	}
+	mersenne89 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 89), big.NewInt(1))
+	if !policy.IsPrimeBig(mersenne89) || policy.IsPrimeBig(new(big.Int).Add(mersenne89, big.NewInt(2))) {
+		t.Error("IsPrimeBig: 2^89 - 1 est premier, 2^89 + 1 est composé")
+	}
+	for _, bound := range []float64{-1, 1, math.NaN()} {
+		if err := (MillerRabinPolicy{ErrorBound: bound}).Validate(); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Validate(%g): erreur %v, attendu ErrInvalidOptions", bound, err)
+		}
+	}
+}
+
+// TestMillerRabinPolicyRand valide les bases tirées d'une source fournie au-delà de 64 bits.
+func TestMillerRabinPolicyRand(t *testing.T) {
+	policy := MillerRabinPolicy{Rand: rand.Reader}
+	one := big.NewInt(1)
+	m89 := new(big.Int).Sub(new(big.Int).Lsh(one, 89), one) // Nombre premier de Mersenne.
+	m67 := new(big.Int).Sub(new(big.Int).Lsh(one, 67), one) // 193707721 · 761838257287.
+	square := new(big.Int).Mul(m89, m89)
+	for _, tc := range []struct {
+		n    *big.Int
+		want bool
+	}{{m89, true}, {m67, false}, {square, false}, {new(big.Int).Lsh(one, 70), false}, {big.NewInt(97), true}} {
+		if got := policy.IsPrimeBig(tc.n); got != tc.want {
+			t.Errorf("IsPrimeBig(%v) = %v, attendu %v", tc.n, got, tc.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("source en erreur: panique attendue")
+		}
+	}()
+	MillerRabinPolicy{Rand: iotest.ErrReader(errors.New("épuisée"))}.IsPrimeBig(m89)
+}
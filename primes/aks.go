@@ -0,0 +1,213 @@
+/*
+ * Fichier: aks.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Test de primalité AKS (Agrawal, Kayal et Saxena, 2002), premier test
+ * déterministe de complexité polynomiale prouvée. Implémentation pédagogique:
+ * elle suit l'énoncé de l'article (puissance parfaite, plus petit r tel que
+ * ord_r(n) > log²n, PGCD avec a <= r, puis identités (X + a)^n ≡ X^n + a
+ * modulo (X^r - 1, n)) et reste très lente en pratique (de l'ordre de 0,1 s
+ * pour un nombre premier proche de 10^4, contre moins d'une microseconde pour
+ * Miller-Rabin): à réserver à la comparaison des algorithmes sur de petites
+ * entrées.
+ */
+package primes
+
+import (
+	"math"
+	"math/bits"
+)
+
+// IsPrimeAKS indique si n est premier par le test AKS. Le résultat est exact, mais le temps de
+// calcul croît comme une puissance élevée de log n: réservé aux petites entrées.
+func IsPrimeAKS(n int64) bool {
+	if n < 2 {
+		return false
+	}
+	// Étape 1: une puissance parfaite a^b (b > 1) est composée.
+	if isPerfectPower(n) {
+		return false
+	}
+
+	// Étape 2: plus petit r tel que l'ordre de n modulo r dépasse log²n.
+	logN := math.Log2(float64(n))
+	maxK := int64(logN * logN)
+	r := int64(2)
+	for ; ; r++ {
+		if gcd(uint64(r), uint64(n)) == 1 && multiplicativeOrderExceeds(n, r, maxK) {
+			break
+		}
+	}
+
+	// Étape 3: un facteur commun avec un a <= r prouve que n est composé.
+	for a := int64(2); a <= min(r, n-1); a++ {
+		if g := gcd(uint64(a), uint64(n)); g > 1 && g < uint64(n) {
+			return false
+		}
+	}
+	// Étape 4: sans facteur <= r, n <= r est premier.
+	if n <= r {
+		return true
+	}
+
+	// Étape 5: (X + a)^n ≡ X^n + a modulo (X^r - 1, n) pour a <= √φ(r)·log n.
+	bound := int64(math.Sqrt(float64(eulerPhi(r))) * logN)
+	ring := aksRing{n: uint64(n), r: int(r)}
+	for a := int64(1); a <= bound; a++ {
+		if !ring.identityHolds(uint64(a)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPerfectPower indique si n = a^b pour des entiers a >= 2 et b >= 2.
+func isPerfectPower(n int64) bool {
+	for b := 2; b < bits.Len64(uint64(n)); b++ {
+		root := iroot(n, b)
+		for _, a := range []int64{root - 1, root, root + 1} {
+			if a >= 2 && exactPow(a, b) == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exactPow retourne a^b, ou -1 si le résultat dépasse un int64.
+func exactPow(a int64, b int) int64 {
+	result := uint64(1)
+	for range b {
+		hi, lo := bits.Mul64(result, uint64(a))
+		if hi != 0 || lo > math.MaxInt64 {
+			return -1
+		}
+		result = lo
+	}
+	return int64(result)
+}
+
+// multiplicativeOrderExceeds indique si l'ordre de n modulo r (premiers entre eux) dépasse maxK.
+func multiplicativeOrderExceeds(n, r, maxK int64) bool {
+	x, base := int64(1), n%r
+	for k := int64(1); k <= maxK; k++ {
+		x = x * base % r
+		if x == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// eulerPhi retourne l'indicatrice d'Euler de r, par division successive.
+func eulerPhi(r int64) int64 {
+	phi := r
+	for p := int64(2); p*p <= r; p++ {
+		if r%p == 0 {
+			for r%p == 0 {
+				r /= p
+			}
+			phi -= phi / p
+		}
+	}
+	if r > 1 {
+		phi -= phi / r
+	}
+	return phi
+}
+
+// aksRing est l'anneau (Z/nZ)[X]/(X^r - 1); un polynôme y est représenté par ses r coefficients.
+type aksRing struct {
+	n uint64
+	r int
+}
+
+// square retourne f² dans l'anneau. Les produits croisés f_i·f_j (i < j) ne sont calculés qu'une
+// fois; ils sont accumulés sur 128 bits et réduits modulo n seulement lorsque l'accumulateur
+// approche de la saturation.
+func (m aksRing) square(f []uint64) []uint64 {
+	hiAcc := make([]uint64, m.r)
+	loAcc := make([]uint64, m.r)
+	add := func(k int, x, y uint64) {
+		hi, lo := bits.Mul64(x, y)
+		var carry uint64
+		loAcc[k], carry = bits.Add64(loAcc[k], lo, 0)
+		hiAcc[k] += hi + carry
+		if hiAcc[k] >= 1<<62 {
+			hiAcc[k], loAcc[k] = 0, bits.Rem64(hiAcc[k], loAcc[k], m.n)
+		}
+	}
+	for i, fi := range f {
+		if fi == 0 {
+			continue
+		}
+		add((2*i)%m.r, fi, fi)
+		var double uint64 // 2·f_i mod n.
+		if fi >= m.n-fi {
+			double = fi - (m.n - fi)
+		} else {
+			double = 2 * fi
+		}
+		for j := i + 1; j < m.r; j++ {
+			if f[j] != 0 {
+				k := i + j
+				if k >= m.r {
+					k -= m.r
+				}
+				add(k, double, f[j])
+			}
+		}
+	}
+	for k := range loAcc {
+		loAcc[k] = bits.Rem64(hiAcc[k], loAcc[k], m.n)
+	}
+	return loAcc
+}
+
+// mulLinear retourne f·(X + a) dans l'anneau, en O(r).
+func (m aksRing) mulLinear(f []uint64, a uint64) []uint64 {
+	g := make([]uint64, m.r)
+	for i, fi := range f {
+		hi, lo := bits.Mul64(fi, a)
+		g[i] = bits.Rem64(hi, lo, m.n)
+	}
+	for i, fi := range f {
+		k := i + 1
+		if k == m.r {
+			k = 0
+		}
+		if g[k] >= m.n-fi {
+			g[k] -= m.n - fi
+		} else {
+			g[k] += fi
+		}
+	}
+	return g
+}
+
+// identityHolds vérifie (X + a)^n ≡ X^(n mod r) + a dans l'anneau, par exponentiation rapide de
+// gauche à droite: chaque bit de n élève au carré puis, s'il vaut 1, multiplie par X + a.
+func (m aksRing) identityHolds(a uint64) bool {
+	a %= m.n
+	result := make([]uint64, m.r)
+	result[0] = 1
+	for i := bits.Len64(m.n) - 1; i >= 0; i-- {
+		result = m.square(result)
+		if m.n>>uint(i)&1 == 1 {
+			result = m.mulLinear(result, a)
+		}
+	}
+
+	expected := make([]uint64, m.r)
+	expected[0] = a
+	k := int(m.n % uint64(m.r))
+	expected[k] = (expected[k] + 1) % m.n
+	for i := range result {
+		if result[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
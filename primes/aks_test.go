@@ -0,0 +1,43 @@
+/*
+ * Fichier: aks_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du test de primalité AKS.
+ */
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+// TestIsPrimeAKS confronte AKS à la division successive sur de petites entrées (le test est lent)
+// et vérifie la détection des puissances parfaites, y compris près de la limite des int64.
+func TestIsPrimeAKS(t *testing.T) {
+	for n := int64(-2); n < 400; n++ {
+		if got := IsPrimeAKS(n); got != IsPrimeTrialDivision(n) {
+			t.Fatalf("IsPrimeAKS(%d) = %v", n, got)
+		}
+	}
+	// 561 et 1105 sont des nombres de Carmichael; 1009 et 1013 sont premiers.
+	for _, n := range []int64{561, 1105, 1007, 1009, 1013, 1024} {
+		if got := IsPrimeAKS(n); got != IsPrimeTrialDivision(n) {
+			t.Errorf("IsPrimeAKS(%d) = %v", n, got)
+		}
+	}
+
+	for _, tc := range []struct {
+		n     int64
+		power bool
+	}{
+		{4, true}, {27, true}, {1 << 62, true}, {3037000499 * 3037000499, true},
+		{3909821048582988049, true}, // 7^22
+		{6, false}, {1<<62 + 1, false}, {math.MaxInt64, false},
+	} {
+		if got := isPerfectPower(tc.n); got != tc.power {
+			t.Errorf("isPerfectPower(%d) = %v, attendu %v", tc.n, got, tc.power)
+		}
+	}
+}
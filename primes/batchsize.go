@@ -0,0 +1,117 @@
+/*
+ * Fichier: batchsize.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Taille des lots adaptative (Options.BatchTarget): les workers mesurent la
+ * durée de chaque lot, et le producteur ajuste la taille des lots suivants
+ * pour que leur durée reste dans une bande autour d'une cible. Les candidats
+ * grossissent vers la fin de l'énumération (p et q plus grands) et leur test
+ * coûte de plus en plus cher: des lots de taille fixe passent de trop petits
+ * (coût des canaux dominant) à trop gros (workers déséquilibrés en fin de
+ * recherche, réactivité de Control et de la progression dégradée).
+ */
+package primes
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Bornes de la taille des lots adaptative.
+const (
+	minAdaptiveBatchSize = 1
+	maxAdaptiveBatchSize = 1 << 16
+)
+
+// maxBatchGrowth borne la croissance de la taille par ajustement: les premiers lots, formés des
+// plus petits candidats, sous-estiment le coût des suivants.
+const maxBatchGrowth = 4
+
+// batchCostSmoothing est le poids d'une nouvelle mesure dans la moyenne mobile exponentielle du
+// coût d'une paire: les lots isolés, plus lents (préemption, ramasse-miettes), sont lissés.
+const batchCostSmoothing = 0.25
+
+// batchSizer ajuste la taille des lots d'une recherche. La durée attendue d'un lot (coût moyen
+// d'une paire multiplié par la taille courante) est comparée à la bande [target/2, 2·target]: en
+// dehors, la taille est recalculée pour viser target, avec une croissance bornée à maxBatchGrowth.
+// Les workers rapportent leurs lots (observe) et le producteur lit la taille courante (size); un
+// batchSizer nil garde la taille fixe.
+type batchSizer struct {
+	target  time.Duration
+	current atomic.Int64
+	resizes atomic.Int64
+
+	mu      sync.Mutex
+	cost    float64 // Coût moyen lissé d'une paire, en nanosecondes (0: aucune mesure).
+	minSeen int     // Plus petite et plus grande taille utilisées.
+	maxSeen int
+}
+
+// newBatchSizer retourne l'ajustement vers target à partir de lots de initial paires; nil si
+// target est nul (taille fixe).
+func newBatchSizer(initial int, target time.Duration) *batchSizer {
+	if target <= 0 {
+		return nil
+	}
+	initial = min(max(initial, minAdaptiveBatchSize), maxAdaptiveBatchSize)
+	s := &batchSizer{target: target, minSeen: initial, maxSeen: initial}
+	s.current.Store(int64(initial))
+	return s
+}
+
+// size retourne la taille des prochains lots, fallback pour un batchSizer nil.
+func (s *batchSizer) size(fallback int) int {
+	if s == nil {
+		return fallback
+	}
+	return int(s.current.Load())
+}
+
+// observe enregistre un lot de pairs paires traité en busy, et recalcule la taille si la durée
+// attendue d'un lot sort de la bande. Appelée par les workers, en concurrence.
+func (s *batchSizer) observe(pairs int, busy time.Duration) {
+	if s == nil || pairs == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	perPair := float64(busy) / float64(pairs)
+	if s.cost == 0 {
+		s.cost = perPair
+	} else {
+		s.cost += batchCostSmoothing * (perPair - s.cost)
+	}
+	size := int(s.current.Load())
+	expected := time.Duration(s.cost * float64(size))
+	if expected >= s.target/2 && expected <= 2*s.target {
+		return
+	}
+	next := int(float64(s.target) / max(s.cost, 1))
+	next = min(max(next, minAdaptiveBatchSize), maxAdaptiveBatchSize, size*maxBatchGrowth)
+	if next == size {
+		return
+	}
+	s.current.Store(int64(next))
+	s.resizes.Add(1)
+	s.minSeen, s.maxSeen = min(s.minSeen, next), max(s.maxSeen, next)
+}
+
+// stats retourne l'état de l'ajustement, pour la progression.
+func (s *batchSizer) stats() BatchStats {
+	if s == nil {
+		return BatchStats{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return BatchStats{Size: int(s.current.Load()), Min: s.minSeen, Max: s.maxSeen, Resizes: s.resizes.Load()}
+}
+
+// BatchStats décrit la taille des lots adaptative (Options.BatchTarget) dans la progression.
+type BatchStats struct {
+	Size     int   // Taille courante des lots (0: taille fixe, pas d'ajustement).
+	Min, Max int   // Plus petite et plus grande taille utilisées.
+	Resizes  int64 // Nombre d'ajustements.
+}
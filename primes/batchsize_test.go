@@ -0,0 +1,73 @@
+/*
+ * Fichier: batchsize_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la taille des lots adaptative (batchSizer, Options.BatchTarget).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestBatchSizer vérifie l'ajustement sur des durées simulées: croissance vers la cible pour des
+// paires bon marché, stabilité dans la bande, réduction quand le coût des paires augmente.
+func TestBatchSizer(t *testing.T) {
+	if s := newBatchSizer(64, 0); s != nil || s.size(64) != 64 || s.stats() != (BatchStats{}) {
+		t.Fatalf("newBatchSizer(64, 0) = %v, attendu nil (taille fixe)", s)
+	}
+	s := newBatchSizer(64, time.Millisecond)
+	s.observe(64, 64*time.Microsecond) // 1 µs par paire: 1000 paires pour 1 ms, croissance bornée.
+	if got := s.size(0); got != 64*maxBatchGrowth {
+		t.Fatalf("après un lot de 1 µs par paire: taille %d, attendu %d", got, 64*maxBatchGrowth)
+	}
+	s.observe(256, 256*time.Microsecond)
+	if got := s.size(0); got != 1000 {
+		t.Fatalf("après un lot de 1 µs par paire: taille %d, attendu 1000", got)
+	}
+	for range 10 {
+		s.observe(1000, 1500*time.Microsecond) // 1,5 ms: dans la bande [0,5 ms, 2 ms].
+	}
+	if got := s.size(0); got != 1000 {
+		t.Errorf("dans la bande: taille %d, attendu 1000 inchangée", got)
+	}
+	for range 20 {
+		s.observe(s.size(0), time.Duration(s.size(0))*10*time.Microsecond) // 10 µs par paire.
+	}
+	got := s.stats()
+	if got.Size < 50 || got.Size > 200 || got.Min > got.Size || got.Max != 1000 || got.Resizes < 2 {
+		t.Errorf("après des paires de 10 µs: %+v, attendu une durée de lot dans la bande (50 à 200 paires)", got)
+	}
+
+	s = newBatchSizer(maxAdaptiveBatchSize/2, time.Hour)
+	s.observe(10, 10*time.Nanosecond)
+	if got := s.size(0); got != maxAdaptiveBatchSize {
+		t.Errorf("taille %d, attendu bornée à %d", got, maxAdaptiveBatchSize)
+	}
+}
+
+// TestSearchBatchTarget vérifie qu'une recherche à lots adaptatifs trouve les mêmes résultats et
+// décrit ses lots dans la progression, et que la durée visée négative est refusée.
+func TestSearchBatchTarget(t *testing.T) {
+	primeList := SieveOfEratosthenes(300)
+	want := 0
+	Search(context.Background(), Options{Primes: primeList, Workers: 2}, func(Result) error { want++; return nil })
+
+	var final Progress
+	got := 0
+	opts := Options{Primes: primeList, Workers: 2, BatchSize: 1, BatchTarget: 200 * time.Microsecond, OnProgress: func(p Progress) { final = p }}
+	if err := Search(context.Background(), opts, func(Result) error { got++; return nil }); err != nil || got != want {
+		t.Fatalf("Search(BatchTarget) = %v, %d résultats, attendu %d", err, got, want)
+	}
+	if b := final.Batches; b.Size < 1 || b.Min > b.Size || b.Max < b.Size || b.Resizes < 1 {
+		t.Errorf("Progress.Batches = %+v, attendu des lots ajustés depuis 1 paire", b)
+	}
+	if _, err := NewOptions(WithBatchTarget(-time.Second)); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("WithBatchTarget(-1s): %v, attendu ErrInvalidOptions", err)
+	}
+}
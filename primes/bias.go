@@ -0,0 +1,58 @@
+/*
+ * Fichier: bias.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Biais de Tchebychev: répartition des nombres premiers du crible entre les
+ * classes de résidus d'un module m, et « course » entre deux classes a et b
+ * (classiquement 3 mod 4 contre 1 mod 4): à chaque nombre premier x, la classe
+ * en tête est celle qui compte le plus de nombres premiers <= x. La classe des
+ * non-résidus quadratiques mène presque tout le temps; les premiers
+ * changements de tête sont rares et tardifs (26861 pour m = 4).
+ */
+package primes
+
+import "fmt"
+
+// BiasReport résume la répartition des nombres premiers par classe et la course entre deux classes.
+type BiasReport struct {
+	Modulus int   // Module m.
+	Counts  []int // Counts[r]: nombres premiers ≡ r mod m.
+	A, B    int   // Classes comparées.
+
+	ALeads, BLeads, Ties int // Nombres premiers x après lesquels a mène, b mène, ou égalité.
+	FirstBLead           int // Plus petit nombre premier x après lequel b mène (0: jamais).
+	MaxGap               int // Plus grande avance de a sur b, π(x; m, a) - π(x; m, b).
+	MaxGapAt             int // Plus petit x où MaxGap est atteint.
+}
+
+// ChebyshevBias compte les nombres premiers de primeList (croissante) par classe modulo modulus
+// et fait la course entre les classes a et b, qui doivent être distinctes et premières avec modulus.
+func ChebyshevBias(primeList []int, modulus, a, b int) (BiasReport, error) {
+	switch {
+	case modulus < 3:
+		return BiasReport{}, fmt.Errorf("%w: module %d (attendu >= 3)", ErrInvalidOptions, modulus)
+	case a < 0 || a >= modulus || b < 0 || b >= modulus || a == b || gcd(uint64(a), uint64(modulus)) != 1 || gcd(uint64(b), uint64(modulus)) != 1:
+		return BiasReport{}, fmt.Errorf("%w: classes %d et %d mod %d (attendu deux classes distinctes premières avec le module)", ErrInvalidOptions, a, b, modulus)
+	}
+	report := BiasReport{Modulus: modulus, Counts: make([]int, modulus), A: a, B: b}
+	for _, x := range primeList {
+		report.Counts[x%modulus]++
+		switch gap := report.Counts[a] - report.Counts[b]; {
+		case gap > 0:
+			report.ALeads++
+			if gap > report.MaxGap {
+				report.MaxGap, report.MaxGapAt = gap, x
+			}
+		case gap < 0:
+			report.BLeads++
+			if report.FirstBLead == 0 {
+				report.FirstBLead = x
+			}
+		default:
+			report.Ties++
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,38 @@
+/*
+ * Fichier: bias_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du biais de Tchebychev.
+ */
+package primes
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestChebyshevBias valide les comptes et le premier changement de tête connu (26861 pour 1 mod 4).
+func TestChebyshevBias(t *testing.T) {
+	report, err := ChebyshevBias(SieveOfEratosthenes(30000), 4, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := report.ALeads + report.BLeads + report.Ties
+	if report.Counts[2] != 1 || report.Counts[1]+report.Counts[2]+report.Counts[3] != 3245 || total != 3245 {
+		t.Errorf("comptes %v sur %d nombres premiers, attendu 3245 (π(30000))", report.Counts, total)
+	}
+	if report.FirstBLead != 26861 {
+		t.Errorf("première avance de 1 mod 4 en %d, attendu 26861", report.FirstBLead)
+	}
+	if report.BLeads == 0 || report.ALeads < 10*report.BLeads || report.MaxGap <= 0 || report.MaxGapAt == 0 {
+		t.Errorf("course %+v: 3 mod 4 devrait mener presque toujours", report)
+	}
+
+	for _, bad := range [][3]int{{2, 1, 1}, {4, 1, 1}, {4, 2, 1}, {4, 1, 5}, {10, 5, 3}} {
+		if _, err := ChebyshevBias(nil, bad[0], bad[1], bad[2]); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("ChebyshevBias(mod %d, %d, %d): erreur %v, attendu ErrInvalidOptions", bad[0], bad[1], bad[2], err)
+		}
+	}
+}
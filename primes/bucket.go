@@ -0,0 +1,99 @@
+/*
+ * Fichier: bucket.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Crible par seaux (« bucket sieve ») pour les grandes limites: l'intervalle
+ * est criblé par segments de la taille du cache L1. Les nombres premiers plus
+ * petits qu'un segment le marquent à chaque passage (markMultiples), à partir
+ * d'un décalage mémorisé; les plus grands, qui touchent au plus une case par
+ * segment, attendent dans le seau du prochain segment qu'ils touchent. Chaque
+ * segment reste dans le cache pendant tout son marquage, au lieu de parcourir
+ * un tableau de la taille de la limite une fois par nombre premier.
+ */
+package primes
+
+import "context"
+
+// bucketSegmentSize est le nombre d'entiers par segment (cache de données L1 courant: 32 Kio).
+const bucketSegmentSize = 1 << 15
+
+// bucketSieveThreshold est la limite à partir de laquelle SieveOfEratosthenesContext
+// utilise le crible par seaux plutôt que le tableau de marquage complet.
+const bucketSieveThreshold = 1 << 24
+
+// bucketEntry est un grand nombre premier en attente, avec la position de son prochain
+// multiple dans le segment de son seau.
+type bucketEntry struct {
+	prime  uint32
+	offset uint32
+}
+
+// bucketSieve retourne les nombres premiers jusqu'à limit par crible par seaux avec des segments
+// de segmentSize entiers, ou ctx.Err() si ctx est annulé.
+func bucketSieve(ctx context.Context, limit, segmentSize int) ([]int, error) {
+	if limit < 2 {
+		return nil, ctx.Err()
+	}
+	sqrtLimit := int(isqrt(int64(limit)))
+	base := SieveOfEratosthenes(sqrtLimit)
+
+	// Petits nombres premiers: prochain multiple à marquer, en position absolue.
+	small := 0
+	for small < len(base) && base[small] < segmentSize {
+		small++
+	}
+	next := make([]int, small)
+	for i, p := range base[:small] {
+		next[i] = p * p
+	}
+
+	// Grands nombres premiers: un seau par segment, en anneau. Un nombre premier p entre dans
+	// le seau du segment de p² quand ce segment est atteint; son multiple suivant n'est jamais
+	// plus de p <= √limit plus loin, d'où la taille de l'anneau.
+	buckets := make([][]bucketEntry, sqrtLimit/segmentSize+2)
+	pending := small // Prochain grand nombre premier à placer dans un seau.
+
+	primeList := make([]int, 0, EstimatePrimeCount(limit))
+	marker := make([]bool, segmentSize)
+	for low := 0; low <= limit; low += segmentSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		segment := marker[:min(segmentSize, limit-low+1)]
+		clear(segment)
+		high := low + len(segment) - 1
+		for i, p := range base[:small] {
+			if next[i] > high {
+				continue
+			}
+			markMultiples(segment, next[i]-low, p)
+			next[i] += (high - next[i] + p) / p * p
+		}
+
+		for ; pending < len(base) && base[pending]*base[pending] <= high; pending++ {
+			p := base[pending]
+			seg := p * p / segmentSize
+			ring := seg % len(buckets)
+			buckets[ring] = append(buckets[ring], bucketEntry{uint32(p), uint32(p*p - seg*segmentSize)})
+		}
+		ring := low / segmentSize % len(buckets)
+		for _, e := range buckets[ring] {
+			segment[e.offset] = true
+			if m := low + int(e.offset) + int(e.prime); m <= limit {
+				seg := m / segmentSize
+				target := seg % len(buckets)
+				buckets[target] = append(buckets[target], bucketEntry{e.prime, uint32(m - seg*segmentSize)})
+			}
+		}
+		buckets[ring] = buckets[ring][:0]
+
+		for i, composite := range segment {
+			if !composite && low+i >= 2 {
+				primeList = append(primeList, low+i)
+			}
+		}
+	}
+	return primeList, nil
+}
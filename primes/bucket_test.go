@@ -0,0 +1,44 @@
+/*
+ * Fichier: bucket_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests d'équivalence du crible par seaux avec le crible complet.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestBucketSieve compare le crible par seaux au crible complet. De petits segments font passer
+// la plupart des nombres premiers de base par les seaux, ce qu'une limite de test ne permettrait
+// pas avec la taille réelle (il faudrait √limit >= 32768).
+func TestBucketSieve(t *testing.T) {
+	for _, segmentSize := range []int{16, 64, 1000, bucketSegmentSize} {
+		for _, limit := range []int{0, 1, 2, 3, 15, 16, 17, 255, 256, 257, 4096, 99_991, 250_000} {
+			got, err := bucketSieve(context.Background(), limit, segmentSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := SieveOfEratosthenes(limit); !slices.Equal(got, expected) {
+				t.Fatalf("bucketSieve(%d, segments de %d): %d nombres premiers, attendu %d", limit, segmentSize, len(got), len(expected))
+			}
+		}
+	}
+
+	// Au-delà du seuil, SieveOfEratosthenes passe par le crible par seaux: π(2^24) = 1077871.
+	if got := len(SieveOfEratosthenes(bucketSieveThreshold)); got != 1077871 {
+		t.Errorf("π(2^24) = %d, attendu 1077871", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := bucketSieve(ctx, 1_000_000, 1024); !errors.Is(err, context.Canceled) {
+		t.Errorf("contexte annulé: erreur %v", err)
+	}
+}
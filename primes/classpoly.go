@@ -0,0 +1,286 @@
+/*
+ * Fichier: classpoly.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Polynômes de classes de Hilbert des discriminants fondamentaux de nombre
+ * de classes 1 à 4, utilisés par le prouveur ECPP (ecpp.go), et recherche de
+ * leurs racines modulo un premier (Cantor-Zassenhaus). Les coefficients ont
+ * été obtenus en développant l'invariant j aux points des formes réduites de
+ * discriminant D (séries d'Eisenstein en précision de 320 chiffres) puis en
+ * arrondissant le produit des X - j(τ); TestCMDiscriminants les contrôle en
+ * vérifiant l'ordre des courbes obtenues modulo des premiers de test.
+ */
+package primes
+
+import (
+	"math/big"
+	"math/rand/v2"
+)
+
+// cmDiscriminants liste les discriminants fondamentaux D de nombre de classes h <= 4 avec leur
+// polynôme de classes de Hilbert H_D(X) = X^h + c[h-1]·X^(h-1) + ... + c[0], dont les racines
+// sont les invariants j des courbes à multiplication complexe par D. Les coefficients, trop
+// grands pour un int64 dès h = 2, sont donnés en décimal.
+var cmDiscriminants = []struct {
+	d int64
+	c []string
+}{
+	// h = 1
+	{-3, []string{"0"}},
+	{-4, []string{"-1728"}},
+	{-7, []string{"3375"}},
+	{-8, []string{"-8000"}},
+	{-11, []string{"32768"}},
+	{-19, []string{"884736"}},
+	{-43, []string{"884736000"}},
+	{-67, []string{"147197952000"}},
+	{-163, []string{"262537412640768000"}},
+	// h = 2
+	{-15, []string{"-121287375", "191025"}},
+	{-20, []string{"-681472000", "-1264000"}},
+	{-24, []string{"14670139392", "-4834944"}},
+	{-35, []string{"-134217728000", "117964800"}},
+	{-40, []string{"9103145472000", "-425692800"}},
+	{-51, []string{"6262062317568", "5541101568"}},
+	{-52, []string{"-567663552000000", "-6896880000"}},
+	{-88, []string{"15798135578688000000", "-6294842640000"}},
+	{-91, []string{"-3845689020776448", "10359073013760"}},
+	{-115, []string{"130231327260672000", "427864611225600"}},
+	{-123, []string{"148809594175488000000", "1354146840576000"}},
+	{-148, []string{"-7898242515936467904000000", "-39660183801072000"}},
+	{-187, []string{"-3845689020776448000000", "4545336381788160000"}},
+	{-232, []string{"14871070713157137145512000000000", "-604729957849891344000"}},
+	{-235, []string{"11946621170462723407872000", "823177419449425920000"}},
+	{-267, []string{"531429662672621376897024000000", "19683091854079488000000"}},
+	{-403, []string{"-108844203402491055833088000000", "2452811389229331391979520000"}},
+	{-427, []string{"155041756222618916546936832000000", "15611455512523783919812608000"}},
+	// h = 3
+	{-23, []string{"12771880859375", "-5151296875", "3491750"}},
+	{-31, []string{"1566028350940383", "-58682638134", "39491307"}},
+	{-59, []string{"374643194001883136", "-140811576541184", "30197678080"}},
+	{-83, []string{"549755813888000000000", "-41490055168000000", "2691907584000"}},
+	{-107, []string{"337618789203968000000000", "-6764523159552000000", "129783279616000"}},
+	{-139, []string{"67408489017571610198016", "-53041786755137667072", "12183160834031616"}},
+	{-211, []string{"5310823021408898698117644288", "277390576406111100862464", "65873587288630099968"}},
+	{-283, []string{"201371843156955365376000000000", "90839236535446929408000000", "89611323386832801792000"}},
+	{-307, []string{"8987619631060626702336000000000", "-5083646425734146162688000000", "805016812009981390848000"}},
+	{-331, []string{"56176242840389398230218488594563072", "368729929041040103875232661504", "6647404730173793386463232"}},
+	{-379, []string{"15443600047689011948024601807415148544", "-121567791009880876719538528321536", "364395404104624239018246144"}},
+	{-499, []string{"4671133182399954782798673154437441310949376", "-6063717825494266394722392560011051008", "3005101108071026200706725969920"}},
+	{-547, []string{"83303937570678403968635240448000000000", "-139712328431787827943469744128000000", "81297395539631654721637478400000"}},
+	{-643, []string{"308052554652302847380880841299197952000000000", "-6300378505047247876499651797450752000000", "39545575162726134099492467011584000"}},
+	{-883, []string{"167990285381627318187575520800123387904000000000", "-151960111125245282033875619529124478976000000", "34903934341011819039224295011933392896000"}},
+	{-907, []string{"149161274746524841328545894969274007552000000000", "39181594208014819617565811575376314368000000", "123072080721198402394477590506838687744000"}},
+	// h = 4
+	{-39, []string{"20919104368024767633", "109873509788637459", "-429878960946", "331531596"}},
+	{-55, []string{"-18577989025032784359375", "172576736359017890625", "-20948398473375", "13136684625"}},
+	{-56, []string{"10064086044321563803648", "2257767342088912896", "2059647197077504", "-16220384512"}},
+	{-68, []string{"-2089297506304000000000000", "-318507038720000000000", "-75843692160000000", "-178211040000"}},
+	{-84, []string{"-5133201653210986057826304", "88821246589810089394176", "-5663679223085309952", "-3196800946944"}},
+	{-120, []string{"4934510722321469030006784000000", "-2588458316335175909376000000", "26329406807264910336000", "-883067971104000"}},
+	{-132, []string{"1656636925108948992000000000000", "54984539729717250048000000000", "-325211610485778048000000", "-4736863498464000"}},
+	{-136, []string{"2422829169428572504087521656832", "-1834607111282472051029311488", "735960027609078992953344", "-8151279336430848"}},
+	{-155, []string{"37425860028464856284790784000000", "20396251654725321097216000000", "-44477871096357453824000", "96905542950912000"}},
+	{-168, []string{"496644064976895846912000000000000000", "-264691184105480095991808000000000", "336511679671210230144000000", "-483435712076832000"}},
+	{-184, []string{"114574710497270997578522590458150912", "38705419208160503264676104110080", "5767007465145198439020847104", "-3215890895076912384"}},
+	{-195, []string{"-233490285492432753672585216000000", "104773100319600336175104000000", "25349140792043819237376000", "11284411506057216000"}},
+	{-203, []string{"31913605837856413057024000000000000", "250634002097696556449792000000000", "-83053272156952592384000000", "27502410406723584000"}},
+	{-219, []string{"110979720274963942538198675506593792", "-15979705448736682450562851012608", "831039118453558669939310592", "155212323706544357376"}},
+	{-228, []string{"120020259495560805847424176128000000000000", "58827548670433207062445836288000000000", "-7985216535621460489954944000000", "-399605224650084576000"}},
+	{-259, []string{"4384296738486457527093398159228928", "5493320206929896679139197321216", "-368189472100537894019530752", "9068999694311625523200"}},
+	{-280, []string{"1775168961518724506399346503073398784000000", "-708555761206745670461365038563328000000", "17602516524144666384420962098176000", "-67667966893419063840000"}},
+	{-291, []string{"21782000952710117887925312635418808680448", "285389231946718842181542553187254272", "10786588141336392324590050738176", "188155567079341753466880"}},
+	{-292, []string{"-380259461042512404779990642688000000000000", "45521551386379385369629968384000000000", "-93693622511929038759497066112000000", "-206287709860428304608000"}},
+	{-312, []string{"1698899690981885675579246225669492736000000000000", "-152340504750882110373595179663329280000000000", "1411168483733488619338991640960000000", "-1258031100283439093280000"}},
+	{-323, []string{"-121974636783103604190112617857024000000000000", "73804562114102168041788801024000000000000", "-494846073292941121091010560000000", "3317765887009185280000000"}},
+	{-328, []string{"88955608603044673650138130944000000000000000", "54802167111836784369290132453376000000000", "11610744584144462730131436503424000000", "-5127512346913614444576000"}},
+	{-340, []string{"43039377624755967291385639037347037184000000", "5906485031594874833231597894020684185600000", "-54548817402421378465247510316573696000", "-14383245771217510630675200"}},
+	{-355, []string{"167490001660588917859010199158784000000", "-24013762453779394698078584832000000", "6828932041616339922516443136000", "50912008581334742581248000"}},
+	{-372, []string{"41393149892607462736698558825033501904896000000000000", "1755509254864401819594526832548625909760000000000", "-2969541010382978868435960918595200000000", "-206603714804587147622880000"}},
+	{-388, []string{"-1121692648948590091501551223636881408000000000000", "208224136957169320201407896480139264000000000", "-20542159225989612130996373047535232000000", "-750062398364686994581728000"}},
+	{-408, []string{"13375974716483932888129605820405217248677888000000000000", "-334918514756463762318006309600841904719872000000000", "218066148024051247931306674050097536000000", "-3622859125108878497350176000"}},
+	{-435, []string{"-12512019875237835915942574589201734434816000000", "42866222697779107335351550466659555737600000", "87465379468169320817492479772196864000", "28597298728131202056826060800"}},
+	{-483, []string{"-296241507936739247491345278560108544000000000000", "160587932046974848398336021151875072000000000", "9557426544972522152310585774047232000000", "966618711103413979025620992000"}},
+	{-520, []string{"171517475891022372428505519185548559222346497654784000000", "-78006534528871949845908360976579586206001479680000000", "46650003139146307922421888174845453223975936000", "-12958889442406058296422344736000"}},
+	{-532, []string{"-19077542993352945680961028994697271308288000000000000", "5131537740610192962070880163006969643272192000000000", "-160054212938390343773833947283393690785408000000", "-29478909019098139074177479136000"}},
+	{-555, []string{"-532755731205331063356397364951543957176713216000000", "19282254568556435196991625190065063388512256000000", "7191013406366483381037450688276469907456000", "138859536630220704987259502592000"}},
+	{-568, []string{"17903747548118085544966894162888109264474112000000000000", "-20244861194040338252021384794239225557256192000000000", "5960215994584814927107650154330552605647232000000", "-328731508303364809994652861984000"}},
+	{-595, []string{"-91399742601830803813322386656934773129216000000", "483054636550112292687021684688517332992000000", "8752111455147508300981595950899265536000", "1908606683491595666107623383040000"}},
+	{-627, []string{"-1261687189208313891495979730091871567872000000000000", "526326624169690832922357632213666758656000000000", "3563858169242172480409901737583233204224000000", "14586137722924213400310156521472000"}},
+	{-667, []string{"-278701754438991300992352387072000000000000000", "-147087485221823269890900432519168000000000", "-3737847346141410401145461932032000000", "172524940705544715709707399634944000"}},
+	{-708, []string{"4046686423378034814414234559373865948538701215210194862739456000000000000", "3603887011528002652771717224491220641587422892784070051840000000000", "-2854565250565963840094617979015298078098347812480000000", "-2012303924332635494819557244440800000"}},
+	{-715, []string{"13189879204176058896562640516998642620432384000000", "94657547256854352451418607502680693669888000000", "60156378344564221943954774472086041657344000", "3038922093329613647424771157499904000"}},
+	{-723, []string{"43799003445375960815587788104700084092928000000000000000", "-17437817166277457429521660531780027831812096000000000", "8222450770908698023546828197247145547399168000000", "4855690107103225136120718536060928000"}},
+	{-760, []string{"57390991709103678336339431944416743303984993656228540622045184000000", "-8762694788548498478760416933120597566268079681131589510758400000", "262960509575258849119050573504013616920976671774792704000", "-41045008988631123111685822548134227200"}},
+	{-763, []string{"1212202634617724845661254714392576000000000000000", "3730143008151395358758986101112700928000000000", "11764579526453656222964578511153528832000000", "48688224497542950284157258615128064000"}},
+	{-772, []string{"-4039979678479578220330132982722340932044073244946432000000000000", "-1654219429424921222911088262751088404746562249930752000000000", "-730409189972766569984362477406681962614314316392064000000", "-81104350841312411963776730201270496000"}},
+	{-795, []string{"1580866394929445594613317271657673734190830966521462784000000", "96989374802114211792220362019627433906928110027145216000000", "1962512368737475150054890329369747830206508302336000", "294853904675299611949375562546552832000"}},
+	{-955, []string{"438953058221654415262613188100773336407392447044238966784000000", "520207875218635547684744626511303352924946915393536000000", "396469707692607651662987973604670339150203846656000", "1456880094856940116294718071366713311232000"}},
+	{-1003, []string{"15040125689821293744115482557611348328448000000000000", "1305202673705533598197444367081354312024064000000000", "-204493994631228266186213761658603748458496000000", "16219528503217062422459730048347378577408000"}},
+	{-1012, []string{"-204344290478354698106731378125784194576718103432833630334976000000000000", "1243508019466325039942928040075544459231301705571821435488256000000000", "-127409933077387882483393397275452601210672521773684542789248000000", "-25320300665394312513202440887044222231008000"}},
+	{-1027, []string{"271046093357449955035386983426329999080765259776000000000000", "164592522336657395778809121398601659935044403200000000000", "-41547404176734721779832688271619104304005120000000", "52960452958968182770743647384658280611840000"}},
+	{-1227, []string{"2513550635275580846572126510888944466103176257943640211456000000000000000", "89252949391959745426288430543461455160950172376987709997056000000000", "5282646588767618158994396140387285593346806931114470408192000000", "619638890847298092963653036606353098743021568000"}},
+	{-1243, []string{"30540293156908205255120060127032312602199851008000000000000", "-54665859623503521460552388837431864605259333632000000000", "26521598516319200744664388741019144869638045696000000", "1266871605300222128375795939246750405038301184000"}},
+	{-1387, []string{"2052723014407052457647477199445183281503328534528000000000000", "36686449491372953371348633076156538920834099576832000000000", "8421392423043512311845823062070841518683467022336000000", "649705640341533249055461232040056199884943609856000"}},
+	{-1411, []string{"259898672030231371072634921991495299708204070671565541211890306346215538688", "563159340355333157360236566159729674583227014555157757651685811744145408", "19568314960219288785284224576189610670941760495017044362657792", "1780126746705689756102562231651060896708610079948800"}},
+	{-1435, []string{"-4009811510734177961140258455491639109235283276327883374802990268416000000", "2539970793779946148723473087883821948343643667859030533390886502400000", "105161502065491843193116493512870644772977750388041962225664000", "4835907878329132222450395857259654466718969836339200"}},
+	{-1507, []string{"946755971011460406830147750660957594274801022094278656000000000000", "-131730022847167071512725748945146741381253488296591360000000000", "3693591679022156272138192761442250249057420055674880000000", "92304656744815388412175046838197961483773831208960000"}},
+	{-1555, []string{"179277385817055839939036171839607344168985308854293076933328502784000000", "-153802169705179237851782806689208034512876098346328765182771200000000", "36521008026523717023567141651588968008073653196177682701746176000", "634043412248649501919536531936002831564519413161984000"}},
+}
+
+// cmPoly renvoie le polynôme unitaire de coefficients c réduit modulo n, du terme constant au
+// terme dominant.
+func cmPoly(n *big.Int, c []string) []*big.Int {
+	f := make([]*big.Int, len(c)+1)
+	for i, s := range c {
+		f[i], _ = new(big.Int).SetString(s, 10)
+		f[i].Mod(f[i], n)
+	}
+	f[len(c)] = big.NewInt(1)
+	return f
+}
+
+// cmInvariant renvoie une racine modulo le premier n du polynôme de classes de Hilbert de
+// coefficients c, ou nil s'il n'en a pas (ou si la recherche échoue, n étant alors composé).
+func cmInvariant(n *big.Int, c []string, rng *rand.Rand) *big.Int {
+	f := cmPoly(n, c)
+	// Seule la partie de f scindée en facteurs linéaires nous intéresse: pgcd(f, X^n - X).
+	xn := polyPowMod([]*big.Int{new(big.Int), big.NewInt(1)}, n, f, n)
+	f = polyGCD(f, polySub(xn, []*big.Int{new(big.Int), big.NewInt(1)}, n), n)
+	for tries := 0; len(f) > 3 && tries < 64; tries++ {
+		// Cantor-Zassenhaus: pgcd(f, (X + δ)^((n-1)/2) - 1) sépare les racines r selon que r + δ
+		// est un carré ou non.
+		delta := new(big.Int).SetUint64(rng.Uint64())
+		e := new(big.Int).Rsh(n, 1)
+		g := polyPowMod([]*big.Int{delta.Mod(delta, n), big.NewInt(1)}, e, f, n)
+		g = polyGCD(f, polySub(g, []*big.Int{big.NewInt(1)}, n), n)
+		if len(g) > 1 && len(g) < len(f) {
+			if 2*len(g) > len(f)+1 {
+				g = polyDivExact(f, g, n)
+			}
+			f = g
+		}
+	}
+	switch len(f) {
+	case 2:
+		// f1·X + f0: X = -f0/f1.
+		r := new(big.Int).ModInverse(f[1], n)
+		if r == nil {
+			return nil
+		}
+		r.Mul(r, f[0]).Neg(r)
+		return r.Mod(r, n)
+	case 3:
+		// f2·X² + f1·X + f0: X = (-f1 + √(f1² - 4f0f2)) / 2f2.
+		disc := new(big.Int).Mul(f[1], f[1])
+		disc.Sub(disc, new(big.Int).Lsh(new(big.Int).Mul(f[0], f[2]), 2)).Mod(disc, n)
+		s := new(big.Int).ModSqrt(disc, n)
+		den := new(big.Int).ModInverse(new(big.Int).Lsh(f[2], 1), n)
+		if s == nil || den == nil {
+			return nil
+		}
+		s.Sub(s, f[1]).Mul(s, den)
+		return s.Mod(s, n)
+	}
+	return nil
+}
+
+// polyTrim retire les coefficients dominants nuls; le polynôme nul est la tranche vide.
+func polyTrim(a []*big.Int) []*big.Int {
+	for len(a) > 0 && a[len(a)-1].Sign() == 0 {
+		a = a[:len(a)-1]
+	}
+	return a
+}
+
+// polySub renvoie a - b modulo n.
+func polySub(a, b []*big.Int, n *big.Int) []*big.Int {
+	r := make([]*big.Int, max(len(a), len(b)))
+	for i := range r {
+		r[i] = new(big.Int)
+		if i < len(a) {
+			r[i].Set(a[i])
+		}
+		if i < len(b) {
+			r[i].Sub(r[i], b[i])
+		}
+		r[i].Mod(r[i], n)
+	}
+	return polyTrim(r)
+}
+
+// polyDivMod renvoie le quotient et le reste de la division de a par b (b non nul) modulo le
+// premier n.
+func polyDivMod(a, b []*big.Int, n *big.Int) (quo, rem []*big.Int) {
+	rem = make([]*big.Int, len(a))
+	for i, c := range a {
+		rem[i] = new(big.Int).Set(c)
+	}
+	inv := new(big.Int).ModInverse(b[len(b)-1], n)
+	if inv == nil {
+		inv = new(big.Int)
+	}
+	quo = make([]*big.Int, max(len(a)-len(b)+1, 0))
+	for i := len(quo) - 1; i >= 0; i-- {
+		c := new(big.Int).Mul(rem[i+len(b)-1], inv)
+		c.Mod(c, n)
+		quo[i] = c
+		for j, bj := range b {
+			t := new(big.Int).Mul(c, bj)
+			rem[i+j].Sub(rem[i+j], t).Mod(rem[i+j], n)
+		}
+	}
+	return polyTrim(quo), polyTrim(rem[:min(len(rem), len(b)-1)])
+}
+
+// polyDivExact renvoie a/b lorsque b divise a.
+func polyDivExact(a, b []*big.Int, n *big.Int) []*big.Int {
+	q, _ := polyDivMod(a, b, n)
+	return q
+}
+
+// polyMulMod renvoie a·b mod f modulo n.
+func polyMulMod(a, b, f []*big.Int, n *big.Int) []*big.Int {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	r := make([]*big.Int, len(a)+len(b)-1)
+	for i := range r {
+		r[i] = new(big.Int)
+	}
+	t := new(big.Int)
+	for i, ai := range a {
+		for j, bj := range b {
+			r[i+j].Add(r[i+j], t.Mul(ai, bj))
+		}
+	}
+	for _, c := range r {
+		c.Mod(c, n)
+	}
+	_, rem := polyDivMod(polyTrim(r), f, n)
+	return rem
+}
+
+// polyPowMod renvoie a^e mod f modulo n.
+func polyPowMod(a []*big.Int, e *big.Int, f []*big.Int, n *big.Int) []*big.Int {
+	_, a = polyDivMod(a, f, n)
+	r := []*big.Int{big.NewInt(1)}
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = polyMulMod(r, r, f, n)
+		if e.Bit(i) == 1 {
+			r = polyMulMod(r, a, f, n)
+		}
+	}
+	return r
+}
+
+// polyGCD renvoie un pgcd de a et b modulo le premier n.
+func polyGCD(a, b []*big.Int, n *big.Int) []*big.Int {
+	for len(b) > 0 {
+		_, r := polyDivMod(a, b, n)
+		a, b = b, r
+	}
+	return a
+}
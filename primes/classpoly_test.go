@@ -0,0 +1,74 @@
+/*
+ * Fichier: classpoly_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des polynômes de classes de Hilbert: chaque discriminant de la table
+ * doit donner, modulo un premier représenté par sa forme principale, une
+ * courbe dont l'ordre est l'un de ceux prédits par Cornacchia.
+ */
+package primes
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// TestCMDiscriminants contrôle les coefficients de cmDiscriminants: une racine j de H_D modulo
+// p doit donner des courbes d'ordre p + 1 - t pour une trace t de cmTraces.
+func TestCMDiscriminants(t *testing.T) {
+	s := &ecppSearch{rng: rand.New(rand.NewPCG(1, 2))}
+	for _, cm := range cmDiscriminants {
+		p := new(big.Int).Lsh(big.NewInt(1), 80)
+		var u, v *big.Int
+		for ok := false; !ok; {
+			for p.Add(p, big.NewInt(1)); !p.ProbablyPrime(0); p.Add(p, big.NewInt(1)) {
+			}
+			u, v, ok = cornacchia(p, cm.d)
+		}
+		j := cmInvariant(p, cm.c, s.rng)
+		if j == nil {
+			t.Errorf("D = %d: H_D sans racine modulo %v", cm.d, p)
+			continue
+		}
+		for range 4 {
+			e := s.curve(p, cm.d, j)
+			pt, ok := s.point(e)
+			if e == nil || !ok {
+				t.Fatalf("D = %d: pas de point modulo %v", cm.d, p)
+			}
+			found := false
+			for _, tr := range cmTraces(u, v, cm.d) {
+				m := new(big.Int).Add(p, big.NewInt(1))
+				if r, err := e.mul(m.Sub(m, tr), pt); err == nil && r.inf {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("D = %d: courbe d'invariant %v d'ordre imprévu modulo %v", cm.d, j, p)
+				break
+			}
+		}
+	}
+}
+
+// TestCornacchia vérifie 4p = u² + |D|·v² sur quelques premiers.
+func TestCornacchia(t *testing.T) {
+	for _, p := range []int64{1000003, 1000033, 1000037, 1000039} {
+		bp := big.NewInt(p)
+		for _, cm := range cmDiscriminants {
+			u, v, ok := cornacchia(bp, cm.d)
+			if !ok {
+				continue
+			}
+			sum := new(big.Int).Mul(v, v)
+			sum.Mul(sum, big.NewInt(-cm.d)).Add(sum, new(big.Int).Mul(u, u))
+			if sum.Cmp(big.NewInt(4*p)) != 0 {
+				t.Errorf("cornacchia(%d, %d) = (%v, %v): u² + |D|v² = %v", p, cm.d, u, v, sum)
+			}
+		}
+	}
+}
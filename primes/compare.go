@@ -0,0 +1,116 @@
+/*
+ * Fichier: compare.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Comparaison de tests de primalité sur un même flux de candidats: chaque
+ * candidat passe par tous les tests, leurs verdicts sont confrontés et leur
+ * temps mesuré. Un contrôle croisé de correction et un banc d'essai en un seul
+ * passage, sur les candidats réels d'une recherche plutôt que sur un jeu de
+ * données artificiel.
+ */
+package primes
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MaxDisagreementExamples est le nombre de désaccords conservés en exemple par une Comparison.
+const MaxDisagreementExamples = 10
+
+// Disagreement est un candidat sur lequel les tests comparés divergent.
+type Disagreement struct {
+	N        int64
+	Verdicts []bool // Verdict de chaque test, dans l'ordre de la comparaison.
+}
+
+// ComparisonStats est le bilan d'un test comparé.
+type ComparisonStats struct {
+	Name  string
+	Calls int64         // Candidats testés.
+	Time  time.Duration // Temps cumulé sur tous les workers.
+}
+
+// comparisonCounters sont les compteurs d'un test, mis à jour par plusieurs workers.
+type comparisonCounters struct {
+	calls atomic.Int64
+	nanos atomic.Int64
+}
+
+// Comparison fait passer chaque candidat par plusieurs tests de primalité (voir IsPrime).
+type Comparison struct {
+	names    []string
+	tests    []func(int64) bool
+	counters []comparisonCounters
+
+	disagreements atomic.Int64
+	mu            sync.Mutex
+	examples      []Disagreement
+}
+
+// NewComparison prépare la comparaison des tests nommés (au moins deux, distincts, parmi
+// PrimalityTestNames). Le premier fait référence: son verdict est celui que retourne IsPrime.
+func NewComparison(names []string) (*Comparison, error) {
+	if len(names) < 2 {
+		return nil, fmt.Errorf("%w: comparaison de %v (au moins deux tests attendus)", ErrInvalidOptions, names)
+	}
+	c := &Comparison{names: slices.Clone(names), counters: make([]comparisonCounters, len(names))}
+	for i, name := range names {
+		if !slices.Contains(primalityTests, name) {
+			return nil, fmt.Errorf("%w: test de primalité %q (attendu l'un de %v)", ErrInvalidOptions, name, primalityTests)
+		}
+		if slices.Contains(names[:i], name) {
+			return nil, fmt.Errorf("%w: test %q comparé deux fois", ErrInvalidOptions, name)
+		}
+		c.tests = append(c.tests, PrimalityTest(name))
+	}
+	return c, nil
+}
+
+// IsPrime applique tous les tests à n, en mesurant chacun, et retourne le verdict du premier.
+// Un désaccord est compté et, parmi les MaxDisagreementExamples premiers, conservé.
+func (c *Comparison) IsPrime(n int64) bool {
+	verdicts := make([]bool, len(c.tests))
+	agree := true
+	for i, test := range c.tests {
+		start := time.Now()
+		verdicts[i] = test(n)
+		c.counters[i].nanos.Add(int64(time.Since(start)))
+		c.counters[i].calls.Add(1)
+		agree = agree && verdicts[i] == verdicts[0]
+	}
+	if !agree {
+		c.disagreements.Add(1)
+		c.mu.Lock()
+		if len(c.examples) < MaxDisagreementExamples {
+			c.examples = append(c.examples, Disagreement{N: n, Verdicts: verdicts})
+		}
+		c.mu.Unlock()
+	}
+	return verdicts[0]
+}
+
+// Names retourne les noms des tests comparés, dans l'ordre de la comparaison.
+func (c *Comparison) Names() []string { return slices.Clone(c.names) }
+
+// Stats retourne le bilan de chaque test, dans l'ordre de la comparaison.
+func (c *Comparison) Stats() []ComparisonStats {
+	stats := make([]ComparisonStats, len(c.names))
+	for i, name := range c.names {
+		stats[i] = ComparisonStats{Name: name, Calls: c.counters[i].calls.Load(), Time: time.Duration(c.counters[i].nanos.Load())}
+	}
+	return stats
+}
+
+// Disagreements retourne le nombre de candidats sur lesquels les tests divergent, et les
+// premiers d'entre eux.
+func (c *Comparison) Disagreements() (int64, []Disagreement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.disagreements.Load(), slices.Clone(c.examples)
+}
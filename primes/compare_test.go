@@ -0,0 +1,70 @@
+/*
+ * Fichier: compare_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la comparaison de tests de primalité.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestComparisonSearch vérifie que des tests exacts s'accordent sur les candidats d'une
+// recherche, que chacun voit tous les candidats et que les résultats sont inchangés.
+func TestComparisonSearch(t *testing.T) {
+	c, err := NewComparison([]string{"trial", "miller", "bpsw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compared, reference []int64
+	collect := func(dst *[]int64) func(Result) error {
+		return func(r Result) error { *dst = append(*dst, r.N); return nil }
+	}
+	if err := Search(context.Background(), Options{Limit: 200, Workers: 2, PrimeTestFunc: c.IsPrime}, collect(&compared)); err != nil {
+		t.Fatal(err)
+	}
+	if err := Search(context.Background(), Options{Limit: 200, Workers: 2}, collect(&reference)); err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(compared)
+	slices.Sort(reference)
+	if !slices.Equal(compared, reference) {
+		t.Errorf("%d résultats avec la comparaison, %d sans", len(compared), len(reference))
+	}
+	if n, _ := c.Disagreements(); n != 0 {
+		t.Errorf("%d désaccords entre tests exacts", n)
+	}
+	stats := c.Stats()
+	for _, s := range stats {
+		if s.Calls == 0 || s.Calls != stats[0].Calls {
+			t.Errorf("%s: %d candidats, attendu %d (> 0)", s.Name, s.Calls, stats[0].Calls)
+		}
+	}
+}
+
+// TestComparisonDisagreement vérifie qu'un pseudo-premier de Lucas fort est relevé comme désaccord.
+func TestComparisonDisagreement(t *testing.T) {
+	c, err := NewComparison([]string{"trial", "lucas"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.IsPrime(5459) || !c.IsPrime(5471) {
+		t.Error("IsPrime ne retourne pas le verdict de la division successive")
+	}
+	n, examples := c.Disagreements()
+	if n != 1 || len(examples) != 1 || examples[0].N != 5459 || !slices.Equal(examples[0].Verdicts, []bool{false, true}) {
+		t.Errorf("désaccords = %d, %+v; attendu 5459 [false true]", n, examples)
+	}
+
+	for _, names := range [][]string{{"miller"}, {"miller", "inconnu"}, {"miller", "miller"}} {
+		if _, err := NewComparison(names); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("NewComparison(%v) = %v, attendu ErrInvalidOptions", names, err)
+		}
+	}
+}
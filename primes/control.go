@@ -0,0 +1,189 @@
+/*
+ * Fichier: control.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Contrôle d'une recherche en cours: suspension, reprise et arrêt de la
+ * distribution des tâches, limitation du nombre de tâches en attente et
+ * bridage de l'utilisation CPU des workers.
+ * Les workers terminent les tâches déjà distribuées puis restent inactifs
+ * tant que la recherche est suspendue.
+ */
+package primes
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// throttlePollInterval est l'intervalle de vérification de la file lorsqu'elle a atteint sa limite.
+	throttlePollInterval = time.Millisecond
+	// minPaceSleep est la veille minimale du bridage CPU: les veilles plus courtes sont cumulées,
+	// car la granularité de l'ordonnanceur les allongerait et fausserait le taux visé.
+	minPaceSleep = 5 * time.Millisecond
+)
+
+// Control pilote la distribution des tâches d'une recherche. La valeur zéro n'est pas utilisable:
+// utiliser NewControl. Toutes les méthodes peuvent être appelées depuis n'importe quelle goroutine.
+type Control struct {
+	// active vaut true tant que la recherche n'est ni suspendue ni arrêtée (chemin rapide sans verrou).
+	active atomic.Bool
+	// queueLimit borne le nombre de tâches en attente dans le canal (0: capacité du canal).
+	queueLimit atomic.Int64
+	// cpuPercent est la part de temps CPU visée par worker (0 ou 100: pas de bridage).
+	cpuPercent atomic.Int64
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	paused  bool
+	stopped bool
+}
+
+// NewControl crée un contrôleur dans l'état "en cours".
+func NewControl() *Control {
+	c := &Control{}
+	c.cond = sync.NewCond(&c.mu)
+	c.active.Store(true)
+	return c
+}
+
+// Pause suspend la distribution de nouvelles tâches.
+func (c *Control) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.stopped {
+		c.paused = true
+		c.active.Store(false)
+	}
+}
+
+// Resume reprend la distribution des tâches après une pause.
+func (c *Control) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.stopped {
+		c.paused = false
+		c.active.Store(true)
+		c.cond.Broadcast()
+	}
+}
+
+// Stop arrête définitivement la distribution des tâches. La recherche se termine
+// après le traitement des tâches déjà distribuées.
+func (c *Control) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	c.paused = false
+	c.active.Store(false)
+	c.cond.Broadcast()
+}
+
+// Paused indique si la recherche est actuellement suspendue.
+func (c *Control) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// Stopped indique si la recherche a été arrêtée.
+func (c *Control) Stopped() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopped
+}
+
+// wait bloque tant que la recherche est suspendue. Retourne false si elle a été arrêtée
+// ou si ctx a été annulé.
+func (c *Control) wait(ctx context.Context) bool {
+	if c.active.Load() {
+		return true
+	}
+	// L'annulation de ctx réveille l'attente (le verrou garantit que le réveil n'est pas perdu).
+	stop := context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.cond.Broadcast()
+	})
+	defer stop()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.paused && !c.stopped && ctx.Err() == nil {
+		c.cond.Wait()
+	}
+	return !c.stopped && ctx.Err() == nil
+}
+
+// SetQueueLimit borne le nombre de tâches en attente de traitement (0 ou moins: pas de limite
+// au-delà de la capacité du canal). Permet de réduire l'empreinte mémoire en cours de recherche.
+func (c *Control) SetQueueLimit(n int) {
+	c.queueLimit.Store(int64(max(n, 0)))
+}
+
+// QueueLimit retourne la limite courante du nombre de tâches en attente (0: aucune).
+func (c *Control) QueueLimit() int {
+	return int(c.queueLimit.Load())
+}
+
+// throttle bloque tant que le nombre de tâches en attente, donné par queued, atteint la limite,
+// ou jusqu'à l'annulation de ctx.
+func (c *Control) throttle(ctx context.Context, queued func() int) {
+	for ctx.Err() == nil {
+		limit := c.queueLimit.Load()
+		if limit <= 0 || int64(queued()) < limit {
+			return
+		}
+		time.Sleep(throttlePollInterval)
+	}
+}
+
+// SetCPUPercent limite chaque worker à environ percent % d'un cœur: après chaque lot, le worker
+// se met en veille proportionnellement au temps de calcul du lot. 100 (ou plus) désactive le bridage.
+func (c *Control) SetCPUPercent(percent int) {
+	c.cpuPercent.Store(int64(min(max(percent, 1), 100)))
+}
+
+// CPUPercent retourne la part de temps CPU visée par worker (100: pas de bridage).
+func (c *Control) CPUPercent() int {
+	if p := c.cpuPercent.Load(); p > 0 {
+		return int(p)
+	}
+	return 100
+}
+
+// pacer applique le bridage CPU d'un worker. Chaque worker possède le sien.
+type pacer struct {
+	ctl  *Control
+	owed time.Duration // Veille due et pas encore effectuée.
+}
+
+// pace comptabilise un lot ayant nécessité busy de calcul et met le worker en veille dès que la
+// veille due atteint minPaceSleep, de sorte que la fraction de temps active corresponde à CPUPercent.
+// Le dépassement éventuel de la veille est déduit des veilles suivantes; l'annulation de ctx
+// l'interrompt.
+func (p *pacer) pace(ctx context.Context, busy time.Duration) {
+	if p.ctl == nil {
+		return
+	}
+	percent := p.ctl.cpuPercent.Load()
+	if percent <= 0 || percent >= 100 {
+		p.owed = 0
+		return
+	}
+	p.owed += busy * time.Duration(100-percent) / time.Duration(percent)
+	if p.owed < minPaceSleep {
+		return
+	}
+	start := time.Now()
+	timer := time.NewTimer(p.owed)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	p.owed -= time.Since(start)
+}
@@ -0,0 +1,146 @@
+/*
+ * Fichier: control_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la suspension, de la reprise et de l'arrêt d'une recherche.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestControlStop valide qu'une recherche arrêtée avant son début ne teste aucune paire.
+func TestControlStop(t *testing.T) {
+	ctl := NewControl()
+	ctl.Stop()
+
+	var last Progress
+	count := 0
+	opts := Options{Primes: SieveOfEratosthenes(100), Workers: 2, BatchSize: 1, Control: ctl, OnProgress: func(pr Progress) { last = pr }}
+	if err := Search(context.Background(), opts, func(Result) error { count++; return nil }); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if count != 0 || last.Tested != 0 {
+		t.Errorf("recherche arrêtée: %d résultats, %d paires testées, attendu 0", count, last.Tested)
+	}
+	if !ctl.Stopped() {
+		t.Errorf("Stopped() = false après Stop()")
+	}
+}
+
+// TestControlPauseResume valide qu'une recherche suspendue n'avance plus puis se termine après reprise.
+func TestControlPauseResume(t *testing.T) {
+	ctl := NewControl()
+	ctl.Pause()
+	if !ctl.Paused() {
+		t.Fatalf("Paused() = false après Pause()")
+	}
+
+	primeList := SieveOfEratosthenes(50)
+	total := int64(len(primeList) * len(primeList))
+	done := make(chan Progress)
+	go func() {
+		var last Progress
+		opts := Options{Primes: primeList, Workers: 2, BatchSize: 1, Control: ctl, OnProgress: func(pr Progress) { last = pr }}
+		Search(context.Background(), opts, func(Result) error { return nil })
+		done <- last
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("la recherche s'est terminée alors qu'elle était suspendue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	ctl.Resume()
+	select {
+	case last := <-done:
+		if last.Tested != total {
+			t.Errorf("paires testées = %d, attendu %d", last.Tested, total)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("la recherche ne s'est pas terminée après Resume()")
+	}
+}
+
+// TestControlQueueLimit valide qu'une file limitée à une tâche n'empêche pas la recherche d'aboutir.
+func TestControlQueueLimit(t *testing.T) {
+	ctl := NewControl()
+	ctl.SetQueueLimit(1)
+	if ctl.QueueLimit() != 1 {
+		t.Fatalf("QueueLimit() = %d, attendu 1", ctl.QueueLimit())
+	}
+
+	primeList := SieveOfEratosthenes(30)
+	var last Progress
+	opts := Options{Primes: primeList, Workers: 2, BatchSize: 1, Control: ctl, OnProgress: func(pr Progress) { last = pr }}
+	Search(context.Background(), opts, func(Result) error { return nil })
+	if want := int64(len(primeList) * len(primeList)); last.Tested != want {
+		t.Errorf("paires testées = %d, attendu %d", last.Tested, want)
+	}
+
+	ctl.SetQueueLimit(-5)
+	if ctl.QueueLimit() != 0 {
+		t.Errorf("QueueLimit() = %d après une limite négative, attendu 0", ctl.QueueLimit())
+	}
+}
+
+// TestControlCPUPercent valide les bornes du bridage CPU et la durée de veille associée.
+func TestControlCPUPercent(t *testing.T) {
+	ctl := NewControl()
+	if ctl.CPUPercent() != 100 {
+		t.Errorf("CPUPercent() par défaut = %d, attendu 100", ctl.CPUPercent())
+	}
+	ctl.SetCPUPercent(0)
+	if ctl.CPUPercent() != 1 {
+		t.Errorf("CPUPercent() après SetCPUPercent(0) = %d, attendu 1", ctl.CPUPercent())
+	}
+	ctl.SetCPUPercent(250)
+	if ctl.CPUPercent() != 100 {
+		t.Errorf("CPUPercent() après SetCPUPercent(250) = %d, attendu 100", ctl.CPUPercent())
+	}
+
+	// À 50 %, les lots courts sont cumulés puis 20 ms de calcul donnent environ 20 ms de veille.
+	ctl.SetCPUPercent(50)
+	p := &pacer{ctl: ctl}
+	start := time.Now()
+	p.pace(context.Background(), time.Millisecond)
+	if p.owed != time.Millisecond || time.Since(start) >= minPaceSleep {
+		t.Errorf("veille courte non cumulée: due %s", p.owed)
+	}
+	p.pace(context.Background(), 19*time.Millisecond)
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("veille de %s, attendu au moins 20ms", elapsed)
+	}
+	if p.owed > 0 {
+		t.Errorf("veille due après la veille = %s, attendu <= 0", p.owed)
+	}
+}
+
+// TestControlPausedContext valide qu'une recherche suspendue se termine à l'annulation de son contexte.
+func TestControlPausedContext(t *testing.T) {
+	ctl := NewControl()
+	ctl.Pause()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Search(ctx, Options{Limit: 100, Workers: 2, Control: ctl}, func(Result) error { return nil })
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Search() = %v, attendu context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("la recherche suspendue ne s'est pas terminée après l'annulation du contexte")
+	}
+}
@@ -0,0 +1,337 @@
+/*
+ * Fichier: count.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Fonction de compte des nombres premiers π(x) sans énumération complète,
+ * par l'algorithme de Lagarias, Miller et Odlyzko (LMO), dérivé de la
+ * formule de Meissel et Lehmer. La récursion de Legendre sur φ(x, a) est
+ * tronquée aux entiers n ≤ y = α·x^⅓: les feuilles ordinaires s'évaluent
+ * par une table de φ pour les six premiers nombres premiers (périodique de
+ * période 30030), les feuilles spéciales par un crible segmenté jusqu'à x/y
+ * qui compte les entiers restants à chaque étape. Le coût est de l'ordre
+ * de x^⅔ opérations (π(10^13) en moins d'une seconde, π(10^15) en une
+ * quinzaine de secondes sur un cœur) et la mémoire de l'ordre de √x.
+ */
+package primes
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+)
+
+// directCountLimit est la borne sous laquelle π(x) est compté par le crible complet.
+const directCountLimit = 1 << 20
+
+// phiSmallPrimes est le nombre de nombres premiers couverts par la table de φ.
+const phiSmallPrimes = 6
+
+// phiTables retourne, pour 1 ≤ c ≤ phiSmallPrimes, la table t[c][n] = φ(n, c) sur une période
+// n < p_1·...·p_c, le produit des c premiers nombres premiers.
+var phiTables = sync.OnceValue(func() [][]uint16 {
+	smallPrimes := []int{2, 3, 5, 7, 11, 13}
+	tables := make([][]uint16, phiSmallPrimes+1)
+	period := 1
+	for c := 1; c <= phiSmallPrimes; c++ {
+		period *= smallPrimes[c-1]
+		t := make([]uint16, period)
+		for n := 1; n < period; n++ {
+			t[n] = t[n-1] + 1
+			for _, p := range smallPrimes[:c] {
+				if n%p == 0 {
+					t[n]--
+					break
+				}
+			}
+		}
+		tables[c] = t
+	}
+	return tables
+})
+
+// phiSmall retourne φ(x, c), le nombre d'entiers de [1, x] sans facteur premier parmi les c
+// premiers nombres premiers, pour 1 ≤ c ≤ phiSmallPrimes: φ(x, c) = (x/P)·φ(P, c) + φ(x mod P, c)
+// avec P le produit de ces nombres premiers.
+func phiSmall(x int64, c int) int64 {
+	t := phiTables()[c]
+	period := int64(len(t))
+	// φ(P, c) = φ(P-1, c): P n'est pas premier avec lui-même.
+	return x/period*int64(t[period-1]) + int64(t[x%period])
+}
+
+// isqrt retourne la partie entière de √n.
+func isqrt(n int64) int64 {
+	r := int64(math.Sqrt(float64(n)))
+	for r*r > n {
+		r--
+	}
+	for (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
+
+// iroot retourne la partie entière de la racine k-ième de n.
+func iroot(n int64, k int) int64 {
+	r := int64(math.Pow(float64(n), 1/float64(k)))
+	pow := func(v int64) float64 { return math.Pow(float64(v), float64(k)) }
+	for r > 0 && pow(r) > float64(n) {
+		r--
+	}
+	for pow(r+1) <= float64(n) {
+		r++
+	}
+	return r
+}
+
+// PrimeCount retourne π(x), le nombre de nombres premiers inférieurs ou égaux à x.
+func PrimeCount(x int64) int64 {
+	if x < directCountLimit {
+		return int64(len(SieveOfEratosthenes(int(max(x, 0)))))
+	}
+	// y = α·x^⅓: un α plus grand réduit le crible des feuilles spéciales (jusqu'à x/y) au prix
+	// de feuilles ordinaires plus nombreuses; α croît avec x, sans que y n'atteigne √x.
+	alpha := max(1, math.Pow(math.Log(float64(x)), 3)/2000)
+	y := min(int64(alpha*float64(iroot(x, 3))), isqrt(x)-1)
+	return primeCountLMO(x, max(y, iroot(x, 3)))
+}
+
+// primeCountLMO évalue π(x) par l'algorithme LMO avec la borne de troncature y, entre x^⅓ et √x:
+//
+//	π(x) = φ(x, a) + a - 1 - P2(x, a),   a = π(y)
+//
+// où P2(x, a) compte les entiers de [1, x] produits de deux nombres premiers supérieurs à p_a
+// (y ≥ x^⅓ exclut ceux de trois facteurs), et φ(x, a) est la somme des feuilles ordinaires
+// (phiOrdinaryLeaves) et spéciales (phiSpecialLeaves).
+func primeCountLMO(x, y int64) int64 {
+	list := SieveOfEratosthenes(int(y))
+	primes := make([]int64, len(list)+1) // primes[i] = p_i; primes[0] inutilisé.
+	for i, p := range list {
+		primes[i+1] = int64(p)
+	}
+	a := int64(len(list))
+	c := min(phiSmallPrimes, int(a))
+
+	// Plus petit facteur premier et fonction de Möbius des entiers de [1, y].
+	lpf := make([]int32, y+1)
+	mu := make([]int8, y+1)
+	for i := range mu {
+		mu[i] = 1
+	}
+	for _, p := range list {
+		for m := p; m <= int(y); m += p {
+			if lpf[m] == 0 {
+				lpf[m] = int32(p)
+			}
+			mu[m] = -mu[m]
+		}
+		for m := p * p; m <= int(y); m += p * p {
+			mu[m] = 0
+		}
+	}
+	lpf[1] = math.MaxInt32
+	pi := make([]int32, y+1) // pi[n] = π(n).
+	for i, n := 0, int64(0); n <= y; n++ {
+		if i < len(list) && int64(list[i]) == n {
+			i++
+		}
+		pi[n] = int32(i)
+	}
+
+	phi := phiOrdinaryLeaves(x, y, primes, c, lpf, mu) + phiSpecialLeaves(x, y, primes, pi, c, lpf, mu)
+	return phi + a - 1 - primePairs(x, y, a)
+}
+
+// phiOrdinaryLeaves retourne la somme des feuilles ordinaires de φ(x, π(y)): μ(n)·φ(x/n, c)
+// pour les entiers sans facteur carré n ≤ y dont le plus petit facteur premier dépasse p_c.
+func phiOrdinaryLeaves(x, y int64, primes []int64, c int, lpf []int32, mu []int8) int64 {
+	var sum int64
+	for n := int64(1); n <= y; n++ {
+		if mu[n] != 0 && int64(lpf[n]) > primes[c] {
+			sum += int64(mu[n]) * phiSmall(x/n, c)
+		}
+	}
+	return sum
+}
+
+// leafSieve est un segment [low, low+2·len) du crible des feuilles spéciales, réduit aux
+// entiers impairs (2 est toujours parmi les c premiers nombres premiers): le bit i de words
+// représente low+2i (low impair), à 1 s'il n'a pas encore été rayé. blocks compte les bits à
+// 1 par bloc de leafBlockWords mots, total ceux du segment, pour compter les entiers restants
+// jusqu'à une position sans parcourir tout le segment.
+type leafSieve struct {
+	low    int64
+	words  []uint64
+	blocks []int32
+	total  int64
+}
+
+const (
+	leafSegmentWords = 1 << 12 // 2^18 entiers impairs par segment.
+	leafBlockWords   = 8
+)
+
+// reset prépare le segment qui commence à low (impair) et s'arrête avant high, chaque entier
+// impair non rayé.
+func (s *leafSieve) reset(low, high int64) {
+	s.low = low
+	n := (high - low + 1) / 2 // Entiers impairs de [low, high).
+	s.words = s.words[:(n+63)/64]
+	for i := range s.words {
+		s.words[i] = math.MaxUint64
+	}
+	if n%64 != 0 {
+		s.words[len(s.words)-1] = 1<<(n%64) - 1
+	}
+	s.total = 0
+	s.blocks = s.blocks[:(len(s.words)+leafBlockWords-1)/leafBlockWords]
+	for k := range s.blocks {
+		count := 0
+		for _, w := range s.words[k*leafBlockWords : min(len(s.words), (k+1)*leafBlockWords)] {
+			count += bits.OnesCount64(w)
+		}
+		s.blocks[k] = int32(count)
+		s.total += int64(count)
+	}
+}
+
+// crossOff raye les multiples impairs de p (impair) du segment, p compris.
+func (s *leafSieve) crossOff(p int64) {
+	high := s.low + 2*int64(len(s.words))*64
+	m := (s.low + p - 1) / p * p
+	if m%2 == 0 {
+		m += p
+	}
+	for ; m < high; m += 2 * p {
+		i := (m - s.low) / 2
+		if w, bit := i/64, uint64(1)<<(i%64); s.words[w]&bit != 0 {
+			s.words[w] &^= bit
+			s.blocks[w/leafBlockWords]--
+			s.total--
+		}
+	}
+}
+
+// leafCursor compte les entiers restants d'un segment jusqu'à des positions croissantes, en
+// cumulant les blocs déjà dépassés.
+type leafCursor struct {
+	s     *leafSieve
+	block int
+	sum   int64 // Entiers restants des blocs avant block.
+}
+
+// count retourne le nombre d'entiers restants du segment inférieurs ou égaux à n (n ≥ low).
+func (cur *leafCursor) count(n int64) int64 {
+	i := (n - cur.s.low) / 2
+	w := int(i / 64)
+	for ; cur.block < w/leafBlockWords; cur.block++ {
+		cur.sum += int64(cur.s.blocks[cur.block])
+	}
+	sum := cur.sum
+	for _, word := range cur.s.words[cur.block*leafBlockWords : w] {
+		sum += int64(bits.OnesCount64(word))
+	}
+	return sum + int64(bits.OnesCount64(cur.s.words[w]<<(63-i%64)))
+}
+
+// phiSpecialLeaves retourne la somme des feuilles spéciales de φ(x, π(y)): -μ(m)·φ(x/(m·p_b), b-1)
+// pour c < b < π(y) et les entiers sans facteur carré m ≤ y < m·p_b dont le plus petit facteur
+// premier dépasse p_b. Au-delà de √y, m est premier, et la plupart des feuilles sont faciles
+// (phiEasyLeaves). Les autres arguments x/(m·p_b), inférieurs à x/y, sont atteints par un crible
+// segmenté de [1, x/y] où les nombres premiers p_1, p_2... sont rayés l'un après l'autre:
+// φ(n, b-1) est le nombre d'entiers restants jusqu'à n une fois rayés p_1...p_{b-1}, cumulé
+// segment après segment dans phi[b].
+func phiSpecialLeaves(x, y int64, primes []int64, pi []int32, c int, lpf []int32, mu []int8) int64 {
+	piY := len(primes) - 1
+	piSqrtY := int(pi[isqrt(y)])
+	limit := x/y + 1
+	phi := make([]int64, piY+1)
+	s := &leafSieve{words: make([]uint64, leafSegmentWords), blocks: make([]int32, leafSegmentWords/leafBlockWords)}
+	var sum int64
+segments:
+	for low := int64(1); low < limit; low += 2 * 64 * leafSegmentWords {
+		high := min(low+2*64*leafSegmentWords, limit)
+		s.reset(low, high)
+		for b := 2; b <= c; b++ {
+			s.crossOff(primes[b])
+		}
+
+		b := c + 1
+		for ; b <= piSqrtY; b++ {
+			p := primes[b]
+			minM := max(x/p/high, y/p)
+			maxM := min(x/p/low, y)
+			if p >= maxM {
+				continue segments // Aucune feuille pour p ni les suivants, ni dans les segments suivants.
+			}
+			cur := leafCursor{s: s}
+			for m := maxM; m > minM; m-- {
+				if mu[m] != 0 && p < int64(lpf[m]) {
+					sum -= int64(mu[m]) * (phi[b] + cur.count(x/p/m))
+				}
+			}
+			phi[b] += s.total
+			s.crossOff(p)
+		}
+		for ; b < piY; b++ {
+			// Feuilles difficiles seulement: x/(p·q) ≥ min(p², y+1).
+			p := primes[b]
+			l := pi[min(x/p/low, y, x/p/min(p*p, y+1))]
+			minM := max(x/p/high, y/p, p)
+			if p >= primes[l] {
+				continue segments
+			}
+			cur := leafCursor{s: s}
+			for ; primes[l] > minM; l-- {
+				sum += phi[b] + cur.count(x/p/primes[l])
+			}
+			phi[b] += s.total
+			s.crossOff(p)
+		}
+	}
+	return sum + phiEasyLeaves(x, y, primes, pi, max(c, piSqrtY)+1)
+}
+
+// phiEasyLeaves retourne la somme des feuilles spéciales φ(x/(p_b·q), b-1), q premier, pour
+// first ≤ b < π(y) dont l'argument n = x/(p_b·q) est inférieur à min(p_b², y+1): les entiers
+// restants de [1, n] sont alors 1 et les nombres premiers de [p_b, n], soit π(n) - b + 2 par la
+// table pi. Les feuilles triviales (n < p_b, φ = 1) sont comptées sans être parcourues.
+func phiEasyLeaves(x, y int64, primes []int64, pi []int32, first int) int64 {
+	var sum int64
+	for b := first; b < len(primes)-1; b++ {
+		p := primes[b]
+		minQ := max(y/p, p) // q > y/p (feuille spéciale) et q > p.
+		if trivial := max(x/p/p, minQ); trivial < y {
+			sum += int64(pi[y] - pi[trivial])
+		}
+		minEasy := max(minQ, x/p/min(p*p, y+1))
+		for l := pi[min(x/p/p, y)]; primes[l] > minEasy; l-- {
+			sum += int64(pi[x/p/primes[l]]) - int64(b) + 2
+		}
+	}
+	return sum
+}
+
+// primePairs retourne P2(x, a) = Σ_{a<i≤π(√x)} (π(x/p_i) - (i-1)), le nombre d'entiers de
+// [1, x] produits de deux nombres premiers supérieurs à y (a = π(y)). Les π(x/p_i), pour
+// x/p_i entre √x et x/y, sont comptés par un seul crible segmenté de ]√x, x/y].
+func primePairs(x, y, a int64) int64 {
+	sqrtX := isqrt(x)
+	mids := SieveRange(y+1, sqrtX) // p_i pour a < i ≤ π(√x), croissants.
+	b := a + int64(len(mids))
+	count, sum := b, int64(0)
+	j := len(mids) - 1 // x/mids[j] croît quand j décroît.
+	forEachPrime(sqrtX+1, x/y, func(q int64) bool {
+		for ; j >= 0 && x/mids[j] < q; j-- {
+			sum += count
+		}
+		count++
+		return true
+	})
+	for ; j >= 0; j-- {
+		sum += count
+	}
+	return sum - (b-a)*(a+b-1)/2
+}
@@ -0,0 +1,114 @@
+/*
+ * Fichier: count_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la fonction de compte des nombres premiers π(x).
+ */
+package primes
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestPrimeCount valide π(x) sur des valeurs de référence, y compris au-delà de la table précalculée.
+func TestPrimeCount(t *testing.T) {
+	testCases := []struct {
+		x        int64
+		expected int64
+	}{
+		{-5, 0}, {0, 0}, {1, 0}, {2, 1}, {3, 2}, {10, 4}, {100, 25},
+		{1_000, 168}, {1_000_000, 78_498}, {1_000_000_000, 50_847_534},
+		{10_000_000_000, 455_052_511}, {1_000_000_000_000, 37_607_912_018},
+	}
+
+	for _, tc := range testCases {
+		if got := PrimeCount(tc.x); got != tc.expected {
+			t.Errorf("PrimeCount(%d) = %d, attendu %d", tc.x, got, tc.expected)
+		}
+	}
+}
+
+// TestPrimeCountMatchesSieve compare π(x) au nombre de premiers du crible pour chaque x de la plage.
+func TestPrimeCountMatchesSieve(t *testing.T) {
+	primeList := SieveOfEratosthenes(5000)
+	count := 0
+	for x := 0; x <= 5000; x++ {
+		if count < len(primeList) && primeList[count] == x {
+			count++
+		}
+		if got := PrimeCount(int64(x)); got != int64(count) {
+			t.Fatalf("PrimeCount(%d) = %d, attendu %d", x, got, count)
+		}
+	}
+}
+
+// TestPrimeCountLarge valide π(10^13), hors de portée de la formule de Legendre sans troncature.
+func TestPrimeCountLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("π(10^13) omis avec -short")
+	}
+	if got, want := PrimeCount(10_000_000_000_000), int64(346_065_536_839); got != want {
+		t.Errorf("PrimeCount(10^13) = %d, attendu %d", got, want)
+	}
+}
+
+// BenchmarkPrimeCount mesure π(x) par l'algorithme LMO (de l'ordre de x^⅔): la formule de
+// Legendre sans troncature y passait plus de 13 s à 10^13, LMO moins d'une seconde.
+func BenchmarkPrimeCount(b *testing.B) {
+	for _, x := range []int64{1e11, 1e12, 1e13} {
+		b.Run(fmt.Sprintf("1e%d", int(math.Log10(float64(x)))), func(b *testing.B) {
+			for b.Loop() {
+				PrimeCount(x)
+			}
+		})
+	}
+}
+
+// TestPrimeCountLMO force l'algorithme LMO sur de petites valeurs, avec des bornes de troncature
+// y de x^⅓ à près de √x: le résultat ne dépend pas de y.
+func TestPrimeCountLMO(t *testing.T) {
+	for _, tc := range []struct{ x, expected int64 }{{1_000, 168}, {1_000_000, 78_498}, {123_456_789, 7_027_260}} {
+		for _, y := range []int64{iroot(tc.x, 3), 2 * iroot(tc.x, 3), isqrt(tc.x) - 1} {
+			if got := primeCountLMO(tc.x, y); got != tc.expected {
+				t.Errorf("primeCountLMO(%d, y = %d) = %d, attendu %d", tc.x, y, got, tc.expected)
+			}
+		}
+	}
+}
+
+// TestPhiSmall compare φ(x, c) par la table périodique au décompte direct.
+func TestPhiSmall(t *testing.T) {
+	smallPrimes := []int64{2, 3, 5, 7, 11, 13}
+	for c := 1; c <= phiSmallPrimes; c++ {
+		var count int64
+		for x := int64(1); x <= 70_000; x++ {
+			if !slices.ContainsFunc(smallPrimes[:c], func(p int64) bool { return x%p == 0 }) {
+				count++
+			}
+			if got := phiSmall(x, c); got != count {
+				t.Fatalf("phiSmall(%d, %d) = %d, attendu %d", x, c, got, count)
+			}
+		}
+	}
+}
+
+// TestIntegerRoots valide isqrt et iroot autour des puissances exactes.
+func TestIntegerRoots(t *testing.T) {
+	for _, n := range []int64{0, 1, 15, 16, 17, 999_999_999_999, 1_000_000_000_000} {
+		r := isqrt(n)
+		if r*r > n || (r+1)*(r+1) <= n {
+			t.Errorf("isqrt(%d) = %d", n, r)
+		}
+	}
+	if got := iroot(1_000_000_000_000, 3); got != 10_000 {
+		t.Errorf("iroot(10^12, 3) = %d, attendu 10000", got)
+	}
+	if got := iroot(999_999_999_999, 4); got != 999 {
+		t.Errorf("iroot(10^12-1, 4) = %d, attendu 999", got)
+	}
+}
@@ -0,0 +1,537 @@
+/*
+ * Fichier: ecpp.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Preuve de primalité par courbes elliptiques (ECPP) pour des entiers de
+ * plusieurs centaines de bits. Le prouveur suit la méthode d'Atkin-Morain
+ * restreinte aux discriminants de nombre de classes 1 et 2, dont les
+ * polynômes de classes de Hilbert sont tabulés: pour chaque discriminant D où
+ * N s'écrit 4N = u² + |D|v² (Cornacchia), les ordres possibles des courbes à
+ * multiplication complexe par D sont connus sans comptage de points. Un ordre m = k·q où q est un
+ * premier probable assez grand donne une étape de Goldwasser-Kilian, puis q
+ * est prouvé à son tour; la descente revient en arrière lorsqu'un q ne mène
+ * nulle part et s'arrête sous 2^64, où IsPrimeUint64 est exact.
+ * Le certificat produit est sérialisable en JSON et se vérifie sans le
+ * prouveur (Certificate.Verify).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrNotPrime signale qu'un entier soumis au prouveur est composé (ou inférieur à 2).
+	ErrNotPrime = errors.New("primes: entier composé")
+	// ErrNoProof signale que le prouveur n'a pas trouvé de certificat dans son budget de
+	// recherche; l'entier n'en est pas moins probablement premier.
+	ErrNoProof = errors.New("primes: aucune preuve trouvée")
+	// ErrInvalidCertificate signale un certificat qui ne prouve pas la primalité annoncée.
+	ErrInvalidCertificate = errors.New("primes: certificat invalide")
+)
+
+// Prover produit des certificats de primalité. Prove renvoie ErrNotPrime si n est composé et
+// ErrNoProof s'il n'a pas su conclure; une erreur du contexte interrompt la recherche.
+type Prover interface {
+	Prove(ctx context.Context, n *big.Int) (*Certificate, error)
+}
+
+// Certificate est un certificat de primalité de N: une chaîne d'étapes de Goldwasser-Kilian,
+// chacune ramenant la primalité de son N à celle de son Q, qui est le N de l'étape suivante.
+// Le dernier Q (ou N lui-même, sans étape) est inférieur à 2^64 et vérifié par IsPrimeUint64.
+type Certificate struct {
+	N     *big.Int          `json:"n"`
+	Steps []CertificateStep `json:"steps"`
+}
+
+// CertificateStep est une étape de Goldwasser-Kilian: sur la courbe y² = x³ + Ax + B modulo N,
+// le point P = (X, Y) vérifie K·P ≠ O et Q·(K·P) = O, avec Q > (N^(1/4) + 1)². Si Q est premier,
+// N l'est aussi. D, le discriminant qui a fourni la courbe, n'est donné qu'à titre indicatif.
+type CertificateStep struct {
+	N *big.Int `json:"n"`
+	D int      `json:"d"`
+	A *big.Int `json:"a"`
+	B *big.Int `json:"b"`
+	X *big.Int `json:"x"`
+	Y *big.Int `json:"y"`
+	K *big.Int `json:"k"`
+	Q *big.Int `json:"q"`
+}
+
+// Verify vérifie le certificat sans recourir au prouveur: chaque étape est recalculée et la
+// chaîne doit aboutir à un premier inférieur à 2^64. L'erreur renvoyée enveloppe
+// ErrInvalidCertificate.
+func (c *Certificate) Verify() error {
+	if c == nil || c.N == nil {
+		return fmt.Errorf("%w: N absent", ErrInvalidCertificate)
+	}
+	n := c.N
+	for i, step := range c.Steps {
+		if step.N == nil || step.N.Cmp(n) != 0 {
+			return fmt.Errorf("%w: étape %d: N ne prolonge pas la chaîne", ErrInvalidCertificate, i)
+		}
+		if err := step.verify(); err != nil {
+			return fmt.Errorf("%w: étape %d (N = %v): %v", ErrInvalidCertificate, i, step.N, err)
+		}
+		n = step.Q
+	}
+	if !n.IsUint64() || !IsPrimeUint64(n.Uint64()) {
+		return fmt.Errorf("%w: %v n'est pas un premier inférieur à 2^64", ErrInvalidCertificate, n)
+	}
+	return nil
+}
+
+// verify vérifie une étape isolément.
+func (s CertificateStep) verify() error {
+	for _, v := range []*big.Int{s.A, s.B, s.X, s.Y, s.K, s.Q} {
+		if v == nil {
+			return errors.New("champ absent")
+		}
+	}
+	n := s.N
+	if n.Sign() <= 0 || new(big.Int).GCD(nil, nil, n, big.NewInt(6)).Cmp(bigOne) != 0 {
+		return errors.New("N doit être premier avec 6")
+	}
+	for _, v := range []*big.Int{s.A, s.B, s.X, s.Y} {
+		if v.Sign() < 0 || v.Cmp(n) >= 0 {
+			return errors.New("coordonnée hors de [0, N)")
+		}
+	}
+	if s.K.Sign() <= 0 {
+		return errors.New("K doit être positif")
+	}
+	if s.Q.Cmp(ecppQBound(n)) < 0 {
+		return errors.New("Q ne dépasse pas (N^(1/4) + 1)²")
+	}
+	e := &ecCurve{n: n, a: s.A, b: s.B}
+	if !e.nonSingular() {
+		return errors.New("discriminant de la courbe non inversible")
+	}
+	p := ecPoint{x: s.X, y: s.Y}
+	if !e.onCurve(p) {
+		return errors.New("P n'est pas sur la courbe")
+	}
+	kp, err := e.mul(s.K, p)
+	if err != nil {
+		return err
+	}
+	if kp.inf {
+		return errors.New("K·P = O")
+	}
+	qkp, err := e.mul(s.Q, kp)
+	if err != nil {
+		return err
+	}
+	if !qkp.inf {
+		return errors.New("Q·(K·P) ≠ O")
+	}
+	return nil
+}
+
+var (
+	bigOne  = big.NewInt(1)
+	bigTwo  = big.NewInt(2)
+	bigFour = big.NewInt(4)
+)
+
+// ecppQBound renvoie (⌊N^(1/4)⌋ + 2)², plus petit entier dont on est sûr qu'il dépasse
+// (N^(1/4) + 1)².
+func ecppQBound(n *big.Int) *big.Int {
+	r := new(big.Int).Sqrt(n)
+	r.Sqrt(r).Add(r, bigTwo)
+	return r.Mul(r, r)
+}
+
+// ecPoint est un point affine d'une courbe elliptique modulo N; inf représente le point à
+// l'infini O.
+type ecPoint struct {
+	x, y *big.Int
+	inf  bool
+}
+
+// ecCurve est la courbe y² = x³ + ax + b sur Z/nZ. Les opérations suivent les formules du cas
+// premier et échouent dès qu'un dénominateur n'est pas inversible modulo n, ce qui ne peut
+// arriver que si n est composé.
+type ecCurve struct {
+	n, a, b *big.Int
+}
+
+// errNotInvertible signale un dénominateur non inversible modulo n: n est alors composé.
+var errNotInvertible = errors.New("dénominateur non inversible: N est composé")
+
+// nonSingular indique si le discriminant 4a³ + 27b² est inversible modulo n.
+func (e *ecCurve) nonSingular() bool {
+	d := new(big.Int).Exp(e.a, big.NewInt(3), e.n)
+	d.Mul(d, bigFour)
+	b2 := new(big.Int).Mul(e.b, e.b)
+	d.Add(d, b2.Mul(b2, big.NewInt(27))).Mod(d, e.n)
+	return new(big.Int).GCD(nil, nil, d, e.n).Cmp(bigOne) == 0
+}
+
+// rhs renvoie x³ + ax + b modulo n.
+func (e *ecCurve) rhs(x *big.Int) *big.Int {
+	r := new(big.Int).Mul(x, x)
+	r.Add(r, e.a).Mul(r, x).Add(r, e.b)
+	return r.Mod(r, e.n)
+}
+
+func (e *ecCurve) onCurve(p ecPoint) bool {
+	y2 := new(big.Int).Mul(p.y, p.y)
+	return y2.Mod(y2, e.n).Cmp(e.rhs(p.x)) == 0
+}
+
+// slope renvoie num/den modulo n.
+func (e *ecCurve) slope(num, den *big.Int) (*big.Int, error) {
+	inv := new(big.Int).ModInverse(den.Mod(den, e.n), e.n)
+	if inv == nil {
+		return nil, errNotInvertible
+	}
+	return inv.Mul(inv, num).Mod(inv, e.n), nil
+}
+
+// chord renvoie le point d'intersection résiduel de la droite de pente l passant par p et
+// d'abscisse x2 pour le second point, réfléchi: le résultat de l'addition.
+func (e *ecCurve) chord(l *big.Int, p ecPoint, x2 *big.Int) ecPoint {
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, p.x).Sub(x, x2).Mod(x, e.n)
+	y := new(big.Int).Sub(p.x, x)
+	y.Mul(y, l).Sub(y, p.y).Mod(y, e.n)
+	return ecPoint{x: x, y: y}
+}
+
+func (e *ecCurve) double(p ecPoint) (ecPoint, error) {
+	if p.inf || p.y.Sign() == 0 {
+		return ecPoint{inf: true}, nil
+	}
+	num := new(big.Int).Mul(p.x, p.x)
+	num.Mul(num, big.NewInt(3)).Add(num, e.a)
+	l, err := e.slope(num, new(big.Int).Lsh(p.y, 1))
+	if err != nil {
+		return ecPoint{}, err
+	}
+	return e.chord(l, p, p.x), nil
+}
+
+func (e *ecCurve) add(p, q ecPoint) (ecPoint, error) {
+	switch {
+	case p.inf:
+		return q, nil
+	case q.inf:
+		return p, nil
+	case p.x.Cmp(q.x) == 0:
+		s := new(big.Int).Add(p.y, q.y)
+		if s.Mod(s, e.n).Sign() == 0 {
+			return ecPoint{inf: true}, nil
+		}
+		if p.y.Cmp(q.y) == 0 {
+			return e.double(p)
+		}
+		return ecPoint{}, errNotInvertible
+	}
+	l, err := e.slope(new(big.Int).Sub(q.y, p.y), new(big.Int).Sub(q.x, p.x))
+	if err != nil {
+		return ecPoint{}, err
+	}
+	return e.chord(l, p, q.x), nil
+}
+
+// mul renvoie k·p (k >= 0) par doublements et additions.
+func (e *ecCurve) mul(k *big.Int, p ecPoint) (ecPoint, error) {
+	r := ecPoint{inf: true}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		var err error
+		if r, err = e.double(r); err != nil {
+			return ecPoint{}, err
+		}
+		if k.Bit(i) == 1 {
+			if r, err = e.add(r, p); err != nil {
+				return ecPoint{}, err
+			}
+		}
+	}
+	return r, nil
+}
+
+const (
+	// ecppSmoothBound borne les petits facteurs retirés de l'ordre m d'une courbe pour en
+	// extraire le cofacteur q.
+	ecppSmoothBound = 1 << 20
+	// ecppCurveTries borne les courbes (tordues) et points essayés pour un ordre candidat.
+	ecppCurveTries = 64
+	// ecppMaxNodes borne le nombre d'entiers dont la descente examine les ordres candidats,
+	// retours en arrière compris.
+	ecppMaxNodes = 512
+)
+
+// ecppSmoothProduct renvoie le produit des nombres premiers inférieurs à ecppSmoothBound,
+// calculé une fois par un arbre de produits.
+var ecppSmoothProduct = sync.OnceValue(func() *big.Int {
+	small := SieveOfEratosthenes(ecppSmoothBound)
+	level := make([]*big.Int, len(small))
+	for i, p := range small {
+		level[i] = big.NewInt(int64(p))
+	}
+	for len(level) > 1 {
+		next := make([]*big.Int, 0, (len(level)+1)/2)
+		for i := 0; i+1 < len(level); i += 2 {
+			next = append(next, new(big.Int).Mul(level[i], level[i+1]))
+		}
+		if len(level)%2 == 1 {
+			next = append(next, level[len(level)-1])
+		}
+		level = next
+	}
+	return level[0]
+})
+
+// ecppCandidate est un ordre de courbe m = k·q dont le cofacteur q est un premier probable
+// assez grand pour une étape de Goldwasser-Kilian.
+type ecppCandidate struct {
+	d    int64
+	c    []string // polynôme de classes de Hilbert de D (voir cmDiscriminants)
+	k, q *big.Int
+}
+
+// cornacchia résout 4n = u² + |d|·v² pour n premier (algorithme de Cornacchia modifié) et
+// renvoie u et v, ou ok = false sans solution.
+func cornacchia(n *big.Int, d int64) (u, v *big.Int, ok bool) {
+	bd := big.NewInt(d)
+	if big.Jacobi(new(big.Int).Mod(bd, n), n) != 1 {
+		return nil, nil, false
+	}
+	x := new(big.Int).ModSqrt(new(big.Int).Mod(bd, n), n)
+	if x == nil {
+		return nil, nil, false
+	}
+	if x.Bit(0) != uint(d&1) {
+		x.Sub(n, x)
+	}
+	a := new(big.Int).Lsh(n, 1)
+	b := x
+	l := new(big.Int).Lsh(n, 2)
+	l.Sqrt(l)
+	for b.Cmp(l) > 0 {
+		a, b = b, new(big.Int).Mod(a, b)
+	}
+	c := new(big.Int).Lsh(n, 2)
+	c.Sub(c, new(big.Int).Mul(b, b))
+	absD := big.NewInt(-d)
+	if new(big.Int).Mod(c, absD).Sign() != 0 {
+		return nil, nil, false
+	}
+	c.Quo(c, absD)
+	v = new(big.Int).Sqrt(c)
+	if new(big.Int).Mul(v, v).Cmp(c) != 0 {
+		return nil, nil, false
+	}
+	return b, v, true
+}
+
+// cmTraces renvoie les traces de Frobenius possibles des courbes à multiplication complexe
+// par d sur Z/nZ, déduites de 4n = u² + |d|·v².
+func cmTraces(u, v *big.Int, d int64) []*big.Int {
+	traces := []*big.Int{u}
+	switch d {
+	case -4:
+		traces = append(traces, new(big.Int).Lsh(v, 1))
+	case -3:
+		v3 := new(big.Int).Mul(v, big.NewInt(3))
+		traces = append(traces,
+			new(big.Int).Rsh(new(big.Int).Add(u, v3), 1),
+			new(big.Int).Rsh(new(big.Int).Sub(u, v3), 1))
+	}
+	for _, t := range slices.Clone(traces) {
+		traces = append(traces, new(big.Int).Neg(t))
+	}
+	return traces
+}
+
+// ecppCandidates renvoie les ordres candidats pour n, du plus petit cofacteur au plus grand
+// pour que la descente soit la plus courte possible.
+func ecppCandidates(n *big.Int) []ecppCandidate {
+	bound := ecppQBound(n)
+	smooth := new(big.Int)
+	var cands []ecppCandidate
+	for _, cm := range cmDiscriminants {
+		u, v, ok := cornacchia(n, cm.d)
+		if !ok {
+			continue
+		}
+		for _, t := range cmTraces(u, v, cm.d) {
+			q := new(big.Int).Add(n, bigOne)
+			q.Sub(q, t)
+			k := big.NewInt(1)
+			g := new(big.Int).GCD(nil, nil, q, smooth.Mod(ecppSmoothProduct(), q))
+			for g.Cmp(bigOne) != 0 {
+				q.Quo(q, g)
+				k.Mul(k, g)
+				g.GCD(nil, nil, q, g)
+			}
+			if q.Cmp(bound) < 0 || q.Cmp(n) >= 0 || !q.ProbablyPrime(0) {
+				continue
+			}
+			cands = append(cands, ecppCandidate{d: cm.d, c: cm.c, k: k, q: q})
+		}
+	}
+	slices.SortFunc(cands, func(a, b ecppCandidate) int { return a.q.Cmp(b.q) })
+	return slices.CompactFunc(cands, func(a, b ecppCandidate) bool { return a.q.Cmp(b.q) == 0 })
+}
+
+// ECPPProver est le prouveur d'Atkin-Morain du paquet (voir l'en-tête du fichier). Sa valeur
+// zéro est prête à l'emploi; la recherche est déterministe pour un n donné.
+type ECPPProver struct{}
+
+// Prove implémente Prover. Les entiers inférieurs à 2^64 reçoivent un certificat sans étape.
+func (ECPPProver) Prove(ctx context.Context, n *big.Int) (*Certificate, error) {
+	if n.Sign() <= 0 || !IsPrimeBig(n) {
+		return nil, fmt.Errorf("%w: %v", ErrNotPrime, n)
+	}
+	s := &ecppSearch{
+		ctx: ctx,
+		rng: rand.New(rand.NewPCG(n.Uint64(), uint64(n.BitLen()))),
+	}
+	steps, err := s.descend(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{N: new(big.Int).Set(n), Steps: steps}, nil
+}
+
+// ecppSearch porte l'état d'une descente.
+type ecppSearch struct {
+	ctx   context.Context
+	rng   *rand.Rand
+	nodes int
+}
+
+// descend prouve n (premier probable) en profondeur d'abord, en revenant en arrière lorsqu'un
+// cofacteur ne se laisse pas prouver.
+func (s *ecppSearch) descend(n *big.Int) ([]CertificateStep, error) {
+	if n.IsUint64() {
+		if IsPrimeUint64(n.Uint64()) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrNotPrime, n)
+	}
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.nodes++; s.nodes > ecppMaxNodes {
+		return nil, fmt.Errorf("%w: budget de %d nœuds épuisé", ErrNoProof, ecppMaxNodes)
+	}
+	var last error = fmt.Errorf("%w: aucun ordre de courbe utilisable pour %v", ErrNoProof, n)
+	for _, c := range ecppCandidates(n) {
+		step, ok := s.findCurve(n, c)
+		if !ok {
+			continue
+		}
+		rest, err := s.descend(c.q)
+		switch {
+		case err == nil:
+			return append([]CertificateStep{step}, rest...), nil
+		case errors.Is(err, ErrNoProof) || errors.Is(err, ErrNotPrime):
+			// Un q premier probable mais composé, ou sans preuve: on essaie l'ordre suivant.
+			last = err
+		default:
+			return nil, err
+		}
+		if s.nodes > ecppMaxNodes {
+			return nil, last
+		}
+	}
+	return nil, last
+}
+
+// randMod renvoie un entier pseudo-aléatoire de [0, n).
+func (s *ecppSearch) randMod(n *big.Int) *big.Int {
+	buf := make([]byte, (n.BitLen()+7)/8+8)
+	for i := range buf {
+		buf[i] = byte(s.rng.Uint32())
+	}
+	r := new(big.Int).SetBytes(buf)
+	return r.Mod(r, n)
+}
+
+// randNonZero renvoie un entier pseudo-aléatoire de [1, n).
+func (s *ecppSearch) randNonZero(n *big.Int) *big.Int {
+	for {
+		if r := s.randMod(n); r.Sign() != 0 {
+			return r
+		}
+	}
+}
+
+// curve renvoie une courbe d'invariant j (modulo n) tirée au hasard parmi ses tordues:
+// y² = x³ + b pour D = -3 (j = 0), y² = x³ + ax pour D = -4 (j = 1728), et sinon a = 3κc²,
+// b = 2κc³ avec κ = j/(1728 - j).
+func (s *ecppSearch) curve(n *big.Int, d int64, j *big.Int) *ecCurve {
+	switch d {
+	case -3:
+		return &ecCurve{n: n, a: new(big.Int), b: s.randNonZero(n)}
+	case -4:
+		return &ecCurve{n: n, a: s.randNonZero(n), b: new(big.Int)}
+	}
+	kappa := new(big.Int).Sub(big.NewInt(1728), j)
+	if kappa.ModInverse(kappa.Mod(kappa, n), n) == nil {
+		return nil
+	}
+	kappa.Mul(kappa, j).Mod(kappa, n)
+	c := s.randNonZero(n)
+	c2 := new(big.Int).Mul(c, c)
+	a := new(big.Int).Mul(kappa, c2)
+	a.Mul(a, big.NewInt(3)).Mod(a, n)
+	b := new(big.Int).Mul(kappa, c2.Mul(c2, c))
+	b.Lsh(b, 1).Mod(b, n)
+	return &ecCurve{n: n, a: a, b: b}
+}
+
+// point renvoie un point pseudo-aléatoire de e d'ordonnée non nulle.
+func (s *ecppSearch) point(e *ecCurve) (ecPoint, bool) {
+	for range ecppCurveTries {
+		x := s.randMod(e.n)
+		r := e.rhs(x)
+		if big.Jacobi(r, e.n) != 1 {
+			continue
+		}
+		if y := new(big.Int).ModSqrt(r, e.n); y != nil {
+			return ecPoint{x: x, y: y}, true
+		}
+	}
+	return ecPoint{}, false
+}
+
+// findCurve cherche, parmi les tordues de la courbe de c, celle d'ordre k·q et un point P
+// tel que k·P ≠ O et q·(k·P) = O.
+func (s *ecppSearch) findCurve(n *big.Int, c ecppCandidate) (CertificateStep, bool) {
+	j := cmInvariant(n, c.c, s.rng)
+	if j == nil {
+		return CertificateStep{}, false
+	}
+	for range ecppCurveTries {
+		e := s.curve(n, c.d, j)
+		if e == nil || !e.nonSingular() {
+			continue
+		}
+		p, ok := s.point(e)
+		if !ok {
+			continue
+		}
+		kp, err := e.mul(c.k, p)
+		if err != nil || kp.inf {
+			continue
+		}
+		if qkp, err := e.mul(c.q, kp); err != nil || !qkp.inf {
+			continue
+		}
+		return CertificateStep{N: new(big.Int).Set(n), D: int(c.d), A: e.a, B: e.b, X: p.x, Y: p.y, K: c.k, Q: c.q}, true
+	}
+	return CertificateStep{}, false
+}
@@ -0,0 +1,134 @@
+/*
+ * Fichier: ecpp_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du prouveur ECPP: certificats de premiers de 89 à 256 bits,
+ * vérifiés après un aller-retour JSON, rejet des composés, des certificats
+ * altérés et interruption par le contexte.
+ */
+package primes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// ecppTestPrimes renvoie des premiers de tailles variées, dont deux premiers de Mersenne.
+func ecppTestPrimes(t *testing.T) []*big.Int {
+	t.Helper()
+	mersenne := func(p uint) *big.Int {
+		m := new(big.Int).Lsh(big.NewInt(1), p)
+		return m.Sub(m, big.NewInt(1))
+	}
+	next := func(bits uint) *big.Int {
+		n := new(big.Int).Lsh(big.NewInt(1), bits-1)
+		for n.Add(n, big.NewInt(1)); !n.ProbablyPrime(0); n.Add(n, big.NewInt(2)) {
+		}
+		return n
+	}
+	return []*big.Int{big.NewInt(1<<61 - 1), mersenne(89), mersenne(127), next(160), next(200), next(256)}
+}
+
+// TestECPPProve prouve des premiers jusqu'à 256 bits et vérifie les certificats relus en JSON.
+func TestECPPProve(t *testing.T) {
+	var prover Prover = ECPPProver{}
+	for _, n := range ecppTestPrimes(t) {
+		cert, err := prover.Prove(context.Background(), n)
+		if err != nil {
+			t.Fatalf("Prove(%v): %v", n, err)
+		}
+		if n.IsUint64() != (len(cert.Steps) == 0) {
+			t.Errorf("Prove(%v): %d étapes", n, len(cert.Steps))
+		}
+		data, err := json.Marshal(cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back Certificate
+		if err := json.Unmarshal(data, &back); err != nil {
+			t.Fatal(err)
+		}
+		if err := back.Verify(); err != nil {
+			t.Errorf("Verify(%v): %v", n, err)
+		}
+		if back.N.Cmp(n) != 0 {
+			t.Errorf("certificat de %v, attendu %v", back.N, n)
+		}
+	}
+}
+
+// TestECPPComposite vérifie le rejet des entiers composés, y compris un pseudo-premier fort.
+func TestECPPComposite(t *testing.T) {
+	p, _ := new(big.Int).SetString("18446744073709551557", 10) // Plus grand premier sous 2^64.
+	for _, n := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(3825123056546413051),
+		new(big.Int).Mul(p, p),
+		new(big.Int).Mul(p, big.NewInt(1<<61-1)),
+	} {
+		if _, err := (ECPPProver{}).Prove(context.Background(), n); !errors.Is(err, ErrNotPrime) {
+			t.Errorf("Prove(%v) = %v, attendu ErrNotPrime", n, err)
+		}
+	}
+}
+
+// TestECPPContext vérifie que la descente s'arrête sur un contexte annulé.
+func TestECPPContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n := ecppTestPrimes(t)[2]
+	if _, err := (ECPPProver{}).Prove(ctx, n); !errors.Is(err, context.Canceled) {
+		t.Errorf("Prove sur un contexte annulé = %v, attendu context.Canceled", err)
+	}
+}
+
+// TestCertificateTampered vérifie que Verify rejette un certificat altéré.
+func TestCertificateTampered(t *testing.T) {
+	n := ecppTestPrimes(t)[4]
+	cert, err := ECPPProver{}.Prove(context.Background(), n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cert.Steps) < 2 {
+		t.Fatalf("certificat de %d étape(s), au moins 2 attendues", len(cert.Steps))
+	}
+	clone := func() *Certificate {
+		data, _ := json.Marshal(cert)
+		var c Certificate
+		json.Unmarshal(data, &c)
+		return &c
+	}
+	inc := func(v *big.Int) { v.Add(v, big.NewInt(2)) }
+	for name, tamper := range map[string]func(c *Certificate){
+		"N":            func(c *Certificate) { inc(c.N) },
+		"N étape":      func(c *Certificate) { inc(c.Steps[0].N) },
+		"Q":            func(c *Certificate) { inc(c.Steps[0].Q) },
+		"K":            func(c *Certificate) { inc(c.Steps[0].K) },
+		"X":            func(c *Certificate) { inc(c.Steps[0].X) },
+		"A":            func(c *Certificate) { inc(c.Steps[1].A) },
+		"tronqué":      func(c *Certificate) { c.Steps = c.Steps[:len(c.Steps)-1] },
+		"sans étape":   func(c *Certificate) { c.Steps = nil },
+		"champ absent": func(c *Certificate) { c.Steps[0].Y = nil },
+		"Q petit": func(c *Certificate) {
+			// K·Q inchangé, mais Q sous la borne (N^(1/4) + 1)².
+			s := &c.Steps[len(c.Steps)-1]
+			s.K.Mul(s.K, s.Q)
+			s.Q = big.NewInt(1)
+		},
+	} {
+		c := clone()
+		tamper(c)
+		if err := c.Verify(); !errors.Is(err, ErrInvalidCertificate) {
+			t.Errorf("%s: Verify() = %v, attendu ErrInvalidCertificate", name, err)
+		}
+	}
+	if err := clone().Verify(); err != nil {
+		t.Errorf("certificat intact: %v", err)
+	}
+}
@@ -0,0 +1,430 @@
+/*
+ * Fichier: extsort.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Destination qui écrit les résultats triés par n (SortSink), quel que soit
+ * leur nombre: un tri externe par fusion. Les résultats sont accumulés en
+ * mémoire par séries de taille bornée; chaque série pleine est triée puis
+ * déversée dans un fichier temporaire. À la fermeture, les séries (fichiers
+ * et reste en mémoire) sont fusionnées par un tas et écrites dans l'ordre,
+ * en écartant au passage les doublons de n si demandé; au-delà de
+ * sortMaxRuns fichiers, les séries sont d'abord fusionnées en une seule, pour
+ * borner le nombre de fichiers ouverts. Les séries tirent
+ * parti du tri pour se compresser: n est codé par son écart au précédent
+ * (varint), de sorte qu'un résultat occupe quelques octets sur disque au lieu
+ * de sa taille en mémoire.
+ */
+package primes
+
+import (
+	"bufio"
+	"cmp"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+	"time"
+	"unsafe"
+)
+
+// DefaultSortRunSize est le nombre par défaut de résultats gardés en mémoire par SortSink avant
+// un déversement sur disque (environ 64 Mio).
+const DefaultSortRunSize = 1 << 20
+
+// SortRunSize retourne le nombre de résultats d'une série en mémoire pour un budget en octets
+// (au moins un).
+func SortRunSize(budget int64) int {
+	return int(max(budget/int64(unsafe.Sizeof(Result{})), 1))
+}
+
+// sortMaxRuns est le nombre de séries sur disque au-delà duquel elles sont fusionnées en une seule.
+const sortMaxRuns = 128
+
+// Drapeaux d'un résultat dans une série déversée.
+const (
+	runTwin   = 1 << iota // Result.Twin.
+	runBig                // Result.Big suit, en octets préfixés par leur longueur.
+	runTiming             // Result.FoundAt et Result.TestTime suivent.
+)
+
+// CompareResults ordonne deux résultats par n croissant (valeur exacte au-delà d'int64), puis par
+// p et q: c'est l'ordre de SortSink.
+func CompareResults(a, b Result) int {
+	if c := compareN(a, b); c != 0 {
+		return c
+	}
+	return cmp.Or(cmp.Compare(a.P, b.P), cmp.Compare(a.Q, b.Q))
+}
+
+// compareN compare les valeurs de n de deux résultats.
+func compareN(a, b Result) int {
+	if c := cmp.Compare(a.N, b.N); c != 0 || (a.Big == nil && b.Big == nil) {
+		return c
+	}
+	return resultBig(a).Cmp(resultBig(b))
+}
+
+// resultBig retourne la valeur exacte de n d'un résultat.
+func resultBig(r Result) *big.Int {
+	if r.Big != nil {
+		return r.Big
+	}
+	return big.NewInt(r.N)
+}
+
+// SortSink écrit les résultats dans sa destination par n croissant, à la fermeture. Chaque série
+// pleine est triée et déversée dans un fichier temporaire, supprimé à la fermeture.
+type SortSink struct {
+	dst     ResultSink
+	runSize int
+	dir     string
+	dedup   bool
+
+	buf        []Result
+	runs       []*os.File
+	spills     int   // Séries déversées.
+	spilled    int64 // Octets écrits sur disque, fusions intermédiaires comprises.
+	seen       int64
+	written    int64
+	duplicates int64
+	err        error // Première erreur de déversement ou de fusion: Write, Flush et Close la retournent.
+}
+
+// NewSortSink retourne une destination qui écrit les résultats dans dst par n croissant, à la
+// fermeture, en gardant au plus runSize résultats en mémoire (DefaultSortRunSize si runSize <= 0)
+// et en déversant les autres dans dir. Avec dedup, un seul résultat est écrit par valeur de n:
+// celui de la plus petite paire.
+func NewSortSink(dst ResultSink, runSize int, dir string, dedup bool) *SortSink {
+	if runSize <= 0 {
+		runSize = DefaultSortRunSize
+	}
+	return &SortSink{dst: dst, runSize: runSize, dir: dir, dedup: dedup}
+}
+
+// Write ajoute res à la série en cours, déversée sur disque une fois pleine.
+func (s *SortSink) Write(res Result) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.seen++
+	s.buf = append(s.buf, res)
+	if len(s.buf) >= s.runSize {
+		s.err = s.spill()
+	}
+	return s.err
+}
+
+// Flush ne fait rien: rien n'est écrit avant la fermeture.
+func (s *SortSink) Flush() error { return s.err }
+
+// Close fusionne les séries et écrit les résultats dans l'ordre, puis ferme la destination et
+// supprime les fichiers temporaires.
+func (s *SortSink) Close() error {
+	defer s.removeRuns()
+	if s.err == nil {
+		s.err = s.merge()
+	}
+	err := s.err
+	if cerr := s.dst.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Err retourne la première erreur de déversement ou de fusion (hors fermeture de la destination).
+func (s *SortSink) Err() error { return s.err }
+
+// Seen retourne le nombre de résultats reçus.
+func (s *SortSink) Seen() int64 { return s.seen }
+
+// Written retourne le nombre de résultats écrits dans la destination.
+func (s *SortSink) Written() int64 { return s.written }
+
+// Duplicates retourne le nombre de résultats écartés comme doublons de n.
+func (s *SortSink) Duplicates() int64 { return s.duplicates }
+
+// Runs retourne le nombre de séries déversées sur disque.
+func (s *SortSink) Runs() int { return s.spills }
+
+// SpilledBytes retourne le volume écrit sur disque, fusions intermédiaires comprises.
+func (s *SortSink) SpilledBytes() int64 { return s.spilled }
+
+// spill trie la série en cours et la déverse dans un nouveau fichier temporaire.
+func (s *SortSink) spill() error {
+	slices.SortFunc(s.buf, CompareResults)
+	f, err := os.CreateTemp(s.dir, "primenumber-sort-*.run")
+	if err != nil {
+		return fmt.Errorf("tri externe: %w", err)
+	}
+	s.runs = append(s.runs, f)
+	s.spills++
+	err = s.writeRun(f, func(emit func(Result) error) error {
+		for _, res := range s.buf {
+			if err := emit(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	clear(s.buf)
+	s.buf = s.buf[:0]
+	if err == nil && len(s.runs) >= sortMaxRuns {
+		err = s.compact()
+	}
+	return err
+}
+
+// writeRun écrit dans f les résultats triés que fill lui passe, puis revient au début de f pour
+// la fusion.
+func (s *SortSink) writeRun(f *os.File, fill func(emit func(Result) error) error) error {
+	w := bufio.NewWriter(f)
+	var prev int64
+	var rec []byte
+	err := fill(func(res Result) error {
+		rec = appendRunResult(rec[:0], res, prev)
+		prev = res.N
+		s.spilled += int64(len(rec))
+		_, err := w.Write(rec)
+		return err
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return fmt.Errorf("tri externe: %w", err)
+	}
+	return nil
+}
+
+// compact fusionne les séries sur disque en une seule, pour borner le nombre de fichiers ouverts.
+func (s *SortSink) compact() error {
+	f, err := os.CreateTemp(s.dir, "primenumber-sort-*.run")
+	if err != nil {
+		return fmt.Errorf("tri externe: %w", err)
+	}
+	runs := s.runs
+	s.runs = []*os.File{f}
+	defer func() {
+		for _, r := range runs {
+			r.Close()
+			os.Remove(r.Name())
+		}
+	}()
+	return s.writeRun(f, func(emit func(Result) error) error { return mergeRuns(runs, nil, emit) })
+}
+
+// removeRuns ferme et supprime les fichiers temporaires.
+func (s *SortSink) removeRuns() {
+	for _, f := range s.runs {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	s.runs = nil
+}
+
+// merge fusionne les séries déversées et la série en mémoire vers la destination.
+func (s *SortSink) merge() error {
+	slices.SortFunc(s.buf, CompareResults)
+	var last Result
+	return mergeRuns(s.runs, s.buf, func(res Result) error {
+		if s.dedup && s.written > 0 && compareN(res, last) == 0 {
+			s.duplicates++
+			return nil
+		}
+		s.written++
+		last = res
+		return s.dst.Write(res)
+	})
+}
+
+// mergeRuns fusionne les séries des fichiers runs et la série triée mem, et passe les résultats à
+// emit dans l'ordre.
+func mergeRuns(runs []*os.File, mem []Result, emit func(Result) error) error {
+	h := &mergeHeap{}
+	for _, f := range runs {
+		if err := h.add(&fileRun{r: bufio.NewReader(f)}); err != nil {
+			return err
+		}
+	}
+	if err := h.add(&memoryRun{items: mem}); err != nil {
+		return err
+	}
+	for h.Len() > 0 {
+		top := h.items[0]
+		if err := emit(top.cur); err != nil {
+			return err
+		}
+		ok, err := top.next()
+		switch {
+		case err != nil:
+			return err
+		case ok:
+			heap.Fix(h, 0)
+		default:
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+// appendRunResult ajoute à dst le codage de res dans une série: écart de n au précédent (uvarint),
+// p et q (uvarint), drapeaux, puis la valeur exacte de n et les mesures si présentes.
+func appendRunResult(dst []byte, res Result, prev int64) []byte {
+	dst = binary.AppendUvarint(dst, uint64(res.N-prev))
+	dst = binary.AppendUvarint(dst, uint64(res.P))
+	dst = binary.AppendUvarint(dst, uint64(res.Q))
+	var flags byte
+	if res.Twin {
+		flags |= runTwin
+	}
+	if res.Big != nil {
+		flags |= runBig
+	}
+	if !res.FoundAt.IsZero() {
+		flags |= runTiming
+	}
+	dst = append(dst, flags)
+	if res.Big != nil {
+		b, _ := res.Big.GobEncode() // Ne peut pas échouer pour un big.Int non nil.
+		dst = binary.AppendUvarint(dst, uint64(len(b)))
+		dst = append(dst, b...)
+	}
+	if !res.FoundAt.IsZero() {
+		dst = binary.AppendVarint(dst, res.FoundAt.UnixNano())
+		dst = binary.AppendVarint(dst, int64(res.TestTime))
+	}
+	return dst
+}
+
+// readRunResult lit un résultat codé par appendRunResult; io.EOF en fin de série.
+func readRunResult(r *bufio.Reader, prev int64) (Result, error) {
+	delta, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Result{}, err // io.EOF à la frontière d'un résultat: fin de la série.
+	}
+	var res Result
+	res.N = prev + int64(delta)
+	p, errP := binary.ReadUvarint(r)
+	q, errQ := binary.ReadUvarint(r)
+	flags, errF := r.ReadByte()
+	if err := errors.Join(errP, errQ, errF); err != nil {
+		return Result{}, errRunCorrupt
+	}
+	res.P, res.Q, res.Twin = int(p), int(q), flags&runTwin != 0
+	if flags&runBig != 0 {
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return Result{}, errRunCorrupt
+		}
+		b := make([]byte, length)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return Result{}, errRunCorrupt
+		}
+		res.Big = new(big.Int)
+		if err := res.Big.GobDecode(b); err != nil {
+			return Result{}, errRunCorrupt
+		}
+	}
+	if flags&runTiming != 0 {
+		at, errA := binary.ReadVarint(r)
+		d, errD := binary.ReadVarint(r)
+		if errA != nil || errD != nil {
+			return Result{}, errRunCorrupt
+		}
+		res.FoundAt, res.TestTime = time.Unix(0, at), time.Duration(d)
+	}
+	return res, nil
+}
+
+// errRunCorrupt signale une série déversée illisible (fichier temporaire tronqué ou altéré).
+var errRunCorrupt = errors.New("tri externe: série temporaire illisible")
+
+// sortRun est une série triée en cours de fusion: next passe au résultat suivant, retourné par
+// current, et retourne false en fin de série.
+type sortRun interface {
+	next() (bool, error)
+	current() Result
+}
+
+// memoryRun est la série restée en mémoire.
+type memoryRun struct {
+	items []Result
+	i     int
+}
+
+func (m *memoryRun) next() (bool, error) {
+	if m.i >= len(m.items) {
+		return false, nil
+	}
+	m.i++
+	return true, nil
+}
+
+func (m *memoryRun) current() Result { return m.items[m.i-1] }
+
+// fileRun est une série déversée, relue depuis son fichier.
+type fileRun struct {
+	r   *bufio.Reader
+	cur Result
+}
+
+func (f *fileRun) next() (bool, error) {
+	res, err := readRunResult(f.r, f.cur.N)
+	switch {
+	case err == io.EOF:
+		return false, nil
+	case err != nil:
+		return false, errRunCorrupt
+	}
+	f.cur = res
+	return true, nil
+}
+
+func (f *fileRun) current() Result { return f.cur }
+
+// mergeEntry est une série du tas de fusion et son résultat courant.
+type mergeEntry struct {
+	run sortRun
+	cur Result
+}
+
+// next avance la série de l'entrée.
+func (e *mergeEntry) next() (bool, error) {
+	ok, err := e.run.next()
+	if ok {
+		e.cur = e.run.current()
+	}
+	return ok, err
+}
+
+// mergeHeap est un tas de séries ordonné par leur résultat courant.
+type mergeHeap struct{ items []*mergeEntry }
+
+// add place run dans le tas, s'il n'est pas vide.
+func (h *mergeHeap) add(run sortRun) error {
+	e := &mergeEntry{run: run}
+	ok, err := e.next()
+	if ok {
+		heap.Push(h, e)
+	}
+	return err
+}
+
+func (h mergeHeap) Len() int           { return len(h.items) }
+func (h mergeHeap) Less(i, j int) bool { return CompareResults(h.items[i].cur, h.items[j].cur) < 0 }
+func (h mergeHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *mergeHeap) Push(x any)        { h.items = append(h.items, x.(*mergeEntry)) }
+func (h *mergeHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
@@ -0,0 +1,107 @@
+/*
+ * Fichier: extsort_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du tri externe des résultats (SortSink).
+ */
+package primes
+
+import (
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// sameResult compare deux résultats champ par champ (valeur exacte et instants compris).
+func sameResult(a, b Result) bool {
+	return a.P == b.P && a.Q == b.Q && a.N == b.N && a.Twin == b.Twin && a.TestTime == b.TestTime &&
+		a.FoundAt.Equal(b.FoundAt) && (a.Big == nil) == (b.Big == nil) && (a.Big == nil || a.Big.Cmp(b.Big) == 0)
+}
+
+// TestSortSink compare la sortie de SortSink, avec et sans déversement sur disque, au tri en
+// mémoire des mêmes résultats, doublons de n et valeurs exactes au-delà d'int64 compris.
+func TestSortSink(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	at := time.Unix(1_700_000_000, 0)
+	var input []Result
+	for i := range 500 {
+		res := Result{P: r.IntN(1000), Q: r.IntN(1000), N: r.Int64N(5000), Twin: i%3 == 0}
+		if i%7 == 0 {
+			res.FoundAt, res.TestTime = at.Add(time.Duration(i)*time.Millisecond), time.Duration(i)
+		}
+		if i%50 == 0 {
+			res.N, res.Big = math.MaxInt64, new(big.Int).Lsh(big.NewInt(int64(i+1)), 70)
+		}
+		input = append(input, res)
+	}
+	want := slices.Clone(input)
+	slices.SortStableFunc(want, CompareResults)
+	distinct := slices.CompactFunc(slices.Clone(want), func(a, b Result) bool { return compareN(a, b) == 0 })
+
+	for _, runSize := range []int{1, 7, 64, 1000} {
+		for _, dedup := range []bool{false, true} {
+			dir := t.TempDir()
+			dst := &sliceSink{}
+			s := NewSortSink(dst, runSize, dir, dedup)
+			for _, res := range input {
+				if err := s.Write(res); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(dst.got) != 0 {
+				t.Errorf("runSize=%d: %d résultats écrits avant la fermeture", runSize, len(dst.got))
+			}
+			if wantRuns := len(input) / runSize; s.Runs() != wantRuns || (wantRuns > 0) != (s.SpilledBytes() > 0) {
+				t.Errorf("runSize=%d: %d séries déversées (%d octets), attendu %d", runSize, s.Runs(), s.SpilledBytes(), wantRuns)
+			}
+			if err := s.Close(); err != nil || dst.closed != 1 {
+				t.Fatalf("runSize=%d: Close() = %v, %d fermetures", runSize, err, dst.closed)
+			}
+			expected := want
+			if dedup {
+				expected = distinct
+			}
+			if !slices.EqualFunc(dst.got, expected, sameResult) {
+				t.Errorf("runSize=%d dedup=%v: %d résultats écrits, attendu %d dans l'ordre de n", runSize, dedup, len(dst.got), len(expected))
+			}
+			if s.Seen() != int64(len(input)) || s.Written() != int64(len(expected)) || s.Duplicates() != int64(len(input)-len(expected)) {
+				t.Errorf("runSize=%d dedup=%v: %d reçus, %d écrits, %d doublons", runSize, dedup, s.Seen(), s.Written(), s.Duplicates())
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Errorf("runSize=%d: %d fichiers temporaires laissés après la fermeture", runSize, len(entries))
+			}
+		}
+	}
+}
+
+// TestSortSinkCompression vérifie que les séries déversées tirent parti du tri: des n proches
+// occupent quelques octets chacun.
+func TestSortSinkCompression(t *testing.T) {
+	s := NewSortSink(&sliceSink{}, 1000, t.TempDir(), false)
+	for i := range 1000 {
+		s.Write(Result{P: i, Q: 1, N: 1_000_000_000_000 + int64(2*i)})
+	}
+	if perResult := s.SpilledBytes() / 1000; s.Runs() != 1 || perResult > 6 {
+		t.Errorf("%d séries, %d octets par résultat sur disque, attendu une série d'au plus 6 octets par résultat", s.Runs(), perResult)
+	}
+	s.Close()
+}
+
+// TestSortSinkSpillError vérifie qu'un répertoire temporaire inutilisable est signalé par Write
+// et par Close.
+func TestSortSinkSpillError(t *testing.T) {
+	dst := &sliceSink{}
+	s := NewSortSink(dst, 1, t.TempDir()+"/absent", false)
+	if err := s.Write(Result{N: 5}); err == nil {
+		t.Fatal("Write: déversement dans un répertoire absent accepté")
+	}
+	if err := s.Close(); err == nil || dst.closed != 1 {
+		t.Errorf("Close() = %v, %d fermetures", err, dst.closed)
+	}
+}
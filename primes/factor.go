@@ -0,0 +1,130 @@
+/*
+ * Fichier: factor.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Factorisation des int64: division par les petits nombres premiers, puis
+ * méthode rho de Pollard avec l'amélioration de Brent pour scinder les
+ * cofacteurs composés restants. Sert à l'analyse des valeurs de n rejetées
+ * et à la sous-commande factor.
+ */
+package primes
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	// smallFactorBound borne les diviseurs essayés par division successive avant la méthode rho.
+	smallFactorBound = 1000
+	// brentBatch est le nombre de différences multipliées entre deux calculs de PGCD (méthode de Brent).
+	brentBatch = 128
+)
+
+// trialPrimes contient les nombres premiers inférieurs ou égaux à smallFactorBound.
+var trialPrimes = SieveOfEratosthenes(smallFactorBound)
+
+// mulMod retourne a·b mod m sans débordement (a, b < m).
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// gcd retourne le plus grand commun diviseur de a et b.
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Factor retourne la décomposition de n en facteurs premiers, par ordre croissant et avec
+// multiplicité (Factor(12) = [2 2 3]). Retourne nil si n < 2.
+func Factor(n int64) []int64 {
+	if n < 2 {
+		return nil
+	}
+	var factors []int64
+	for _, p := range trialPrimes {
+		p := int64(p)
+		if p*p > n {
+			break
+		}
+		for n%p == 0 {
+			factors = append(factors, p)
+			n /= p
+		}
+	}
+	if n > 1 {
+		factors = appendFactors(factors, n)
+	}
+	slices.Sort(factors)
+	return factors
+}
+
+// appendFactors ajoute à factors les facteurs premiers de n, qui n'a aucun facteur <= smallFactorBound.
+func appendFactors(factors []int64, n int64) []int64 {
+	if n < smallFactorBound*smallFactorBound || IsPrime(n) {
+		return append(factors, n)
+	}
+	d := pollardBrent(n)
+	return appendFactors(appendFactors(factors, d), n/d)
+}
+
+// SmallestFactor retourne le plus petit facteur premier de n (n lui-même s'il est premier),
+// ou 0 si n < 2.
+func SmallestFactor(n int64) int64 {
+	if n < 2 {
+		return 0
+	}
+	// Chemin rapide: la plupart des n composés ont un petit facteur, inutile de tout factoriser.
+	for _, p := range trialPrimes {
+		if int64(p)*int64(p) > n {
+			return n
+		}
+		if n%int64(p) == 0 {
+			return int64(p)
+		}
+	}
+	return Factor(n)[0]
+}
+
+// pollardBrent retourne un diviseur non trivial d'un entier composé impair n par la méthode rho
+// de Pollard avec l'amélioration de Brent: détection de cycle par puissances de deux et PGCD
+// calculé sur le produit de brentBatch différences. Le polynôme x² + c change tant que la
+// recherche échoue.
+func pollardBrent(n int64) int64 {
+	m := uint64(n)
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return (mulMod(x, x, m) + c) % m }
+		y, r, q, d := uint64(2), 1, uint64(1), uint64(1)
+		var x, ys uint64
+		for d == 1 {
+			x = y
+			for range r {
+				y = f(y)
+			}
+			for k := 0; k < r && d == 1; k += brentBatch {
+				ys = y
+				for range min(brentBatch, r-k) {
+					y = f(y)
+					q = mulMod(q, max(x, y)-min(x, y), m)
+				}
+				d = gcd(q, m)
+			}
+			r *= 2
+		}
+		if d == m {
+			// Le produit a absorbé le facteur: on reprend pas à pas depuis la dernière sauvegarde.
+			for d = 1; d == 1; {
+				ys = f(ys)
+				d = gcd(max(x, ys)-min(x, ys), m)
+			}
+		}
+		if d != m {
+			return int64(d)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Fichier: factor_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la factorisation et de la recherche du plus petit facteur premier.
+ */
+package primes
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestSmallestFactor valide le plus petit facteur sur des cas limites, petits et grands,
+// ainsi que sur tous les entiers d'un intervalle comparés à la division successive.
+func TestSmallestFactor(t *testing.T) {
+	testCases := []struct {
+		n        int64
+		expected int64
+	}{
+		{-5, 0},
+		{1, 0},
+		{2, 2},
+		{9, 3},
+		{997 * 997, 997},
+		{1009 * 1013, 1009}, // Facteurs au-delà de la division successive.
+		{1_000_000_007 * 998_244_353, 998_244_353}, // Deux grands facteurs premiers.
+		{4_294_967_291 * 2_147_483_647, 2_147_483_647},
+		{math.MaxInt64, 7}, // 2^63 - 1 = 7² · 73 · 127 · 337 · 92737 · 649657.
+		{1_000_000_007 * 3 * 3, 3},
+		{2_305_843_009_213_693_951, 2_305_843_009_213_693_951}, // Premier de Mersenne 2^61 - 1.
+	}
+	for _, tc := range testCases {
+		if got := SmallestFactor(tc.n); got != tc.expected {
+			t.Errorf("SmallestFactor(%d) = %d, attendu %d", tc.n, got, tc.expected)
+		}
+	}
+
+	for n := int64(2); n <= 20_000; n++ {
+		expected := n
+		for d := int64(2); d*d <= n; d++ {
+			if n%d == 0 {
+				expected = d
+				break
+			}
+		}
+		if got := SmallestFactor(n); got != expected {
+			t.Fatalf("SmallestFactor(%d) = %d, attendu %d", n, got, expected)
+		}
+	}
+}
+
+// TestFactor valide la décomposition sur des cas de référence et, pour un intervalle d'entiers,
+// que le produit des facteurs redonne n et que chaque facteur est premier.
+func TestFactor(t *testing.T) {
+	testCases := []struct {
+		n        int64
+		expected []int64
+	}{
+		{0, nil},
+		{1, nil},
+		{2, []int64{2}},
+		{12, []int64{2, 2, 3}},
+		{1009 * 1009, []int64{1009, 1009}},
+		{1_000_000_007 * 998_244_353, []int64{998_244_353, 1_000_000_007}},
+		{math.MaxInt64, []int64{7, 7, 73, 127, 337, 92737, 649657}},
+		{1 << 62, slices.Repeat([]int64{2}, 62)},
+		{3_037_000_493 * 3_037_000_493, []int64{3_037_000_493, 3_037_000_493}}, // Carré d'un premier proche de √MaxInt64.
+		{2_305_843_009_213_693_951, []int64{2_305_843_009_213_693_951}},
+	}
+	for _, tc := range testCases {
+		if got := Factor(tc.n); !slices.Equal(got, tc.expected) {
+			t.Errorf("Factor(%d) = %v, attendu %v", tc.n, got, tc.expected)
+		}
+	}
+
+	for n := int64(1_000_000_000_000); n < 1_000_000_002_000; n++ {
+		product := int64(1)
+		for _, f := range Factor(n) {
+			if !IsPrime(f) {
+				t.Fatalf("Factor(%d): facteur %d non premier", n, f)
+			}
+			product *= f
+		}
+		if product != n {
+			t.Fatalf("Factor(%d): produit %d", n, product)
+		}
+	}
+}
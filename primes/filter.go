@@ -0,0 +1,78 @@
+/*
+ * Fichier: filter.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Filtres appliqués par les workers aux nombres premiers n trouvés, pour
+ * des recherches composées en une seule passe: seuls les n acceptés par le
+ * filtre sont remontés (nombres de Sophie Germain, nombres premiers sûrs).
+ */
+package primes
+
+import (
+	"math"
+	"math/big"
+	"slices"
+)
+
+// Filter décide si un nombre premier n trouvé par la recherche doit être remonté.
+type Filter interface {
+	// Name est le nom du filtre, tel qu'accepté par LookupFilter.
+	Name() string
+	// Accept est appelé pour chaque n premier; seuls les n acceptés sont remontés.
+	Accept(n int64) bool
+}
+
+// funcFilter est l'implémentation des filtres prédéfinis.
+type funcFilter struct {
+	name   string
+	accept func(n int64) bool
+}
+
+func (f *funcFilter) Name() string        { return f.name }
+func (f *funcFilter) Accept(n int64) bool { return f.accept(n) }
+func (f *funcFilter) String() string      { return f.name }
+
+// Filtres prédéfinis.
+var (
+	// FilterSophieGermain accepte n si 2n+1 est premier (n est alors un nombre premier de Sophie Germain).
+	// 2n+1 est testé sur math/big lorsqu'il dépasse la capacité d'un int64.
+	FilterSophieGermain Filter = &funcFilter{
+		name: "sophie-germain",
+		accept: func(n int64) bool {
+			if n <= (math.MaxInt64-1)/2 {
+				return IsPrime(2*n + 1)
+			}
+			m := new(big.Int).Lsh(big.NewInt(n), 1)
+			return IsPrimeBig(m.Add(m, big.NewInt(1)))
+		},
+	}
+	// FilterSafe accepte n si (n-1)/2 est premier (n est alors un nombre premier sûr).
+	FilterSafe Filter = &funcFilter{
+		name:   "safe",
+		accept: func(n int64) bool { return n%2 == 1 && IsPrime((n-1)/2) },
+	}
+)
+
+// filters associe les filtres prédéfinis à leur nom.
+var filters = map[string]Filter{
+	FilterSophieGermain.Name(): FilterSophieGermain,
+	FilterSafe.Name():          FilterSafe,
+}
+
+// LookupFilter retourne le filtre prédéfini nommé name.
+func LookupFilter(name string) (Filter, bool) {
+	f, ok := filters[name]
+	return f, ok
+}
+
+// FilterNames retourne les noms des filtres prédéfinis, triés.
+func FilterNames() []string {
+	names := make([]string, 0, len(filters))
+	for name := range filters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,69 @@
+/*
+ * Fichier: filter_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des filtres de Sophie Germain et des nombres premiers sûrs.
+ */
+package primes
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// TestFilters valide les filtres sur des valeurs de référence, y compris au-delà de int64 pour 2n+1.
+func TestFilters(t *testing.T) {
+	testCases := []struct {
+		filter   Filter
+		n        int64
+		expected bool
+	}{
+		{FilterSophieGermain, 2, true},
+		{FilterSophieGermain, 11, true},
+		{FilterSophieGermain, 13, false},
+		{FilterSophieGermain, 41, true},
+		{FilterSophieGermain, math.MaxInt64 - 24, false}, // 2n+1 dépasse int64: testé sur math/big.
+		{FilterSafe, 5, true},
+		{FilterSafe, 7, true},
+		{FilterSafe, 23, true},
+		{FilterSafe, 13, false},
+		{FilterSafe, 2, false},
+	}
+	for _, tc := range testCases {
+		if got := tc.filter.Accept(tc.n); got != tc.expected {
+			t.Errorf("%s.Accept(%d) = %v, attendu %v", tc.filter.Name(), tc.n, got, tc.expected)
+		}
+	}
+}
+
+// TestSearchFiltered valide que seuls les résultats acceptés par le filtre sont remontés.
+func TestSearchFiltered(t *testing.T) {
+	primeList := SieveOfEratosthenes(30)
+	var all, filtered int
+	Search(context.Background(), Options{Primes: primeList, Workers: 2, BatchSize: 4}, func(Result) error { all++; return nil })
+	Search(context.Background(), Options{Primes: primeList, Workers: 2, BatchSize: 4, Filter: FilterSophieGermain}, func(r Result) error {
+		filtered++
+		if !IsPrime(2*r.N + 1) {
+			t.Errorf("n=%d remonté alors que 2n+1 est composé", r.N)
+		}
+		return nil
+	})
+	if filtered == 0 || filtered >= all {
+		t.Errorf("%d résultats filtrés sur %d, attendu entre 1 et %d", filtered, all, all-1)
+	}
+}
+
+// TestLookupFilter valide la recherche des filtres par nom.
+func TestLookupFilter(t *testing.T) {
+	for _, name := range FilterNames() {
+		if f, ok := LookupFilter(name); !ok || f.Name() != name {
+			t.Errorf("LookupFilter(%q) = %v, %v", name, f, ok)
+		}
+	}
+	if _, ok := LookupFilter("twin"); ok {
+		t.Errorf("LookupFilter(twin): ok = true")
+	}
+}
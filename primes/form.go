@@ -0,0 +1,178 @@
+/*
+ * Fichier: form.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Formes quadratiques évaluées par le moteur de recherche. Une forme associe
+ * à chaque paire (p, q) de nombres premiers un candidat n dont la primalité
+ * est testée; elle peut écarter d'emblée les paires dont le candidat est
+ * forcément composé (Prune). Les formes sont enregistrées par nom, ce qui
+ * permet d'étudier de nouvelles formes sans modifier les workers.
+ */
+package primes
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"sync"
+)
+
+// Form est une forme évaluée sur les paires (p, q) de nombres premiers.
+type Form interface {
+	// Name est le nom unique de la forme, tel qu'accepté par LookupForm (ex: "p^2+4q^2").
+	Name() string
+	// Eval retourne le candidat n associé à la paire (p, q).
+	Eval(p, q int64) int64
+	// Prune retourne true si la paire peut être écartée sans test: candidat forcément composé,
+	// ou déjà produit par une autre paire.
+	Prune(p, q int64) bool
+}
+
+// LimitedForm est implémentée par les formes dont les valeurs dépassent int64 au-delà d'une limite:
+// MaxLimit est la plus grande limite telle que Eval(p, q) tienne dans un int64 pour tous p, q <= MaxLimit.
+type LimitedForm interface {
+	Form
+	MaxLimit() int
+}
+
+// polyForm est l'implémentation des formes prédéfinies.
+type polyForm struct {
+	name       string
+	eval       func(p, q int64) int64
+	prune      func(p, q int64) bool
+	evalBig    func(p, q *big.Int) *big.Int
+	eval64     func(p, q uint64) (uint64, bool)
+	maxLimit   int
+	maxLimit64 int
+}
+
+func (f *polyForm) Name() string          { return f.name }
+func (f *polyForm) Eval(p, q int64) int64 { return f.eval(p, q) }
+func (f *polyForm) Prune(p, q int64) bool { return f.prune(p, q) }
+func (f *polyForm) MaxLimit() int         { return f.maxLimit }
+func (f *polyForm) EvalBig(p, q int64) *big.Int {
+	return f.evalBig(big.NewInt(p), big.NewInt(q))
+}
+func (f *polyForm) evalUint64(p, q uint64) (uint64, bool) { return f.eval64(p, q) }
+func (f *polyForm) maxLimitUint64() int                   { return f.maxLimit64 }
+func (f *polyForm) String() string                        { return f.name }
+
+// Formes prédéfinies.
+var (
+	// FormP2Plus4Q2 est la forme n = p^2 + 4q^2 du théorème de Green et Sawhney. Pour p = 2,
+	// n est pair et supérieur à 2, donc composé.
+	FormP2Plus4Q2 Form = &polyForm{
+		name:  "p^2+4q^2",
+		eval:  func(p, q int64) int64 { return p*p + 4*q*q },
+		prune: func(p, q int64) bool { return p == 2 },
+		evalBig: func(p, q *big.Int) *big.Int {
+			p.Mul(p, p)
+			q.Mul(q, q)
+			return p.Add(p, q.Lsh(q, 2))
+		},
+		eval64: func(p, q uint64) (uint64, bool) {
+			p2, ok1 := mul64(p, p)
+			q2, ok2 := mul64(q, q)
+			q2, ok3 := mul64(4, q2)
+			n, ok4 := add64(p2, q2)
+			return n, ok1 && ok2 && ok3 && ok4
+		},
+		maxLimit:   MaxLimit,
+		maxLimit64: MaxLimitUint64,
+	}
+	// FormP2PlusQ4 est la forme n = p^2 + q^4 (Friedlander et Iwaniec). Si p et q sont tous deux
+	// impairs ou tous deux égaux à 2, n est pair et composé: seules les paires où exactement un des
+	// deux vaut 2 sont testées.
+	FormP2PlusQ4 Form = &polyForm{
+		name:  "p^2+q^4",
+		eval:  func(p, q int64) int64 { return p*p + q*q*q*q },
+		prune: func(p, q int64) bool { return (p == 2) == (q == 2) },
+		evalBig: func(p, q *big.Int) *big.Int {
+			p.Mul(p, p)
+			q.Mul(q, q)
+			return p.Add(p, q.Mul(q, q))
+		},
+		eval64: func(p, q uint64) (uint64, bool) {
+			p2, ok1 := mul64(p, p)
+			q2, ok2 := mul64(q, q)
+			q4, ok3 := mul64(q2, q2)
+			n, ok4 := add64(p2, q4)
+			return n, ok1 && ok2 && ok3 && ok4
+		},
+		maxLimit:   55108,
+		maxLimit64: 65535,
+	}
+	// FormX2Plus1 est la forme n = x^2 + 1 (problème de Landau), évaluée en x = p; q est ignoré et
+	// seule la paire q = 2 est testée pour chaque p. Pour x premier impair, n est pair: seul x = 2 aboutit.
+	FormX2Plus1 Form = &polyForm{
+		name:  "x^2+1",
+		eval:  func(p, q int64) int64 { return p*p + 1 },
+		prune: func(p, q int64) bool { return q != 2 },
+		evalBig: func(p, q *big.Int) *big.Int {
+			return p.Add(p.Mul(p, p), big.NewInt(1))
+		},
+		eval64: func(p, q uint64) (uint64, bool) {
+			p2, ok1 := mul64(p, p)
+			n, ok2 := add64(p2, 1)
+			return n, ok1 && ok2
+		},
+		maxLimit:   min(3037000499, math.MaxInt), // Bornés par int sur les plateformes 32 bits.
+		maxLimit64: min(4294967295, math.MaxInt),
+	}
+)
+
+// DefaultForm est la forme utilisée par Search.
+var DefaultForm = FormP2Plus4Q2
+
+var (
+	formsMu sync.RWMutex
+	forms   = map[string]Form{}
+)
+
+func init() {
+	for _, f := range []Form{FormP2Plus4Q2, FormP2PlusQ4, FormX2Plus1} {
+		RegisterForm(f)
+	}
+}
+
+// RegisterForm enregistre une forme sous son nom. Comme database/sql.Register,
+// RegisterForm panique si une forme de même nom est déjà enregistrée.
+func RegisterForm(f Form) {
+	formsMu.Lock()
+	defer formsMu.Unlock()
+	if _, dup := forms[f.Name()]; dup {
+		panic(fmt.Sprintf("primes: forme %q déjà enregistrée", f.Name()))
+	}
+	forms[f.Name()] = f
+}
+
+// LookupForm retourne la forme enregistrée sous name.
+func LookupForm(name string) (Form, bool) {
+	formsMu.RLock()
+	defer formsMu.RUnlock()
+	f, ok := forms[name]
+	return f, ok
+}
+
+// FormNames retourne les noms des formes enregistrées, triés.
+func FormNames() []string {
+	formsMu.RLock()
+	defer formsMu.RUnlock()
+	names := make([]string, 0, len(forms))
+	for name := range forms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// CheckFormLimit retourne une erreur enveloppant ErrOverflow si la limite dépasse la MaxLimit de la forme.
+func CheckFormLimit(f Form, limit int) error {
+	if lf, ok := f.(LimitedForm); ok && limit > lf.MaxLimit() {
+		return fmt.Errorf("%w (forme %s, limite %d > %d)", ErrOverflow, f.Name(), limit, lf.MaxLimit())
+	}
+	return nil
+}
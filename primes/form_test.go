@@ -0,0 +1,129 @@
+/*
+ * Fichier: form_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des formes prédéfinies, du registre et de la recherche sur une forme personnalisée.
+ */
+package primes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+// sumForm est une forme de test: n = p + q + 1.
+type sumForm struct{}
+
+func (sumForm) Name() string          { return "test:p+q+1" }
+func (sumForm) Eval(p, q int64) int64 { return p + q + 1 }
+func (sumForm) Prune(p, q int64) bool { return p > q }
+
+// TestFormPruneSound valide que l'élagage ne fait perdre aucune valeur première de n.
+func TestFormPruneSound(t *testing.T) {
+	primeList := SieveOfEratosthenes(200)
+	for _, f := range []Form{FormP2Plus4Q2, FormP2PlusQ4, FormX2Plus1} {
+		all, kept := map[int64]bool{}, map[int64]bool{}
+		for _, p := range primeList {
+			for _, q := range primeList {
+				n := f.Eval(int64(p), int64(q))
+				if !IsPrime(n) {
+					continue
+				}
+				all[n] = true
+				if !f.Prune(int64(p), int64(q)) {
+					kept[n] = true
+				}
+			}
+		}
+		if len(all) == 0 {
+			t.Errorf("%s: aucun nombre premier trouvé", f.Name())
+		}
+		for n := range all {
+			if !kept[n] {
+				t.Errorf("%s: n=%d premier perdu par l'élagage", f.Name(), n)
+			}
+		}
+	}
+}
+
+// TestFormMaxLimit valide que Eval ne déborde pas à la limite annoncée.
+func TestFormMaxLimit(t *testing.T) {
+	for _, f := range []Form{FormP2Plus4Q2, FormP2PlusQ4, FormX2Plus1} {
+		m := int64(f.(LimitedForm).MaxLimit())
+		bm := big.NewInt(m)
+		var exact *big.Int
+		switch f {
+		case FormP2Plus4Q2:
+			exact = new(big.Int).Mul(big.NewInt(5), new(big.Int).Mul(bm, bm))
+		case FormP2PlusQ4:
+			sq := new(big.Int).Mul(bm, bm)
+			exact = new(big.Int).Add(sq, new(big.Int).Mul(sq, sq))
+		case FormX2Plus1:
+			exact = new(big.Int).Add(new(big.Int).Mul(bm, bm), big.NewInt(1))
+		}
+		if !exact.IsInt64() || f.Eval(m, m) != exact.Int64() {
+			t.Errorf("%s: Eval(%d, %d) déborde", f.Name(), m, m)
+		}
+		if m == math.MaxInt { // Limite bornée par int (plateformes 32 bits): aucune limite au-delà.
+			continue
+		}
+		if err := CheckFormLimit(f, int(m)+1); !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s: CheckFormLimit(%d) = %v, attendu ErrOverflow", f.Name(), m+1, err)
+		}
+	}
+	if err := CheckFormLimit(sumForm{}, math.MaxInt); err != nil {
+		t.Errorf("forme sans limite: CheckFormLimit = %v", err)
+	}
+}
+
+// TestFormRegistry valide l'enregistrement et la recherche des formes par nom.
+func TestFormRegistry(t *testing.T) {
+	for _, name := range []string{"p^2+4q^2", "p^2+q^4", "x^2+1"} {
+		if f, ok := LookupForm(name); !ok || f.Name() != name {
+			t.Errorf("LookupForm(%q) = %v, %v", name, f, ok)
+		}
+	}
+	if _, ok := LookupForm("inconnue"); ok {
+		t.Errorf("LookupForm(inconnue): ok = true")
+	}
+
+	// La forme de test reste enregistrée: on ne l'enregistre qu'une fois, même avec -count > 1.
+	if _, ok := LookupForm(sumForm{}.Name()); !ok {
+		RegisterForm(sumForm{})
+	}
+	if !slices.Contains(FormNames(), "test:p+q+1") {
+		t.Errorf("FormNames() = %v, forme de test absente", FormNames())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterForm en double: panique attendue")
+		}
+	}()
+	RegisterForm(sumForm{})
+}
+
+// TestSearchForm valide la recherche sur une forme personnalisée, élagage compris.
+func TestSearchForm(t *testing.T) {
+	primeList := SieveOfEratosthenes(10) // 2, 3, 5, 7
+	var got []Result
+	var last Progress
+	opts := Options{Primes: primeList, Form: sumForm{}, Workers: 2, BatchSize: 3, OnProgress: func(pr Progress) { last = pr }}
+	Search(context.Background(), opts, func(r Result) error { got = append(got, r); return nil })
+
+	// Paires p <= q avec p + q + 1 premier.
+	expected := []Result{{P: 2, Q: 2, N: 5}, {P: 3, Q: 3, N: 7}, {P: 3, Q: 7, N: 11}, {P: 5, Q: 5, N: 11}, {P: 5, Q: 7, N: 13}}
+	slices.SortFunc(got, func(a, b Result) int { return cmp.Or(cmp.Compare(a.N, b.N), cmp.Compare(a.P, b.P)) })
+	if !slices.Equal(got, expected) {
+		t.Errorf("résultats = %v, attendu %v", got, expected)
+	}
+	if last.Tested != 16 {
+		t.Errorf("paires testées = %d, attendu 16 (les paires écartées comptent)", last.Tested)
+	}
+}
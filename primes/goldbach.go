@@ -0,0 +1,102 @@
+/*
+ * Fichier: goldbach.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Vérification de la conjecture de Goldbach: tout nombre pair n >= 4 est la
+ * somme de deux nombres premiers. Les nombres pairs sont répartis par blocs
+ * entre un pool de workers qui cherchent, pour chaque n, le plus petit
+ * nombre premier p tel que n - p soit premier, à l'aide d'une table issue
+ * du crible.
+ */
+package primes
+
+import "sync"
+
+// goldbachChunk est le nombre de nombres pairs traités par tâche.
+const goldbachChunk = 4096
+
+// GoldbachReport résume une vérification de la conjecture de Goldbach.
+type GoldbachReport struct {
+	Limit          int   // Plus grand nombre pair vérifié (au plus la limite demandée).
+	Verified       int64 // Nombres pairs n, 4 <= n <= Limit, décomposés en somme de deux premiers.
+	Counterexample int   // Plus petit contre-exemple trouvé (0: aucun).
+	MaxMinPrime    int   // Plus grand « plus petit p » rencontré: n = p + (n-p) avec p minimal.
+	MaxMinPrimeAt  int   // Nombre pair n pour lequel MaxMinPrime a été atteint (le plus petit en cas d'égalité).
+}
+
+// goldbachRange est une tâche: les nombres pairs de [from, to].
+type goldbachRange struct{ from, to int }
+
+// VerifyGoldbach vérifie la conjecture de Goldbach pour tous les nombres pairs de 4 à limit,
+// avec numWorkers workers.
+func VerifyGoldbach(limit, numWorkers int) GoldbachReport {
+	report := GoldbachReport{Limit: limit &^ 1}
+	if limit < 4 {
+		report.Limit = 0
+		return report
+	}
+	primeList := SieveOfEratosthenes(limit)
+	isPrime := make([]bool, limit+1)
+	for _, p := range primeList {
+		isPrime[p] = true
+	}
+
+	tasks := make(chan goldbachRange, max(numWorkers, 1))
+	partials := make([]GoldbachReport, max(numWorkers, 1))
+	var wg sync.WaitGroup
+	for w := range partials {
+		wg.Add(1)
+		go func(r *GoldbachReport) {
+			defer wg.Done()
+			for task := range tasks {
+				for n := task.from; n <= task.to; n += 2 {
+					p := goldbachMinPrime(n, primeList, isPrime)
+					switch {
+					case p == 0:
+						if r.Counterexample == 0 || n < r.Counterexample {
+							r.Counterexample = n
+						}
+					case p > r.MaxMinPrime || (p == r.MaxMinPrime && n < r.MaxMinPrimeAt):
+						r.MaxMinPrime, r.MaxMinPrimeAt = p, n
+					}
+					if p != 0 {
+						r.Verified++
+					}
+				}
+			}
+		}(&partials[w])
+	}
+
+	for from := 4; from <= report.Limit; from += 2 * goldbachChunk {
+		tasks <- goldbachRange{from: from, to: min(report.Limit, from+2*(goldbachChunk-1))}
+	}
+	close(tasks)
+	wg.Wait()
+
+	// Fusion des résultats partiels des workers.
+	for _, r := range partials {
+		report.Verified += r.Verified
+		if r.Counterexample != 0 && (report.Counterexample == 0 || r.Counterexample < report.Counterexample) {
+			report.Counterexample = r.Counterexample
+		}
+		if r.MaxMinPrime > report.MaxMinPrime || (r.MaxMinPrime == report.MaxMinPrime && r.MaxMinPrimeAt < report.MaxMinPrimeAt) {
+			report.MaxMinPrime, report.MaxMinPrimeAt = r.MaxMinPrime, r.MaxMinPrimeAt
+		}
+	}
+	return report
+}
+
+// goldbachMinPrime retourne le plus petit nombre premier p <= n/2 tel que n - p soit premier, ou 0.
+func goldbachMinPrime(n int, primeList []int, isPrime []bool) int {
+	for _, p := range primeList {
+		if p > n/2 {
+			break
+		}
+		if isPrime[n-p] {
+			return p
+		}
+	}
+	return 0
+}
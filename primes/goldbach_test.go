@@ -0,0 +1,39 @@
+/*
+ * Fichier: goldbach_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la vérification de la conjecture de Goldbach.
+ */
+package primes
+
+import "testing"
+
+// TestVerifyGoldbach valide le rapport sur des valeurs de référence et son indépendance du nombre de workers.
+func TestVerifyGoldbach(t *testing.T) {
+	// Valeurs de référence: 98 = 19 + 79 et 992 = 73 + 919 sont les décompositions minimales les plus tardives.
+	testCases := []struct {
+		limit    int
+		expected GoldbachReport
+	}{
+		{3, GoldbachReport{}},
+		{4, GoldbachReport{Limit: 4, Verified: 1, MaxMinPrime: 2, MaxMinPrimeAt: 4}},
+		{11, GoldbachReport{Limit: 10, Verified: 4, MaxMinPrime: 3, MaxMinPrimeAt: 6}},
+		{100, GoldbachReport{Limit: 100, Verified: 49, MaxMinPrime: 19, MaxMinPrimeAt: 98}},
+		{1001, GoldbachReport{Limit: 1000, Verified: 499, MaxMinPrime: 73, MaxMinPrimeAt: 992}},
+	}
+
+	for _, tc := range testCases {
+		for _, workers := range []int{1, 3} {
+			if got := VerifyGoldbach(tc.limit, workers); got != tc.expected {
+				t.Errorf("VerifyGoldbach(%d, %d) = %+v, attendu %+v", tc.limit, workers, got, tc.expected)
+			}
+		}
+	}
+
+	one, many := VerifyGoldbach(200_000, 1), VerifyGoldbach(200_000, 4)
+	if one != many || one.Counterexample != 0 || one.Verified != 99_999 {
+		t.Errorf("VerifyGoldbach(200000): 1 worker %+v, 4 workers %+v", one, many)
+	}
+}
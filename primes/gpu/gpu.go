@@ -0,0 +1,19 @@
+/*
+ * Fichier: gpu.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Accélérateur GPU du paquet primes (voir primes.Accelerator): le marquage
+ * des segments du crible et le pré-filtre des candidats par petits nombres
+ * premiers sur un GPU OpenCL, les survivants restant testés sur CPU par les
+ * workers. Importer le paquet enregistre l'accélérateur "gpu". Il n'est
+ * compilé qu'avec l'étiquette de build gpu et cgo (opencl.go): sans elle,
+ * primes.OpenAccelerator("gpu") échoue avec primes.ErrNoAccelerator
+ * (stub.go), et le programme reste compilable sans cgo ni SDK OpenCL. Le
+ * code cgo ne peut pas vivre dans primes, qui contient de l'assembleur Go.
+ */
+package gpu
+
+// Name est le nom sous lequel l'accélérateur est enregistré.
+const Name = "gpu"
@@ -0,0 +1,88 @@
+/*
+ * Fichier: gpu_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'accélérateur GPU: sans l'étiquette de build gpu, son absence
+ * signalée; avec elle et un GPU OpenCL, le marquage et le pré-filtre comparés
+ * à l'accélérateur de référence "cpu" (ignorés sans GPU).
+ */
+package gpu
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// openOrSkip ouvre l'accélérateur GPU, ou ignore le test s'il est indisponible.
+func openOrSkip(t *testing.T) primes.Accelerator {
+	t.Helper()
+	acc, err := primes.OpenAccelerator(Name)
+	if errors.Is(err, primes.ErrNoAccelerator) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { acc.Close() })
+	return acc
+}
+
+// TestRegistered vérifie que l'accélérateur est enregistré, disponible ou non.
+func TestRegistered(t *testing.T) {
+	if !slices.Contains(primes.AcceleratorNames(), Name) {
+		t.Fatalf("accélérateurs = %v", primes.AcceleratorNames())
+	}
+	if _, err := primes.OpenAccelerator(Name); err != nil && !errors.Is(err, primes.ErrNoAccelerator) {
+		t.Errorf("ouverture: %v", err)
+	}
+}
+
+// TestMarkSegment compare le marquage du GPU à celui de l'accélérateur de référence.
+func TestMarkSegment(t *testing.T) {
+	acc := openOrSkip(t)
+	ref, _ := primes.OpenAccelerator("cpu")
+	sievePrimes := primes.SieveOfEratosthenes(1000)
+	for _, base := range []int{0, 999_983, 10_000_000} {
+		want, got := make([]bool, 100_000), make([]bool, 100_000)
+		if err := ref.MarkSegment(want, base, sievePrimes); err != nil {
+			t.Fatal(err)
+		}
+		if err := acc.MarkSegment(got, base, sievePrimes); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("base %d: marques différentes", base)
+		}
+	}
+}
+
+// TestPrefilter compare le pré-filtre du GPU à celui de l'accélérateur de référence.
+func TestPrefilter(t *testing.T) {
+	acc := openOrSkip(t)
+	ref, _ := primes.OpenAccelerator("cpu")
+	var ns []int64
+	for n := int64(-2); n < 20_000; n++ {
+		ns = append(ns, n)
+	}
+	ns = append(ns, 1021*1031, 1<<62+1, 9_223_372_036_854_775_783)
+	want, got := make([]bool, len(ns)), make([]bool, len(ns))
+	for i := range ns {
+		want[i], got[i] = true, true
+	}
+	if err := ref.Prefilter(ns, want); err != nil {
+		t.Fatal(err)
+	}
+	if err := acc.Prefilter(ns, got); err != nil {
+		t.Fatal(err)
+	}
+	for i := range ns {
+		if got[i] != want[i] {
+			t.Errorf("%d: gardé %v, attendu %v", ns[i], got[i], want[i])
+		}
+	}
+}
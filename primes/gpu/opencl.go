@@ -0,0 +1,232 @@
+//go:build gpu && cgo
+
+/*
+ * Fichier: opencl.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Accélérateur "gpu" sur OpenCL 1.2 (étiquette de build gpu, cgo et un
+ * pilote OpenCL: -lOpenCL sous Linux et Windows, cadre OpenCL sous macOS).
+ * Le premier GPU de la première plateforme qui en a un est retenu. Deux
+ * noyaux: mark_segment (un élément de travail par nombre premier du crible,
+ * qui marque ses multiples dans le segment) et prefilter (un élément de
+ * travail par candidat, divisé par les petits nombres premiers). Les appels
+ * sont sérialisés: les arguments des noyaux sont partagés.
+ */
+package gpu
+
+/*
+#cgo linux LDFLAGS: -lOpenCL
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#define CL_TARGET_OPENCL_VERSION 120
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+#include <stdlib.h>
+
+typedef struct {
+	cl_context ctx;
+	cl_command_queue queue;
+	cl_program prog;
+	cl_kernel mark;
+	cl_kernel prefilter;
+} pn_gpu;
+
+// pn_open ouvre le premier GPU trouvé, dont le nom est copié dans name, et compile src.
+static cl_int pn_open(pn_gpu *g, const char *src, char *name, size_t name_len) {
+	cl_platform_id plats[16];
+	cl_uint nplat = 0;
+	cl_int err = clGetPlatformIDs(16, plats, &nplat);
+	if (err != CL_SUCCESS) return err;
+	if (nplat > 16) nplat = 16;
+	cl_device_id dev = NULL;
+	for (cl_uint i = 0; i < nplat && dev == NULL; i++) {
+		if (clGetDeviceIDs(plats[i], CL_DEVICE_TYPE_GPU, 1, &dev, NULL) != CL_SUCCESS) dev = NULL;
+	}
+	if (dev == NULL) return CL_DEVICE_NOT_FOUND;
+	if ((err = clGetDeviceInfo(dev, CL_DEVICE_NAME, name_len, name, NULL)) != CL_SUCCESS) return err;
+	g->ctx = clCreateContext(NULL, 1, &dev, NULL, NULL, &err);
+	if (err != CL_SUCCESS) return err;
+	g->queue = clCreateCommandQueue(g->ctx, dev, 0, &err);
+	if (err != CL_SUCCESS) return err;
+	g->prog = clCreateProgramWithSource(g->ctx, 1, &src, NULL, &err);
+	if (err != CL_SUCCESS) return err;
+	if ((err = clBuildProgram(g->prog, 1, &dev, NULL, NULL, NULL)) != CL_SUCCESS) return err;
+	g->mark = clCreateKernel(g->prog, "mark_segment", &err);
+	if (err != CL_SUCCESS) return err;
+	g->prefilter = clCreateKernel(g->prog, "prefilter", &err);
+	return err;
+}
+
+// pn_close libère ce que pn_open a créé.
+static void pn_close(pn_gpu *g) {
+	if (g->prefilter) clReleaseKernel(g->prefilter);
+	if (g->mark) clReleaseKernel(g->mark);
+	if (g->prog) clReleaseProgram(g->prog);
+	if (g->queue) clReleaseCommandQueue(g->queue);
+	if (g->ctx) clReleaseContext(g->ctx);
+}
+
+// pn_run exécute kernel sur global éléments de travail, ses arguments 2 et suivants déjà fixés:
+// host (len octets, argument 0) est copié vers le GPU puis relu, in (in_len octets, argument 1)
+// est copié en lecture seule.
+static cl_int pn_run(pn_gpu *g, cl_kernel kernel, size_t global, void *host, size_t len, const void *in, size_t in_len) {
+	cl_int err;
+	cl_mem dhost = clCreateBuffer(g->ctx, CL_MEM_READ_WRITE | CL_MEM_COPY_HOST_PTR, len, host, &err);
+	if (err != CL_SUCCESS) return err;
+	cl_mem din = clCreateBuffer(g->ctx, CL_MEM_READ_ONLY | CL_MEM_COPY_HOST_PTR, in_len, (void *)in, &err);
+	if (err != CL_SUCCESS) {
+		clReleaseMemObject(dhost);
+		return err;
+	}
+	err = clSetKernelArg(kernel, 0, sizeof(cl_mem), &dhost);
+	if (err == CL_SUCCESS) err = clSetKernelArg(kernel, 1, sizeof(cl_mem), &din);
+	if (err == CL_SUCCESS) err = clEnqueueNDRangeKernel(g->queue, kernel, 1, NULL, &global, NULL, 0, NULL, NULL);
+	if (err == CL_SUCCESS) err = clEnqueueReadBuffer(g->queue, dhost, CL_TRUE, 0, len, host, 0, NULL, NULL);
+	clReleaseMemObject(din);
+	clReleaseMemObject(dhost);
+	return err;
+}
+
+// pn_mark marque dans composite (len octets, à partir de base) les multiples des nprimes nombres
+// premiers de primes.
+static cl_int pn_mark(pn_gpu *g, unsigned char *composite, size_t len, cl_long base, const cl_long *primes, size_t nprimes) {
+	cl_ulong ulen = len;
+	cl_int err = clSetKernelArg(g->mark, 2, sizeof(ulen), &ulen);
+	if (err == CL_SUCCESS) err = clSetKernelArg(g->mark, 3, sizeof(base), &base);
+	if (err != CL_SUCCESS) return err;
+	return pn_run(g, g->mark, nprimes, composite, len, primes, nprimes * sizeof(cl_long));
+}
+
+// pn_prefilter met keep[i] à 0 pour chacun des n candidats de ns qui a un petit facteur premier
+// parmi les nsmall de small.
+static cl_int pn_prefilter(pn_gpu *g, unsigned char *keep, const cl_long *ns, size_t n, const cl_long *small, cl_uint nsmall) {
+	cl_int err;
+	cl_mem dsmall = clCreateBuffer(g->ctx, CL_MEM_READ_ONLY | CL_MEM_COPY_HOST_PTR, nsmall * sizeof(cl_long), (void *)small, &err);
+	if (err != CL_SUCCESS) return err;
+	err = clSetKernelArg(g->prefilter, 2, sizeof(nsmall), &nsmall);
+	if (err == CL_SUCCESS) err = clSetKernelArg(g->prefilter, 3, sizeof(cl_mem), &dsmall);
+	if (err == CL_SUCCESS) err = pn_run(g, g->prefilter, n, keep, n, ns, n * sizeof(cl_long));
+	clReleaseMemObject(dsmall);
+	return err;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+func init() { primes.RegisterAccelerator(Name, open) }
+
+// gpuKernels est le source OpenCL C des deux noyaux. mark_segment ne fait qu'écrire 1: les
+// écritures concurrentes d'un même octet par deux nombres premiers sont sans conséquence.
+const gpuKernels = `
+__kernel void mark_segment(__global uchar *composite, __global const long *primes, ulong len, long base) {
+	long p = primes[get_global_id(0)];
+	long first = p * p;
+	if (first < base) first = (base + p - 1) / p * p;
+	for (long i = first - base; i < (long)len; i += p) composite[i] = 1;
+}
+
+__kernel void prefilter(__global uchar *keep, __global const long *ns, uint nsmall, __global const long *small) {
+	size_t i = get_global_id(0);
+	long n = ns[i];
+	if (n < 2) return;
+	for (uint j = 0; j < nsmall; j++) {
+		long p = small[j];
+		if (p >= n) return;
+		if (n % p == 0) {
+			keep[i] = 0;
+			return;
+		}
+	}
+}
+`
+
+// gpuAccelerator est l'accélérateur OpenCL.
+type gpuAccelerator struct {
+	mu     sync.Mutex // Sérialise les appels: arguments des noyaux partagés.
+	g      *C.pn_gpu  // Alloué en C: contient des pointeurs C.
+	device string
+	small  []int64 // primes.PrefilterPrimes, en cl_long.
+}
+
+// open ouvre le premier GPU OpenCL et y compile les noyaux.
+func open() (primes.Accelerator, error) {
+	g := (*C.pn_gpu)(C.calloc(1, C.size_t(unsafe.Sizeof(C.pn_gpu{}))))
+	src := C.CString(gpuKernels)
+	defer C.free(unsafe.Pointer(src))
+	var name [256]C.char
+	if code := C.pn_open(g, src, &name[0], C.size_t(len(name))); code != C.CL_SUCCESS {
+		C.pn_close(g)
+		C.free(unsafe.Pointer(g))
+		if code == C.CL_DEVICE_NOT_FOUND {
+			return nil, fmt.Errorf("%w: gpu: aucun GPU OpenCL", primes.ErrNoAccelerator)
+		}
+		return nil, fmt.Errorf("%w: gpu: %v", primes.ErrNoAccelerator, clError(code))
+	}
+	small := primes.PrefilterPrimes()
+	a := &gpuAccelerator{g: g, device: C.GoString(&name[0]), small: make([]int64, len(small))}
+	for i, p := range small {
+		a.small[i] = int64(p)
+	}
+	return a, nil
+}
+
+func (a *gpuAccelerator) Name() string { return Name }
+
+// String retourne le nom du périphérique.
+func (a *gpuAccelerator) String() string { return "gpu (" + a.device + ")" }
+
+func (a *gpuAccelerator) MarkSegment(composite []bool, base int, sievePrimes []int) error {
+	if len(composite) == 0 || len(sievePrimes) == 0 {
+		return nil
+	}
+	ps := make([]int64, len(sievePrimes))
+	for i, p := range sievePrimes {
+		ps[i] = int64(p)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Un bool occupe un octet valant 0 ou 1, comme les marques du noyau.
+	return clError(C.pn_mark(a.g, (*C.uchar)(unsafe.Pointer(&composite[0])), C.size_t(len(composite)),
+		C.cl_long(base), (*C.cl_long)(unsafe.Pointer(&ps[0])), C.size_t(len(ps))))
+}
+
+func (a *gpuAccelerator) Prefilter(ns []int64, keep []bool) error {
+	if len(ns) == 0 {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return clError(C.pn_prefilter(a.g, (*C.uchar)(unsafe.Pointer(&keep[0])), (*C.cl_long)(unsafe.Pointer(&ns[0])),
+		C.size_t(len(ns)), (*C.cl_long)(unsafe.Pointer(&a.small[0])), C.cl_uint(len(a.small))))
+}
+
+func (a *gpuAccelerator) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.g != nil {
+		C.pn_close(a.g)
+		C.free(unsafe.Pointer(a.g))
+		a.g = nil
+	}
+	return nil
+}
+
+// clError retourne nil pour CL_SUCCESS, sinon l'erreur OpenCL code.
+func clError(code C.cl_int) error {
+	if code == C.CL_SUCCESS {
+		return nil
+	}
+	return fmt.Errorf("OpenCL: erreur %d", int(code))
+}
@@ -0,0 +1,25 @@
+//go:build !gpu || !cgo
+
+/*
+ * Fichier: stub.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Accélérateur GPU absent: compilation sans l'étiquette de build gpu, ou
+ * sans cgo.
+ */
+package gpu
+
+import (
+	"fmt"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+func init() { primes.RegisterAccelerator(Name, open) }
+
+// open signale l'absence du backend OpenCL dans cette compilation.
+func open() (primes.Accelerator, error) {
+	return nil, fmt.Errorf("%w: gpu (compiler avec -tags gpu et cgo, et un pilote OpenCL)", primes.ErrNoAccelerator)
+}
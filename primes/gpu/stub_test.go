@@ -0,0 +1,25 @@
+//go:build !gpu || !cgo
+
+/*
+ * Fichier: stub_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Test de l'accélérateur GPU absent, hors étiquette de build gpu.
+ */
+package gpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestUnavailable vérifie que l'accélérateur signale son absence de cette compilation.
+func TestUnavailable(t *testing.T) {
+	if _, err := primes.OpenAccelerator(Name); !errors.Is(err, primes.ErrNoAccelerator) {
+		t.Errorf("ouverture sans -tags gpu: %v, attendu ErrNoAccelerator", err)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * Fichier: integration.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Outils des tests d'intégration de bout en bout du paquet primes: un oracle
+ * séquentiel, indépendant du moteur de recherche (énumération directe de la
+ * région de paires, test des candidats par math/big), et l'exécution complète
+ * d'une recherche par l'API de la bibliothèque selon plusieurs modes (simple,
+ * répartie en parts, interrompue puis reprise d'après son point de reprise).
+ * Chaque mode doit retrouver exactement l'ensemble de résultats de l'oracle:
+ * les tests (integration_test.go) croisent algorithmes, formes, régions de
+ * paires, parts et reprises sur de petites limites, pour qu'une évolution
+ * d'un mode ne diverge pas en silence des autres.
+ */
+package integration
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync/atomic"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// Expected retourne, triés par primes.CompareResults, les résultats attendus de la recherche opts:
+// énumération séquentielle des paires de la région opts.Pairs (bornes Min, Limit ou Primes, PMin
+// et PMax), sans lots ni workers, où chaque candidat de la forme (DefaultForm par défaut) non
+// écarté par Prune est testé par big.Int.ProbablyPrime, exact en deçà de 2^64, puis par opts.Filter.
+// Twin est renseigné avec opts.Twins. Les autres options (test de primalité, workers, lots,
+// source de paires...) sont sans effet sur l'ensemble attendu et sont ignorées.
+func Expected(opts primes.Options) []primes.Result {
+	form := opts.Form
+	if form == nil {
+		form = primes.DefaultForm
+	}
+	primeList := opts.Primes
+	if len(primeList) == 0 {
+		for n := 2; n <= opts.Limit; n++ {
+			if isPrime(int64(n)) {
+				primeList = append(primeList, n)
+			}
+		}
+	}
+	var results []primes.Result
+	for _, p := range primeList {
+		if p < opts.Min || p < opts.PMin || (opts.PMax > 0 && p > opts.PMax) {
+			continue
+		}
+		for _, q := range primeList {
+			if q < opts.Min || !opts.Pairs.Contains(p, q) || form.Prune(int64(p), int64(q)) {
+				continue
+			}
+			n := form.Eval(int64(p), int64(q))
+			if !isPrime(n) || (opts.Filter != nil && !opts.Filter.Accept(n)) {
+				continue
+			}
+			res := primes.Result{P: p, Q: q, N: n}
+			if opts.Twins {
+				res.Twin = isPrime(n-2) || isPrime(n+2)
+			}
+			results = append(results, res)
+		}
+	}
+	slices.SortFunc(results, primes.CompareResults)
+	return results
+}
+
+// isPrime teste n par math/big, sans passer par les tests du paquet primes.
+func isPrime(n int64) bool {
+	return n > 1 && big.NewInt(n).ProbablyPrime(20)
+}
+
+// Run exécute la recherche opts par primes.Search et retourne ses résultats triés par
+// primes.CompareResults et sa dernière progression. opts.OnProgress, s'il est fourni, est
+// toujours appelé.
+func Run(ctx context.Context, opts primes.Options) ([]primes.Result, primes.Progress, error) {
+	var results []primes.Result
+	var last primes.Progress
+	onProgress := opts.OnProgress
+	opts.OnProgress = func(pr primes.Progress) {
+		last = pr
+		if onProgress != nil {
+			onProgress(pr)
+		}
+	}
+	err := primes.Search(ctx, opts, func(res primes.Result) error {
+		results = append(results, res)
+		return nil
+	})
+	slices.SortFunc(results, primes.CompareResults)
+	return results, last, err
+}
+
+// RunSharded exécute la recherche opts sur la grille de primeList en shards parts indépendantes
+// (primes.NewShardSource), l'une après l'autre, comme autant d'exécutions séparées, et retourne
+// l'union triée de leurs résultats. Une paire présente dans deux parts est une erreur.
+func RunSharded(ctx context.Context, opts primes.Options, primeList []int, shards int) ([]primes.Result, error) {
+	var all []primes.Result
+	var tested int64
+	for shard := range shards {
+		part := opts
+		part.Primes = primeList
+		part.Jobs = primes.NewShardSource(primes.NewGridSource(primeList, opts.Pairs, opts.PMin, opts.PMax), shard, shards)
+		results, last, err := Run(ctx, part)
+		if err != nil {
+			return nil, fmt.Errorf("part %d/%d: %w", shard, shards, err)
+		}
+		tested += last.Tested
+		all = append(all, results...)
+	}
+	if want := opts.Pairs.Count(len(primeList)); opts.PMin == 0 && opts.PMax == 0 && tested != want {
+		return nil, fmt.Errorf("%d parts: %d paires testées, attendu %d", shards, tested, want)
+	}
+	return merge(all)
+}
+
+// RunResumed exécute la recherche opts sur la grille de primeList en l'interrompant (annulation
+// du contexte) dès son stopAfter-ième candidat testé, puis la reprend d'après la *primes.PartialError
+// obtenue (primes.NewResumeSource), comme une reprise sur point de contrôle. Elle retourne
+// l'union triée des résultats des deux exécutions et le nombre de paires de la première
+// (celui de la grille entière si elle s'est achevée avant l'interruption). Un résultat transmis
+// par les deux exécutions est une erreur.
+func RunResumed(ctx context.Context, opts primes.Options, primeList []int, stopAfter int) ([]primes.Result, int64, error) {
+	first, cancel := context.WithCancel(ctx)
+	defer cancel()
+	grid := func() primes.JobSource {
+		return primes.NewGridSource(primeList, opts.Pairs, opts.PMin, opts.PMax)
+	}
+	isPrime := opts.PrimeTestFunc
+	if isPrime == nil {
+		isPrime = primes.PrimalityTest(cmp.Or(opts.PrimeTest, "miller"))
+	}
+	var tested atomic.Int64
+	opts.Primes = primeList
+	opts.Jobs = grid()
+	opts.PrimeTestFunc = func(n int64) bool {
+		if tested.Add(1) == int64(stopAfter) {
+			cancel()
+		}
+		return isPrime(n)
+	}
+	var results []primes.Result
+	err := primes.Search(first, opts, func(res primes.Result) error {
+		results = append(results, res)
+		return nil
+	})
+	var partial *primes.PartialError
+	switch {
+	case err == nil:
+		all, err := merge(results)
+		return all, opts.Pairs.Count(len(primeList)), err
+	case !errors.As(err, &partial):
+		return nil, 0, err
+	case partial.Results != len(results):
+		return nil, 0, fmt.Errorf("interruption: %d résultats annoncés, %d reçus", partial.Results, len(results))
+	}
+
+	opts.Jobs, opts.PrimeTestFunc = primes.NewResumeSource(grid(), partial.Completed), isPrime
+	rest, _, err := Run(ctx, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reprise après %d paires: %w", partial.Completed, err)
+	}
+	all, err := merge(append(results, rest...))
+	return all, partial.Completed, err
+}
+
+// merge trie results et vérifie qu'aucune paire n'y figure deux fois.
+func merge(results []primes.Result) ([]primes.Result, error) {
+	slices.SortFunc(results, primes.CompareResults)
+	for i := 1; i < len(results); i++ {
+		if primes.CompareResults(results[i-1], results[i]) == 0 {
+			return nil, fmt.Errorf("paire (%d, %d) transmise deux fois", results[i].P, results[i].Q)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,285 @@
+/*
+ * Fichier: integration_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests d'intégration de bout en bout: chaque mode de recherche (algorithmes,
+ * formes, régions de paires, workers et lots, sources de paires, parts,
+ * reprise, recherche inverse) doit retrouver l'ensemble de résultats de
+ * l'oracle séquentiel Expected.
+ */
+package integration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// testLimit est la limite des matrices de tests; aksLimit celle du test AKS, très lent.
+const (
+	testLimit = 120
+	aksLimit  = 20
+)
+
+// forms retourne les formes enregistrées.
+func forms(t *testing.T) []primes.Form {
+	t.Helper()
+	var list []primes.Form
+	for _, name := range primes.FormNames() {
+		f, ok := primes.LookupForm(name)
+		if !ok {
+			t.Fatalf("LookupForm(%q) introuvable", name)
+		}
+		list = append(list, f)
+	}
+	return list
+}
+
+// pairModes retourne les régions de paires.
+func pairModes() []primes.PairMode {
+	var list []primes.PairMode
+	for _, name := range primes.PairModeNames() {
+		m, _ := primes.LookupPairMode(name)
+		list = append(list, m)
+	}
+	return list
+}
+
+// checkResults compare des résultats triés à ceux attendus (p, q, n et Twin).
+func checkResults(t *testing.T, got, want []primes.Result) {
+	t.Helper()
+	same := func(a, b primes.Result) bool { return a.P == b.P && a.Q == b.Q && a.N == b.N && a.Twin == b.Twin }
+	if slices.EqualFunc(got, want, same) {
+		return
+	}
+	for i := range min(len(got), len(want)) {
+		if !same(got[i], want[i]) {
+			t.Fatalf("%d résultats, attendu %d; premier écart au rang %d: %+v, attendu %+v", len(got), len(want), i, got[i], want[i])
+		}
+	}
+	t.Fatalf("%d résultats, attendu %d", len(got), len(want))
+}
+
+// TestExpected valide l'oracle sur des valeurs connues.
+func TestExpected(t *testing.T) {
+	got := Expected(primes.Options{Limit: 10})
+	checkResults(t, got, []primes.Result{{P: 5, Q: 2, N: 41}, {P: 5, Q: 3, N: 61}, {P: 3, Q: 5, N: 109}, {P: 7, Q: 5, N: 149}})
+
+	got = Expected(primes.Options{Limit: 10, Form: primes.FormX2Plus1, Twins: true})
+	checkResults(t, got, []primes.Result{{P: 2, Q: 2, N: 5, Twin: true}})
+}
+
+// checkSuperset vérifie que des résultats triés contiennent ceux attendus: les autres doivent
+// être des pseudo-premiers (n composé) d'un test probabiliste.
+func checkSuperset(t *testing.T, got, want []primes.Result) {
+	t.Helper()
+	for _, res := range got {
+		if _, found := slices.BinarySearchFunc(want, res, primes.CompareResults); !found && isPrime(res.N) {
+			t.Fatalf("résultat %+v absent de l'oracle", res)
+		}
+	}
+	for _, res := range want {
+		if _, found := slices.BinarySearchFunc(got, res, primes.CompareResults); !found {
+			t.Fatalf("résultat attendu %+v manquant", res)
+		}
+	}
+}
+
+// TestAlgorithms croise tests de primalité, formes et régions de paires. Le test de Lucas fort
+// seul, probabiliste, peut en plus retenir des pseudo-premiers (10877 = 73 x 149).
+func TestAlgorithms(t *testing.T) {
+	for _, algo := range primes.PrimalityTestNames() {
+		limit := testLimit
+		if algo == "aks" {
+			limit = aksLimit
+		}
+		for _, form := range forms(t) {
+			for _, pairs := range pairModes() {
+				t.Run(fmt.Sprintf("%s/%s/%s", algo, form.Name(), pairs), func(t *testing.T) {
+					t.Parallel()
+					opts := primes.Options{Limit: limit, PrimeTest: algo, Form: form, Pairs: pairs, Workers: 3, BatchSize: 7}
+					got, last, err := Run(context.Background(), opts)
+					if err != nil {
+						t.Fatalf("Search() = %v", err)
+					}
+					if algo == "lucas" {
+						checkSuperset(t, got, Expected(opts))
+					} else {
+						checkResults(t, got, Expected(opts))
+					}
+					if want := pairs.Count(len(primes.SieveOfEratosthenes(limit))); last.Tested != want || last.Total != want || last.Found != int64(len(got)) {
+						t.Errorf("progression finale = %d/%d paires, %d résultats; attendu %d paires et %d résultats", last.Tested, last.Total, last.Found, want, len(got))
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestExecutionModes fait varier, pour chaque forme, l'exécution de la recherche: workers, lots,
+// ordre des paires, chien de garde, contrôle, filtre, jumeaux et résultats par canal.
+func TestExecutionModes(t *testing.T) {
+	primeList := primes.SieveOfEratosthenes(testLimit)
+	type mode struct {
+		name  string
+		opts  []primes.Option
+		shell bool // Paires par couronnes (ShellSource).
+		ch    bool // Résultats lus par SearchChan.
+	}
+	modes := []mode{
+		{name: "séquentiel", opts: []primes.Option{primes.WithWorkers(1), primes.WithBatchSize(1)}},
+		{name: "lots de 1000", opts: []primes.Option{primes.WithWorkers(4), primes.WithBatchSize(1000)}},
+		{name: "lots adaptatifs", opts: []primes.Option{primes.WithWorkers(3), primes.WithBatchTarget(time.Millisecond)}},
+		{name: "tampons minimaux", opts: []primes.Option{primes.WithWorkers(2), primes.WithBatchSize(3), primes.WithBuffers(1, 1)}},
+		{name: "couronnes", opts: []primes.Option{primes.WithWorkers(3)}, shell: true},
+		{name: "chien de garde", opts: []primes.Option{primes.WithWorkers(3), primes.WithWatchdog(time.Minute, nil), primes.WithSkipStalled()}},
+		{name: "contrôle", opts: []primes.Option{primes.WithWorkers(3), primes.WithControl(primes.NewControl())}},
+		{name: "jumeaux", opts: []primes.Option{primes.WithWorkers(3), primes.WithTwins(), primes.WithTiming()}},
+		{name: "canal", opts: []primes.Option{primes.WithWorkers(3), primes.WithBatchSize(5)}, ch: true},
+	}
+	for _, name := range primes.FilterNames() {
+		filter, _ := primes.LookupFilter(name)
+		modes = append(modes, mode{name: "filtre " + name, opts: []primes.Option{primes.WithWorkers(3), primes.WithFilter(filter)}})
+	}
+
+	for _, form := range forms(t) {
+		for _, mode := range modes {
+			t.Run(form.Name()+"/"+mode.name, func(t *testing.T) {
+				t.Parallel()
+				var opts primes.Options
+				for _, opt := range append([]primes.Option{primes.WithPrimes(primeList), primes.WithForm(form)}, mode.opts...) {
+					opt(&opts)
+				}
+				if mode.shell { // Une source n'est parcourue qu'une fois: une par sous-test.
+					opts.Jobs = primes.NewShellSource(primeList, opts.Pairs)
+				}
+				var got []primes.Result
+				var err error
+				if mode.ch {
+					results, errc := primes.SearchChan(context.Background(), opts)
+					for res := range results {
+						got = append(got, res)
+					}
+					err = <-errc
+					slices.SortFunc(got, primes.CompareResults)
+				} else {
+					got, _, err = Run(context.Background(), opts)
+				}
+				if err != nil {
+					t.Fatalf("Search() = %v", err)
+				}
+				checkResults(t, got, Expected(opts))
+			})
+		}
+	}
+}
+
+// TestShards vérifie que des parts indépendantes de la grille en retrouvent exactement les
+// résultats, tranches de p comprises.
+func TestShards(t *testing.T) {
+	primeList := primes.SieveOfEratosthenes(testLimit)
+	for _, form := range forms(t) {
+		for _, pairs := range pairModes() {
+			for _, shards := range []int{1, 2, 3, 7} {
+				t.Run(fmt.Sprintf("%s/%s/%d parts", form.Name(), pairs, shards), func(t *testing.T) {
+					t.Parallel()
+					opts := primes.Options{Form: form, Pairs: pairs, Workers: 2, BatchSize: 4}
+					got, err := RunSharded(context.Background(), opts, primeList, shards)
+					if err != nil {
+						t.Fatal(err)
+					}
+					opts.Primes = primeList
+					checkResults(t, got, Expected(opts))
+
+					opts.PMin, opts.PMax = 11, 61
+					got, err = RunSharded(context.Background(), opts, primeList, shards)
+					if err != nil {
+						t.Fatal(err)
+					}
+					checkResults(t, got, Expected(opts))
+				})
+			}
+		}
+	}
+}
+
+// TestResume interrompt la recherche à différents stades et vérifie que la reprise d'après le
+// point de reprise complète exactement les résultats, sans doublon ni oubli. Une forme qui teste
+// moins de candidats que le stade visé s'achève sans interruption.
+func TestResume(t *testing.T) {
+	primeList := primes.SieveOfEratosthenes(testLimit)
+	for _, form := range forms(t) {
+		for _, pairs := range []primes.PairMode{primes.PairsAll, primes.PairsLess} {
+			opts := primes.Options{Primes: primeList, Form: form, Pairs: pairs, Workers: 3, BatchSize: 5, JobsBuffer: 1}
+			want := Expected(opts)
+			for _, stopAfter := range []int{1, 10, 100, 400} {
+				t.Run(fmt.Sprintf("%s/%s/après %d candidats", form.Name(), pairs, stopAfter), func(t *testing.T) {
+					t.Parallel()
+					got, completed, err := RunResumed(context.Background(), opts, primeList, stopAfter)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if total := pairs.Count(len(primeList)); completed < 1 || completed > total {
+						t.Errorf("point de reprise = %d paires, attendu dans [1, %d]", completed, total)
+					}
+					checkResults(t, got, want)
+				})
+			}
+		}
+	}
+}
+
+// TestReverse vérifie que la recherche inverse retrouve les résultats de l'oracle pour la forme
+// p^2 + 4q^2, régions de paires, bornes et filtres compris.
+func TestReverse(t *testing.T) {
+	for _, pairs := range pairModes() {
+		for _, bounds := range [][4]int{{0, testLimit, 0, 0}, {5, testLimit, 0, 0}, {0, testLimit, 11, 61}} {
+			t.Run(fmt.Sprintf("%s/%v", pairs, bounds), func(t *testing.T) {
+				t.Parallel()
+				opts := primes.Options{Min: bounds[0], Limit: bounds[1], PMin: bounds[2], PMax: bounds[3], Pairs: pairs, Workers: 2, Twins: true}
+				var got []primes.Result
+				err := primes.SearchReverse(context.Background(), opts, func(res primes.Result) error {
+					got = append(got, res)
+					return nil
+				})
+				if err != nil {
+					t.Fatalf("SearchReverse() = %v", err)
+				}
+				if !slices.IsSortedFunc(got, primes.CompareResults) {
+					t.Errorf("résultats non triés par n croissant")
+				}
+				checkResults(t, got, Expected(opts))
+			})
+		}
+	}
+}
+
+// TestLargerLimit reprend les modes principaux sur une limite plus grande (omis avec -short).
+func TestLargerLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("limite de 3000 omise avec -short")
+	}
+	primeList := primes.SieveOfEratosthenes(3000)
+	opts := primes.Options{Primes: primeList, Workers: 4, BatchSize: 256}
+	want := Expected(opts)
+
+	got, _, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Search() = %v", err)
+	}
+	checkResults(t, got, want)
+	if got, err = RunSharded(context.Background(), opts, primeList, 5); err != nil {
+		t.Fatal(err)
+	}
+	checkResults(t, got, want)
+	if got, _, err = RunResumed(context.Background(), opts, primeList, 10000); err != nil {
+		t.Fatal(err)
+	}
+	checkResults(t, got, want)
+}
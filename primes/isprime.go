@@ -0,0 +1,39 @@
+/*
+ * Fichier: isprime.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Point d'entrée unique des tests de primalité pour les utilisateurs de la
+ * bibliothèque: l'algorithme est choisi automatiquement selon la taille de
+ * l'entrée (division successive pour les petits nombres, Miller-Rabin
+ * déterministe sur 64 bits, y compris les uint64, Baillie-PSW au-delà).
+ */
+package primes
+
+import "math/big"
+
+// trialDivisionThreshold est la borne en deçà de laquelle la division successive
+// (au plus √n/3 divisions) est plus rapide que Miller-Rabin.
+const trialDivisionThreshold = 1 << 20
+
+// IsPrime indique si n est premier. Le résultat est exact pour tout int64.
+func IsPrime(n int64) bool {
+	if n < trialDivisionThreshold {
+		return IsPrimeTrialDivision(n)
+	}
+	return IsPrimeMillerRabin64(n)
+}
+
+// IsPrimeBig indique si n est premier. Le résultat est exact lorsque n tient dans un uint64
+// (voir IsPrime et IsPrimeUint64); au-delà, le test de Baillie-PSW est utilisé
+// (big.Int.ProbablyPrime(0)), pour lequel aucun contre-exemple n'est connu.
+func IsPrimeBig(n *big.Int) bool {
+	if n.IsInt64() {
+		return IsPrime(n.Int64())
+	}
+	if n.IsUint64() {
+		return IsPrimeUint64(n.Uint64())
+	}
+	return n.Sign() > 0 && n.ProbablyPrime(0)
+}
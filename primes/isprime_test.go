@@ -0,0 +1,70 @@
+/*
+ * Fichier: isprime_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du point d'entrée IsPrime / IsPrimeBig.
+ */
+package primes
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestIsPrime compare IsPrime au crible de part et d'autre du seuil de la division successive,
+// puis valide quelques grands nombres.
+func TestIsPrime(t *testing.T) {
+	limit := trialDivisionThreshold + 2000
+	primeSet := make(map[int]bool)
+	for _, p := range SieveOfEratosthenes(limit) {
+		primeSet[p] = true
+	}
+	for n := -5; n <= limit; n++ {
+		if n > 3000 && n < trialDivisionThreshold-2000 {
+			continue
+		}
+		if got := IsPrime(int64(n)); got != primeSet[n] {
+			t.Fatalf("IsPrime(%d) = %v, attendu %v", n, got, primeSet[n])
+		}
+	}
+
+	testCases := []struct {
+		n        int64
+		expected bool
+	}{
+		{1_000_000_007, true},
+		{3_215_031_751, false}, // Pseudo-premier fort pour les bases 2, 3, 5 et 7.
+		{math.MaxInt64 - 24, true},
+		{math.MaxInt64, false},
+	}
+	for _, tc := range testCases {
+		if got := IsPrime(tc.n); got != tc.expected {
+			t.Errorf("IsPrime(%d) = %v, attendu %v", tc.n, got, tc.expected)
+		}
+	}
+}
+
+// TestIsPrimeBig valide IsPrimeBig en deçà et au-delà de la capacité d'un int64.
+func TestIsPrimeBig(t *testing.T) {
+	mersenne127 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	testCases := []struct {
+		name     string
+		n        *big.Int
+		expected bool
+	}{
+		{"Négatif", big.NewInt(-7), false},
+		{"Petit premier", big.NewInt(97), true},
+		{"int64 composé", big.NewInt(3_215_031_751), false},
+		{"Mersenne 2^127-1", mersenne127, true},
+		{"(2^127-1)^2", new(big.Int).Mul(mersenne127, mersenne127), false},
+		{"2^127+1", new(big.Int).Add(mersenne127, big.NewInt(2)), false},
+	}
+	for _, tc := range testCases {
+		if got := IsPrimeBig(tc.n); got != tc.expected {
+			t.Errorf("%s: IsPrimeBig(%s) = %v, attendu %v", tc.name, tc.n, got, tc.expected)
+		}
+	}
+}
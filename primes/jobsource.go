@@ -0,0 +1,317 @@
+/*
+ * Fichier: jobsource.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sources des paires (p, q) distribuées aux workers. Le producteur de la
+ * recherche ne parcourt plus lui-même la grille: il lit une JobSource. La
+ * grille (complète, triangle ou autre région de PairMode, tranche de p
+ * comprise) est la source par défaut, aussi parcourue par couronnes de petits
+ * p et q d'abord (ShellSource); les autres sources la partagent entre
+ * exécutions (ShardSource), reprennent une énumération après un point de
+ * reprise (ResumeSource), la prolongent au-delà d'une ancienne limite
+ * (FrontierSource) ou lisent les paires d'un flux texte (ReaderSource).
+ * Chaque source est un itérateur simple, testable sans lancer de recherche.
+ */
+package primes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JobSource produit les paires (p, q) d'une recherche, une à la fois. Elle est lue par une seule
+// goroutine, le producteur de la recherche.
+type JobSource interface {
+	// Next retourne la paire suivante, ou ok = false quand la source est épuisée.
+	Next() (job Job, ok bool)
+}
+
+// jobSourceLen est implémentée par les sources dont le nombre de paires est connu d'avance, pour
+// la progression (Progress.Total). Len retourne -1 s'il est inconnu.
+type jobSourceLen interface {
+	Len() int64
+}
+
+// jobSourceErr est implémentée par les sources qui peuvent échouer (lecture d'un flux): Err est
+// consultée une fois la source épuisée.
+type jobSourceErr interface {
+	Err() error
+}
+
+// sourceLen retourne le nombre de paires de src, -1 s'il est inconnu.
+func sourceLen(src JobSource) int64 {
+	if l, ok := src.(jobSourceLen); ok {
+		return l.Len()
+	}
+	return -1
+}
+
+// GridSource énumère la région pairs de la grille formée par une liste croissante de nombres
+// premiers, p restreint à la tranche [pMin, pMax] (pMax = 0: aucune borne), dans l'ordre de p
+// puis de q.
+type GridSource struct {
+	primes     []int
+	pairs      PairMode
+	pMin, pMax int
+
+	i, j, hi int  // Indices de p et du prochain q, et fin des q de p.
+	inRow    bool // Les q de primes[i] sont en cours d'énumération.
+}
+
+// NewGridSource retourne la source de la région pairs de la grille de primeList (triée), p
+// restreint à [pMin, pMax] (pMax = 0: aucune borne supérieure).
+func NewGridSource(primeList []int, pairs PairMode, pMin, pMax int) *GridSource {
+	return &GridSource{primes: primeList, pairs: pairs, pMin: pMin, pMax: pMax}
+}
+
+// pInRange indique si p appartient à la tranche [pMin, pMax].
+func (s *GridSource) pInRange(p int) bool {
+	return p >= s.pMin && (s.pMax == 0 || p <= s.pMax)
+}
+
+// Next implémente JobSource.
+func (s *GridSource) Next() (Job, bool) {
+	for s.i < len(s.primes) {
+		p := s.primes[s.i]
+		if !s.inRow {
+			if !s.pInRange(p) {
+				s.i++
+				continue
+			}
+			s.j, s.hi = s.pairs.qRange(s.i, len(s.primes))
+			s.inRow = true
+		}
+		for s.j < s.hi {
+			q := s.primes[s.j]
+			s.j++
+			if s.pairs.Contains(p, q) {
+				return Job{P: p, Q: q}, true
+			}
+		}
+		s.i++
+		s.inRow = false
+	}
+	return Job{}, false
+}
+
+// Len retourne le nombre total de paires de la source.
+func (s *GridSource) Len() int64 {
+	if s.pMin == 0 && s.pMax == 0 {
+		return s.pairs.Count(len(s.primes))
+	}
+	var total int64
+	for i, p := range s.primes {
+		if !s.pInRange(p) {
+			continue
+		}
+		lo, hi := s.pairs.qRange(i, len(s.primes))
+		total += int64(hi - lo)
+		if s.pairs == PairsDistinct {
+			total--
+		}
+	}
+	return total
+}
+
+// ShellSource énumère la région pairs de la grille formée par une liste croissante de nombres
+// premiers par couronnes: pour k croissant, les paires dont le plus grand indice vaut k (q
+// croissant pour p = primes[k], puis p croissant pour q = primes[k]). Les paires de petits p et q,
+// donc les plus petits candidats, sont distribuées les premières: le premier résultat arrive au
+// plus tôt, là où GridSource parcourt tous les q du plus petit p.
+type ShellSource struct {
+	primes []int
+	pairs  PairMode
+	k, i   int // Couronne, et position dans la couronne (0 à 2k).
+}
+
+// NewShellSource retourne la source de la région pairs de primeList (triée), par couronnes.
+func NewShellSource(primeList []int, pairs PairMode) *ShellSource {
+	return &ShellSource{primes: primeList, pairs: pairs}
+}
+
+// Next implémente JobSource.
+func (s *ShellSource) Next() (Job, bool) {
+	for s.k < len(s.primes) {
+		for s.i <= 2*s.k {
+			var job Job
+			if s.i <= s.k {
+				job = Job{P: s.primes[s.k], Q: s.primes[s.i]}
+			} else {
+				job = Job{P: s.primes[s.i-s.k-1], Q: s.primes[s.k]}
+			}
+			s.i++
+			if s.pairs.Contains(job.P, job.Q) {
+				return job, true
+			}
+		}
+		s.k++
+		s.i = 0
+	}
+	return Job{}, false
+}
+
+// Len retourne le nombre total de paires de la source.
+func (s *ShellSource) Len() int64 { return s.pairs.Count(len(s.primes)) }
+
+// ShardSource ne garde d'une source que la part shard (0 <= shard < shards): les paires de rang
+// k tel que k mod shards = shard. Les parts d'une même source sont disjointes et la couvrent
+// entièrement: shards exécutions indépendantes se partagent ainsi une recherche.
+type ShardSource struct {
+	src           JobSource
+	shard, shards int
+	k             int64 // Rang de la prochaine paire de src.
+}
+
+// NewShardSource retourne la part shard de src parmi shards parts. Elle panique si shard n'est
+// pas dans [0, shards[.
+func NewShardSource(src JobSource, shard, shards int) *ShardSource {
+	if shards < 1 || shard < 0 || shard >= shards {
+		panic(fmt.Sprintf("primes: part %d sur %d", shard, shards))
+	}
+	return &ShardSource{src: src, shard: shard, shards: shards}
+}
+
+// Next implémente JobSource.
+func (s *ShardSource) Next() (Job, bool) {
+	for {
+		job, ok := s.src.Next()
+		if !ok {
+			return Job{}, false
+		}
+		k := s.k
+		s.k++
+		if k%int64(s.shards) == int64(s.shard) {
+			return job, true
+		}
+	}
+}
+
+// Len retourne le nombre de paires de la part, -1 si celui de la source est inconnu.
+func (s *ShardSource) Len() int64 {
+	n := sourceLen(s.src)
+	if n < 0 {
+		return -1
+	}
+	return max(n-int64(s.shard)+int64(s.shards)-1, 0) / int64(s.shards)
+}
+
+// Err implémente jobSourceErr pour la source partagée.
+func (s *ShardSource) Err() error { return sourceErr(s.src) }
+
+// ResumeSource reprend une source après ses skip premières paires, déjà traitées d'après un point
+// de reprise (par exemple le nombre de paires d'un préfixe entièrement testé).
+type ResumeSource struct {
+	src  JobSource
+	skip int64
+}
+
+// NewResumeSource retourne src privée de ses skip premières paires.
+func NewResumeSource(src JobSource, skip int64) *ResumeSource {
+	return &ResumeSource{src: src, skip: max(skip, 0)}
+}
+
+// Next implémente JobSource.
+func (s *ResumeSource) Next() (Job, bool) {
+	for ; s.skip > 0; s.skip-- {
+		if _, ok := s.src.Next(); !ok {
+			s.skip = 0
+			return Job{}, false
+		}
+	}
+	return s.src.Next()
+}
+
+// Len retourne le nombre de paires restantes, -1 si celui de la source est inconnu.
+func (s *ResumeSource) Len() int64 {
+	n := sourceLen(s.src)
+	if n < 0 {
+		return -1
+	}
+	return max(n-s.skip, 0)
+}
+
+// Err implémente jobSourceErr pour la source reprise.
+func (s *ResumeSource) Err() error { return sourceErr(s.src) }
+
+// FrontierSource ne garde d'une source que les paires au-delà de la frontière above: celles dont
+// p ou q dépasse above. Elle prolonge une recherche déjà menée jusqu'à la limite above sans
+// retester les paires qu'elle couvrait.
+type FrontierSource struct {
+	src   JobSource
+	above int
+}
+
+// NewFrontierSource retourne les paires de src dont max(p, q) > above.
+func NewFrontierSource(src JobSource, above int) *FrontierSource {
+	return &FrontierSource{src: src, above: above}
+}
+
+// Next implémente JobSource.
+func (s *FrontierSource) Next() (Job, bool) {
+	for {
+		job, ok := s.src.Next()
+		if !ok || max(job.P, job.Q) > s.above {
+			return job, ok
+		}
+	}
+}
+
+// Err implémente jobSourceErr pour la source prolongée.
+func (s *FrontierSource) Err() error { return sourceErr(s.src) }
+
+// ReaderSource lit les paires d'un flux texte, une par ligne: "p,q" ou "p q". Les lignes vides et
+// celles qui commencent par # sont ignorées. La première ligne invalide, ou une erreur de
+// lecture, épuise la source; Err la retourne.
+type ReaderSource struct {
+	sc   *bufio.Scanner
+	line int
+	err  error
+}
+
+// NewReaderSource retourne la source des paires lues sur r (l'entrée standard, par exemple).
+func NewReaderSource(r io.Reader) *ReaderSource {
+	return &ReaderSource{sc: bufio.NewScanner(r)}
+}
+
+// Next implémente JobSource.
+func (s *ReaderSource) Next() (Job, bool) {
+	for s.err == nil && s.sc.Scan() {
+		s.line++
+		text := strings.TrimSpace(s.sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+		if len(fields) != 2 {
+			s.err = fmt.Errorf("%w: ligne %d: %q (attendu p,q)", ErrInvalidOptions, s.line, text)
+			return Job{}, false
+		}
+		p, errP := strconv.Atoi(fields[0])
+		q, errQ := strconv.Atoi(fields[1])
+		if errP != nil || errQ != nil || p < 0 || q < 0 {
+			s.err = fmt.Errorf("%w: ligne %d: paire invalide %q", ErrInvalidOptions, s.line, text)
+			return Job{}, false
+		}
+		return Job{P: p, Q: q}, true
+	}
+	if s.err == nil {
+		s.err = s.sc.Err()
+	}
+	return Job{}, false
+}
+
+// Err retourne l'erreur qui a épuisé la source (ligne invalide ou lecture), nil à la fin du flux.
+func (s *ReaderSource) Err() error { return s.err }
+
+// sourceErr retourne l'erreur d'une source épuisée, nil si elle ne peut pas échouer.
+func sourceErr(src JobSource) error {
+	if e, ok := src.(jobSourceErr); ok {
+		return e.Err()
+	}
+	return nil
+}
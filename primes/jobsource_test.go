@@ -0,0 +1,246 @@
+/*
+ * Fichier: jobsource_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des sources de paires: couverture exacte de la grille, des parts et
+ * des reprises, prolongation au-delà d'une limite, lecture d'un flux, et
+ * recherche sur une source fournie.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// drain retourne toutes les paires de src.
+func drain(src JobSource) []Job {
+	var jobs []Job
+	for job, ok := src.Next(); ok; job, ok = src.Next() {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// TestGridSource vérifie, pour chaque mode et avec ou sans tranche de p, que la grille énumère
+// exactement les paires de la région, dans l'ordre de p puis de q, et que Len les compte.
+func TestGridSource(t *testing.T) {
+	primeList := SieveOfEratosthenes(60)
+	for _, name := range PairModeNames() {
+		mode, _ := LookupPairMode(name)
+		for _, bounds := range [][2]int{{0, 0}, {7, 31}, {40, 0}} {
+			var want []Job
+			for _, p := range primeList {
+				for _, q := range primeList {
+					if p >= bounds[0] && (bounds[1] == 0 || p <= bounds[1]) && mode.Contains(p, q) {
+						want = append(want, Job{P: p, Q: q})
+					}
+				}
+			}
+			src := NewGridSource(primeList, mode, bounds[0], bounds[1])
+			got := drain(src)
+			if len(got) != len(want) {
+				t.Fatalf("%s %v: %d paires, attendu %d", name, bounds, len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("%s %v: paire %d = %v, attendu %v", name, bounds, i, got[i], want[i])
+				}
+			}
+			if n := src.Len(); n != int64(len(want)) {
+				t.Errorf("%s %v: Len() = %d, attendu %d", name, bounds, n, len(want))
+			}
+			if _, ok := src.Next(); ok {
+				t.Errorf("%s %v: paire après l'épuisement", name, bounds)
+			}
+		}
+	}
+}
+
+// TestShardSource vérifie que les parts d'une grille sont disjointes, la couvrent exactement et
+// que Len compte les paires de chaque part.
+func TestShardSource(t *testing.T) {
+	primeList := SieveOfEratosthenes(100)
+	grid := drain(NewGridSource(primeList, PairsLess, 0, 0))
+	for _, shards := range []int{1, 3, 7, len(grid) + 2} {
+		seen := map[Job]int{}
+		for shard := range shards {
+			src := NewShardSource(NewGridSource(primeList, PairsLess, 0, 0), shard, shards)
+			jobs := drain(src)
+			if n := src.Len(); n != int64(len(jobs)) {
+				t.Errorf("part %d/%d: Len() = %d, %d paires", shard, shards, n, len(jobs))
+			}
+			for _, job := range jobs {
+				seen[job]++
+			}
+		}
+		if len(seen) != len(grid) {
+			t.Errorf("%d parts: %d paires distinctes, attendu %d", shards, len(seen), len(grid))
+		}
+		for _, job := range grid {
+			if seen[job] != 1 {
+				t.Errorf("%d parts: paire %v vue %d fois", shards, job, seen[job])
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewShardSource(src, 3, 3): pas de panique")
+		}
+	}()
+	NewShardSource(NewGridSource(primeList, PairsAll, 0, 0), 3, 3)
+}
+
+// TestShellSource vérifie, pour chaque mode, que le parcours par couronnes énumère exactement les
+// paires de la grille, une fois chacune, sans jamais revenir à une couronne plus petite.
+func TestShellSource(t *testing.T) {
+	primeList := SieveOfEratosthenes(60)
+	index := map[int]int{}
+	for i, p := range primeList {
+		index[p] = i
+	}
+	for _, name := range PairModeNames() {
+		mode, _ := LookupPairMode(name)
+		grid := drain(NewGridSource(primeList, mode, 0, 0))
+		src := NewShellSource(primeList, mode)
+		got := drain(src)
+		if src.Len() != int64(len(grid)) || len(got) != len(grid) {
+			t.Fatalf("%s: %d paires (Len %d), attendu %d", name, len(got), src.Len(), len(grid))
+		}
+		seen := map[Job]bool{}
+		shell := 0
+		for _, job := range got {
+			if seen[job] || !mode.Contains(job.P, job.Q) {
+				t.Fatalf("%s: paire %v en double ou hors de la région", name, job)
+			}
+			seen[job] = true
+			k := max(index[job.P], index[job.Q])
+			if k < shell {
+				t.Fatalf("%s: paire %v de la couronne %d après la couronne %d", name, job, k, shell)
+			}
+			shell = k
+		}
+	}
+	if got := drain(NewShellSource([]int{2, 3, 5}, PairsAll))[:4]; got[0] != (Job{P: 2, Q: 2}) || got[1] != (Job{P: 3, Q: 2}) || got[3] != (Job{P: 2, Q: 3}) {
+		t.Errorf("premières paires %v, attendu (2,2), (3,2), (3,3), (2,3)", got)
+	}
+}
+
+// TestResumeSource vérifie qu'une reprise poursuit exactement la grille après les paires déjà
+// traitées, y compris au-delà de sa fin.
+func TestResumeSource(t *testing.T) {
+	primeList := SieveOfEratosthenes(50)
+	grid := drain(NewGridSource(primeList, PairsAll, 0, 0))
+	for _, skip := range []int64{0, 1, 17, int64(len(grid)), int64(len(grid)) + 5} {
+		src := NewResumeSource(NewGridSource(primeList, PairsAll, 0, 0), skip)
+		want := grid[min(int(skip), len(grid)):]
+		if n := src.Len(); n != int64(len(want)) {
+			t.Errorf("reprise après %d: Len() = %d, attendu %d", skip, n, len(want))
+		}
+		got := drain(src)
+		if len(got) != len(want) || (len(want) > 0 && got[0] != want[0]) {
+			t.Errorf("reprise après %d: %d paires, attendu %d", skip, len(got), len(want))
+		}
+	}
+}
+
+// TestFrontierSource vérifie que la prolongation au-delà d'une limite couvre exactement les
+// paires de la grille étendue absentes de la grille d'origine.
+func TestFrontierSource(t *testing.T) {
+	for _, name := range PairModeNames() {
+		mode, _ := LookupPairMode(name)
+		for _, above := range []int{0, 2, 30, 60} {
+			seen := make(map[Job]bool)
+			for _, job := range drain(NewGridSource(SieveOfEratosthenes(above), mode, 0, 0)) {
+				seen[job] = true
+			}
+			var want []Job
+			for _, job := range drain(NewGridSource(SieveOfEratosthenes(60), mode, 0, 0)) {
+				if !seen[job] {
+					want = append(want, job)
+				}
+			}
+			got := drain(NewFrontierSource(NewGridSource(SieveOfEratosthenes(60), mode, 0, 0), above))
+			if len(got) != len(want) {
+				t.Fatalf("%s au-delà de %d: %d paires, attendu %d", name, above, len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("%s au-delà de %d: paire %d = %v, attendu %v", name, above, i, got[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+// TestReaderSource vérifie la lecture des paires d'un flux et l'arrêt sur une ligne invalide.
+func TestReaderSource(t *testing.T) {
+	src := NewReaderSource(strings.NewReader("# paires\n3,5\n\n7 11\n  13\t17  \n"))
+	got := drain(src)
+	want := []Job{{3, 5}, {7, 11}, {13, 17}}
+	if len(got) != len(want) || src.Err() != nil {
+		t.Fatalf("paires = %v (erreur %v), attendu %v", got, src.Err(), want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("paire %d = %v, attendu %v", i, got[i], want[i])
+		}
+	}
+	if n := sourceLen(src); n != -1 {
+		t.Errorf("sourceLen = %d, attendu -1", n)
+	}
+
+	for _, input := range []string{"3,5\n7\n11,13\n", "3,x\n", "3,5,7\n", "-3,5\n"} {
+		src := NewReaderSource(strings.NewReader(input))
+		drain(src)
+		if !errors.Is(src.Err(), ErrInvalidOptions) {
+			t.Errorf("%q: erreur %v, attendu ErrInvalidOptions", input, src.Err())
+		}
+	}
+}
+
+// TestSearchJobs vérifie qu'une recherche sur les parts d'une grille retrouve les résultats de la
+// grille, et qu'une source invalide ou qui déborde arrête la recherche.
+func TestSearchJobs(t *testing.T) {
+	primeList := SieveOfEratosthenes(300)
+	want := map[Result]bool{}
+	Search(context.Background(), Options{Primes: primeList, Workers: 2}, func(r Result) error {
+		want[r] = true
+		return nil
+	})
+	got := 0
+	for shard := range 3 {
+		src := NewShardSource(NewGridSource(primeList, PairsAll, 0, 0), shard, 3)
+		var last Progress
+		err := Search(context.Background(), Options{Jobs: src, Workers: 2, OnProgress: func(p Progress) { last = p }}, func(r Result) error {
+			if !want[r] {
+				t.Errorf("part %d: résultat inattendu %+v", shard, r)
+			}
+			got++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("part %d: %v", shard, err)
+		}
+		if last.Tested != last.Total || last.Total == 0 {
+			t.Errorf("part %d: %d paires testées sur %d", shard, last.Tested, last.Total)
+		}
+	}
+	if got != len(want) {
+		t.Errorf("%d résultats sur les parts, attendu %d", got, len(want))
+	}
+
+	err := Search(context.Background(), Options{Jobs: NewReaderSource(strings.NewReader("3,5\nx\n")), Workers: 1}, func(Result) error { return nil })
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("source invalide: erreur %v, attendu ErrInvalidOptions", err)
+	}
+	err = Search(context.Background(), Options{Jobs: NewReaderSource(strings.NewReader("3,3037000499\n")), Workers: 1}, func(Result) error { return nil })
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("paire hors limite: erreur %v, attendu ErrOverflow", err)
+	}
+}
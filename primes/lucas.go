@@ -0,0 +1,185 @@
+/*
+ * Fichier: lucas.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de primalité de Lucas sur int64: test de Lucas et test de Lucas fort,
+ * avec les paramètres de Selfridge (méthode A: premier D de la suite 5, -7,
+ * 9, -11, ... tel que (D/n) = -1, P = 1, Q = (1 - D)/4), et test de
+ * Baillie-PSW, qui combine Miller-Rabin en base 2 et le test de Lucas fort.
+ * Aucun composé ne passe Baillie-PSW sous 2^64; chacun des deux tests a
+ * isolément ses pseudo-premiers (5459, 5777... pour Lucas fort).
+ */
+package primes
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/agbru/PrimeNumber/primes/ntheory"
+)
+
+// lucasParams est le résultat de la recherche des paramètres de Selfridge pour n.
+type lucasParams struct {
+	d, q      int64 // D et Q (P = 1).
+	composite bool  // n a un facteur commun avec l'un des D essayés, ou est un carré.
+}
+
+// selfridgeParams cherche D dans 5, -7, 9, -11, ... tel que (D/n) = -1, pour n impair > 1.
+// Aucun tel D n'existe si n est un carré parfait, d'où le test préalable.
+func selfridgeParams(n int64) lucasParams {
+	if isSquare(n) {
+		return lucasParams{composite: true}
+	}
+	for d := int64(5); ; {
+		j, _ := ntheory.Jacobi(d, n)
+		if j == -1 {
+			return lucasParams{d: d, q: (1 - d) / 4}
+		}
+		if j == 0 && d != n && -d != n {
+			return lucasParams{composite: true}
+		}
+		if d > 0 {
+			d = -d - 2
+		} else {
+			d = -d + 2
+		}
+	}
+}
+
+// isSquare indique si n >= 0 est un carré parfait.
+func isSquare(n int64) bool {
+	r := uint64(math.Sqrt(float64(n)))
+	for r*r > uint64(n) {
+		r--
+	}
+	for (r+1)*(r+1) <= uint64(n) {
+		r++
+	}
+	return r*r == uint64(n)
+}
+
+// lucasMod regroupe l'arithmétique modulo n des suites de Lucas, sur des résidus dans [0, n[.
+type lucasMod struct{ n uint64 }
+
+func (m lucasMod) add(a, b uint64) uint64 {
+	s, carry := bits.Add64(a, b, 0)
+	if carry != 0 || s >= m.n {
+		s -= m.n
+	}
+	return s
+}
+
+func (m lucasMod) sub(a, b uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + (m.n - b)
+}
+
+func (m lucasMod) mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m.n)
+}
+
+// half retourne a/2 modulo n impair.
+func (m lucasMod) half(a uint64) uint64 {
+	if a%2 == 0 {
+		return a / 2
+	}
+	return a/2 + m.n/2 + 1 // (a + n)/2 sans débordement, a et n impairs.
+}
+
+// residue retourne a mod n dans [0, n[.
+func (m lucasMod) residue(a int64) uint64 {
+	return uint64(ntheory.Mod(a, int64(m.n)))
+}
+
+// lucasSequence calcule U_k, V_k et Q^k modulo n pour P = 1 (k >= 1), par la méthode binaire:
+// U_2k = U_k·V_k, V_2k = V_k² - 2Q^k, puis, pour un bit à 1, U_k+1 = (U_k + V_k)/2 et
+// V_k+1 = (D·U_k + V_k)/2.
+func lucasSequence(m lucasMod, d, q int64, k uint64) (u, v, qk uint64) {
+	dm, qm := m.residue(d), m.residue(q)
+	u, v, qk = 1, 1%m.n, qm
+	for i := bits.Len64(k) - 2; i >= 0; i-- {
+		u = m.mul(u, v)
+		v = m.sub(m.mul(v, v), m.add(qk, qk))
+		qk = m.mul(qk, qk)
+		if k>>uint(i)&1 == 1 {
+			u, v = m.half(m.add(u, v)), m.half(m.add(m.mul(dm, u), v))
+			qk = m.mul(qk, qm)
+		}
+	}
+	return u, v, qk
+}
+
+// lucasCandidate traite les cas immédiats des tests de Lucas: ok vaut false si la réponse est
+// déjà connue (prime), sinon les paramètres de Selfridge de n sont retournés.
+func lucasCandidate(n int64) (params lucasParams, prime, ok bool) {
+	switch {
+	case n < 2:
+		return params, false, false
+	case n == 2:
+		return params, true, false
+	case n%2 == 0:
+		return params, false, false
+	}
+	params = selfridgeParams(n)
+	if params.composite {
+		return params, false, false
+	}
+	return params, false, true
+}
+
+// IsLucasProbablePrime applique le test de Lucas avec les paramètres de Selfridge: n est
+// probablement premier si U_{n+1} ≡ 0 mod n. Des composés le passent (323, 377, 1159...).
+func IsLucasProbablePrime(n int64) bool {
+	params, prime, ok := lucasCandidate(n)
+	if !ok {
+		return prime
+	}
+	m := lucasMod{uint64(n)}
+	u, _, _ := lucasSequence(m, params.d, params.q, uint64(n)+1)
+	return u == 0
+}
+
+// IsStrongLucasProbablePrime applique le test de Lucas fort avec les paramètres de Selfridge:
+// avec n + 1 = 2^s·d, d impair, n est probablement premier si U_d ≡ 0 ou V_{d·2^r} ≡ 0 mod n
+// pour un r < s. Plus sélectif que le test de Lucas, il a encore des pseudo-premiers (5459, 5777...).
+func IsStrongLucasProbablePrime(n int64) bool {
+	params, prime, ok := lucasCandidate(n)
+	if !ok {
+		return prime
+	}
+	m := lucasMod{uint64(n)}
+	d := uint64(n) + 1
+	s := bits.TrailingZeros64(d)
+	d >>= uint(s)
+	u, v, qk := lucasSequence(m, params.d, params.q, d)
+	if u == 0 || v == 0 {
+		return true
+	}
+	for r := 1; r < s; r++ {
+		v = m.sub(m.mul(v, v), m.add(qk, qk))
+		if v == 0 {
+			return true
+		}
+		qk = m.mul(qk, qk)
+	}
+	return false
+}
+
+// IsPrimeBPSW applique le test de Baillie-PSW: Miller-Rabin en base 2 puis Lucas fort. Le
+// résultat est exact pour tout int64 (aucun pseudo-premier de Baillie-PSW sous 2^64).
+func IsPrimeBPSW(n int64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinBases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	return millerRabin64(n, millerRabinBases[:1]) && IsStrongLucasProbablePrime(n)
+}
@@ -0,0 +1,66 @@
+/*
+ * Fichier: lucas_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des tests de Lucas et de Baillie-PSW, contre les pseudo-premiers connus.
+ */
+package primes
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestLucasPseudoprimes vérifie que les composés acceptés par les tests de Lucas sont exactement
+// les pseudo-premiers connus (OEIS A217120 et A217255) et qu'aucun nombre premier n'est rejeté.
+func TestLucasPseudoprimes(t *testing.T) {
+	lucas := []int64{323, 377, 1159, 1829, 3827, 5459, 5777, 9071, 9179, 10877, 11419, 11663, 13919, 14839, 16109, 16211, 18407, 18971, 19043}
+	strong := []int64{5459, 5777, 10877, 16109, 18971, 22499, 24569, 25199, 40309, 58519, 75077, 97439}
+
+	var gotLucas, gotStrong []int64
+	for n := int64(-3); n < 100000; n++ {
+		prime := IsPrimeTrialDivision(n)
+		if IsLucasProbablePrime(n) != prime {
+			if prime {
+				t.Fatalf("IsLucasProbablePrime(%d) = false pour un nombre premier", n)
+			}
+			if n < 20000 {
+				gotLucas = append(gotLucas, n)
+			}
+		}
+		if IsStrongLucasProbablePrime(n) != prime {
+			if prime {
+				t.Fatalf("IsStrongLucasProbablePrime(%d) = false pour un nombre premier", n)
+			}
+			gotStrong = append(gotStrong, n)
+		}
+		if IsPrimeBPSW(n) != prime {
+			t.Fatalf("IsPrimeBPSW(%d) = %v", n, !prime)
+		}
+	}
+	if !slices.Equal(gotLucas, lucas) {
+		t.Errorf("pseudo-premiers de Lucas < 20000: %v, attendu %v", gotLucas, lucas)
+	}
+	if !slices.Equal(gotStrong, strong) {
+		t.Errorf("pseudo-premiers de Lucas forts < 100000: %v, attendu %v", gotStrong, strong)
+	}
+}
+
+// TestIsPrimeBPSW confronte Baillie-PSW à Miller-Rabin sur de grands int64, dont les
+// pseudo-premiers forts en base 2 que la moitié Lucas doit rejeter.
+func TestIsPrimeBPSW(t *testing.T) {
+	for _, n := range []int64{2047, 3277, 4033, 4681, 8321, 3215031751, 3825123056546413051, math.MaxInt64,
+		1_000_000_007, 2147483647, 9_223_372_036_854_775_783, 4_611_686_014_132_420_609} {
+		if got, want := IsPrimeBPSW(n), IsPrimeMillerRabin64(n); got != want {
+			t.Errorf("IsPrimeBPSW(%d) = %v, attendu %v", n, got, want)
+		}
+	}
+	for n := int64(math.MaxInt64 - 20000); n < math.MaxInt64; n += 2 {
+		if got, want := IsPrimeBPSW(n), IsPrimeMillerRabin64(n); got != want {
+			t.Fatalf("IsPrimeBPSW(%d) = %v, attendu %v", n, got, want)
+		}
+	}
+}
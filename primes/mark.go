@@ -0,0 +1,67 @@
+/*
+ * Fichier: mark.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Boucle de marquage des cribles. Pour les petits pas (les petits nombres
+ * premiers, qui font l'essentiel du travail du crible), les multiples sont
+ * marqués huit octets à la fois: le motif des multiples se répète tous les
+ * « pas » mots de 64 bits, et chaque mot du tableau reçoit un OU avec son
+ * motif (orPatterns, en assembleur sur amd64 et arm64, en Go ailleurs ou avec
+ * l'étiquette de build purego). Les grands pas gardent le marquage un à un.
+ */
+package primes
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// wideMarkMaxStep est le pas en dessous duquel le marquage se fait par mots de 64 bits.
+const wideMarkMaxStep = 16
+
+// markPatterns[step][w] est le mot de 64 bits dont l'octet j vaut 1 si 8w + j est multiple de step.
+var markPatterns = func() (patterns [wideMarkMaxStep][]uint64) {
+	for step := 1; step < wideMarkMaxStep; step++ {
+		patterns[step] = make([]uint64, step)
+		for w := range step {
+			for j := range 8 {
+				if (8*w+j)%step == 0 {
+					patterns[step][w] |= 1 << (8 * j)
+				}
+			}
+		}
+	}
+	return patterns
+}()
+
+// markMultiples marque marker[start], marker[start+step], ... jusqu'à la fin de marker (start >= 0, step >= 1).
+func markMultiples(marker []bool, start, step int) {
+	if step < wideMarkMaxStep && len(marker)-start >= 8*step {
+		// Un bool occupe un octet valant 0 ou 1: le OU avec un motif d'octets 0 ou 1 reste valide.
+		region := unsafe.Slice((*byte)(unsafe.Pointer(&marker[start])), len(marker)-start)
+		orPatterns(region, markPatterns[step])
+		// Octets après le dernier mot complet.
+		tail := len(region) &^ 7
+		for i := (tail + step - 1) / step * step; i < len(region); i += step {
+			region[i] = 1
+		}
+		return
+	}
+	for i := start; i < len(marker); i += step {
+		marker[i] = true
+	}
+}
+
+// orPatternsGeneric est la version Go de orPatterns: dst[8w:8w+8] |= patterns[w % len(patterns)]
+// pour chaque mot complet de dst (petit-boutiste).
+func orPatternsGeneric(dst []byte, patterns []uint64) {
+	k := 0
+	for i := 0; i+8 <= len(dst); i += 8 {
+		binary.LittleEndian.PutUint64(dst[i:], binary.LittleEndian.Uint64(dst[i:])|patterns[k])
+		if k++; k == len(patterns) {
+			k = 0
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !purego
+
+/*
+ * Fichier: mark_amd64.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Déclaration de la boucle de marquage par mots en assembleur amd64 (mark_amd64.s).
+ */
+package primes
+
+// orPatterns fait dst[8w:8w+8] |= patterns[w % len(patterns)] pour chaque mot complet de dst
+// (len(patterns) >= 1), comme orPatternsGeneric.
+//
+//go:noescape
+func orPatterns(dst []byte, patterns []uint64)
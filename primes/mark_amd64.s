@@ -0,0 +1,48 @@
+//go:build !purego
+
+// Fichier: mark_amd64.s
+// Auteur: [Votre Nom/Organisation]
+// Date: 16 octobre 2026
+//
+// Description:
+// Marquage par mots de 64 bits: un OU mémoire par mot, motif suivant pris
+// cycliquement dans patterns, période par période.
+
+#include "textflag.h"
+
+// func orPatterns(dst []byte, patterns []uint64)
+TEXT ·orPatterns(SB), NOSPLIT, $0-48
+	MOVQ dst_base+0(FP), DI
+	MOVQ dst_len+8(FP), CX
+	MOVQ patterns_base+24(FP), SI
+	MOVQ patterns_len+32(FP), DX
+	SHRQ $3, CX              // Nombre de mots complets.
+
+period:                      // Une période complète: un mot par motif.
+	CMPQ CX, DX
+	JB   rest
+	XORQ BX, BX
+
+inner:
+	MOVQ (SI)(BX*8), AX
+	ORQ  AX, (DI)(BX*8)
+	INCQ BX
+	CMPQ BX, DX
+	JB   inner
+	LEAQ (DI)(DX*8), DI
+	SUBQ DX, CX
+	JMP  period
+
+rest:                        // Période incomplète finale.
+	XORQ BX, BX
+
+restloop:
+	CMPQ BX, CX
+	JAE  done
+	MOVQ (SI)(BX*8), AX
+	ORQ  AX, (DI)(BX*8)
+	INCQ BX
+	JMP  restloop
+
+done:
+	RET
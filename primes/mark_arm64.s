@@ -0,0 +1,51 @@
+//go:build !purego
+
+// Fichier: mark_arm64.s
+// Auteur: [Votre Nom/Organisation]
+// Date: 16 octobre 2026
+//
+// Description:
+// Marquage par mots de 64 bits: chargement, OU et rangement post-incrémenté
+// d'un mot par itération, motif suivant pris cycliquement dans patterns,
+// période par période.
+
+#include "textflag.h"
+
+// func orPatterns(dst []byte, patterns []uint64)
+TEXT ·orPatterns(SB), NOSPLIT, $0-48
+	MOVD dst_base+0(FP), R0
+	MOVD dst_len+8(FP), R1
+	MOVD patterns_base+24(FP), R2
+	MOVD patterns_len+32(FP), R3
+	LSR  $3, R1, R1          // Nombre de mots complets.
+
+period:                      // Une période complète: un mot par motif.
+	CMP  R3, R1
+	BLO  rest
+	MOVD R2, R4
+	MOVD R3, R5
+
+inner:
+	MOVD.P 8(R4), R6
+	MOVD   (R0), R7
+	ORR    R6, R7, R7
+	MOVD.P R7, 8(R0)
+	SUBS   $1, R5, R5
+	BNE    inner
+	SUB    R3, R1, R1
+	B      period
+
+rest:                        // Période incomplète finale.
+	CBZ  R1, done
+	MOVD R2, R4
+
+restloop:
+	MOVD.P 8(R4), R6
+	MOVD   (R0), R7
+	ORR    R6, R7, R7
+	MOVD.P R7, 8(R0)
+	SUBS   $1, R1, R1
+	BNE    restloop
+
+done:
+	RET
@@ -0,0 +1,14 @@
+//go:build (!amd64 && !arm64) || purego
+
+/*
+ * Fichier: mark_generic.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Marquage par mots en Go pur, hors amd64 et arm64 ou avec l'étiquette purego.
+ */
+package primes
+
+// orPatterns applique les motifs de marquage à dst (voir orPatternsGeneric).
+func orPatterns(dst []byte, patterns []uint64) { orPatternsGeneric(dst, patterns) }
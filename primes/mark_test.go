@@ -0,0 +1,67 @@
+/*
+ * Fichier: mark_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests d'équivalence du marquage par mots (assembleur ou Go) avec le marquage un à un.
+ */
+package primes
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestOrPatterns compare orPatterns (assembleur selon l'architecture) à orPatternsGeneric.
+func TestOrPatterns(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for step := 1; step < wideMarkMaxStep; step++ {
+		for _, size := range []int{0, 7, 8, 9, 16, 17, 8 * step, 8*step + 5, 1000} {
+			dst := make([]byte, size)
+			for i := range dst {
+				dst[i] = byte(rng.IntN(2))
+			}
+			expected := slices.Clone(dst)
+			orPatternsGeneric(expected, markPatterns[step])
+			orPatterns(dst, markPatterns[step])
+			if !slices.Equal(dst, expected) {
+				t.Fatalf("pas %d, %d octets: orPatterns diffère de la version Go", step, size)
+			}
+		}
+	}
+}
+
+// TestMarkMultiples compare markMultiples au marquage un à un pour des pas, départs et tailles variés.
+func TestMarkMultiples(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	for range 2000 {
+		size, step := rng.IntN(600), 1+rng.IntN(40)
+		start := rng.IntN(size + 10)
+		marker := make([]bool, size)
+		for i := range marker {
+			marker[i] = rng.IntN(4) == 0
+		}
+		expected := slices.Clone(marker)
+		for i := start; i < size; i += step {
+			expected[i] = true
+		}
+		markMultiples(marker, start, step)
+		if !slices.Equal(marker, expected) {
+			t.Fatalf("markMultiples(%d cases, départ %d, pas %d) diffère du marquage un à un", size, start, step)
+		}
+	}
+
+	// Le crible reste identique au crible segmenté, qui marque par segments à des décalages arbitraires.
+	sieved := SieveOfEratosthenes(200_000)
+	ranged := SieveRange(2, 200_000)
+	if len(sieved) != 17984 || len(ranged) != len(sieved) {
+		t.Fatalf("π(200000): crible %d, segmenté %d, attendu 17984", len(sieved), len(ranged))
+	}
+	for i, p := range ranged {
+		if int(p) != sieved[i] {
+			t.Fatalf("nombre premier n°%d: segmenté %d, crible %d", i, p, sieved[i])
+		}
+	}
+}
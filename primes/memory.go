@@ -0,0 +1,77 @@
+/*
+ * Fichier: memory.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Estimation a priori de la mémoire nécessaire à une recherche: crible,
+ * table des nombres premiers et tampons des canaux.
+ */
+package primes
+
+import (
+	"cmp"
+	"math"
+	"unsafe"
+)
+
+// goroutineStack est la taille initiale approximative de la pile d'une goroutine.
+const goroutineStack = 8 << 10
+
+// MemoryEstimate détaille l'estimation mémoire d'une recherche, en octets.
+type MemoryEstimate struct {
+	Sieve      int64 // Tableau de marquage du crible (libéré après le crible).
+	PrimeTable int64 // Liste des nombres premiers conservée pendant toute la recherche.
+	Buffers    int64 // Canaux de tâches et de résultats, piles des workers.
+}
+
+// Total retourne le pic estimé: le crible et la table coexistent pendant la collecte des nombres premiers.
+func (m MemoryEstimate) Total() int64 {
+	return max(m.Sieve+m.PrimeTable, m.PrimeTable+m.Buffers)
+}
+
+// EstimatePrimeCount retourne une estimation par excès de π(limit), le nombre de premiers <= limit.
+// Théorème des nombres premiers: pi(x) ~ x / ln(x), avec une marge de 20 %.
+func EstimatePrimeCount(limit int) int {
+	if limit < 2 {
+		return 0
+	}
+	return int(float64(limit)/math.Log(float64(limit))*1.2) + 10
+}
+
+// sieveMemory estime la mémoire de travail du crible jusqu'à limit: le tableau de marquage complet,
+// ou, pour le crible par seaux, un segment, la table des petits nombres premiers et les seaux.
+func sieveMemory(limit int) int64 {
+	if limit < bucketSieveThreshold {
+		return int64(limit) + 1
+	}
+	basePrimes := int64(EstimatePrimeCount(int(isqrt(int64(limit)))))
+	return bucketSegmentSize + basePrimes*int64(2*unsafe.Sizeof(int(0))+unsafe.Sizeof(bucketEntry{}))
+}
+
+// EstimateMemory estime la mémoire nécessaire à une recherche jusqu'à limit avec numWorkers workers
+// et des lots de batchSize paires, avec les tampons par défaut.
+func EstimateMemory(limit, numWorkers, batchSize int) MemoryEstimate {
+	return EstimateMemoryBuffers(limit, numWorkers, batchSize, 0, 0)
+}
+
+// EstimateMemoryBuffers est EstimateMemory avec des tampons de jobsBuffer lots et resultsBuffer
+// résultats (0: capacités par défaut, voir Options.JobsBuffer).
+func EstimateMemoryBuffers(limit, numWorkers, batchSize, jobsBuffer, resultsBuffer int) MemoryEstimate {
+	if limit < 2 {
+		return MemoryEstimate{}
+	}
+	primeCount := EstimatePrimeCount(limit)
+	batchSize = max(batchSize, 1)
+	jobsBuffer = cmp.Or(jobsBuffer, DefaultJobsBuffer(numWorkers, batchSize))
+	resultsBuffer = cmp.Or(resultsBuffer, DefaultResultsBuffer(numWorkers, batchSize))
+	// Lots en attente dans le canal, plus un lot en cours de traitement par worker.
+	pendingJobs := int64(jobsBuffer+numWorkers) * int64(batchSize)
+	return MemoryEstimate{
+		Sieve:      sieveMemory(limit),
+		PrimeTable: int64(primeCount) * int64(unsafe.Sizeof(int(0))),
+		Buffers: pendingJobs*int64(unsafe.Sizeof(Job{})) +
+			int64(resultsBuffer)*int64(unsafe.Sizeof(Result{})) +
+			int64(numWorkers)*goroutineStack,
+	}
+}
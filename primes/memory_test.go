@@ -0,0 +1,49 @@
+/*
+ * Fichier: memory_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'estimation mémoire d'une recherche.
+ */
+package primes
+
+import "testing"
+
+// TestEstimatePrimeCount vérifie que l'estimation majore le nombre réel de premiers.
+func TestEstimatePrimeCount(t *testing.T) {
+	for _, limit := range []int{2, 10, 100, 1000, 100000, 1000000} {
+		actual := len(SieveOfEratosthenes(limit))
+		if est := EstimatePrimeCount(limit); est < actual {
+			t.Errorf("EstimatePrimeCount(%d) = %d < π = %d", limit, est, actual)
+		}
+	}
+	if est := EstimatePrimeCount(1); est != 0 {
+		t.Errorf("EstimatePrimeCount(1) = %d, attendu 0", est)
+	}
+}
+
+// TestEstimateMemory valide la composition de l'estimation.
+func TestEstimateMemory(t *testing.T) {
+	if est := EstimateMemory(1, 4, 1); est.Total() != 0 {
+		t.Errorf("EstimateMemory(1) = %+v, attendu 0", est)
+	}
+
+	est := EstimateMemory(1000000, 4, DefaultBatchSize)
+	if est.Sieve != 1000001 {
+		t.Errorf("Sieve = %d, attendu 1000001", est.Sieve)
+	}
+	// Au-delà du seuil du crible par seaux, le crible n'occupe plus qu'un segment et ses seaux.
+	if large := EstimateMemory(1_000_000_000, 4, DefaultBatchSize); large.Sieve <= bucketSegmentSize || large.Sieve > 1<<20 {
+		t.Errorf("Sieve(10^9) = %d, attendu un segment et les seaux (< 1 Mio)", large.Sieve)
+	}
+	if est.PrimeTable <= 0 || est.Buffers <= 0 {
+		t.Errorf("estimation incomplète: %+v", est)
+	}
+	if est.Total() < est.Sieve+est.PrimeTable {
+		t.Errorf("Total() = %d inférieur au pic du crible %d", est.Total(), est.Sieve+est.PrimeTable)
+	}
+	if bigger := EstimateMemory(2000000, 4, DefaultBatchSize); bigger.Total() <= est.Total() {
+		t.Errorf("l'estimation ne croît pas avec la limite: %d <= %d", bigger.Total(), est.Total())
+	}
+}
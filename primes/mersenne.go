@@ -0,0 +1,53 @@
+/*
+ * Fichier: mersenne.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Test de Lucas-Lehmer des nombres de Mersenne M_p = 2^p - 1 sur math/big.
+ * La réduction modulo M_p se fait sans division: 2^p ≡ 1 (mod M_p), donc
+ * x ≡ (x mod 2^p) + (x >> p).
+ */
+package primes
+
+import "math/big"
+
+// reduceMersenne réduit x (positif) modulo m = 2^p - 1, en place.
+func reduceMersenne(x *big.Int, p uint, m, tmp *big.Int) *big.Int {
+	for x.BitLen() > int(p) {
+		tmp.Rsh(x, p)
+		x.And(x, m)
+		x.Add(x, tmp)
+	}
+	if x.Cmp(m) == 0 {
+		x.SetInt64(0)
+	}
+	return x
+}
+
+// IsMersennePrime indique si M_p = 2^p - 1 est premier, par le test de Lucas-Lehmer:
+// pour p premier impair, M_p est premier si et seulement si s_{p-2} ≡ 0 (mod M_p),
+// avec s_0 = 4 et s_{k+1} = s_k² - 2. M_p est composé dès que p l'est.
+func IsMersennePrime(p int) bool {
+	if !IsPrime(int64(p)) {
+		return false
+	}
+	if p == 2 {
+		return true // M_2 = 3.
+	}
+
+	exp := uint(p)
+	one := big.NewInt(1)
+	m := new(big.Int).Sub(new(big.Int).Lsh(one, exp), one)
+	two := big.NewInt(2)
+	s, tmp := big.NewInt(4), new(big.Int)
+	for range p - 2 {
+		s.Mul(s, s)
+		s.Sub(s, two)
+		if s.Sign() < 0 {
+			s.Add(s, m)
+		}
+		reduceMersenne(s, exp, m, tmp)
+	}
+	return s.Sign() == 0
+}
@@ -0,0 +1,46 @@
+/*
+ * Fichier: mersenne_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du test de Lucas-Lehmer.
+ */
+package primes
+
+import (
+	"math/big"
+	"testing"
+)
+
+// mersenneExponents sont les exposants p ≤ 1300 pour lesquels 2^p - 1 est premier.
+var mersenneExponents = map[int]bool{
+	2: true, 3: true, 5: true, 7: true, 13: true, 17: true, 19: true, 31: true, 61: true,
+	89: true, 107: true, 127: true, 521: true, 607: true, 1279: true,
+}
+
+// TestIsMersennePrime compare le test de Lucas-Lehmer à la liste des exposants de Mersenne connus.
+func TestIsMersennePrime(t *testing.T) {
+	for p := 0; p <= 1300; p++ {
+		if got := IsMersennePrime(p); got != mersenneExponents[p] {
+			t.Errorf("IsMersennePrime(%d) = %v, attendu %v", p, got, mersenneExponents[p])
+		}
+	}
+}
+
+// TestReduceMersenne compare la réduction sans division au modulo de math/big.
+func TestReduceMersenne(t *testing.T) {
+	const p = 61
+	m := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), p), big.NewInt(1))
+	for _, x := range []*big.Int{
+		big.NewInt(0),
+		new(big.Int).Set(m),
+		new(big.Int).Mul(m, big.NewInt(12345)),
+		new(big.Int).Add(new(big.Int).Mul(m, m), big.NewInt(987654321)),
+	} {
+		expected := new(big.Int).Mod(x, m)
+		if got := reduceMersenne(new(big.Int).Set(x), p, m, new(big.Int)); got.Cmp(expected) != 0 {
+			t.Errorf("reduceMersenne(%s) = %s, attendu %s", x, got, expected)
+		}
+	}
+}
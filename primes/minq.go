@@ -0,0 +1,56 @@
+/*
+ * Fichier: minq.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Rapport « q minimal »: pour chaque nombre premier p de la liste, le plus
+ * petit nombre premier q de la liste tel que n = forme(p, q) soit premier.
+ * Les valeurs de p sont réparties entre un pool de workers.
+ */
+package primes
+
+import "sync"
+
+// MinQ est une ligne du rapport q minimal. Q et N valent 0 si aucun q ne convient.
+type MinQ struct {
+	P int
+	Q int
+	N int64
+}
+
+// MinimalQ calcule, pour chaque p de primeList (trié), le plus petit q de primeList tel que
+// form.Eval(p, q) soit premier, les paires écartées par form.Prune étant ignorées. Le résultat
+// suit l'ordre de primeList.
+func MinimalQ(form Form, primeList []int, primeTest string, numWorkers int) []MinQ {
+	isPrime := PrimalityTest(primeTest)
+	report := make([]MinQ, len(primeList))
+	indexes := make(chan int, max(numWorkers, 1))
+	var wg sync.WaitGroup
+	for range max(numWorkers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Chaque worker écrit des lignes distinctes du rapport: aucune synchronisation n'est requise.
+			for i := range indexes {
+				p := int64(primeList[i])
+				report[i] = MinQ{P: primeList[i]}
+				for _, q := range primeList {
+					if form.Prune(p, int64(q)) {
+						continue
+					}
+					if n := form.Eval(p, int64(q)); isPrime(n) {
+						report[i].Q, report[i].N = q, n
+						break
+					}
+				}
+			}
+		}()
+	}
+	for i := range primeList {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,46 @@
+/*
+ * Fichier: minq_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du rapport q minimal.
+ */
+package primes
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// TestMinimalQ valide le rapport sur la forme par défaut et son indépendance du nombre de workers.
+func TestMinimalQ(t *testing.T) {
+	// p = 2 est toujours élagué (n pair); 3² + 4·5² = 109, 5² + 4·2² = 41, 7² + 4·5² = 149.
+	expected := []MinQ{
+		{P: 2},
+		{P: 3, Q: 5, N: 109},
+		{P: 5, Q: 2, N: 41},
+		{P: 7, Q: 5, N: 149},
+	}
+	for _, workers := range []int{1, 3} {
+		if got := MinimalQ(DefaultForm, SieveOfEratosthenes(10), "miller", workers); !slices.Equal(got, expected) {
+			t.Errorf("MinimalQ(10, %d workers) = %v, attendu %v", workers, got, expected)
+		}
+	}
+
+	// Contrôle croisé avec la recherche complète: le q minimal est le plus petit q trouvé pour p.
+	primeList := SieveOfEratosthenes(300)
+	smallest := map[int]int{}
+	Search(context.Background(), Options{Primes: primeList, Workers: 2, BatchSize: 16}, func(r Result) error {
+		if q, ok := smallest[r.P]; !ok || r.Q < q {
+			smallest[r.P] = r.Q
+		}
+		return nil
+	})
+	for _, row := range MinimalQ(DefaultForm, primeList, "miller", 4) {
+		if row.Q != smallest[row.P] {
+			t.Errorf("p=%d: q minimal %d, attendu %d", row.P, row.Q, smallest[row.P])
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Fichier: mrtrace.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Trace pédagogique du test de Miller-Rabin: décomposition n - 1 = 2^s·d,
+ * puis, pour chaque base essayée (les mêmes que IsPrimeMillerRabin64), la
+ * suite des carrés a^d, a^(2d), ... modulo n et l'issue du tour. Sert à
+ * l'option -explain de la CLI.
+ */
+package primes
+
+// RoundOutcome est l'issue d'un tour de Miller-Rabin.
+type RoundOutcome int
+
+const (
+	RoundOne      RoundOutcome = iota // a^d ≡ 1: compatible avec n premier.
+	RoundMinusOne                     // La suite atteint n - 1: compatible avec n premier.
+	RoundWitness                      // La suite n'atteint jamais n - 1: a prouve que n est composé.
+)
+
+// MillerRabinRound décrit un tour du test pour une base.
+type MillerRabinRound struct {
+	Base    int64
+	Powers  []int64 // a^d, a^(2d), ..., a^(2^k·d) mod n, jusqu'à l'issue du tour.
+	Outcome RoundOutcome
+}
+
+// MillerRabinTrace décrit le déroulement complet du test pour n. Pour n < 4 ou n pair, la
+// réponse est immédiate: S, D et Rounds restent nuls.
+type MillerRabinTrace struct {
+	N      int64
+	S      int   // n - 1 = 2^S · D,
+	D      int64 // avec D impair.
+	Rounds []MillerRabinRound
+	Prime  bool
+}
+
+// TraceMillerRabin exécute le test de Miller-Rabin de IsPrimeMillerRabin64 en enregistrant
+// chaque étape; Prime vaut toujours IsPrimeMillerRabin64(n).
+func TraceMillerRabin(n int64) MillerRabinTrace {
+	trace := MillerRabinTrace{N: n}
+	switch {
+	case n < 2:
+		return trace
+	case n == 2 || n == 3:
+		trace.Prime = true
+		return trace
+	case n%2 == 0:
+		return trace
+	}
+	trace.D = n - 1
+	for trace.D%2 == 0 {
+		trace.D /= 2
+		trace.S++
+	}
+
+	trace.Prime = true
+	for _, a := range millerRabinBases {
+		if a >= n-1 {
+			break
+		}
+		x := power64(a, trace.D, n)
+		round := MillerRabinRound{Base: a, Powers: []int64{x}, Outcome: RoundWitness}
+		switch {
+		case x == 1:
+			round.Outcome = RoundOne
+		case x == n-1:
+			round.Outcome = RoundMinusOne
+		default:
+			for r := 1; r < trace.S; r++ {
+				x = power64(x, 2, n)
+				round.Powers = append(round.Powers, x)
+				if x == n-1 {
+					round.Outcome = RoundMinusOne
+					break
+				}
+			}
+		}
+		trace.Rounds = append(trace.Rounds, round)
+		if round.Outcome == RoundWitness {
+			trace.Prime = false
+			break
+		}
+	}
+	return trace
+}
@@ -0,0 +1,53 @@
+/*
+ * Fichier: mrtrace_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la trace du test de Miller-Rabin.
+ */
+package primes
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestTraceMillerRabin vérifie le verdict de la trace contre IsPrimeMillerRabin64 et le détail
+// des tours sur des cas connus.
+func TestTraceMillerRabin(t *testing.T) {
+	for n := int64(-2); n < 5000; n++ {
+		if got := TraceMillerRabin(n).Prime; got != IsPrimeMillerRabin64(n) {
+			t.Fatalf("TraceMillerRabin(%d).Prime = %v, IsPrimeMillerRabin64 = %v", n, got, !got)
+		}
+	}
+	for _, n := range []int64{3215031751, 1_000_000_007, 9_223_372_036_854_775_783} {
+		if got := TraceMillerRabin(n).Prime; got != IsPrimeMillerRabin64(n) {
+			t.Errorf("TraceMillerRabin(%d).Prime = %v", n, got)
+		}
+	}
+
+	// 13 - 1 = 2^2 · 3; base 2: 2^3 ≡ 8, 8² ≡ 12 = n - 1.
+	tr := TraceMillerRabin(13)
+	if tr.S != 2 || tr.D != 3 || len(tr.Rounds) != 5 || !tr.Prime {
+		t.Fatalf("TraceMillerRabin(13) = %+v", tr)
+	}
+	if r := tr.Rounds[0]; r.Base != 2 || !slices.Equal(r.Powers, []int64{8, 12}) || r.Outcome != RoundMinusOne {
+		t.Errorf("13, base 2: %+v", r)
+	}
+
+	// 2047 = 23 · 89 est pseudo-premier fort en base 2 (2^1023 ≡ 1); la base 3 est un témoin.
+	tr = TraceMillerRabin(2047)
+	if tr.S != 1 || tr.D != 1023 || tr.Prime || len(tr.Rounds) != 2 {
+		t.Fatalf("TraceMillerRabin(2047) = %+v", tr)
+	}
+	if tr.Rounds[0].Outcome != RoundOne || tr.Rounds[1].Base != 3 || tr.Rounds[1].Outcome != RoundWitness {
+		t.Errorf("2047: tours %+v", tr.Rounds)
+	}
+
+	for _, n := range []int64{-7, 0, 1, 2, 3, 4, 100} {
+		if tr := TraceMillerRabin(n); tr.Rounds != nil || tr.S != 0 {
+			t.Errorf("TraceMillerRabin(%d) = %+v, attendu aucun tour", n, tr)
+		}
+	}
+}
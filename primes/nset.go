@@ -0,0 +1,172 @@
+/*
+ * Fichier: nset.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Ensembles de valeurs de n, pour compter les valeurs distinctes et écarter
+ * les doublons des recherches qui comptent les résultats. Les formes
+ * prédéfinies donnent chaque n premier par une seule paire (représentation
+ * unique en somme de deux carrés): un doublon y signale une anomalie; il est
+ * attendu avec une transformation fournie. HashNSet est une table de hachage:
+ * simple, mais environ 10 à 20 octets par valeur. RoaringNSet suit le principe
+ * des bitmaps « roaring », sans dépendance: les valeurs sont regroupées par
+ * leurs 48 bits de poids fort, et chaque groupe de 65 536 valeurs est un
+ * tableau trié de uint16 (2 octets par valeur) tant qu'il compte au plus 4096
+ * valeurs, puis un bitmap de 8 Kio. Sur des résultats denses, l'occupation
+ * descend sous 2 octets par valeur. Sur des résultats épars (au plus quelques
+ * valeurs par tranche de 65 536), chaque valeur porte presque seule le coût
+ * fixe d'un groupe, environ 90 octets: HashNSet est alors plus compact.
+ */
+package primes
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// NSet est un ensemble de valeurs de n (positives ou nulles). Ses méthodes ne sont pas sûres pour
+// un usage concurrent: la CLI l'alimente depuis la goroutine de collecte des résultats.
+type NSet interface {
+	// Add ajoute n et retourne false s'il était déjà présent.
+	Add(n int64) bool
+	// Contains indique si n est présent.
+	Contains(n int64) bool
+	// Len retourne le nombre de valeurs distinctes.
+	Len() int
+	// SizeBytes estime l'occupation mémoire de l'ensemble.
+	SizeBytes() int64
+}
+
+// HashNSet est un NSet sur une table de hachage.
+type HashNSet struct{ m map[int64]struct{} }
+
+// NewHashNSet retourne un HashNSet vide.
+func NewHashNSet() *HashNSet { return &HashNSet{m: map[int64]struct{}{}} }
+
+// Add implémente NSet.
+func (s *HashNSet) Add(n int64) bool {
+	if _, ok := s.m[n]; ok {
+		return false
+	}
+	s.m[n] = struct{}{}
+	return true
+}
+
+// Contains implémente NSet.
+func (s *HashNSet) Contains(n int64) bool {
+	_, ok := s.m[n]
+	return ok
+}
+
+// Len implémente NSet.
+func (s *HashNSet) Len() int { return len(s.m) }
+
+// SizeBytes implémente NSet. L'estimation suit la table de Go: des emplacements de 8 octets de clé
+// et 1 octet de contrôle, remplis aux 7/8 au plus, en nombre arrondi à une puissance de deux.
+func (s *HashNSet) SizeBytes() int64 {
+	if len(s.m) == 0 {
+		return 0
+	}
+	slots := uint64(len(s.m)*8/7 + 1)
+	return int64(9) << bits.Len64(slots-1)
+}
+
+// Paramètres des conteneurs de RoaringNSet.
+const (
+	roaringArrayMax   = 4096                  // Au-delà, un tableau (2 octets par valeur) dépasse le bitmap.
+	roaringBitmapSize = 1 << 16 / 64          // Mots du bitmap d'un conteneur (8 Kio).
+	roaringOverhead   = 20 + 2*24 + 16        // Entrée de l'index (clé, pointeur, contrôle), en-têtes de tranches et allocations minimales.
+	roaringBitmapLen  = roaringBitmapSize * 8 // Octets du bitmap d'un conteneur.
+)
+
+// roaringContainer regroupe les valeurs de même clé (48 bits de poids fort), par leurs 16 bits
+// de poids faible: tableau trié, puis bitmap au-delà de roaringArrayMax valeurs.
+type roaringContainer struct {
+	array  []uint16
+	bitmap []uint64
+}
+
+// add ajoute low au conteneur et retourne false s'il était déjà présent.
+func (c *roaringContainer) add(low uint16) bool {
+	if c.bitmap != nil {
+		word, bit := low/64, uint64(1)<<(low%64)
+		if c.bitmap[word]&bit != 0 {
+			return false
+		}
+		c.bitmap[word] |= bit
+		return true
+	}
+	i, found := slices.BinarySearch(c.array, low)
+	if found {
+		return false
+	}
+	c.array = slices.Insert(c.array, i, low)
+	if len(c.array) > roaringArrayMax {
+		c.bitmap = make([]uint64, roaringBitmapSize)
+		for _, v := range c.array {
+			c.bitmap[v/64] |= 1 << (v % 64)
+		}
+		c.array = nil
+	}
+	return true
+}
+
+// contains indique si low est présent dans le conteneur.
+func (c *roaringContainer) contains(low uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[low/64]&(1<<(low%64)) != 0
+	}
+	_, found := slices.BinarySearch(c.array, low)
+	return found
+}
+
+// RoaringNSet est un NSet compressé à la manière des bitmaps roaring.
+// Les conteneurs sont indexés par une table de hachage: un nouveau groupe s'ajoute en temps
+// constant, même quand les valeurs éparses en créent presque un par valeur.
+type RoaringNSet struct {
+	containers map[uint64]*roaringContainer // Par 48 bits de poids fort des valeurs.
+	count      int
+}
+
+// NewRoaringNSet retourne un RoaringNSet vide.
+func NewRoaringNSet() *RoaringNSet {
+	return &RoaringNSet{containers: map[uint64]*roaringContainer{}}
+}
+
+// Add implémente NSet.
+func (s *RoaringNSet) Add(n int64) bool {
+	key := uint64(n) >> 16
+	c := s.containers[key]
+	if c == nil {
+		c = &roaringContainer{}
+		s.containers[key] = c
+	}
+	if !c.add(uint16(n)) {
+		return false
+	}
+	s.count++
+	return true
+}
+
+// Contains implémente NSet.
+func (s *RoaringNSet) Contains(n int64) bool {
+	c := s.containers[uint64(n)>>16]
+	return c != nil && c.contains(uint16(n))
+}
+
+// Len implémente NSet.
+func (s *RoaringNSet) Len() int { return s.count }
+
+// SizeBytes implémente NSet: tableaux et bitmaps des conteneurs, et leur surcoût fixe.
+func (s *RoaringNSet) SizeBytes() int64 {
+	size := int64(len(s.containers)) * roaringOverhead
+	for _, c := range s.containers {
+		if c.bitmap != nil {
+			size += roaringBitmapLen
+		} else {
+			size += int64(2 * cap(c.array))
+		}
+	}
+	return size
+}
@@ -0,0 +1,86 @@
+/*
+ * Fichier: nset_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des ensembles de valeurs de n (HashNSet, RoaringNSet).
+ */
+package primes
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+// TestNSet compare les deux ensembles à une table de référence, sur des valeurs éparses, des
+// valeurs denses (conversion des conteneurs en bitmap) et les bornes d'int64.
+func TestNSet(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	values := []int64{0, 1, 65535, 65536, math.MaxInt64, math.MaxInt64 - 1}
+	for range 20000 {
+		values = append(values, rng.Int64N(1<<40))
+	}
+	for n := int64(1 << 20); n < 1<<20+3*roaringArrayMax; n += 1 + rng.Int64N(2) {
+		values = append(values, n)
+	}
+	for _, set := range []NSet{NewHashNSet(), NewRoaringNSet()} {
+		ref := map[int64]bool{}
+		for _, n := range append(values, values[:1000]...) {
+			if got, want := set.Add(n), !ref[n]; got != want {
+				t.Fatalf("%T.Add(%d) = %v, attendu %v", set, n, got, want)
+			}
+			ref[n] = true
+		}
+		if set.Len() != len(ref) {
+			t.Errorf("%T.Len() = %d, attendu %d", set, set.Len(), len(ref))
+		}
+		for _, n := range []int64{0, 65535, 1 << 20, math.MaxInt64, 2, 1<<20 + 3*roaringArrayMax + 1} {
+			if set.Contains(n) != ref[n] {
+				t.Errorf("%T.Contains(%d) = %v, attendu %v", set, n, set.Contains(n), ref[n])
+			}
+		}
+	}
+}
+
+// TestNSetSize vérifie que RoaringNSet occupe bien moins qu'une table de hachage sur des valeurs
+// denses.
+func TestNSetSize(t *testing.T) {
+	hash, roaring := NewHashNSet(), NewRoaringNSet()
+	for n := int64(0); n < 1<<20; n += 3 {
+		hash.Add(n)
+		roaring.Add(n)
+	}
+	if perValue := float64(roaring.SizeBytes()) / float64(roaring.Len()); perValue > 0.5 {
+		t.Errorf("RoaringNSet: %.2f octet(s) par valeur, attendu moins de 0,5", perValue)
+	}
+	if hash.SizeBytes() < 10*roaring.SizeBytes() {
+		t.Errorf("HashNSet %d octets, RoaringNSet %d: écart attendu d'au moins 10x", hash.SizeBytes(), roaring.SizeBytes())
+	}
+}
+
+// TestNSetSparse vérifie l'ajout en temps constant de valeurs éparses (un conteneur par valeur,
+// dans un ordre quelconque), et leur coût annoncé, supérieur à celui d'une table de hachage.
+func TestNSetSparse(t *testing.T) {
+	const count = 200000
+	hash, roaring := NewHashNSet(), NewRoaringNSet()
+	start := time.Now()
+	for i := range int64(count) {
+		n := (i * 7919 % count) << 20
+		hash.Add(n)
+		if !roaring.Add(n) {
+			t.Fatalf("Add(%d) = false pour une nouvelle valeur", n)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("%d valeurs éparses ajoutées en %v, attendu un temps linéaire", count, elapsed)
+	}
+	if roaring.Len() != count || !roaring.Contains(5<<20) || roaring.Contains(5<<20+1) {
+		t.Errorf("Len() = %d, attendu %d, ou appartenance erronée", roaring.Len(), count)
+	}
+	if roaring.SizeBytes() < hash.SizeBytes() {
+		t.Errorf("RoaringNSet %d octets, HashNSet %d: valeurs éparses plus coûteuses attendues", roaring.SizeBytes(), hash.SizeBytes())
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Fichier: nth.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Recherche du n-ième nombre premier et des nombres premiers les plus
+ * proches d'une valeur. NthPrime estime p_n par l'inverse du logarithme
+ * intégral, Li⁻¹(n), compte les premiers jusqu'à l'estimation avec
+ * PrimeCount puis crible en avant ou en arrière le court intervalle qui la
+ * sépare de p_n (de l'ordre de √p_n·ln p_n); NextPrime et PrevPrime
+ * parcourent les candidats avec le test de Miller-Rabin déterministe.
+ */
+package primes
+
+import "math"
+
+// smallPrimes sont les premiers nombres premiers, pour lesquels l'estimation de p_n n'est pas utilisée.
+var smallPrimes = []int64{2, 3, 5, 7, 11}
+
+// nthWindow est la largeur minimale des fenêtres criblées autour de l'estimation de p_n.
+const nthWindow = 1 << 16
+
+// logIntegral retourne li(x) pour x > 1, par la série de Ramanujan:
+//
+//	li(x) = γ + ln ln x + √x Σ_{k≥1} (-1)^(k-1) (ln x)^k / (k! 2^(k-1)) Σ_{j=0}^{⌊(k-1)/2⌋} 1/(2j+1)
+func logIntegral(x float64) float64 {
+	const eulerGamma = 0.5772156649015329
+	ln := math.Log(x)
+	sum, term, inner := 0.0, -1.0, 0.0
+	for k := 1; k < 200; k++ {
+		term *= -ln / float64(k) / 2 // (-1)^(k-1) (ln x)^k / (k! 2^k)
+		if (k-1)%2 == 0 {
+			inner += 1 / float64(k)
+		}
+		delta := 2 * term * inner
+		sum += delta
+		if math.Abs(delta) < 1e-17*math.Abs(sum) {
+			break
+		}
+	}
+	return eulerGamma + math.Log(ln) + math.Sqrt(x)*sum
+}
+
+// inverseLogIntegral retourne x tel que li(x) = n (n ≥ 2), par la méthode de Newton
+// (li'(x) = 1/ln x) depuis n·ln n.
+func inverseLogIntegral(n float64) float64 {
+	x := n * math.Log(n)
+	for range 100 {
+		next := x - (logIntegral(x)-n)*math.Log(x)
+		if math.Abs(next-x) < 1 {
+			return next
+		}
+		x = next
+	}
+	return x
+}
+
+// NthPrime retourne le n-ième nombre premier (NthPrime(1) = 2). ok vaut false si n < 1.
+func NthPrime(n int64) (p int64, ok bool) {
+	if n < 1 {
+		return 0, false
+	}
+	if n <= int64(len(smallPrimes)) {
+		return smallPrimes[n-1], true
+	}
+
+	// p_n s'écarte de Li⁻¹(n) d'environ √p_n·ln p_n.
+	return nthPrimeNear(n, int64(inverseLogIntegral(float64(n)))), true
+}
+
+// nthPrimeNear retourne p_n (n ≥ 1) à partir d'une estimation x ≥ 2: après π(x), il reste
+// |π(x) - n| nombres premiers à parcourir, dans un sens ou dans l'autre, par fenêtres de crible.
+func nthPrimeNear(n, x int64) (p int64) {
+	count := PrimeCount(x)
+	width := max(nthWindow, int64(math.Log(float64(x))*float64(max(count-n, n-count)+1)))
+	if count < n { // p_n > x: (n - count)-ième nombre premier après x.
+		for lo := x + 1; p == 0; lo += width {
+			forEachPrime(lo, lo+width-1, func(q int64) bool {
+				count++
+				if count == n {
+					p = q
+				}
+				return count < n
+			})
+		}
+		return p
+	}
+	// p_n ≤ x: (count - n + 1)-ième nombre premier en descendant depuis x.
+	for hi := x; p == 0; hi -= width {
+		window := SieveRange(max(2, hi-width+1), hi)
+		if rank := count - n; rank < int64(len(window)) {
+			p = window[int64(len(window))-1-rank]
+		}
+		count -= int64(len(window))
+	}
+	return p
+}
+
+// NextPrime retourne le plus petit nombre premier strictement supérieur à n.
+// ok vaut false s'il dépasse la capacité d'un int64.
+func NextPrime(n int64) (p int64, ok bool) {
+	if n < 2 {
+		return 2, true
+	}
+	// Candidats impairs à partir de n+1.
+	for c := n + 1 | 1; c > n; c += 2 {
+		if IsPrimeMillerRabin64(c) {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// PrevPrime retourne le plus grand nombre premier strictement inférieur à n.
+// ok vaut false si n ≤ 2.
+func PrevPrime(n int64) (p int64, ok bool) {
+	switch {
+	case n <= 2:
+		return 0, false
+	case n == 3:
+		return 2, true
+	}
+	// Candidats impairs à partir de n-1.
+	for c := (n - 2) | 1; c >= 3; c -= 2 {
+		if IsPrimeMillerRabin64(c) {
+			return c, true
+		}
+	}
+	return 2, true
+}
@@ -0,0 +1,116 @@
+/*
+ * Fichier: nth_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de NthPrime, NextPrime et PrevPrime.
+ */
+package primes
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestNthPrime valide le n-ième nombre premier sur des valeurs de référence et contre le crible.
+func TestNthPrime(t *testing.T) {
+	primeList := SieveOfEratosthenes(20000)
+	for i, p := range primeList {
+		if got, ok := NthPrime(int64(i + 1)); !ok || got != int64(p) {
+			t.Fatalf("NthPrime(%d) = %d, %v, attendu %d", i+1, got, ok, p)
+		}
+	}
+
+	testCases := []struct{ n, expected int64 }{
+		{1_000_000, 15_485_863},
+		{10_000_000, 179_424_673},
+	}
+	for _, tc := range testCases {
+		if got, ok := NthPrime(tc.n); !ok || got != tc.expected {
+			t.Errorf("NthPrime(%d) = %d, %v, attendu %d", tc.n, got, ok, tc.expected)
+		}
+	}
+	if _, ok := NthPrime(0); ok {
+		t.Errorf("NthPrime(0): ok = true, attendu false")
+	}
+}
+
+// TestNthPrimeNear part d'estimations au-dessus et au-dessous de p_n, proches ou lointaines, pour
+// cribler dans les deux sens.
+func TestNthPrimeNear(t *testing.T) {
+	primeList := SieveOfEratosthenes(3_000_000)
+	for _, n := range []int64{1, 2, 6, 1000, 78_498, 200_000} {
+		want := int64(primeList[n-1])
+		for _, x := range []int64{2, want - 1, want, want + 1, want + 70_000, 2 * want} {
+			if got := nthPrimeNear(n, x); got != want {
+				t.Errorf("nthPrimeNear(%d, %d) = %d, attendu %d", n, x, got, want)
+			}
+		}
+	}
+}
+
+// TestNthPrimeLarge valide p_(10^12), hors de portée du crible de tout l'intervalle de Rosser et
+// Schoenfeld (de l'ordre de n).
+func TestNthPrimeLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("p_(10^12) omis avec -short")
+	}
+	if got, ok := NthPrime(1_000_000_000_000); !ok || got != 29_996_224_275_833 {
+		t.Errorf("NthPrime(10^12) = %d, %v, attendu 29996224275833", got, ok)
+	}
+}
+
+// BenchmarkNthPrime mesure p_n par l'estimation Li⁻¹(n), π en ce point et le crible du court
+// intervalle restant: le crible de tout l'intervalle de Rosser et Schoenfeld prenait plusieurs
+// minutes à 10^12, cette méthode environ deux secondes.
+func BenchmarkNthPrime(b *testing.B) {
+	for _, n := range []int64{1e9, 1e10, 1e11, 1e12} {
+		b.Run(fmt.Sprintf("1e%d", int(math.Log10(float64(n)))), func(b *testing.B) {
+			for b.Loop() {
+				NthPrime(n)
+			}
+		})
+	}
+}
+
+// TestLogIntegral valide li(x) et son inverse sur des valeurs de référence.
+func TestLogIntegral(t *testing.T) {
+	for _, tc := range []struct{ x, li float64 }{{10, 6.1655995047872979}, {1e6, 78627.549159462181}, {1e12, 37607950279.760844}} {
+		if got := logIntegral(tc.x); math.Abs(got-tc.li) > 1e-9*tc.li {
+			t.Errorf("logIntegral(%g) = %v, attendu %v", tc.x, got, tc.li)
+		}
+		if got := inverseLogIntegral(tc.li); math.Abs(got-tc.x) > max(1, 1e-9*tc.x) {
+			t.Errorf("inverseLogIntegral(%v) = %v, attendu %g", tc.li, got, tc.x)
+		}
+	}
+}
+
+// TestNextPrevPrime valide les nombres premiers voisins, y compris aux bornes.
+func TestNextPrevPrime(t *testing.T) {
+	nextCases := []struct{ n, expected int64 }{
+		{-10, 2}, {0, 2}, {2, 3}, {3, 5}, {13, 17}, {24, 29}, {1_000_000_000, 1_000_000_007},
+		{math.MaxInt64 - 100, math.MaxInt64 - 24},
+	}
+	for _, tc := range nextCases {
+		if got, ok := NextPrime(tc.n); !ok || got != tc.expected {
+			t.Errorf("NextPrime(%d) = %d, %v, attendu %d", tc.n, got, ok, tc.expected)
+		}
+	}
+	if _, ok := NextPrime(math.MaxInt64 - 24); ok {
+		t.Errorf("NextPrime(plus grand premier int64): ok = true, attendu false")
+	}
+
+	prevCases := []struct{ n, expected int64 }{
+		{3, 2}, {4, 3}, {5, 3}, {8, 7}, {9, 7}, {30, 29}, {1_000_000_007, 999_999_937},
+	}
+	for _, tc := range prevCases {
+		if got, ok := PrevPrime(tc.n); !ok || got != tc.expected {
+			t.Errorf("PrevPrime(%d) = %d, %v, attendu %d", tc.n, got, ok, tc.expected)
+		}
+	}
+	if _, ok := PrevPrime(2); ok {
+		t.Errorf("PrevPrime(2): ok = true, attendu false")
+	}
+}
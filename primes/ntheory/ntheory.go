@@ -0,0 +1,270 @@
+/*
+ * Fichier: ntheory.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Outils de théorie des nombres sur int64: PGCD et PGCD étendu, inverse
+ * modulaire, multiplication et exponentiation modulaires sans débordement,
+ * symboles de Jacobi et de Legendre, théorème des restes chinois (modules
+ * quelconques, pas forcément premiers entre eux), racine carrée modulaire
+ * (Tonelli-Shanks) et algorithme de Cornacchia (x^2 + d·y^2 = m). Ces briques
+ * servent aux tests de primalité de type Lucas/BPSW et à la décomposition des
+ * résultats en somme de deux carrés, et évitent aux utilisateurs de la
+ * bibliothèque de les réécrire. Le paquet ne dépend pas de primes.
+ */
+package ntheory
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrDomain signale un argument hors du domaine de la fonction (module nul ou négatif...).
+	ErrDomain = errors.New("ntheory: argument hors du domaine")
+	// ErrNotInvertible signale un élément sans inverse modulaire.
+	ErrNotInvertible = errors.New("ntheory: élément non inversible")
+	// ErrNoSolution signale un système de congruences incompatible.
+	ErrNoSolution = errors.New("ntheory: système de congruences sans solution")
+	// ErrOverflow signale un module combiné dépassant la capacité d'un int64.
+	ErrOverflow = errors.New("ntheory: module combiné dépassant la capacité d'un int64")
+)
+
+// abs retourne |a| en uint64 (y compris pour math.MinInt64).
+func abs(a int64) uint64 {
+	if a < 0 {
+		return uint64(-a)
+	}
+	return uint64(a)
+}
+
+// GCD retourne le plus grand commun diviseur de a et b, toujours positif ou nul (GCD(0, 0) = 0).
+func GCD(a, b int64) int64 {
+	x, y := abs(a), abs(b)
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return int64(x)
+}
+
+// ExtendedGCD retourne g = GCD(a, b) et des coefficients de Bézout x, y tels que a·x + b·y = g.
+func ExtendedGCD(a, b int64) (g, x, y int64) {
+	oldR, r := a, b
+	oldX, x := int64(1), int64(0)
+	oldY, y := int64(0), int64(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		oldR, oldX, oldY = -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// Mod retourne a mod m dans [0, m[ (m > 0), contrairement à l'opérateur % qui garde le signe de a.
+func Mod(a, m int64) int64 {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// MulMod retourne a·b mod m dans [0, m[ sans débordement (m > 0).
+func MulMod(a, b, m int64) int64 {
+	if m <= math.MaxUint32 {
+		// Produit de deux restes < 2^32: il tient dans un uint64, sans la division sur 128 bits.
+		return int64(uint64(Mod(a, m)) * uint64(Mod(b, m)) % uint64(m))
+	}
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m)))
+	return int64(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// PowMod retourne base^exp mod m dans [0, m[ (exp >= 0, m > 0), par exponentiation rapide.
+func PowMod(base, exp, m int64) int64 {
+	result, b := Mod(1, m), Mod(base, m)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = MulMod(result, b, m)
+		}
+		b = MulMod(b, b, m)
+	}
+	return result
+}
+
+// ModInverse retourne l'inverse de a modulo m (m > 0), dans [0, m[. L'erreur enveloppe
+// ErrNotInvertible si a et m ne sont pas premiers entre eux, ErrDomain si m <= 0.
+func ModInverse(a, m int64) (int64, error) {
+	if m <= 0 {
+		return 0, fmt.Errorf("%w: module %d", ErrDomain, m)
+	}
+	g, x, _ := ExtendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, fmt.Errorf("%w: %d mod %d (PGCD %d)", ErrNotInvertible, a, m, g)
+	}
+	return Mod(x, m), nil
+}
+
+// Jacobi retourne le symbole de Jacobi (a/n), -1, 0 ou 1, pour n impair positif. L'erreur
+// enveloppe ErrDomain si n est pair ou négatif.
+func Jacobi(a, n int64) (int, error) {
+	if n <= 0 || n%2 == 0 {
+		return 0, fmt.Errorf("%w: symbole de Jacobi (%d/%d), n doit être impair et positif", ErrDomain, a, n)
+	}
+	a = Mod(a, n)
+	result := 1
+	for a != 0 {
+		// (2/n) = -1 si n ≡ 3 ou 5 mod 8.
+		for a%2 == 0 {
+			a /= 2
+			if r := n % 8; r == 3 || r == 5 {
+				result = -result
+			}
+		}
+		// Réciprocité quadratique: le signe change si a ≡ n ≡ 3 mod 4.
+		a, n = n, a
+		if a%4 == 3 && n%4 == 3 {
+			result = -result
+		}
+		a %= n
+	}
+	if n != 1 {
+		return 0, nil
+	}
+	return result, nil
+}
+
+// Legendre retourne le symbole de Legendre (a/p) pour un nombre premier impair p: 1 si a est un
+// résidu quadratique non nul modulo p, -1 s'il n'en est pas un, 0 si p divise a. La primalité de p
+// n'est pas vérifiée (le résultat est alors le symbole de Jacobi); l'erreur enveloppe ErrDomain si
+// p < 3 ou p est pair.
+func Legendre(a, p int64) (int, error) {
+	if p < 3 {
+		return 0, fmt.Errorf("%w: symbole de Legendre (%d/%d), p doit être un nombre premier impair", ErrDomain, a, p)
+	}
+	return Jacobi(a, p)
+}
+
+// CRT résout le système x ≡ residues[i] mod moduli[i] (moduli[i] > 0, pas forcément premiers entre
+// eux) et retourne la plus petite solution x >= 0 et le module m = PPCM(moduli): les solutions sont
+// les x + k·m. L'erreur enveloppe ErrNoSolution si les congruences sont incompatibles, ErrOverflow
+// si le PPCM dépasse un int64 et ErrDomain si un module est nul ou négatif ou si les longueurs diffèrent.
+func CRT(residues, moduli []int64) (x, m int64, err error) {
+	if len(residues) != len(moduli) {
+		return 0, 0, fmt.Errorf("%w: %d restes pour %d modules", ErrDomain, len(residues), len(moduli))
+	}
+	x, m = 0, 1
+	for i, mi := range moduli {
+		if mi <= 0 {
+			return 0, 0, fmt.Errorf("%w: module %d", ErrDomain, mi)
+		}
+		ri := Mod(residues[i], mi)
+		// x ≡ r mod m et x ≡ ri mod mi: x = r + m·t avec m·t ≡ ri - r mod mi.
+		g, inv, _ := ExtendedGCD(m, mi)
+		diff := ri - Mod(x, mi)
+		if diff%g != 0 {
+			return 0, 0, fmt.Errorf("%w: x ≡ %d mod %d incompatible avec les congruences précédentes", ErrNoSolution, residues[i], mi)
+		}
+		step := mi / g
+		hi, lo := bits.Mul64(uint64(m), uint64(step))
+		if hi != 0 || lo > 1<<63-1 {
+			return 0, 0, fmt.Errorf("%w: PPCM(%d, %d)", ErrOverflow, m, mi)
+		}
+		t := MulMod(diff/g, inv, step)
+		x, m = x+m*t, int64(lo) // m·t < m·step = PPCM: pas de débordement.
+	}
+	return x, m, nil
+}
+
+// SqrtMod retourne une racine carrée r de a modulo le nombre premier p (r² ≡ a mod p), dans [0, p[,
+// par l'algorithme de Tonelli-Shanks; l'autre racine est p - r. La primalité de p n'est pas
+// vérifiée. L'erreur enveloppe ErrNoSolution si a n'est pas un résidu quadratique modulo p,
+// ErrDomain si p < 2.
+func SqrtMod(a, p int64) (int64, error) {
+	if p < 2 {
+		return 0, fmt.Errorf("%w: racine carrée modulo %d", ErrDomain, p)
+	}
+	a = Mod(a, p)
+	if p == 2 || a == 0 {
+		return a, nil
+	}
+	// Pour p premier impair, le symbole de Jacobi est celui de Legendre, bien moins coûteux que le
+	// critère d'Euler.
+	if j, _ := Jacobi(a, p); j != 1 {
+		return 0, fmt.Errorf("%w: %d n'est pas un carré modulo %d", ErrNoSolution, a, p)
+	}
+	// p - 1 = q·2^s avec q impair, et z un non-résidu quadratique.
+	s := bits.TrailingZeros64(uint64(p - 1))
+	q := (p - 1) >> s
+	z := int64(2)
+	for j, _ := Jacobi(z, p); j != -1; j, _ = Jacobi(z, p) {
+		z++
+	}
+	m, c, t, r := s, PowMod(z, q, p), PowMod(a, q, p), PowMod(a, (q+1)/2, p)
+	for t != 1 {
+		// Plus petit i tel que t^(2^i) = 1.
+		i, t2 := 0, t
+		for t2 != 1 {
+			t2 = MulMod(t2, t2, p)
+			i++
+		}
+		b := c
+		for range m - i - 1 {
+			b = MulMod(b, b, p)
+		}
+		m, c = i, MulMod(b, b, p)
+		t, r = MulMod(t, c, p), MulMod(r, b, p)
+	}
+	return r, nil
+}
+
+// isqrt retourne la partie entière de √n (n >= 0).
+func isqrt(n int64) int64 {
+	r := int64(math.Sqrt(float64(n)))
+	for r > 0 && (r > math.MaxInt64/r || r*r > n) {
+		r--
+	}
+	for r+1 <= math.MaxInt64/(r+1) && (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
+
+// Cornacchia résout x² + d·y² = m (0 < d < m, m premier) et retourne la solution x, y > 0, unique
+// pour m premier, par l'algorithme de Cornacchia: une racine carrée r0 de -d modulo m, puis
+// l'algorithme d'Euclide sur (m, r0) jusqu'au premier reste inférieur à √m. La primalité de m
+// n'est pas vérifiée (pour m composé, seules certaines solutions primitives sont trouvées).
+// L'erreur enveloppe ErrNoSolution si l'équation n'a pas de solution, ErrDomain si d ou m sont
+// hors du domaine.
+func Cornacchia(d, m int64) (x, y int64, err error) {
+	if d <= 0 || m <= d {
+		return 0, 0, fmt.Errorf("%w: x² + %d·y² = %d", ErrDomain, d, m)
+	}
+	r0, err := SqrtMod(-d, m)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: x² + %d·y² = %d (-%d n'est pas un carré modulo %d)", ErrNoSolution, d, m, d, m)
+	}
+	if r0 <= m/2 {
+		r0 = m - r0
+	}
+	limit := isqrt(m)
+	a, b := m, r0
+	for b > limit {
+		a, b = b, a%b
+	}
+	rest := m - b*b
+	if rest%d != 0 {
+		return 0, 0, fmt.Errorf("%w: x² + %d·y² = %d", ErrNoSolution, d, m)
+	}
+	y = isqrt(rest / d)
+	if y == 0 || y*y != rest/d {
+		return 0, 0, fmt.Errorf("%w: x² + %d·y² = %d", ErrNoSolution, d, m)
+	}
+	return b, y, nil
+}
@@ -0,0 +1,223 @@
+/*
+ * Fichier: ntheory_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des outils de théorie des nombres, contrôlés par force brute sur de petites valeurs.
+ */
+package ntheory
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestGCD valide GCD et les coefficients de Bézout de ExtendedGCD, signes compris.
+func TestGCD(t *testing.T) {
+	for a := int64(-30); a <= 30; a++ {
+		for b := int64(-30); b <= 30; b++ {
+			g, x, y := ExtendedGCD(a, b)
+			if g != GCD(a, b) || a*x+b*y != g || g < 0 {
+				t.Fatalf("ExtendedGCD(%d, %d) = %d, %d, %d; GCD = %d", a, b, g, x, y, GCD(a, b))
+			}
+			if g != 0 && (a%g != 0 || b%g != 0) {
+				t.Fatalf("GCD(%d, %d) = %d ne divise pas les deux", a, b, g)
+			}
+		}
+	}
+}
+
+// TestModular valide MulMod et PowMod près de la capacité d'un int64, et ModInverse.
+func TestModular(t *testing.T) {
+	const m = math.MaxInt64 // 2^63 - 1 = 7² · 73 · 127 · 337 · 92737 · 649657.
+	if got := MulMod(m-1, m-1, m); got != 1 {
+		t.Errorf("MulMod(m-1, m-1, m) = %d, attendu 1", got)
+	}
+	if got := MulMod(-3, 5, 7); got != 6 {
+		t.Errorf("MulMod(-3, 5, 7) = %d, attendu 6", got)
+	}
+	// Petit théorème de Fermat pour le nombre premier 2^61 - 1.
+	if p := int64(1)<<61 - 1; PowMod(3, p-1, p) != 1 || PowMod(5, 0, 1) != 0 {
+		t.Error("PowMod: théorème de Fermat non vérifié")
+	}
+
+	for n := int64(1); n <= 40; n++ {
+		for a := int64(-40); a <= 40; a++ {
+			inv, err := ModInverse(a, n)
+			if GCD(a, n) != 1 {
+				if !errors.Is(err, ErrNotInvertible) {
+					t.Fatalf("ModInverse(%d, %d): erreur %v, attendu ErrNotInvertible", a, n, err)
+				}
+				continue
+			}
+			if err != nil || inv < 0 || inv >= n || MulMod(a, inv, n) != Mod(1, n) {
+				t.Fatalf("ModInverse(%d, %d) = %d, %v", a, n, inv, err)
+			}
+		}
+	}
+	if _, err := ModInverse(3, 0); !errors.Is(err, ErrDomain) {
+		t.Errorf("ModInverse(3, 0): erreur %v, attendu ErrDomain", err)
+	}
+}
+
+// TestJacobi compare Legendre au critère d'Euler et Jacobi au produit des symboles de Legendre.
+func TestJacobi(t *testing.T) {
+	oddPrimes := []int64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31}
+	legendre := func(a, p int64) int {
+		switch PowMod(a, (p-1)/2, p) {
+		case 0:
+			return 0
+		case 1:
+			return 1
+		}
+		return -1
+	}
+	for _, p := range oddPrimes {
+		for a := int64(-50); a <= 50; a++ {
+			if got, err := Legendre(a, p); err != nil || got != legendre(a, p) {
+				t.Fatalf("Legendre(%d, %d) = %d, %v; attendu %d", a, p, got, err, legendre(a, p))
+			}
+		}
+	}
+	for n := int64(1); n < 400; n += 2 {
+		for a := int64(-20); a <= 60; a++ {
+			expected, m := 1, n
+			for _, p := range oddPrimes {
+				for m%p == 0 {
+					expected *= legendre(a, p)
+					m /= p
+				}
+			}
+			if m != 1 {
+				continue // Facteur premier hors de la table.
+			}
+			if got, err := Jacobi(a, n); err != nil || got != expected {
+				t.Fatalf("Jacobi(%d, %d) = %d, %v; attendu %d", a, n, got, err, expected)
+			}
+		}
+	}
+	for _, bad := range [][2]int64{{1, 0}, {1, 8}, {1, -3}} {
+		if _, err := Jacobi(bad[0], bad[1]); !errors.Is(err, ErrDomain) {
+			t.Errorf("Jacobi(%d, %d): erreur %v, attendu ErrDomain", bad[0], bad[1], err)
+		}
+	}
+	if _, err := Legendre(1, 1); !errors.Is(err, ErrDomain) {
+		t.Errorf("Legendre(1, 1): erreur %v, attendu ErrDomain", err)
+	}
+}
+
+// TestCRT compare CRT à une recherche exhaustive, avec des modules premiers entre eux ou non.
+func TestCRT(t *testing.T) {
+	cases := []struct {
+		residues, moduli []int64
+	}{
+		{[]int64{2, 3, 2}, []int64{3, 5, 7}}, // Sunzi: 23 mod 105.
+		{[]int64{1, 3}, []int64{4, 6}},       // Modules non premiers entre eux: 9 mod 12.
+		{[]int64{1, 2}, []int64{4, 6}},       // Incompatible (parités différentes).
+		{[]int64{-1, 10}, []int64{8, 9}},     // Restes négatifs ou hors intervalle.
+		{nil, nil},                           // Système vide: 0 mod 1.
+	}
+	for _, c := range cases {
+		x, m, err := CRT(c.residues, c.moduli)
+		lcm, want := int64(1), int64(-1)
+		for _, mi := range c.moduli {
+			lcm = lcm / GCD(lcm, mi) * mi
+		}
+		for y := int64(0); y < lcm && want < 0; y++ {
+			ok := true
+			for i, mi := range c.moduli {
+				ok = ok && Mod(y, mi) == Mod(c.residues[i], mi)
+			}
+			if ok {
+				want = y
+			}
+		}
+		switch {
+		case want < 0 && !errors.Is(err, ErrNoSolution):
+			t.Errorf("CRT(%v, %v): erreur %v, attendu ErrNoSolution", c.residues, c.moduli, err)
+		case want >= 0 && (err != nil || x != want || m != lcm):
+			t.Errorf("CRT(%v, %v) = %d mod %d, %v; attendu %d mod %d", c.residues, c.moduli, x, m, err, want, lcm)
+		}
+	}
+
+	if _, _, err := CRT([]int64{0, 0}, []int64{1 << 40, 1<<40 - 1}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("PPCM > int64: erreur %v, attendu ErrOverflow", err)
+	}
+	if _, _, err := CRT([]int64{0}, []int64{0}); !errors.Is(err, ErrDomain) {
+		t.Errorf("module nul: erreur %v, attendu ErrDomain", err)
+	}
+}
+
+// TestSqrtMod compare SqrtMod au critère d'Euler sur de petits nombres premiers, et vérifie une
+// racine modulo un grand nombre premier (p ≡ 1 mod 2^k, cas le plus long de Tonelli-Shanks).
+func TestSqrtMod(t *testing.T) {
+	for _, p := range []int64{2, 3, 5, 7, 13, 17, 41, 97, 257, 65537} {
+		for a := int64(-10); a < 3*p; a++ {
+			r, err := SqrtMod(a, p)
+			residue := Mod(a, p) == 0 || PowMod(a, (p-1)/2, p) == 1 || p == 2
+			switch {
+			case !residue && !errors.Is(err, ErrNoSolution):
+				t.Fatalf("SqrtMod(%d, %d): erreur %v, attendu ErrNoSolution", a, p, err)
+			case residue && (err != nil || r < 0 || r >= p || MulMod(r, r, p) != Mod(a, p)):
+				t.Fatalf("SqrtMod(%d, %d) = %d, %v", a, p, r, err)
+			}
+		}
+	}
+	const p = 4611686018427387847 // Plus grand nombre premier < 2^62.
+	if r, err := SqrtMod(4, p); err != nil || MulMod(r, r, p) != 4 {
+		t.Errorf("SqrtMod(4, %d) = %d, %v", p, r, err)
+	}
+	if _, err := SqrtMod(1, 1); !errors.Is(err, ErrDomain) {
+		t.Errorf("SqrtMod(1, 1): erreur %v, attendu ErrDomain", err)
+	}
+}
+
+// TestCornacchia compare Cornacchia à une recherche exhaustive de x² + d·y² = m pour les nombres
+// premiers m < 2000, et vérifie une décomposition en somme de deux carrés près de 2^62.
+func TestCornacchia(t *testing.T) {
+	for m := int64(2); m < 2000; m++ {
+		if !isPrimeSmall(m) {
+			continue
+		}
+		for d := int64(1); d < min(m, 12); d++ {
+			var wantX, wantY int64
+			for y := int64(1); d*y*y < m; y++ {
+				if x := isqrt(m - d*y*y); x > 0 && x*x == m-d*y*y {
+					wantX, wantY = x, y
+					break
+				}
+			}
+			x, y, err := Cornacchia(d, m)
+			switch {
+			case wantY == 0 && !errors.Is(err, ErrNoSolution):
+				t.Fatalf("Cornacchia(%d, %d): erreur %v, attendu ErrNoSolution", d, m, err)
+			case wantY != 0 && (err != nil || x != wantX || y != wantY):
+				t.Fatalf("Cornacchia(%d, %d) = %d, %d, %v; attendu %d, %d", d, m, x, y, err, wantX, wantY)
+			}
+		}
+	}
+	const m = 4611686018427387733 // Nombre premier ≡ 1 mod 4 proche de 2^62.
+	if x, y, err := Cornacchia(1, m); err != nil || uint64(x)*uint64(x)+uint64(y)*uint64(y) != m {
+		t.Errorf("Cornacchia(1, %d) = %d, %d, %v", m, x, y, err)
+	}
+	for _, bad := range [][2]int64{{0, 5}, {5, 5}, {-1, 7}} {
+		if _, _, err := Cornacchia(bad[0], bad[1]); !errors.Is(err, ErrDomain) {
+			t.Errorf("Cornacchia(%d, %d): erreur %v, attendu ErrDomain", bad[0], bad[1], err)
+		}
+	}
+}
+
+// isPrimeSmall est un test de primalité par divisions, pour les petites valeurs des tests.
+func isPrimeSmall(n int64) bool {
+	if n < 2 {
+		return false
+	}
+	for d := int64(2); d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,340 @@
+/*
+ * Fichier: options.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Configuration d'une recherche: structure Options, options fonctionnelles
+ * (WithWorkers, WithPrimalityTest, WithForm, WithBounds...) et validation
+ * unique, partagée par NewOptions et les points d'entrée de la recherche.
+ * Une transformation fournie (WithTransform) remplace la forme pour des
+ * expériences sur d'autres candidats sans modifier le moteur.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// ErrInvalidOptions signale une configuration de recherche invalide.
+var ErrInvalidOptions = errors.New("primes: options de recherche invalides")
+
+// primalityTests sont les noms de tests de primalité acceptés par PrimalityTest.
+var primalityTests = []string{"miller", "trial", "auto", "adaptive", "lucas", "bpsw", "aks"}
+
+// PrimalityTestNames retourne les noms des tests de primalité disponibles.
+func PrimalityTestNames() []string {
+	return slices.Clone(primalityTests)
+}
+
+// TransformFunc calcule le candidat n associé à la paire (p, q), ou écarte la paire sans test
+// (ok = false). Elle est appelée par plusieurs workers à la fois.
+type TransformFunc func(p, q int64) (n int64, ok bool)
+
+// Options configure une recherche (voir Search). Les champs laissés à leur valeur zéro prennent
+// leur valeur par défaut. Les options peuvent être construites directement ou avec NewOptions.
+type Options struct {
+	Min           int                 // Borne inférieure de p et q (0: aucune).
+	Limit         int                 // Borne supérieure de p et q: le crible est calculé jusqu'à Limit si Primes est vide.
+	Primes        []int               // Liste triée des nombres premiers à combiner (prioritaire sur Limit).
+	PMin          int                 // Borne inférieure de p seul, q restant dans [Min, Limit] (0: aucune).
+	PMax          int                 // Borne supérieure de p seul (0: aucune); une tranche [PMin, PMax] de la grille.
+	Jobs          JobSource           // Paires à tester à la place de la grille (voir WithJobs).
+	PrimeTest     string              // Test de primalité: l'un de PrimalityTestNames (défaut: "miller").
+	PrimeTestFunc func(int64) bool    // Test fourni, prioritaire sur PrimeTest (appelé par plusieurs workers à la fois).
+	BigPrimeTest  func(*big.Int) bool // Test des candidats au-delà de 2^64, avec OverflowPromote (défaut: IsPrimeBig).
+	Workers       int                 // Nombre de workers (défaut: runtime.NumCPU()).
+	BatchSize     int                 // Paires par lot (défaut: DefaultBatchSize); taille initiale avec BatchTarget.
+	BatchTarget   time.Duration       // Durée visée par lot: taille des lots adaptative (0: taille fixe; voir WithBatchTarget).
+	JobsBuffer    int                 // Capacité du canal des tâches, en lots (défaut: DefaultJobsBuffer).
+	ResultsBuffer int                 // Capacité des canaux de résultats (défaut: DefaultResultsBuffer).
+	Form          Form                // Forme de n (défaut: DefaultForm).
+	Transform     TransformFunc       // Transformation fournie, prioritaire sur Form (voir WithTransform).
+	Pairs         PairMode            // Région de la grille (p, q) énumérée (défaut: PairsAll).
+	OnOverflow    OverflowPolicy      // Sort des candidats dépassant int64 (défaut: OverflowError).
+	Filter        Filter              // Filtre optionnel des n premiers remontés.
+	Twins         bool                // Renseigne Result.Twin.
+	Timing        bool                // Renseigne Result.FoundAt et Result.TestTime.
+	Explain       *Explain            // Analyse optionnelle des valeurs composées.
+	Control       *Control            // Suspension, reprise et arrêt optionnels de la distribution des tâches.
+	OnProgress    ProgressFunc        // Appelé toutes les ProgressInterval puis une dernière fois à la fin.
+	Reporter      ProgressReporter    // Suivi des étapes de la recherche (voir WithReporter).
+	StallTimeout  time.Duration       // Délai du chien de garde des workers (0: aucun; voir WithWatchdog).
+	OnStall       func(Stall)         // Reçoit chaque blocage détecté par le chien de garde.
+	SkipStalled   bool                // Abandonne les paires bloquées (voir WithSkipStalled).
+	Accelerator   Accelerator         // Crible et pré-filtre déchargés (voir WithAccelerator).
+}
+
+// Option modifie une configuration de recherche (voir NewOptions).
+type Option func(*Options)
+
+// WithWorkers fixe le nombre de workers.
+func WithWorkers(n int) Option { return func(o *Options) { o.Workers = n } }
+
+// WithBatchSize fixe le nombre de paires par lot distribué aux workers.
+func WithBatchSize(n int) Option { return func(o *Options) { o.BatchSize = n } }
+
+// WithBatchTarget rend la taille des lots adaptative: partie de BatchSize, elle est ajustée
+// pendant la recherche, d'après le coût observé des paires, pour que chaque lot dure environ
+// target (entre target/2 et 2·target). Utile quand le coût des candidats varie au fil de
+// l'énumération; sans effet sur SearchReverse, qui découpe les n en blocs fixes.
+func WithBatchTarget(target time.Duration) Option { return func(o *Options) { o.BatchTarget = target } }
+
+// WithBuffers fixe la capacité du canal des tâches (en lots) et celle des canaux de résultats;
+// 0 garde la capacité par défaut, calculée à partir des workers et de la taille des lots.
+func WithBuffers(jobs, results int) Option {
+	return func(o *Options) { o.JobsBuffer, o.ResultsBuffer = jobs, results }
+}
+
+// WithPrimalityTest choisit le test de primalité (voir PrimalityTest).
+func WithPrimalityTest(name string) Option { return func(o *Options) { o.PrimeTest = name } }
+
+// WithBigPrimalityTest fournit le test des candidats promus au-delà d'int64 (voir
+// OverflowPromote), par exemple MillerRabinPolicy.IsPrimeBig avec une source de bases
+// cryptographique.
+func WithBigPrimalityTest(fn func(*big.Int) bool) Option {
+	return func(o *Options) { o.BigPrimeTest = fn }
+}
+
+// WithForm choisit la forme de n.
+func WithForm(f Form) Option { return func(o *Options) { o.Form = f } }
+
+// WithTransform remplace l'évaluation de la forme par fn: chaque paire (p, q) est soumise à fn, qui
+// calcule le candidat ou écarte la paire (ex: n = p^2 + 4q^2 + 2). Form.Prune n'est pas appliquée et
+// la limite de la forme n'est pas vérifiée: un débordement n'est détecté que si fn retourne un n
+// négatif (la recherche échoue alors avec ErrOverflow). Le nom de la forme reste celui de Form.
+func WithTransform(fn TransformFunc) Option { return func(o *Options) { o.Transform = fn } }
+
+// WithPairs restreint l'énumération à une région de la grille (p, q).
+func WithPairs(m PairMode) Option { return func(o *Options) { o.Pairs = m } }
+
+// WithOverflowPolicy choisit le sort des candidats dépassant int64: avec OverflowSkip ou
+// OverflowPromote, la limite de la forme n'est plus vérifiée et chaque paire au-delà est évaluée
+// exactement (sur uint64 jusqu'à 2^64 pour les formes prédéfinies, BigForm au-delà); avec
+// OverflowUint64, la limite est vérifiée jusqu'à 2^64 (CheckFormLimitUint64). OverflowPromote et
+// OverflowUint64 sont incompatibles avec Transform et Filter.
+func WithOverflowPolicy(p OverflowPolicy) Option { return func(o *Options) { o.OnOverflow = p } }
+
+// WithJobs remplace l'énumération de la grille par les paires de src (une part, une reprise ou un
+// flux, par exemple): Min, Limit, Primes, PMin, PMax et Pairs ne s'appliquent plus et le crible
+// n'est pas calculé. Avec OverflowError, une paire dont n déborde arrête la recherche avec une
+// erreur enveloppant ErrOverflow. Une source qui échoue (voir ReaderSource.Err) arrête la recherche
+// avec son erreur.
+func WithJobs(src JobSource) Option { return func(o *Options) { o.Jobs = src } }
+
+// WithFilter ne remonte que les n premiers acceptés par f.
+func WithFilter(f Filter) Option { return func(o *Options) { o.Filter = f } }
+
+// WithTwins active le marquage des nombres premiers jumeaux (Result.Twin).
+func WithTwins() Option { return func(o *Options) { o.Twins = true } }
+
+// WithTiming horodate chaque résultat et mesure la durée du test de son candidat (Result.FoundAt,
+// Result.TestTime).
+func WithTiming() Option { return func(o *Options) { o.Timing = true } }
+
+// WithBounds restreint p et q à l'intervalle [lo, hi]; le crible est calculé jusqu'à hi.
+func WithBounds(lo, hi int) Option { return func(o *Options) { o.Min, o.Limit = lo, hi } }
+
+// WithPBounds restreint p seul à l'intervalle [lo, hi] (hi = 0: aucune borne supérieure), pour
+// découper la grille (p, q) en tranches indépendantes.
+func WithPBounds(lo, hi int) Option { return func(o *Options) { o.PMin, o.PMax = lo, hi } }
+
+// WithPrimes fournit la liste triée des nombres premiers à combiner, à la place du crible.
+func WithPrimes(primeList []int) Option { return func(o *Options) { o.Primes = primeList } }
+
+// WithExplain active l'analyse des valeurs composées (voir Explain).
+func WithExplain(every int, onComposite func(Composite)) Option {
+	return func(o *Options) { o.Explain = &Explain{Every: every, OnComposite: onComposite} }
+}
+
+// WithControl associe un contrôleur de suspension, de reprise et d'arrêt à la recherche.
+func WithControl(ctl *Control) Option { return func(o *Options) { o.Control = ctl } }
+
+// WithProgress fixe le callback de progression.
+func WithProgress(fn ProgressFunc) Option { return func(o *Options) { o.OnProgress = fn } }
+
+// WithReporter fixe le suivi des étapes de la recherche (crible, lots, résultats, bilan); pour
+// plusieurs suivis, voir MultiReporter. OnProgress, s'il est fixé, reçoit les mêmes états que
+// OnBatchDone.
+func WithReporter(r ProgressReporter) Option { return func(o *Options) { o.Reporter = r } }
+
+// WithWatchdog active le chien de garde des workers: un test de candidat qui dure plus que
+// timeout est signalé une fois à onStall, appelé depuis la goroutine du chien de garde (jamais en
+// concurrence avec lui-même). Le relevé coûte deux écritures atomiques par candidat; sans effet
+// sur SearchReverse.
+func WithWatchdog(timeout time.Duration, onStall func(Stall)) Option {
+	return func(o *Options) { o.StallTimeout, o.OnStall = timeout, onStall }
+}
+
+// WithSkipStalled complète WithWatchdog: une paire bloquée est abandonnée, son test se poursuit
+// en arrière-plan sans être attendu (le CPU qu'il occupe n'est rendu qu'à sa fin) et un nouveau
+// worker reprend la suite. Le résultat de la paire est perdu: voir Stall.Skipped.
+func WithSkipStalled() Option { return func(o *Options) { o.SkipStalled = true } }
+
+// WithAccelerator décharge sur acc le marquage du crible (SieveAccelerated) et le pré-filtre des
+// candidats par petits nombres premiers: chaque worker soumet son lot à acc.Prefilter et ne teste
+// sur CPU que les survivants. Les résultats sont les mêmes; l'appelant ferme acc après la
+// recherche.
+func WithAccelerator(acc Accelerator) Option { return func(o *Options) { o.Accelerator = acc } }
+
+// NewOptions construit et valide une configuration de recherche à partir des options données.
+// L'erreur enveloppe ErrInvalidOptions, ou ErrOverflow si les bornes dépassent la capacité de la forme.
+func NewOptions(opts ...Option) (Options, error) {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.validate()
+}
+
+// validate complète les options par leurs valeurs par défaut et vérifie leur cohérence.
+func (o Options) validate() (Options, error) {
+	if o.PrimeTest == "" {
+		o.PrimeTest = "miller"
+	}
+	if o.Workers == 0 {
+		o.Workers = runtime.NumCPU()
+	}
+	if o.BatchSize == 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.JobsBuffer == 0 {
+		o.JobsBuffer = DefaultJobsBuffer(o.Workers, o.BatchSize)
+	}
+	if o.ResultsBuffer == 0 {
+		o.ResultsBuffer = DefaultResultsBuffer(o.Workers, o.BatchSize)
+	}
+	if o.Form == nil {
+		o.Form = DefaultForm
+	}
+	if o.BigPrimeTest == nil {
+		o.BigPrimeTest = IsPrimeBig
+	}
+
+	switch {
+	case !slices.Contains(primalityTests, o.PrimeTest):
+		return o, fmt.Errorf("%w: test de primalité %q (attendu l'un de %v)", ErrInvalidOptions, o.PrimeTest, primalityTests)
+	case o.Workers < 0 || o.BatchSize < 0:
+		return o, fmt.Errorf("%w: workers=%d, lots de %d (attendu >= 1)", ErrInvalidOptions, o.Workers, o.BatchSize)
+	case o.StallTimeout < 0:
+		return o, fmt.Errorf("%w: délai du chien de garde %v (attendu >= 0)", ErrInvalidOptions, o.StallTimeout)
+	case o.SkipStalled && o.StallTimeout == 0:
+		return o, fmt.Errorf("%w: l'abandon des paires bloquées exige un délai de chien de garde", ErrInvalidOptions)
+	case o.BatchTarget < 0:
+		return o, fmt.Errorf("%w: durée visée par lot %v (attendu >= 0)", ErrInvalidOptions, o.BatchTarget)
+	case o.JobsBuffer < 0 || o.ResultsBuffer < 0:
+		return o, fmt.Errorf("%w: tampons de %d lots et %d résultats (attendu >= 1)", ErrInvalidOptions, o.JobsBuffer, o.ResultsBuffer)
+	case o.Min < 0 || o.Limit < 0 || (len(o.Primes) == 0 && o.Min > o.Limit):
+		return o, fmt.Errorf("%w: bornes [%d, %d]", ErrInvalidOptions, o.Min, o.Limit)
+	case o.PMin < 0 || o.PMax < 0 || (o.PMax > 0 && o.PMin > o.PMax):
+		return o, fmt.Errorf("%w: bornes de p [%d, %d]", ErrInvalidOptions, o.PMin, o.PMax)
+	case o.Pairs < PairsAll || o.Pairs > PairsEqual:
+		return o, fmt.Errorf("%w: mode de paires %d", ErrInvalidOptions, o.Pairs)
+	case o.Explain != nil && o.Explain.Every < 0:
+		return o, fmt.Errorf("%w: échantillonnage des composés %d (attendu >= 0)", ErrInvalidOptions, o.Explain.Every)
+	case o.OnOverflow < OverflowError || o.OnOverflow > OverflowUint64:
+		return o, fmt.Errorf("%w: politique de débordement %d", ErrInvalidOptions, o.OnOverflow)
+	case (o.OnOverflow == OverflowPromote || o.OnOverflow == OverflowUint64) && (o.Transform != nil || o.Filter != nil):
+		return o, fmt.Errorf("%w: la politique %s exclut transformation et filtre", ErrInvalidOptions, o.OnOverflow)
+	}
+	if _, ok := o.Form.(BigForm); (o.OnOverflow == OverflowSkip || o.OnOverflow == OverflowPromote) && o.Transform == nil && !ok {
+		return o, fmt.Errorf("%w: la forme %s ne s'évalue pas au-delà d'int64 (politique %s)", ErrInvalidOptions, o.Form.Name(), o.OnOverflow)
+	}
+
+	limit := o.Limit
+	if len(o.Primes) > 0 {
+		limit = o.Primes[len(o.Primes)-1]
+	}
+	switch {
+	case o.Transform != nil || o.OnOverflow == OverflowSkip || o.OnOverflow == OverflowPromote:
+		return o, nil // Valeurs non bornées par la forme, ou débordements traités paire par paire.
+	case o.OnOverflow == OverflowUint64:
+		return o, CheckFormLimitUint64(o.Form, limit)
+	}
+	return o, CheckFormLimit(o.Form, limit)
+}
+
+// primeList retourne les nombres premiers à combiner: Primes, ou le crible jusqu'à Limit
+// (interrompu par l'annulation de ctx, déchargé sur Accelerator s'il est fixé), restreints à ceux
+// supérieurs ou égaux à Min.
+func (o Options) primeList(ctx context.Context) ([]int, error) {
+	list := o.Primes
+	if len(list) == 0 {
+		var err error
+		if o.Accelerator != nil {
+			list, err = SieveAccelerated(ctx, o.Limit, o.Accelerator)
+		} else {
+			list, err = SieveOfEratosthenesContext(ctx, o.Limit)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	i, _ := slices.BinarySearch(list, o.Min)
+	return list[i:], nil
+}
+
+// primalityFunc retourne le test de primalité des options: PrimeTestFunc, sinon PrimeTest.
+func (o Options) primalityFunc() func(int64) bool {
+	if o.PrimeTestFunc != nil {
+		return o.PrimeTestFunc
+	}
+	return PrimalityTest(o.PrimeTest)
+}
+
+// candidateFunc retourne le calcul des candidats: Transform, sinon l'évaluation de Form, dont les
+// paires écartées par Prune sont rejetées.
+func (o Options) candidateFunc() TransformFunc {
+	if o.Transform != nil {
+		return o.Transform
+	}
+	form := o.Form
+	return func(p, q int64) (int64, bool) {
+		if form.Prune(p, q) {
+			return 0, false
+		}
+		return form.Eval(p, q), true
+	}
+}
+
+// bigForm retourne l'évaluation exacte des candidats si la politique de débordement l'exige, et
+// la limite en deçà de laquelle elle est inutile (0: à vérifier pour toute paire).
+func (o Options) bigForm() (BigForm, int64) {
+	bf, ok := o.Form.(BigForm)
+	if !ok || o.OnOverflow == OverflowError || o.OnOverflow == OverflowUint64 || o.Transform != nil {
+		return nil, 0
+	}
+	if lf, ok := o.Form.(LimitedForm); ok {
+		return bf, int64(lf.MaxLimit())
+	}
+	return bf, 0
+}
+
+// wideForm retourne l'évaluation sur uint64 des candidats si la politique de débordement en
+// admet au-delà d'int64, et la limite int64 de la forme, en deçà de laquelle elle est inutile.
+func (o Options) wideForm() (wideForm, int64) {
+	wf, ok := o.Form.(wideForm)
+	if !ok || o.OnOverflow == OverflowError || o.Transform != nil {
+		return nil, 0
+	}
+	return wf, int64(wf.MaxLimit())
+}
+
+// pInRange indique si p appartient à la tranche [PMin, PMax].
+func (o Options) pInRange(p int) bool {
+	return p >= o.PMin && (o.PMax == 0 || p <= o.PMax)
+}
+
+// jobSource retourne la source des paires de primeList: Jobs, sinon la grille des options.
+func (o Options) jobSource(primeList []int) JobSource {
+	if o.Jobs != nil {
+		return o.Jobs
+	}
+	return NewGridSource(primeList, o.Pairs, o.PMin, o.PMax)
+}
@@ -0,0 +1,156 @@
+/*
+ * Fichier: options_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des options fonctionnelles et de la validation de la configuration.
+ */
+package primes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// TestNewOptions valide les valeurs par défaut et l'application des options fonctionnelles.
+func TestNewOptions(t *testing.T) {
+	o, err := NewOptions()
+	if err != nil {
+		t.Fatalf("NewOptions() = %v", err)
+	}
+	if o.PrimeTest != "miller" || o.Workers != runtime.NumCPU() || o.BatchSize != DefaultBatchSize || o.Form != DefaultForm {
+		t.Errorf("valeurs par défaut = %+v", o)
+	}
+
+	ctl := NewControl()
+	o, err = NewOptions(WithWorkers(3), WithBatchSize(8), WithPrimalityTest("auto"), WithForm(FormX2Plus1),
+		WithFilter(FilterSafe), WithTwins(), WithBounds(10, 100), WithControl(ctl), WithExplain(2, func(Composite) {}))
+	if err != nil {
+		t.Fatalf("NewOptions(...) = %v", err)
+	}
+	if o.Workers != 3 || o.BatchSize != 8 || o.PrimeTest != "auto" || o.Form != FormX2Plus1 || o.Filter != FilterSafe ||
+		!o.Twins || o.Min != 10 || o.Limit != 100 || o.Control != ctl || o.Explain.Every != 2 {
+		t.Errorf("options appliquées = %+v", o)
+	}
+}
+
+// TestOptionsValidation valide le rejet des configurations invalides.
+func TestOptionsValidation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []Option
+		expected error
+	}{
+		{"test inconnu", []Option{WithPrimalityTest("inconnu")}, ErrInvalidOptions},
+		{"workers négatifs", []Option{WithWorkers(-1)}, ErrInvalidOptions},
+		{"lots négatifs", []Option{WithBatchSize(-4)}, ErrInvalidOptions},
+		{"bornes inversées", []Option{WithBounds(50, 10)}, ErrInvalidOptions},
+		{"borne négative", []Option{WithBounds(-1, 10)}, ErrInvalidOptions},
+		{"bornes de p inversées", []Option{WithPBounds(50, 10)}, ErrInvalidOptions},
+		{"échantillonnage négatif", []Option{WithExplain(-1, nil)}, ErrInvalidOptions},
+		{"débordement", []Option{WithBounds(0, MaxLimit+1)}, ErrOverflow},
+		{"débordement de la forme", []Option{WithForm(FormP2PlusQ4), WithBounds(0, 60000)}, ErrOverflow},
+	}
+	for _, tc := range testCases {
+		if _, err := NewOptions(tc.opts...); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: NewOptions = %v, attendu %v", tc.name, err, tc.expected)
+		}
+	}
+
+	// Search applique la même validation aux options construites directement.
+	if err := Search(context.Background(), Options{Workers: -2}, func(Result) error { return nil }); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Search(Workers: -2) = %v, attendu ErrInvalidOptions", err)
+	}
+}
+
+// TestSearchBounds valide la restriction de p et q à l'intervalle donné par WithBounds.
+func TestSearchBounds(t *testing.T) {
+	o, err := NewOptions(WithBounds(5, 10), WithWorkers(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int64
+	if err := Search(context.Background(), o, func(r Result) error { got = append(got, r.N); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	// p, q ∈ {5, 7}: 5² + 4·7² = 221 = 13·17, 7² + 4·5² = 149, 5² + 4·5² = 125, 7² + 4·7² = 245.
+	if !slices.Equal(got, []int64{149}) {
+		t.Errorf("résultats = %v, attendu [149]", got)
+	}
+}
+
+// TestSearchPBounds vérifie que des tranches de p disjointes couvrent exactement la recherche
+// complète, pour chaque mode de paires, et que la progression compte les paires de la tranche.
+func TestSearchPBounds(t *testing.T) {
+	collect := func(opts ...Option) ([]Result, Progress) {
+		t.Helper()
+		o, err := NewOptions(append(opts, WithBounds(0, 200), WithWorkers(2))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []Result
+		var last Progress
+		o.OnProgress = func(p Progress) { last = p }
+		if err := Search(context.Background(), o, func(r Result) error { got = append(got, r); return nil }); err != nil {
+			t.Fatal(err)
+		}
+		slices.SortFunc(got, func(a, b Result) int { return int(a.N - b.N) })
+		return got, last
+	}
+	for _, mode := range []PairMode{PairsAll, PairsLess, PairsDistinct, PairsEqual} {
+		all, _ := collect(WithPairs(mode))
+		var union []Result
+		for _, r := range [][2]int{{0, 50}, {51, 120}, {121, 0}} {
+			got, last := collect(WithPairs(mode), WithPBounds(r[0], r[1]))
+			if last.Tested != last.Total {
+				t.Errorf("%v, p dans %v: %d paires testées sur %d annoncées", mode, r, last.Tested, last.Total)
+			}
+			union = append(union, got...)
+		}
+		slices.SortFunc(union, func(a, b Result) int { return int(a.N - b.N) })
+		if !slices.Equal(union, all) {
+			t.Errorf("%v: %d résultats par tranches, %d en une recherche", mode, len(union), len(all))
+		}
+	}
+}
+
+// TestSearchTransform valide une transformation des candidats fournie par l'utilisateur.
+func TestSearchTransform(t *testing.T) {
+	transform := func(p, q int64) (int64, bool) { return p*p + 4*q*q + 2, p != q }
+	o, err := NewOptions(WithBounds(0, 100), WithWorkers(2), WithTransform(transform))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []Result
+	if err := Search(context.Background(), o, func(r Result) error { got = append(got, r); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	var want []Result
+	primeList := SieveOfEratosthenes(100)
+	for _, p := range primeList {
+		for _, q := range primeList {
+			if n, ok := transform(int64(p), int64(q)); ok && IsPrime(n) {
+				want = append(want, Result{P: p, Q: q, N: n})
+			}
+		}
+	}
+	byPair := func(a, b Result) int { return cmp.Or(cmp.Compare(a.P, b.P), cmp.Compare(a.Q, b.Q)) }
+	slices.SortFunc(got, byPair)
+	if len(want) == 0 || !slices.Equal(got, want) {
+		t.Errorf("%d résultats, attendu %d", len(got), len(want))
+	}
+
+	// La limite de la forme ne s'applique pas; un n négatif est un débordement.
+	if _, err := NewOptions(WithBounds(0, MaxLimit+1), WithTransform(transform)); err != nil {
+		t.Errorf("limite hors forme avec transformation: %v", err)
+	}
+	o.Transform = func(p, q int64) (int64, bool) { return -p, true }
+	if err := Search(context.Background(), o, func(Result) error { return nil }); !errors.Is(err, ErrOverflow) {
+		t.Errorf("n négatif: %v, attendu ErrOverflow", err)
+	}
+}
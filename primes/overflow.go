@@ -0,0 +1,68 @@
+/*
+ * Fichier: overflow.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Politique de débordement: au-delà de la limite d'une forme, certains
+ * candidats n dépassent int64. Par défaut la recherche est refusée (ou
+ * échoue avec ErrOverflow); elle peut aussi ignorer ces paires en les
+ * comptant, tester leur candidat exact (sur 64 bits non signés jusqu'à 2^64,
+ * sur math/big au-delà: primalité probable, Baillie-PSW, voir IsPrimeBig),
+ * ou repousser la limite jusqu'à 2^64 en testant exactement chaque candidat
+ * sur 64 bits non signés (OverflowUint64).
+ */
+package primes
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// OverflowPolicy décide du sort des paires dont le candidat dépasse int64.
+type OverflowPolicy int
+
+// Politiques de débordement.
+const (
+	OverflowError   OverflowPolicy = iota // Limite refusée d'emblée, ErrOverflow sinon ("error").
+	OverflowSkip                          // Paire ignorée, comptée dans Progress.Overflowed ("skip").
+	OverflowPromote                       // Candidat testé exactement (uint64, sinon math/big), résultat dans Result.Big ("promote-big").
+	OverflowUint64                        // Limite vérifiée jusqu'à 2^64, candidat testé sur uint64, résultat dans Result.Big ("uint64").
+)
+
+// overflowPolicyNames sont les noms des politiques, dans l'ordre des constantes.
+var overflowPolicyNames = []string{"error", "skip", "promote-big", "uint64"}
+
+// String retourne le nom de la politique, tel qu'accepté par LookupOverflowPolicy.
+func (p OverflowPolicy) String() string {
+	if p < 0 || int(p) >= len(overflowPolicyNames) {
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+	return overflowPolicyNames[p]
+}
+
+// OverflowPolicyNames retourne les noms des politiques de débordement.
+func OverflowPolicyNames() []string { return overflowPolicyNames }
+
+// LookupOverflowPolicy retourne la politique de nom name.
+func LookupOverflowPolicy(name string) (OverflowPolicy, bool) {
+	for i, n := range overflowPolicyNames {
+		if n == name {
+			return OverflowPolicy(i), true
+		}
+	}
+	return OverflowError, false
+}
+
+// BigForm est implémentée par les formes capables d'évaluer exactement leurs candidats au-delà
+// d'int64; les politiques OverflowSkip et OverflowPromote l'exigent.
+type BigForm interface {
+	Form
+	EvalBig(p, q int64) *big.Int
+}
+
+// hasTwinBig indique si n-2 ou n+2 est premier selon isPrime.
+func hasTwinBig(n *big.Int, isPrime func(*big.Int) bool) bool {
+	two := big.NewInt(2)
+	return isPrime(new(big.Int).Sub(n, two)) || isPrime(new(big.Int).Add(n, two))
+}
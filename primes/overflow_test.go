@@ -0,0 +1,113 @@
+/*
+ * Fichier: overflow_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des politiques de débordement et de l'évaluation exacte des formes.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestEvalBig vérifie que l'évaluation exacte des formes prédéfinies coïncide avec Eval.
+func TestEvalBig(t *testing.T) {
+	for _, f := range []Form{FormP2Plus4Q2, FormP2PlusQ4, FormX2Plus1} {
+		for _, p := range []int64{2, 3, 97, 55108} {
+			for _, q := range []int64{2, 5, 1009} {
+				if got := f.(BigForm).EvalBig(p, q); !got.IsInt64() || got.Int64() != f.Eval(p, q) {
+					t.Errorf("%s.EvalBig(%d, %d) = %v, attendu %d", f.Name(), p, q, got, f.Eval(p, q))
+				}
+			}
+		}
+	}
+}
+
+// TestOverflowPolicy compare les politiques skip et promote-big à une énumération exacte.
+func TestOverflowPolicy(t *testing.T) {
+	// q >= 1.6e9 fait déborder 4q^2; p >= 1.6e9 avec q petit reste dans int64.
+	primeList := []int{3, 5, 7, 11, 13}
+	for x := int64(1_600_000_000); len(primeList) < 8; x++ {
+		if IsPrime(x) {
+			primeList = append(primeList, int(x))
+		}
+	}
+	if _, err := NewOptions(WithPrimes(primeList)); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("politique error: %v, attendu ErrOverflow", err)
+	}
+
+	var fits, promoted int
+	var overflowed int64
+	for _, p := range primeList {
+		for _, q := range primeList {
+			exact := FormP2Plus4Q2.(BigForm).EvalBig(int64(p), int64(q))
+			switch {
+			case !exact.IsInt64():
+				overflowed++
+				if IsPrimeBig(exact) {
+					promoted++
+				}
+			case IsPrime(exact.Int64()):
+				fits++
+			}
+		}
+	}
+	if overflowed == 0 || promoted == 0 {
+		t.Fatalf("jeu de test sans débordement (%d) ou sans candidat promu (%d)", overflowed, promoted)
+	}
+
+	run := func(policy OverflowPolicy) ([]Result, Progress) {
+		t.Helper()
+		var last Progress
+		o, err := NewOptions(WithPrimes(primeList), WithWorkers(2), WithOverflowPolicy(policy), WithProgress(func(pr Progress) { last = pr }))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []Result
+		if err := Search(context.Background(), o, func(r Result) error { got = append(got, r); return nil }); err != nil {
+			t.Fatal(err)
+		}
+		return got, last
+	}
+
+	got, pr := run(OverflowSkip)
+	if len(got) != fits || pr.Overflowed != overflowed {
+		t.Errorf("skip: %d résultats, %d débordements, attendu %d et %d", len(got), pr.Overflowed, fits, overflowed)
+	}
+	got, pr = run(OverflowPromote)
+	bigs := 0
+	for _, r := range got {
+		if r.Big == nil {
+			continue
+		}
+		bigs++
+		if r.N != math.MaxInt64 || r.Big.Cmp(new(big.Int).SetInt64(math.MaxInt64)) <= 0 {
+			t.Errorf("résultat promu (p=%d, q=%d): N=%d, Big=%v", r.P, r.Q, r.N, r.Big)
+		}
+	}
+	if len(got) != fits+promoted || bigs != promoted || pr.Overflowed != overflowed {
+		t.Errorf("promote-big: %d résultats dont %d promus, %d débordements, attendu %d, %d et %d", len(got), bigs, pr.Overflowed, fits+promoted, promoted, overflowed)
+	}
+
+	filter, _ := LookupFilter(FilterNames()[0])
+	for name, opts := range map[string][]Option{
+		"filtre":         {WithFilter(filter)},
+		"transformation": {WithTransform(func(p, q int64) (int64, bool) { return p + q, true })},
+	} {
+		opts = append(opts, WithBounds(0, 100), WithOverflowPolicy(OverflowPromote))
+		if _, err := NewOptions(opts...); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("promote-big avec %s: %v, attendu ErrInvalidOptions", name, err)
+		}
+	}
+	for _, name := range OverflowPolicyNames() {
+		if p, ok := LookupOverflowPolicy(name); !ok || p.String() != name {
+			t.Errorf("LookupOverflowPolicy(%q) = %v, %v", name, p, ok)
+		}
+	}
+}
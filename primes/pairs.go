@@ -0,0 +1,95 @@
+/*
+ * Fichier: pairs.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Région de la grille (p, q) énumérée par la recherche: grille complète (par
+ * défaut), triangle (p < q ou p <= q), grille sans la diagonale (p != q) ou
+ * diagonale seule (p = q). Les études symétriques n'ont besoin que d'un
+ * triangle, soit environ deux fois moins de paires à tester.
+ */
+package primes
+
+import "fmt"
+
+// PairMode sélectionne les paires (p, q) énumérées.
+type PairMode int
+
+// Modes d'énumération des paires.
+const (
+	PairsAll       PairMode = iota // Grille complète ("all").
+	PairsLess                      // p < q ("lt").
+	PairsLessEqual                 // p <= q ("le").
+	PairsDistinct                  // p != q ("ne").
+	PairsEqual                     // p = q ("eq").
+)
+
+// pairModeNames sont les noms des modes, dans l'ordre des constantes.
+var pairModeNames = []string{"all", "lt", "le", "ne", "eq"}
+
+// String retourne le nom du mode, tel qu'accepté par LookupPairMode.
+func (m PairMode) String() string {
+	if m < 0 || int(m) >= len(pairModeNames) {
+		return fmt.Sprintf("PairMode(%d)", int(m))
+	}
+	return pairModeNames[m]
+}
+
+// PairModeNames retourne les noms des modes d'énumération.
+func PairModeNames() []string { return pairModeNames }
+
+// LookupPairMode retourne le mode de nom name.
+func LookupPairMode(name string) (PairMode, bool) {
+	for i, n := range pairModeNames {
+		if n == name {
+			return PairMode(i), true
+		}
+	}
+	return PairsAll, false
+}
+
+// Contains indique si la paire (p, q) appartient à la région.
+func (m PairMode) Contains(p, q int) bool {
+	switch m {
+	case PairsLess:
+		return p < q
+	case PairsLessEqual:
+		return p <= q
+	case PairsDistinct:
+		return p != q
+	case PairsEqual:
+		return p == q
+	}
+	return true
+}
+
+// Count retourne le nombre de paires de la région formées à partir de n nombres premiers distincts.
+func (m PairMode) Count(n int) int64 {
+	all := int64(n) * int64(n)
+	switch m {
+	case PairsLess:
+		return (all - int64(n)) / 2
+	case PairsLessEqual:
+		return (all + int64(n)) / 2
+	case PairsDistinct:
+		return all - int64(n)
+	case PairsEqual:
+		return int64(n)
+	}
+	return all
+}
+
+// qRange retourne l'intervalle [lo, hi[ des indices de q à parcourir pour le nombre premier d'indice i
+// d'une liste croissante de n nombres premiers; pour PairsDistinct, q = p reste à écarter (Contains).
+func (m PairMode) qRange(i, n int) (lo, hi int) {
+	switch m {
+	case PairsLess:
+		return i + 1, n
+	case PairsLessEqual:
+		return i, n
+	case PairsEqual:
+		return i, i + 1
+	}
+	return 0, n
+}
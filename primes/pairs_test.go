@@ -0,0 +1,77 @@
+/*
+ * Fichier: pairs_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des modes d'énumération des paires.
+ */
+package primes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestPairModes vérifie, pour chaque mode, que la recherche retourne exactement les résultats de la
+// grille complète situés dans la région, et que Count et la progression comptent les paires testées.
+func TestPairModes(t *testing.T) {
+	primeList := SieveOfEratosthenes(200)
+	var all []Result
+	Search(context.Background(), Options{Primes: primeList, Workers: 2}, func(r Result) error {
+		all = append(all, r)
+		return nil
+	})
+
+	for _, name := range PairModeNames() {
+		mode, ok := LookupPairMode(name)
+		if !ok || mode.String() != name {
+			t.Fatalf("LookupPairMode(%q) = %v, %v", name, mode, ok)
+		}
+		var expected, got []Result
+		for _, r := range all {
+			if mode.Contains(r.P, r.Q) {
+				expected = append(expected, r)
+			}
+		}
+		var tested int64
+		err := Search(context.Background(), Options{Primes: primeList, Workers: 3, BatchSize: 7, Pairs: mode,
+			OnProgress: func(pr Progress) { tested = pr.Tested }}, func(r Result) error {
+			got = append(got, r)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sortResults := func(rs []Result) {
+			slices.SortFunc(rs, func(a, b Result) int { return cmp.Or(cmp.Compare(a.N, b.N), cmp.Compare(a.P, b.P)) })
+		}
+		sortResults(expected)
+		sortResults(got)
+		if !slices.Equal(got, expected) {
+			t.Errorf("mode %s: %d résultats, attendu %d", name, len(got), len(expected))
+		}
+
+		var count int64
+		for _, p := range primeList {
+			for _, q := range primeList {
+				if mode.Contains(p, q) {
+					count++
+				}
+			}
+		}
+		if mode.Count(len(primeList)) != count || tested != count {
+			t.Errorf("mode %s: Count = %d, paires testées %d, attendu %d", name, mode.Count(len(primeList)), tested, count)
+		}
+	}
+
+	if _, ok := LookupPairMode("p<q"); ok {
+		t.Error(`LookupPairMode("p<q") accepté`)
+	}
+	if _, err := NewOptions(WithPairs(PairMode(9))); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("mode 9: erreur %v, attendu ErrInvalidOptions", err)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Fichier: partition_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Preuve exhaustive, sur de petites grilles, du partage du travail: pour
+ * chaque région de paires, chaque paire (p, q) est soumise exactement une
+ * fois aux workers, quels que soient la taille des lots et le nombre de
+ * workers, le découpage en parts, en tranches de p ou en une recherche
+ * interrompue puis reprise. Une erreur de partage sauterait ou doublerait
+ * silencieusement une région de l'espace de recherche.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pairCounter compte les paires soumises aux workers, par une transformation appelée pour
+// chacune (les candidats sont écartés sans test).
+type pairCounter struct {
+	mu     sync.Mutex
+	seen   map[Job]int
+	calls  int
+	onCall func(calls int) // Appelée après chaque paire, verrou tenu.
+}
+
+func newPairCounter() *pairCounter { return &pairCounter{seen: map[Job]int{}} }
+
+// transform implémente TransformFunc.
+func (c *pairCounter) transform(p, q int64) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seen[Job{P: int(p), Q: int(q)}]++
+	c.calls++
+	if c.onCall != nil {
+		c.onCall(c.calls)
+	}
+	return 0, false
+}
+
+// checkExactlyOnce vérifie que seen contient chaque paire de want exactement une fois, et aucune autre.
+func checkExactlyOnce(t *testing.T, label string, seen map[Job]int, want []Job) {
+	t.Helper()
+	for _, job := range want {
+		if n := seen[job]; n != 1 {
+			t.Errorf("%s: paire %v soumise %d fois", label, job, n)
+		}
+	}
+	if len(seen) != len(want) {
+		t.Errorf("%s: %d paires distinctes soumises, attendu %d", label, len(seen), len(want))
+	}
+}
+
+// partitionGrid retourne les nombres premiers des tests et les paires de la région mode.
+func partitionGrid(mode PairMode) ([]int, []Job) {
+	primeList := SieveOfEratosthenes(60)
+	var want []Job
+	for _, p := range primeList {
+		for _, q := range primeList {
+			if mode.Contains(p, q) {
+				want = append(want, Job{P: p, Q: q})
+			}
+		}
+	}
+	return primeList, want
+}
+
+// TestPartitionBatches vérifie la couverture exacte de chaque région par une recherche, pour des
+// lots d'une paire, de taille sans rapport avec la grille, plus grands qu'elle ou adaptatifs, et
+// plusieurs workers.
+func TestPartitionBatches(t *testing.T) {
+	for _, name := range PairModeNames() {
+		mode, _ := LookupPairMode(name)
+		primeList, want := partitionGrid(mode)
+		for _, batchSize := range []int{1, 5, 64, 1000} {
+			for _, workers := range []int{1, 3} {
+				c := newPairCounter()
+				opts := Options{Primes: primeList, Pairs: mode, Workers: workers, BatchSize: batchSize, JobsBuffer: 1, Transform: c.transform}
+				if err := Search(context.Background(), opts, func(Result) error { return nil }); err != nil {
+					t.Fatalf("%s lots=%d workers=%d: %v", name, batchSize, workers, err)
+				}
+				checkExactlyOnce(t, name, c.seen, want)
+			}
+		}
+
+		// Lots adaptatifs: la taille change d'un lot à l'autre pendant la distribution.
+		c := newPairCounter()
+		opts := Options{Primes: primeList, Pairs: mode, Workers: 3, BatchSize: 1, BatchTarget: 50 * time.Microsecond, Transform: c.transform}
+		if err := Search(context.Background(), opts, func(Result) error { return nil }); err != nil {
+			t.Fatalf("%s lots adaptatifs: %v", name, err)
+		}
+		checkExactlyOnce(t, name+" lots adaptatifs", c.seen, want)
+	}
+}
+
+// TestPartitionShards vérifie que des recherches sur les parts d'une grille, et sur des tranches
+// de p adjacentes, couvrent ensemble chaque paire exactement une fois.
+func TestPartitionShards(t *testing.T) {
+	for _, name := range PairModeNames() {
+		mode, _ := LookupPairMode(name)
+		primeList, want := partitionGrid(mode)
+		for _, shards := range []int{2, 5, len(want) + 3} {
+			c := newPairCounter()
+			for shard := range shards {
+				src := NewShardSource(NewGridSource(primeList, mode, 0, 0), shard, shards)
+				opts := Options{Jobs: src, Workers: 2, BatchSize: 3, Transform: c.transform}
+				if err := Search(context.Background(), opts, func(Result) error { return nil }); err != nil {
+					t.Fatalf("%s part %d/%d: %v", name, shard, shards, err)
+				}
+			}
+			checkExactlyOnce(t, name, c.seen, want)
+		}
+
+		// Tranches [0, 13], [14, 29] et [30, fin]: les bornes de p sont incluses.
+		c := newPairCounter()
+		for _, bounds := range [][2]int{{0, 13}, {14, 29}, {30, 0}} {
+			opts := Options{Primes: primeList, Pairs: mode, PMin: bounds[0], PMax: bounds[1], Workers: 2, Transform: c.transform}
+			if err := Search(context.Background(), opts, func(Result) error { return nil }); err != nil {
+				t.Fatalf("%s tranche %v: %v", name, bounds, err)
+			}
+		}
+		checkExactlyOnce(t, name+" tranches", c.seen, want)
+	}
+}
+
+// TestPartitionResume interrompt une recherche après un nombre variable de paires, vérifie que les
+// paires soumises sont exactement le préfixe décrit par la PartialError, puis que la reprise après
+// ce préfixe complète la grille sans doublon.
+func TestPartitionResume(t *testing.T) {
+	for _, name := range PairModeNames() {
+		mode, _ := LookupPairMode(name)
+		primeList, want := partitionGrid(mode)
+		for _, stopAfter := range []int{1, 4, 37, 150} {
+			if stopAfter >= len(want) {
+				continue
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			c := newPairCounter()
+			c.onCall = func(calls int) {
+				if calls == stopAfter {
+					cancel()
+				}
+			}
+			opts := Options{Primes: primeList, Pairs: mode, Workers: 2, BatchSize: 4, JobsBuffer: 1, Transform: c.transform}
+			err := Search(ctx, opts, func(Result) error { return nil })
+			cancel()
+			var partial *PartialError
+			if !errors.As(err, &partial) {
+				t.Fatalf("%s arrêt après %d: Search() = %v, attendu une PartialError", name, stopAfter, err)
+			}
+			checkExactlyOnce(t, name+" préfixe", c.seen, want[:partial.Completed])
+
+			c.onCall = nil
+			resumed := NewResumeSource(NewGridSource(primeList, mode, 0, 0), partial.Completed)
+			if err := Search(context.Background(), Options{Jobs: resumed, Workers: 2, BatchSize: 3, Transform: c.transform}, func(Result) error { return nil }); err != nil {
+				t.Fatalf("%s reprise après %d paires: %v", name, partial.Completed, err)
+			}
+			checkExactlyOnce(t, name+" reprise", c.seen, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Fichier: primality.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 20 juin 2025
+ *
+ * Description:
+ * Tests de primalité utilisés pour vérifier les nombres n = p^2 + 4q^2:
+ * division successive et Miller-Rabin déterministe sur int64.
+ */
+package primes
+
+import (
+	"math"
+	"math/big"
+)
+
+// IsPrimeTrialDivision vérifie si un grand nombre est premier par division successive.
+// Nécessaire pour les résultats 'n' qui peuvent dépasser la limite du crible.
+// Utilise int64 pour la robustesse.
+func IsPrimeTrialDivision(n int64) bool {
+	if n <= 1 {
+		return false
+	}
+	if n <= 3 {
+		return true
+	}
+	if n%2 == 0 || n%3 == 0 {
+		return false
+	}
+	// On vérifie les diviseurs de la forme 6k ± 1 jusqu'à sqrt(n).
+	limit := int64(math.Sqrt(float64(n)))
+	for i := int64(5); i <= limit; i = i + 6 {
+		if n%i == 0 || n%(i+2) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// power64 calcule (base^exp) % mod de manière sûre avec math/big pour éviter les débordements.
+func power64(base, exp, mod int64) int64 {
+	bBase := big.NewInt(base)
+	bExp := big.NewInt(exp)
+	bMod := big.NewInt(mod)
+
+	// Le paquet math/big gère les grands nombres de manière sûre.
+	res := new(big.Int)
+	res.Exp(bBase, bExp, bMod)
+
+	return res.Int64()
+}
+
+// IsPrimeMillerRabin64 implémente le test de primalité de Miller-Rabin.
+// Cette version est déterministe pour tous les nombres de type int64.
+// Elle utilise un ensemble de bases prédéfinies qui garantissent l'exactitude.
+func IsPrimeMillerRabin64(n int64) bool {
+	return millerRabin64(n, millerRabinBases)
+}
+
+// millerRabin64 exécute le test de Miller-Rabin de n pour les bases données (les bases >= n-1
+// sont ignorées); n est composé si l'une d'elles est un témoin.
+func millerRabin64(n int64, bases []int64) bool {
+	if n < 2 {
+		return false
+	}
+	if n == 2 || n == 3 {
+		return true
+	}
+	if n%2 == 0 {
+		return false
+	}
+
+	// Écrire n-1 comme 2^s * d
+	d := n - 1
+	s := 0
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+
+	for _, a := range bases {
+		if a >= n-1 {
+			break
+		}
+		x := power64(a, d, n)
+
+		if x == 1 || x == n-1 {
+			continue
+		}
+
+		isWitness := true
+		for r := 1; r < s; r++ {
+			x = power64(x, 2, n)
+			if x == n-1 {
+				isWitness = false
+				break
+			}
+		}
+
+		if isWitness {
+			return false // n est composé.
+		}
+	}
+
+	return true // n est probablement (ici, certainement) premier.
+}
+
+// millerRabinBases sont les bases qui rendent le test de Miller-Rabin déterministe pour n < 2^64
+// (voir aussi IsPrimeUint64).
+var millerRabinBases = []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// PrimalityTest retourne la fonction de test correspondant au nom d'algorithme:
+// "miller" pour Miller-Rabin, "auto" pour IsPrime (choix selon la taille),
+// "adaptive" pour Miller-Rabin avec les bases choisies selon n (MillerRabinPolicy),
+// "lucas" pour le test de Lucas fort seul (probabiliste), "bpsw" pour Baillie-PSW,
+// "aks" pour AKS (exact mais très lent, à but pédagogique),
+// toute autre valeur pour la division successive ("trial").
+func PrimalityTest(name string) func(int64) bool {
+	switch name {
+	case "miller":
+		return IsPrimeMillerRabin64
+	case "auto":
+		return IsPrime
+	case "adaptive":
+		return MillerRabinPolicy{}.IsPrime
+	case "lucas":
+		return IsStrongLucasProbablePrime
+	case "bpsw":
+		return IsPrimeBPSW
+	case "aks":
+		return IsPrimeAKS
+	}
+	return IsPrimeTrialDivision
+}
@@ -1,47 +1,17 @@
 /*
- * Fichier: main_test.go
+ * Fichier: primality_test.go
  * Auteur: [Votre Nom/Organisation]
  * Date: 20 juin 2025
  *
  * Description:
- * Ce fichier contient les tests unitaires pour le programme de vérification
- * du théorème sur les nombres premiers. Il valide le crible d'Eratosthène
- * et les différentes fonctions de test de primalité.
+ * Tests unitaires des fonctions de test de primalité et de l'exponentiation modulaire.
  */
-package main
+package primes
 
-import (
-	"reflect"
-	"testing"
-)
+import "testing"
 
-// TestSieveOfEratosthenes valide la génération des nombres premiers.
-func TestSieveOfEratosthenes(t *testing.T) {
-	testCases := []struct {
-		name     string
-		limit    int
-		expected []int
-	}{
-		{"Limite de 30", 30, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
-		{"Limite de 10", 10, []int{2, 3, 5, 7}},
-		{"Limite de 2", 2, []int{2}},
-		{"Limite de 1", 1, nil},
-		{"Limite de 0", 0, nil},
-		{"Limite négative", -10, nil},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			result := sieveOfEratosthenes(tc.limit)
-			if !reflect.DeepEqual(result, tc.expected) {
-				t.Errorf("sieveOfEratosthenes(%d) = %v, attendu %v", tc.limit, result, tc.expected)
-			}
-		})
-	}
-}
-
-// TestIsNPrimeAccordingToGreenSawhneyContext valide le test de primalité par division utilisé dans le contexte de Green-Sawhney.
-func TestIsNPrimeAccordingToGreenSawhneyContext(t *testing.T) {
+// TestIsPrimeTrialDivision valide le test de primalité par division successive.
+func TestIsPrimeTrialDivision(t *testing.T) {
 	testCases := []struct {
 		name     string
 		n        int64
@@ -59,8 +29,8 @@ func TestIsNPrimeAccordingToGreenSawhneyContext(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			if result := isNPrimeAccordingToGreenSawhneyContext(tc.n); result != tc.expected {
-				t.Errorf("isNPrimeAccordingToGreenSawhneyContext(%d) = %v, attendu %v", tc.n, result, tc.expected)
+			if result := IsPrimeTrialDivision(tc.n); result != tc.expected {
+				t.Errorf("IsPrimeTrialDivision(%d) = %v, attendu %v", tc.n, result, tc.expected)
 			}
 		})
 	}
@@ -88,8 +58,8 @@ func TestIsPrimeMillerRabin64(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			if result := isPrimeMillerRabin64(tc.n); result != tc.expected {
-				t.Errorf("isPrimeMillerRabin64(%d) = %v, attendu %v", tc.n, result, tc.expected)
+			if result := IsPrimeMillerRabin64(tc.n); result != tc.expected {
+				t.Errorf("IsPrimeMillerRabin64(%d) = %v, attendu %v", tc.n, result, tc.expected)
 			}
 		})
 	}
@@ -0,0 +1,94 @@
+/*
+ * Fichier: primorial.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Primorielles (p#, produit des nombres premiers <= p) et factorielles sur
+ * math/big, et recherche des nombres premiers primoriels (p# ± 1) et
+ * factoriels (n! ± 1). Au-delà de 64 bits, la primalité est probable
+ * (Baillie-PSW, voir IsPrimeBig): aucun pseudo-premier n'est connu, mais ce
+ * n'est pas une preuve.
+ */
+package primes
+
+import (
+	"context"
+	"math/big"
+)
+
+// Primorial retourne n#, le produit des nombres premiers inférieurs ou égaux à n (1 si n < 2).
+func Primorial(n int) *big.Int {
+	result := big.NewInt(1)
+	if n < 2 {
+		return result
+	}
+	var f big.Int
+	for _, p := range SieveOfEratosthenes(n) {
+		result.Mul(result, f.SetInt64(int64(p)))
+	}
+	return result
+}
+
+// Factorial retourne n! (1 si n < 2).
+func Factorial(n int) *big.Int {
+	if n < 2 {
+		return big.NewInt(1)
+	}
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// SpecialPrime est un nombre premier de la forme base ± 1, base étant N# ou N!.
+type SpecialPrime struct {
+	N      int // Argument de la primorielle ou de la factorielle.
+	Sign   int // +1 ou -1.
+	Digits int // Nombre de chiffres décimaux.
+}
+
+// PrimorialPrimes retourne les nombres premiers p# ± 1 pour p premier inférieur ou égal à
+// limit, par p croissant puis -1 avant +1. La recherche s'arrête à l'annulation de ctx, en
+// retournant les résultats déjà trouvés et ctx.Err().
+func PrimorialPrimes(ctx context.Context, limit int) ([]SpecialPrime, error) {
+	var found []SpecialPrime
+	base := big.NewInt(1)
+	var f big.Int
+	for _, p := range SieveOfEratosthenes(max(limit, 0)) {
+		base.Mul(base, f.SetInt64(int64(p)))
+		var err error
+		if found, err = appendPlusMinusOne(ctx, found, base, p); err != nil {
+			return found, err
+		}
+	}
+	return found, nil
+}
+
+// FactorialPrimes retourne les nombres premiers n! ± 1 pour 1 <= n <= limit, par n croissant
+// puis -1 avant +1. La recherche s'arrête à l'annulation de ctx, comme PrimorialPrimes.
+func FactorialPrimes(ctx context.Context, limit int) ([]SpecialPrime, error) {
+	var found []SpecialPrime
+	base := big.NewInt(1)
+	var f big.Int
+	for n := 1; n <= limit; n++ {
+		base.Mul(base, f.SetInt64(int64(n)))
+		var err error
+		if found, err = appendPlusMinusOne(ctx, found, base, n); err != nil {
+			return found, err
+		}
+	}
+	return found, nil
+}
+
+// appendPlusMinusOne ajoute à found base - 1 et base + 1 s'ils sont premiers.
+func appendPlusMinusOne(ctx context.Context, found []SpecialPrime, base *big.Int, n int) ([]SpecialPrime, error) {
+	var candidate big.Int
+	for _, sign := range []int{-1, 1} {
+		if err := ctx.Err(); err != nil {
+			return found, err
+		}
+		candidate.Add(base, big.NewInt(int64(sign)))
+		if IsPrimeBig(&candidate) {
+			found = append(found, SpecialPrime{N: n, Sign: sign, Digits: len(candidate.String())})
+		}
+	}
+	return found, nil
+}
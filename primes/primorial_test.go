@@ -0,0 +1,74 @@
+/*
+ * Fichier: primorial_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des primorielles, factorielles et nombres premiers primoriels et
+ * factoriels, comparés aux suites de l'OEIS.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestPrimorialFactorial valide quelques valeurs de p# et n!.
+func TestPrimorialFactorial(t *testing.T) {
+	for _, tc := range []struct{ n, primorial, factorial int64 }{{0, 1, 1}, {1, 1, 1}, {2, 2, 2}, {10, 210, 3628800}, {13, 30030, 6227020800}} {
+		if got := Primorial(int(tc.n)); got.Int64() != tc.primorial {
+			t.Errorf("Primorial(%d) = %v, attendu %d", tc.n, got, tc.primorial)
+		}
+		if got := Factorial(int(tc.n)); got.Int64() != tc.factorial {
+			t.Errorf("Factorial(%d) = %v, attendu %d", tc.n, got, tc.factorial)
+		}
+	}
+}
+
+// splitSigns sépare les arguments des nombres premiers trouvés selon leur signe.
+func splitSigns(found []SpecialPrime) (minus, plus []int) {
+	for _, s := range found {
+		if s.Sign < 0 {
+			minus = append(minus, s.N)
+		} else {
+			plus = append(plus, s.N)
+		}
+	}
+	return minus, plus
+}
+
+// TestPrimorialPrimes compare aux suites OEIS A057704 (p# - 1) et A014545 (p# + 1).
+func TestPrimorialPrimes(t *testing.T) {
+	found, err := PrimorialPrimes(context.Background(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	minus, plus := splitSigns(found)
+	if !slices.Equal(minus, []int{3, 5, 11, 13, 41, 89}) || !slices.Equal(plus, []int{2, 3, 5, 7, 11, 31}) {
+		t.Errorf("p# - 1: %v, p# + 1: %v", minus, plus)
+	}
+	if found[0] != (SpecialPrime{N: 2, Sign: 1, Digits: 1}) {
+		t.Errorf("premier résultat = %+v, attendu 2# + 1 = 3", found[0])
+	}
+}
+
+// TestFactorialPrimes compare aux suites OEIS A002982 (n! - 1) et A002981 (n! + 1).
+func TestFactorialPrimes(t *testing.T) {
+	found, err := FactorialPrimes(context.Background(), 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	minus, plus := splitSigns(found)
+	if !slices.Equal(minus, []int{3, 4, 6, 7, 12, 14, 30, 32, 33, 38}) || !slices.Equal(plus, []int{1, 2, 3, 11, 27, 37, 41}) {
+		t.Errorf("n! - 1: %v, n! + 1: %v", minus, plus)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FactorialPrimes(ctx, 60); !errors.Is(err, context.Canceled) {
+		t.Errorf("FactorialPrimes(annulé) = %v, attendu context.Canceled", err)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Fichier: progression.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Progressions arithmétiques dans un ensemble de valeurs, par exemple les
+ * nombres premiers trouvés par la recherche. D'après le théorème de
+ * Green-Tao, les nombres premiers contiennent des progressions arithmétiques
+ * arbitrairement longues; la question se pose pour les sous-ensembles
+ * « spéciaux » comme les n = p^2 + 4q^2. Chaque paire (a, b) de l'ensemble
+ * qui ne prolonge pas une progression (a - d absent, d = b - a) commence une
+ * progression maximale, prolongée terme à terme: O(k·m^2) pour m valeurs,
+ * avec une mémoire linéaire.
+ */
+package primes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// maxLongestProgressions borne le nombre de plus longues progressions conservées en exemple.
+const maxLongestProgressions = 20
+
+// Progression est une progression arithmétique Start, Start+Diff, ..., de Len termes.
+type Progression struct {
+	Start, Diff int64
+	Len         int
+}
+
+// Terms retourne les termes de la progression.
+func (p Progression) Terms() []int64 {
+	terms := make([]int64, p.Len)
+	for i := range terms {
+		terms[i] = p.Start + int64(i)*p.Diff
+	}
+	return terms
+}
+
+// ProgressionReport résume les progressions arithmétiques maximales d'un ensemble de valeurs.
+type ProgressionReport struct {
+	Values  int           // Valeurs distinctes examinées.
+	MinLen  int           // Longueur minimale k des progressions comptées.
+	Counts  []int64       // Counts[m]: progressions maximales de m termes (m >= MinLen).
+	MaxLen  int           // Longueur de la plus longue progression (0: aucune de MinLen termes).
+	Longest []Progression // Plus longues progressions, par Start puis Diff croissants (au plus maxLongestProgressions).
+}
+
+// Total retourne le nombre de progressions maximales d'au moins MinLen termes.
+func (r ProgressionReport) Total() int64 {
+	var total int64
+	for _, n := range r.Counts {
+		total += n
+	}
+	return total
+}
+
+// ArithmeticProgressions cherche les progressions arithmétiques maximales d'au moins k termes
+// (k >= 3) parmi values (ordre et doublons indifférents), et retient les plus longues. Une
+// progression est maximale si elle ne se prolonge ni avant son premier terme ni après son dernier.
+// L'annulation de ctx, vérifiée à chaque premier terme, arrête la recherche avec ctx.Err().
+func ArithmeticProgressions(ctx context.Context, values []int64, k int) (ProgressionReport, error) {
+	if k < 3 {
+		return ProgressionReport{}, fmt.Errorf("%w: progressions de %d termes (attendu >= 3)", ErrInvalidOptions, k)
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	set := make(map[int64]struct{}, len(sorted))
+	for _, v := range sorted {
+		set[v] = struct{}{}
+	}
+	has := func(v int64) bool {
+		_, ok := set[v]
+		return ok
+	}
+
+	report := ProgressionReport{Values: len(sorted), MinLen: k, Counts: make([]int64, k)}
+	last := int64(0)
+	if len(sorted) > 0 {
+		last = sorted[len(sorted)-1]
+	}
+	for i, a := range sorted {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		for _, b := range sorted[i+1:] {
+			d := b - a
+			// Le dernier terme possible borne d: a + (k-1)d <= last.
+			if d > (last-a)/int64(k-1) {
+				break
+			}
+			if has(a - d) {
+				continue // (a, b) prolonge une progression commencée plus tôt.
+			}
+			length := 2
+			for t := b; t <= last-d && has(t+d); t += d { // t+d <= last: pas de débordement.
+				length++
+			}
+			if length < k {
+				continue
+			}
+			if length >= len(report.Counts) {
+				report.Counts = append(report.Counts, make([]int64, length+1-len(report.Counts))...)
+			}
+			report.Counts[length]++
+			if length > report.MaxLen {
+				report.MaxLen, report.Longest = length, report.Longest[:0]
+			}
+			if length == report.MaxLen && len(report.Longest) < maxLongestProgressions {
+				report.Longest = append(report.Longest, Progression{Start: a, Diff: d, Len: length})
+			}
+		}
+	}
+	return report, nil
+}
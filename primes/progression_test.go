@@ -0,0 +1,84 @@
+/*
+ * Fichier: progression_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la recherche de progressions arithmétiques.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestArithmeticProgressions vérifie les progressions maximales d'ensembles connus: nombres
+// premiers (3, 5, 7 et 5, 11, 17, 23, 29), progression construite et doublons.
+func TestArithmeticProgressions(t *testing.T) {
+	primes := make([]int64, 0)
+	for _, p := range SieveOfEratosthenes(30) {
+		primes = append(primes, int64(p))
+	}
+	report, err := ArithmeticProgressions(context.Background(), primes, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Values != 10 || report.MaxLen != 5 || len(report.Longest) != 1 || report.Longest[0] != (Progression{Start: 5, Diff: 6, Len: 5}) {
+		t.Errorf("premiers <= 30: %+v, attendu la progression 5, 11, 17, 23, 29", report)
+	}
+	// Référence par force brute sur les premiers <= 200: chaque (début, raison) non prolongeable
+	// vers la gauche est une progression maximale.
+	var list []int64
+	set := map[int64]bool{}
+	for _, p := range SieveOfEratosthenes(200) {
+		list = append(list, int64(p))
+		set[int64(p)] = true
+	}
+	want := map[int]int64{}
+	for _, a := range list {
+		for d := int64(1); a+2*d <= 200; d++ {
+			if set[a-d] {
+				continue
+			}
+			length := 1
+			for set[a+int64(length)*d] {
+				length++
+			}
+			if length >= 3 {
+				want[length]++
+			}
+		}
+	}
+	report, _ = ArithmeticProgressions(context.Background(), list, 3)
+	for length, n := range report.Counts {
+		if n != want[length] {
+			t.Errorf("premiers <= 200: %d progressions de %d termes, attendu %d", n, length, want[length])
+		}
+	}
+	if len(report.Counts) <= report.MaxLen || report.Counts[report.MaxLen] == 0 || want[report.MaxLen+1] != 0 {
+		t.Errorf("premiers <= 200: plus longue progression de %d termes, comptes %v", report.MaxLen, report.Counts)
+	}
+
+	values := []int64{100, 7, 1, 13, 19, 7, 25, 31, 4}
+	report, _ = ArithmeticProgressions(context.Background(), values, 4)
+	if report.MaxLen != 6 || report.Longest[0] != (Progression{Start: 1, Diff: 6, Len: 6}) || report.Total() != 1 {
+		t.Errorf("1, 7, ..., 31: %+v", report)
+	}
+	if terms := report.Longest[0].Terms(); len(terms) != 6 || terms[5] != 31 {
+		t.Errorf("Terms() = %v", terms)
+	}
+
+	if report, _ := ArithmeticProgressions(context.Background(), []int64{1, 2, 4, 8}, 3); report.MaxLen != 0 || report.Total() != 0 {
+		t.Errorf("1, 2, 4, 8: %+v, attendu aucune progression", report)
+	}
+	if _, err := ArithmeticProgressions(context.Background(), primes, 2); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("k = 2: erreur %v, attendu ErrInvalidOptions", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ArithmeticProgressions(ctx, primes, 3); !errors.Is(err, context.Canceled) {
+		t.Errorf("contexte annulé: erreur %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ * Fichier: pseudoprime.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de primalité probable en une base (Fermat, Euler-Jacobi, Miller fort)
+ * et recherche des pseudo-premiers: les nombres composés qui passent l'un de
+ * ces tests pour toutes les bases données. La vérité de référence vient du
+ * crible segmenté: seuls les entiers entre deux nombres premiers consécutifs
+ * sont soumis au test. Les listes obtenues (341, 561, 645... en base 2 pour
+ * Fermat) servent de corpus de régression aux tests probabilistes.
+ */
+package primes
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"github.com/agbru/PrimeNumber/primes/ntheory"
+)
+
+// pseudoprimeTests sont les tests acceptés par Pseudoprimes.
+var pseudoprimeTests = []string{"fermat", "euler", "strong"}
+
+// PseudoprimeTestNames retourne les noms des tests à une base: fermat, euler (Euler-Jacobi) et
+// strong (Miller fort).
+func PseudoprimeTestNames() []string { return slices.Clone(pseudoprimeTests) }
+
+// IsFermatProbablePrime indique si n > 1 passe le test de Fermat en base a: a^(n-1) ≡ 1 mod n.
+func IsFermatProbablePrime(n, a int64) bool {
+	return n > 1 && ntheory.PowMod(a, n-1, n) == 1%n
+}
+
+// IsEulerProbablePrime indique si n impair > 1 passe le test d'Euler-Jacobi en base a:
+// a^((n-1)/2) ≡ (a/n) mod n, avec (a/n) le symbole de Jacobi non nul.
+func IsEulerProbablePrime(n, a int64) bool {
+	if n == 2 {
+		return true
+	}
+	if n < 2 || n%2 == 0 {
+		return false
+	}
+	j, _ := ntheory.Jacobi(a, n)
+	return j != 0 && ntheory.PowMod(a, (n-1)/2, n) == ntheory.Mod(int64(j), n)
+}
+
+// IsStrongProbablePrime indique si n impair > 1 passe le test de Miller fort en base a: avec
+// n-1 = d·2^s, d impair, a^d ≡ 1 ou a^(d·2^r) ≡ -1 mod n pour un r < s.
+func IsStrongProbablePrime(n, a int64) bool {
+	if n == 2 {
+		return true
+	}
+	if n < 2 || n%2 == 0 {
+		return false
+	}
+	s := bits.TrailingZeros64(uint64(n - 1))
+	x := ntheory.PowMod(a, (n-1)>>s, n)
+	if x == 1 || x == n-1 {
+		return true
+	}
+	for range s - 1 {
+		if x = ntheory.MulMod(x, x, n); x == n-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// probablePrimeTest retourne le test à une base de nom name, nil s'il est inconnu.
+func probablePrimeTest(name string) func(n, a int64) bool {
+	switch name {
+	case "fermat":
+		return IsFermatProbablePrime
+	case "euler":
+		return IsEulerProbablePrime
+	case "strong":
+		return IsStrongProbablePrime
+	}
+	return nil
+}
+
+// Pseudoprimes appelle fn, dans l'ordre croissant, pour chaque nombre composé n <= limit qui passe
+// le test name (voir PseudoprimeTestNames) pour toutes les bases, jusqu'à ce que fn retourne false.
+// Les nombres premiers sont énumérés par crible segmenté: le test n'est appliqué qu'aux composés.
+// L'erreur enveloppe ErrInvalidOptions pour un test inconnu ou une base < 2; ctx.Err() est
+// retournée si ctx est annulé.
+func Pseudoprimes(ctx context.Context, limit int64, name string, bases []int64, fn func(n int64) bool) error {
+	test := probablePrimeTest(name)
+	switch {
+	case test == nil:
+		return fmt.Errorf("%w: test %q (attendu l'un de %v)", ErrInvalidOptions, name, pseudoprimeTests)
+	case len(bases) == 0 || slices.Min(bases) < 2:
+		return fmt.Errorf("%w: bases %v (attendu au moins une base >= 2)", ErrInvalidOptions, bases)
+	}
+	passes := func(n int64) bool {
+		for _, a := range bases {
+			if !test(n, a) {
+				return false
+			}
+		}
+		return true
+	}
+	// Les composés sont les entiers entre deux nombres premiers consécutifs, et après le dernier.
+	stopped := false
+	next := int64(4)
+	composites := func(upTo int64) bool {
+		for n := next; n < upTo; n++ {
+			if passes(n) && !fn(n) {
+				stopped = true
+				return false
+			}
+		}
+		return true
+	}
+	err := forEachPrimeUint64(ctx, 2, uint64(max(limit, 0)), func(p uint64) bool {
+		ok := composites(int64(p))
+		next = int64(p) + 1
+		return ok
+	})
+	if err != nil || stopped {
+		return err
+	}
+	composites(limit + 1)
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Fichier: pseudoprime_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des tests à une base et de la recherche des pseudo-premiers, comparés
+ * aux suites connues (OEIS A001567, A047713, A001262).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestPseudoprimes compare les pseudo-premiers en base 2 aux suites connues et vérifie les bases
+// multiples, l'arrêt anticipé et les erreurs.
+func TestPseudoprimes(t *testing.T) {
+	collect := func(limit int64, name string, bases ...int64) []int64 {
+		t.Helper()
+		var got []int64
+		if err := Pseudoprimes(context.Background(), limit, name, bases, func(n int64) bool {
+			got = append(got, n)
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	for _, tc := range []struct {
+		name  string
+		bases []int64
+		want  []int64
+	}{
+		{"fermat", []int64{2}, []int64{341, 561, 645, 1105, 1387, 1729, 1905, 2047, 2465, 2701, 2821, 3277, 4033, 4369, 4371, 4681}}, // A001567
+		{"euler", []int64{2}, []int64{561, 1105, 1729, 1905, 2047, 2465, 3277, 4033, 4681}},                                          // A047713
+		{"strong", []int64{2}, []int64{2047, 3277, 4033, 4681}},                                                                      // A001262
+		{"fermat", []int64{2, 3}, []int64{1105, 1729, 2465, 2701, 2821}},
+	} {
+		if got := collect(5000, tc.name, tc.bases...); !slices.Equal(got, tc.want) {
+			t.Errorf("Pseudoprimes(5000, %s, %v) = %v, attendu %v", tc.name, tc.bases, got, tc.want)
+		}
+	}
+	// Plus petit pseudo-premier fort pour les bases 2 et 3 (A014233).
+	if got := collect(1373653, "strong", 2, 3); !slices.Equal(got, []int64{1373653}) {
+		t.Errorf("Pseudoprimes(1373653, strong, [2 3]) = %v, attendu [1373653]", got)
+	}
+
+	var first []int64
+	if err := Pseudoprimes(context.Background(), 1e6, "fermat", []int64{2}, func(n int64) bool {
+		first = append(first, n)
+		return len(first) < 3
+	}); err != nil || !slices.Equal(first, []int64{341, 561, 645}) {
+		t.Errorf("arrêt après 3: %v, %v", first, err)
+	}
+	for _, bad := range []struct {
+		name  string
+		bases []int64
+	}{{"lucas", []int64{2}}, {"fermat", nil}, {"strong", []int64{1}}} {
+		if err := Pseudoprimes(context.Background(), 100, bad.name, bad.bases, func(int64) bool { return true }); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Pseudoprimes(%s, %v): erreur %v, attendu ErrInvalidOptions", bad.name, bad.bases, err)
+		}
+	}
+}
+
+// TestProbablePrimeTests vérifie que les nombres premiers passent les trois tests dans toutes les bases.
+func TestProbablePrimeTests(t *testing.T) {
+	for _, p := range SieveOfEratosthenes(2000) {
+		for a := int64(2); a < 20; a++ {
+			if a%int64(p) == 0 {
+				continue
+			}
+			n := int64(p)
+			if !IsFermatProbablePrime(n, a) || !IsEulerProbablePrime(n, a) || !IsStrongProbablePrime(n, a) {
+				t.Fatalf("le nombre premier %d échoue en base %d", n, a)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Fichier: reporter.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Suivi de l'avancement de la recherche (ProgressReporter): une interface
+ * unique, consommée par les interfaces de la CLI (ligne de statistiques,
+ * interface terminal, tableau de bord web) comme par les programmes qui
+ * embarquent le paquet, au lieu d'un branchement propre à chacune. Les étapes
+ * sont la fin du crible, l'avancement des lots testés, chaque résultat et la
+ * fin de la recherche, avec son bilan.
+ */
+package primes
+
+import "time"
+
+// SieveInfo décrit le crible qui précède la recherche.
+type SieveInfo struct {
+	Primes  int           // Nombres premiers à combiner.
+	Elapsed time.Duration // Durée du crible (ou de la sélection dans Options.Primes).
+}
+
+// Summary est le bilan d'une recherche terminée.
+type Summary struct {
+	Progress     // Dernier état d'avancement.
+	Results  int // Résultats transmis à l'appelant.
+	Elapsed  time.Duration
+	Err      error // Erreur qui a arrêté la recherche (nil si elle est complète ou arrêtée par Control).
+}
+
+// ProgressReporter suit l'avancement d'une recherche (voir WithReporter). Les méthodes sont
+// appelées depuis la goroutine qui collecte les résultats, jamais en concurrence entre elles, et
+// doivent rendre la main rapidement: la collecte attend leur retour.
+type ProgressReporter interface {
+	// OnSieveDone est appelé une fois le crible calculé, avant la distribution des paires (pas
+	// d'appel avec une source de paires fournie, Options.Jobs).
+	OnSieveDone(SieveInfo)
+	// OnBatchDone reçoit l'état cumulé des lots terminés (Progress.Tested paires testées), toutes
+	// les ProgressInterval puis une dernière fois à la fin.
+	OnBatchDone(Progress)
+	// OnResult reçoit chaque résultat, juste avant la fonction de l'appelant.
+	OnResult(Result)
+	// OnFinish reçoit le bilan, une fois les workers terminés.
+	OnFinish(Summary)
+}
+
+// NopReporter est un ProgressReporter qui ignore toutes les étapes: à inclure dans un type qui
+// n'en suit que certaines.
+type NopReporter struct{}
+
+func (NopReporter) OnSieveDone(SieveInfo) {}
+func (NopReporter) OnBatchDone(Progress)  {}
+func (NopReporter) OnResult(Result)       {}
+func (NopReporter) OnFinish(Summary)      {}
+
+// multiReporter transmet chaque étape à plusieurs suivis, dans l'ordre.
+type multiReporter []ProgressReporter
+
+// MultiReporter retourne un suivi qui transmet chaque étape à tous les suivis de reporters (nil
+// ignorés), dans l'ordre.
+func MultiReporter(reporters ...ProgressReporter) ProgressReporter {
+	var m multiReporter
+	for _, r := range reporters {
+		if r != nil {
+			m = append(m, r)
+		}
+	}
+	return m
+}
+
+func (m multiReporter) OnSieveDone(s SieveInfo) {
+	for _, r := range m {
+		r.OnSieveDone(s)
+	}
+}
+
+func (m multiReporter) OnBatchDone(p Progress) {
+	for _, r := range m {
+		r.OnBatchDone(p)
+	}
+}
+
+func (m multiReporter) OnResult(res Result) {
+	for _, r := range m {
+		r.OnResult(res)
+	}
+}
+
+func (m multiReporter) OnFinish(s Summary) {
+	for _, r := range m {
+		r.OnFinish(s)
+	}
+}
+
+// progressFuncReporter adapte une ProgressFunc (Options.OnProgress) à l'interface.
+type progressFuncReporter struct {
+	NopReporter
+	fn ProgressFunc
+}
+
+func (r progressFuncReporter) OnBatchDone(p Progress) { r.fn(p) }
+
+// reporter retourne le suivi des options: Reporter et OnProgress réunis (jamais nil).
+func (o Options) reporter() ProgressReporter {
+	var fn ProgressReporter
+	if o.OnProgress != nil {
+		fn = progressFuncReporter{fn: o.OnProgress}
+	}
+	return MultiReporter(o.Reporter, fn)
+}
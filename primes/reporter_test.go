@@ -0,0 +1,72 @@
+/*
+ * Fichier: reporter_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du suivi de l'avancement (ProgressReporter).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// recordingReporter retient les étapes reçues.
+type recordingReporter struct {
+	sieves   []SieveInfo
+	batches  []Progress
+	results  int
+	finishes []Summary
+}
+
+func (r *recordingReporter) OnSieveDone(s SieveInfo) { r.sieves = append(r.sieves, s) }
+func (r *recordingReporter) OnBatchDone(p Progress)  { r.batches = append(r.batches, p) }
+func (r *recordingReporter) OnResult(Result)         { r.results++ }
+func (r *recordingReporter) OnFinish(s Summary)      { r.finishes = append(r.finishes, s) }
+
+// TestReporter vérifie les étapes transmises par Search, SearchReverse et MultiReporter.
+func TestReporter(t *testing.T) {
+	for _, reverse := range []bool{false, true} {
+		rec, progress := &recordingReporter{}, 0
+		opts := Options{Limit: 100, Workers: 2, Reporter: MultiReporter(nil, rec, NopReporter{}),
+			OnProgress: func(Progress) { progress++ }}
+		searchFunc := Search
+		if reverse {
+			searchFunc = SearchReverse
+		}
+		found := 0
+		if err := searchFunc(context.Background(), opts, func(Result) error { found++; return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if len(rec.sieves) != 1 || rec.sieves[0].Primes != 25 {
+			t.Errorf("reverse=%v: OnSieveDone = %+v, attendu un appel pour 25 nombres premiers", reverse, rec.sieves)
+		}
+		if rec.results != found || found == 0 {
+			t.Errorf("reverse=%v: %d appels à OnResult pour %d résultats", reverse, rec.results, found)
+		}
+		if len(rec.batches) == 0 || len(rec.batches) != progress {
+			t.Fatalf("reverse=%v: %d appels à OnBatchDone, %d à OnProgress", reverse, len(rec.batches), progress)
+		}
+		last := rec.batches[len(rec.batches)-1]
+		if last.Tested != last.Total || last.Found != int64(found) {
+			t.Errorf("reverse=%v: dernier état %+v, attendu %d résultats et tout testé", reverse, last, found)
+		}
+		if len(rec.finishes) != 1 || rec.finishes[0].Results != found || rec.finishes[0].Err != nil || rec.finishes[0].Tested != last.Tested {
+			t.Errorf("reverse=%v: OnFinish = %+v", reverse, rec.finishes)
+		}
+	}
+
+	// Une erreur de l'appelant figure dans le bilan; une source de paires fournie n'a pas de crible.
+	rec := &recordingReporter{}
+	errStop := errors.New("arrêt")
+	opts := Options{Jobs: NewGridSource(SieveOfEratosthenes(50), PairsAll, 0, 0), Workers: 1, Reporter: rec}
+	if err := Search(context.Background(), opts, func(Result) error { return errStop }); !errors.Is(err, errStop) {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(rec.sieves) != 0 || len(rec.finishes) != 1 || !errors.Is(rec.finishes[0].Err, errStop) || rec.finishes[0].Results != 1 {
+		t.Errorf("OnSieveDone = %+v, OnFinish = %+v", rec.sieves, rec.finishes)
+	}
+}
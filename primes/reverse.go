@@ -0,0 +1,193 @@
+/*
+ * Fichier: reverse.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Recherche inverse pour la forme p^2 + 4q^2: au lieu de tester chaque paire
+ * (p, q), les nombres premiers n jusqu'à la plus grande valeur de la forme
+ * sont énumérés par crible segmenté, et l'algorithme de Cornacchia
+ * (ntheory.Cornacchia) retrouve l'unique représentation n = x^2 + 4y^2 de
+ * chaque n ≡ 1 mod 4; n est un résultat si x et y sont des nombres premiers
+ * de la recherche. La primalité de n vient du crible, celle de p et q de la
+ * liste des nombres premiers: aucun test de primalité n'est partagé avec la
+ * recherche directe, dont les résultats doivent être identiques. Le coût
+ * suit le nombre d'entiers criblés (5·L^2 pour la limite L) au lieu du nombre
+ * de paires (π(L)^2) multiplié par le coût d'un test.
+ */
+package primes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes/ntheory"
+	"golang.org/x/sync/errgroup"
+)
+
+// reverseBlockSize est le nombre d'entiers de n criblés par tâche d'un worker de la recherche inverse.
+const reverseBlockSize = 16 * segmentSize
+
+// reverseBlock est le résultat d'une tâche: les résultats d'un bloc de n, dans l'ordre croissant.
+type reverseBlock struct {
+	index   int64
+	results []Result
+}
+
+// SearchReverse trouve les mêmes résultats que Search pour la forme p^2 + 4q^2, par la recherche
+// inverse: énumération des nombres premiers n et décomposition n = p^2 + 4q^2 par Cornacchia.
+// Les résultats sont transmis à fn dans l'ordre croissant de n. Les bornes (Min, Limit, Primes,
+// PMin, PMax), Pairs, Filter, Twins, Timing, Workers et Control s'appliquent; le test de
+// primalité ne sert qu'au filtre et aux jumeaux. Dans la progression, Tested et Total comptent
+// les entiers n criblés et Workers reste vide. L'erreur enveloppe ErrInvalidOptions pour une autre
+// forme, une transformation, une politique de débordement autre que OverflowError, l'analyse
+// des composés (Explain) ou une source de paires (Jobs). Une erreur de fn, l'annulation de ctx et Control.Stop arrêtent la
+// recherche comme pour Search.
+func SearchReverse(ctx context.Context, opts Options, fn func(Result) error) error {
+	opts, err := opts.validate()
+	if err != nil {
+		return err
+	}
+	switch {
+	case opts.Form != FormP2Plus4Q2 || opts.Transform != nil:
+		return fmt.Errorf("%w: la recherche inverse ne traite que la forme %s", ErrInvalidOptions, FormP2Plus4Q2.Name())
+	case opts.OnOverflow != OverflowError || opts.Explain != nil:
+		return fmt.Errorf("%w: la recherche inverse exclut la politique %s et l'analyse des composés", ErrInvalidOptions, opts.OnOverflow)
+	case opts.Jobs != nil:
+		return fmt.Errorf("%w: la recherche inverse n'énumère pas de paires (Jobs)", ErrInvalidOptions)
+	}
+	start := time.Now()
+	primeList, err := opts.primeList(ctx)
+	if err != nil || len(primeList) == 0 {
+		return err
+	}
+	rep := opts.reporter()
+	rep.OnSieveDone(SieveInfo{Primes: len(primeList), Elapsed: time.Since(start)})
+	started := time.Now()
+	lo := uint64(FormP2Plus4Q2.Eval(int64(primeList[0]), int64(primeList[0])))
+	hi := uint64(FormP2Plus4Q2.Eval(int64(primeList[len(primeList)-1]), int64(primeList[len(primeList)-1])))
+	base, err := SieveOfEratosthenesContext(ctx, int(isqrtUint64(hi)))
+	if err != nil {
+		return err
+	}
+	isPrime := opts.primalityFunc()
+	inList := func(v int64) bool {
+		_, found := slices.BinarySearch(primeList, int(v))
+		return found
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	g, ctx := errgroup.WithContext(ctx)
+	ctl := opts.Control
+	blocks := int64((hi-lo)/reverseBlockSize + 1)
+	var next, sieved, found atomic.Int64
+	done := make(chan reverseBlock, opts.Workers)
+	var workersDone sync.WaitGroup
+	for range opts.Workers {
+		workersDone.Add(1)
+		g.Go(func() error {
+			defer workersDone.Done()
+			for {
+				index := next.Add(1) - 1
+				if index >= blocks || (ctl != nil && !ctl.wait(ctx)) {
+					return nil
+				}
+				start := lo + uint64(index)*reverseBlockSize
+				end := min(hi, start+reverseBlockSize-1)
+				block := reverseBlock{index: index}
+				err := sieveSegments(ctx, start, end, base, func(n uint64) bool {
+					if n%4 != 1 {
+						return true
+					}
+					tested := time.Now()
+					p, q, err := ntheory.Cornacchia(4, int64(n))
+					if err != nil || !inList(p) || !inList(q) || !opts.pInRange(int(p)) || !opts.Pairs.Contains(int(p), int(q)) {
+						return true
+					}
+					if opts.Filter != nil && !opts.Filter.Accept(int64(n)) {
+						return true
+					}
+					res := Result{P: int(p), Q: int(q), N: int64(n), Twin: opts.Twins && hasTwin(int64(n), isPrime)}
+					if opts.Timing {
+						res.FoundAt = time.Now()
+						res.TestTime = res.FoundAt.Sub(tested)
+					}
+					block.results = append(block.results, res)
+					return true
+				})
+				if err != nil {
+					return nil // Annulation: l'erreur est celle du contexte parent.
+				}
+				sieved.Add(int64(end - start + 1))
+				found.Add(int64(len(block.results)))
+				select {
+				case done <- block:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		})
+	}
+	g.Go(func() error {
+		workersDone.Wait()
+		close(done)
+		return nil
+	})
+
+	progress := func() Progress {
+		pr := Progress{Tested: sieved.Load(), Total: int64(hi - lo + 1), Found: found.Load()}
+		rep.OnBatchDone(pr)
+		return pr
+	}
+	ticker := time.NewTicker(ProgressInterval)
+	defer ticker.Stop()
+	// Les blocs arrivent dans le désordre: ceux qui devancent le suivant attendu sont mis de côté.
+	pending := map[int64][]Result{}
+	var nextBlock int64
+	var emitErr error
+	emitted := 0
+	for open := true; open; {
+		select {
+		case block, ok := <-done:
+			if !ok {
+				open = false
+				break
+			}
+			if emitErr != nil || ctx.Err() != nil {
+				continue
+			}
+			pending[block.index] = block.results
+			for results, ok := pending[nextBlock]; ok && emitErr == nil; results, ok = pending[nextBlock] {
+				delete(pending, nextBlock)
+				nextBlock++
+				for _, res := range results {
+					emitted++
+					rep.OnResult(res)
+					if emitErr = fn(res); emitErr != nil {
+						cancel()
+						break
+					}
+				}
+			}
+		case <-ticker.C:
+			progress()
+		}
+	}
+	final := progress()
+	groupErr := g.Wait()
+	err = parent.Err()
+	switch {
+	case emitErr != nil:
+		err = emitErr
+	case groupErr != nil:
+		err = groupErr
+	}
+	rep.OnFinish(Summary{Progress: final, Results: emitted, Elapsed: time.Since(started), Err: err})
+	return err
+}
@@ -0,0 +1,75 @@
+/*
+ * Fichier: reverse_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la recherche inverse par Cornacchia.
+ */
+package primes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// collect retourne les résultats de search pour opts, triés par n.
+func collect(t *testing.T, search func(context.Context, Options, func(Result) error) error, opts Options) []Result {
+	t.Helper()
+	var got []Result
+	if err := search(context.Background(), opts, func(r Result) error {
+		got = append(got, r)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	slices.SortFunc(got, func(a, b Result) int { return cmp.Compare(a.N, b.N) })
+	return got
+}
+
+// TestSearchReverse compare la recherche inverse à la recherche directe, avec bornes, tranche de p,
+// région de la grille, filtre et jumeaux, et vérifie l'ordre croissant de ses résultats.
+func TestSearchReverse(t *testing.T) {
+	filter, _ := LookupFilter("sophie-germain")
+	for _, opts := range []Options{
+		{Limit: 1000, Workers: 3},
+		{Limit: 600, Min: 50, Pairs: PairsLess, Twins: true, Workers: 2},
+		{Limit: 600, PMin: 100, PMax: 300, Filter: filter, Workers: 1},
+		{Primes: []int{3, 5, 7, 11, 13}, Pairs: PairsEqual, Workers: 2},
+	} {
+		want := collect(t, Search, opts)
+		var ordered []Result
+		if err := SearchReverse(context.Background(), opts, func(r Result) error {
+			ordered = append(ordered, r)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.IsSortedFunc(ordered, func(a, b Result) int { return cmp.Compare(a.N, b.N) }) {
+			t.Errorf("%+v: résultats de la recherche inverse non triés par n", opts)
+		}
+		if !slices.Equal(ordered, want) {
+			t.Errorf("%+v: %d résultats inverses, %d directs", opts, len(ordered), len(want))
+		}
+	}
+
+	for _, opts := range []Options{{Limit: 100, Form: FormP2PlusQ4}, {Limit: 100, OnOverflow: OverflowSkip}} {
+		if err := SearchReverse(context.Background(), opts, func(Result) error { return nil }); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%+v: erreur %v, attendu ErrInvalidOptions", opts, err)
+		}
+	}
+
+	// Une erreur du callback arrête la recherche et est retournée.
+	stop := errors.New("arrêt")
+	calls := 0
+	err := SearchReverse(context.Background(), Options{Limit: 1000}, func(Result) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("erreur du callback: %v après %d appels, attendu %v après 1", err, calls, stop)
+	}
+}
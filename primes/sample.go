@@ -0,0 +1,148 @@
+/*
+ * Fichier: sample.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Estimation de Monte-Carlo de la densité des paires (p, q) donnant un n
+ * premier, pour les limites où l'énumération exhaustive est hors de portée:
+ * des paires de nombres premiers uniformément réparties dans [Min, Limit] sont
+ * tirées (par rejet, sans crible) et testées, puis la proportion de succès est
+ * encadrée par un intervalle de Wilson à 95 %. Multipliée par π(x)², elle
+ * estime le nombre de résultats qu'une recherche complète trouverait.
+ */
+package primes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+// sampleZ est le quantile de la loi normale pour un intervalle de confiance à 95 %.
+const sampleZ = 1.959963984540054
+
+// sampleCheckInterval est le nombre de tirages entre deux vérifications de l'annulation.
+const sampleCheckInterval = 1024
+
+// DensityEstimate est le résultat d'un échantillonnage (voir SampleDensity).
+type DensityEstimate struct {
+	Samples    int64   // Paires tirées.
+	Hits       int64   // Paires retenues par la recherche: non écartées, n premier et accepté par le filtre.
+	Density    float64 // Hits / Samples.
+	Low, High  float64 // Intervalle de confiance à 95 % (Wilson) de la densité.
+	PrimeCount int64   // Nombre de nombres premiers dans [Min, Limit].
+	Pairs      int64   // Nombre de paires de la région énumérée (PrimeCount² pour la grille complète).
+}
+
+// Expected retourne l'estimation du nombre de résultats d'une recherche complète,
+// Density × Pairs, et son intervalle de confiance.
+func (e DensityEstimate) Expected() (estimate, low, high float64) {
+	pairs := float64(e.Pairs)
+	return e.Density * pairs, e.Low * pairs, e.High * pairs
+}
+
+// wilsonInterval retourne l'intervalle de score de Wilson de la proportion hits/n pour le quantile z.
+func wilsonInterval(hits, n int64, z float64) (low, high float64) {
+	if n == 0 {
+		return 0, 1
+	}
+	p, nf := float64(hits)/float64(n), float64(n)
+	denom := 1 + z*z/nf
+	center := (p + z*z/(2*nf)) / denom
+	half := z * math.Sqrt(p*(1-p)/nf+z*z/(4*nf*nf)) / denom
+	return max(0, center-half), min(1, center+half)
+}
+
+// SampleDensity tire samples paires (p, q) de nombres premiers uniformément dans [opts.Min,
+// opts.Limit] (ou dans opts.Primes), et dans la région opts.Pairs, et estime la densité des paires retenues par la recherche.
+// Les tirages sont répartis entre opts.Workers workers; pour un même seed et un même nombre de
+// workers, le résultat est reproductible. Jobs, Twins, Explain, Control et OnProgress sont ignorés; la
+// politique de débordement doit être OverflowError.
+func SampleDensity(ctx context.Context, opts Options, samples int64, seed uint64) (DensityEstimate, error) {
+	opts, err := opts.validate()
+	if err != nil {
+		return DensityEstimate{}, err
+	}
+	if samples < 1 {
+		return DensityEstimate{}, fmt.Errorf("%w: %d tirages (attendu >= 1)", ErrInvalidOptions, samples)
+	}
+	if opts.OnOverflow != OverflowError {
+		return DensityEstimate{}, fmt.Errorf("%w: estimation avec la politique de débordement %s", ErrInvalidOptions, opts.OnOverflow)
+	}
+
+	// Tirage d'un nombre premier: dans la liste fournie, sinon par rejet dans [lo, hi].
+	lo, hi := max(opts.Min, 2), opts.Limit
+	var list []int
+	var primeCount int64
+	if len(opts.Primes) > 0 {
+		if list, err = opts.primeList(ctx); err != nil {
+			return DensityEstimate{}, err
+		}
+		primeCount = int64(len(list))
+	} else if hi >= lo {
+		primeCount = PrimeCount(int64(hi)) - PrimeCount(int64(lo)-1)
+	}
+	if opts.Pairs.Count(int(primeCount)) == 0 {
+		return DensityEstimate{}, fmt.Errorf("%w: aucune paire (%v) de nombres premiers dans [%d, %d]", ErrInvalidOptions, opts.Pairs, opts.Min, opts.Limit)
+	}
+	isPrime := opts.primalityFunc()
+	candidate := opts.candidateFunc()
+	randomPrime := func(rng *rand.Rand) int64 {
+		if list != nil {
+			return int64(list[rng.IntN(len(list))])
+		}
+		for {
+			if x := lo + rng.IntN(hi-lo+1); isPrime(int64(x)) {
+				return int64(x)
+			}
+		}
+	}
+
+	hits := make([]int64, opts.Workers)
+	var wg sync.WaitGroup
+	for w := range opts.Workers {
+		share := samples / int64(opts.Workers)
+		if int64(w) < samples%int64(opts.Workers) {
+			share++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(seed, uint64(w)))
+			for i := range share {
+				if i%sampleCheckInterval == 0 && ctx.Err() != nil {
+					return
+				}
+				p, q := randomPrime(rng), randomPrime(rng)
+				if opts.Pairs == PairsEqual {
+					q = p
+				}
+				for !opts.Pairs.Contains(int(p), int(q)) { // Rejet: uniforme dans la région.
+					p, q = randomPrime(rng), randomPrime(rng)
+				}
+				n, ok := candidate(p, q)
+				if !ok {
+					continue
+				}
+				if isPrime(n) && (opts.Filter == nil || opts.Filter.Accept(n)) {
+					hits[w]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return DensityEstimate{}, err
+	}
+
+	est := DensityEstimate{Samples: samples, PrimeCount: primeCount, Pairs: opts.Pairs.Count(int(primeCount))}
+	for _, h := range hits {
+		est.Hits += h
+	}
+	est.Density = float64(est.Hits) / float64(samples)
+	est.Low, est.High = wilsonInterval(est.Hits, samples, sampleZ)
+	return est, nil
+}
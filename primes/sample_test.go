@@ -0,0 +1,92 @@
+/*
+ * Fichier: sample_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'estimation de densité par échantillonnage.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestSampleDensity vérifie que l'intervalle de confiance contient la densité exacte,
+// que le tirage est reproductible et que les options invalides sont rejetées.
+func TestSampleDensity(t *testing.T) {
+	// Jusqu'à 100: 171 paires retenues sur π(100)² = 625.
+	opts := Options{Limit: 100, Workers: 2}
+	est, err := SampleDensity(context.Background(), opts, 20000, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exact := 171.0 / 625
+	if est.PrimeCount != 25 || est.Samples != 20000 || est.Low > exact || est.High < exact || est.Low >= est.High {
+		t.Errorf("estimation %+v, densité exacte %.4f hors de l'intervalle", est, exact)
+	}
+	if n, low, high := est.Expected(); low > 171 || high < 171 || n != est.Density*625 {
+		t.Errorf("Expected() = %.1f [%.1f, %.1f], attendu un intervalle contenant 171", n, low, high)
+	}
+
+	// Même graine, même nombre de workers: même résultat, avec ou sans liste fournie.
+	again, _ := SampleDensity(context.Background(), opts, 20000, 42)
+	if again != est {
+		t.Errorf("tirage non reproductible: %+v puis %+v", est, again)
+	}
+	listed, err := SampleDensity(context.Background(), Options{Primes: SieveOfEratosthenes(100), Workers: 2}, 5000, 1)
+	if err != nil || listed.PrimeCount != 25 {
+		t.Errorf("liste fournie: %+v, %v", listed, err)
+	}
+
+	for _, bad := range []struct {
+		opts    Options
+		samples int64
+	}{
+		{Options{Limit: 100}, 0},
+		{Options{Limit: 1}, 10},
+		{Options{Min: 24, Limit: 28}, 10},
+	} {
+		if _, err := SampleDensity(context.Background(), bad.opts, bad.samples, 0); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("SampleDensity(%+v, %d): erreur %v, attendu ErrInvalidOptions", bad.opts, bad.samples, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SampleDensity(ctx, opts, 1000, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("contexte annulé: erreur %v", err)
+	}
+}
+
+// TestWilsonInterval contrôle l'intervalle de Wilson sur des valeurs connues.
+func TestWilsonInterval(t *testing.T) {
+	low, high := wilsonInterval(50, 100, sampleZ)
+	if low < 0.4038 || low > 0.4039 || high < 0.5961 || high > 0.5962 {
+		t.Errorf("wilsonInterval(50, 100) = [%.4f, %.4f], attendu [0.4038, 0.5962]", low, high)
+	}
+	if low, high := wilsonInterval(0, 10, sampleZ); low != 0 || high <= 0 {
+		t.Errorf("wilsonInterval(0, 10) = [%g, %g]", low, high)
+	}
+}
+
+// TestSampleDensityPairs vérifie l'estimation restreinte au triangle p < q.
+func TestSampleDensityPairs(t *testing.T) {
+	hits := 0
+	Search(context.Background(), Options{Limit: 100, Pairs: PairsLess}, func(Result) error {
+		hits++
+		return nil
+	})
+	est, err := SampleDensity(context.Background(), Options{Limit: 100, Workers: 2, Pairs: PairsLess}, 20000, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, low, high := est.Expected(); est.Pairs != 300 || low > float64(hits) || high < float64(hits) {
+		t.Errorf("p < q: %d paires, N estimé %.1f [%.1f, %.1f], attendu 300 paires et %d résultats", est.Pairs, n, low, high, hits)
+	}
+	if _, err := SampleDensity(context.Background(), Options{Primes: []int{7}, Pairs: PairsLess}, 10, 0); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("aucune paire p < q: erreur %v, attendu ErrInvalidOptions", err)
+	}
+}
@@ -0,0 +1,640 @@
+/*
+ * Fichier: search.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 20 juin 2025
+ *
+ * Description:
+ * Recherche parallèle des paires (p, q) de nombres premiers telles que
+ * n = p^2 + 4*q^2 (ou toute autre forme enregistrée, voir form.go) soit
+ * premier, à l'aide d'un pool de workers (goroutines) alimenté par des canaux. La progression est remontée par un callback, ce
+ * qui permet au même moteur de servir la CLI comme la cible WebAssembly.
+ */
+package primes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ProgressInterval est l'intervalle entre deux appels du callback de progression.
+const ProgressInterval = 100 * time.Millisecond
+
+// DefaultBatchSize est le nombre de paires par lot distribué aux workers par défaut. Regrouper les
+// paires amortit le coût des opérations sur les canaux, dominant pour les petits candidats.
+const DefaultBatchSize = 64
+
+// MaxLimit est la plus grande limite pour laquelle n = p^2 + 4q^2 tient dans un int64
+// pour tous p, q <= MaxLimit (5·MaxLimit² <= math.MaxInt64).
+const MaxLimit = 1358187913
+
+// ErrOverflow signale qu'une limite produirait des valeurs de n dépassant int64.
+var ErrOverflow = errors.New("primes: n dépasse la capacité d'un int64")
+
+// PartialError est l'erreur d'une recherche interrompue par l'annulation de son contexte: les
+// Completed premières paires de l'énumération ont toutes été testées et leurs Results résultats
+// transmis, et aucune autre. L'énumération reprend donc exactement avec
+// NewResumeSource(source, Completed) (voir WithJobs). Elle enveloppe ctx.Err(): errors.Is(err,
+// context.Canceled) reste vrai.
+type PartialError struct {
+	Err       error // Cause de l'interruption: context.Canceled ou context.DeadlineExceeded.
+	Completed int64 // Paires testées, en tête de l'énumération.
+	Total     int64 // Paires de l'énumération complète (0 si la source ne le connaît pas).
+	Results   int   // Résultats transmis.
+}
+
+// Error implémente error.
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("primes: recherche interrompue après %d paires sur %d (%d résultats): %v", e.Completed, e.Total, e.Results, e.Err)
+}
+
+// Unwrap retourne la cause de l'interruption.
+func (e *PartialError) Unwrap() error { return e.Err }
+
+// CheckLimit retourne une erreur enveloppant ErrOverflow si la limite dépasse MaxLimit
+// (forme par défaut; voir CheckFormLimit pour les autres formes).
+func CheckLimit(limit int) error {
+	if limit > MaxLimit {
+		return fmt.Errorf("%w (limite %d > %d)", ErrOverflow, limit, MaxLimit)
+	}
+	return nil
+}
+
+// Job représente une tâche à effectuer par un worker: une paire (p, q) à tester.
+type Job struct {
+	P int
+	Q int
+}
+
+// Result représente un résultat positif trouvé par un worker.
+// Le type de 'N' est int64 pour éviter les débordements (overflows).
+type Result struct {
+	P    int
+	Q    int
+	N    int64
+	Twin bool     // n-2 ou n+2 est premier (renseigné seulement si la détection est demandée).
+	Big  *big.Int // Valeur exacte de n au-delà d'int64 (OverflowPromote); N vaut alors math.MaxInt64.
+
+	// Mesures renseignées seulement si Options.Timing est demandé: la mesure coûte deux lectures
+	// de l'horloge par candidat.
+	FoundAt  time.Time     // Instant de la découverte, à la sortie du test.
+	TestTime time.Duration // Durée du test de primalité de n (et du filtre éventuel).
+}
+
+// hasTwin indique si n-2 ou n+2 est premier, c'est-à-dire si n appartient à une paire de nombres premiers jumeaux.
+func hasTwin(n int64, isPrime func(int64) bool) bool {
+	return isPrime(n-2) || (n <= math.MaxInt64-2 && isPrime(n+2))
+}
+
+// WorkerStats décrit l'activité cumulée d'un worker.
+type WorkerStats struct {
+	Batches int64         // Lots traités.
+	Jobs    int64         // Paires testées.
+	Found   int64         // Résultats positifs.
+	Busy    time.Duration // Temps passé à tester des paires.
+}
+
+// Progress est l'état d'avancement transmis au callback de progression.
+type Progress struct {
+	Tested int64 // Paires testées.
+	Total  int64 // Nombre total de paires à tester (0 si la source de paires ne le connaît pas).
+	Found  int64 // Résultats trouvés.
+	// Overflowed compte les paires dont le candidat dépasse int64, ignorées (OverflowSkip) ou
+	// testées sur math/big (OverflowPromote).
+	Overflowed int64
+	// Skipped compte les paires abandonnées par le chien de garde (Options.SkipStalled), comptées
+	// aussi dans Tested: leur résultat est inconnu.
+	Skipped int64
+	Workers []WorkerStats // Activité par worker, indexée par numéro de worker.
+	Batches BatchStats    // Taille des lots adaptative (vide sans Options.BatchTarget).
+}
+
+// ProgressFunc reçoit périodiquement l'état d'avancement de la recherche.
+type ProgressFunc func(Progress)
+
+// workerCounters contient les compteurs atomiques d'un worker, lus pendant la recherche.
+type workerCounters struct {
+	batches    atomic.Int64
+	jobs       atomic.Int64
+	found      atomic.Int64
+	overflowed atomic.Int64
+	busyNs     atomic.Int64
+	skipped    atomic.Int64
+}
+
+// Composite décrit une valeur de n rejetée car composée, avec son plus petit facteur premier.
+type Composite struct {
+	P      int
+	Q      int
+	N      int64
+	Factor int64 // Plus petit facteur premier de n (SmallestFactor).
+}
+
+// Explain active l'analyse des valeurs de n composées pendant une recherche.
+type Explain struct {
+	// Every est l'échantillonnage: chaque worker analyse une valeur composée sur Every (1: toutes).
+	Every int
+	// OnComposite reçoit chaque valeur analysée, depuis la goroutine appelante de Search.
+	OnComposite func(Composite)
+}
+
+// workerConfig regroupe les paramètres de test communs à tous les workers d'une recherche.
+type workerConfig struct {
+	form         Form
+	candidate    TransformFunc
+	bigForm      BigForm  // Évaluation exacte des candidats, selon la politique de débordement.
+	bigAbove     int64    // Limite de la forme: en deçà, aucun candidat ne déborde.
+	wide         wideForm // Évaluation sur uint64 des candidats, selon la politique de débordement.
+	wideAbove    int64    // Limite int64 de la forme: en deçà, l'évaluation sur uint64 est inutile.
+	onOverflow   OverflowPolicy
+	filter       Filter
+	twins        bool
+	timing       bool
+	explainEvery int // 0: pas d'analyse des valeurs composées.
+	isPrime      func(int64) bool
+	isPrimeBig   func(*big.Int) bool
+	sizer        *batchSizer // Taille des lots adaptative (nil: taille fixe).
+	accel        Accelerator // Pré-filtre des lots (nil: aucun).
+}
+
+// worker est une fonction qui s'exécute dans une goroutine.
+// Elle reçoit des lots de tâches (Jobs) depuis un canal, les traite,
+// et envoie les résultats positifs dans un autre canal (et, si l'analyse est demandée, un
+// échantillon des valeurs composées dans composites). pending, s'il n'est pas vide, est traité
+// avant le premier lot du canal (reste du lot d'un worker abandonné par le chien de garde). Le
+// bridage CPU éventuel (Control.SetCPUPercent) est appliqué entre les lots. Après l'annulation
+// de ctx, les lots restants sont retirés du canal sans être traités. Un candidat dépassant int64
+// est traité selon cfg.onOverflow, sur uint64 jusqu'à 2^64 si la forme le permet (cfg.wide);
+// avec OverflowError, ou OverflowUint64 au-delà de 2^64, retourne une erreur enveloppant
+// ErrOverflow si la forme produit une valeur de n négative (débordement non détecté par
+// CheckFormLimit). Avec un chien de garde (slot non nil), chaque test lui est annoncé, et le
+// worker s'arrête avec errWorkerDetached si le chien de garde a abandonné son candidat. Avec un
+// accélérateur, les candidats de chaque lot lui sont soumis d'abord (cfg.prefilter): ceux qu'il
+// écarte sont composés sans passer par le test de primalité.
+func worker(ctx context.Context, batches <-chan []Job, pending []Job, results chan<- Result, composites chan<- Composite, cfg workerConfig, counters *workerCounters, ctl *Control, slot *watchSlot) error {
+	pacing := pacer{ctl: ctl}
+	rejected := 0
+	var survivors []bool
+	testBatch := func(batch []Job) error {
+		start := time.Now()
+		var err error
+		if survivors, err = cfg.prefilter(batch, survivors); err != nil {
+			return err
+		}
+		slot.setBatch(batch)
+		for i, job := range batch {
+			seq := slot.begin(i)
+			outcome, res, comp, err := cfg.test(job, survivors == nil || survivors[i], counters, &rejected)
+			if !slot.end(seq) {
+				return errWorkerDetached
+			}
+			switch {
+			case err != nil:
+				return err
+			case outcome == jobFound:
+				counters.found.Add(1)
+				results <- res
+			case outcome == jobComposite:
+				composites <- comp
+			}
+		}
+		busy := time.Since(start)
+		counters.busyNs.Add(int64(busy))
+		counters.jobs.Add(int64(len(batch)))
+		counters.batches.Add(1)
+		cfg.sizer.observe(len(batch), busy)
+		pacing.pace(ctx, busy)
+		return nil
+	}
+	if len(pending) > 0 && ctx.Err() == nil {
+		if err := testBatch(pending); err != nil {
+			return err
+		}
+	}
+	for batch := range batches {
+		if ctx.Err() != nil {
+			continue
+		}
+		if err := testBatch(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jobOutcome est le sort d'une paire testée par un worker.
+type jobOutcome int
+
+const (
+	jobRejected  jobOutcome = iota // Paire écartée, candidat composé ou ignoré.
+	jobFound                       // Résultat à transmettre.
+	jobComposite                   // Valeur composée de l'échantillon de l'analyse, à transmettre.
+)
+
+// prefilter soumet à cfg.accel les candidats de batch, et retourne keep (réutilisé, agrandi au
+// besoin) où keep[i] est faux si le candidat de batch[i] a un petit facteur premier; nil sans
+// accélérateur. Un candidat écarté par la forme ou hors d'int64 est laissé au test.
+func (cfg workerConfig) prefilter(batch []Job, keep []bool) ([]bool, error) {
+	if cfg.accel == nil {
+		return nil, nil
+	}
+	keep = slices.Grow(keep[:0], len(batch))[:len(batch)]
+	ns := make([]int64, len(batch))
+	for i, job := range batch {
+		keep[i] = true
+		if n, ok := cfg.candidate(int64(job.P), int64(job.Q)); ok && n > 0 {
+			ns[i] = n // Une valeur qui déborde (n > 0 faux ou chemin uint64/big) ne sert pas.
+		}
+	}
+	if err := cfg.accel.Prefilter(ns, keep); err != nil {
+		return nil, fmt.Errorf("accélérateur %s: %w", cfg.accel.Name(), err)
+	}
+	return keep, nil
+}
+
+// test teste la paire job et retourne son sort, avec le résultat ou la valeur composée à
+// transmettre; rejected compte les valeurs composées du worker, pour l'échantillonnage de
+// l'analyse. survivor est faux si le pré-filtre a trouvé un petit facteur au candidat, qui n'est
+// alors pas soumis au test de primalité. Rien n'est transmis ici: un worker abandonné par le
+// chien de garde ne transmet pas le sort de son dernier candidat.
+func (cfg workerConfig) test(job Job, survivor bool, counters *workerCounters, rejected *int) (jobOutcome, Result, Composite, error) {
+	p, q := int64(job.P), int64(job.Q)
+	n, ok := cfg.candidate(p, q)
+	if !ok {
+		return jobRejected, Result{}, Composite{}, nil
+	}
+	var tested time.Time
+	if cfg.timing {
+		tested = time.Now()
+	}
+	if cfg.wide != nil && max(p, q) > cfg.wideAbove {
+		wn, fits := cfg.wide.evalUint64(uint64(p), uint64(q))
+		switch {
+		case fits && wn > math.MaxInt64:
+			return cfg.testUint64(job, wn, counters, tested)
+		case fits:
+			n = int64(wn) // Valeur exacte, testée comme en deçà de la limite.
+		case cfg.onOverflow == OverflowUint64:
+			return jobRejected, Result{}, Composite{}, fmt.Errorf("%w (forme %s, p=%d, q=%d, n >= 2^64)", ErrOverflow, cfg.form.Name(), p, q)
+		default:
+			return cfg.testBig(job, cfg.wide.EvalBig(p, q), counters, tested)
+		}
+	} else if exact := cfg.overflowed(p, q); exact != nil || n < 0 {
+		return cfg.testBig(job, exact, counters, tested)
+	}
+
+	if !survivor || !cfg.isPrime(n) {
+		if cfg.explainEvery > 0 {
+			if *rejected++; *rejected%cfg.explainEvery == 0 {
+				return jobComposite, Result{}, Composite{P: job.P, Q: job.Q, N: n, Factor: SmallestFactor(n)}, nil
+			}
+		}
+		return jobRejected, Result{}, Composite{}, nil
+	}
+	if cfg.filter != nil && !cfg.filter.Accept(n) {
+		return jobRejected, Result{}, Composite{}, nil
+	}
+	res := cfg.stamp(Result{P: job.P, Q: job.Q, N: n}, tested)
+	res.Twin = cfg.twins && hasTwin(n, cfg.isPrime)
+	return jobFound, res, Composite{}, nil
+}
+
+// testBig traite selon cfg.onOverflow le candidat de job au-delà d'int64, de valeur exacte exact
+// (nil sans évaluation exacte): ignoré avec OverflowSkip, testé sur math/big avec OverflowPromote,
+// erreur enveloppant ErrOverflow sinon.
+func (cfg workerConfig) testBig(job Job, exact *big.Int, counters *workerCounters, tested time.Time) (jobOutcome, Result, Composite, error) {
+	switch cfg.onOverflow {
+	case OverflowSkip:
+		counters.overflowed.Add(1)
+		return jobRejected, Result{}, Composite{}, nil
+	case OverflowPromote:
+		counters.overflowed.Add(1)
+		if !cfg.isPrimeBig(exact) {
+			return jobRejected, Result{}, Composite{}, nil
+		}
+		res := cfg.stamp(Result{P: job.P, Q: job.Q, N: math.MaxInt64, Big: exact}, tested)
+		res.Twin = cfg.twins && hasTwinBig(exact, cfg.isPrimeBig)
+		return jobFound, res, Composite{}, nil
+	}
+	return jobRejected, Result{}, Composite{}, fmt.Errorf("%w (forme %s, p=%d, q=%d)", ErrOverflow, cfg.form.Name(), job.P, job.Q)
+}
+
+// testUint64 teste le candidat wn de job, compris entre 2^63 et 2^64, exactement sur uint64:
+// ignoré avec OverflowSkip, résultat dans Result.Big sinon.
+func (cfg workerConfig) testUint64(job Job, wn uint64, counters *workerCounters, tested time.Time) (jobOutcome, Result, Composite, error) {
+	counters.overflowed.Add(1)
+	if cfg.onOverflow == OverflowSkip || !IsPrimeUint64(wn) {
+		return jobRejected, Result{}, Composite{}, nil
+	}
+	res := cfg.stamp(Result{P: job.P, Q: job.Q, N: math.MaxInt64, Big: new(big.Int).SetUint64(wn)}, tested)
+	res.Twin = cfg.twins && hasTwinUint64(wn)
+	return jobFound, res, Composite{}, nil
+}
+
+// stamp renseigne les mesures du résultat si elles sont demandées: le test de son candidat a
+// commencé à tested. La recherche des jumeaux n'entre pas dans la durée.
+func (cfg workerConfig) stamp(res Result, tested time.Time) Result {
+	if cfg.timing {
+		res.FoundAt = time.Now()
+		res.TestTime = res.FoundAt.Sub(tested)
+	}
+	return res
+}
+
+// overflowed retourne la valeur exacte du candidat de la paire (p, q) s'il dépasse int64, nil
+// sinon ou sans évaluation exacte.
+func (cfg workerConfig) overflowed(p, q int64) *big.Int {
+	if cfg.bigForm == nil || max(p, q) <= cfg.bigAbove && cfg.bigAbove > 0 {
+		return nil
+	}
+	if exact := cfg.bigForm.EvalBig(p, q); !exact.IsInt64() {
+		return exact
+	}
+	return nil
+}
+
+// minPendingPairs est le nombre minimal de paires en attente dans le canal des tâches par défaut:
+// avec de petits lots, les workers n'attendent pas le producteur.
+const minPendingPairs = 1024
+
+// Bornes de la capacité par défaut des canaux de résultats.
+const (
+	minResultsBuffer = 100
+	maxResultsBuffer = 4096
+)
+
+// DefaultJobsBuffer retourne la capacité par défaut, en lots, du canal des tâches: quatre lots par
+// worker, et au moins minPendingPairs paires en attente.
+func DefaultJobsBuffer(workers, batchSize int) int {
+	batchSize = max(batchSize, 1)
+	return max(4*workers, (minPendingPairs+batchSize-1)/batchSize)
+}
+
+// DefaultResultsBuffer retourne la capacité par défaut des canaux de résultats: un lot complet de
+// résultats par worker, borné à [minResultsBuffer, maxResultsBuffer].
+func DefaultResultsBuffer(workers, batchSize int) int {
+	return min(max(workers*batchSize, minResultsBuffer), maxResultsBuffer)
+}
+
+// snapshotProgress construit l'état d'avancement à partir des compteurs des workers et de
+// l'ajustement de la taille des lots.
+func snapshotProgress(counters []workerCounters, total int64, sizer *batchSizer) Progress {
+	pr := Progress{Total: total, Workers: make([]WorkerStats, len(counters)), Batches: sizer.stats()}
+	for i := range counters {
+		ws := WorkerStats{
+			Batches: counters[i].batches.Load(),
+			Jobs:    counters[i].jobs.Load(),
+			Found:   counters[i].found.Load(),
+			Busy:    time.Duration(counters[i].busyNs.Load()),
+		}
+		pr.Workers[i] = ws
+		pr.Tested += ws.Jobs
+		pr.Found += ws.Found
+		pr.Overflowed += counters[i].overflowed.Load()
+		pr.Skipped += counters[i].skipped.Load()
+	}
+	return pr
+}
+
+// Search teste toutes les paires (p, q) décrites par opts et appelle fn pour chaque résultat,
+// depuis la goroutine appelante, au fur et à mesure de leur production. Une erreur retournée
+// par fn arrête la distribution des tâches: les workers terminent les lots déjà distribués,
+// les résultats restants sont ignorés et Search retourne cette erreur. L'annulation de ctx
+// arrête aussi la distribution, mais les lots déjà distribués sont entièrement testés et leurs
+// résultats transmis à fn: Search retourne alors une *PartialError, qui enveloppe ctx.Err() et
+// indique jusqu'où l'énumération est allée (le crible interrompu retourne ctx.Err() seul). Un
+// arrêt par opts.Control.Stop n'est pas une erreur. Les options sont validées avant le début de la
+// recherche (voir NewOptions).
+func Search(ctx context.Context, opts Options, fn func(Result) error) error {
+	opts, err := opts.validate()
+	if err != nil {
+		return err
+	}
+	var primeList []int
+	if opts.Jobs == nil {
+		start := time.Now()
+		if primeList, err = opts.primeList(ctx); err != nil {
+			return err
+		}
+		opts.reporter().OnSieveDone(SieveInfo{Primes: len(primeList), Elapsed: time.Since(start)})
+	}
+	_, err = search(ctx, primeList, opts, fn)
+	return err
+}
+
+// SearchChan lance la recherche décrite par opts en arrière-plan et retourne le canal de ses
+// résultats, fermé à la fin de la recherche, ainsi qu'un canal recevant au plus une erreur
+// (celle que retournerait Search) puis fermé. Le consommateur doit lire les résultats jusqu'à
+// la fermeture du canal ou annuler ctx: sinon, la recherche reste bloquée.
+func SearchChan(ctx context.Context, opts Options) (<-chan Result, <-chan error) {
+	out := make(chan Result, cmp.Or(opts.ResultsBuffer, DefaultResultsBuffer(opts.Workers, opts.BatchSize)))
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(out)
+		err := Search(ctx, opts, func(res Result) error {
+			select {
+			case out <- res:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errc <- err
+		}
+	}()
+	return out, errc
+}
+
+// search est le moteur commun aux points d'entrée de la recherche: il teste toutes les paires
+// de primeList (ou de opts.Jobs) avec les options (complétées) opts et transmet chaque résultat à emit. Le
+// producteur, les workers et la fermeture des canaux forment un errgroup: la première erreur,
+// celle d'une de ces goroutines ou celle d'emit, annule le contexte commun et arrête la
+// distribution des tâches; les canaux sont ensuite vidés pour que toutes les goroutines se
+// terminent. L'annulation de ctx n'arrête que le producteur: les lots distribués sont testés et
+// leurs résultats transmis, et l'erreur est une *PartialError. Retourne le nombre de résultats
+// transmis et l'erreur ayant arrêté la recherche.
+func search(ctx context.Context, primeList []int, opts Options, emit func(Result) error) (int, error) {
+	parent := ctx
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	g, ctx := errgroup.WithContext(ctx)
+	// dispatch est annulé avec ctx ou avec parent: il arrête le producteur (et ses attentes de
+	// Control); parent.Err() est aussi vérifié avant chaque envoi, AfterFunc étant asynchrone.
+	dispatch, stopDispatch := context.WithCancel(ctx)
+	defer stopDispatch()
+	defer context.AfterFunc(parent, stopDispatch)()
+
+	ctl := opts.Control
+	rep, started := opts.reporter(), time.Now()
+	cfg := workerConfig{form: opts.Form, candidate: opts.candidateFunc(), filter: opts.Filter, twins: opts.Twins, timing: opts.Timing, isPrime: opts.primalityFunc(), isPrimeBig: opts.BigPrimeTest, onOverflow: opts.OnOverflow}
+	cfg.bigForm, cfg.bigAbove = opts.bigForm()
+	cfg.wide, cfg.wideAbove = opts.wideForm()
+	cfg.sizer = newBatchSizer(opts.BatchSize, opts.BatchTarget)
+	cfg.accel = opts.Accelerator
+	source := opts.jobSource(primeList)
+	total := max(sourceLen(source), 0)
+	// Une source fournie n'est pas bornée par la limite validée: chaque paire y est vérifiée.
+	checkOverflow := opts.Jobs != nil && opts.Transform == nil && opts.OnOverflow == OverflowError
+
+	// --- Mise en place du Pool de Workers et des canaux ---
+	jobs := make(chan []Job, opts.JobsBuffer)
+	results := make(chan Result, opts.ResultsBuffer)
+	var composites chan Composite // nil sans analyse: jamais sélectionné.
+	explain := opts.Explain
+	if explain != nil && explain.OnComposite != nil {
+		cfg.explainEvery = max(explain.Every, 1)
+		composites = make(chan Composite, opts.ResultsBuffer)
+	}
+	var workersDone sync.WaitGroup
+	counters := make([]workerCounters, opts.Workers)
+	watch := newWatchdog(opts, counters)
+
+	// Chien de garde des workers, arrêté avec eux: il n'appelle plus OnStall après la fin de la
+	// recherche.
+	stopWatch := func() {}
+	if watch != nil {
+		watchStop, watchDone := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(watchDone)
+			watch.run(watchStop)
+		}()
+		stopWatch = func() {
+			close(watchStop)
+			<-watchDone
+		}
+	}
+
+	// Démarrage des workers.
+	for w := range opts.Workers {
+		workersDone.Add(1)
+		g.Go(func() error {
+			defer workersDone.Done()
+			return watch.supervise(w, func(pending []Job) error {
+				return worker(ctx, jobs, pending, results, composites, cfg, &counters[w], ctl, watch.slot(w))
+			})
+		})
+	}
+
+	// --- Distribution des tâches ---
+	var dispatched int64 // Paires distribuées: lu après la fin du producteur (g.Wait).
+	g.Go(func() error {
+		// Ferme le canal, signale aux workers qu'il n'y a plus de tâches.
+		defer close(jobs)
+		batchSize := cfg.sizer.size(opts.BatchSize)
+		batch := make([]Job, 0, batchSize)
+		send := func() bool {
+			if dispatch.Err() != nil || parent.Err() != nil {
+				return false
+			}
+			if ctl != nil {
+				if !ctl.wait(dispatch) {
+					return false
+				}
+				ctl.throttle(dispatch, func() int { return len(jobs) })
+			}
+			if parent.Err() != nil {
+				return false
+			}
+			select {
+			case jobs <- batch:
+			case <-dispatch.Done():
+				return false
+			}
+			dispatched += int64(len(batch))
+			batchSize = cfg.sizer.size(opts.BatchSize)
+			batch = make([]Job, 0, batchSize)
+			return true
+		}
+		for job, ok := source.Next(); ok; job, ok = source.Next() {
+			if checkOverflow && pairOverflows(opts.Form, int64(job.P), int64(job.Q)) {
+				return fmt.Errorf("%w (forme %s, p=%d, q=%d)", ErrOverflow, opts.Form.Name(), job.P, job.Q)
+			}
+			batch = append(batch, job)
+			if len(batch) >= batchSize && !send() {
+				return nil
+			}
+		}
+		if len(batch) > 0 && !send() {
+			return nil
+		}
+		return sourceErr(source)
+	})
+
+	// --- Fermeture des canaux de résultats ---
+	g.Go(func() error {
+		workersDone.Wait() // Attend la fin de tous les workers.
+		stopWatch()
+		close(results)
+		if composites != nil {
+			close(composites)
+		}
+		return nil
+	})
+
+	// --- Collecte des résultats ---
+	// Après une erreur ou une annulation, la collecte continue sans rien transmettre, jusqu'à la
+	// fin des workers.
+	ticker := time.NewTicker(ProgressInterval)
+	defer ticker.Stop()
+
+	count := 0
+	var emitErr error
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				// Les workers ont terminé: on remonte les valeurs composées restantes.
+				if composites != nil {
+					for c := range composites {
+						if ctx.Err() == nil {
+							explain.OnComposite(c)
+						}
+					}
+				}
+				final := snapshotProgress(counters, total, cfg.sizer)
+				rep.OnBatchDone(final)
+				groupErr := g.Wait()
+				var err error
+				switch {
+				case emitErr != nil:
+					err = emitErr
+				case groupErr != nil:
+					err = groupErr
+				case parent.Err() != nil:
+					err = &PartialError{Err: parent.Err(), Completed: dispatched, Total: total, Results: count}
+				}
+				rep.OnFinish(Summary{Progress: final, Results: count, Elapsed: time.Since(started), Err: err})
+				return count, err
+			}
+			if ctx.Err() != nil {
+				continue
+			}
+			count++
+			rep.OnResult(res)
+			if emitErr = emit(res); emitErr != nil {
+				cancel()
+			}
+		case c, ok := <-composites:
+			if !ok {
+				composites = nil // Fermé juste avant results: on ne le sélectionne plus.
+				continue
+			}
+			if ctx.Err() == nil {
+				explain.OnComposite(c)
+			}
+		case <-ticker.C:
+			rep.OnBatchDone(snapshotProgress(counters, total, cfg.sizer))
+		}
+	}
+}
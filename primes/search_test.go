@@ -0,0 +1,345 @@
+/*
+ * Fichier: search_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du moteur de recherche parallèle des paires (p, q).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestSearch valide les résultats et la progression finale de la recherche sur une petite limite.
+func TestSearch(t *testing.T) {
+	primeList := SieveOfEratosthenes(10) // 2, 3, 5, 7
+
+	for _, tc := range []struct {
+		algo      string
+		batchSize int
+	}{{"miller", 1}, {"trial", 1}, {"auto", 1}, {"miller", 3}, {"miller", DefaultBatchSize}} {
+		t.Run(fmt.Sprintf("%s/lots de %d", tc.algo, tc.batchSize), func(t *testing.T) {
+			var got []Result
+			var last Progress
+			opts := Options{Primes: primeList, PrimeTest: tc.algo, Workers: 3, BatchSize: tc.batchSize, OnProgress: func(pr Progress) {
+				last = pr
+			}}
+			err := Search(context.Background(), opts, func(r Result) error {
+				got = append(got, r)
+				return nil
+			})
+			count := len(got)
+
+			if err != nil {
+				t.Errorf("Search() = %v", err)
+			}
+			if last.Tested != 16 || last.Total != 16 || last.Found != int64(count) {
+				t.Errorf("progression finale = %+v, attendu 16/16 paires et %d résultats", last, count)
+			}
+			var jobs, batches int64
+			for _, ws := range last.Workers {
+				jobs += ws.Jobs
+				batches += ws.Batches
+			}
+			if wantBatches := int64((16 + tc.batchSize - 1) / tc.batchSize); len(last.Workers) != 3 || jobs != 16 || batches != wantBatches {
+				t.Errorf("statistiques par worker = %+v, attendu 3 workers totalisant 16 paires en %d lots", last.Workers, wantBatches)
+			}
+
+			sort.Slice(got, func(i, j int) bool { return got[i].N < got[j].N })
+			expected := []Result{{P: 5, Q: 2, N: 41}, {P: 5, Q: 3, N: 61}, {P: 3, Q: 5, N: 109}, {P: 7, Q: 5, N: 149}}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("résultats = %v, attendu %v", got, expected)
+			}
+		})
+	}
+}
+
+// TestCheckLimit valide la détection du débordement de n.
+func TestCheckLimit(t *testing.T) {
+	if err := CheckLimit(MaxLimit); err != nil {
+		t.Errorf("CheckLimit(MaxLimit) = %v, attendu nil", err)
+	}
+	if err := CheckLimit(MaxLimit + 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("CheckLimit(MaxLimit+1) = %v, attendu ErrOverflow", err)
+	}
+	p := int64(MaxLimit)
+	if n := p*p + 4*p*p; n < 0 {
+		t.Errorf("5·MaxLimit² déborde: %d", n)
+	}
+}
+
+// TestDefaultBuffers valide les capacités par défaut des canaux, calculées à partir du nombre de
+// workers et de la taille des lots.
+func TestDefaultBuffers(t *testing.T) {
+	testCases := []struct{ workers, batchSize, jobs, results int }{
+		{1, 64, 16, 100},
+		{8, 64, 32, 512},
+		{64, 64, 256, 4096},
+		{4, 1, 1024, 100},
+		{2, 1000, 8, 2000},
+		{0, 0, 1024, 100},
+	}
+	for _, tc := range testCases {
+		if got := DefaultJobsBuffer(tc.workers, tc.batchSize); got != tc.jobs {
+			t.Errorf("DefaultJobsBuffer(%d, %d) = %d, attendu %d", tc.workers, tc.batchSize, got, tc.jobs)
+		}
+		if got := DefaultResultsBuffer(tc.workers, tc.batchSize); got != tc.results {
+			t.Errorf("DefaultResultsBuffer(%d, %d) = %d, attendu %d", tc.workers, tc.batchSize, got, tc.results)
+		}
+	}
+
+	opts, err := NewOptions(WithWorkers(3), WithBatchSize(10), WithBuffers(5, 0))
+	if err != nil || opts.JobsBuffer != 5 || opts.ResultsBuffer != DefaultResultsBuffer(3, 10) {
+		t.Errorf("WithBuffers(5, 0): tampons %d et %d (%v)", opts.JobsBuffer, opts.ResultsBuffer, err)
+	}
+	if _, err := NewOptions(WithBuffers(-1, 0)); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("WithBuffers(-1, 0): erreur %v, attendu ErrInvalidOptions", err)
+	}
+}
+
+// TestSearchTwins valide le marquage des nombres premiers jumeaux parmi les résultats.
+func TestSearchTwins(t *testing.T) {
+	primeList := SieveOfEratosthenes(10) // n trouvés: 41, 61, 109, 149
+	twins := map[int64]bool{}
+	Search(context.Background(), Options{Primes: primeList, Twins: true, Workers: 2, BatchSize: 1}, func(r Result) error { twins[r.N] = r.Twin; return nil })
+
+	// 41 (43), 61 (59), 109 (107) et 149 (151) sont tous membres d'une paire de jumeaux.
+	for _, n := range []int64{41, 61, 109, 149} {
+		if !twins[n] {
+			t.Errorf("n=%d non marqué comme jumeau", n)
+		}
+	}
+
+	if hasTwin(97, IsPrime) {
+		t.Errorf("hasTwin(97) = true, attendu false (95 et 99 composés)")
+	}
+	// n+2 dépasserait int64: seul n-2 (composé) est testé.
+	if hasTwin(math.MaxInt64, IsPrime) {
+		t.Errorf("hasTwin(MaxInt64) = true, attendu false")
+	}
+}
+
+// TestSearchTiming valide l'horodatage des résultats et la mesure de la durée de leur test.
+func TestSearchTiming(t *testing.T) {
+	opts, err := NewOptions(WithPrimes(SieveOfEratosthenes(10)), WithWorkers(2), WithTiming())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	var got []Result
+	if err := Search(context.Background(), opts, func(r Result) error { got = append(got, r); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	end := time.Now()
+	if len(got) != 4 {
+		t.Fatalf("%d résultats, attendu 4", len(got))
+	}
+	for _, r := range got {
+		if r.FoundAt.Before(start) || r.FoundAt.After(end) || r.TestTime < 0 || r.TestTime > end.Sub(start) {
+			t.Errorf("n=%d: découverte %v, test %v, hors de la recherche [%v, %v]", r.N, r.FoundAt, r.TestTime, start, end)
+		}
+	}
+
+	// Sans WithTiming, les résultats ne portent aucune mesure.
+	Search(context.Background(), Options{Primes: SieveOfEratosthenes(10)}, func(r Result) error {
+		if !r.FoundAt.IsZero() || r.TestTime != 0 {
+			t.Errorf("n=%d: mesures %v, %v sans Timing", r.N, r.FoundAt, r.TestTime)
+		}
+		return nil
+	})
+}
+
+// TestSearchExplain valide l'analyse des valeurs composées et son échantillonnage.
+func TestSearchExplain(t *testing.T) {
+	primeList := SieveOfEratosthenes(10) // 16 paires, 4 élaguées (p = 2), 4 n premiers: 8 composés.
+	testCases := []struct {
+		every    int
+		expected int
+	}{
+		{0, 8}, // Every <= 0 équivaut à 1.
+		{1, 8},
+		{2, 4},
+		{100, 0},
+	}
+	for _, tc := range testCases {
+		var got []Composite
+		count := 0
+		opts := Options{Primes: primeList, Workers: 1, BatchSize: 3, Explain: &Explain{Every: tc.every, OnComposite: func(c Composite) { got = append(got, c) }}}
+		Search(context.Background(), opts, func(Result) error { count++; return nil })
+		if count != 4 || len(got) != tc.expected {
+			t.Errorf("Every=%d: %d résultats, %d composés, attendu 4 et %d", tc.every, count, len(got), tc.expected)
+		}
+		for _, c := range got {
+			if c.N != DefaultForm.Eval(int64(c.P), int64(c.Q)) || c.Factor <= 1 || c.Factor >= c.N || c.N%c.Factor != 0 {
+				t.Errorf("composé incohérent: %+v", c)
+			}
+		}
+	}
+}
+
+// TestSearchCallbackError valide qu'une erreur du callback arrête la recherche et est retournée.
+func TestSearchCallbackError(t *testing.T) {
+	errStop := errors.New("arrêt demandé")
+	var last Progress
+	calls := 0
+	opts := Options{Limit: 2000, Workers: 4, BatchSize: 1, OnProgress: func(pr Progress) { last = pr }}
+	err := Search(context.Background(), opts, func(Result) error {
+		if calls++; calls == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Search() = %v, attendu %v", err, errStop)
+	}
+	if calls != 3 {
+		t.Errorf("callback appelé %d fois après l'erreur, attendu 3", calls)
+	}
+	if last.Tested >= last.Total {
+		t.Errorf("toutes les paires testées (%d/%d) malgré l'arrêt", last.Tested, last.Total)
+	}
+}
+
+// TestSearchContext valide l'arrêt par annulation du contexte.
+func TestSearchContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Search(ctx, Options{Limit: 2000}, func(Result) error {
+		t.Error("résultat reçu après l'annulation du contexte")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Search() = %v, attendu context.Canceled", err)
+	}
+}
+
+// TestSearchPartial vérifie qu'une annulation en cours de recherche transmet exactement les
+// résultats d'un préfixe de l'énumération, décrit par une PartialError, et que la reprise après ce
+// préfixe complète les résultats.
+func TestSearchPartial(t *testing.T) {
+	primeList := SieveOfEratosthenes(500)
+	rank := map[Job]int64{}
+	for i, job := range drain(NewGridSource(primeList, PairsAll, 0, 0)) {
+		rank[job] = int64(i)
+	}
+	var all []Result
+	Search(context.Background(), Options{Primes: primeList, Workers: 2}, func(r Result) error {
+		all = append(all, r)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := map[Result]bool{}
+	err := Search(ctx, Options{Primes: primeList, Workers: 2, BatchSize: 8, JobsBuffer: 2}, func(r Result) error {
+		got[r] = true
+		if len(got) == 10 {
+			cancel()
+		}
+		return nil
+	})
+	var partial *PartialError
+	if !errors.As(err, &partial) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Search() = %v, attendu une PartialError enveloppant context.Canceled", err)
+	}
+	if partial.Results != len(got) || partial.Total != int64(len(rank)) || partial.Completed >= partial.Total {
+		t.Errorf("PartialError = %+v, %d résultats reçus sur %d paires", partial, len(got), len(rank))
+	}
+	for _, r := range all {
+		if inPrefix := rank[Job{P: int(r.P), Q: int(r.Q)}] < partial.Completed; inPrefix != got[r] {
+			t.Errorf("résultat %+v: reçu %v, dans le préfixe de %d paires %v", r, got[r], partial.Completed, inPrefix)
+		}
+	}
+
+	resumed := NewResumeSource(NewGridSource(primeList, PairsAll, 0, 0), partial.Completed)
+	if err := Search(context.Background(), Options{Jobs: resumed, Workers: 2}, func(r Result) error {
+		if got[r] {
+			t.Errorf("résultat %+v reçu deux fois", r)
+		}
+		got[r] = true
+		return nil
+	}); err != nil || len(got) != len(all) {
+		t.Errorf("reprise: %v, %d résultats au total, attendu %d", err, len(got), len(all))
+	}
+}
+
+// TestSearchOptions valide les valeurs par défaut, le crible jusqu'à Limit et la détection du débordement.
+func TestSearchOptions(t *testing.T) {
+	count := 0
+	if err := Search(context.Background(), Options{Limit: 10}, func(Result) error { count++; return nil }); err != nil || count != 4 {
+		t.Errorf("Search(Limit: 10) = %v, %d résultats, attendu 4", err, count)
+	}
+	err := Search(context.Background(), Options{Limit: MaxLimit + 1}, func(Result) error { return nil })
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Search(Limit: MaxLimit+1) = %v, attendu ErrOverflow", err)
+	}
+}
+
+// TestSearchChan valide la variante à canaux: résultats complets, erreur de validation et annulation.
+func TestSearchChan(t *testing.T) {
+	results, errc := SearchChan(context.Background(), Options{Limit: 10, Workers: 2})
+	var got []int64
+	for r := range results {
+		got = append(got, r.N)
+	}
+	slices.Sort(got)
+	if err := <-errc; err != nil || !slices.Equal(got, []int64{41, 61, 109, 149}) {
+		t.Errorf("SearchChan(Limit: 10) = %v, %v; attendu [41 61 109 149], nil", got, err)
+	}
+
+	results, errc = SearchChan(context.Background(), Options{Limit: MaxLimit + 1})
+	if _, ok := <-results; ok {
+		t.Errorf("résultat reçu malgré le débordement")
+	}
+	if err := <-errc; !errors.Is(err, ErrOverflow) {
+		t.Errorf("SearchChan(Limit: MaxLimit+1) = %v, attendu ErrOverflow", err)
+	}
+
+	// Le consommateur s'arrête après un résultat et annule: la recherche se termine sans blocage.
+	ctx, cancel := context.WithCancel(context.Background())
+	results, errc = SearchChan(ctx, Options{Limit: 3000, Workers: 4, BatchSize: 1})
+	<-results
+	cancel()
+	for range results {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("SearchChan annulé = %v, attendu context.Canceled", err)
+	}
+}
+
+// overflowForm est une forme de test dont n déborde dès que p + q > 4, sans limite déclarée.
+type overflowForm struct{}
+
+func (overflowForm) Name() string          { return "test:overflow" }
+func (overflowForm) Prune(p, q int64) bool { return false }
+func (overflowForm) Eval(p, q int64) int64 {
+	if p+q > 4 {
+		return math.MinInt64 + p + q
+	}
+	return p + q + 1
+}
+
+// TestSearchWorkerError valide qu'une erreur détectée par un worker annule la recherche et est retournée.
+func TestSearchWorkerError(t *testing.T) {
+	calls := 0
+	err := Search(context.Background(), Options{Limit: 5000, Form: overflowForm{}, Workers: 4, BatchSize: 1}, func(Result) error {
+		calls++
+		return nil
+	})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Search() = %v, attendu ErrOverflow", err)
+	}
+	if calls > 1 {
+		t.Errorf("%d résultats transmis après le débordement, attendu au plus 1 (n = 5 pour p = q = 2)", calls)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Fichier: segmented.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Crible d'Eratosthène segmenté: énumère les nombres premiers d'un intervalle
+ * [lo, hi] arbitraire en ne conservant en mémoire que les nombres premiers
+ * jusqu'à √hi et un segment de taille fixe, contrairement au crible complet.
+ * Range étend l'énumération à toute la plage des uint64.
+ */
+package primes
+
+import (
+	"context"
+	"math"
+)
+
+// segmentSize est le nombre d'entiers criblés par segment (tient dans le cache L2).
+const segmentSize = 1 << 18
+
+// isqrtUint64 retourne la partie entière de √n sur toute la plage des uint64.
+func isqrtUint64(n uint64) uint64 {
+	r := min(uint64(math.Sqrt(float64(n))), math.MaxUint32)
+	for r*r > n {
+		r--
+	}
+	for r < math.MaxUint32 && (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
+
+// forEachPrime appelle fn pour chaque nombre premier de [lo, hi], dans l'ordre croissant,
+// jusqu'à ce que fn retourne false.
+func forEachPrime(lo, hi int64, fn func(p int64) bool) {
+	if hi < max(lo, 2) {
+		return
+	}
+	forEachPrimeUint64(context.Background(), uint64(max(lo, 2)), uint64(hi), func(p uint64) bool { return fn(int64(p)) })
+}
+
+// forEachPrimeUint64 est le crible segmenté de forEachPrime sur toute la plage des uint64: les
+// nombres premiers jusqu'à √hi marquent successivement chaque segment de [lo, hi]. Il s'arrête
+// quand fn retourne false ou à l'annulation de ctx, vérifiée entre deux segments (ctx.Err()).
+func forEachPrimeUint64(ctx context.Context, lo, hi uint64, fn func(p uint64) bool) error {
+	lo = max(lo, 2)
+	if hi < lo {
+		return nil
+	}
+	base, err := SieveOfEratosthenesContext(ctx, int(isqrtUint64(hi)))
+	if err != nil {
+		return err
+	}
+	return sieveSegments(ctx, lo, hi, base, fn)
+}
+
+// sieveSegments crible [lo, hi] (2 <= lo <= hi) par segments avec base, les nombres premiers
+// jusqu'à √hi au moins, pour forEachPrimeUint64 et les appelants qui criblent plusieurs
+// intervalles avec la même base.
+func sieveSegments(ctx context.Context, lo, hi uint64, base []int, fn func(p uint64) bool) error {
+	marker := make([]bool, segmentSize)
+	for start := lo; ; start += segmentSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := hi // Calculé sans dépasser 2^64 - 1 pour le dernier segment.
+		if hi-start >= segmentSize {
+			end = start + segmentSize - 1
+		}
+		segment := marker[:end-start+1]
+		clear(segment)
+		for _, bp := range base {
+			p := uint64(bp)
+			if p*p > end {
+				break
+			}
+			// Position du premier multiple de p dans le segment, sans marquer p lui-même (calculée en
+			// décalage pour ne pas dépasser 2^64 - 1).
+			offset := (p - start%p) % p
+			if p*p > start {
+				offset = p*p - start
+			}
+			if offset <= end-start {
+				markMultiples(segment, int(offset), int(p))
+			}
+		}
+		for i, composite := range segment {
+			if !composite && !fn(start+uint64(i)) {
+				return nil
+			}
+		}
+		if end == hi {
+			return nil
+		}
+	}
+}
+
+// SieveRange retourne les nombres premiers de l'intervalle [lo, hi] par crible segmenté.
+func SieveRange(lo, hi int64) []int64 {
+	var primeList []int64
+	forEachPrime(lo, hi, func(p int64) bool {
+		primeList = append(primeList, p)
+		return true
+	})
+	return primeList
+}
+
+// Range retourne les nombres premiers de l'intervalle [a, b] sur toute la plage des uint64, sans
+// cribler depuis 2: seuls les nombres premiers jusqu'à √b sont calculés, puis [a, b] est criblé
+// par segments. Le coût dépend de la largeur de la fenêtre et de √b, pas de b: une fenêtre d'un
+// million d'entiers près de 10^18 demande les nombres premiers jusqu'à 10^9.
+func Range(a, b uint64) []uint64 {
+	var primeList []uint64
+	RangeFunc(context.Background(), a, b, func(p uint64) bool {
+		primeList = append(primeList, p)
+		return true
+	})
+	return primeList
+}
+
+// RangeFunc appelle fn pour chaque nombre premier de [a, b], dans l'ordre croissant, sans les
+// conserver, jusqu'à ce que fn retourne false. Il retourne ctx.Err() si ctx est annulé.
+func RangeFunc(ctx context.Context, a, b uint64, fn func(p uint64) bool) error {
+	return forEachPrimeUint64(ctx, a, b, fn)
+}
@@ -0,0 +1,82 @@
+/*
+ * Fichier: segmented_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du crible segmenté.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestSieveRange compare le crible segmenté au crible complet, sur plusieurs segments.
+func TestSieveRange(t *testing.T) {
+	full := SieveOfEratosthenes(3 * segmentSize)
+	testCases := []struct{ lo, hi int64 }{
+		{0, 100}, {2, 2}, {14, 16}, {90, 97}, {segmentSize - 10, 2*segmentSize + 10}, {1, 3 * segmentSize},
+	}
+
+	for _, tc := range testCases {
+		var expected []int64
+		for _, p := range full {
+			if int64(p) >= tc.lo && int64(p) <= tc.hi {
+				expected = append(expected, int64(p))
+			}
+		}
+		got := SieveRange(tc.lo, tc.hi)
+		if len(got) != len(expected) {
+			t.Errorf("SieveRange(%d, %d): %d premiers, attendu %d", tc.lo, tc.hi, len(got), len(expected))
+			continue
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Errorf("SieveRange(%d, %d)[%d] = %d, attendu %d", tc.lo, tc.hi, i, got[i], expected[i])
+				break
+			}
+		}
+	}
+
+	if got := SieveRange(100, 10); got != nil {
+		t.Errorf("SieveRange(100, 10) = %v, attendu vide", got)
+	}
+}
+
+// TestRange compare Range au test de Miller-Rabin déterministe sur des fenêtres haut placées, et
+// vérifie l'arrêt par annulation.
+func TestRange(t *testing.T) {
+	for _, tc := range []struct{ a, b uint64 }{
+		{0, 1}, {0, 100}, {1e12, 1e12 + 1000}, {1e14 - segmentSize/2, 1e14 + segmentSize/2},
+	} {
+		var expected []uint64
+		for n := tc.a; n <= tc.b; n++ {
+			if IsPrimeUint64(n) {
+				expected = append(expected, n)
+			}
+		}
+		if got := Range(tc.a, tc.b); !slices.Equal(got, expected) {
+			t.Errorf("Range(%d, %d): %d premiers, attendu %d", tc.a, tc.b, len(got), len(expected))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := RangeFunc(ctx, 0, 1e9, func(uint64) bool { return true }); !errors.Is(err, context.Canceled) {
+		t.Errorf("RangeFunc annulé: %v, attendu context.Canceled", err)
+	}
+}
+
+// TestIsqrtUint64 vérifie la racine entière jusqu'à 2^64 - 1.
+func TestIsqrtUint64(t *testing.T) {
+	for n, want := range map[uint64]uint64{0: 0, 1: 1, 15: 3, 16: 4, 1e18: 1e9, math.MaxUint64: math.MaxUint32, (math.MaxUint32 - 1) * (math.MaxUint32 - 1): math.MaxUint32 - 1} {
+		if got := isqrtUint64(n); got != want {
+			t.Errorf("isqrtUint64(%d) = %d, attendu %d", n, got, want)
+		}
+	}
+}
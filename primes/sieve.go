@@ -0,0 +1,71 @@
+/*
+ * Fichier: sieve.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 20 juin 2025
+ *
+ * Description:
+ * Génération des nombres premiers par le crible d'Eratosthène.
+ */
+package primes
+
+import "context"
+
+// sieveCheckInterval est le nombre d'entiers parcourus entre deux vérifications de l'annulation
+// lors de la collecte des nombres premiers.
+const sieveCheckInterval = 1 << 20
+
+// SieveOfEratosthenes génère tous les nombres premiers jusqu'à une limite donnée.
+// C'est une méthode beaucoup plus efficace que des tests de primalité individuels.
+func SieveOfEratosthenes(limit int) []int {
+	primes, _ := SieveOfEratosthenesContext(context.Background(), limit)
+	return primes
+}
+
+// SieveOfEratosthenesContext est SieveOfEratosthenes interrompu par l'annulation de ctx,
+// auquel cas il retourne ctx.Err().
+func SieveOfEratosthenesContext(ctx context.Context, limit int) ([]int, error) {
+	// Ajout d'une validation pour gérer les cas limites (négatifs, 0, 1)
+	// et prévenir les erreurs "index out of range".
+	if limit < 2 {
+		return nil, ctx.Err()
+	}
+	// Au-delà de quelques dizaines de millions, le tableau complet ne tient plus dans aucun
+	// cache: le crible par seaux (bucket.go) traite des segments de la taille du cache L1.
+	if limit >= bucketSieveThreshold {
+		return bucketSieve(ctx, limit, bucketSegmentSize)
+	}
+
+	// Initialise un tableau de booléens pour marquer les nombres.
+	// `primes[i]` sera `true` si `i` n'est pas premier.
+	primesMarker := make([]bool, limit+1)
+	primesMarker[0], primesMarker[1] = true, true // 0 et 1 ne sont pas premiers.
+
+	// Algorithme du crible.
+	for p := 2; p*p <= limit; p++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !primesMarker[p] { // Si p est premier...
+			markMultiples(primesMarker, p*p, p) // ...marquer tous ses multiples comme non premiers.
+		}
+	}
+
+	// Collectionner les nombres premiers.
+	// Pré-allouer la slice de nombres premiers avec une capacité estimée pour réduire les réallocations.
+	primes := make([]int, 0, EstimatePrimeCount(limit))
+
+	for p := 2; p <= limit; p++ {
+		if p%sieveCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if !primesMarker[p] {
+			primes = append(primes, p)
+		}
+	}
+	if len(primes) == 0 {
+		return nil, nil
+	}
+	return primes, nil
+}
@@ -0,0 +1,53 @@
+/*
+ * Fichier: sieve_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 20 juin 2025
+ *
+ * Description:
+ * Tests unitaires du crible d'Eratosthène.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestSieveOfEratosthenes valide la génération des nombres premiers.
+func TestSieveOfEratosthenes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    int
+		expected []int
+	}{
+		{"Limite de 30", 30, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+		{"Limite de 10", 10, []int{2, 3, 5, 7}},
+		{"Limite de 2", 2, []int{2}},
+		{"Limite de 1", 1, nil},
+		{"Limite de 0", 0, nil},
+		{"Limite négative", -10, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := SieveOfEratosthenes(tc.limit)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("SieveOfEratosthenes(%d) = %v, attendu %v", tc.limit, result, tc.expected)
+			}
+		})
+	}
+}
+
+// TestSieveOfEratosthenesContext valide le crible annulable.
+func TestSieveOfEratosthenesContext(t *testing.T) {
+	if got, err := SieveOfEratosthenesContext(context.Background(), 30); err != nil || !reflect.DeepEqual(got, SieveOfEratosthenes(30)) {
+		t.Errorf("SieveOfEratosthenesContext(30) = %v, %v", got, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got, err := SieveOfEratosthenesContext(ctx, 1_000_000); !errors.Is(err, context.Canceled) || got != nil {
+		t.Errorf("crible annulé = %d nombres, %v; attendu nil, context.Canceled", len(got), err)
+	}
+}
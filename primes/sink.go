@@ -0,0 +1,214 @@
+/*
+ * Fichier: sink.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Destinations des résultats (ResultSink) et tampon borné entre la recherche
+ * et une destination lente (BufferedSink): tant que le tampon n'est pas plein,
+ * la collecte continue pendant que la destination écrit; une fois plein,
+ * Write bloque, la collecte s'arrête, le canal des résultats se remplit et les
+ * workers attendent. Une destination lente freine donc la recherche au lieu de
+ * faire croître la mémoire. FanOutSink répartit les résultats entre plusieurs
+ * destinations indépendantes: l'échec de l'une n'arrête pas les autres.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultSinkBuffer est la capacité par défaut, en résultats, du tampon de BufferedSink.
+const DefaultSinkBuffer = 1024
+
+// ResultSink est une destination de résultats. Write n'est appelé que depuis une goroutine
+// à la fois; Flush pousse les résultats déjà écrits vers leur support (fichier, réseau...);
+// Close termine la sortie et libère la destination.
+type ResultSink interface {
+	Write(Result) error
+	Flush() error
+	Close() error
+}
+
+// sinkOp est une opération en attente dans le tampon: un résultat, ou une demande de vidage
+// (flush non nil) qui reçoit l'erreur de Flush.
+type sinkOp struct {
+	res   Result
+	flush chan error
+}
+
+// BufferedSink découple une destination de l'appelant par un tampon borné, écrit par une
+// goroutine dédiée. Après la première erreur de la destination, les résultats suivants sont
+// ignorés et Write, Flush et Close retournent cette erreur.
+type BufferedSink struct {
+	sink ResultSink
+	ops  chan sinkOp
+	done chan struct{}
+
+	mu  sync.Mutex
+	err error
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewBufferedSink démarre l'écriture vers sink à travers un tampon de capacity résultats
+// (DefaultSinkBuffer si capacity <= 0). Close doit être appelé pour arrêter la goroutine d'écriture.
+func NewBufferedSink(sink ResultSink, capacity int) *BufferedSink {
+	if capacity <= 0 {
+		capacity = DefaultSinkBuffer
+	}
+	b := &BufferedSink{sink: sink, ops: make(chan sinkOp, capacity), done: make(chan struct{})}
+	go b.drain()
+	return b
+}
+
+// drain écrit les opérations du tampon dans la destination, jusqu'à la fermeture du tampon.
+func (b *BufferedSink) drain() {
+	defer close(b.done)
+	for op := range b.ops {
+		if op.flush != nil {
+			err := b.Err()
+			if err == nil {
+				err = b.sink.Flush()
+				b.fail(err)
+			}
+			op.flush <- err
+			continue
+		}
+		if b.Err() == nil {
+			b.fail(b.sink.Write(op.res))
+		}
+	}
+}
+
+// fail mémorise la première erreur de la destination.
+func (b *BufferedSink) fail(err error) {
+	if err == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Err retourne la première erreur de la destination.
+func (b *BufferedSink) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+// Write place res dans le tampon; bloque tant que le tampon est plein. Retourne l'erreur d'une
+// écriture précédente, le cas échéant.
+func (b *BufferedSink) Write(res Result) error {
+	if err := b.Err(); err != nil {
+		return err
+	}
+	b.ops <- sinkOp{res: res}
+	return nil
+}
+
+// Flush attend l'écriture des résultats déjà placés dans le tampon puis vide la destination.
+func (b *BufferedSink) Flush() error {
+	reply := make(chan error, 1)
+	b.ops <- sinkOp{flush: reply}
+	return <-reply
+}
+
+// Close attend l'écriture des résultats en attente puis ferme la destination. Retourne la première
+// erreur de la destination, sinon celle de sa fermeture.
+func (b *BufferedSink) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.ops)
+		<-b.done
+		b.closeErr = b.sink.Close()
+		if err := b.Err(); err != nil {
+			b.closeErr = err
+		}
+	})
+	return b.closeErr
+}
+
+// FanOutSink écrit chaque résultat dans plusieurs destinations. Une destination en erreur est
+// écartée (OnError est alors appelé une fois pour elle) sans interrompre les autres; Write et Flush
+// n'échouent que lorsque toutes les destinations ont échoué. Comme pour un appel direct, la
+// destination la plus lente freine les autres: placer chacune derrière un BufferedSink.
+type FanOutSink struct {
+	sinks  []ResultSink
+	failed []error
+	// OnError reçoit l'indice et l'erreur d'une destination écartée, depuis la goroutine de l'appel
+	// qui a échoué (Write, Flush ou Close).
+	OnError func(i int, err error)
+}
+
+// NewFanOutSink répartit les résultats entre sinks.
+func NewFanOutSink(sinks ...ResultSink) *FanOutSink {
+	return &FanOutSink{sinks: sinks, failed: make([]error, len(sinks))}
+}
+
+// each applique op à chaque destination encore valide et écarte celles qui échouent. Retourne
+// une erreur, la jonction de celles des destinations, si aucune n'est plus valide.
+func (f *FanOutSink) each(op func(ResultSink) error) error {
+	for i, sink := range f.sinks {
+		if f.failed[i] != nil {
+			continue
+		}
+		if err := op(sink); err != nil {
+			f.failed[i] = err
+			if f.OnError != nil {
+				f.OnError(i, err)
+			}
+		}
+	}
+	for _, err := range f.failed {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Join(f.failed...)
+}
+
+// Write écrit res dans chaque destination valide.
+func (f *FanOutSink) Write(res Result) error {
+	return f.each(func(s ResultSink) error { return s.Write(res) })
+}
+
+// Flush vide chaque destination valide.
+func (f *FanOutSink) Flush() error { return f.each(ResultSink.Flush) }
+
+// Close ferme toutes les destinations, y compris celles déjà écartées (pour libérer leurs
+// ressources). Retourne une erreur si aucune destination n'a abouti.
+func (f *FanOutSink) Close() error {
+	var closeErrs []error
+	for i, sink := range f.sinks {
+		err := sink.Close()
+		if f.failed[i] == nil && err != nil {
+			f.failed[i] = err
+			if f.OnError != nil {
+				f.OnError(i, err)
+			}
+		}
+		closeErrs = append(closeErrs, f.failed[i])
+	}
+	for _, err := range closeErrs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Join(closeErrs...)
+}
+
+// SearchTo exécute la recherche décrite par opts (voir Search) en écrivant chaque résultat dans
+// sink, puis vide sink. Une erreur d'écriture arrête la recherche; sink n'est pas fermé.
+func SearchTo(ctx context.Context, opts Options, sink ResultSink) error {
+	err := Search(ctx, opts, sink.Write)
+	if ferr := sink.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+/*
+ * Fichier: sink_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du tampon borné et de la répartition des destinations de résultats.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// sliceSink mémorise les résultats; gate, s'il est non nil, retient chaque écriture jusqu'à
+// réception d'un jeton; failAt fait échouer l'écriture de rang failAt (à partir de 1).
+type sliceSink struct {
+	got             []Result
+	gate            chan struct{}
+	failAt          int
+	flushed, closed int
+}
+
+var errSinkFull = errors.New("destination pleine")
+
+func (s *sliceSink) Write(res Result) error {
+	if s.gate != nil {
+		<-s.gate
+	}
+	if len(s.got)+1 == s.failAt {
+		return errSinkFull
+	}
+	s.got = append(s.got, res)
+	return nil
+}
+
+func (s *sliceSink) Flush() error { s.flushed++; return nil }
+func (s *sliceSink) Close() error { s.closed++; return nil }
+
+// TestBufferedSinkBackpressure vérifie qu'une destination bloquée finit par bloquer Write, une
+// fois le tampon plein, puis que tout est écrit dans l'ordre.
+func TestBufferedSinkBackpressure(t *testing.T) {
+	dst := &sliceSink{gate: make(chan struct{})}
+	b := NewBufferedSink(dst, 2)
+
+	written := make(chan int, 10)
+	go func() {
+		for i := range 10 {
+			b.Write(Result{N: int64(i)})
+			written <- i
+		}
+		close(written)
+	}()
+	// Une écriture en cours dans la destination et deux dans le tampon: la quatrième bloque.
+	time.Sleep(50 * time.Millisecond)
+	if n := len(written); n > 4 {
+		t.Fatalf("%d écritures acceptées avec une destination bloquée et un tampon de 2", n)
+	}
+	close(dst.gate)
+	for range written {
+	}
+	if err := b.Flush(); err != nil || dst.flushed != 1 || len(dst.got) != 10 {
+		t.Fatalf("Flush() = %v, %d vidages, %d résultats écrits", err, dst.flushed, len(dst.got))
+	}
+	for i, res := range dst.got {
+		if res.N != int64(i) {
+			t.Fatalf("résultat %d: n=%d, ordre non conservé", i, res.N)
+		}
+	}
+	if err := b.Close(); err != nil || dst.closed != 1 || b.Close() != nil || dst.closed != 1 {
+		t.Errorf("Close() = %v, %d fermetures", err, dst.closed)
+	}
+}
+
+// TestBufferedSinkError vérifie que la première erreur de la destination remonte par Write,
+// Flush et Close, et que SearchTo arrête alors la recherche.
+func TestBufferedSinkError(t *testing.T) {
+	dst := &sliceSink{failAt: 3}
+	b := NewBufferedSink(dst, 1)
+	err := SearchTo(context.Background(), Options{Limit: 100, Workers: 2}, b)
+	if !errors.Is(err, errSinkFull) {
+		t.Errorf("SearchTo() = %v, attendu %v", err, errSinkFull)
+	}
+	if err := b.Close(); !errors.Is(err, errSinkFull) || len(dst.got) != 2 || dst.flushed != 0 {
+		t.Errorf("Close() = %v, %d résultats écrits, %d vidages", err, len(dst.got), dst.flushed)
+	}
+
+	all := &sliceSink{}
+	if err := SearchTo(context.Background(), Options{Limit: 100, Workers: 2}, all); err != nil || len(all.got) != 171 || all.flushed != 1 {
+		t.Errorf("SearchTo() = %v, %d résultats, %d vidages; attendu 171 résultats et un vidage", err, len(all.got), all.flushed)
+	}
+}
+
+// TestFanOutSink vérifie qu'une destination en échec est écartée et signalée une fois, que les
+// autres reçoivent tous les résultats, et que l'erreur ne remonte qu'une fois toutes en échec.
+func TestFanOutSink(t *testing.T) {
+	ok, broken := &sliceSink{}, &sliceSink{failAt: 2}
+	f := NewFanOutSink(broken, ok)
+	var failed []int
+	f.OnError = func(i int, err error) {
+		if !errors.Is(err, errSinkFull) {
+			t.Errorf("OnError(%d, %v), attendu %v", i, err, errSinkFull)
+		}
+		failed = append(failed, i)
+	}
+	if err := SearchTo(context.Background(), Options{Limit: 100, Workers: 2}, f); err != nil {
+		t.Fatalf("SearchTo() = %v avec une destination valide", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+	if len(failed) != 1 || failed[0] != 0 {
+		t.Errorf("destinations signalées %v, attendu [0]", failed)
+	}
+	if len(ok.got) != 171 || ok.flushed != 1 || ok.closed != 1 {
+		t.Errorf("destination valide: %d résultats, %d vidages, %d fermetures", len(ok.got), ok.flushed, ok.closed)
+	}
+	if len(broken.got) != 1 || broken.flushed != 0 || broken.closed != 1 {
+		t.Errorf("destination en échec: %d résultats, %d vidages, %d fermetures", len(broken.got), broken.flushed, broken.closed)
+	}
+
+	all := NewFanOutSink(&sliceSink{failAt: 1}, &sliceSink{failAt: 3})
+	if err := SearchTo(context.Background(), Options{Limit: 100, Workers: 2}, all); !errors.Is(err, errSinkFull) {
+		t.Errorf("SearchTo() = %v, attendu %v une fois toutes les destinations en échec", err, errSinkFull)
+	}
+	if err := all.Close(); !errors.Is(err, errSinkFull) {
+		t.Errorf("Close() = %v, attendu %v", err, errSinkFull)
+	}
+}
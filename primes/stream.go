@@ -0,0 +1,158 @@
+/*
+ * Fichier: stream.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Test d'un flux de candidats fournis par l'appelant (paires (p, q) évaluées
+ * par la forme, ou valeurs de n seules) par un pool de workers, au lieu de
+ * l'énumération de la grille. Les verdicts sont rendus dans l'ordre du flux,
+ * ce qui permet de composer la recherche avec d'autres outils qui génèrent
+ * les candidats.
+ */
+package primes
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// StreamCandidate est un candidat du flux: une paire (p, q) dont n est calculé par la forme
+// (Pair), ou une valeur de n seule.
+type StreamCandidate struct {
+	P, Q int64
+	N    int64 // Valeur testée; calculée par la forme pour une paire.
+	Pair bool
+}
+
+// StreamVerdict est le verdict d'un candidat du flux.
+type StreamVerdict struct {
+	StreamCandidate
+	Prime bool // n est premier (et accepté par le filtre éventuel); faux pour une paire écartée par la forme.
+}
+
+// streamItem est un candidat numéroté, pour rendre les verdicts dans l'ordre du flux.
+type streamItem struct {
+	seq int64
+	c   StreamCandidate
+}
+
+// streamVerdict est un verdict numéroté.
+type streamVerdict struct {
+	seq int64
+	v   StreamVerdict
+}
+
+// SearchStream teste les candidats reçus sur in jusqu'à sa fermeture, répartis entre opts.Workers
+// workers, et appelle fn pour chacun depuis la goroutine appelante, dans l'ordre de in. Le test de
+// primalité, la forme (ou la transformation) et le filtre des options s'appliquent; les autres
+// options de l'énumération (bornes, paires, Explain, Control, OnProgress) sont ignorées. Une paire
+// dont n déborde arrête le flux avec une erreur enveloppant ErrOverflow; une erreur de fn ou
+// l'annulation de ctx l'arrêtent de même. L'appelant doit fermer in, ou annuler ctx.
+func SearchStream(ctx context.Context, opts Options, in <-chan StreamCandidate, fn func(StreamVerdict) error) error {
+	opts, err := opts.validate()
+	if err != nil {
+		return err
+	}
+	candidate, isPrime := opts.candidateFunc(), opts.primalityFunc()
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	g, ctx := errgroup.WithContext(ctx)
+
+	items := make(chan streamItem, opts.Workers*opts.BatchSize)
+	verdicts := make(chan streamVerdict, opts.Workers*opts.BatchSize)
+	g.Go(func() error {
+		defer close(items)
+		var seq int64
+		for {
+			select {
+			case c, ok := <-in:
+				if !ok {
+					return nil
+				}
+				select {
+				case items <- streamItem{seq: seq, c: c}:
+					seq++
+				case <-ctx.Done():
+					return nil
+				}
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+	workers, wctx := errgroup.WithContext(ctx)
+	for range opts.Workers {
+		workers.Go(func() error {
+			for it := range items {
+				if wctx.Err() != nil {
+					continue
+				}
+				v := StreamVerdict{StreamCandidate: it.c}
+				ok := true
+				if it.c.Pair {
+					v.N, ok = candidate(it.c.P, it.c.Q)
+					overflow := ok && v.N < 0
+					if opts.Transform == nil {
+						overflow = pairOverflows(opts.Form, it.c.P, it.c.Q)
+						v.N = opts.Form.Eval(it.c.P, it.c.Q) // Renseigné aussi pour une paire écartée par la forme.
+					}
+					if overflow {
+						return fmt.Errorf("%w (forme %s, p=%d, q=%d)", ErrOverflow, opts.Form.Name(), it.c.P, it.c.Q)
+					}
+				}
+				v.Prime = ok && isPrime(v.N) && (opts.Filter == nil || opts.Filter.Accept(v.N))
+				select {
+				case verdicts <- streamVerdict{seq: it.seq, v: v}:
+				case <-wctx.Done():
+				}
+			}
+			return nil
+		})
+	}
+	g.Go(func() error {
+		defer close(verdicts)
+		return workers.Wait()
+	})
+
+	// Les verdicts arrivent dans le désordre: ceux qui devancent le suivant attendu sont mis de côté.
+	pending := map[int64]StreamVerdict{}
+	var next int64
+	var emitErr error
+	for sv := range verdicts {
+		if emitErr != nil || ctx.Err() != nil {
+			continue
+		}
+		pending[sv.seq] = sv.v
+		for v, ok := pending[next]; ok; v, ok = pending[next] {
+			delete(pending, next)
+			next++
+			if emitErr = fn(v); emitErr != nil {
+				cancel()
+				break
+			}
+		}
+	}
+	groupErr := g.Wait()
+	switch {
+	case emitErr != nil:
+		return emitErr
+	case groupErr != nil:
+		return groupErr
+	}
+	return parent.Err()
+}
+
+// pairOverflows indique si le candidat de la paire (p, q) dépasse int64: au-delà de la limite de la
+// forme, la valeur exacte est calculée si la forme le permet (BigForm).
+func pairOverflows(form Form, p, q int64) bool {
+	lf, ok := form.(LimitedForm)
+	if !ok || max(p, q) <= int64(lf.MaxLimit()) {
+		return false
+	}
+	bf, ok := form.(BigForm)
+	return !ok || !bf.EvalBig(p, q).IsInt64()
+}
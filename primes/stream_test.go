@@ -0,0 +1,69 @@
+/*
+ * Fichier: stream_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du test d'un flux de candidats par le pool de workers.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// feed retourne un canal fermé contenant les candidats donnés.
+func feed(candidates ...StreamCandidate) <-chan StreamCandidate {
+	in := make(chan StreamCandidate, len(candidates))
+	for _, c := range candidates {
+		in <- c
+	}
+	close(in)
+	return in
+}
+
+// TestSearchStream vérifie les verdicts, leur ordre et l'arrêt du flux sur erreur.
+func TestSearchStream(t *testing.T) {
+	var candidates []StreamCandidate
+	for n := range int64(2000) {
+		candidates = append(candidates, StreamCandidate{N: n})
+	}
+	for _, p := range []int64{2, 3, 5, 7, 11} {
+		for _, q := range []int64{2, 3, 5, 7} {
+			candidates = append(candidates, StreamCandidate{P: p, Q: q, Pair: true})
+		}
+	}
+	opts := Options{Workers: 4, BatchSize: 8}
+	i := 0
+	err := SearchStream(context.Background(), opts, feed(candidates...), func(v StreamVerdict) error {
+		c := candidates[i]
+		i++
+		want := c.N
+		if c.Pair {
+			want = c.P*c.P + 4*c.Q*c.Q
+		}
+		if v.P != c.P || v.Q != c.Q || v.N != want {
+			t.Fatalf("verdict %d: %+v, attendu le candidat %+v (n=%d)", i-1, v, c, want)
+		}
+		if prime := IsPrime(want) && (!c.Pair || c.P != 2); v.Prime != prime {
+			t.Errorf("verdict de %+v: %v, attendu %v", c, v.Prime, prime)
+		}
+		return nil
+	})
+	if err != nil || i != len(candidates) {
+		t.Fatalf("SearchStream: %v après %d verdicts sur %d", err, i, len(candidates))
+	}
+
+	big := int64(MaxLimit) * 2
+	if err := SearchStream(context.Background(), opts, feed(StreamCandidate{P: 3, Q: big, Pair: true}), func(StreamVerdict) error { return nil }); !errors.Is(err, ErrOverflow) {
+		t.Errorf("paire débordante: %v, attendu ErrOverflow", err)
+	}
+	stop := errors.New("arrêt")
+	seen := 0
+	err = SearchStream(context.Background(), opts, feed(candidates...), func(StreamVerdict) error { seen++; return stop })
+	if !errors.Is(err, stop) || seen != 1 {
+		t.Errorf("erreur du callback: %v après %d verdicts, attendu %v après 1", err, seen, stop)
+	}
+}
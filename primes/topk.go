@@ -0,0 +1,97 @@
+/*
+ * Fichier: topk.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Destination qui ne garde que les k premiers résultats d'un classement
+ * (TopSink): un tas de taille k retient les meilleurs au fil de la recherche,
+ * en mémoire O(k) quel que soit le nombre de résultats, puis les écrit dans
+ * l'ordre du classement à la fermeture.
+ */
+package primes
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// TopSink garde les k premiers résultats selon less (less(a, b): a est classé avant b) et ne les
+// écrit dans sa destination qu'à la fermeture, dans l'ordre du classement.
+type TopSink struct {
+	dst  ResultSink
+	k    int
+	heap topHeap
+	seen int64
+}
+
+// NewTopSink retourne une destination qui garde les k premiers résultats selon less et les écrit
+// dans dst à la fermeture (k >= 1).
+func NewTopSink(dst ResultSink, k int, less func(a, b Result) bool) *TopSink {
+	return &TopSink{dst: dst, k: max(k, 1), heap: topHeap{less: less}}
+}
+
+// Write retient res s'il fait partie des k premiers résultats reçus jusqu'ici.
+func (t *TopSink) Write(res Result) error {
+	t.seen++
+	switch {
+	case t.heap.Len() < t.k:
+		heap.Push(&t.heap, res)
+	case t.heap.less(res, t.heap.items[0]):
+		t.heap.items[0] = res
+		heap.Fix(&t.heap, 0)
+	}
+	return nil
+}
+
+// Flush ne fait rien: rien n'est écrit avant la fermeture.
+func (t *TopSink) Flush() error { return nil }
+
+// Close écrit les résultats retenus dans l'ordre du classement, puis ferme la destination.
+func (t *TopSink) Close() error {
+	var err error
+	for _, res := range t.Results() {
+		if err = t.dst.Write(res); err != nil {
+			break
+		}
+	}
+	if cerr := t.dst.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Results retourne les résultats retenus, dans l'ordre du classement.
+func (t *TopSink) Results() []Result {
+	results := slices.Clone(t.heap.items)
+	slices.SortFunc(results, func(a, b Result) int {
+		switch {
+		case t.heap.less(a, b):
+			return -1
+		case t.heap.less(b, a):
+			return 1
+		}
+		return 0
+	})
+	return results
+}
+
+// Seen retourne le nombre de résultats reçus, retenus ou non.
+func (t *TopSink) Seen() int64 { return t.seen }
+
+// topHeap est un tas dont la racine est le résultat retenu classé en dernier: c'est lui qu'un
+// meilleur résultat remplace.
+type topHeap struct {
+	items []Result
+	less  func(a, b Result) bool
+}
+
+func (h topHeap) Len() int           { return len(h.items) }
+func (h topHeap) Less(i, j int) bool { return h.less(h.items[j], h.items[i]) }
+func (h topHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *topHeap) Push(x any)        { h.items = append(h.items, x.(Result)) }
+func (h *topHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
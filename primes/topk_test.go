@@ -0,0 +1,43 @@
+/*
+ * Fichier: topk_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la destination des k premiers résultats d'un classement.
+ */
+package primes
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// TestTopSink compare les résultats retenus au tri complet des résultats de la recherche.
+func TestTopSink(t *testing.T) {
+	byN := func(a, b Result) bool { return a.N > b.N }
+	all := &sliceSink{}
+	if err := SearchTo(context.Background(), Options{Limit: 100, Workers: 2}, all); err != nil {
+		t.Fatal(err)
+	}
+	slices.SortFunc(all.got, func(a, b Result) int { return int(b.N - a.N) })
+
+	for _, k := range []int{1, 5, 171, 500} {
+		dst := &sliceSink{}
+		top := NewTopSink(dst, k, byN)
+		if err := SearchTo(context.Background(), Options{Limit: 100, Workers: 2}, top); err != nil {
+			t.Fatal(err)
+		}
+		if len(dst.got) != 0 {
+			t.Errorf("k=%d: %d résultats écrits avant la fermeture", k, len(dst.got))
+		}
+		if err := top.Close(); err != nil || dst.closed != 1 {
+			t.Fatalf("k=%d: Close() = %v, %d fermetures", k, err, dst.closed)
+		}
+		expected := all.got[:min(k, len(all.got))]
+		if !slices.Equal(dst.got, expected) || top.Seen() != int64(len(all.got)) {
+			t.Errorf("k=%d: %d résultats écrits (sur %d reçus), attendu les %d plus grands n", k, len(dst.got), top.Seen(), len(expected))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Fichier: uint64.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Primalité exacte sur toute la plage des uint64: Miller-Rabin déterministe
+ * avec des multiplications modulaires sur 128 bits (math/bits), sans
+ * math/big. Les candidats compris entre 2^63 et 2^64, hors de portée des
+ * tests sur int64, sont ainsi testés exactement (voir IsPrimeBig).
+ * Évaluation des formes sur 64 bits non signés (wideForm): au-delà de la
+ * limite int64 d'une forme, le moteur calcule et teste ces candidats sans
+ * math/big, et la politique OverflowUint64 repousse la limite jusqu'à ce que
+ * n atteigne 2^64.
+ */
+package primes
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// MaxLimitUint64 est la plus grande limite de la forme par défaut telle que n = p² + 4q² tienne
+// dans un uint64 pour tous p, q <= MaxLimitUint64 (5·MaxLimitUint64² < 2^64).
+const MaxLimitUint64 = 1920767766
+
+// wideForm est implémentée par les formes qui s'évaluent exactement sur 64 bits non signés:
+// les candidats compris entre 2^63 et 2^64 y sont calculés et testés sans math/big.
+type wideForm interface {
+	LimitedForm
+	BigForm
+	// evalUint64 retourne le candidat de la paire (p, q), ou false s'il dépasse 2^64 - 1.
+	evalUint64(p, q uint64) (uint64, bool)
+	// maxLimitUint64 est la plus grande limite telle que evalUint64 n'échoue pour aucun p, q <= limite.
+	maxLimitUint64() int
+}
+
+// mul64 retourne a·b, ou false si le produit dépasse 2^64 - 1.
+func mul64(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
+
+// add64 retourne a + b, ou false si la somme dépasse 2^64 - 1.
+func add64(a, b uint64) (uint64, bool) {
+	sum, carry := bits.Add64(a, b, 0)
+	return sum, carry == 0
+}
+
+// CheckFormLimitUint64 retourne une erreur enveloppant ErrOverflow si la limite dépasse celle de
+// la forme sur 64 bits non signés (politique OverflowUint64). Une forme qui ne s'évalue pas sur
+// uint64 reste bornée par sa limite int64 (voir CheckFormLimit).
+func CheckFormLimitUint64(f Form, limit int) error {
+	wf, ok := f.(wideForm)
+	if !ok {
+		return CheckFormLimit(f, limit)
+	}
+	if limit > wf.maxLimitUint64() {
+		return fmt.Errorf("%w (forme %s, limite %d > %d sur uint64)", ErrOverflow, f.Name(), limit, wf.maxLimitUint64())
+	}
+	return nil
+}
+
+// hasTwinUint64 indique si n-2 ou n+2 est premier, n premier supérieur à 2^63: le plus grand
+// nombre premier d'un uint64 étant 2^64 - 59, n+2 ne déborde pas.
+func hasTwinUint64(n uint64) bool {
+	return IsPrimeUint64(n-2) || IsPrimeUint64(n+2)
+}
+
+// powMod retourne base^exp mod m (m > 0) par exponentiation rapide sur 128 bits.
+func powMod(base, exp, m uint64) uint64 {
+	result := uint64(1) % m
+	base %= m
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+		exp >>= 1
+	}
+	return result
+}
+
+// IsPrimeUint64 indique si n est premier. Le test de Miller-Rabin avec les bases
+// millerRabinBases est déterministe pour tout n < 2^64.
+func IsPrimeUint64(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinBases {
+		if n%uint64(p) == 0 {
+			return n == uint64(p)
+		}
+	}
+	s := bits.TrailingZeros64(n - 1)
+	d := (n - 1) >> s
+	for _, a := range millerRabinBases {
+		x := powMod(uint64(a), d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		witness := true
+		for range s - 1 {
+			if x = mulMod(x, x, n); x == n-1 {
+				witness = false
+				break
+			}
+		}
+		if witness {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+/*
+ * Fichier: uint64_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la primalité exacte sur uint64, confrontée à IsPrime et à
+ * math/big au-delà de 2^63, de l'évaluation des formes sur uint64 et de la
+ * politique OverflowUint64.
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// TestIsPrimeUint64 compare IsPrimeUint64 à IsPrime sous 2^63 et à big.Int.ProbablyPrime au-delà.
+func TestIsPrimeUint64(t *testing.T) {
+	for n := range uint64(10000) {
+		if got, want := IsPrimeUint64(n), IsPrime(int64(n)); got != want {
+			t.Errorf("IsPrimeUint64(%d) = %v, attendu %v", n, got, want)
+		}
+	}
+	known := map[uint64]bool{
+		math.MaxUint64 - 58: true,  // Plus grand nombre premier sous 2^64.
+		math.MaxUint64:      false, // 3·5·17·257·641·65537·6700417.
+		1<<63 + 29:          true,
+		3825123056546413051: false, // Pseudo-premier fort pour les bases 2 à 23.
+	}
+	for n, want := range known {
+		if got := IsPrimeUint64(n); got != want {
+			t.Errorf("IsPrimeUint64(%d) = %v, attendu %v", n, got, want)
+		}
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	var b big.Int
+	for range 2000 {
+		n := rng.Uint64() | 1<<63 | 1
+		if got, want := IsPrimeUint64(n), b.SetUint64(n).ProbablyPrime(20); got != want {
+			t.Errorf("IsPrimeUint64(%d) = %v, attendu %v", n, got, want)
+		}
+	}
+}
+
+// TestEvalUint64 compare l'évaluation des formes prédéfinies sur uint64 à EvalBig, débordement
+// au-delà de 2^64 compris, et vérifie que leur limite uint64 est la plus grande possible.
+func TestEvalUint64(t *testing.T) {
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+	for _, f := range []Form{FormP2Plus4Q2, FormP2PlusQ4, FormX2Plus1} {
+		wf := f.(wideForm)
+		limit := int64(wf.maxLimitUint64())
+		for _, p := range []int64{2, 3, 97, 55108, limit, limit + 1, 3037000507} {
+			for _, q := range []int64{2, 5, 1009, limit, limit + 1} {
+				n, ok := wf.evalUint64(uint64(p), uint64(q))
+				exact := wf.EvalBig(p, q)
+				if fits := exact.Cmp(maxUint64) <= 0; ok != fits || ok && n != exact.Uint64() {
+					t.Errorf("%s.evalUint64(%d, %d) = %d, %v; attendu %v", f.Name(), p, q, n, ok, exact)
+				}
+			}
+		}
+		if _, ok := wf.evalUint64(uint64(limit), uint64(limit)); !ok {
+			t.Errorf("%s: (%d, %d) déborde à la limite uint64", f.Name(), limit, limit)
+		}
+		if f != FormX2Plus1 || math.MaxInt > math.MaxUint32 { // x^2+1 est borné par int en 32 bits.
+			if _, ok := wf.evalUint64(uint64(limit+1), uint64(limit+1)); ok {
+				t.Errorf("%s: (%d, %d) ne déborde pas au-delà de la limite uint64", f.Name(), limit+1, limit+1)
+			}
+		}
+	}
+	if err := CheckFormLimitUint64(FormP2Plus4Q2, MaxLimitUint64); err != nil {
+		t.Errorf("CheckFormLimitUint64(MaxLimitUint64) = %v", err)
+	}
+	if err := CheckFormLimitUint64(FormP2Plus4Q2, MaxLimitUint64+1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("CheckFormLimitUint64(MaxLimitUint64+1) = %v, attendu ErrOverflow", err)
+	}
+}
+
+// TestOverflowUint64 compare la politique OverflowUint64 à une énumération exacte sur math/big,
+// puis vérifie sa limite et le refus d'un candidat au-delà de 2^64.
+func TestOverflowUint64(t *testing.T) {
+	// Au-delà de MaxLimit, 4q^2 dépasse int64 mais p^2 + 4q^2 tient dans un uint64.
+	primeList := []int{3, 5, 7, 11, 13}
+	for x := int64(1_900_000_000); len(primeList) < 10; x++ {
+		if IsPrime(x) {
+			primeList = append(primeList, int(x))
+		}
+	}
+	want := map[Job]*big.Int{}
+	var overflowed int64
+	for _, p := range primeList {
+		for _, q := range primeList {
+			exact := FormP2Plus4Q2.(BigForm).EvalBig(int64(p), int64(q))
+			if !exact.IsInt64() {
+				overflowed++
+			}
+			if exact.ProbablyPrime(20) {
+				want[Job{p, q}] = exact
+			}
+		}
+	}
+
+	var last Progress
+	o, err := NewOptions(WithPrimes(primeList), WithWorkers(2), WithTwins(), WithOverflowPolicy(OverflowUint64), WithProgress(func(pr Progress) { last = pr }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []Result
+	if err := Search(context.Background(), o, func(r Result) error { got = append(got, r); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	bigs := 0
+	for _, r := range got {
+		exact, ok := want[Job{r.P, r.Q}]
+		n := big.NewInt(r.N)
+		if r.Big != nil {
+			bigs++
+			n = r.Big
+			if r.N != math.MaxInt64 {
+				t.Errorf("résultat au-delà d'int64 (p=%d, q=%d): N=%d", r.P, r.Q, r.N)
+			}
+			two := big.NewInt(2)
+			if twin := new(big.Int).Sub(n, two).ProbablyPrime(20) || new(big.Int).Add(n, two).ProbablyPrime(20); r.Twin != twin {
+				t.Errorf("(p=%d, q=%d): Twin=%v, attendu %v", r.P, r.Q, r.Twin, twin)
+			}
+		}
+		if !ok || n.Cmp(exact) != 0 {
+			t.Errorf("résultat inattendu (p=%d, q=%d, n=%v)", r.P, r.Q, n)
+		}
+	}
+	if len(got) != len(want) || bigs == 0 || last.Overflowed != overflowed {
+		t.Errorf("%d résultats dont %d au-delà d'int64, %d débordements; attendu %d résultats et %d débordements", len(got), bigs, last.Overflowed, len(want), overflowed)
+	}
+
+	if _, err := NewOptions(WithBounds(0, MaxLimitUint64+1), WithOverflowPolicy(OverflowUint64)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("limite MaxLimitUint64+1: %v, attendu ErrOverflow", err)
+	}
+	if _, err := NewOptions(WithBounds(0, 100), WithOverflowPolicy(OverflowUint64), WithTransform(func(p, q int64) (int64, bool) { return p + q, true })); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("uint64 avec transformation: %v, attendu ErrInvalidOptions", err)
+	}
+	// Une paire fournie au-delà de la limite uint64 arrête la recherche.
+	o, err = NewOptions(WithJobs(NewReaderSource(strings.NewReader("3,5\n4294967311,3\n"))), WithOverflowPolicy(OverflowUint64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Search(context.Background(), o, func(Result) error { return nil }); !errors.Is(err, ErrOverflow) {
+		t.Errorf("paire au-delà de 2^64: %v, attendu ErrOverflow", err)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Fichier: unrepresented.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Complément des résultats de la forme p^2 + 4q^2: les nombres premiers
+ * n ≡ 1 mod 4 jusqu'à une borne qui ne sont pas de cette forme avec p et q
+ * premiers. Tout nombre premier n ≡ 1 mod 4 s'écrit de façon unique
+ * n = x^2 + 4y^2 (Fermat); la machinerie de la recherche inverse (crible
+ * segmenté des n, puis ntheory.Cornacchia) retrouve cette décomposition, et
+ * n est « non représenté » si x ou y n'est pas premier.
+ */
+package primes
+
+import (
+	"context"
+	"slices"
+
+	"github.com/agbru/PrimeNumber/primes/ntheory"
+)
+
+// UnrepresentedPrime est un nombre premier n ≡ 1 mod 4 absent des résultats de la forme
+// p^2 + 4q^2: n = X^2 + 4Y^2 est son unique décomposition, où X ou Y n'est pas premier.
+type UnrepresentedPrime struct {
+	N      int64
+	X, Y   int64
+	XPrime bool // X est premier (Y ne l'est donc pas).
+	YPrime bool // Y est premier (X ne l'est donc pas).
+}
+
+// UnrepresentedReport compare les nombres premiers n ≡ 1 mod 4 jusqu'à Limit aux valeurs de la
+// forme p^2 + 4q^2.
+type UnrepresentedReport struct {
+	Limit         int64
+	Candidates    int64                // Nombres premiers n ≡ 1 mod 4 jusqu'à Limit.
+	Represented   int64                // Ceux de la forme p^2 + 4q^2 avec p et q premiers.
+	Smallest      []UnrepresentedPrime // Les plus petits non représentés, dans l'ordre croissant.
+	OnlyX, OnlyY  int64                // Non représentés dont seul X (resp. seul Y) n'est pas premier.
+	NeitherPrimes int64                // Non représentés dont ni X ni Y n'est premier.
+}
+
+// Unrepresented retourne le nombre de nombres premiers non représentés du rapport.
+func (r UnrepresentedReport) Unrepresented() int64 { return r.Candidates - r.Represented }
+
+// FindUnrepresented énumère les nombres premiers n ≡ 1 mod 4 jusqu'à limit et compte ceux qui ne
+// sont pas de la forme p^2 + 4q^2 avec p et q premiers; les keep plus petits sont conservés dans
+// Smallest (keep < 0: tous). L'annulation de ctx interrompt le crible et retourne ctx.Err().
+func FindUnrepresented(ctx context.Context, limit int64, keep int) (UnrepresentedReport, error) {
+	report := UnrepresentedReport{Limit: limit}
+	if limit < 5 {
+		return report, nil
+	}
+	// x et y ne dépassent pas la racine de n: le crible de base suffit à tester leur primalité.
+	base, err := SieveOfEratosthenesContext(ctx, int(isqrtUint64(uint64(limit))))
+	if err != nil {
+		return report, err
+	}
+	isBase := func(v int64) bool {
+		_, found := slices.BinarySearch(base, int(v))
+		return found
+	}
+	err = sieveSegments(ctx, 5, uint64(limit), base, func(n uint64) bool {
+		if n%4 != 1 {
+			return true
+		}
+		x, y, err := ntheory.Cornacchia(4, int64(n))
+		if err != nil {
+			return true // Impossible pour n premier ≡ 1 mod 4 (théorème de Fermat).
+		}
+		report.Candidates++
+		u := UnrepresentedPrime{N: int64(n), X: x, Y: y, XPrime: isBase(x), YPrime: isBase(y)}
+		switch {
+		case u.XPrime && u.YPrime:
+			report.Represented++
+			return true
+		case u.YPrime:
+			report.OnlyX++
+		case u.XPrime:
+			report.OnlyY++
+		default:
+			report.NeitherPrimes++
+		}
+		if keep < 0 || len(report.Smallest) < keep {
+			report.Smallest = append(report.Smallest, u)
+		}
+		return true
+	})
+	return report, err
+}
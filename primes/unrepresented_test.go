@@ -0,0 +1,79 @@
+/*
+ * Fichier: unrepresented_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du complément des résultats de la forme p^2 + 4q^2 (FindUnrepresented).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestFindUnrepresented compare le rapport au complément, parmi les nombres premiers ≡ 1 mod 4,
+// des résultats de la recherche directe.
+func TestFindUnrepresented(t *testing.T) {
+	const limit = 20000 // p <= 141 et q <= 70 pour n <= limit: la recherche jusqu'à 150 suffit.
+	represented := map[int64]bool{}
+	if err := Search(context.Background(), Options{Limit: 150, Workers: 2}, func(r Result) error {
+		represented[r.N] = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var want []int64
+	candidates := 0
+	for _, n := range SieveOfEratosthenes(limit) {
+		if n%4 == 1 {
+			candidates++
+			if !represented[int64(n)] {
+				want = append(want, int64(n))
+			}
+		}
+	}
+
+	report, err := FindUnrepresented(context.Background(), limit, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Candidates != int64(candidates) || report.Unrepresented() != int64(len(want)) || len(report.Smallest) != len(want) {
+		t.Fatalf("%d candidats, %d non représentés (%d listés), attendu %d et %d", report.Candidates, report.Unrepresented(), len(report.Smallest), candidates, len(want))
+	}
+	var onlyX, onlyY, neither int64
+	for i, u := range report.Smallest {
+		if u.N != want[i] || u.X*u.X+4*u.Y*u.Y != u.N {
+			t.Fatalf("non représenté %d = %+v, attendu n = %d", i, u, want[i])
+		}
+		if u.XPrime != IsPrimeMillerRabin64(u.X) || u.YPrime != IsPrimeMillerRabin64(u.Y) {
+			t.Errorf("%+v: primalité de x ou y erronée", u)
+		}
+		switch {
+		case u.YPrime:
+			onlyX++
+		case u.XPrime:
+			onlyY++
+		default:
+			neither++
+		}
+	}
+	if onlyX != report.OnlyX || onlyY != report.OnlyY || neither != report.NeitherPrimes {
+		t.Errorf("répartition %d/%d/%d, attendu %d/%d/%d", report.OnlyX, report.OnlyY, report.NeitherPrimes, onlyX, onlyY, neither)
+	}
+
+	small, _ := FindUnrepresented(context.Background(), limit, 3)
+	if len(small.Smallest) != 3 || small.Smallest[0].N != 5 || small.Smallest[2].N != 17 || small.Unrepresented() != report.Unrepresented() {
+		t.Errorf("FindUnrepresented(keep=3) = %+v", small.Smallest)
+	}
+	if empty, err := FindUnrepresented(context.Background(), 4, -1); err != nil || empty.Candidates != 0 {
+		t.Errorf("FindUnrepresented(4) = %+v, %v", empty, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FindUnrepresented(ctx, limit, -1); !errors.Is(err, context.Canceled) {
+		t.Errorf("contexte annulé: %v, attendu context.Canceled", err)
+	}
+}
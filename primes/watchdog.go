@@ -0,0 +1,180 @@
+/*
+ * Fichier: watchdog.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Chien de garde des workers (Options.StallTimeout): un superviseur relève
+ * périodiquement le candidat en cours de test de chaque worker et signale
+ * celui dont le test dure plus que le délai fixé (candidat pathologique sur
+ * math/big, test fourni qui boucle...), pour qu'une exécution de plusieurs
+ * jours ne reste pas bloquée en silence. Le test d'un candidat ne peut pas
+ * être interrompu: sur demande (Options.SkipStalled), le worker bloqué est
+ * abandonné à son calcul, dont le résultat sera ignoré, et un remplaçant
+ * reprend le reste de son lot puis la distribution des tâches.
+ */
+package primes
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Stall décrit un candidat dont le test dépasse Options.StallTimeout.
+type Stall struct {
+	Worker  int           // Numéro du worker bloqué.
+	P, Q    int           // Paire en cours de test.
+	Elapsed time.Duration // Durée du test à la détection (au moins StallTimeout).
+	// Skipped indique que la paire est abandonnée (Options.SkipStalled): son résultat est ignoré,
+	// elle est comptée dans Progress.Tested et Progress.Skipped, et un nouveau worker reprend la
+	// suite du lot.
+	Skipped bool
+}
+
+// errWorkerDetached arrête un worker abandonné par le chien de garde, une fois son test terminé.
+var errWorkerDetached = errors.New("primes: worker abandonné par le chien de garde")
+
+// watchSlot est l'état d'un worker relevé par le chien de garde. seq, impair pendant le test
+// d'un candidat, ne fait que croître: le chien de garde abandonne le candidat en l'avançant à sa
+// place, et le worker, qui ne peut plus l'avancer, s'arrête à la fin de son test.
+type watchSlot struct {
+	seq      atomic.Int64
+	batch    atomic.Pointer[[]Job] // Lot en cours.
+	index    atomic.Int64          // Position dans le lot du candidat en cours.
+	takeover chan []Job            // Reste du lot d'un worker abandonné, pour son remplaçant.
+}
+
+// setBatch annonce le lot que le worker commence; sans effet sur un slot nil (pas de chien de
+// garde), comme begin et end.
+func (s *watchSlot) setBatch(batch []Job) {
+	if s != nil {
+		s.batch.Store(&batch)
+	}
+}
+
+// begin annonce le test du candidat i du lot et retourne son numéro de séquence.
+func (s *watchSlot) begin(i int) int64 {
+	if s == nil {
+		return 0
+	}
+	s.index.Store(int64(i))
+	return s.seq.Add(1)
+}
+
+// end termine le test du candidat seq; false si le chien de garde l'a abandonné entre-temps.
+func (s *watchSlot) end(seq int64) bool {
+	return s == nil || s.seq.CompareAndSwap(seq, seq+1)
+}
+
+// watchdog supervise les workers d'une recherche.
+type watchdog struct {
+	timeout  time.Duration
+	skip     bool
+	onStall  func(Stall)
+	slots    []watchSlot
+	counters []workerCounters
+}
+
+// newWatchdog retourne le chien de garde des workers de counters, ou nil sans délai.
+func newWatchdog(opts Options, counters []workerCounters) *watchdog {
+	if opts.StallTimeout <= 0 {
+		return nil
+	}
+	wd := &watchdog{timeout: opts.StallTimeout, skip: opts.SkipStalled, onStall: opts.OnStall, slots: make([]watchSlot, len(counters)), counters: counters}
+	for i := range wd.slots {
+		wd.slots[i].takeover = make(chan []Job, 1)
+	}
+	return wd
+}
+
+// slot retourne l'état relevé du worker w (nil sans chien de garde).
+func (wd *watchdog) slot(w int) *watchSlot {
+	if wd == nil {
+		return nil
+	}
+	return &wd.slots[w]
+}
+
+// supervise exécute le worker w par run, dans une goroutine à part: si le chien de garde
+// l'abandonne, run est relancé sur le reste de son lot, et le worker bloqué finit son test
+// sans être attendu. Retourne l'erreur du dernier worker.
+func (wd *watchdog) supervise(w int, run func(pending []Job) error) error {
+	if wd == nil || !wd.skip {
+		return run(nil)
+	}
+	var pending []Job
+	for {
+		done := make(chan error, 1) // Reçoit aussi, sans lecteur, le retour d'un worker abandonné.
+		go func() { done <- run(pending) }()
+		select {
+		case err := <-done:
+			return err
+		case pending = <-wd.slots[w].takeover:
+		}
+	}
+}
+
+// watchTick retourne la période des relevés pour le délai timeout.
+func watchTick(timeout time.Duration) time.Duration {
+	return max(timeout/4, time.Millisecond)
+}
+
+// watchObservation est le dernier relevé d'un worker: le test seq, observé depuis since.
+type watchObservation struct {
+	seq      int64
+	since    time.Time // Zéro entre deux candidats.
+	reported bool      // Blocage déjà signalé.
+}
+
+// run relève les workers jusqu'à la fermeture de stop. Un candidat est signalé une fois, quand
+// le même test est observé depuis au moins le délai.
+func (wd *watchdog) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(watchTick(wd.timeout))
+	defer ticker.Stop()
+	seen := make([]watchObservation, len(wd.slots))
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			for w := range wd.slots {
+				if stall, ok := wd.check(w, &seen[w], now); ok && wd.onStall != nil {
+					wd.onStall(stall)
+				}
+			}
+		}
+	}
+}
+
+// check relève le worker w à l'instant now, d'après son relevé précédent obs, et retourne le
+// blocage à signaler. Avec SkipStalled, la paire bloquée est abandonnée et le reste de son lot
+// confié à un remplaçant.
+func (wd *watchdog) check(w int, obs *watchObservation, now time.Time) (Stall, bool) {
+	s := &wd.slots[w]
+	seq := s.seq.Load()
+	switch {
+	case seq%2 == 0: // Entre deux candidats: rien à surveiller.
+		*obs = watchObservation{seq: seq}
+		return Stall{}, false
+	case seq != obs.seq || obs.since.IsZero():
+		*obs = watchObservation{seq: seq, since: now}
+		return Stall{}, false
+	case obs.reported || now.Sub(obs.since) < wd.timeout:
+		return Stall{}, false
+	}
+	batch, index := *s.batch.Load(), s.index.Load()
+	if s.seq.Load() != seq || index >= int64(len(batch)) {
+		return Stall{}, false // Test terminé pendant le relevé.
+	}
+	obs.reported = true
+	job := batch[index]
+	stall := Stall{Worker: w, P: job.P, Q: job.Q, Elapsed: now.Sub(obs.since)}
+	if wd.skip && s.seq.CompareAndSwap(seq, seq+1) {
+		wd.counters[w].jobs.Add(index + 1) // Paires du lot déjà testées, et la paire abandonnée.
+		wd.counters[w].skipped.Add(1)
+		s.takeover <- batch[index+1:]
+		stall.Skipped = true
+	}
+	return stall, true
+}
@@ -0,0 +1,97 @@
+/*
+ * Fichier: watchdog_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du chien de garde des workers (Options.StallTimeout, SkipStalled).
+ */
+package primes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingTest retourne un test de primalité qui reste bloqué sur n = target jusqu'à la
+// fermeture de release, et le test exact pour les autres candidats.
+func blockingTest(target int64, release <-chan struct{}) func(int64) bool {
+	return func(n int64) bool {
+		if n == target {
+			<-release
+		}
+		return IsPrime(n)
+	}
+}
+
+// TestWatchdog vérifie le signalement d'un candidat bloqué, puis son abandon: les autres
+// résultats sont tous transmis et la paire abandonnée est comptée.
+func TestWatchdog(t *testing.T) {
+	primeList := SieveOfEratosthenes(200)
+	var want []Result
+	Search(context.Background(), Options{Primes: primeList, Workers: 2}, func(r Result) error { want = append(want, r); return nil })
+	stuck := want[len(want)/2]
+
+	// Sans abandon: le blocage est signalé une fois, la recherche attend la fin du test.
+	release := make(chan struct{})
+	var stalls []Stall
+	opts := Options{Primes: primeList, Workers: 2, PrimeTestFunc: blockingTest(stuck.N, release), StallTimeout: 20 * time.Millisecond,
+		OnStall: func(s Stall) {
+			stalls = append(stalls, s)
+			close(release)
+		}}
+	got := 0
+	if err := Search(context.Background(), opts, func(Result) error { got++; return nil }); err != nil || got != len(want) {
+		t.Fatalf("Search = %v, %d résultats, attendu %d", err, got, len(want))
+	}
+	if len(stalls) != 1 || stalls[0].P != stuck.P || stalls[0].Q != stuck.Q || stalls[0].Skipped || stalls[0].Elapsed < opts.StallTimeout {
+		t.Errorf("blocages %+v, attendu (%d, %d) signalé sans abandon", stalls, stuck.P, stuck.Q)
+	}
+
+	// Avec abandon: la recherche se termine sans attendre le test bloqué, son résultat en moins.
+	release = make(chan struct{})
+	defer close(release)
+	var mu sync.Mutex
+	stalls = nil
+	var final Progress
+	opts = Options{Primes: primeList, Workers: 2, BatchSize: 16, PrimeTestFunc: blockingTest(stuck.N, release), StallTimeout: 20 * time.Millisecond, SkipStalled: true,
+		OnStall: func(s Stall) {
+			mu.Lock()
+			defer mu.Unlock()
+			stalls = append(stalls, s)
+		},
+		OnProgress: func(p Progress) { final = p }}
+	seen := map[Job]bool{}
+	if err := Search(context.Background(), opts, func(r Result) error { seen[Job{r.P, r.Q}] = true; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range want {
+		if found := seen[Job{r.P, r.Q}]; found == (r.N == stuck.N) {
+			t.Errorf("résultat (%d, %d): transmis = %v", r.P, r.Q, found)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(stalls) != 1 || !stalls[0].Skipped || stalls[0].P != stuck.P {
+		t.Errorf("blocages %+v, attendu (%d, %d) abandonné", stalls, stuck.P, stuck.Q)
+	}
+	if final.Skipped != 1 || final.Tested != final.Total {
+		t.Errorf("progression %d/%d, %d abandonnée(s), attendu toutes les paires comptées dont 1 abandonnée", final.Tested, final.Total, final.Skipped)
+	}
+}
+
+// TestWatchdogOptions vérifie la validation des options du chien de garde.
+func TestWatchdogOptions(t *testing.T) {
+	if _, err := NewOptions(WithWatchdog(-time.Second, nil)); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("WithWatchdog(-1s): %v, attendu ErrInvalidOptions", err)
+	}
+	if _, err := NewOptions(WithSkipStalled()); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("WithSkipStalled sans délai: %v, attendu ErrInvalidOptions", err)
+	}
+	if o, err := NewOptions(WithWatchdog(time.Minute, func(Stall) {}), WithSkipStalled()); err != nil || o.StallTimeout != time.Minute || !o.SkipStalled || o.OnStall == nil {
+		t.Errorf("NewOptions = %+v, %v", o, err)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Fichier: primesfile.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Import d'une liste externe de nombres premiers (option -primes-file), par
+ * exemple une table précalculée par primesieve, utilisée à la place du crible.
+ * Deux encodages sont acceptés: texte (entiers séparés par des blancs ou des
+ * retours à la ligne, les lignes commençant par '#' étant ignorées, comme le
+ * manifeste de list-primes) et binaire (uint32 petit-boutistes, comme
+ * list-primes -format binary). La liste doit être strictement croissante; un échantillon
+ * régulièrement réparti peut en outre être soumis à un test de primalité.
+ */
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// primesFileFormats sont les encodages acceptés par -primes-file-format.
+var primesFileFormats = []string{"auto", "txt", "binary"}
+
+// detectPrimesFormat devine l'encodage d'un fichier: texte s'il ne contient que des chiffres, des
+// blancs et des lignes de commentaire commençant par '#'.
+func detectPrimesFormat(data []byte) string {
+	lineStart := true
+	for i := 0; i < len(data); i++ {
+		b := data[i]
+		if b == '#' && lineStart {
+			j := bytes.IndexByte(data[i:], '\n')
+			if j < 0 {
+				break
+			}
+			i += j
+			continue
+		}
+		if (b < '0' || b > '9') && b != ' ' && b != '\t' && b != '\n' && b != '\r' {
+			return "binary"
+		}
+		lineStart = b == '\n'
+	}
+	return "txt"
+}
+
+// parsePrimesText analyse une liste texte d'entiers séparés par des blancs, en ignorant les lignes
+// de commentaire commençant par '#'.
+func parsePrimesText(data []byte) ([]int, error) {
+	var primeList []int
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	prev := -1
+	for scanner.Scan() {
+		if bytes.HasPrefix(scanner.Bytes(), []byte("#")) {
+			continue
+		}
+		for _, field := range strings.Fields(scanner.Text()) {
+			p, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("entrée %d: %v", len(primeList), err)
+			}
+			if p <= prev {
+				return nil, fmt.Errorf("entrée %d (%d) non strictement croissante", len(primeList), p)
+			}
+			primeList = append(primeList, p)
+			prev = p
+		}
+	}
+	return primeList, scanner.Err()
+}
+
+// readPrimesFile lit et valide la liste de nombres premiers du fichier path. Les erreurs de lecture
+// enveloppent errIO; les contenus invalides enveloppent errInvalidInput.
+func readPrimesFile(path, format string) ([]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	if format == "auto" {
+		format = detectPrimesFormat(data)
+	}
+
+	var primeList []int
+	if format == "txt" {
+		primeList, err = parsePrimesText(data)
+	} else {
+		primeList, err = decodePrimes(data, math.MaxUint32)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errInvalidInput, path, err)
+	}
+	if len(primeList) > 0 && primeList[0] < 2 {
+		return nil, fmt.Errorf("%w: %s: %d n'est pas premier", errInvalidInput, path, primeList[0])
+	}
+	return primeList, nil
+}
+
+// spotCheckPrimes soumet au plus samples éléments régulièrement répartis de primeList (dont le
+// premier et le dernier) au test de Miller-Rabin et retourne une erreur au premier nombre composé.
+func spotCheckPrimes(primeList []int, samples int) error {
+	if len(primeList) == 0 || samples <= 0 {
+		return nil
+	}
+	samples = min(samples, len(primeList))
+	for i := range samples {
+		idx := 0
+		if samples > 1 {
+			idx = i * (len(primeList) - 1) / (samples - 1)
+		}
+		if p := primeList[idx]; !primes.IsPrimeMillerRabin64(int64(p)) {
+			return fmt.Errorf("%w: entrée %d (%d) n'est pas premier", errInvalidInput, idx, p)
+		}
+	}
+	return nil
+}
+
+// truncatePrimes retourne le préfixe de primeList borné par limit.
+func truncatePrimes(primeList []int, limit int) []int {
+	n := 0
+	for n < len(primeList) && primeList[n] <= limit {
+		n++
+	}
+	return primeList[:n]
+}
@@ -0,0 +1,99 @@
+/*
+ * Fichier: primesfile_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'import d'une liste externe de nombres premiers.
+ */
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestReadPrimesFile valide la lecture des encodages texte et binaire et le rejet des listes invalides.
+func TestReadPrimesFile(t *testing.T) {
+	dir := t.TempDir()
+	binaryData := []byte{2, 0, 0, 0, 3, 0, 0, 0, 5, 0, 0, 0, 7, 0, 0, 0}
+	testCases := []struct {
+		name     string
+		data     []byte
+		format   string
+		expected []int
+		wantErr  error
+	}{
+		{"Texte", []byte("2\n3\n5\n7\n"), "auto", []int{2, 3, 5, 7}, nil},
+		{"Texte sur une ligne", []byte("2 3\t5\r\n7"), "txt", []int{2, 3, 5, 7}, nil},
+		{"Texte avec manifeste", []byte("# run_id: X\n# args: [\"-o\" \"p.txt\"]\n2\n3\n5\n7\n# end: 2026\n"), "auto", []int{2, 3, 5, 7}, nil},
+		{"Binaire", binaryData, "auto", []int{2, 3, 5, 7}, nil},
+		{"Binaire forcé", binaryData, "binary", []int{2, 3, 5, 7}, nil},
+		{"Vide", nil, "auto", nil, nil},
+		{"Non croissante", []byte("2\n5\n3\n"), "txt", nil, errInvalidInput},
+		{"Doublon", []byte("2\n3\n3\n"), "txt", nil, errInvalidInput},
+		{"Non numérique", []byte("2\nsept\n"), "txt", nil, errInvalidInput},
+		{"Commence par 1", []byte("1\n2\n3\n"), "txt", nil, errInvalidInput},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "primes")
+			if err := os.WriteFile(path, tc.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := readPrimesFile(path, tc.format)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("erreur = %v, attendu %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tc.expected) {
+				t.Errorf("readPrimesFile = %v (%v), attendu %v", got, err, tc.expected)
+			}
+		})
+	}
+
+	if _, err := readPrimesFile(filepath.Join(dir, "absent"), "auto"); !errors.Is(err, errIO) {
+		t.Errorf("fichier absent: erreur = %v, attendu errIO", err)
+	}
+}
+
+// TestSpotCheckPrimes valide la détection d'un nombre composé dans l'échantillon.
+func TestSpotCheckPrimes(t *testing.T) {
+	if err := spotCheckPrimes([]int{2, 3, 5, 7, 11}, 100); err != nil {
+		t.Errorf("liste première rejetée: %v", err)
+	}
+	if err := spotCheckPrimes([]int{2, 3, 5, 7, 9}, 2); !errors.Is(err, errInvalidInput) {
+		t.Errorf("dernier élément composé non détecté: %v", err)
+	}
+	if err := spotCheckPrimes([]int{2, 3, 5, 7, 9}, 0); err != nil {
+		t.Errorf("vérification désactivée: %v", err)
+	}
+}
+
+// TestRunPrimesFile valide l'utilisation d'une liste importée de bout en bout.
+func TestRunPrimesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "primes.txt")
+	if err := os.WriteFile(path, []byte("2\n3\n5\n7\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"-primes-file", path}, io.Discard, io.Discard); err != nil {
+		t.Errorf("run avec -primes-file: %v", err)
+	}
+	if got := exitCode(run([]string{"-primes-file", path, "-primes-file-format", "csv"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("format inconnu -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+
+	if err := os.WriteFile(path, []byte("2\n3\n4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := exitCode(run([]string{"-primes-file", path}, io.Discard, io.Discard)); got != exitInvalidInput {
+		t.Errorf("liste avec un composé -> code %d, attendu %d", got, exitInvalidInput)
+	}
+}
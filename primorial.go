@@ -0,0 +1,78 @@
+/*
+ * Fichier: primorial.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande primorial: affiche une primorielle N# (ou une factorielle N!
+ * avec -factorial), ou liste les nombres premiers N# ± 1 (N! ± 1) jusqu'à une
+ * limite (primes.PrimorialPrimes, primes.FactorialPrimes).
+ */
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// runPrimorial implémente la sous-commande primorial.
+func runPrimorial(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("primorial", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	nPtr := fs.Int("n", -1, tr(msgFlagPrimorialN))
+	searchPtr := fs.Int("search", 0, tr(msgFlagPrimorialSearch))
+	factorialPtr := fs.Bool("factorial", false, tr(msgFlagPrimorialFactorial))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if (*nPtr >= 0) == (*searchPtr > 0) {
+		return fmt.Errorf("%w: primorial: -n N ou -search LIMITE attendu (l'un des deux)", errInvalidFlags)
+	}
+
+	symbol, value, search := "#", primes.Primorial, primes.PrimorialPrimes
+	if *factorialPtr {
+		symbol, value, search = "!", primes.Factorial, primes.FactorialPrimes
+	}
+	out := &errWriter{w: stdout}
+
+	if *nPtr >= 0 {
+		v := value(*nPtr)
+		digits := v.String()
+		fmt.Fprint(out, tr(msgPrimorialValue, strconv.Itoa(*nPtr)+symbol, digits, len(digits)))
+		return writeError(out)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	start := time.Now()
+	found, err := search(ctx, *searchPtr)
+	for _, s := range found {
+		sign := "+"
+		if s.Sign < 0 {
+			sign = "-"
+		}
+		fmt.Fprint(out, tr(msgPrimorialFound, strconv.Itoa(s.N)+symbol, sign, s.Digits))
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return errInterrupted
+		}
+		return err
+	}
+	fmt.Fprint(out, tr(msgPrimorialSummary, len(found), "N"+symbol, *searchPtr, time.Since(start).Round(time.Millisecond)))
+	return writeError(out)
+}
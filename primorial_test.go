@@ -0,0 +1,45 @@
+/*
+ * Fichier: primorial_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande primorial.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRunPrimorial valide la sous-commande de bout en bout.
+func TestRunPrimorial(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"-n", "13"}, []string{"13# = 30030 (5 chiffres)"}},
+		{[]string{"-n", "10", "-factorial"}, []string{"10! = 3628800 (7 chiffres)"}},
+		{[]string{"-search", "13"}, []string{"3# - 1 est premier (1 chiffres)", "11# + 1 est premier (4 chiffres)", "13# - 1 est premier (5 chiffres)", "9 nombre(s) premier(s) de la forme N# ± 1 pour N <= 13"}},
+		{[]string{"-search", "11", "-factorial"}, []string{"1! + 1 est premier", "11! + 1 est premier (8 chiffres)", "8 nombre(s) premier(s) de la forme N! ± 1"}},
+	}
+	for _, tc := range testCases {
+		var out bytes.Buffer
+		if err := run(append([]string{"primorial", "-lang", "fr"}, tc.args...), &out, io.Discard); err != nil {
+			t.Fatalf("primorial %v: %v", tc.args, err)
+		}
+		for _, want := range tc.expected {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("primorial %v: sortie = %q, attendu %q", tc.args, out.String(), want)
+			}
+		}
+	}
+	for _, args := range [][]string{{}, {"-n", "5", "-search", "10"}} {
+		if got := exitCode(run(append([]string{"primorial"}, args...), io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("primorial %v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
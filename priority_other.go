@@ -0,0 +1,16 @@
+//go:build !unix
+
+/*
+ * Fichier: priority_other.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sur les systèmes non Unix, -nice se limite au bridage CPU des workers.
+ */
+package main
+
+// lowerPriority est sans effet hors Unix: seul le bridage des workers s'applique.
+func lowerPriority() error {
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+/*
+ * Fichier: priority_unix.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Abaissement de la priorité du processus (option -nice) sur les systèmes Unix.
+ */
+package main
+
+import "syscall"
+
+// niceIncrement est la valeur de courtoisie (nice) appliquée par -nice.
+const niceIncrement = 10
+
+// lowerPriority abaisse la priorité d'ordonnancement du processus. Les threads créés
+// ensuite par le runtime héritent de cette priorité.
+func lowerPriority() error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, 0, niceIncrement)
+}
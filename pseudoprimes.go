@@ -0,0 +1,90 @@
+/*
+ * Fichier: pseudoprimes.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande pseudoprimes: écrit les nombres composés jusqu'à -limit qui
+ * passent le test de Fermat, d'Euler-Jacobi ou de Miller fort pour toutes les
+ * bases de -base (primes.Pseudoprimes), un par ligne. Le crible sert de
+ * vérité de référence. La liste alimente le corpus de régression des tests
+ * probabilistes et les travaux pratiques.
+ */
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// parseBases analyse la liste de bases de -base ("2" ou "2,3,5").
+func parseBases(s string) ([]int64, error) {
+	var bases []int64
+	for _, f := range strings.Split(s, ",") {
+		a, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+		if err != nil || a < 2 {
+			return nil, fmt.Errorf("%w: -base: base invalide %q (attendu un entier >= 2)", errInvalidFlags, f)
+		}
+		bases = append(bases, a)
+	}
+	return bases, nil
+}
+
+// runPseudoprimes implémente la sous-commande pseudoprimes.
+func runPseudoprimes(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("pseudoprimes", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	basePtr := fs.String("base", "2", tr(msgFlagPseudoBase))
+	limitPtr := fs.Int64("limit", 100_000, tr(msgFlagPseudoLimit))
+	testPtr := fs.String("test", "fermat", tr(msgFlagPseudoTest, strings.Join(primes.PseudoprimeTestNames(), ", ")))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgPseudoUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	bases, err := parseBases(*basePtr)
+	if err != nil {
+		return err
+	}
+	if *limitPtr < 0 || *limitPtr == 1<<63-1 {
+		return fmt.Errorf("%w: -limit=%d (attendu entre 0 et 2^63 - 2)", errInvalidFlags, *limitPtr)
+	}
+	if !slices.Contains(primes.PseudoprimeTestNames(), *testPtr) {
+		return fmt.Errorf("%w: -test=%q (attendu l'un de %v)", errInvalidFlags, *testPtr, primes.PseudoprimeTestNames())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	out := &errWriter{w: stdout}
+	bw := bufio.NewWriter(out)
+	count := 0
+	err = primes.Pseudoprimes(ctx, *limitPtr, *testPtr, bases, func(n int64) bool {
+		count++
+		_, werr := fmt.Fprintln(bw, n)
+		return werr == nil
+	})
+	bw.Flush()
+	fmt.Fprint(stderr, tr(msgPseudoSummary, countInt(count), *testPtr, *basePtr, countInt(*limitPtr)))
+	if err != nil {
+		return errInterrupted
+	}
+	return writeError(out)
+}
@@ -0,0 +1,39 @@
+/*
+ * Fichier: pseudoprimes_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande pseudoprimes.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestRunPseudoprimes valide la sous-commande de bout en bout.
+func TestRunPseudoprimes(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	var out, status bytes.Buffer
+	if err := run([]string{"pseudoprimes", "-test", "strong", "-base", "2", "-limit", "5000"}, &out, &status); err != nil {
+		t.Fatalf("pseudoprimes: %v", err)
+	}
+	if want := "2047\n3277\n4033\n4681\n"; out.String() != want {
+		t.Errorf("sortie = %q, attendu %q", out.String(), want)
+	}
+	if summary := tr(msgPseudoSummary, countInt(4), "strong", "2", countInt(5000)); !strings.Contains(status.String(), summary) {
+		t.Errorf("résumé %q absent de %q", summary, status.String())
+	}
+	for _, args := range [][]string{{"-base", "1"}, {"-base", "2,x"}, {"-test", "lucas"}, {"-limit", "-1"}} {
+		if got := exitCode(run(append([]string{"pseudoprimes"}, args...), io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("pseudoprimes %v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
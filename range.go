@@ -0,0 +1,96 @@
+/*
+ * Fichier: range.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande range: écrit les nombres premiers d'un intervalle [A, B]
+ * (primes.RangeFunc), un par ligne, sans cribler depuis 2. Seuls les nombres
+ * premiers jusqu'à √B sont calculés: une fenêtre étroite très haut placée
+ * (jusqu'à 2^64 - 1) reste accessible. -count n'écrit que leur nombre.
+ */
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// parseRangeArg analyse une borne de range: un entier jusqu'à 2^64 - 1, ou une notation
+// scientifique exacte comme pour count-primes ("1e12").
+func parseRangeArg(s string) (uint64, error) {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return n, nil
+	}
+	n, err := parseCountArg(s)
+	return uint64(n), err
+}
+
+// runRange implémente la sous-commande range.
+func runRange(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("range", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	countPtr := fs.Bool("count", false, tr(msgFlagRangeCount))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgRangeUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("%w: range: deux bornes A et B sont requises", errInvalidFlags)
+	}
+	var bounds [2]uint64
+	for i, arg := range fs.Args() {
+		v, err := parseRangeArg(arg)
+		if err != nil {
+			return fmt.Errorf("%w: range: %v", errInvalidFlags, err)
+		}
+		bounds[i] = v
+	}
+	a, b := bounds[0], bounds[1]
+	if a > b {
+		return fmt.Errorf("%w: range: A=%d supérieur à B=%d", errInvalidFlags, a, b)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	out := &errWriter{w: stdout}
+	bw := bufio.NewWriter(out)
+	var buf []byte
+	count := 0
+	err := primes.RangeFunc(ctx, a, b, func(p uint64) bool {
+		count++
+		if !*countPtr {
+			buf = strconv.AppendUint(buf[:0], p, 10)
+			if _, err := bw.Write(append(buf, '\n')); err != nil {
+				return false // Erreur d'écriture, retournée par writeError.
+			}
+		}
+		return true
+	})
+	if *countPtr {
+		fmt.Fprintln(bw, count)
+	}
+	bw.Flush()
+	if err != nil {
+		return errInterrupted
+	}
+	return writeError(out)
+}
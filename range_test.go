@@ -0,0 +1,38 @@
+/*
+ * Fichier: range_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande range.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// TestRunRange valide la sous-commande de bout en bout.
+func TestRunRange(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"range", "1e12", "1000000000100"}, &out, io.Discard); err != nil {
+		t.Fatalf("range: %v", err)
+	}
+	if want := "1000000000039\n1000000000061\n1000000000063\n1000000000091\n"; out.String() != want {
+		t.Errorf("sortie = %q, attendu %q", out.String(), want)
+	}
+	out.Reset()
+	if err := run([]string{"range", "-count", "0", "100"}, &out, io.Discard); err != nil {
+		t.Fatalf("range -count: %v", err)
+	}
+	if out.String() != "25\n" {
+		t.Errorf("range -count 0 100 = %q, attendu 25", out.String())
+	}
+	for _, args := range [][]string{{"range"}, {"range", "10"}, {"range", "20", "10"}, {"range", "-1", "10"}, {"range", "0", "18446744073709551616"}} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("run(%v) -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
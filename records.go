@@ -0,0 +1,118 @@
+/*
+ * Fichier: records.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Fichier de records (-records): conserve d'une exécution à l'autre le plus
+ * grand n trouvé pour chaque forme, avec sa paire (p, q), la date et les
+ * paramètres de l'exécution, et signale les nouveaux records.
+ */
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// recordsVersion est la version du format du fichier de records.
+const recordsVersion = 1
+
+// record est le plus grand n trouvé pour une forme.
+type record struct {
+	N      int64     `json:"n"`
+	P      int       `json:"p"`
+	Q      int       `json:"q"`
+	Time   time.Time `json:"time"`
+	Params runParams `json:"params"`
+}
+
+// recordsFile est le contenu du fichier de records, indexé par nom de forme.
+type recordsFile struct {
+	Version int               `json:"version"`
+	Records map[string]record `json:"records"`
+}
+
+// readRecords lit le fichier de records path. Un fichier absent donne un ensemble vide.
+func readRecords(path string) (recordsFile, error) {
+	rf := recordsFile{Version: recordsVersion, Records: map[string]record{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return rf, nil
+	}
+	if err != nil {
+		return rf, fmt.Errorf("%w: %v", errIO, err)
+	}
+	if err := json.Unmarshal(data, &rf); err != nil || rf.Version != recordsVersion {
+		return rf, fmt.Errorf("%w: fichier de records %s illisible (version %d, %v)", errInvalidInput, path, rf.Version, err)
+	}
+	if rf.Records == nil {
+		rf.Records = map[string]record{}
+	}
+	return rf, nil
+}
+
+// writeRecords écrit le fichier de records sous un nom temporaire puis le renomme, pour
+// qu'une exécution interrompue ne laisse jamais un fichier partiel.
+func writeRecords(path string, rf recordsFile) error {
+	data, err := json.MarshalIndent(rf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+// writeFileAtomic écrit data dans un fichier temporaire du répertoire de path puis le renomme en
+// path: le fichier est soit l'ancien, soit le nouveau, jamais partiel.
+func writeFileAtomic(path string, data []byte) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// updateRecord compare candidate au record de la forme dans le fichier path et l'y enregistre
+// s'il le bat. Retourne le record précédent (ok = false s'il n'y en avait pas) et beaten = true
+// si le fichier a été mis à jour. Le fichier est verrouillé le temps de la mise à jour: deux
+// exécutions qui terminent ensemble se succèdent au lieu d'écraser le record l'une de l'autre.
+func updateRecord(path, form string, candidate record) (previous record, ok, beaten bool, err error) {
+	lock, err := lockFile(path, true)
+	if err != nil {
+		return record{}, false, false, fmt.Errorf("%w: %v", errIO, err)
+	}
+	defer lock.Unlock()
+	rf, err := readRecords(path)
+	if err != nil {
+		return record{}, false, false, err
+	}
+	previous, ok = rf.Records[form]
+	if ok && candidate.N <= previous.N {
+		return previous, ok, false, nil
+	}
+	rf.Records[form] = candidate
+	if err := writeRecords(path, rf); err != nil {
+		return previous, ok, false, fmt.Errorf("%w: %v", errIO, err)
+	}
+	return previous, ok, true, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * Fichier: records_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du fichier de records.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestUpdateRecord valide la création, le remplacement et la conservation d'un record.
+func TestUpdateRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.json")
+	steps := []struct {
+		n        int64
+		beaten   bool
+		previous int64
+	}{
+		{100, true, 0},   // Premier record de la forme.
+		{50, false, 100}, // Plus petit: conservé.
+		{100, false, 100},
+		{200, true, 100},
+	}
+	for _, step := range steps {
+		prev, _, beaten, err := updateRecord(path, "p^2+4q^2", record{N: step.n, P: 1, Q: 2})
+		if err != nil {
+			t.Fatalf("updateRecord(%d): %v", step.n, err)
+		}
+		if beaten != step.beaten || prev.N != step.previous {
+			t.Errorf("updateRecord(%d) = précédent %d, battu %v; attendu %d, %v", step.n, prev.N, beaten, step.previous, step.beaten)
+		}
+	}
+
+	// Les formes sont indépendantes.
+	if _, ok, beaten, err := updateRecord(path, "x^2+1", record{N: 5}); err != nil || ok || !beaten {
+		t.Errorf("autre forme: ok=%v, battu=%v, %v", ok, beaten, err)
+	}
+	rf, err := readRecords(path)
+	if err != nil || rf.Records["p^2+4q^2"].N != 200 || rf.Records["x^2+1"].N != 5 {
+		t.Errorf("readRecords = %+v, %v", rf, err)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readRecords(path); exitCode(err) != exitInvalidInput {
+		t.Errorf("fichier corrompu: code %d, attendu %d", exitCode(err), exitInvalidInput)
+	}
+}
+
+// TestRunRecords valide le signalement d'un nouveau record de bout en bout.
+func TestRunRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.json")
+	args := []string{"-limit", "30", "-workers", "2", "-records", path, "-lang", "fr"}
+
+	var out bytes.Buffer
+	if err := run(args, &out, io.Discard); err != nil {
+		t.Fatalf("première exécution: %v", err)
+	}
+	if !strings.Contains(out.String(), "Nouveau record") {
+		t.Errorf("première exécution: record non signalé:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run(args, &out, io.Discard); err != nil {
+		t.Fatalf("seconde exécution: %v", err)
+	}
+	if strings.Contains(out.String(), "Nouveau record") {
+		t.Errorf("seconde exécution identique: record signalé à tort:\n%s", out.String())
+	}
+}
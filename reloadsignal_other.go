@@ -0,0 +1,17 @@
+//go:build !unix
+
+/*
+ * Fichier: reloadsignal_other.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Hors Unix, SIGHUP n'existe pas: le fichier d'options (-config) n'est lu
+ * qu'au démarrage.
+ */
+package main
+
+// notifyReload est sans effet hors Unix.
+func notifyReload(reload func()) (stop func()) {
+	return func() {}
+}
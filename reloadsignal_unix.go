@@ -0,0 +1,42 @@
+//go:build unix
+
+/*
+ * Fichier: reloadsignal_unix.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Rechargement du fichier d'options (-config) par SIGHUP sous Unix, comme
+ * pour la plupart des démons.
+ */
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReload appelle reload à chaque SIGHUP. La fonction retournée cesse l'écoute et attend la
+// fin de la goroutine d'écoute.
+func notifyReload(reload func()) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-sigCh:
+				reload()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+/*
+ * Fichier: reloadsignal_unix_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du rechargement du fichier d'options par SIGHUP.
+ */
+package main
+
+import (
+	"sync/atomic"
+	"syscall"
+	"testing"
+)
+
+// TestReloadSignal valide que SIGHUP déclenche le rechargement.
+func TestReloadSignal(t *testing.T) {
+	var reloads atomic.Int32
+	stop := notifyReload(func() { reloads.Add(1) })
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGHUP)
+	if !waitFor(func() bool { return reloads.Load() == 1 }) {
+		t.Fatalf("SIGHUP n'a pas déclenché le rechargement")
+	}
+}
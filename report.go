@@ -0,0 +1,215 @@
+/*
+ * Fichier: report.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Rapport HTML autonome (option -report): manifeste de l'exécution,
+ * statistiques du résumé, deux graphiques en SVG intégré (nombre cumulé de
+ * résultats N(x) et répartition des résultats selon p) et tableau paginé des
+ * résultats, dans un seul fichier à partager sans la CLI. Les graphiques sont
+ * calculés au fil de la recherche sur des tranches fixes; le tableau garde les
+ * reportMaxRows plus petits n.
+ */
+package main
+
+import (
+	"bufio"
+	"cmp"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+//go:embed report.html
+var reportHTML string
+
+// reportTemplate est le gabarit du rapport.
+var reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+
+const (
+	reportMaxRows  = 10000 // Résultats conservés pour le tableau.
+	reportPageSize = 100   // Lignes par page du tableau.
+	reportBins     = 50    // Tranches des graphiques.
+	reportWidth    = 600   // Dimensions des graphiques, en pixels.
+	reportHeight   = 240
+)
+
+// reportBuilder accumule les données du rapport au fil des résultats.
+type reportBuilder struct {
+	limit int
+	rows  []primes.Result
+	count int
+	twins int
+	byMax []int64 // Résultats par tranche de max(p, q).
+	byP   []int64 // Résultats par tranche de p.
+}
+
+// newReportBuilder prépare un rapport pour une recherche de p, q <= limit.
+func newReportBuilder(limit int) *reportBuilder {
+	return &reportBuilder{limit: max(limit, 1), byMax: make([]int64, reportBins), byP: make([]int64, reportBins)}
+}
+
+// bin retourne la tranche de x dans [0, limit].
+func (b *reportBuilder) bin(x int) int {
+	return min(reportBins-1, int(int64(x)*reportBins/int64(b.limit+1)))
+}
+
+// sortRows trie les résultats conservés par n et ne garde que les reportMaxRows premiers.
+func (b *reportBuilder) sortRows() {
+	slices.SortFunc(b.rows, func(x, y primes.Result) int { return cmp.Or(cmp.Compare(x.N, y.N), cmp.Compare(x.P, y.P)) })
+	b.rows = b.rows[:min(len(b.rows), reportMaxRows)]
+}
+
+// add compte un résultat.
+func (b *reportBuilder) add(res primes.Result) {
+	b.count++
+	if res.Twin {
+		b.twins++
+	}
+	b.byMax[b.bin(max(res.P, res.Q))]++
+	b.byP[b.bin(res.P)]++
+	b.rows = append(b.rows, res)
+	if len(b.rows) >= 2*reportMaxRows {
+		b.sortRows()
+	}
+}
+
+// reportSummary regroupe les chiffres du résumé de l'exécution.
+type reportSummary struct {
+	Form        string
+	Pairs       primes.PairMode
+	PrimeCount  int
+	PairsTested int64
+	Duration    time.Duration
+	Twins       bool
+	Interrupted bool
+}
+
+// reportField est une ligne clé, valeur du rapport.
+type reportField struct{ Key, Value string }
+
+// reportBar est une barre d'un histogramme, en coordonnées SVG.
+type reportBar struct {
+	X, Y, W, H float64
+	Title      string
+}
+
+// reportChart est un graphique SVG: une courbe (Line, points "x,y") ou des barres.
+type reportChart struct {
+	Title         string
+	Width, Height int
+	Line          string
+	Bars          []reportBar
+	XMax, YMax    string
+}
+
+// reportData est le modèle du gabarit.
+type reportData struct {
+	Form      string
+	Manifest  []manifestEntry
+	Summary   []reportField
+	Charts    []reportChart
+	Rows      []primes.Result
+	Total     int
+	Truncated bool
+	PageSize  int
+}
+
+// cumulativeChart trace la courbe du nombre cumulé de résultats par tranche.
+func (b *reportBuilder) cumulativeChart(title string, bins []int64) reportChart {
+	chart := reportChart{Title: title, Width: reportWidth, Height: reportHeight, XMax: fmt.Sprint(b.limit), YMax: fmt.Sprint(b.count)}
+	points := []string{fmt.Sprintf("0,%d", reportHeight)}
+	var total int64
+	for i, n := range bins {
+		total += n
+		x := float64(i+1) * reportWidth / reportBins
+		y := reportHeight - float64(total)*reportHeight/float64(max(b.count, 1))
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	chart.Line = strings.Join(points, " ")
+	return chart
+}
+
+// histogramChart trace l'histogramme des résultats par tranche.
+func (b *reportBuilder) histogramChart(title string, bins []int64) reportChart {
+	top := max(slices.Max(bins), 1)
+	chart := reportChart{Title: title, Width: reportWidth, Height: reportHeight, XMax: fmt.Sprint(b.limit), YMax: fmt.Sprint(top)}
+	w := float64(reportWidth) / reportBins
+	for i, n := range bins {
+		h := float64(n) * reportHeight / float64(top)
+		lo, hi := int64(i)*int64(b.limit+1)/reportBins, int64(i+1)*int64(b.limit+1)/reportBins-1
+		chart.Bars = append(chart.Bars, reportBar{X: float64(i) * w, Y: reportHeight - h, W: w - 1, H: h, Title: fmt.Sprintf("[%d, %d]: %d", lo, hi, n)})
+	}
+	return chart
+}
+
+// write écrit le rapport HTML; manifest porte l'heure de fin.
+func (b *reportBuilder) write(w io.Writer, manifest *runManifest, s reportSummary) error {
+	b.sortRows()
+	status := "complète"
+	if s.Interrupted {
+		status = "interrompue (résultats partiels)"
+	}
+	summary := []reportField{
+		{"Statut", status},
+		{"Forme", s.Form},
+		{"Paires", s.Pairs.String()},
+		{"Nombres premiers p, q", fmt.Sprint(s.PrimeCount)},
+		{"Paires testées", fmt.Sprint(s.PairsTested)},
+		{"Résultats", fmt.Sprint(b.count)},
+	}
+	if s.PairsTested > 0 {
+		summary = append(summary, reportField{"Densité (résultats / paires testées)", fmt.Sprintf("%.6g", float64(b.count)/float64(s.PairsTested))})
+	}
+	if s.Twins {
+		summary = append(summary, reportField{"Jumeaux", fmt.Sprint(b.twins)})
+	}
+	summary = append(summary,
+		reportField{"Durée de la recherche", s.Duration.Round(time.Millisecond).String()},
+		reportField{"Débit", fmt.Sprintf("%.0f paires/s", throughput(s.PairsTested, s.Duration))})
+
+	manifest.finish()
+	entries := append(manifest.entries(), manifestEntry{"end", manifest.End.Format(time.RFC3339Nano)})
+	data := reportData{
+		Form:     s.Form,
+		Manifest: entries,
+		Summary:  summary,
+		Charts: []reportChart{
+			b.cumulativeChart("Nombre cumulé de résultats N(x), paires p, q ≤ x", b.byMax),
+			b.histogramChart("Résultats par tranche de p", b.byP),
+		},
+		Rows:      b.rows,
+		Total:     b.count,
+		Truncated: b.count > len(b.rows),
+		PageSize:  reportPageSize,
+	}
+	return reportTemplate.Execute(w, data)
+}
+
+// writeReport écrit le rapport dans le fichier path.
+func writeReport(path string, b *reportBuilder, manifest *runManifest, s reportSummary) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	bw := bufio.NewWriter(f)
+	err = b.write(bw, manifest, s)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
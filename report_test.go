@@ -0,0 +1,71 @@
+/*
+ * Fichier: report_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du rapport HTML (option -report).
+ */
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestRunReport valide le rapport d'une recherche de bout en bout.
+func TestRunReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rapport.html")
+	if err := run([]string{"-limit", "100", "-workers", "2", "-report", path, "-lang", "fr"}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("run -report: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+	for _, want := range []string{
+		"<td>Résultats</td><td>171</td>",
+		"<td>param.report</td>",
+		"<td>end</td>",
+		`<polyline class="curve"`,
+		"<td>41</td></tr>", // Plus petit n: 5² + 4·2².
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("rapport sans %q", want)
+		}
+	}
+	if got := strings.Count(html, `<rect class="bar"`); got != reportBins {
+		t.Errorf("%d barres, attendu %d", got, reportBins)
+	}
+	if got := strings.Count(html, "<tr><td>"); got < 171 {
+		t.Errorf("%d lignes, attendu au moins les 171 résultats", got)
+	}
+}
+
+// TestReportTruncation vérifie que le tableau garde les reportMaxRows plus petits n, quel que soit l'ordre d'arrivée.
+func TestReportTruncation(t *testing.T) {
+	b := newReportBuilder(1000)
+	for i := reportMaxRows + 500; i > 0; i-- {
+		b.add(primes.Result{P: 3, Q: 5, N: int64(i)})
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	if err := b.write(&out, newManifest("test", nil, fs, nil, time.Now()), reportSummary{Form: "f"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.rows) != reportMaxRows || b.rows[0].N != 1 || b.rows[reportMaxRows-1].N != reportMaxRows {
+		t.Errorf("%d lignes de %d à %d, attendu %d lignes de 1 à %d", len(b.rows), b.rows[0].N, b.rows[len(b.rows)-1].N, reportMaxRows, reportMaxRows)
+	}
+	if !strings.Contains(out.String(), "les 10000 plus petits n") {
+		t.Error("troncature non signalée")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Fichier: residues.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Analyse des classes de résidus (option -residues): les n trouvés sont
+ * répartis selon n mod 4, 8 et 24, et les p, q selon leur classe mod 4. La
+ * forme quadratique impose une structure (par exemple n = p² + 4q² impair est
+ * toujours ≡ 1 mod 4) que ce tableau permet de vérifier empiriquement.
+ */
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// residueRow est une ligne du tableau: la répartition d'une valeur selon un module.
+type residueRow struct {
+	label  string
+	counts []int64 // counts[r]: nombre de valeurs ≡ r.
+}
+
+// residueCounts compte les résultats par classe de résidus.
+type residueCounts struct {
+	rows  []residueRow
+	total int64
+}
+
+// newResidueCounts prépare les lignes n mod 4, n mod 8, n mod 24, p mod 4 et q mod 4.
+func newResidueCounts() *residueCounts {
+	return &residueCounts{rows: []residueRow{
+		{label: "n mod 4", counts: make([]int64, 4)},
+		{label: "n mod 8", counts: make([]int64, 8)},
+		{label: "n mod 24", counts: make([]int64, 24)},
+		{label: "p mod 4", counts: make([]int64, 4)},
+		{label: "q mod 4", counts: make([]int64, 4)},
+	}}
+}
+
+// add compte un résultat dans chaque ligne.
+func (c *residueCounts) add(res primes.Result) {
+	values := []int64{res.N, res.N, res.N, int64(res.P), int64(res.Q)}
+	for i, row := range c.rows {
+		row.counts[values[i]%int64(len(row.counts))]++
+	}
+	c.total++
+}
+
+// write écrit une ligne par module, avec les seules classes non vides et leur proportion.
+func (c *residueCounts) write(w io.Writer) {
+	for _, row := range c.rows {
+		var cells []string
+		for r, n := range row.counts {
+			if n > 0 {
+				cells = append(cells, fmt.Sprintf("%d: %d (%.1f %%)", r, n, 100*float64(n)/float64(c.total)))
+			}
+		}
+		if len(cells) == 0 {
+			cells = []string{"-"}
+		}
+		fmt.Fprintf(w, "%-9s | %s\n", row.label, strings.Join(cells, " | "))
+	}
+}
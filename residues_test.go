@@ -0,0 +1,48 @@
+/*
+ * Fichier: residues_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'analyse des classes de résidus (option -residues).
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestResidueCounts valide le tableau sur quelques résultats connus.
+func TestResidueCounts(t *testing.T) {
+	c := newResidueCounts()
+	c.add(primes.Result{P: 5, Q: 2, N: 41})  // 41 ≡ 1 mod 8, ≡ 17 mod 24.
+	c.add(primes.Result{P: 3, Q: 5, N: 109}) // 109 ≡ 5 mod 8, ≡ 13 mod 24.
+	var out bytes.Buffer
+	c.write(&out)
+	expected := "n mod 4   | 1: 2 (100.0 %)\n" +
+		"n mod 8   | 1: 1 (50.0 %) | 5: 1 (50.0 %)\n" +
+		"n mod 24  | 13: 1 (50.0 %) | 17: 1 (50.0 %)\n" +
+		"p mod 4   | 1: 1 (50.0 %) | 3: 1 (50.0 %)\n" +
+		"q mod 4   | 1: 1 (50.0 %) | 2: 1 (50.0 %)\n"
+	if out.String() != expected {
+		t.Errorf("tableau:\n%s\nattendu:\n%s", out.String(), expected)
+	}
+}
+
+// TestRunResidues vérifie que la forme par défaut ne produit que des n ≡ 1 mod 4.
+func TestRunResidues(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-limit", "100", "-residues", "-workers", "2", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("run -residues: %v", err)
+	}
+	for _, want := range []string{"Classes de résidus des résultats:\n", "n mod 4   | 1: 171 (100.0 %)\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("sortie sans %q:\n%s", want, out.String())
+		}
+	}
+}
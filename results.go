@@ -0,0 +1,348 @@
+/*
+ * Fichier: results.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Écriture des résultats de la recherche au format choisi par -format: le
+ * tableau texte habituel, ou un document JSON {"results": [...], "manifest":
+ * {...}} écrit au fil de l'eau (le manifeste vient en dernier, une fois l'heure
+ * de fin connue), ou du NDJSON (un objet JSON par ligne) pour les outils qui
+ * lisent un flux. Le format JSON est celui que relit la sous-commande diff.
+ * Le manifeste du tableau omet la valeur de chaque option (param.*), sauf avec
+ * -manifest-verbose; celui du NDJSON tient sur une ligne {"manifest": {...}},
+ * en tête du flux puis complétée de l'heure de fin en dernière ligne.
+ * Les colonnes du tableau sont dimensionnées d'après les plus grandes valeurs
+ * possibles (sizeColumns); sur un terminal, l'en-tête, les nouveaux records et
+ * les valeurs composées sont mis en évidence par des couleurs ANSI.
+ * Avec -timing, chaque résultat porte l'heure de sa découverte et la durée du
+ * test de son candidat.
+ * Les formats csv, pbz et parquet portent aussi le manifeste: lignes de
+ * commentaire autour des lignes csv, messages en tête et en fin du flux pbz,
+ * métadonnées clé-valeur du pied de fichier parquet.
+ * resultWriter est une destination de résultats (primes.ResultSink).
+ */
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"github.com/klauspost/compress/zstd"
+)
+
+// resultFormats sont les formats de sortie acceptés par -format pour la recherche.
+var resultFormats = []string{"table", "json", "ndjson", "csv", "pbz", "parquet"}
+
+// colorModes sont les valeurs acceptées par -color.
+var colorModes = []string{"auto", "always", "never"}
+
+// colorEnabled indique si le tableau écrit sur w est mis en couleurs: toujours ou jamais selon
+// mode, et en mode auto si w est un terminal, sauf si NO_COLOR est défini ou TERM vaut dumb.
+func colorEnabled(mode string, w io.Writer, getenv func(string) string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if getenv("NO_COLOR") != "" || getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// defaultTableWidths sont les largeurs des colonnes p, q et n d'un tableau non dimensionné.
+var defaultTableWidths = [3]int{10, 10, 25}
+
+// Présentation des mesures des résultats (option -timing): heure locale de la découverte à la
+// microseconde dans le tableau, et largeurs de ses colonnes.
+const (
+	foundAtLayout = "15:04:05.000000"
+	foundAtWidth  = len(foundAtLayout)
+	testTimeWidth = 10
+)
+
+// Séquences ANSI de mise en évidence du tableau (option -color).
+const (
+	ansiBold   = "\x1b[1m"
+	ansiRecord = "\x1b[1;32m" // Nouveau record: gras, vert.
+	ansiDim    = "\x1b[2m"    // Valeur composée analysée.
+	ansiReset  = "\x1b[0m"
+)
+
+// jsonResult est un résultat de la recherche au format JSON.
+type jsonResult struct {
+	P    int      `json:"p"`
+	Q    int      `json:"q"`
+	N    int64    `json:"n"`
+	NBig *big.Int `json:"n_big,omitempty"` // Valeur exacte au-delà d'int64; n vaut alors math.MaxInt64.
+	Twin bool     `json:"twin,omitempty"`
+
+	FoundAt *time.Time `json:"found_at,omitempty"` // Instant de la découverte (-timing).
+	TestNs  int64      `json:"test_ns,omitempty"`  // Durée du test du candidat en nanosecondes (-timing).
+}
+
+// newJSONResult convertit un résultat de la recherche au format JSON.
+func newJSONResult(res primes.Result) jsonResult {
+	jr := jsonResult{P: res.P, Q: res.Q, N: res.N, NBig: res.Big, Twin: res.Twin}
+	if !res.FoundAt.IsZero() {
+		jr.FoundAt, jr.TestNs = &res.FoundAt, int64(res.TestTime)
+	}
+	return jr
+}
+
+// result reconvertit un résultat relu (convert) en résultat de la recherche.
+func (jr jsonResult) result() primes.Result {
+	res := primes.Result{P: jr.P, Q: jr.Q, N: jr.N, Big: jr.NBig, Twin: jr.Twin, TestTime: time.Duration(jr.TestNs)}
+	if jr.FoundAt != nil {
+		res.FoundAt = *jr.FoundAt
+	}
+	return res
+}
+
+// csvResultHeader est l'en-tête du format csv: les champs du format JSON, vides s'ils sont absents.
+const csvResultHeader = "p,q,n,n_big,twin,found_at,test_ns"
+
+// writeCSVResult écrit une ligne du format csv.
+func writeCSVResult(w io.Writer, jr jsonResult) {
+	var nBig, foundAt, testNs string
+	if jr.NBig != nil {
+		nBig = jr.NBig.String()
+	}
+	if jr.FoundAt != nil {
+		foundAt, testNs = jr.FoundAt.Format(time.RFC3339Nano), fmt.Sprint(jr.TestNs)
+	}
+	fmt.Fprintf(w, "%d,%d,%d,%s,%t,%s,%s\n", jr.P, jr.Q, jr.N, nBig, jr.Twin, foundAt, testNs)
+}
+
+// ndjsonManifestPrefix commence les lignes de manifeste du format NDJSON, jamais celles d'un résultat.
+const ndjsonManifestPrefix = `{"manifest":`
+
+// writeNDJSONManifest écrit le manifeste m sur une ligne NDJSON {"manifest": {...}}.
+func writeNDJSONManifest(w io.Writer, m *runManifest) {
+	data, _ := json.Marshal(m)
+	fmt.Fprintf(w, "%s%s}\n", ndjsonManifestPrefix, data)
+}
+
+// resultWriter écrit les résultats de la recherche sur w au format table, json, ndjson, csv, pbz ou
+// parquet.
+// Le manifeste, facultatif, encadre le tableau et le csv en commentaires, encadre le flux pbz et
+// le NDJSON (lignes {"manifest": ...}), complète le document JSON ou le pied de fichier parquet.
+// Sur un *errWriter, les méthodes de primes.ResultSink retournent la première erreur d'écriture.
+type resultWriter struct {
+	w        io.Writer
+	format   string
+	formName string
+	manifest *runManifest
+	count    int
+
+	manifestParams bool // Valeur de chaque option dans le manifeste du tableau (-manifest-verbose).
+
+	widths      [3]int // Largeurs des colonnes p, q et n du tableau (zéro: defaultTableWidths).
+	color       bool   // Mise en évidence par couleurs ANSI.
+	recordAbove int64  // Un n supérieur est un nouveau record, mis en évidence (0: aucun record connu).
+	timing      bool   // Colonnes de l'heure de découverte et de la durée du test (-timing).
+
+	zw  *zstd.Encoder  // Compresseur du format pbz, créé par begin.
+	pbz pbzState       // Dernier résultat écrit au format pbz.
+	pb  []byte         // Message pbz en cours de codage.
+	pq  *parquetWriter // Fichier du format parquet, créé par begin.
+}
+
+// sizeColumns dimensionne les colonnes du tableau pour des nombres premiers jusqu'à maxPrime et des
+// valeurs de n jusqu'à maxN, dans la présentation des nombres active (-numbers).
+func (rw *resultWriter) sizeColumns(maxPrime int, maxN *big.Int) {
+	width := func(label string, v fmt.Formatter) int {
+		return max(utf8.RuneCountInString(label), utf8.RuneCountInString(fmt.Sprint(v)))
+	}
+	rw.widths = [3]int{width("p", groupedInt(maxPrime)), width("q", groupedInt(maxPrime)), width("n = "+rw.formName, groupedBig{maxN})}
+}
+
+// row écrit une ligne du tableau, mise en évidence par style si les couleurs sont actives. Avec
+// -timing, timing donne l'heure de découverte et la durée du test (vides s'ils sont absents).
+func (rw *resultWriter) row(style string, p, q, n any, check string, timing ...string) {
+	w := rw.widths
+	if w == ([3]int{}) {
+		w = defaultTableWidths
+	}
+	line := fmt.Sprintf("%-*v | %-*v | %-*v | ", w[0], p, w[1], q, w[2], n)
+	if rw.timing {
+		timing = append(timing, "", "")
+		line += fmt.Sprintf("%-*s | %-*s | ", foundAtWidth, timing[0], testTimeWidth, timing[1])
+	}
+	line += check
+	if rw.color && style != "" {
+		line = style + line + ansiReset
+	}
+	fmt.Fprintln(rw.w, line)
+}
+
+// begin écrit l'en-tête: manifeste (lignes de commentaire du tableau et du csv, premier message
+// pbz, première ligne NDJSON), titres des colonnes, ouverture du document JSON.
+func (rw *resultWriter) begin() {
+	switch rw.format {
+	case "ndjson":
+		if rw.manifest != nil {
+			writeNDJSONManifest(rw.w, rw.manifest)
+		}
+	case "csv":
+		if rw.manifest != nil {
+			rw.manifest.writeHeader(rw.w, true)
+		}
+		fmt.Fprintln(rw.w, csvResultHeader)
+	case "pbz":
+		rw.zw, _ = newPBZWriter(rw.w) // Sans option invalide, NewWriter n'échoue pas.
+		if rw.manifest != nil {
+			rw.zw.Write(appendPBZManifest(nil, rw.manifest))
+		}
+	case "parquet":
+		rw.pq = newParquetWriter(rw.w)
+	case "json":
+		if rw.manifest != nil {
+			fmt.Fprint(rw.w, `{"results":[`)
+		} else {
+			fmt.Fprint(rw.w, "[")
+		}
+	default:
+		if rw.manifest != nil {
+			rw.manifest.writeHeader(rw.w, rw.manifestParams)
+		}
+		rw.row(ansiBold, "p", "q", "n = "+rw.formName, tr(msgColumnCheck), tr(msgColumnFoundAt), tr(msgColumnTestTime))
+	}
+}
+
+// result écrit un résultat, un objet JSON par ligne dans le document JSON.
+func (rw *resultWriter) result(res primes.Result) {
+	switch rw.format {
+	case "ndjson":
+		data, _ := json.Marshal(newJSONResult(res))
+		fmt.Fprintf(rw.w, "%s\n", data)
+	case "json":
+		data, _ := json.Marshal(newJSONResult(res))
+		if rw.count > 0 {
+			fmt.Fprint(rw.w, ",")
+		}
+		fmt.Fprintf(rw.w, "\n%s", data)
+	case "csv":
+		writeCSVResult(rw.w, newJSONResult(res))
+	case "pbz":
+		rw.pb = rw.pbz.appendPBZResult(rw.pb[:0], newJSONResult(res))
+		rw.zw.Write(rw.pb) // Les erreurs d'écriture sont retenues par rw.w.
+	case "parquet":
+		rw.pq.add(newJSONResult(res))
+	default:
+		check := tr(msgFound)
+		if res.Twin {
+			check += " " + tr(msgTwinMark)
+		}
+		style := ""
+		if rw.recordAbove > 0 && res.N > rw.recordAbove {
+			check += " " + tr(msgRecordMark)
+			style = ansiRecord
+			rw.recordAbove = res.N // Les records suivants doivent battre celui-ci.
+		}
+		var n fmt.Formatter = groupedInt(res.N)
+		if res.Big != nil {
+			n = groupedBig{res.Big}
+		}
+		var timing []string
+		if !res.FoundAt.IsZero() {
+			timing = []string{res.FoundAt.Format(foundAtLayout), res.TestTime.String()}
+		}
+		rw.row(style, groupedInt(res.P), groupedInt(res.Q), n, check, timing...)
+	}
+	rw.count++
+}
+
+// composite écrit une valeur composée analysée (-explain-composites); les formats JSON ne retiennent que les résultats.
+func (rw *resultWriter) composite(c primes.Composite) {
+	if rw.format == "table" {
+		rw.row(ansiDim, groupedInt(c.P), groupedInt(c.Q), groupedInt(c.N), tr(msgCompositeMark, groupedInt(c.Factor)))
+	}
+}
+
+// end termine la sortie: heure de fin du manifeste (manifeste complet en fin de document JSON, de
+// flux pbz et NDJSON et dans le pied de fichier parquet), fermeture du document JSON.
+func (rw *resultWriter) end() {
+	switch rw.format {
+	case "ndjson":
+		if rw.manifest != nil {
+			rw.manifest.finish()
+			writeNDJSONManifest(rw.w, rw.manifest)
+		}
+	case "csv":
+		if rw.manifest != nil {
+			rw.manifest.writeTrailer(rw.w)
+		}
+	case "pbz":
+		if rw.manifest != nil {
+			rw.manifest.finish()
+			rw.zw.Write(appendPBZManifest(nil, rw.manifest))
+		}
+		rw.zw.Close()
+	case "parquet":
+		var manifest []byte
+		if rw.manifest != nil {
+			rw.manifest.finish()
+			manifest, _ = json.Marshal(rw.manifest)
+		}
+		rw.pq.close(manifest)
+	case "json":
+		if rw.count > 0 {
+			fmt.Fprint(rw.w, "\n")
+		}
+		if rw.manifest == nil {
+			fmt.Fprint(rw.w, "]\n")
+			return
+		}
+		rw.manifest.finish()
+		data, _ := json.Marshal(rw.manifest)
+		fmt.Fprintf(rw.w, "],\"manifest\":%s}\n", data)
+	default:
+		if rw.manifest != nil {
+			rw.manifest.writeTrailer(rw.w)
+		}
+	}
+}
+
+// err retourne la première erreur d'écriture sur w, si w la mémorise (*errWriter).
+func (rw *resultWriter) err() error {
+	if ew, ok := rw.w.(*errWriter); ok {
+		return writeError(ew)
+	}
+	return nil
+}
+
+// Write écrit un résultat (primes.ResultSink).
+func (rw *resultWriter) Write(res primes.Result) error {
+	rw.result(res)
+	return rw.err()
+}
+
+// Flush retourne la première erreur d'écriture (primes.ResultSink): la sortie n'est pas tamponnée,
+// sauf le bloc compressé en cours du format pbz, écrit à cette occasion, et le groupe de lignes en
+// cours du format parquet, écrit seulement une fois complet.
+func (rw *resultWriter) Flush() error {
+	if rw.zw != nil {
+		rw.zw.Flush()
+	}
+	return rw.err()
+}
+
+// Close termine la sortie (primes.ResultSink).
+func (rw *resultWriter) Close() error {
+	rw.end()
+	return rw.err()
+}
@@ -0,0 +1,163 @@
+/*
+ * Fichier: results_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'écriture des résultats de la recherche (tableau, JSON et NDJSON).
+ */
+package main
+
+import (
+	"bytes"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestResultWriter valide les formats tableau, JSON et NDJSON, liste vide comprise.
+func TestResultWriter(t *testing.T) {
+	testCases := []struct {
+		format   string
+		results  []primes.Result
+		expected string
+	}{
+		{"table", []primes.Result{{P: 5, Q: 2, N: 41, Twin: true}}, "p          | q          | n = p^2+4q^2              | Vérification\n" +
+			"5          | 2          | 41                        | Trouvé! (jumeau)\n"},
+		{"json", []primes.Result{{P: 5, Q: 2, N: 41, Twin: true}, {P: 3, Q: 5, N: 109}}, "[\n{\"p\":5,\"q\":2,\"n\":41,\"twin\":true},\n{\"p\":3,\"q\":5,\"n\":109}\n]\n"},
+		{"json", nil, "[]\n"},
+		{"ndjson", []primes.Result{{P: 5, Q: 2, N: 41, Twin: true}, {P: 3, Q: 5, N: 109}}, "{\"p\":5,\"q\":2,\"n\":41,\"twin\":true}\n{\"p\":3,\"q\":5,\"n\":109}\n"},
+		{"ndjson", nil, ""},
+	}
+	defer setLanguage(defaultLanguage)
+	setLanguage(language.French)
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		rw := &resultWriter{w: &buf, format: tc.format, formName: "p^2+4q^2"}
+		rw.begin()
+		for _, res := range tc.results {
+			rw.result(res)
+		}
+		rw.composite(primes.Composite{P: 3, Q: 3, N: 45, Factor: 3}) // Ignoré en JSON.
+		rw.end()
+		expected := tc.expected
+		if tc.format == "table" {
+			expected += "3          | 3          | 45                        | " + tr(msgCompositeMark, 3) + "\n"
+		}
+		if buf.String() != expected {
+			t.Errorf("%s: sortie = %q, attendu %q", tc.format, buf.String(), expected)
+		}
+	}
+}
+
+// TestResultWriterCSVManifest vérifie que le manifeste encadre le csv en lignes de commentaire,
+// relues comme manifeste.
+func TestResultWriterCSVManifest(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("limit", 10, "")
+	var buf bytes.Buffer
+	rw := &resultWriter{w: &buf, format: "csv", manifest: newManifest("test", nil, fs, nil, time.Now())}
+	rw.begin()
+	rw.result(primes.Result{P: 5, Q: 2, N: 41})
+	rw.end()
+	out := buf.String()
+	if !strings.HasPrefix(out, "# run_id: ") || !strings.Contains(out, "# param.limit: 10\n"+csvResultHeader+"\n5,2,41,") || !strings.Contains(out, "\n# end: ") {
+		t.Errorf("sortie csv = %q, attendu le manifeste en commentaires avant l'en-tête et l'heure de fin après les lignes", out)
+	}
+	var got []jsonResult
+	var m *runManifest
+	if err := readCSVResults(strings.NewReader(out), "test", func(rm *runManifest) { m = rm }, func(jr jsonResult) error {
+		got = append(got, jr)
+		return nil
+	}); err != nil || len(got) != 1 || got[0].N != 41 {
+		t.Errorf("relu %+v, %v: attendu le seul résultat n = 41", got, err)
+	}
+	if m == nil || m.RunID != rw.manifest.RunID || m.Params["limit"] != "10" || !m.End.Equal(rw.manifest.End) {
+		t.Errorf("manifeste relu %+v, attendu %+v", m, rw.manifest)
+	}
+}
+
+// TestResultWriterTiming valide les mesures des résultats (-timing) dans le tableau et en JSON.
+func TestResultWriterTiming(t *testing.T) {
+	defer setLanguage(defaultLanguage)
+	setLanguage(language.English)
+	found := time.Date(2026, 10, 16, 12, 30, 45, 123456789, time.UTC)
+	res := primes.Result{P: 5, Q: 2, N: 41, FoundAt: found, TestTime: 1500 * time.Nanosecond}
+
+	var buf bytes.Buffer
+	rw := &resultWriter{w: &buf, format: "table", formName: "p^2+4q^2", timing: true}
+	rw.begin()
+	rw.result(res)
+	rw.composite(primes.Composite{P: 3, Q: 3, N: 45, Factor: 3})
+	want := "p          | q          | n = p^2+4q^2              | Found at        | Test       | Check\n" +
+		"5          | 2          | 41                        | 12:30:45.123456 | 1.5µs      | Found!\n" +
+		"3          | 3          | 45                        |                 |            | " + tr(msgCompositeMark, 3) + "\n"
+	if buf.String() != want {
+		t.Errorf("tableau = %q, attendu %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	rw = &resultWriter{w: &buf, format: "ndjson"}
+	rw.result(res)
+	if want := `{"p":5,"q":2,"n":41,"found_at":"2026-10-16T12:30:45.123456789Z","test_ns":1500}` + "\n"; buf.String() != want {
+		t.Errorf("ndjson = %q, attendu %q", buf.String(), want)
+	}
+}
+
+// TestResultWriterTable valide le dimensionnement des colonnes et la mise en évidence des records.
+func TestResultWriterTable(t *testing.T) {
+	defer setLanguage(defaultLanguage)
+	setLanguage(language.English)
+	var buf bytes.Buffer
+	rw := &resultWriter{w: &buf, format: "table", formName: "p^2+4q^2", color: true, recordAbove: 100}
+	rw.sizeColumns(97, big.NewInt(97*97+4*97*97))
+	rw.begin()
+	for _, res := range []primes.Result{{P: 5, Q: 2, N: 41}, {P: 3, Q: 5, N: 109}, {P: 3, Q: 19, N: 1453}, {P: 5, Q: 17, N: 1181}} {
+		rw.result(res)
+	}
+	rw.composite(primes.Composite{P: 3, Q: 3, N: 45, Factor: 3})
+	expected := ansiBold + "p  | q  | n = p^2+4q^2 | Check" + ansiReset + "\n" +
+		"5  | 2  | 41           | Found!\n" +
+		ansiRecord + "3  | 5  | 109          | Found! (new record)" + ansiReset + "\n" +
+		ansiRecord + "3  | 19 | 1,453        | Found! (new record)" + ansiReset + "\n" +
+		"5  | 17 | 1,181        | Found!\n" +
+		ansiDim + "3  | 3  | 45           | Composite, factor 3" + ansiReset + "\n"
+	if buf.String() != expected {
+		t.Errorf("tableau = %q, attendu %q", buf.String(), expected)
+	}
+}
+
+// TestColorEnabled valide le choix des couleurs selon -color, la sortie et l'environnement.
+func TestColorEnabled(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	var buf bytes.Buffer
+	if !colorEnabled("always", &buf, env(nil)) || colorEnabled("never", os.Stdout, env(nil)) {
+		t.Errorf("always/never non respectés")
+	}
+	if colorEnabled("auto", &buf, env(nil)) {
+		t.Errorf("auto sur un tampon: couleurs inattendues")
+	}
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if colorEnabled("auto", f, env(nil)) {
+		t.Errorf("auto sur un fichier ordinaire: couleurs inattendues")
+	}
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		if !colorEnabled("auto", tty, env(nil)) || colorEnabled("auto", tty, env(map[string]string{"NO_COLOR": "1"})) {
+			t.Errorf("auto sur un terminal: NO_COLOR non respecté ou couleurs absentes")
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/*
+ * Fichier: reverse_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'option -reverse.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestRunReverse vérifie que la recherche inverse écrit les mêmes résultats que la recherche
+// directe, et refuse les options qui supposent le test des paires.
+func TestRunReverse(t *testing.T) {
+	base := []string{"-limit", "300", "-format", "ndjson", "-manifest=false", "-pairs", "lt", "-twins"}
+	lines := func(args ...string) []string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := run(append(slices.Clone(base), args...), &out, io.Discard); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		l := strings.Split(resultLines(out.String()), "\n")
+		slices.Sort(l)
+		return l
+	}
+	if forward, reverse := lines("-workers", "1"), lines("-reverse", "-workers", "2"); !slices.Equal(forward, reverse) {
+		t.Errorf("-reverse: %d résultats, %d pour la recherche directe", len(reverse), len(forward))
+	}
+	for _, args := range [][]string{{"-form", "p^2+q^4"}, {"-on-overflow", "skip"}, {"-compare", "miller,bpsw"}, {"-autotune"}} {
+		if err := run(append([]string{"-limit", "100", "-reverse"}, args...), io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+			t.Errorf("-reverse %v: %v, attendu le code %d", args, err, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+/*
+ * Fichier: scheduler.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Planificateur du mode serveur: des recherches récurrentes (-schedule de
+ * serve, répétable, ou lignes "schedule = ..." du fichier -config) qui
+ * repoussent une frontière à heures fixes, par exemple de 10^6 chaque nuit à
+ * 2 h. Une planification "NOM: MIN HEURE JOUR MOIS JOUR_SEMAINE extend=N ..."
+ * suit la syntaxe de cron; à chaque déclenchement, elle soumet la recherche
+ * des paires dont p ou q dépasse la frontière, jusqu'à la frontière + N. La
+ * frontière n'avance qu'une fois cette recherche achevée: une recherche en
+ * échec ou annulée est soumise de nouveau au déclenchement suivant, et un
+ * déclenchement pendant qu'elle est encore en file ou en cours est ignoré.
+ * L'état de chaque planification (frontière, dernier déclenchement, dernière
+ * recherche) est tenu dans la base du serveur: un déclenchement manqué
+ * pendant un arrêt est rattrapé une fois au redémarrage.
+ */
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleCatchUp borne la période examinée pour rattraper un déclenchement manqué.
+const scheduleCatchUp = 366 * 24 * time.Hour
+
+// cronField est l'ensemble des valeurs permises d'un champ de cron, en bits.
+type cronField uint64
+
+// cronSpec est une expression de cron à cinq champs: minute, heure, jour du mois, mois, jour de
+// la semaine (0 ou 7: dimanche).
+type cronSpec struct {
+	minute, hour, dom, month, dow cronField
+	domAny, dowAny                bool // Champ "*": sans restriction.
+}
+
+// cronRanges sont les bornes des cinq champs.
+var cronRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// parseCron lit les cinq champs d'une expression de cron. Un champ est une liste séparée par des
+// virgules de "*", "V", "A-B", chacun suivi ou non d'un pas "/P".
+func parseCron(fields []string) (cronSpec, error) {
+	if len(fields) != 5 {
+		return cronSpec{}, fmt.Errorf("%d champs de cron (attendu 5: minute heure jour mois jour_semaine)", len(fields))
+	}
+	var parsed [5]cronField
+	for i, field := range fields {
+		lo, hi := cronRanges[i][0], cronRanges[i][1]
+		for part := range strings.SplitSeq(field, ",") {
+			rng, stepText, hasStep := strings.Cut(part, "/")
+			step := 1
+			if hasStep {
+				var err error
+				if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+					return cronSpec{}, fmt.Errorf("champ %q: pas %q invalide", field, stepText)
+				}
+			}
+			a, b := lo, hi
+			if rng != "*" {
+				aText, bText, isRange := strings.Cut(rng, "-")
+				var errA, errB error
+				a, errA = strconv.Atoi(aText)
+				b = a
+				if isRange {
+					b, errB = strconv.Atoi(bText)
+				} else if hasStep {
+					b = hi
+				}
+				if errA != nil || errB != nil || a < lo || b > hi || a > b {
+					return cronSpec{}, fmt.Errorf("champ %q: %q hors de [%d, %d]", field, part, lo, hi)
+				}
+			}
+			for v := a; v <= b; v += step {
+				parsed[i] |= 1 << v
+			}
+		}
+	}
+	spec := cronSpec{minute: parsed[0], hour: parsed[1], dom: parsed[2], month: parsed[3], dow: parsed[4],
+		domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	if spec.dow&(1<<7) != 0 {
+		spec.dow |= 1 // 7 est aussi dimanche.
+	}
+	return spec, nil
+}
+
+// matches indique si la minute de t est permise. Comme cron, si le jour du mois et le jour de la
+// semaine sont tous deux restreints, l'un ou l'autre suffit.
+func (c cronSpec) matches(t time.Time) bool {
+	if c.minute&(1<<t.Minute()) == 0 || c.hour&(1<<t.Hour()) == 0 || c.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dom, dow := c.dom&(1<<t.Day()) != 0, c.dow&(1<<int(t.Weekday())) != 0
+	if !c.domAny && !c.dowAny {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// next retourne la première minute permise après after et au plus tard à until, ou l'heure zéro
+// s'il n'y en a aucune. Les minutes sont lues dans le fuseau de until (l'heure locale du serveur).
+func (c cronSpec) next(after, until time.Time) time.Time {
+	for t := after.In(until.Location()).Truncate(time.Minute).Add(time.Minute); !t.After(until); t = t.Add(time.Minute) {
+		if c.matches(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// searchSchedule est une planification de recherches récurrentes.
+type searchSchedule struct {
+	name   string
+	text   string // Expression d'origine, rendue par GET /schedules.
+	cron   cronSpec
+	extend int          // Avancée de la frontière à chaque recherche.
+	from   int          // Frontière initiale.
+	params searchParams // Forme, test, paires, tranches et workers des recherches.
+}
+
+// parseSchedule lit une planification "NOM: MIN HEURE JOUR MOIS JOUR_SEMAINE extend=N [from=F]
+// [form=...] [primetest=...] [pairs=...] [chunks=K] [workers=W]".
+func parseSchedule(value string) (searchSchedule, error) {
+	name, rest, ok := strings.Cut(value, ":")
+	name = strings.TrimSpace(name)
+	fields := strings.Fields(rest)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") || len(fields) < 5 {
+		return searchSchedule{}, fmt.Errorf("%q: attendu \"NOM: MIN HEURE JOUR MOIS JOUR_SEMAINE extend=N ...\"", value)
+	}
+	cron, err := parseCron(fields[:5])
+	if err != nil {
+		return searchSchedule{}, fmt.Errorf("%q: %v", value, err)
+	}
+	s := searchSchedule{name: name, text: value, cron: cron}
+	for _, kv := range fields[5:] {
+		key, v, ok := strings.Cut(kv, "=")
+		var n int
+		var nerr error
+		switch key {
+		case "extend", "from", "chunks", "workers":
+			n, nerr = strconv.Atoi(strings.ReplaceAll(v, "_", ""))
+		}
+		switch {
+		case !ok || nerr != nil:
+			return searchSchedule{}, fmt.Errorf("%q: %q (attendu clé=valeur)", value, kv)
+		case key == "extend":
+			s.extend = n
+		case key == "from":
+			s.from = n
+		case key == "chunks":
+			s.params.Chunks = n
+		case key == "workers":
+			s.params.Workers = n
+		case key == "form":
+			s.params.Form = v
+		case key == "primetest":
+			s.params.PrimeTest = v
+		case key == "pairs":
+			s.params.Pairs = v
+		default:
+			return searchSchedule{}, fmt.Errorf("%q: clé inconnue %q (attendu extend, from, form, primetest, pairs, chunks, workers)", value, key)
+		}
+	}
+	if s.extend < 1 || s.from < 0 {
+		return searchSchedule{}, fmt.Errorf("%q: extend=%d, from=%d (attendu extend >= 1, from >= 0)", value, s.extend, s.from)
+	}
+	return s, nil
+}
+
+// searchParams retourne les paramètres de la recherche qui repousse la frontière frontier.
+func (s searchSchedule) searchParams(frontier int) searchParams {
+	p := s.params
+	p.Above, p.Limit = frontier, frontier+s.extend
+	return p
+}
+
+// scheduleList est la valeur de -schedule, répétable (flag.Value).
+type scheduleList []searchSchedule
+
+func (l *scheduleList) String() string {
+	var specs []string
+	for _, s := range *l {
+		specs = append(specs, s.text)
+	}
+	return strings.Join(specs, "; ")
+}
+
+func (l *scheduleList) Set(value string) error {
+	s, err := parseSchedule(value)
+	if err != nil {
+		return err
+	}
+	for _, other := range *l {
+		if other.name == s.name {
+			return fmt.Errorf("%q: planification %s en double", value, s.name)
+		}
+	}
+	*l = append(*l, s)
+	return nil
+}
+
+// scheduleState est l'état persisté d'une planification.
+type scheduleState struct {
+	Frontier   int       `json:"frontier"`
+	LastFire   time.Time `json:"last_fire,omitzero"`
+	LastSearch string    `json:"last_search,omitempty"`
+}
+
+// scheduleStatus est l'état d'une planification rendu par GET /schedules.
+type scheduleStatus struct {
+	Name string `json:"name"`
+	Spec string `json:"spec"`
+	scheduleState
+	Next time.Time `json:"next,omitzero"`
+}
+
+// fireSchedules déclenche les planifications dont une minute permise est passée depuis leur
+// dernier déclenchement (au plus scheduleCatchUp), à l'instant now. Une planification jamais
+// déclenchée attend sa première minute permise après son enregistrement.
+func (s *searchServer) fireSchedules(now time.Time) {
+	for _, sched := range s.schedules {
+		st, found, err := s.store.schedule(sched.name)
+		if err != nil {
+			s.logf(msgServeError, err)
+			continue
+		}
+		if !found {
+			st = scheduleState{Frontier: sched.from, LastFire: now}
+			if err := s.store.putSchedule(sched.name, st); err != nil {
+				s.logf(msgServeError, err)
+			}
+			continue
+		}
+		last := st.LastFire
+		if oldest := now.Add(-scheduleCatchUp); last.Before(oldest) {
+			last = oldest
+		}
+		if sched.cron.next(last, now).IsZero() {
+			continue
+		}
+		if err := s.fireSchedule(sched, st, now); err != nil {
+			s.logf(msgServeError, fmt.Errorf("planification %s: %w", sched.name, err))
+		}
+	}
+}
+
+// fireSchedule déclenche la planification sched d'état st: la frontière avance si la dernière
+// recherche est achevée, et la recherche suivante est soumise, sauf si la dernière est encore en
+// file ou en cours.
+func (s *searchServer) fireSchedule(sched searchSchedule, st scheduleState, now time.Time) error {
+	st.LastFire = now
+	if st.LastSearch != "" {
+		rec, err := s.store.search(st.LastSearch)
+		switch {
+		case err != nil:
+			return err
+		case !rec.finished():
+			s.logf(msgServeScheduleBusy, sched.name, rec.ID, rec.State)
+			return s.store.putSchedule(sched.name, st)
+		case rec.State == searchDone:
+			st.Frontier = max(st.Frontier, rec.Params.Limit)
+		}
+	}
+	rec, _, err := s.submit("", sched.searchParams(st.Frontier), duplicateSkip)
+	if err != nil {
+		// La planification attend le déclenchement suivant, par exemple sous un plafond levé d'ici là.
+		if perr := s.store.putSchedule(sched.name, st); perr != nil {
+			return perr
+		}
+		return err
+	}
+	st.LastSearch = rec.ID
+	s.logf(msgServeScheduleFired, sched.name, rec.ID, rec.Params.Above, rec.Params.Limit)
+	return s.store.putSchedule(sched.name, st)
+}
+
+// scheduleStatuses retourne l'état des planifications et leur prochain déclenchement après now.
+func (s *searchServer) scheduleStatuses(now time.Time) ([]scheduleStatus, error) {
+	list := make([]scheduleStatus, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		st, found, err := s.store.schedule(sched.name)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			st.Frontier = sched.from
+		}
+		next := sched.cron.next(now, now.Add(scheduleCatchUp)) // Zéro pour une date qui n'existe pas (31 février).
+		list = append(list, scheduleStatus{Name: sched.name, Spec: sched.text, scheduleState: st, Next: next})
+	}
+	return list, nil
+}
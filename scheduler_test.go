@@ -0,0 +1,203 @@
+/*
+ * Fichier: scheduler_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du planificateur du mode serveur: expressions de cron, prochain
+ * déclenchement, lecture des planifications, et déclenchements successifs
+ * qui repoussent la frontière (recherche en cours, annulée, rattrapage).
+ */
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseCron vérifie la lecture des champs de cron et les minutes permises.
+func TestParseCron(t *testing.T) {
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC) }
+	// Le 16 octobre 2026 est un vendredi, le 18 un dimanche.
+	tests := []struct {
+		spec string
+		t    time.Time
+		want bool
+	}{
+		{"0 2 * * *", at(16, 2, 0), true},
+		{"0 2 * * *", at(16, 2, 1), false},
+		{"*/15 * * * *", at(16, 7, 45), true},
+		{"*/15 * * * *", at(16, 7, 50), false},
+		{"5-10/5 * * * *", at(16, 7, 10), true},
+		{"30 8-18 * * 1-5", at(16, 12, 30), true},
+		{"30 8-18 * * 1-5", at(18, 12, 30), false},
+		{"0 0 * * 7", at(18, 0, 0), true},
+		{"0 0 1,16 * *", at(16, 0, 0), true},
+		{"0 0 * 1-9 *", at(16, 0, 0), false},
+		// Jour du mois et jour de la semaine restreints: l'un ou l'autre suffit.
+		{"0 0 1 * 5", at(16, 0, 0), true},
+		{"0 0 1 * 1", at(16, 0, 0), false},
+	}
+	for _, tt := range tests {
+		c, err := parseCron(strings.Fields(tt.spec))
+		if err != nil {
+			t.Fatalf("%q: %v", tt.spec, err)
+		}
+		if got := c.matches(tt.t); got != tt.want {
+			t.Errorf("%q à %v: %v, attendu %v", tt.spec, tt.t, got, tt.want)
+		}
+	}
+	for _, spec := range []string{"* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "x * * * *"} {
+		if _, err := parseCron(strings.Fields(spec)); err == nil {
+			t.Errorf("%q accepté", spec)
+		}
+	}
+}
+
+// TestCronNext vérifie le prochain déclenchement, et l'absence de déclenchement pour une date
+// qui n'existe pas.
+func TestCronNext(t *testing.T) {
+	after := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	year := after.Add(scheduleCatchUp)
+	c, _ := parseCron(strings.Fields("0 2 * * *"))
+	if got, want := c.next(after, year), time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("prochain déclenchement = %v, attendu %v", got, want)
+	}
+	if got := c.next(after, after.Add(time.Hour)); !got.IsZero() {
+		t.Errorf("déclenchement avant la borne: %v", got)
+	}
+	feb31, _ := parseCron(strings.Fields("0 0 31 2 *"))
+	if got := feb31.next(after, year); !got.IsZero() {
+		t.Errorf("31 février: %v", got)
+	}
+}
+
+// TestParseSchedule vérifie la lecture d'une planification et ses refus.
+func TestParseSchedule(t *testing.T) {
+	s, err := parseSchedule("nuit: 0 2 * * * extend=1_000_000 from=500 form=p2+2q2 chunks=64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.name != "nuit" || s.extend != 1_000_000 || s.from != 500 || s.params.Form != "p2+2q2" || s.params.Chunks != 64 {
+		t.Errorf("planification = %+v", s)
+	}
+	if p := s.searchParams(2000); p.Above != 2000 || p.Limit != 1_002_000 {
+		t.Errorf("paramètres = %+v", p)
+	}
+	for _, spec := range []string{
+		"0 2 * * * extend=10",
+		"nuit 0 2 * * * extend=10",
+		"nuit: 0 2 * * *",
+		"nuit: 0 2 * * extend=10",
+		"nuit: 0 2 * * * extend=0",
+		"nuit: 0 2 * * * extend=10 depth=3",
+		"nuit: 0 2 * * * extend=dix",
+	} {
+		if _, err := parseSchedule(spec); err == nil {
+			t.Errorf("%q accepté", spec)
+		}
+	}
+	var list scheduleList
+	if err := list.Set("a: 0 2 * * * extend=10"); err != nil {
+		t.Fatal(err)
+	}
+	if err := list.Set("a: 0 3 * * * extend=10"); err == nil {
+		t.Error("planification en double acceptée")
+	}
+}
+
+// TestServerSchedule déclenche une planification de jour en jour: la frontière avance après
+// chaque recherche achevée, un déclenchement pendant une recherche en cours est ignoré, une
+// recherche annulée est soumise de nouveau, et l'état survit à un nouveau serveur sur la base.
+func TestServerSchedule(t *testing.T) {
+	srv := newTestServer(t, 1, 1)
+	sched, err := parseSchedule("nuit: 0 2 * * * extend=100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.schedules = []searchSchedule{sched}
+	day := func(d, hour int) time.Time { return time.Date(2026, 10, d, hour, 0, 0, 0, time.Local) }
+	state := func() scheduleState {
+		t.Helper()
+		st, found, err := srv.store.schedule("nuit")
+		if err != nil || !found {
+			t.Fatalf("état de la planification: %v, %v", found, err)
+		}
+		return st
+	}
+	run := func() {
+		t.Helper()
+		if err := srv.dispatch(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		srv.wg.Wait()
+	}
+
+	srv.fireSchedules(day(16, 3)) // Enregistrement: aucun déclenchement.
+	srv.fireSchedules(day(17, 1))
+	if st := state(); st.LastSearch != "" || st.Frontier != 0 {
+		t.Fatalf("déclenchement avant 2 h: %+v", st)
+	}
+	srv.fireSchedules(day(17, 2))
+	first, err := srv.store.search(state().LastSearch)
+	if err != nil || first.Params.Above != 0 || first.Params.Limit != 100 {
+		t.Fatalf("première recherche = %+v, %v", first, err)
+	}
+	srv.fireSchedules(day(18, 2)) // Recherche encore en file: déclenchement ignoré.
+	if st := state(); st.LastSearch != first.ID || !st.LastFire.Equal(day(18, 2)) {
+		t.Errorf("déclenchement pendant la recherche: %+v", st)
+	}
+	run()
+
+	srv.fireSchedules(day(19, 2))
+	second, _ := srv.store.search(state().LastSearch)
+	if st := state(); st.Frontier != 100 || second.Params.Above != 100 || second.Params.Limit != 200 {
+		t.Fatalf("seconde recherche = %+v, état %+v", second, st)
+	}
+	if _, err := srv.cancel("", second.ID); err != nil {
+		t.Fatal(err)
+	}
+	srv.fireSchedules(day(20, 2))
+	third, _ := srv.store.search(state().LastSearch)
+	if third.ID == second.ID || third.Params.Above != 100 || third.Params.Limit != 200 {
+		t.Errorf("recherche annulée non soumise de nouveau: %+v", third)
+	}
+	run()
+
+	// Un nouveau serveur sur la même base rattrape le déclenchement manqué pendant l'arrêt.
+	restarted := newSearchServer(srv.store, 1, 1, io.Discard)
+	restarted.schedules = srv.schedules
+	restarted.fireSchedules(day(23, 12))
+	fourth, _ := srv.store.search(state().LastSearch)
+	if st := state(); st.Frontier != 200 || fourth.Params.Above != 200 || fourth.Params.Limit != 300 {
+		t.Errorf("rattrapage = %+v, état %+v", fourth, st)
+	}
+	statuses, err := restarted.scheduleStatuses(day(23, 12))
+	if err != nil || len(statuses) != 1 || !statuses[0].Next.Equal(day(24, 2)) || statuses[0].Frontier != 200 {
+		t.Errorf("états = %+v, %v", statuses, err)
+	}
+}
+
+// TestRunServeSchedule vérifie les refus des planifications de serve, en option ou dans le
+// fichier d'options.
+func TestRunServeSchedule(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "serve.conf")
+	if err := os.WriteFile(config, []byte("schedule = nuit: 0 2 * * * extend=10 form=x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-dir", t.TempDir(), "-schedule", "nuit: 0 25 * * * extend=10"},
+		{"-dir", t.TempDir(), "-schedule", "nuit: 0 2 * * * extend=10 workers=99", "-workers", "2"},
+		{"-dir", t.TempDir(), "-config", config},
+	} {
+		if err := runServe(args, io.Discard, io.Discard); !errors.Is(err, errInvalidFlags) {
+			t.Errorf("serve %v: %v, attendu errInvalidFlags", args, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Fichier: sdnotify.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Intégration à systemd (protocole sd_notify), sans dépendance: lancée comme
+ * service Type=notify, l'exécution envoie READY=1 une fois le crible calculé,
+ * puis WATCHDOG=1 depuis la boucle de collecte des résultats si le service
+ * déclare WatchdogSec=. Une recherche bloquée cesse ainsi d'envoyer ses
+ * signes de vie et systemd la redémarre. Hors systemd (NOTIFY_SOCKET absent),
+ * rien n'est envoyé.
+ */
+package main
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// sdNotifier envoie les notifications d'état au gestionnaire de services (systemd).
+type sdNotifier struct {
+	primes.NopReporter
+	addr     string        // Socket datagramme NOTIFY_SOCKET ('@' pour l'espace de noms abstrait).
+	watchdog time.Duration // Délai du chien de garde (WATCHDOG_USEC); 0: désactivé.
+	lastPing time.Time
+}
+
+// newSDNotifier lit NOTIFY_SOCKET et WATCHDOG_USEC par getenv. Retourne nil hors systemd. Le chien de
+// garde est ignoré si WATCHDOG_PID désigne un autre processus.
+func newSDNotifier(getenv func(string) string) *sdNotifier {
+	addr := getenv("NOTIFY_SOCKET")
+	if addr == "" {
+		return nil
+	}
+	n := &sdNotifier{addr: addr}
+	if pid := getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return n
+	}
+	if usec, err := strconv.ParseInt(getenv("WATCHDOG_USEC"), 10, 64); err == nil && usec > 0 {
+		n.watchdog = time.Duration(usec) * time.Microsecond
+	}
+	return n
+}
+
+// notify envoie state (ex: "READY=1") au gestionnaire de services. Sans effet sur un notifier nil.
+func (n *sdNotifier) notify(state string) error {
+	if n == nil {
+		return nil
+	}
+	conn, err := net.Dial("unixgram", n.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// ping envoie WATCHDOG=1 si la moitié du délai du chien de garde s'est écoulée depuis le dernier
+// envoi, comme le recommande sd_watchdog_enabled(3). Sans effet sur un notifier nil ou sans chien
+// de garde. Appelée depuis une seule goroutine.
+// OnBatchDone envoie un signe de vie à chaque état d'avancement, émis par la boucle de collecte
+// (primes.ProgressReporter).
+func (n *sdNotifier) OnBatchDone(primes.Progress) { n.ping(time.Now()) }
+
+func (n *sdNotifier) ping(now time.Time) {
+	if n == nil || n.watchdog == 0 || now.Sub(n.lastPing) < n.watchdog/2 {
+		return
+	}
+	n.lastPing = now
+	n.notify("WATCHDOG=1") // Un envoi manqué est rattrapé au suivant, avant l'expiration du délai.
+}
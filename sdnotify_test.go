@@ -0,0 +1,72 @@
+//go:build unix
+
+/*
+ * Fichier: sdnotify_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'intégration systemd (sd_notify): lecture de l'environnement et
+ * notifications reçues par un faux gestionnaire de services.
+ */
+package main
+
+import (
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestNewSDNotifier valide la lecture de NOTIFY_SOCKET, WATCHDOG_USEC et WATCHDOG_PID.
+func TestNewSDNotifier(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	if n := newSDNotifier(env(nil)); n != nil {
+		t.Errorf("hors systemd: %+v, attendu nil", n)
+	}
+	if err := (*sdNotifier)(nil).notify("READY=1"); err != nil {
+		t.Errorf("notify sur nil: %v", err)
+	}
+	n := newSDNotifier(env(map[string]string{"NOTIFY_SOCKET": "/run/notify", "WATCHDOG_USEC": "30000000", "WATCHDOG_PID": strconv.Itoa(os.Getpid())}))
+	if n == nil || n.addr != "/run/notify" || n.watchdog != 30*time.Second {
+		t.Errorf("notifier = %+v", n)
+	}
+	n = newSDNotifier(env(map[string]string{"NOTIFY_SOCKET": "/run/notify", "WATCHDOG_USEC": "30000000", "WATCHDOG_PID": "1"}))
+	if n == nil || n.watchdog != 0 {
+		t.Errorf("chien de garde d'un autre processus: %+v", n)
+	}
+}
+
+// TestSDNotifyRun valide les notifications d'une recherche lancée sous un faux systemd.
+func TestSDNotifyRun(t *testing.T) {
+	path := shortSocketPath(t)
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	t.Setenv("NOTIFY_SOCKET", path)
+	t.Setenv("WATCHDOG_USEC", "1") // Un signe de vie à chaque progression.
+
+	if err := run([]string{"-limit", "300", "-workers", "1"}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	var states []string
+	buf := make([]byte, 256)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			break
+		}
+		states = append(states, string(buf[:n]))
+	}
+	if len(states) < 3 || states[0] != "READY=1" || states[len(states)-1] != "STOPPING=1" || !slices.Contains(states, "WATCHDOG=1") {
+		t.Errorf("notifications = %v, attendu READY=1, WATCHDOG=1..., STOPPING=1", states)
+	}
+}
@@ -0,0 +1,820 @@
+/*
+ * Fichier: server.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande serve: mode serveur REST. Les recherches soumises
+ * (POST /searches) sont placées dans une file; au plus -max-concurrent
+ * d'entre elles s'exécutent à la fois, dans l'ordre des soumissions, chacune
+ * avec son budget de workers (-workers par défaut, et au plus). Une recherche
+ * est découpée en tranches de p, comme une campagne chunks, exécutées l'une
+ * après l'autre sous un bail; l'état des recherches, des tranches et les
+ * résultats sont tenus dans la base embarquée (serverstore.go), si bien qu'un
+ * redémarrage reprend les recherches en cours à leurs tranches en attente.
+ * Les résultats se lisent par pages (GET /searches/{id}/results), par n
+ * croissant, avec un curseur et un intervalle de n facultatif.
+ * L'option above d'une soumission prolonge une recherche déjà menée jusqu'à
+ * cette limite: seules les paires dont p ou q la dépasse sont testées.
+ * Des workers distants (client work) réservent aussi des tranches
+ * (POST /leases), sous un bail à échéance qu'ils renouvellent, et en envoient
+ * les résultats (POST /leases/{id}/results). Baux et validations sont
+ * persistés: un bail échu rend sa tranche à l'attente, et des résultats
+ * renvoyés par un worker qui se reconnecte ne sont pas comptés deux fois.
+ * Avec -local=false, le serveur ne fait que coordonner les workers.
+ * Une soumission identique (mêmes paramètres déterminants, voir
+ * searchParams.hash) à une recherche en file, en cours ou achevée est refusée,
+ * ignorée ou ajoutée sous un nouvel identifiant selon -on-duplicate ou le
+ * champ on_duplicate de la soumission.
+ * Le contrôle d'accès (jetons, débit et recherches actives par jeton) est
+ * dans serverauth.go.
+ */
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// serverDBName est le nom de la base du serveur dans son répertoire.
+const serverDBName = "server.db"
+
+// Taille des pages de GET /searches/{id}/results: par défaut et au plus.
+const (
+	defaultResultPage = 1000
+	maxResultPage     = 10_000
+)
+
+// serverShutdownTimeout borne l'attente des requêtes HTTP en cours à l'arrêt du serveur.
+const serverShutdownTimeout = 5 * time.Second
+
+// defaultLeaseTTL est la durée par défaut des baux des workers distants (-lease-ttl).
+const defaultLeaseTTL = 10 * time.Minute
+
+// serverTickInterval espace les passes de la file sans événement, qui rendent à l'attente les
+// tranches des baux échus.
+const serverTickInterval = 5 * time.Second
+
+// Politiques d'une soumission identique à une recherche déjà soumise (-on-duplicate).
+const (
+	duplicateFail   = "fail"   // Refuser la soumission (409).
+	duplicateSkip   = "skip"   // Rendre la recherche existante au lieu d'en créer une.
+	duplicateAppend = "append" // Créer la recherche sous un nouvel identifiant, liée à l'existante.
+)
+
+// duplicatePolicies liste les politiques de -on-duplicate.
+var duplicatePolicies = []string{duplicateFail, duplicateSkip, duplicateAppend}
+
+// errDuplicateSearch signale une soumission identique à une recherche déjà soumise.
+var errDuplicateSearch = errors.New("recherche identique déjà soumise")
+
+// searchParams sont les paramètres d'une recherche soumise au serveur.
+type searchParams struct {
+	Limit     int    `json:"limit"`
+	Above     int    `json:"above,omitempty"` // Frontière: seules les paires avec max(p, q) > Above sont testées.
+	Form      string `json:"form,omitempty"`
+	PrimeTest string `json:"primetest,omitempty"`
+	Pairs     string `json:"pairs,omitempty"`
+	Chunks    int    `json:"chunks,omitempty"`
+	Workers   int    `json:"workers,omitempty"` // Budget de workers (0: celui du serveur).
+}
+
+// normalize complète les paramètres par leurs valeurs par défaut (budget du serveur: budget) et
+// vérifie leur cohérence; l'erreur enveloppe errInvalidFlags.
+func (p searchParams) normalize(budget int) (searchParams, error) {
+	p.Form = cmp.Or(p.Form, primes.DefaultForm.Name())
+	p.PrimeTest = cmp.Or(p.PrimeTest, "miller")
+	p.Pairs = cmp.Or(p.Pairs, primes.PairsAll.String())
+	p.Chunks = cmp.Or(p.Chunks, 16)
+	p.Workers = cmp.Or(p.Workers, budget)
+	form, ok := primes.LookupForm(p.Form)
+	switch {
+	case p.Limit < 2:
+		return p, fmt.Errorf("%w: limit=%d (attendu >= 2)", errInvalidFlags, p.Limit)
+	case p.Above < 0 || p.Above >= p.Limit:
+		return p, fmt.Errorf("%w: above=%d (attendu dans [0, %d[)", errInvalidFlags, p.Above, p.Limit)
+	case !ok:
+		return p, fmt.Errorf("%w: form=%q (attendu l'une de %v)", errInvalidFlags, p.Form, primes.FormNames())
+	case !slices.Contains(primes.PrimalityTestNames(), p.PrimeTest):
+		return p, fmt.Errorf("%w: primetest=%q (attendu l'un de %v)", errInvalidFlags, p.PrimeTest, primes.PrimalityTestNames())
+	case p.Chunks < 1:
+		return p, fmt.Errorf("%w: chunks=%d (attendu >= 1)", errInvalidFlags, p.Chunks)
+	case p.Workers < 1 || p.Workers > budget:
+		return p, fmt.Errorf("%w: workers=%d (attendu dans [1, %d], budget du serveur)", errInvalidFlags, p.Workers, budget)
+	}
+	if _, ok := primes.LookupPairMode(p.Pairs); !ok {
+		return p, fmt.Errorf("%w: pairs=%q (attendu l'un de %v)", errInvalidFlags, p.Pairs, primes.PairModeNames())
+	}
+	return p, primes.CheckFormLimit(form, p.Limit)
+}
+
+// hash retourne l'empreinte SHA-256 des paramètres normalisés qui déterminent les résultats:
+// limite, frontière, forme, test et paires, mais ni le découpage en tranches ni les workers.
+func (p searchParams) hash() string {
+	data, _ := json.Marshal([]any{p.Limit, p.Above, p.Form, p.PrimeTest, p.Pairs})
+	return sha256Hex(data)
+}
+
+// submitRequest est le corps de POST /searches: les paramètres de la recherche et la politique
+// d'une soumission identique ("": celle du serveur).
+type submitRequest struct {
+	searchParams
+	OnDuplicate string `json:"on_duplicate,omitempty"`
+}
+
+// searchServer conduit les recherches du serveur: file, lancement et exécution des tranches.
+type searchServer struct {
+	store         *serverStore
+	maxConcurrent int              // Recherches exécutées à la fois.
+	workers       int              // Budget de workers par recherche: défaut et plafond des soumissions.
+	local         bool             // Les tranches s'exécutent aussi sur le serveur, pas seulement chez les workers.
+	leaseTTL      time.Duration    // Durée des baux des workers distants, renouvelables.
+	tokens        []serverToken    // Jetons d'accès (nil: API ouverte).
+	limiter       *rateLimiter     // Débit des requêtes par jeton (nil: illimité).
+	maxSearches   int              // Recherches actives (en file ou en cours) par jeton (0: illimitées).
+	onDuplicate   string           // Politique par défaut d'une soumission identique (duplicatePolicies).
+	schedules     []searchSchedule // Recherches récurrentes (scheduler.go).
+	submitMu      sync.Mutex       // Sérialise le décompte des recherches actives et la soumission.
+
+	logMu sync.Mutex
+	log   io.Writer // Journal des événements des recherches.
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc // Recherches dont les tranches s'exécutent ici.
+	wg      sync.WaitGroup
+	wake    chan struct{}
+}
+
+// newSearchServer retourne le serveur des recherches de store, qui journalise sur log.
+func newSearchServer(store *serverStore, maxConcurrent, workers int, log io.Writer) *searchServer {
+	return &searchServer{
+		store:         store,
+		maxConcurrent: maxConcurrent,
+		workers:       workers,
+		local:         true,
+		leaseTTL:      defaultLeaseTTL,
+		onDuplicate:   duplicateFail,
+		log:           log,
+		running:       make(map[string]context.CancelFunc),
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// logf écrit un événement dans le journal.
+func (s *searchServer) logf(id msgID, args ...any) {
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+	fmt.Fprint(s.log, tr(id, args...))
+}
+
+// notify demande une nouvelle passe de la file (soumission, place libérée).
+func (s *searchServer) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run fait tourner la file jusqu'à l'annulation de ctx, puis attend la fin des tranches en cours
+// (interrompues, elles retournent à l'attente). Une passe a lieu à chaque réveil et au moins
+// toutes les serverTickInterval, pour les baux échus et les planifications.
+func (s *searchServer) run(ctx context.Context) {
+	ticker := time.NewTicker(serverTickInterval)
+	defer ticker.Stop()
+	for {
+		s.fireSchedules(time.Now())
+		if err := s.dispatch(ctx); err != nil {
+			s.logf(msgServeError, err)
+		}
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch fait une passe de la file: les tranches des baux échus retournent à l'attente, les
+// recherches en cours sans exécution locale (après un redémarrage, ou une tranche rendue à
+// l'attente) reprennent, puis les recherches en file sont lancées dans l'ordre des soumissions
+// tant que moins de maxConcurrent recherches sont en cours.
+func (s *searchServer) dispatch(ctx context.Context) error {
+	if n, err := s.store.expireLeases(time.Now()); err != nil {
+		return err
+	} else if n > 0 {
+		s.logf(msgServeLeasesExpired, n)
+	}
+	list, err := s.store.searches()
+	if err != nil {
+		return err
+	}
+	active := 0
+	for _, rec := range list {
+		if rec.State == searchRunning {
+			active++
+			s.start(ctx, rec)
+		}
+	}
+	for _, rec := range list {
+		if rec.State != searchQueued || active >= s.maxConcurrent {
+			continue
+		}
+		rec, err := s.store.updateSearch(rec.ID, func(rec *serverSearch) error {
+			if rec.State != searchQueued {
+				return errSearchFinished // Annulée depuis la lecture de la file.
+			}
+			rec.State, rec.Started = searchRunning, time.Now().UTC()
+			return nil
+		})
+		if errors.Is(err, errSearchFinished) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		active++
+		s.logf(msgServeSearchStarted, rec.ID, rec.Params.Limit, rec.Chunks, rec.Params.Workers)
+		s.start(ctx, rec)
+	}
+	return nil
+}
+
+// start exécute en arrière-plan les tranches de la recherche rec, sauf si c'est déjà le cas ou si
+// elles ne s'exécutent que chez les workers distants.
+func (s *searchServer) start(ctx context.Context, rec serverSearch) {
+	if !s.local {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.running[rec.ID]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.running[rec.ID] = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		finished := s.runSearch(ctx, rec)
+		s.mu.Lock()
+		delete(s.running, rec.ID)
+		s.mu.Unlock()
+		cancel()
+		if finished {
+			s.notify() // Une place se libère dans la file.
+		}
+	}()
+}
+
+// stop interrompt l'exécution des tranches de la recherche id, s'il y en a une.
+func (s *searchServer) stop(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cancel, ok := s.running[id]; ok {
+		cancel()
+	}
+}
+
+// runSearch exécute les tranches en attente de la recherche rec, l'une après l'autre, avec son
+// budget de workers. Il indique si la recherche a atteint un état final.
+func (s *searchServer) runSearch(ctx context.Context, rec serverSearch) (finished bool) {
+	var primeList []int // Crible calculé à la première tranche.
+	for {
+		lease, chunk, ok, err := s.store.leaseChunk(rec.ID, localWorker, 0)
+		if err != nil {
+			s.logf(msgServeError, err)
+			return false
+		}
+		if !ok {
+			return false
+		}
+		if primeList == nil {
+			primeList = primes.SieveOfEratosthenes(rec.Params.Limit)
+		}
+		data, _, err := searchChunk(ctx, rec.Params, chunk, primeList)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Arrêt du serveur ou annulation: la tranche retourne à l'attente.
+				if err := s.store.releaseLease(lease.ID); err != nil {
+					s.logf(msgServeError, err)
+				}
+				return false
+			}
+			if _, ferr := s.store.finishSearch(rec.ID, searchFailed, err.Error()); ferr != nil {
+				s.logf(msgServeError, ferr)
+			}
+			s.logf(msgServeSearchFailed, rec.ID, err)
+			return true
+		}
+		done, _, err := s.store.commitChunk(lease.ID, data)
+		if errors.Is(err, errSearchFinished) {
+			return false // Recherche annulée pendant la tranche.
+		}
+		if err != nil {
+			s.logf(msgServeError, err)
+			return false
+		}
+		if s.committed(done) {
+			return true
+		}
+	}
+}
+
+// committed journalise l'achèvement de la recherche rec, dont une tranche vient d'être validée, et
+// l'indique.
+func (s *searchServer) committed(rec serverSearch) bool {
+	if rec.State != searchDone {
+		return false
+	}
+	s.logf(msgServeSearchDone, rec.ID, rec.Results)
+	return true
+}
+
+// searchChunk recherche les résultats de la tranche c de la recherche de paramètres params et
+// retourne leur contenu NDJSON (voir searchNDJSON). Au-delà d'une frontière (Above), seules les
+// paires dont p ou q la dépasse sont testées.
+func searchChunk(ctx context.Context, params searchParams, c chunkEntry, primeList []int) ([]byte, int, error) {
+	form, _ := primes.LookupForm(params.Form)
+	pairs, _ := primes.LookupPairMode(params.Pairs)
+	src := primes.NewFrontierSource(primes.NewGridSource(primeList, pairs, c.PMin, c.PMax), params.Above)
+	return searchNDJSON(ctx, primes.Options{
+		Jobs:      src,
+		PrimeTest: params.PrimeTest,
+		Workers:   params.Workers,
+		Form:      form,
+	})
+}
+
+// submit enregistre une recherche du jeton owner en file et réveille la file; created vaut false
+// si une recherche identique existante est rendue à la place (politique skip). Une recherche est
+// identique si ses paramètres ont la même empreinte et qu'elle n'a ni échoué ni été annulée; la
+// politique onDuplicate ("": celle du serveur) décide alors. Au-delà de maxSearches recherches
+// actives du jeton, la soumission est refusée (errTooManyRequests).
+func (s *searchServer) submit(owner string, params searchParams, onDuplicate string) (rec serverSearch, created bool, err error) {
+	params, err = params.normalize(s.workers)
+	if err != nil {
+		return rec, false, err
+	}
+	onDuplicate = cmp.Or(onDuplicate, s.onDuplicate)
+	if !slices.Contains(duplicatePolicies, onDuplicate) {
+		return rec, false, fmt.Errorf("%w: on_duplicate=%q (attendu l'une de %v)", errInvalidFlags, onDuplicate, duplicatePolicies)
+	}
+	hash := params.hash()
+	s.submitMu.Lock()
+	defer s.submitMu.Unlock()
+	list, err := s.store.searches()
+	if err != nil {
+		return rec, false, err
+	}
+	var previous *serverSearch
+	active := 0
+	for i, other := range list {
+		if other.Owner == owner && !other.finished() {
+			active++
+		}
+		if previous == nil && other.State != searchFailed && other.State != searchCancelled && other.Params.hash() == hash {
+			previous = &list[i]
+		}
+	}
+	if previous != nil {
+		switch onDuplicate {
+		case duplicateFail:
+			return rec, false, fmt.Errorf("%w: %s (%s, empreinte %s)", errDuplicateSearch, previous.ID, previous.State, hash)
+		case duplicateSkip:
+			return *previous, false, nil
+		}
+	}
+	if s.maxSearches > 0 && active >= s.maxSearches {
+		return rec, false, fmt.Errorf("%w: %s: %d recherches actives (au plus %d)", errTooManyRequests, cmp.Or(owner, "client"), active, s.maxSearches)
+	}
+	rec = serverSearch{Owner: owner, Params: params, ParamsHash: hash, Submitted: time.Now().UTC()}
+	if previous != nil {
+		rec.DuplicateOf = previous.ID
+	}
+	primeList := primes.SieveOfEratosthenes(params.Limit)
+	if rec, err = s.store.createSearch(rec, splitChunks(primeList, params.Chunks)); err != nil {
+		return rec, false, err
+	}
+	s.notify()
+	return rec, true, nil
+}
+
+// cancel annule la recherche id, en file ou en cours, pour le jeton owner: une recherche soumise
+// avec un autre jeton est refusée (errForbidden).
+func (s *searchServer) cancel(owner, id string) (serverSearch, error) {
+	rec, err := s.store.search(id)
+	if err != nil {
+		return rec, err
+	}
+	if rec.Owner != owner {
+		return rec, fmt.Errorf("%w: %s", errForbidden, id)
+	}
+	rec, err = s.store.finishSearch(id, searchCancelled, "")
+	if err != nil {
+		return rec, err
+	}
+	s.stop(id)
+	s.notify()
+	return rec, nil
+}
+
+// handler retourne le routeur HTTP de l'API REST, derrière le contrôle d'accès.
+func (s *searchServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /searches", s.handleSubmit)
+	mux.HandleFunc("GET /searches", s.handleList)
+	mux.HandleFunc("GET /searches/{id}", s.handleGet)
+	mux.HandleFunc("DELETE /searches/{id}", s.handleCancel)
+	mux.HandleFunc("GET /searches/{id}/results", s.handleResults)
+	mux.HandleFunc("GET /schedules", s.handleSchedules)
+	mux.HandleFunc("POST /leases", s.handleLease)
+	mux.HandleFunc("POST /leases/{id}/renew", s.handleRenew)
+	mux.HandleFunc("POST /leases/{id}/release", s.handleRelease)
+	mux.HandleFunc("POST /leases/{id}/results", s.handleCommit)
+	return s.authenticate(mux)
+}
+
+// handleSubmit soumet la recherche décrite par le corps JSON de la requête (submitRequest): 201
+// pour une recherche créée, 200 pour une recherche identique existante rendue à sa place.
+func (s *searchServer) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req submitRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeHTTPError(w, fmt.Errorf("%w: corps de la requête: %v", errInvalidInput, err))
+		return
+	}
+	rec, created, err := s.submit(requestOwner(r), req.searchParams, req.OnDuplicate)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/searches/"+rec.ID)
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, rec)
+}
+
+// handleList retourne toutes les recherches.
+func (s *searchServer) handleList(w http.ResponseWriter, r *http.Request) {
+	list, err := s.store.searches()
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nonNil(list))
+}
+
+// handleGet retourne l'état d'une recherche.
+func (s *searchServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	rec, err := s.store.search(r.PathValue("id"))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rec)
+}
+
+// handleCancel annule une recherche.
+func (s *searchServer) handleCancel(w http.ResponseWriter, r *http.Request) {
+	rec, err := s.cancel(requestOwner(r), r.PathValue("id"))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rec)
+}
+
+// resultPageResponse est une page de GET /searches/{id}/results.
+type resultPageResponse struct {
+	Results []json.RawMessage `json:"results"`        // Résultats au format NDJSON de la sortie (jsonResult).
+	Next    string            `json:"next,omitempty"` // Curseur de la page suivante (paramètre after), absent à la fin.
+}
+
+// handleResults retourne une page des résultats d'une recherche, par n croissant: au plus limit
+// résultats (défaut: defaultResultPage) après le curseur after, restreints à n dans
+// [n_min, n_max]. Une recherche en cours rend les résultats de ses tranches déjà terminées.
+func (s *searchServer) handleResults(w http.ResponseWriter, r *http.Request) {
+	after, limit, nMin, nMax, err := parseResultQuery(r.URL.Query())
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	page, next, err := s.store.resultPage(r.PathValue("id"), after, limit, nMin, nMax)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resultPageResponse{Results: nonNil(page), Next: hex.EncodeToString(next)})
+}
+
+// handleSchedules retourne l'état des planifications: frontière, dernier déclenchement, dernière
+// recherche et prochain déclenchement.
+func (s *searchServer) handleSchedules(w http.ResponseWriter, r *http.Request) {
+	list, err := s.scheduleStatuses(time.Now())
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// leaseRequest est le corps de POST /leases.
+type leaseRequest struct {
+	Worker string `json:"worker"` // Nom du worker, repris dans le journal et les baux.
+}
+
+// leaseGrant est la réponse de POST /leases: la tranche réservée et ce qu'il faut pour la calculer.
+type leaseGrant struct {
+	Lease   string       `json:"lease"`
+	Search  string       `json:"search"`
+	Chunk   chunkEntry   `json:"chunk"`
+	Params  searchParams `json:"params"`
+	Expires time.Time    `json:"expires"`
+}
+
+// commitResponse est la réponse de POST /leases/{id}/results.
+type commitResponse struct {
+	Duplicate bool         `json:"duplicate"` // Résultats déjà validés: ils n'ont pas été comptés à nouveau.
+	Search    serverSearch `json:"search"`
+}
+
+// handleLease réserve au worker nommé dans le corps JSON (leaseRequest) la première tranche
+// disponible des recherches en cours, pour leaseTTL; 204 s'il n'y en a aucune.
+func (s *searchServer) handleLease(w http.ResponseWriter, r *http.Request) {
+	var req leaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeHTTPError(w, fmt.Errorf("%w: corps de la requête: %v", errInvalidInput, err))
+		return
+	}
+	if req.Worker == "" || req.Worker == localWorker {
+		writeHTTPError(w, fmt.Errorf("%w: worker=%q (nom manquant ou réservé)", errInvalidInput, req.Worker))
+		return
+	}
+	lease, chunk, ok, err := s.store.leaseNext(req.Worker, s.leaseTTL)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	rec, err := s.store.search(lease.Search)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	s.logf(msgServeLeaseGranted, lease.Search, chunk.Name, req.Worker)
+	writeJSON(w, http.StatusOK, leaseGrant{Lease: lease.ID, Search: lease.Search, Chunk: chunk, Params: rec.Params, Expires: lease.Expires})
+}
+
+// handleRenew repousse l'échéance d'un bail actif; 410 s'il ne l'est plus, et le worker abandonne
+// alors sa tranche.
+func (s *searchServer) handleRenew(w http.ResponseWriter, r *http.Request) {
+	lease, err := s.store.renewLease(r.PathValue("id"), s.leaseTTL)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, lease)
+}
+
+// handleRelease rend la tranche d'un bail à l'attente, sans résultat (arrêt du worker).
+func (s *searchServer) handleRelease(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.releaseLease(r.PathValue("id")); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	s.notify()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleCommit valide les résultats d'une tranche, envoyés en NDJSON dans le corps de la requête.
+// Un envoi répété est reconnu et n'est pas compté deux fois (duplicate).
+func (s *searchServer) handleCommit(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeHTTPError(w, fmt.Errorf("%w: corps de la requête: %v", errInvalidInput, err))
+		return
+	}
+	rec, dup, err := s.store.commitChunk(r.PathValue("id"), data)
+	if errors.Is(err, errSearchFinished) {
+		// Recherche annulée pendant le calcul: le bail est caduc, le worker abandonne la tranche.
+		err = fmt.Errorf("%w: %w", errLeaseNotFound, err)
+	}
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if !dup {
+		s.committed(rec)
+		s.notify()
+	}
+	writeJSON(w, http.StatusOK, commitResponse{Duplicate: dup, Search: rec})
+}
+
+// parseResultQuery lit les paramètres de pagination et de filtrage de GET /searches/{id}/results;
+// l'erreur enveloppe errInvalidInput.
+func parseResultQuery(q url.Values) (after []byte, limit int, nMin, nMax int64, err error) {
+	limit, nMax = defaultResultPage, math.MaxInt64
+	if v := q.Get("after"); v != "" {
+		if after, err = hex.DecodeString(v); err != nil || len(after) != resultKeyLen {
+			return nil, 0, 0, 0, fmt.Errorf("%w: after=%q (curseur invalide)", errInvalidInput, v)
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 || limit > maxResultPage {
+			return nil, 0, 0, 0, fmt.Errorf("%w: limit=%q (attendu dans [1, %d])", errInvalidInput, v, maxResultPage)
+		}
+	}
+	for _, bound := range []struct {
+		name string
+		v    *int64
+	}{{"n_min", &nMin}, {"n_max", &nMax}} {
+		if v := q.Get(bound.name); v != "" {
+			if *bound.v, err = strconv.ParseInt(v, 10, 64); err != nil || *bound.v < 0 {
+				return nil, 0, 0, 0, fmt.Errorf("%w: %s=%q (attendu un entier >= 0)", errInvalidInput, bound.name, v)
+			}
+		}
+	}
+	return after, limit, nMin, nMax, nil
+}
+
+// nonNil retourne list, ou une liste vide (et non null en JSON) si elle est nil.
+func nonNil[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
+
+// writeJSON écrit v en JSON avec le statut HTTP status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeHTTPError écrit l'erreur err en JSON ({"error": ...}), avec le statut HTTP de sa sentinelle.
+func writeHTTPError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errSearchNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, errUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, errForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, errTooManyRequests):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, errLeaseNotFound):
+		status = http.StatusGone
+	case errors.Is(err, errSearchFinished), errors.Is(err, errVerification), errors.Is(err, errDuplicateSearch):
+		status = http.StatusConflict
+	case errors.Is(err, errInvalidFlags), errors.Is(err, errInvalidInput), errors.Is(err, primes.ErrOverflow):
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// runServe implémente la sous-commande serve; elle rend la main à la réception de SIGINT ou
+// SIGTERM, une fois les tranches en cours interrompues et retournées à l'attente.
+func runServe(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	listenPtr := fs.String("listen", "localhost:8080", tr(msgFlagServeListen))
+	dirPtr := fs.String("dir", "", tr(msgFlagServeDir))
+	maxConcurrentPtr := fs.Int("max-concurrent", 1, tr(msgFlagServeMaxConcurrent))
+	workersPtr := fs.Int("workers", defaultWorkers(), tr(msgFlagServeWorkers))
+	localPtr := fs.Bool("local", true, tr(msgFlagServeLocal))
+	leaseTTLPtr := fs.Duration("lease-ttl", defaultLeaseTTL, tr(msgFlagServeLeaseTTL))
+	tokensPtr := fs.String("tokens", "", tr(msgFlagServeTokens))
+	ratePtr := fs.Float64("rate", 20, tr(msgFlagServeRate))
+	burstPtr := fs.Int("burst", 40, tr(msgFlagServeBurst))
+	maxSearchesPtr := fs.Int("max-searches", 8, tr(msgFlagServeMaxSearches))
+	onDuplicatePtr := fs.String("on-duplicate", duplicateFail, tr(msgFlagServeOnDuplicate, strings.Join(duplicatePolicies, ", ")))
+	var schedules scheduleList
+	fs.Var(&schedules, "schedule", tr(msgFlagServeSchedule))
+	configPtr := fs.String("config", "", tr(msgFlagServeConfig))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgServeUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	// Le fichier d'options complète les options absentes de la ligne de commande, planifications
+	// comprises (lignes "schedule = ...").
+	if *configPtr != "" {
+		if _, err := applyConfigFile(fs, *configPtr); err != nil {
+			return err
+		}
+	}
+	if *dirPtr == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: serve: -dir est requis, sans argument", errInvalidFlags)
+	}
+	if *maxConcurrentPtr < 1 || *workersPtr < 1 {
+		return fmt.Errorf("%w: serve: -max-concurrent=%d, -workers=%d (attendu >= 1)", errInvalidFlags, *maxConcurrentPtr, *workersPtr)
+	}
+	if *leaseTTLPtr < time.Second {
+		return fmt.Errorf("%w: serve: -lease-ttl=%v (attendu >= 1s)", errInvalidFlags, *leaseTTLPtr)
+	}
+	if *ratePtr < 0 || *burstPtr < 1 || *maxSearchesPtr < 0 {
+		return fmt.Errorf("%w: serve: -rate=%g, -burst=%d, -max-searches=%d (attendu -rate >= 0, -burst >= 1, -max-searches >= 0)", errInvalidFlags, *ratePtr, *burstPtr, *maxSearchesPtr)
+	}
+	if !slices.Contains(duplicatePolicies, *onDuplicatePtr) {
+		return fmt.Errorf("%w: serve: -on-duplicate=%q (attendu l'une de %v)", errInvalidFlags, *onDuplicatePtr, duplicatePolicies)
+	}
+	for _, sched := range schedules {
+		if _, err := sched.searchParams(sched.from).normalize(*workersPtr); err != nil {
+			return fmt.Errorf("%w: serve: -schedule %q: %v", errInvalidFlags, sched.text, err)
+		}
+	}
+	var tokens []serverToken
+	if *tokensPtr != "" {
+		var err error
+		if tokens, err = loadTokens(*tokensPtr); err != nil {
+			return err
+		}
+	} else if !isLoopback(*listenPtr) {
+		return fmt.Errorf("%w: serve: -listen %s hors de la boucle locale exige -tokens", errInvalidFlags, *listenPtr)
+	}
+	if err := os.MkdirAll(*dirPtr, 0o755); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	store, err := openServerStore(filepath.Join(*dirPtr, serverDBName))
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	// Les baux du serveur lui-même ont été interrompus par son arrêt: leurs tranches sont à refaire.
+	if err := store.releaseWorkerLeases(localWorker); err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", *listenPtr)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	srv := newSearchServer(store, *maxConcurrentPtr, *workersPtr, stderr)
+	srv.local, srv.leaseTTL = *localPtr, *leaseTTLPtr
+	srv.tokens, srv.limiter, srv.maxSearches = tokens, newRateLimiter(*ratePtr, *burstPtr), *maxSearchesPtr
+	srv.onDuplicate, srv.schedules = *onDuplicatePtr, schedules
+	httpSrv := &http.Server{Handler: srv.handler()}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	queueDone := make(chan struct{})
+	go func() {
+		srv.run(ctx)
+		close(queueDone)
+	}()
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- httpSrv.Serve(ln) }()
+	fmt.Fprint(stdout, tr(msgServeListening, ln.Addr(), store.db.Path()))
+
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+		stop()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+	httpSrv.Shutdown(shutdownCtx)
+	<-queueDone
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	fmt.Fprint(stdout, tr(msgServeStopped))
+	return nil
+}
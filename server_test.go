@@ -0,0 +1,420 @@
+/*
+ * Fichier: server_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du mode serveur: API REST, file et plafond de recherches simultanées,
+ * budget de workers, prolongation au-delà d'une frontière, pages de
+ * résultats, baux des workers distants, annulation et reprise après
+ * redémarrage.
+ */
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// newTestServer retourne un serveur de recherches sur une base temporaire, sans file en marche.
+func newTestServer(t *testing.T, maxConcurrent, workers int) *searchServer {
+	t.Helper()
+	store, err := openServerStore(filepath.Join(t.TempDir(), serverDBName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return newSearchServer(store, maxConcurrent, workers, io.Discard)
+}
+
+// countResults compte les résultats de la grille jusqu'à limit avec la forme et les paires par
+// défaut, en une recherche directe.
+func countResults(t *testing.T, limit int) int {
+	t.Helper()
+	count := 0
+	err := primes.Search(context.Background(), primes.Options{Limit: limit, Workers: 1}, func(primes.Result) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+// doJSON envoie une requête (corps JSON body s'il n'est pas nil) et décode la réponse dans out.
+func doJSON(t *testing.T, method, url string, body any, out any) int {
+	t.Helper()
+	var r io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		r = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequest(method, url, r)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: réponse illisible: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// waitSearch interroge la recherche id jusqu'à ce qu'elle soit terminée.
+func waitSearch(t *testing.T, base, id string) serverSearch {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		var rec serverSearch
+		if status := doJSON(t, http.MethodGet, base+"/searches/"+id, nil, &rec); status != http.StatusOK {
+			t.Fatalf("GET /searches/%s: statut %d", id, status)
+		}
+		if rec.finished() {
+			return rec
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("recherche %s non terminée: %+v", id, rec)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestServerSearch soumet des recherches par l'API et compare leurs résultats à une recherche
+// directe, y compris la prolongation d'une recherche au-delà d'une frontière.
+func TestServerSearch(t *testing.T) {
+	srv := newTestServer(t, 2, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.run(ctx)
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+
+	var full, frontier serverSearch
+	if status := doJSON(t, http.MethodPost, ts.URL+"/searches", searchParams{Limit: 300, Chunks: 4}, &full); status != http.StatusCreated {
+		t.Fatalf("soumission: statut %d", status)
+	}
+	if full.State != searchQueued || full.Chunks != 4 || full.Params.Workers != 2 || full.Params.Form != primes.DefaultForm.Name() {
+		t.Errorf("recherche soumise = %+v", full)
+	}
+	doJSON(t, http.MethodPost, ts.URL+"/searches", searchParams{Limit: 300, Above: 150, Chunks: 3, Workers: 1}, &frontier)
+	full, frontier = waitSearch(t, ts.URL, full.ID), waitSearch(t, ts.URL, frontier.ID)
+
+	if want := countResults(t, 300); full.State != searchDone || full.Results != want || full.ChunksDone != 4 {
+		t.Errorf("recherche complète = %+v, attendu %d résultats", full, want)
+	}
+	if want := countResults(t, 300) - countResults(t, 150); frontier.State != searchDone || frontier.Results != want {
+		t.Errorf("recherche au-delà de 150 = %+v, attendu %d résultats", frontier, want)
+	}
+	var list []serverSearch
+	if doJSON(t, http.MethodGet, ts.URL+"/searches", nil, &list); len(list) != 2 || list[0].ID != full.ID {
+		t.Errorf("liste = %+v", list)
+	}
+}
+
+// TestServerQueue vérifie qu'au plus maxConcurrent recherches s'exécutent à la fois, les autres
+// attendant dans la file dans l'ordre des soumissions.
+func TestServerQueue(t *testing.T) {
+	srv := newTestServer(t, 1, 1)
+	var ids []string
+	for range 3 {
+		rec, _, err := srv.submit("", searchParams{Limit: 200, Chunks: 2}, duplicateAppend)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, rec.ID)
+	}
+	ctx := context.Background()
+	for i := range ids {
+		if err := srv.dispatch(ctx); err != nil {
+			t.Fatal(err)
+		}
+		for j, id := range ids {
+			rec, _ := srv.store.search(id)
+			if j > i && rec.State != searchQueued {
+				t.Errorf("passe %d: recherche %d à l'état %q, attendu en file", i, j, rec.State)
+			}
+		}
+		srv.wg.Wait()
+		if rec, _ := srv.store.search(ids[i]); rec.State != searchDone {
+			t.Errorf("passe %d: recherche %d à l'état %q, attendu achevée", i, i, rec.State)
+		}
+	}
+}
+
+// TestServerErrors vérifie les refus de l'API: paramètres invalides, budget de workers dépassé,
+// recherche inconnue, annulation d'une recherche déjà terminée.
+func TestServerErrors(t *testing.T) {
+	srv := newTestServer(t, 1, 2)
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+
+	for _, body := range []string{`{"limit": 1}`, `{"limit": 100, "workers": 3}`, `{"limit": 100, "above": 100}`, `{"limit": 100, "form": "x"}`, `{"limit": 100, "depth": 1}`, `[`} {
+		resp, err := http.Post(ts.URL+"/searches", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var msg map[string]string
+		json.NewDecoder(resp.Body).Decode(&msg)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest || msg["error"] == "" {
+			t.Errorf("%s: statut %d, %v; attendu 400", body, resp.StatusCode, msg)
+		}
+	}
+	if status := doJSON(t, http.MethodGet, ts.URL+"/searches/inconnue", nil, nil); status != http.StatusNotFound {
+		t.Errorf("recherche inconnue: statut %d", status)
+	}
+	rec, _, err := srv.submit("", searchParams{Limit: 100}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cancelled serverSearch
+	if status := doJSON(t, http.MethodDelete, ts.URL+"/searches/"+rec.ID, nil, &cancelled); status != http.StatusOK || cancelled.State != searchCancelled {
+		t.Errorf("annulation: statut %d, %+v", status, cancelled)
+	}
+	if status := doJSON(t, http.MethodDelete, ts.URL+"/searches/"+rec.ID, nil, nil); status != http.StatusConflict {
+		t.Errorf("seconde annulation: statut %d, attendu 409", status)
+	}
+	if err := srv.dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := srv.store.search(rec.ID); got.State != searchCancelled {
+		t.Errorf("recherche annulée relancée: %+v", got)
+	}
+}
+
+// TestServerRestart simule l'arrêt brutal d'un serveur pendant une tranche: après réouverture de
+// la base, la recherche reprend et ses résultats sont complets.
+func TestServerRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), serverDBName)
+	store, err := openServerStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newSearchServer(store, 1, 1, io.Discard)
+	rec, _, err := srv.submit("", searchParams{Limit: 200, Chunks: 3}, "")
+	if err == nil {
+		_, err = store.updateSearch(rec.ID, func(rec *serverSearch) error { rec.State = searchRunning; return nil })
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok, err := store.leaseChunk(rec.ID, localWorker, 0); !ok || err != nil {
+		t.Fatalf("bail: %v, %v", ok, err)
+	}
+	store.Close() // Arrêt brutal: le bail reste dans la base.
+
+	store, err = openServerStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.releaseWorkerLeases(localWorker); err != nil {
+		t.Fatal(err)
+	}
+	srv = newSearchServer(store, 1, 1, io.Discard)
+	if err := srv.dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	srv.wg.Wait()
+	if got, _ := store.search(rec.ID); got.State != searchDone || got.Results != countResults(t, 200) {
+		t.Errorf("recherche reprise = %+v, attendu %d résultats", got, countResults(t, 200))
+	}
+}
+
+// TestRunServeFlags vérifie les refus des options de serve.
+func TestRunServeFlags(t *testing.T) {
+	for _, args := range [][]string{{}, {"-dir", t.TempDir(), "-max-concurrent", "0"}, {"-dir", t.TempDir(), "-workers", "0"}, {"-dir", t.TempDir(), "-lease-ttl", "0s"}, {"-dir", t.TempDir(), "-listen", ":0"}} {
+		if err := runServe(args, io.Discard, io.Discard); !errors.Is(err, errInvalidFlags) {
+			t.Errorf("serve %v: %v, attendu errInvalidFlags", args, err)
+		}
+	}
+}
+
+// TestServerResults parcourt les résultats d'une recherche par pages et vérifie l'ordre des n, la
+// couverture exacte par les curseurs, le filtrage par intervalle de n et les paramètres refusés.
+func TestServerResults(t *testing.T) {
+	srv := newTestServer(t, 1, 1)
+	rec, _, err := srv.submit("", searchParams{Limit: 300, Chunks: 3}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	srv.wg.Wait()
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	base := ts.URL + "/searches/" + rec.ID + "/results"
+
+	var all []jsonResult
+	pages := 0
+	for url := base + "?limit=100"; ; pages++ {
+		var page struct {
+			Results []jsonResult `json:"results"`
+			Next    string       `json:"next"`
+		}
+		if status := doJSON(t, http.MethodGet, url, nil, &page); status != http.StatusOK {
+			t.Fatalf("GET %s: statut %d", url, status)
+		}
+		all = append(all, page.Results...)
+		if page.Next == "" {
+			break
+		}
+		if len(page.Results) != 100 {
+			t.Fatalf("page %d incomplète (%d résultats) avec un curseur", pages, len(page.Results))
+		}
+		url = base + "?limit=100&after=" + page.Next
+	}
+	want := countResults(t, 300)
+	if len(all) != want || pages != (want-1)/100 {
+		t.Fatalf("%d résultats en %d pages suivantes, attendu %d", len(all), pages, want)
+	}
+	seen := make(map[jsonResult]bool)
+	for i, jr := range all {
+		if i > 0 && jr.N < all[i-1].N || seen[jr] {
+			t.Fatalf("résultat %d (%+v) hors d'ordre ou répété", i, jr)
+		}
+		seen[jr] = true
+	}
+
+	nMin, nMax := all[len(all)/4].N, all[len(all)/2].N
+	inRange := 0
+	for _, jr := range all {
+		if jr.N >= nMin && jr.N <= nMax {
+			inRange++
+		}
+	}
+	var page struct {
+		Results []jsonResult `json:"results"`
+		Next    string       `json:"next"`
+	}
+	doJSON(t, http.MethodGet, fmt.Sprintf("%s?n_min=%d&n_max=%d&limit=%d", base, nMin, nMax, maxResultPage), nil, &page)
+	if len(page.Results) != inRange || page.Next != "" || page.Results[0].N != nMin || page.Results[len(page.Results)-1].N != nMax {
+		t.Errorf("intervalle [%d, %d]: %d résultats (curseur %q), attendu %d", nMin, nMax, len(page.Results), page.Next, inRange)
+	}
+
+	for _, query := range []string{"?limit=0", "?limit=10001", "?after=zz", "?after=00", "?n_min=-1", "?n_max=x"} {
+		if status := doJSON(t, http.MethodGet, base+query, nil, nil); status != http.StatusBadRequest {
+			t.Errorf("%s: statut %d, attendu 400", query, status)
+		}
+	}
+	if status := doJSON(t, http.MethodGet, ts.URL+"/searches/inconnue/results", nil, nil); status != http.StatusNotFound {
+		t.Errorf("recherche inconnue: statut %d, attendu 404", status)
+	}
+}
+
+// TestServerLeases exerce l'API des workers distants sur un serveur coordinateur (-local=false):
+// réservation, renouvellement, envoi des résultats, renvoi reconnu comme doublon, bail terminé
+// (410) et absence de tranche (204).
+func TestServerLeases(t *testing.T) {
+	srv := newTestServer(t, 1, 2)
+	srv.local = false
+	rec, _, err := srv.submit("", searchParams{Limit: 100, Chunks: 1}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+
+	if status := doJSON(t, http.MethodPost, ts.URL+"/leases", leaseRequest{Worker: localWorker}, nil); status != http.StatusBadRequest {
+		t.Errorf("worker réservé: statut %d", status)
+	}
+	var grant leaseGrant
+	if status := doJSON(t, http.MethodPost, ts.URL+"/leases", leaseRequest{Worker: "w1"}, &grant); status != http.StatusOK {
+		t.Fatalf("bail: statut %d", status)
+	}
+	if grant.Search != rec.ID || grant.Params.Limit != 100 || grant.Expires.IsZero() {
+		t.Errorf("bail = %+v", grant)
+	}
+	if status := doJSON(t, http.MethodPost, ts.URL+"/leases", leaseRequest{Worker: "w2"}, nil); status != http.StatusNoContent {
+		t.Errorf("bail sans tranche disponible: statut %d", status)
+	}
+	if status := doJSON(t, http.MethodPost, ts.URL+"/leases/"+grant.Lease+"/renew", nil, &serverLease{}); status != http.StatusOK {
+		t.Errorf("renouvellement: statut %d", status)
+	}
+
+	data, count, err := searchChunk(context.Background(), grant.Params, grant.Chunk, primes.SieveOfEratosthenes(grant.Params.Limit))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, wantDup := range []bool{false, true} {
+		resp, err := http.Post(ts.URL+"/leases/"+grant.Lease+"/results", "application/x-ndjson", bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got commitResponse
+		json.NewDecoder(resp.Body).Decode(&got)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || got.Duplicate != wantDup || got.Search.State != searchDone || got.Search.Results != count {
+			t.Errorf("envoi %d: statut %d, %+v; attendu doublon=%v, %d résultats", i+1, resp.StatusCode, got, wantDup, count)
+		}
+	}
+	if status := doJSON(t, http.MethodPost, ts.URL+"/leases/"+grant.Lease+"/renew", nil, nil); status != http.StatusGone {
+		t.Errorf("renouvellement d'un bail validé: statut %d", status)
+	}
+	if got, _ := srv.store.search(rec.ID); got.Results != count {
+		t.Errorf("résultats comptés %d fois: %+v", got.Results/max(count, 1), got)
+	}
+}
+
+// TestServerDuplicate vérifie la détection des soumissions identiques: refus par défaut, recherche
+// existante rendue (skip), nouvelle recherche liée à l'existante (append), paramètres sans effet
+// sur les résultats ignorés, et recherches annulées exclues.
+func TestServerDuplicate(t *testing.T) {
+	srv := newTestServer(t, 1, 2)
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+
+	var first serverSearch
+	if status := doJSON(t, http.MethodPost, ts.URL+"/searches", submitRequest{searchParams: searchParams{Limit: 100, Chunks: 2}}, &first); status != http.StatusCreated || first.ParamsHash == "" {
+		t.Fatalf("soumission: statut %d, %+v", status, first)
+	}
+	// Le découpage et les workers ne changent pas les résultats: la soumission reste identique.
+	same := searchParams{Limit: 100, Chunks: 5, Workers: 2}
+	var msg map[string]string
+	if status := doJSON(t, http.MethodPost, ts.URL+"/searches", submitRequest{searchParams: same}, &msg); status != http.StatusConflict || !strings.Contains(msg["error"], first.ID) {
+		t.Errorf("soumission identique: statut %d, %v", status, msg)
+	}
+	var skipped serverSearch
+	if status := doJSON(t, http.MethodPost, ts.URL+"/searches", submitRequest{same, duplicateSkip}, &skipped); status != http.StatusOK || skipped.ID != first.ID {
+		t.Errorf("skip: statut %d, %+v", status, skipped)
+	}
+	var appended serverSearch
+	if status := doJSON(t, http.MethodPost, ts.URL+"/searches", submitRequest{same, duplicateAppend}, &appended); status != http.StatusCreated || appended.ID == first.ID || appended.DuplicateOf != first.ID {
+		t.Errorf("append: statut %d, %+v", status, appended)
+	}
+	if status := doJSON(t, http.MethodPost, ts.URL+"/searches", submitRequest{same, "replace"}, &msg); status != http.StatusBadRequest {
+		t.Errorf("politique inconnue: statut %d", status)
+	}
+	if status := doJSON(t, http.MethodPost, ts.URL+"/searches", submitRequest{searchParams: searchParams{Limit: 100, Above: 50}}, nil); status != http.StatusCreated {
+		t.Errorf("frontière différente: statut %d", status)
+	}
+
+	for _, rec := range []serverSearch{first, appended} {
+		if _, err := srv.cancel("", rec.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, created, err := srv.submit("", same, ""); err != nil || !created {
+		t.Errorf("soumission après annulation: %v, %v", created, err)
+	}
+}
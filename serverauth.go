@@ -0,0 +1,196 @@
+/*
+ * Fichier: serverauth.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Contrôle d'accès du mode serveur: jetons d'accès (en-tête Authorization:
+ * Bearer) lus dans le fichier -tokens de serve, limitation de débit par jeton
+ * (seau à jetons: -rate requêtes par seconde, rafales de -burst) et plafond
+ * de recherches actives (en file ou en cours) par jeton. Sans fichier de
+ * jetons, l'API est ouverte et la limitation de débit porte sur l'adresse du
+ * client; serve refuse alors d'écouter ailleurs que sur la boucle locale.
+ */
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minTokenLen est la longueur minimale d'un jeton d'accès.
+const minTokenLen = 16
+
+var (
+	// errUnauthorized signale une requête sans jeton d'accès valide.
+	errUnauthorized = errors.New("jeton d'accès manquant ou inconnu")
+	// errForbidden signale une action sur une recherche soumise avec un autre jeton.
+	errForbidden = errors.New("recherche soumise avec un autre jeton")
+	// errTooManyRequests signale un débit ou un nombre de recherches actives dépassé.
+	errTooManyRequests = errors.New("trop de requêtes")
+)
+
+// serverToken est un jeton d'accès et le nom sous lequel ses requêtes sont tenues.
+type serverToken struct {
+	name  string
+	token []byte
+}
+
+// loadTokens lit le fichier de jetons path: une ligne "NOM JETON" par jeton, lignes vides et
+// commentaires (#) ignorés. Les noms et les jetons sont uniques, et un jeton compte au moins
+// minTokenLen caractères.
+func loadTokens(path string) ([]serverToken, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	defer f.Close()
+	var tokens []serverToken
+	names := make(map[string]bool)
+	values := make(map[string]bool)
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		switch {
+		case len(fields) != 2:
+			return nil, fmt.Errorf("%w: %s:%d: attendu \"NOM JETON\"", errInvalidInput, path, line)
+		case len(fields[1]) < minTokenLen:
+			return nil, fmt.Errorf("%w: %s:%d: jeton de %s trop court (%d caractères, au moins %d)", errInvalidInput, path, line, fields[0], len(fields[1]), minTokenLen)
+		case names[fields[0]] || values[fields[1]]:
+			return nil, fmt.Errorf("%w: %s:%d: nom ou jeton en double (%s)", errInvalidInput, path, line, fields[0])
+		}
+		names[fields[0]], values[fields[1]] = true, true
+		tokens = append(tokens, serverToken{name: fields[0], token: []byte(fields[1])})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errIO, path, err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: %s: aucun jeton", errInvalidInput, path)
+	}
+	return tokens, nil
+}
+
+// lookupToken retourne le nom du jeton token, en comparant en temps constant à chacun des jetons
+// pour ne rien révéler de leur contenu.
+func lookupToken(tokens []serverToken, token string) (name string, ok bool) {
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare(t.token, []byte(token)) == 1 {
+			name, ok = t.name, true
+		}
+	}
+	return name, ok
+}
+
+// isLoopback indique si l'adresse d'écoute addr (hôte:port) n'est joignable que depuis la machine.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// rateLimiter limite le débit des requêtes par clé (nom du jeton ou adresse du client): un seau
+// de burst jetons par clé, rempli de rate jetons par seconde.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket est le seau d'une clé: jetons disponibles à l'instant last.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter retourne un limiteur de rate requêtes par seconde et par clé, par rafales d'au
+// plus burst; nil (aucune limite) si rate vaut 0.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{rate: rate, burst: float64(max(burst, 1)), buckets: make(map[string]*tokenBucket)}
+}
+
+// allow prélève un jeton du seau de key à l'instant now; sinon, retry est l'attente avant qu'un
+// jeton soit disponible.
+func (l *rateLimiter) allow(key string, now time.Time) (ok bool, retry time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, found := l.buckets[key]
+	if !found {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// ownerKey est la clé du nom du jeton d'une requête dans son contexte.
+type ownerKey struct{}
+
+// requestOwner retourne le nom du jeton de la requête r ("" sans fichier de jetons).
+func requestOwner(r *http.Request) string {
+	owner, _ := r.Context().Value(ownerKey{}).(string)
+	return owner
+}
+
+// authenticate enveloppe next du contrôle d'accès: jeton exigé si le serveur en a, puis débit
+// limité par jeton (ou par adresse du client sans jetons). Le nom du jeton est placé dans le
+// contexte de la requête (requestOwner).
+func (s *searchServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := ""
+		if s.tokens != nil {
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			name, ok := lookupToken(s.tokens, strings.TrimSpace(token))
+			if !found || !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="PrimeNumber"`)
+				writeHTTPError(w, errUnauthorized)
+				return
+			}
+			key = name
+			r = r.WithContext(context.WithValue(r.Context(), ownerKey{}, name))
+		} else if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			key = host
+		}
+		if ok, retry := s.limiter.allow(key, time.Now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			writeHTTPError(w, fmt.Errorf("%w: %s: plus de %g requêtes par seconde", errTooManyRequests, cmp.Or(key, "client"), s.limiter.rate))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
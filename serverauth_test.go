@@ -0,0 +1,202 @@
+/*
+ * Fichier: serverauth_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du contrôle d'accès du mode serveur: fichier de jetons, seau à jetons,
+ * adresses de la boucle locale, et API protégée (jeton exigé, annulation
+ * réservée au jeton de la soumission, plafonds de recherches et de débit).
+ */
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Jetons de test.
+const (
+	testTokenAlice = "alice-0123456789abcdef"
+	testTokenBob   = "bob-0123456789abcdef"
+)
+
+// TestLoadTokens vérifie la lecture du fichier de jetons et ses refus.
+func TestLoadTokens(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	tokens, err := loadTokens(write("ok", "# équipe\nalice "+testTokenAlice+"\n\n  bob "+testTokenBob+"  \n"))
+	if err != nil || len(tokens) != 2 {
+		t.Fatalf("jetons = %v, %v", tokens, err)
+	}
+	if name, ok := lookupToken(tokens, testTokenBob); !ok || name != "bob" {
+		t.Errorf("jeton de bob: %q, %v", name, ok)
+	}
+	if _, ok := lookupToken(tokens, testTokenBob[:len(testTokenBob)-1]); ok {
+		t.Error("préfixe d'un jeton accepté")
+	}
+	for _, content := range []string{
+		"",
+		"alice\n",
+		"alice court\n",
+		"alice " + testTokenAlice + "\nalice " + testTokenBob + "\n",
+		"alice " + testTokenAlice + "\nbob " + testTokenAlice + "\n",
+	} {
+		if _, err := loadTokens(write("bad", content)); !errors.Is(err, errInvalidInput) {
+			t.Errorf("fichier %q: %v, attendu errInvalidInput", content, err)
+		}
+	}
+	if _, err := loadTokens(filepath.Join(dir, "absent")); !errors.Is(err, errIO) {
+		t.Errorf("fichier absent: %v", err)
+	}
+}
+
+// TestRateLimiter vérifie la rafale, le remplissage du seau, l'attente annoncée et l'indépendance
+// des clés.
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(2, 3)
+	now := time.Now()
+	for i := range 3 {
+		if ok, _ := l.allow("a", now); !ok {
+			t.Fatalf("requête %d de la rafale refusée", i+1)
+		}
+	}
+	ok, retry := l.allow("a", now)
+	if ok || retry <= 0 || retry > 500*time.Millisecond {
+		t.Errorf("au-delà de la rafale: %v, attente %v", ok, retry)
+	}
+	if ok, _ := l.allow("b", now); !ok {
+		t.Error("seau d'une autre clé vide")
+	}
+	if ok, _ := l.allow("a", now.Add(500*time.Millisecond)); !ok {
+		t.Error("seau non rempli après 500 ms à 2 requêtes par seconde")
+	}
+	if ok, _ := newRateLimiter(0, 1).allow("a", now); !ok {
+		t.Error("limiteur désactivé refusant une requête")
+	}
+}
+
+// TestIsLoopback vérifie la reconnaissance des adresses d'écoute de la boucle locale.
+func TestIsLoopback(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"localhost:8080": true,
+		"127.0.0.1:80":   true,
+		"[::1]:8080":     true,
+		":8080":          false,
+		"0.0.0.0:8080":   false,
+		"192.0.2.1:8080": false,
+		"localhost":      false,
+	} {
+		if got := isLoopback(addr); got != want {
+			t.Errorf("isLoopback(%q) = %v, attendu %v", addr, got, want)
+		}
+	}
+}
+
+// TestServerAuth exerce l'API protégée par des jetons: requête sans jeton ou avec un jeton
+// inconnu, soumission et annulation par jeton, plafond de recherches actives et de débit.
+func TestServerAuth(t *testing.T) {
+	srv := newTestServer(t, 1, 1)
+	srv.tokens = []serverToken{{"alice", []byte(testTokenAlice)}, {"bob", []byte(testTokenBob)}}
+	srv.maxSearches = 1
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	alice := newServerClient(ts.URL, testTokenAlice)
+	bob := newServerClient(ts.URL, testTokenBob)
+
+	for _, token := range []string{"", "inconnu-0123456789abcdef"} {
+		resp, err := http.DefaultClient.Do(mustRequest(t, http.MethodGet, ts.URL+"/searches", token))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+			t.Errorf("jeton %q: statut %d", token, resp.StatusCode)
+		}
+	}
+
+	var rec serverSearch
+	if err := alice.do(http.MethodPost, "/searches", searchParams{Limit: 100}, &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Owner != "alice" {
+		t.Errorf("propriétaire = %q, attendu alice", rec.Owner)
+	}
+	if err := alice.do(http.MethodPost, "/searches", searchParams{Limit: 110}, nil); !errors.Is(err, errInvalidFlags) || !strings.Contains(err.Error(), "recherches actives") {
+		t.Errorf("seconde recherche active d'alice: %v, attendu un refus", err)
+	}
+	if err := bob.do(http.MethodPost, "/searches", searchParams{Limit: 110}, nil); err != nil {
+		t.Errorf("recherche de bob refusée: %v", err)
+	}
+	if err := bob.do(http.MethodDelete, "/searches/"+rec.ID, nil, nil); !errors.Is(err, errInvalidFlags) {
+		t.Errorf("annulation par bob de la recherche d'alice: %v, attendu un refus", err)
+	}
+	if err := alice.do(http.MethodDelete, "/searches/"+rec.ID, nil, &rec); err != nil || rec.State != searchCancelled {
+		t.Fatalf("annulation par alice: %+v, %v", rec, err)
+	}
+	if err := alice.do(http.MethodPost, "/searches", searchParams{Limit: 100}, nil); err != nil {
+		t.Errorf("recherche d'alice après annulation: %v", err)
+	}
+
+	srv.limiter = newRateLimiter(0.001, 1)
+	if err := alice.do(http.MethodGet, "/searches", nil, &[]serverSearch{}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(mustRequest(t, http.MethodGet, ts.URL+"/searches", testTokenAlice))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("débit dépassé: statut %d, Retry-After %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	if err := bob.do(http.MethodGet, "/searches", nil, &[]serverSearch{}); err != nil {
+		t.Errorf("débit de bob limité par celui d'alice: %v", err)
+	}
+}
+
+// TestRunClientToken vérifie que le client transmet le jeton de -token ou de PRIMENUMBER_TOKEN.
+func TestRunClientToken(t *testing.T) {
+	srv := newTestServer(t, 1, 1)
+	srv.tokens = []serverToken{{"alice", []byte(testTokenAlice)}}
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+
+	t.Setenv(tokenEnv, "")
+	if err := runClient([]string{"status", "-server", ts.URL}, io.Discard, io.Discard); !errors.Is(err, errInvalidFlags) {
+		t.Errorf("sans jeton: %v, attendu un refus", err)
+	}
+	if err := runClient([]string{"status", "-server", ts.URL, "-token", testTokenAlice}, io.Discard, io.Discard); err != nil {
+		t.Errorf("-token: %v", err)
+	}
+	t.Setenv(tokenEnv, testTokenAlice)
+	if err := runClient([]string{"status", "-server", ts.URL}, io.Discard, io.Discard); err != nil {
+		t.Errorf("%s: %v", tokenEnv, err)
+	}
+}
+
+// mustRequest retourne une requête method sur url, avec le jeton token s'il n'est pas vide.
+func mustRequest(t *testing.T, method, url, token string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
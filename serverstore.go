@@ -0,0 +1,576 @@
+/*
+ * Fichier: serverstore.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Base embarquée du mode serveur (bbolt, fichier server.db du répertoire
+ * -dir de serve): recherches soumises, tranches de chaque recherche avec leur
+ * bail, baux des workers avec leur échéance et leur état, et résultats indexés
+ * par (n, p, q). Chaque changement d'état est une transaction: un redémarrage
+ * du serveur retrouve les recherches en file ou en cours, leurs tranches
+ * terminées, les baux en cours et leurs résultats, et l'état des
+ * planifications (scheduler.go); un bail validé le reste,
+ * si bien que des résultats renvoyés par un worker qui se reconnecte ne sont
+ * pas comptés deux fois.
+ */
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Compartiments de la base du serveur.
+var (
+	bucketSearches  = []byte("searches")  // Identifiant -> serverSearch (JSON).
+	bucketChunks    = []byte("chunks")    // Un sous-compartiment par recherche: rang -> serverChunk (JSON).
+	bucketResults   = []byte("results")   // Un sous-compartiment par recherche: clé (n, p, q) -> ligne NDJSON.
+	bucketLeases    = []byte("leases")    // Identifiant du bail -> serverLease (JSON).
+	bucketSchedules = []byte("schedules") // Nom de la planification -> scheduleState (JSON).
+)
+
+// États d'une recherche du serveur.
+const (
+	searchQueued    = "queued"
+	searchRunning   = "running"
+	searchDone      = "done"
+	searchFailed    = "failed"
+	searchCancelled = "cancelled"
+)
+
+// États d'un bail.
+const (
+	leaseActive    = "active"    // La tranche est réservée au worker.
+	leaseCommitted = "committed" // Les résultats de la tranche ont été validés.
+	leaseReleased  = "released"  // Rendu par le worker, ou caduc (recherche terminée, tranche réattribuée).
+	leaseExpired   = "expired"   // Échu sans renouvellement: la tranche est retournée à l'attente.
+)
+
+// localWorker est le worker des baux pris par le serveur lui-même.
+const localWorker = "local"
+
+var (
+	// errSearchNotFound signale un identifiant de recherche inconnu.
+	errSearchNotFound = errors.New("recherche inconnue")
+	// errSearchFinished signale une recherche déjà terminée (achevée, en échec ou annulée).
+	errSearchFinished = errors.New("recherche déjà terminée")
+	// errLeaseNotFound signale un bail inconnu, ou qui n'est plus actif.
+	errLeaseNotFound = errors.New("bail inconnu ou terminé")
+)
+
+// serverSearch est une recherche soumise au serveur: ses paramètres, son état et son avancement.
+type serverSearch struct {
+	ID          string       `json:"id"`
+	Owner       string       `json:"owner,omitempty"` // Nom du jeton de la soumission (serverauth.go).
+	Params      searchParams `json:"params"`
+	ParamsHash  string       `json:"params_hash,omitempty"`  // Empreinte des paramètres (searchParams.hash).
+	DuplicateOf string       `json:"duplicate_of,omitempty"` // Recherche identique antérieure (politique append).
+	State       string       `json:"state"`
+	Chunks      int          `json:"chunks"`
+	ChunksDone  int          `json:"chunks_done"`
+	Results     int          `json:"results"`
+	Error       string       `json:"error,omitempty"`
+	Submitted   time.Time    `json:"submitted"`
+	Started     time.Time    `json:"started,omitzero"`
+	Finished    time.Time    `json:"finished,omitzero"`
+}
+
+// finished indique si la recherche a atteint un état final.
+func (rec serverSearch) finished() bool {
+	return rec.State == searchDone || rec.State == searchFailed || rec.State == searchCancelled
+}
+
+// serverChunk est une tranche d'une recherche et le bail qui la réserve, s'il y en a un.
+type serverChunk struct {
+	chunkEntry
+	Lease string `json:"lease,omitempty"`
+}
+
+// serverLease réserve une tranche d'une recherche à un worker jusqu'à son rendu ou son échéance.
+// Les baux terminés restent dans la base: un worker qui renvoie des résultats déjà validés est
+// reconnu au lieu d'être compté deux fois.
+type serverLease struct {
+	ID      string    `json:"id"`
+	Search  string    `json:"search"`
+	Chunk   int       `json:"chunk"`
+	Worker  string    `json:"worker"`
+	State   string    `json:"state"`
+	Expires time.Time `json:"expires,omitzero"` // Échéance (zéro: aucune, baux du serveur).
+}
+
+// serverStore est la base du serveur.
+type serverStore struct {
+	db *bolt.DB
+}
+
+// openServerStore ouvre (ou crée) la base du fichier path. La base est verrouillée: un second
+// serveur sur le même répertoire échoue au lieu d'attendre.
+func openServerStore(path string) (*serverStore, error) {
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("%w: base %s: %v", errIO, path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketSearches, bucketChunks, bucketResults, bucketLeases, bucketSchedules} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%w: base %s: %v", errIO, path, err)
+	}
+	return &serverStore{db: db}, nil
+}
+
+// Close ferme la base.
+func (s *serverStore) Close() error { return s.db.Close() }
+
+// update exécute fn dans une transaction d'écriture; une erreur d'E/S de la base enveloppe errIO.
+func (s *serverStore) update(fn func(tx *bolt.Tx) error) error {
+	return storeError(s.db.Update(fn))
+}
+
+// view exécute fn dans une transaction de lecture.
+func (s *serverStore) view(fn func(tx *bolt.Tx) error) error {
+	return storeError(s.db.View(fn))
+}
+
+// storeError enveloppe dans errIO les erreurs de la base, pas celles du serveur lui-même.
+func storeError(err error) error {
+	if err == nil || errors.Is(err, errSearchNotFound) || errors.Is(err, errSearchFinished) || errors.Is(err, errLeaseNotFound) ||
+		errors.Is(err, errInvalidInput) || errors.Is(err, errVerification) {
+		return err
+	}
+	return fmt.Errorf("%w: base du serveur: %v", errIO, err)
+}
+
+// getRecord décode la valeur JSON de key dans le compartiment b; ok vaut false si elle manque.
+func getRecord(b *bolt.Bucket, key []byte, v any) (ok bool, err error) {
+	data := b.Get(key)
+	if data == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// putRecord écrit v en JSON sous key dans le compartiment b.
+func putRecord(b *bolt.Bucket, key []byte, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, data)
+}
+
+// chunkKey est la clé de la tranche de rang i: l'ordre des clés est celui des tranches.
+func chunkKey(i int) []byte { return binary.BigEndian.AppendUint32(nil, uint32(i)) }
+
+// resultKeyLen est la longueur de la clé d'un résultat.
+const resultKeyLen = 24
+
+// resultKey est la clé d'un résultat: n, puis p et q, en gros-boutiste pour que l'ordre des clés
+// soit celui des n.
+func resultKey(jr jsonResult) []byte {
+	key := binary.BigEndian.AppendUint64(nil, uint64(jr.N))
+	key = binary.BigEndian.AppendUint64(key, uint64(jr.P))
+	return binary.BigEndian.AppendUint64(key, uint64(jr.Q))
+}
+
+// getSearch lit la recherche id dans la transaction tx.
+func getSearch(tx *bolt.Tx, id string) (serverSearch, error) {
+	var rec serverSearch
+	ok, err := getRecord(tx.Bucket(bucketSearches), []byte(id), &rec)
+	if err == nil && !ok {
+		err = fmt.Errorf("%w: %s", errSearchNotFound, id)
+	}
+	return rec, err
+}
+
+// createSearch enregistre une nouvelle recherche en file, avec ses tranches, et la retourne
+// complétée de son identifiant (croissant: l'ordre des identifiants est celui des soumissions).
+func (s *serverStore) createSearch(rec serverSearch, chunks []chunkEntry) (serverSearch, error) {
+	err := s.update(func(tx *bolt.Tx) error {
+		searches := tx.Bucket(bucketSearches)
+		seq, err := searches.NextSequence()
+		if err != nil {
+			return err
+		}
+		rec.ID = fmt.Sprintf("%08d", seq)
+		rec.State, rec.Chunks = searchQueued, len(chunks)
+		chunkBucket, err := tx.Bucket(bucketChunks).CreateBucket([]byte(rec.ID))
+		if err != nil {
+			return err
+		}
+		for i, c := range chunks {
+			if err := putRecord(chunkBucket, chunkKey(i), serverChunk{chunkEntry: c}); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Bucket(bucketResults).CreateBucket([]byte(rec.ID)); err != nil {
+			return err
+		}
+		return putRecord(searches, []byte(rec.ID), rec)
+	})
+	return rec, err
+}
+
+// search retourne la recherche id.
+func (s *serverStore) search(id string) (rec serverSearch, err error) {
+	err = s.view(func(tx *bolt.Tx) error {
+		rec, err = getSearch(tx, id)
+		return err
+	})
+	return rec, err
+}
+
+// searches retourne toutes les recherches, dans l'ordre des soumissions.
+func (s *serverStore) searches() ([]serverSearch, error) {
+	var list []serverSearch
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSearches).ForEach(func(_, data []byte) error {
+			var rec serverSearch
+			if err := json.Unmarshal(data, &rec); err != nil {
+				return err
+			}
+			list = append(list, rec)
+			return nil
+		})
+	})
+	return list, err
+}
+
+// updateSearch applique fn à la recherche id et enregistre le résultat; une erreur de fn annule
+// la transaction.
+func (s *serverStore) updateSearch(id string, fn func(rec *serverSearch) error) (rec serverSearch, err error) {
+	err = s.update(func(tx *bolt.Tx) error {
+		if rec, err = getSearch(tx, id); err != nil {
+			return err
+		}
+		if err := fn(&rec); err != nil {
+			return err
+		}
+		return putRecord(tx.Bucket(bucketSearches), []byte(id), rec)
+	})
+	return rec, err
+}
+
+// finishSearch fait passer la recherche id à l'état final state (message errMsg en cas d'échec) et
+// rend caducs les baux de ses tranches; errSearchFinished si elle était déjà terminée.
+func (s *serverStore) finishSearch(id, state, errMsg string) (rec serverSearch, err error) {
+	err = s.update(func(tx *bolt.Tx) error {
+		if rec, err = getSearch(tx, id); err != nil {
+			return err
+		}
+		if rec.finished() {
+			return fmt.Errorf("%w: %s (%s)", errSearchFinished, id, rec.State)
+		}
+		rec.State, rec.Error, rec.Finished = state, errMsg, time.Now().UTC()
+		chunks := tx.Bucket(bucketChunks).Bucket([]byte(id))
+		err := chunks.ForEach(func(k, data []byte) error {
+			var c serverChunk
+			if err := json.Unmarshal(data, &c); err != nil || c.Lease == "" {
+				return err
+			}
+			return endLease(tx, c.Lease, leaseReleased)
+		})
+		if err != nil {
+			return err
+		}
+		return putRecord(tx.Bucket(bucketSearches), []byte(id), rec)
+	})
+	return rec, err
+}
+
+// getLease lit le bail id dans la transaction tx; errLeaseNotFound s'il est inconnu.
+func getLease(tx *bolt.Tx, id string) (serverLease, error) {
+	var lease serverLease
+	ok, err := getRecord(tx.Bucket(bucketLeases), []byte(id), &lease)
+	if err == nil && !ok {
+		err = fmt.Errorf("%w: %s", errLeaseNotFound, id)
+	}
+	return lease, err
+}
+
+// endLease met fin au bail actif id (état state) et libère sa tranche si elle lui est encore
+// réservée; un bail déjà terminé est laissé tel quel.
+func endLease(tx *bolt.Tx, id, state string) error {
+	lease, err := getLease(tx, id)
+	if err != nil || lease.State != leaseActive {
+		return err
+	}
+	lease.State = state
+	chunks := tx.Bucket(bucketChunks).Bucket([]byte(lease.Search))
+	var c serverChunk
+	if _, err := getRecord(chunks, chunkKey(lease.Chunk), &c); err != nil {
+		return err
+	}
+	if c.Lease == id {
+		c.Lease = ""
+		if err := putRecord(chunks, chunkKey(lease.Chunk), c); err != nil {
+			return err
+		}
+	}
+	return putRecord(tx.Bucket(bucketLeases), []byte(id), lease)
+}
+
+// leaseChunk réserve au worker la première tranche en attente et sans bail de la recherche id, qui
+// doit être en cours, pour une durée ttl (0: sans échéance, pour les baux du serveur); ok vaut
+// false s'il n'en reste aucune.
+func (s *serverStore) leaseChunk(id, worker string, ttl time.Duration) (lease serverLease, chunk chunkEntry, ok bool, err error) {
+	err = s.update(func(tx *bolt.Tx) error {
+		lease, chunk, ok, err = leaseSearchChunk(tx, id, worker, ttl)
+		return err
+	})
+	return lease, chunk, ok, err
+}
+
+// leaseNext réserve au worker, pour une durée ttl, la première tranche disponible des recherches
+// en cours, dans l'ordre des soumissions; ok vaut false s'il n'y en a aucune.
+func (s *serverStore) leaseNext(worker string, ttl time.Duration) (lease serverLease, chunk chunkEntry, ok bool, err error) {
+	err = s.update(func(tx *bolt.Tx) error {
+		cur := tx.Bucket(bucketSearches).Cursor()
+		for k, _ := cur.First(); k != nil && !ok; k, _ = cur.Next() {
+			if lease, chunk, ok, err = leaseSearchChunk(tx, string(k), worker, ttl); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return lease, chunk, ok, err
+}
+
+// leaseSearchChunk implémente leaseChunk dans la transaction tx.
+func leaseSearchChunk(tx *bolt.Tx, id, worker string, ttl time.Duration) (lease serverLease, chunk chunkEntry, ok bool, err error) {
+	rec, err := getSearch(tx, id)
+	if err != nil || rec.State != searchRunning {
+		return lease, chunk, false, err
+	}
+	chunks := tx.Bucket(bucketChunks).Bucket([]byte(id))
+	cur := chunks.Cursor()
+	for k, data := cur.First(); k != nil; k, data = cur.Next() {
+		var c serverChunk
+		if err := json.Unmarshal(data, &c); err != nil {
+			return lease, chunk, false, err
+		}
+		if c.Status != chunkPending || c.Lease != "" {
+			continue
+		}
+		lease = serverLease{ID: rand.Text(), Search: id, Chunk: int(binary.BigEndian.Uint32(k)), Worker: worker, State: leaseActive}
+		if ttl > 0 {
+			lease.Expires = time.Now().UTC().Add(ttl)
+		}
+		c.Lease = lease.ID
+		if err := putRecord(chunks, k, c); err != nil {
+			return lease, chunk, false, err
+		}
+		return lease, c.chunkEntry, true, putRecord(tx.Bucket(bucketLeases), []byte(lease.ID), lease)
+	}
+	return lease, chunk, false, nil
+}
+
+// renewLease repousse de ttl l'échéance du bail actif id; errLeaseNotFound s'il n'est plus actif
+// (échu, rendu, validé ou caduc): le worker doit alors abandonner sa tranche.
+func (s *serverStore) renewLease(id string, ttl time.Duration) (lease serverLease, err error) {
+	err = s.update(func(tx *bolt.Tx) error {
+		if lease, err = getLease(tx, id); err != nil {
+			return err
+		}
+		if lease.State != leaseActive {
+			return fmt.Errorf("%w: %s (%s)", errLeaseNotFound, id, lease.State)
+		}
+		lease.Expires = time.Now().UTC().Add(ttl)
+		return putRecord(tx.Bucket(bucketLeases), []byte(id), lease)
+	})
+	return lease, err
+}
+
+// releaseLease rend la tranche du bail id à l'attente, sans résultat; sans effet sur un bail déjà
+// terminé.
+func (s *serverStore) releaseLease(id string) error {
+	return s.update(func(tx *bolt.Tx) error { return endLease(tx, id, leaseReleased) })
+}
+
+// expireLeases rend à l'attente les tranches des baux actifs dont l'échéance est passée à now
+// (worker arrêté ou injoignable) et retourne leur nombre.
+func (s *serverStore) expireLeases(now time.Time) (count int, err error) {
+	err = s.update(func(tx *bolt.Tx) error {
+		var expired []string
+		err := tx.Bucket(bucketLeases).ForEach(func(k, data []byte) error {
+			var lease serverLease
+			if err := json.Unmarshal(data, &lease); err != nil {
+				return err
+			}
+			if lease.State == leaseActive && !lease.Expires.IsZero() && lease.Expires.Before(now) {
+				expired = append(expired, string(k))
+			}
+			return nil
+		})
+		for _, id := range expired {
+			if err == nil {
+				err = endLease(tx, id, leaseExpired)
+			}
+		}
+		count = len(expired)
+		return err
+	})
+	return count, err
+}
+
+// releaseWorkerLeases rend à l'attente les tranches des baux actifs du worker, au démarrage du
+// serveur pour ses propres baux, interrompus par son arrêt.
+func (s *serverStore) releaseWorkerLeases(worker string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		var ids []string
+		err := tx.Bucket(bucketLeases).ForEach(func(k, data []byte) error {
+			var lease serverLease
+			if err := json.Unmarshal(data, &lease); err != nil {
+				return err
+			}
+			if lease.State == leaseActive && lease.Worker == worker {
+				ids = append(ids, string(k))
+			}
+			return nil
+		})
+		for _, id := range ids {
+			if err == nil {
+				err = endLease(tx, id, leaseReleased)
+			}
+		}
+		return err
+	})
+}
+
+// commitChunk enregistre les résultats de la tranche du bail id (contenu NDJSON de searchNDJSON),
+// marque la tranche terminée et le bail validé, dans une seule transaction; la recherche est
+// achevée avec sa dernière tranche. Les résultats d'un bail échu ou rendu sont encore acceptés
+// tant que la tranche n'est pas terminée. Un envoi répété (bail déjà validé, ou tranche terminée
+// entre-temps sous un autre bail) n'est pas compté une seconde fois: duplicate vaut alors true,
+// et l'erreur enveloppe errVerification si ses résultats diffèrent de ceux déjà enregistrés. La
+// recherche mise à jour est retournée.
+func (s *serverStore) commitChunk(id string, data []byte) (rec serverSearch, duplicate bool, err error) {
+	err = s.update(func(tx *bolt.Tx) error {
+		lease, err := getLease(tx, id)
+		if err != nil {
+			return err
+		}
+		if rec, err = getSearch(tx, lease.Search); err != nil {
+			return err
+		}
+		chunks := tx.Bucket(bucketChunks).Bucket([]byte(lease.Search))
+		var c serverChunk
+		if _, err := getRecord(chunks, chunkKey(lease.Chunk), &c); err != nil {
+			return err
+		}
+		sum := sha256Hex(data)
+		if lease.State == leaseCommitted || c.Status == chunkDone {
+			duplicate = true
+			if sum != c.SHA256 {
+				return fmt.Errorf("%w: tranche %s de la recherche %s: résultats divergents (SHA-256 %s, enregistré %s)", errVerification, c.Name, rec.ID, sum, c.SHA256)
+			}
+			if lease.State == leaseCommitted {
+				return nil
+			}
+			lease.State = leaseCommitted
+			return putRecord(tx.Bucket(bucketLeases), []byte(id), lease)
+		}
+		if rec.finished() {
+			return fmt.Errorf("%w: %s (%s)", errSearchFinished, rec.ID, rec.State)
+		}
+
+		results := tx.Bucket(bucketResults).Bucket([]byte(lease.Search))
+		count := 0
+		for line := range bytes.Lines(data) {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			var jr jsonResult
+			if err := json.Unmarshal(line, &jr); err != nil {
+				return fmt.Errorf("%w: résultat %d de la tranche: %v", errInvalidInput, count+1, err)
+			}
+			if err := results.Put(resultKey(jr), bytes.Clone(line)); err != nil {
+				return err
+			}
+			count++
+		}
+		if c.Lease != "" && c.Lease != id {
+			// La tranche avait été réattribuée: le bail qui la réserve devient caduc.
+			if err := endLease(tx, c.Lease, leaseReleased); err != nil {
+				return err
+			}
+		}
+		c.Lease, c.Status, c.Results, c.SHA256, c.Completed = "", chunkDone, count, sum, time.Now().UTC()
+		if err := putRecord(chunks, chunkKey(lease.Chunk), c); err != nil {
+			return err
+		}
+		lease.State = leaseCommitted
+		if err := putRecord(tx.Bucket(bucketLeases), []byte(id), lease); err != nil {
+			return err
+		}
+		rec.ChunksDone++
+		rec.Results += count
+		if rec.ChunksDone == rec.Chunks {
+			rec.State, rec.Finished = searchDone, c.Completed
+		}
+		return putRecord(tx.Bucket(bucketSearches), []byte(rec.ID), rec)
+	})
+	return rec, duplicate, err
+}
+
+// resultPage retourne au plus limit résultats de la recherche id, par n croissant (puis p et q),
+// restreints à n dans [nMin, nMax] et situés après la clé after (nil: depuis le début). next est
+// la clé du dernier résultat retourné s'il en reste d'autres dans l'intervalle, nil sinon: elle
+// sert de curseur à la page suivante, stable même si des résultats sont ajoutés entre deux pages.
+func (s *serverStore) resultPage(id string, after []byte, limit int, nMin, nMax int64) (page []json.RawMessage, next []byte, err error) {
+	err = s.view(func(tx *bolt.Tx) error {
+		if _, err := getSearch(tx, id); err != nil {
+			return err
+		}
+		cur := tx.Bucket(bucketResults).Bucket([]byte(id)).Cursor()
+		start := binary.BigEndian.AppendUint64(nil, uint64(max(nMin, 0)))
+		if bytes.Compare(after, start) >= 0 {
+			start = after
+		}
+		k, v := cur.Seek(start)
+		if k != nil && bytes.Equal(k, after) {
+			k, v = cur.Next()
+		}
+		var last []byte
+		for ; k != nil && int64(binary.BigEndian.Uint64(k)) <= nMax; k, v = cur.Next() {
+			if len(page) == limit {
+				next = last
+				break
+			}
+			page = append(page, bytes.Clone(v))
+			last = bytes.Clone(k)
+		}
+		return nil
+	})
+	return page, next, err
+}
+
+// schedule lit l'état de la planification name; found vaut false si elle n'a jamais été
+// enregistrée.
+func (s *serverStore) schedule(name string) (st scheduleState, found bool, err error) {
+	err = s.view(func(tx *bolt.Tx) error {
+		found, err = getRecord(tx.Bucket(bucketSchedules), []byte(name), &st)
+		return err
+	})
+	return st, found, err
+}
+
+// putSchedule enregistre l'état de la planification name.
+func (s *serverStore) putSchedule(name string, st scheduleState) error {
+	return s.update(func(tx *bolt.Tx) error { return putRecord(tx.Bucket(bucketSchedules), []byte(name), st) })
+}
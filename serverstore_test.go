@@ -0,0 +1,214 @@
+/*
+ * Fichier: serverstore_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la base du serveur: baux exclusifs des tranches, rendu, validation
+ * des résultats, envois répétés et baux échus, annulation, et reprise après
+ * réouverture de la base.
+ */
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// newTestStore ouvre une base dans un répertoire temporaire et y crée une recherche en cours de
+// chunks tranches.
+func newTestStore(t *testing.T, chunks int) (*serverStore, serverSearch) {
+	t.Helper()
+	store, err := openServerStore(filepath.Join(t.TempDir(), serverDBName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	params, err := searchParams{Limit: 100, Chunks: chunks}.normalize(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := store.createSearch(serverSearch{Params: params, Submitted: time.Now()}, splitChunks(primes.SieveOfEratosthenes(100), chunks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec, err = store.updateSearch(rec.ID, func(rec *serverSearch) error { rec.State = searchRunning; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	return store, rec
+}
+
+// TestServerStoreLeases vérifie qu'une tranche n'est réservée qu'une fois, qu'un bail rendu la
+// remet en attente et que la dernière tranche validée achève la recherche.
+func TestServerStoreLeases(t *testing.T) {
+	store, rec := newTestStore(t, 3)
+	var leases []serverLease
+	seen := make(map[int]bool)
+	for range 3 {
+		lease, _, ok, err := store.leaseChunk(rec.ID, localWorker, 0)
+		if err != nil || !ok || seen[lease.Chunk] {
+			t.Fatalf("bail = %+v, %v, %v (tranches déjà réservées: %v)", lease, ok, err, seen)
+		}
+		seen[lease.Chunk] = true
+		leases = append(leases, lease)
+	}
+	if _, _, ok, err := store.leaseChunk(rec.ID, localWorker, 0); ok || err != nil {
+		t.Fatalf("quatrième bail sur 3 tranches: %v, %v", ok, err)
+	}
+	if err := store.releaseLease(leases[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	again, _, ok, err := store.leaseChunk(rec.ID, localWorker, 0)
+	if err != nil || !ok || again.Chunk != leases[1].Chunk {
+		t.Fatalf("bail après rendu = %+v, %v, %v; attendu la tranche %d", again, ok, err, leases[1].Chunk)
+	}
+	leases[1] = again
+
+	data := []byte(`{"p":2,"q":3,"n":40}` + "\n" + `{"p":3,"q":2,"n":25}` + "\n")
+	for i, lease := range leases {
+		got, dup, err := store.commitChunk(lease.ID, data)
+		if err != nil || dup {
+			t.Fatal(err)
+		}
+		if want := i + 1; got.ChunksDone != want || got.Results != 2*want {
+			t.Errorf("après %d tranche(s): %d tranches, %d résultats (doublon: %v)", want, got.ChunksDone, got.Results, dup)
+		}
+	}
+	got, err := store.search(rec.ID)
+	if err != nil || got.State != searchDone || got.Finished.IsZero() {
+		t.Fatalf("recherche = %+v, %v; attendu achevée", got, err)
+	}
+	if again, dup, err := store.commitChunk(leases[0].ID, data); err != nil || !dup || again.Results != got.Results {
+		t.Errorf("bail validé deux fois: %+v, %v, %v; attendu un doublon non compté", again, dup, err)
+	}
+	if _, dup, err := store.commitChunk(leases[0].ID, data[:21]); !dup || !errors.Is(err, errVerification) {
+		t.Errorf("renvoi divergent: %v, %v; attendu errVerification", dup, err)
+	}
+	if _, _, err := store.commitChunk("inconnu", data); !errors.Is(err, errLeaseNotFound) {
+		t.Errorf("bail inconnu: %v", err)
+	}
+}
+
+// TestServerStoreExpire vérifie qu'un bail échu rend sa tranche à l'attente et ne peut plus être
+// renouvelé, que ses résultats envoyés en retard sont encore acceptés, et que le bail qui a repris
+// la tranche voit alors son envoi reconnu comme un doublon.
+func TestServerStoreExpire(t *testing.T) {
+	store, _ := newTestStore(t, 1)
+	late, _, ok, err := store.leaseNext("w1", time.Minute)
+	if err != nil || !ok || late.Expires.IsZero() {
+		t.Fatalf("bail = %+v, %v, %v", late, ok, err)
+	}
+	if _, err := store.renewLease(late.ID, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := store.expireLeases(time.Now()); n != 0 || err != nil {
+		t.Fatalf("bail non échu expiré: %d, %v", n, err)
+	}
+	if n, err := store.expireLeases(time.Now().Add(2 * time.Minute)); n != 1 || err != nil {
+		t.Fatalf("expiration: %d, %v", n, err)
+	}
+	if _, err := store.renewLease(late.ID, time.Minute); !errors.Is(err, errLeaseNotFound) {
+		t.Errorf("renouvellement d'un bail échu: %v", err)
+	}
+	again, _, ok, err := store.leaseNext("w2", time.Minute)
+	if err != nil || !ok || again.Chunk != late.Chunk {
+		t.Fatalf("bail après expiration = %+v, %v, %v", again, ok, err)
+	}
+
+	data := []byte(`{"p":2,"q":3,"n":40}` + "\n")
+	got, dup, err := store.commitChunk(late.ID, data)
+	if err != nil || dup || got.State != searchDone || got.Results != 1 {
+		t.Fatalf("résultats en retard = %+v, %v, %v", got, dup, err)
+	}
+	if _, err := store.renewLease(again.ID, time.Minute); !errors.Is(err, errLeaseNotFound) {
+		t.Errorf("bail réattribué encore actif: %v", err)
+	}
+	if got, dup, err := store.commitChunk(again.ID, data); err != nil || !dup || got.Results != 1 {
+		t.Errorf("envoi du bail réattribué = %+v, %v, %v; attendu un doublon", got, dup, err)
+	}
+	if _, _, ok, err := store.leaseNext("w3", time.Minute); ok || err != nil {
+		t.Errorf("bail sur une recherche achevée: %v, %v", ok, err)
+	}
+}
+
+// TestServerStoreCancel vérifie que l'annulation retire les baux de la recherche et qu'une
+// recherche terminée ne peut plus changer d'état.
+func TestServerStoreCancel(t *testing.T) {
+	store, rec := newTestStore(t, 2)
+	lease, _, _, err := store.leaseChunk(rec.ID, localWorker, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.finishSearch(rec.ID, searchCancelled, ""); err != nil || got.State != searchCancelled {
+		t.Fatalf("annulation = %+v, %v", got, err)
+	}
+	if _, _, err := store.commitChunk(lease.ID, nil); !errors.Is(err, errSearchFinished) {
+		t.Errorf("validation après annulation: %v", err)
+	}
+	if _, _, ok, err := store.leaseChunk(rec.ID, localWorker, 0); ok || err != nil {
+		t.Errorf("bail d'une recherche annulée: %v, %v", ok, err)
+	}
+	if _, err := store.finishSearch(rec.ID, searchFailed, "x"); !errors.Is(err, errSearchFinished) {
+		t.Errorf("seconde fin: %v", err)
+	}
+	if _, err := store.search("inconnue"); !errors.Is(err, errSearchNotFound) {
+		t.Errorf("recherche inconnue: %v", err)
+	}
+}
+
+// TestServerStoreReopen vérifie qu'une base rouverte retrouve la recherche et rend à l'attente
+// les tranches des baux du serveur, interrompus par son arrêt, et qu'elle est verrouillée contre
+// un second serveur.
+func TestServerStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), serverDBName)
+	store, err := openServerStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := store.createSearch(serverSearch{Params: searchParams{Limit: 100}}, splitChunks(primes.SieveOfEratosthenes(100), 1))
+	if err == nil {
+		_, err = store.updateSearch(rec.ID, func(rec *serverSearch) error { rec.State = searchRunning; return nil })
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok, err := store.leaseChunk(rec.ID, localWorker, 0); !ok || err != nil {
+		t.Fatalf("bail: %v, %v", ok, err)
+	}
+	if _, err := openServerStore(path); !errors.Is(err, errIO) {
+		t.Errorf("seconde ouverture de la base: %v, attendu un refus", err)
+	}
+	store.Close()
+
+	store, err = openServerStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { store.Close() }()
+	if _, _, ok, _ := store.leaseChunk(rec.ID, localWorker, 0); ok {
+		t.Fatal("tranche réservée deux fois avant le rendu des baux")
+	}
+	if err := store.releaseWorkerLeases(localWorker); err != nil {
+		t.Fatal(err)
+	}
+	lease, _, ok, err := store.leaseChunk(rec.ID, localWorker, 0)
+	if !ok || err != nil {
+		t.Fatalf("bail après réouverture: %v, %v", ok, err)
+	}
+	if _, _, err := store.commitChunk(lease.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// Après un nouveau redémarrage, le bail validé est toujours reconnu.
+	if store, err = openServerStore(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, dup, err := store.commitChunk(lease.ID, nil); err != nil || !dup || got.State != searchDone {
+		t.Errorf("renvoi après redémarrage = %+v, %v, %v; attendu un doublon", got, dup, err)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * Fichier: signature.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Authentification des fichiers de résultats: l'option -sign (recherche,
+ * list-primes, min-q, avec -o) écrit à côté du fichier une signature détachée
+ * FICHIER.sig contenant sa somme SHA-256 et une signature ed25519 de cette
+ * somme; la sous-commande verify-signature contrôle l'une et l'autre. Les clés
+ * sont au format PEM standard (PKCS#8 pour la clé privée, PKIX pour la clé
+ * publique), tel que produit par openssl genpkey -algorithm ed25519.
+ */
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// signatureSuffix est l'extension de la signature détachée d'un fichier de résultats.
+const signatureSuffix = ".sig"
+
+// fileSignature est le contenu d'une signature détachée.
+type fileSignature struct {
+	SHA256    []byte // Somme SHA-256 du fichier.
+	Signature []byte // Signature ed25519 de la somme; vide si le fichier n'est pas signé.
+}
+
+// readPEMBlock lit le premier bloc PEM du fichier path.
+func readPEMBlock(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s: aucun bloc PEM", errInvalidInput, path)
+	}
+	return block, nil
+}
+
+// loadSigningKey lit une clé privée ed25519 au format PEM PKCS#8.
+func loadSigningKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errInvalidInput, path, err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: clé %T, attendu ed25519", errInvalidInput, path, key)
+	}
+	return priv, nil
+}
+
+// loadVerifyKey lit une clé publique ed25519 au format PEM PKIX.
+func loadVerifyKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errInvalidInput, path, err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: clé %T, attendu ed25519", errInvalidInput, path, key)
+	}
+	return pub, nil
+}
+
+// fileDigest calcule la somme SHA-256 du fichier path sans le charger en mémoire.
+func fileDigest(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	return h.Sum(nil), nil
+}
+
+// signFile écrit la signature détachée path.sig du fichier path.
+func signFile(path string, key ed25519.PrivateKey) error {
+	digest, err := fileDigest(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "sha256 %s\n", hex.EncodeToString(digest))
+	fmt.Fprintf(&buf, "ed25519 %s\n", base64.StdEncoding.EncodeToString(ed25519.Sign(key, digest)))
+	if err := os.WriteFile(path+signatureSuffix, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
+
+// readSignature lit une signature détachée: une ligne "sha256 HEX" et une ligne facultative "ed25519 BASE64".
+func readSignature(path string) (fileSignature, error) {
+	var sig fileSignature
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sig, fmt.Errorf("%w: %v", errIO, err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		kind, value, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		switch kind {
+		case "":
+		case "sha256":
+			sig.SHA256, err = hex.DecodeString(value)
+		case "ed25519":
+			sig.Signature, err = base64.StdEncoding.DecodeString(value)
+		default:
+			err = fmt.Errorf("ligne inconnue %q", kind)
+		}
+		if err != nil {
+			return sig, fmt.Errorf("%w: %s: %v", errInvalidInput, path, err)
+		}
+	}
+	if len(sig.SHA256) != sha256.Size {
+		return sig, fmt.Errorf("%w: %s: somme SHA-256 absente ou invalide", errInvalidInput, path)
+	}
+	return sig, nil
+}
+
+// verifyFile contrôle la somme du fichier path et, si pub n'est pas nil, la signature ed25519.
+// Un écart enveloppe errVerification.
+func verifyFile(path string, sig fileSignature, pub ed25519.PublicKey) error {
+	digest, err := fileDigest(path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(digest, sig.SHA256) {
+		return fmt.Errorf("%w: %s: somme SHA-256 %x, attendu %x", errVerification, path, digest, sig.SHA256)
+	}
+	if pub != nil && !ed25519.Verify(pub, digest, sig.Signature) {
+		return fmt.Errorf("%w: %s: signature ed25519 invalide", errVerification, path)
+	}
+	return nil
+}
+
+// runVerifySignature implémente la sous-commande verify-signature.
+func runVerifySignature(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("verify-signature", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	keyPtr := fs.String("key", "", tr(msgFlagVerifyKey))
+	sigPtr := fs.String("sig", "", tr(msgFlagVerifySig))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgVerifySignatureUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("%w: verify-signature: un fichier est requis", errInvalidFlags)
+	}
+	path := fs.Arg(0)
+	sigPath := *sigPtr
+	if sigPath == "" {
+		sigPath = path + signatureSuffix
+	}
+
+	var pub ed25519.PublicKey
+	if *keyPtr != "" {
+		var err error
+		if pub, err = loadVerifyKey(*keyPtr); err != nil {
+			return err
+		}
+	}
+	sig, err := readSignature(sigPath)
+	if err != nil {
+		return err
+	}
+	if err := verifyFile(path, sig, pub); err != nil {
+		return err
+	}
+
+	out := &errWriter{w: stdout}
+	if pub != nil {
+		fmt.Fprint(out, tr(msgSignatureOK, path))
+	} else {
+		fmt.Fprint(out, tr(msgChecksumOnly, path))
+	}
+	return writeError(out)
+}
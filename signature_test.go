@@ -0,0 +1,148 @@
+/*
+ * Fichier: signature_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la signature détachée des fichiers de résultats et de la
+ * sous-commande verify-signature.
+ */
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestKeys génère une paire de clés ed25519 et l'écrit en PEM dans dir.
+func writeTestKeys(t *testing.T, dir, name string) (privPath, pubPath string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPath, pubPath = filepath.Join(dir, name+".pem"), filepath.Join(dir, name+".pub.pem")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return privPath, pubPath
+}
+
+// TestSignFile valide la signature, sa vérification et la détection d'un fichier ou d'une clé différents.
+func TestSignFile(t *testing.T) {
+	dir := t.TempDir()
+	privPath, pubPath := writeTestKeys(t, dir, "cle")
+	_, otherPub := writeTestKeys(t, dir, "autre")
+	priv, err := loadSigningKey(privPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := loadVerifyKey(pubPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongPub, err := loadVerifyKey(otherPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "resultats.txt")
+	if err := os.WriteFile(path, []byte("5 | 2 | 41\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := signFile(path, priv); err != nil {
+		t.Fatalf("signFile: %v", err)
+	}
+	sig, err := readSignature(path + signatureSuffix)
+	if err != nil {
+		t.Fatalf("readSignature: %v", err)
+	}
+	if err := verifyFile(path, sig, pub); err != nil {
+		t.Errorf("signature valide rejetée: %v", err)
+	}
+	if err := verifyFile(path, sig, wrongPub); !errors.Is(err, errVerification) {
+		t.Errorf("autre clé acceptée: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("5 | 2 | 43\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyFile(path, sig, nil); !errors.Is(err, errVerification) {
+		t.Errorf("fichier modifié accepté: %v", err)
+	}
+
+	// Une clé publique n'est pas une clé de signature, et réciproquement.
+	if _, err := loadSigningKey(pubPath); !errors.Is(err, errInvalidInput) {
+		t.Errorf("loadSigningKey(clé publique) = %v, attendu errInvalidInput", err)
+	}
+	if _, err := loadVerifyKey(path); !errors.Is(err, errInvalidInput) {
+		t.Errorf("loadVerifyKey(non PEM) = %v, attendu errInvalidInput", err)
+	}
+}
+
+// TestRunSigned valide -sign de bout en bout (recherche et list-primes) et la sous-commande verify-signature.
+func TestRunSigned(t *testing.T) {
+	dir := t.TempDir()
+	privPath, pubPath := writeTestKeys(t, dir, "cle")
+	results := filepath.Join(dir, "resultats.txt")
+	listing := filepath.Join(dir, "primes.json")
+
+	if err := run([]string{"-limit", "30", "-o", results, "-sign", privPath}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("recherche signée: %v", err)
+	}
+	if data, err := os.ReadFile(results); err != nil || !strings.Contains(string(data), "149") {
+		t.Errorf("fichier de résultats = %q (%v)", data, err)
+	}
+	if err := run([]string{"list-primes", "-limit", "30", "-format", "json", "-o", listing, "-sign", privPath}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("list-primes signé: %v", err)
+	}
+
+	for _, path := range []string{results, listing} {
+		var out bytes.Buffer
+		if err := run([]string{"verify-signature", "-key", pubPath, path}, &out, io.Discard); err != nil {
+			t.Errorf("verify-signature %s: %v", path, err)
+		}
+		if !strings.Contains(out.String(), path) {
+			t.Errorf("sortie = %q", out.String())
+		}
+	}
+
+	if err := os.WriteFile(results, []byte("falsifié\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"Fichier modifié", []string{"verify-signature", "-key", pubPath, results}, exitVerification},
+		{"Signature absente", []string{"verify-signature", filepath.Join(dir, "absent.txt")}, exitIO},
+		{"Sans fichier", []string{"verify-signature"}, exitInvalidFlags},
+		{"-sign sans -o", []string{"-limit", "30", "-sign", privPath}, exitInvalidFlags},
+		{"Clé illisible", []string{"min-q", "-o", listing, "-sign", filepath.Join(dir, "absente.pem")}, exitIO},
+	}
+	for _, tc := range testCases {
+		if got := exitCode(run(tc.args, io.Discard, io.Discard)); got != tc.expected {
+			t.Errorf("%s: code %d, attendu %d", tc.name, got, tc.expected)
+		}
+	}
+}
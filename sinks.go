@@ -0,0 +1,129 @@
+/*
+ * Fichier: sinks.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Destinations supplémentaires des résultats (-sink format:cible, répétable):
+ * un fichier, une connexion TCP (tcp://hôte:port) ou une destination déclarée
+ * par un greffon (plugin://nom, voir plugins.go), chacun dans son format
+ * (table, json ou ndjson). Les résultats sont répartis entre la sortie
+ * habituelle et ces destinations; une destination en échec est signalée puis
+ * écartée sans interrompre la recherche ni les autres.
+ */
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// sinkDialTimeout borne l'établissement d'une connexion TCP de -sink.
+const sinkDialTimeout = 5 * time.Second
+
+// sinkSpec est une destination demandée par -sink.
+type sinkSpec struct {
+	format string
+	target string // Chemin de fichier, adresse tcp://hôte:port ou destination plugin://nom.
+}
+
+func (s sinkSpec) String() string { return s.format + ":" + s.target }
+
+// parseSinkSpec lit une valeur de -sink de la forme format:cible.
+func parseSinkSpec(value string) (sinkSpec, error) {
+	format, target, ok := strings.Cut(value, ":")
+	if !ok || target == "" {
+		return sinkSpec{}, fmt.Errorf("%q: attendu format:cible (fichier, tcp://hôte:port ou plugin://nom)", value)
+	}
+	if !slices.Contains(resultFormats, format) {
+		return sinkSpec{}, fmt.Errorf("%q: format %q (attendu %v)", value, format, resultFormats)
+	}
+	if addr, isTCP := strings.CutPrefix(target, "tcp://"); isTCP {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return sinkSpec{}, fmt.Errorf("%q: %v", value, err)
+		}
+	}
+	if name, isPlugin := strings.CutPrefix(target, "plugin://"); isPlugin && name == "" {
+		return sinkSpec{}, fmt.Errorf("%q: destination de greffon sans nom", value)
+	}
+	return sinkSpec{format: format, target: target}, nil
+}
+
+// sinkSpecList est la valeur de -sink, répétable (flag.Value); le manifeste en retient la liste.
+type sinkSpecList []sinkSpec
+
+func (l *sinkSpecList) String() string {
+	var specs []string
+	for _, s := range *l {
+		specs = append(specs, s.String())
+	}
+	return strings.Join(specs, ",")
+}
+
+func (l *sinkSpecList) Set(value string) error {
+	spec, err := parseSinkSpec(value)
+	if err == nil {
+		*l = append(*l, spec)
+	}
+	return err
+}
+
+// outputSink est une destination -sink ouverte: les résultats passent par un tampon d'écriture
+// vers le fichier ou la connexion, fermés avec la sortie.
+type outputSink struct {
+	*resultWriter
+	buf    *bufio.Writer
+	closer io.Closer
+}
+
+// openSink ouvre la destination décrite par spec; les destinations plugin:// sont cherchées
+// parmi les greffons lancés.
+func openSink(spec sinkSpec, formName string, plugins *pluginSet) (*outputSink, error) {
+	var dest io.WriteCloser
+	var err error
+	if addr, isTCP := strings.CutPrefix(spec.target, "tcp://"); isTCP {
+		dest, err = net.DialTimeout("tcp", addr, sinkDialTimeout)
+	} else if name, isPlugin := strings.CutPrefix(spec.target, "plugin://"); isPlugin {
+		dest, err = plugins.sinkWriter(name)
+	} else {
+		dest, err = os.Create(spec.target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: -sink %s: %v", errIO, spec, err)
+	}
+	buf := bufio.NewWriter(dest)
+	return &outputSink{
+		resultWriter: &resultWriter{w: &errWriter{w: buf}, format: spec.format, formName: formName},
+		buf:          buf,
+		closer:       dest,
+	}, nil
+}
+
+// Flush pousse les résultats écrits vers le fichier ou la connexion (primes.ResultSink).
+func (s *outputSink) Flush() error {
+	if err := s.resultWriter.Flush(); err != nil {
+		return err
+	}
+	if err := s.buf.Flush(); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
+
+// Close termine la sortie et ferme le fichier ou la connexion (primes.ResultSink).
+func (s *outputSink) Close() error {
+	err := s.resultWriter.Close()
+	if err == nil {
+		err = s.Flush()
+	}
+	if cerr := s.closer.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("%w: %v", errIO, cerr)
+	}
+	return err
+}
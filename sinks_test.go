@@ -0,0 +1,91 @@
+/*
+ * Fichier: sinks_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des destinations supplémentaires des résultats (-sink).
+ */
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestParseSinkSpec valide la lecture des valeurs de -sink.
+func TestParseSinkSpec(t *testing.T) {
+	testCases := []struct {
+		value string
+		valid bool
+	}{
+		{"ndjson:out.ndjson", true},
+		{"table:/tmp/résultats.txt", true},
+		{"json:tcp://localhost:9000", true},
+		{"ndjson:plugin://audit", true},
+		{"ndjson:plugin://", false},
+		{"out.ndjson", false},
+		{"ndjson:", false},
+		{"csv:out.csv", true},
+		{"pbz:out.pbz", true},
+		{"xml:out.xml", false},
+		{"ndjson:tcp://localhost", false},
+	}
+	for _, tc := range testCases {
+		spec, err := parseSinkSpec(tc.value)
+		if (err == nil) != tc.valid {
+			t.Errorf("parseSinkSpec(%q) = %v, %v; valide attendu: %v", tc.value, spec, err, tc.valid)
+		}
+		if err == nil && spec.String() != tc.value {
+			t.Errorf("parseSinkSpec(%q).String() = %q", tc.value, spec.String())
+		}
+	}
+}
+
+// TestRunSinks vérifie que chaque destination reçoit tous les résultats, et qu'une destination en
+// échec est signalée sans priver les autres de leurs résultats.
+func TestRunSinks(t *testing.T) {
+	dir := t.TempDir()
+	ndjson, table := filepath.Join(dir, "r.ndjson"), filepath.Join(dir, "r.txt")
+	var stdout strings.Builder
+	if err := run([]string{"-limit", "30", "-format", "json", "-sink", "ndjson:" + ndjson, "-sink", "table:" + table}, &stdout, io.Discard); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	data, err := os.ReadFile(ndjson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(resultLines(string(data)), "\n")
+	if len(lines) != 37 || !slices.Contains(lines, `{"p":3,"q":5,"n":109}`) {
+		t.Errorf("%s: %d lignes; attendu 37 résultats, dont n=109:\n%s", ndjson, len(lines), data)
+	}
+	if _, manifest, err := readResults(ndjson); err != nil || manifest == nil || manifest.End.IsZero() {
+		t.Errorf("%s: manifeste %+v, %v; attendu le manifeste complet", ndjson, manifest, err)
+	}
+	if got := strings.Count(stdout.String(), `{"p":`); got != 37 {
+		t.Errorf("sortie standard: %d résultats, attendu 37", got)
+	}
+	if data, err = os.ReadFile(table); err != nil || !strings.Contains(string(data), "# run_id: ") {
+		t.Errorf("%s sans manifeste (%v):\n%s", table, err, data)
+	}
+
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full indisponible")
+	}
+	var status strings.Builder
+	err = run([]string{"-limit", "30", "-sink", "ndjson:/dev/full", "-sink", "ndjson:" + ndjson}, &status, io.Discard)
+	if got := exitCode(err); got != exitIO {
+		t.Errorf("run() -> code %d (%v), attendu %d", got, err, exitIO)
+	}
+	if !strings.Contains(status.String(), "ndjson:/dev/full") {
+		t.Errorf("échec de ndjson:/dev/full non signalé:\n%s", status.String())
+	}
+	data, _ = os.ReadFile(ndjson)
+	if lines = strings.Split(resultLines(string(data)), "\n"); len(lines) != 37 {
+		t.Errorf("%s: %d lignes après l'échec d'une autre destination, attendu 37", ndjson, len(lines))
+	}
+}
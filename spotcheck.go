@@ -0,0 +1,85 @@
+/*
+ * Fichier: spotcheck.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Contrôle par sondage (option -spot-check): un échantillon aléatoire des
+ * résultats est revérifié pendant l'exécution par le test indépendant de
+ * -verify, pour un coût proportionnel au taux choisi. Le résumé donne le
+ * taux d'accord de l'échantillon; un désaccord fait échouer l'exécution
+ * (code de sortie 5) sans l'interrompre, pour mesurer l'ampleur de l'écart.
+ */
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// parseSpotRate analyse le taux de -spot-check: un pourcentage ("0.1%") ou une fraction
+// ("0.001"), dans ]0, 1].
+func parseSpotRate(s string) (float64, error) {
+	text, scale := strings.TrimSpace(s), 1.0
+	if t, ok := strings.CutSuffix(text, "%"); ok {
+		text, scale = strings.TrimSpace(t), 100
+	}
+	v, err := strconv.ParseFloat(text, 64)
+	rate := v / scale
+	if err != nil || !(rate > 0 && rate <= 1) {
+		return 0, fmt.Errorf("%w: -spot-check=%q (attendu un taux dans ]0, 1] ou un pourcentage dans ]0 %%, 100 %%])", errInvalidFlags, s)
+	}
+	return rate, nil
+}
+
+// spotChecker tire les résultats à revérifier et tient le bilan du sondage. Il est alimenté depuis
+// la goroutine de collecte des résultats.
+type spotChecker struct {
+	rate          float64
+	seed          uint64 // Graine du tirage, rappelée dans le résumé.
+	rng           *rand.Rand
+	seen, checked int
+	disagreements int
+	first         error // Premier désaccord, détaillé dans le résumé.
+}
+
+// newSpotChecker retourne un sondage au taux rate, reproductible pour une graine donnée.
+func newSpotChecker(rate float64, seed uint64) *spotChecker {
+	return &spotChecker{rate: rate, seed: seed, rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// check tire res avec la probabilité du taux et, s'il est retenu, le revérifie (verifyResult).
+func (s *spotChecker) check(res primes.Result, form primes.Form, filter primes.Filter, primeTestAlgorithm string) {
+	s.seen++
+	if s.rng.Float64() >= s.rate {
+		return
+	}
+	s.checked++
+	if err := verifyResult(res, form, filter, primeTestAlgorithm); err != nil {
+		s.disagreements++
+		if s.first == nil {
+			s.first = err
+		}
+	}
+}
+
+// agreement retourne la part des résultats revérifiés confirmés par le test indépendant, en
+// pourcentage (100 si aucun n'a été tiré).
+func (s *spotChecker) agreement() float64 {
+	if s.checked == 0 {
+		return 100
+	}
+	return 100 * float64(s.checked-s.disagreements) / float64(s.checked)
+}
+
+// independentTestName nomme le test indépendant de verifyResult pour le test de la recherche.
+func independentTestName(primeTestAlgorithm string) string {
+	if primeTestAlgorithm == "trial" {
+		return "miller"
+	}
+	return "trial"
+}
@@ -0,0 +1,69 @@
+/*
+ * Fichier: spotcheck_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de l'option -spot-check.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestParseSpotRate vérifie les pourcentages, les fractions et le refus des taux hors de ]0, 1].
+func TestParseSpotRate(t *testing.T) {
+	for in, want := range map[string]float64{"0.1%": 0.001, "100%": 1, " 5 % ": 0.05, "0.25": 0.25, "1": 1} {
+		if got, err := parseSpotRate(in); err != nil || got != want {
+			t.Errorf("parseSpotRate(%q) = %v, %v; attendu %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0", "0%", "-1%", "150%", "1.5", "abc", "%"} {
+		if _, err := parseSpotRate(in); exitCode(err) != exitInvalidFlags {
+			t.Errorf("parseSpotRate(%q): %v, attendu le code %d", in, err, exitInvalidFlags)
+		}
+	}
+}
+
+// TestSpotCheckerDisagreement vérifie le décompte d'un résultat faux retenu par le sondage.
+func TestSpotCheckerDisagreement(t *testing.T) {
+	s := newSpotChecker(1, 1)
+	s.check(primes.Result{P: 5, Q: 2, N: 41}, primes.DefaultForm, nil, "miller")
+	s.check(primes.Result{P: 5, Q: 2, N: 43}, primes.DefaultForm, nil, "miller")
+	if s.seen != 2 || s.checked != 2 || s.disagreements != 1 || s.first == nil || s.agreement() != 50 {
+		t.Errorf("sondage: %d vus, %d revérifiés, %d désaccords (%v), accord %v; attendu 2, 2, 1, 50", s.seen, s.checked, s.disagreements, s.first, s.agreement())
+	}
+}
+
+// TestRunSpotCheck vérifie qu'une recherche sondée à 100 % revérifie tous ses résultats, sans
+// changer sa sortie, et qu'un taux invalide est refusé.
+func TestRunSpotCheck(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	var plain bytes.Buffer
+	if err := run([]string{"-limit", "100", "-workers", "1", "-format", "ndjson", "-manifest=false"}, &plain, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	var out, status bytes.Buffer
+	if err := run([]string{"-limit", "100", "-workers", "1", "-format", "ndjson", "-manifest=false", "-spot-check", "100%", "-seed", "3"}, &out, &status); err != nil {
+		t.Fatal(err)
+	}
+	want := resultLines(plain.String())
+	if resultLines(out.String()) != want {
+		t.Error("-spot-check: résultats différents de la recherche sans sondage")
+	}
+	found := strings.Count(want, "\n") + 1
+	if summary := tr(msgSpotCheckSummary, "trial", 3, countInt(found), countInt(found), 100.0); !strings.Contains(status.String(), summary) {
+		t.Errorf("résumé %q absent de:\n%s", summary, status.String())
+	}
+	if err := run([]string{"-limit", "100", "-spot-check", "0%"}, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+		t.Errorf("-spot-check 0%%: %v, attendu le code %d", err, exitInvalidFlags)
+	}
+}
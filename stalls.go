@@ -0,0 +1,86 @@
+/*
+ * Fichier: stalls.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Journal du chien de garde des workers (-stall-timeout, -skip-stalled): chaque
+ * candidat dont le test dépasse le délai est signalé avec sa paire (p, q) et
+ * sa valeur de n, et les paires abandonnées sont rappelées dans le résumé,
+ * les résultats de l'exécution étant alors incomplets.
+ */
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// maxListedStalls borne le nombre de paires abandonnées citées dans le résumé.
+const maxListedStalls = 10
+
+// stallLog reçoit les blocages signalés par le chien de garde.
+type stallLog struct {
+	form primes.Form
+	logf func(string)
+
+	mu      sync.Mutex
+	stalls  int
+	skipped []primes.Job
+}
+
+// onStall signale le blocage s (appelé depuis la goroutine du chien de garde).
+func (l *stallLog) onStall(s primes.Stall) {
+	l.mu.Lock()
+	l.stalls++
+	if s.Skipped {
+		l.skipped = append(l.skipped, primes.Job{P: s.P, Q: s.Q})
+	}
+	l.mu.Unlock()
+	msg := msgStall
+	if s.Skipped {
+		msg = msgStallSkipped
+	}
+	l.logf(tr(msg, s.Worker, s.Elapsed.Round(time.Millisecond), candidateString(l.form, s.P, s.Q), s.P, s.Q))
+}
+
+// counts retourne le nombre de blocages signalés et de paires abandonnées.
+func (l *stallLog) counts() (stalls, skipped int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stalls, len(l.skipped)
+}
+
+// summary retourne le rappel des paires abandonnées ("" s'il n'y en a pas ou sans chien de
+// garde, l == nil).
+func (l *stallLog) summary() string {
+	if l == nil {
+		return ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.skipped) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, min(len(l.skipped), maxListedStalls))
+	for _, job := range l.skipped[:cap(pairs)] {
+		pairs = append(pairs, fmt.Sprintf("(%d, %d)", job.P, job.Q))
+	}
+	if len(l.skipped) > maxListedStalls {
+		pairs = append(pairs, "...")
+	}
+	return tr(msgStallSummary, countInt(len(l.skipped)), strings.Join(pairs, " "))
+}
+
+// candidateString retourne la valeur de n de la forme pour la paire (p, q), exacte au-delà
+// d'int64 si la forme le permet.
+func candidateString(form primes.Form, p, q int) string {
+	if bf, ok := form.(primes.BigForm); ok {
+		return bf.EvalBig(int64(p), int64(q)).String()
+	}
+	return fmt.Sprint(form.Eval(int64(p), int64(q)))
+}
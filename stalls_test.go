@@ -0,0 +1,73 @@
+/*
+ * Fichier: stalls_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du journal du chien de garde des workers (-stall-timeout, -skip-stalled).
+ */
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestStallLog vérifie les messages d'un blocage signalé puis abandonné, et le rappel des paires
+// abandonnées, borné à maxListedStalls.
+func TestStallLog(t *testing.T) {
+	defer setLanguage(defaultLanguage)
+	setLanguage(language.French)
+
+	var lines []string
+	l := &stallLog{form: primes.DefaultForm, logf: func(s string) { lines = append(lines, s) }}
+	if l.summary() != "" || (*stallLog)(nil).summary() != "" {
+		t.Error("rappel sans paire abandonnée")
+	}
+	l.onStall(primes.Stall{Worker: 1, P: 7, Q: 3, Elapsed: 90 * time.Second})
+	l.onStall(primes.Stall{Worker: 2, P: 11, Q: 5, Elapsed: time.Minute, Skipped: true})
+	if want := "worker 1 bloqué depuis 1m30s sur n = 85 (p=7, q=3)"; !strings.Contains(lines[0], want) {
+		t.Errorf("message %q, attendu %q", lines[0], want)
+	}
+	if want := "n = 221 (p=11, q=5): paire abandonnée"; !strings.Contains(lines[1], want) {
+		t.Errorf("message %q, attendu %q", lines[1], want)
+	}
+	for i := range maxListedStalls {
+		l.onStall(primes.Stall{P: 13, Q: i, Skipped: true})
+	}
+	if stalls, skipped := l.counts(); stalls != 2+maxListedStalls || skipped != 1+maxListedStalls {
+		t.Errorf("comptes %d, %d", stalls, skipped)
+	}
+	s := l.summary()
+	if !strings.Contains(s, "11 paire(s) abandonnée(s)") || !strings.Contains(s, "(11, 5) (13, 0)") || !strings.HasSuffix(strings.TrimSpace(s), "...") {
+		t.Errorf("rappel %q", s)
+	}
+}
+
+// TestRunStallTimeout vérifie les options de bout en bout: vérification du bilan sans paire
+// abandonnée et combinaisons refusées.
+func TestRunStallTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	if err := run([]string{"-limit", "200", "-stall-timeout", "1h", "-skip-stalled", "-summary-out", path}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	s := readSummary(t, path)
+	if len(s.Checks) != 1 || s.Checks[0].Name != "stall-timeout" || !s.Checks[0].Passed || s.Counts.Skipped != 0 {
+		t.Errorf("bilan %+v, vérifications %+v", s.Counts, s.Checks)
+	}
+	for _, args := range [][]string{
+		{"-skip-stalled"},
+		{"-stall-timeout", "-1s"},
+		{"-reverse", "-stall-timeout", "1m"},
+	} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Fichier: statsline.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Ligne de statistiques périodique (option -stats-interval): une ligne compacte
+ * avec le temps écoulé, le débit sur le dernier intervalle, le nombre de
+ * résultats, l'avancement et la mémoire utilisée, suivie des résultats par
+ * décade de n, pour suivre une longue exécution (par exemple via SSH) sans l'interface terminal ni le tableau de bord.
+ */
+package main
+
+import (
+	"time"
+)
+
+// statsReporter produit les lignes de statistiques à partir des compteurs de l'exécution.
+type statsReporter struct {
+	stats      *searchStats
+	start      time.Time
+	lastTime   time.Time
+	lastTested int64
+	memory     func() int64 // Mémoire utilisée (heapInUse; remplaçable dans les tests).
+}
+
+// newStatsReporter crée un générateur de lignes dont le temps écoulé part de start.
+func newStatsReporter(stats *searchStats, start time.Time) *statsReporter {
+	return &statsReporter{stats: stats, start: start, lastTime: start, memory: heapInUse}
+}
+
+// line retourne la ligne de statistiques à l'instant now; le débit porte sur l'intervalle
+// écoulé depuis la ligne précédente.
+func (r *statsReporter) line(now time.Time) string {
+	tested := r.stats.pairsTested.Load()
+	rate := throughput(tested-r.lastTested, now.Sub(r.lastTime))
+	r.lastTime, r.lastTested = now, tested
+	percent := 0.0
+	if r.stats.totalPairs > 0 {
+		percent = 100 * float64(tested) / float64(r.stats.totalPairs)
+	}
+	elapsed := now.Sub(r.start).Round(100 * time.Millisecond)
+	return tr(msgStatsLine, elapsed, rate, r.stats.primesFound.Load(), percent, formatBytes(r.memory())) + formatDecadeLine(&r.stats.decades)
+}
+
+// run écrit une ligne avec logf à l'intervalle de live, jusqu'à la fermeture de done. Un
+// changement d'intervalle (rechargement du fichier d'options) prend effet aussitôt; un
+// intervalle nul suspend les lignes.
+func (r *statsReporter) run(live *liveSettings, logf func(string), done <-chan struct{}) {
+	for {
+		var tick <-chan time.Time
+		var timer *time.Timer
+		if interval := live.interval(); interval > 0 {
+			timer = time.NewTimer(interval)
+			tick = timer.C
+		}
+		select {
+		case now := <-tick:
+			logf(r.line(now))
+		case <-live.changed:
+		case <-done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
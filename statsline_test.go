@@ -0,0 +1,89 @@
+/*
+ * Fichier: statsline_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la ligne de statistiques périodique (option -stats-interval).
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// TestStatsLine valide le débit par intervalle, l'avancement et la mémoire affichés.
+func TestStatsLine(t *testing.T) {
+	defer setLanguage(defaultLanguage)
+	setLanguage(language.French)
+
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	stats := &searchStats{totalPairs: 1000}
+	r := newStatsReporter(stats, start)
+	r.memory = func() int64 { return 3 << 20 }
+
+	stats.pairsTested.Store(200)
+	stats.primesFound.Store(7)
+	if got, want := r.line(start.Add(2*time.Second)), "[2s] 100 paires/s | 7 résultats | 20.0 % | mémoire 3.0 MiB\n"; got != want {
+		t.Errorf("première ligne = %q, attendu %q", got, want)
+	}
+	// Le débit ne porte que sur le dernier intervalle: 300 paires en 1s.
+	stats.pairsTested.Store(500)
+	if got, want := r.line(start.Add(3*time.Second)), "[3s] 300 paires/s | 7 résultats | 50.0 % | mémoire 3.0 MiB\n"; got != want {
+		t.Errorf("deuxième ligne = %q, attendu %q", got, want)
+	}
+}
+
+// TestRunStatsInterval valide l'option de bout en bout et le rejet d'un intervalle négatif.
+func TestRunStatsInterval(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-limit", "1500", "-stats-interval", "1ms", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(out.String(), " paires/s | ") {
+		t.Errorf("aucune ligne de statistiques:\n%.500s", out.String())
+	}
+	if got := exitCode(run([]string{"-stats-interval", "-1s"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("intervalle négatif -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
+
+// TestStatsReporterLive valide la prise en compte immédiate d'un changement d'intervalle: aucune
+// ligne à intervalle nul, des lignes dès qu'il est fixé, plus aucune après retour à zéro.
+func TestStatsReporterLive(t *testing.T) {
+	live := newLiveSettings(logLevelInfo, 0)
+	var lines atomic.Int32
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		newStatsReporter(&searchStats{}, time.Now()).run(live, func(string) { lines.Add(1) }, done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if n := lines.Load(); n != 0 {
+		t.Errorf("%d lignes à intervalle nul", n)
+	}
+	live.setInterval(time.Millisecond)
+	deadline := time.Now().Add(time.Second)
+	for lines.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if lines.Load() < 3 {
+		t.Fatalf("%d lignes après le passage à 1ms", lines.Load())
+	}
+	live.setInterval(0)
+	time.Sleep(10 * time.Millisecond)
+	n := lines.Load()
+	time.Sleep(20 * time.Millisecond)
+	if lines.Load() != n {
+		t.Errorf("lignes écrites après le retour à un intervalle nul")
+	}
+	close(done)
+	<-stopped
+}
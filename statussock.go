@@ -0,0 +1,236 @@
+/*
+ * Fichier: statussock.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Socket d'état local (option -status-socket) et sous-commande status.
+ * Une exécution en cours écoute sur un socket UNIX et répond à chaque
+ * connexion par un instantané JSON de sa progression; la sous-commande
+ * status interroge ce socket et affiche l'état de l'exécution. Avec
+ * -snapshot, elle demande à l'exécution d'écrire dans un fichier ses
+ * derniers résultats (au plus maxSnapshotResults) et sa progression, sans
+ * l'interrompre.
+ *
+ * Protocole: le client envoie une ligne de requête, "status" ou "snapshot
+ * FICHIER", et reçoit une ligne JSON en réponse. Sans requête dans le délai
+ * statusRequestTimeout, l'état est servi comme pour "status".
+ *
+ * Les sockets UNIX existent sous Linux, macOS, les BSD et Windows (10 version
+ * 1803 et suivantes, sans recours aux tubes nommés); seule la longueur
+ * maximale du chemin varie (maxSocketPath).
+ */
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// statusTimeout borne la durée d'une interrogation du socket d'état.
+const statusTimeout = 2 * time.Second
+
+// maxSocketPath est la longueur maximale, en octets, d'un chemin de socket UNIX sur la plateforme:
+// 104 sous macOS et les BSD, 108 sous Linux et Windows (octet nul final non compris).
+var maxSocketPath = len(syscall.RawSockaddrUnix{}.Path) - 1
+
+// checkSocketPath refuse un chemin de socket trop long pour la plateforme, que le système
+// signalerait sans explication ("invalid argument") ou tronquerait.
+func checkSocketPath(path string) error {
+	if len(path) > maxSocketPath {
+		return fmt.Errorf("chemin de socket trop long: %d octets (au plus %d sur cette plateforme), préférer un répertoire court", len(path), maxSocketPath)
+	}
+	return nil
+}
+
+// statusRequestTimeout borne l'attente de la ligne de requête d'un client.
+const statusRequestTimeout = 500 * time.Millisecond
+
+// snapshotDocument est le contenu d'un instantané: les derniers résultats écrits au format relu
+// par la sous-commande diff, le nombre de résultats plus anciens omis (au-delà de
+// maxSnapshotResults) et la progression de l'exécution au moment de l'instantané.
+type snapshotDocument struct {
+	Results  []jsonResult      `json:"results"`
+	Omitted  int64             `json:"omitted,omitempty"`
+	Progress dashboardSnapshot `json:"progress"`
+}
+
+// snapshotReply est la réponse à une requête snapshot.
+type snapshotReply struct {
+	File    string `json:"file"`
+	Results int    `json:"results"`
+	Omitted int64  `json:"omitted,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// writeSnapshot écrit de façon atomique dans path les résultats conservés par dash et sa
+// progression, et retourne le nombre de résultats écrits et celui des résultats omis.
+func writeSnapshot(path string, dash *dashboard) (int, int64, error) {
+	doc := snapshotDocument{Progress: dash.snapshot()}
+	doc.Results, doc.Omitted = dash.keptResults()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(doc.Results), doc.Omitted, writeFileAtomic(path, append(data, '\n'))
+}
+
+// startStatusSocket écoute sur le socket UNIX path et sert les instantanés de dash.
+// Un fichier de socket laissé par une exécution précédente est supprimé; un socket
+// sur lequel une autre exécution écoute encore est refusé.
+func startStatusSocket(path string, dash *dashboard) (net.Listener, error) {
+	if err := checkSocketPath(path); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, statusTimeout); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s est déjà utilisé par une autre exécution", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	go serveStatus(ln, dash)
+	return ln, nil
+}
+
+// serveStatus répond à chaque requête par une ligne JSON, jusqu'à la fermeture de ln.
+func serveStatus(ln net.Listener, dash *dashboard) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			conn.SetReadDeadline(time.Now().Add(statusRequestTimeout))
+			request, _ := bufio.NewReader(conn).ReadString('\n')
+			conn.SetWriteDeadline(time.Now().Add(statusTimeout))
+			if file, ok := strings.CutPrefix(strings.TrimSpace(request), "snapshot "); ok {
+				reply := snapshotReply{File: file}
+				var err error
+				if reply.Results, reply.Omitted, err = writeSnapshot(file, dash); err != nil {
+					reply.Error = err.Error()
+				}
+				json.NewEncoder(conn).Encode(reply)
+				return
+			}
+			json.NewEncoder(conn).Encode(dash.snapshot())
+		}()
+	}
+}
+
+// statusRequest envoie la requête request au socket d'état path et décode la réponse dans reply.
+func statusRequest(path, request string, reply any) error {
+	if err := checkSocketPath(path); err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("unix", path, statusTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(statusTimeout))
+	if _, err := fmt.Fprintf(conn, "%s\n", request); err != nil {
+		return err
+	}
+	return json.NewDecoder(conn).Decode(reply)
+}
+
+// queryStatus interroge le socket d'état path et retourne l'instantané reçu.
+func queryStatus(path string) (dashboardSnapshot, error) {
+	var snap dashboardSnapshot
+	err := statusRequest(path, "status", &snap)
+	return snap, err
+}
+
+// requestSnapshot demande à l'exécution qui écoute sur path d'écrire un instantané dans file. Le
+// chemin est rendu absolu: l'exécution peut avoir un autre répertoire courant.
+func requestSnapshot(path, file string) (snapshotReply, error) {
+	var reply snapshotReply
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return reply, err
+	}
+	if err := statusRequest(path, "snapshot "+abs, &reply); err != nil {
+		return reply, err
+	}
+	if reply.Error != "" {
+		return reply, errors.New(reply.Error)
+	}
+	return reply, nil
+}
+
+// runStatus implémente la sous-commande status.
+func runStatus(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	socketPtr := fs.String("socket", "", tr(msgFlagStatusSocket))
+	jsonPtr := fs.Bool("json", false, tr(msgFlagStatusJSON))
+	snapshotPtr := fs.String("snapshot", "", tr(msgFlagStatusSnapshot))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if *socketPtr == "" {
+		return fmt.Errorf("%w: status: -socket est requis", errInvalidFlags)
+	}
+	*socketPtr = resolvePath(*socketPtr, defaultStatusSocket)
+
+	out := &errWriter{w: stdout}
+	if *snapshotPtr != "" {
+		reply, err := requestSnapshot(*socketPtr, *snapshotPtr)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errIO, tr(msgStatusSnapshotError, *socketPtr, err))
+		}
+		fmt.Fprint(out, tr(msgStatusSnapshotWritten, reply.Results, reply.File))
+		if reply.Omitted > 0 {
+			fmt.Fprint(out, tr(msgStatusSnapshotOmitted, reply.Omitted))
+		}
+		return writeError(out)
+	}
+
+	snap, err := queryStatus(*socketPtr)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errIO, tr(msgStatusUnreachable, *socketPtr, err))
+	}
+
+	if *jsonPtr {
+		json.NewEncoder(out).Encode(snap)
+		return writeError(out)
+	}
+
+	state := tr(msgStatusRunning)
+	switch {
+	case snap.Done:
+		state = tr(msgStatusDone)
+	case snap.Paused:
+		state = tr(msgStatusPaused)
+	}
+	percent := 0.0
+	if snap.TotalPairs > 0 {
+		percent = 100 * float64(snap.PairsTested) / float64(snap.TotalPairs)
+	}
+	elapsed := time.Duration(snap.ElapsedSec * float64(time.Second)).Round(time.Second)
+	fmt.Fprint(out, tr(msgStatusSummary, state, snap.Params.Limit, snap.Params.Workers, snap.Params.PrimeTest))
+	fmt.Fprint(out, tr(msgStatusProgress, snap.PairsTested, snap.TotalPairs, percent, snap.PrimesFound, elapsed))
+	return writeError(out)
+}
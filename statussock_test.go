@@ -0,0 +1,162 @@
+/*
+ * Fichier: statussock_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du socket d'état local et de la sous-commande status.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// shortSocketPath retourne un chemin de socket court: la longueur des chemins de socket UNIX est limitée.
+func shortSocketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "pn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+// TestStatusSocket valide l'instantané servi par le socket et son affichage par la sous-commande status.
+func TestStatusSocket(t *testing.T) {
+	path := shortSocketPath(t)
+	stats := &searchStats{totalPairs: 100}
+	stats.pairsTested.Add(25)
+	stats.primesFound.Add(3)
+	dash := newDashboard(stats, runParams{Limit: 30, Workers: 2, PrimeTest: "miller"}, time.Now())
+	dash.ctl = primes.NewControl()
+	dash.ctl.Pause()
+
+	ln, err := startStatusSocket(path, dash)
+	if err != nil {
+		t.Fatalf("startStatusSocket: %v", err)
+	}
+	defer ln.Close()
+
+	snap, err := queryStatus(path)
+	if err != nil {
+		t.Fatalf("queryStatus: %v", err)
+	}
+	if snap.PairsTested != 25 || snap.PrimesFound != 3 || !snap.Paused || snap.Params.Limit != 30 {
+		t.Errorf("instantané inattendu: %+v", snap)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"status", "-socket", path, "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	for _, want := range []string{"suspendue", "25/100", "25.0 %", "3 trouvés"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("sortie de status sans %q:\n%s", want, out.String())
+		}
+	}
+
+	// Une seconde exécution ne peut pas réutiliser un socket encore à l'écoute.
+	if _, err := startStatusSocket(path, dash); err == nil {
+		t.Errorf("startStatusSocket sur un socket actif: erreur attendue")
+	}
+}
+
+// TestStatusSocketStale valide la reprise d'un fichier de socket laissé par une exécution précédente.
+func TestStatusSocketStale(t *testing.T) {
+	path := shortSocketPath(t)
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ln, err := startStatusSocket(path, newDashboard(&searchStats{}, runParams{}, time.Now()))
+	if err != nil {
+		t.Fatalf("startStatusSocket sur un fichier périmé: %v", err)
+	}
+	ln.Close()
+}
+
+// TestStatusUnreachable valide les codes de sortie de la sous-commande status sans exécution en cours.
+func TestStatusUnreachable(t *testing.T) {
+	if got := exitCode(run([]string{"status", "-socket", shortSocketPath(t)}, io.Discard, io.Discard)); got != exitIO {
+		t.Errorf("status sans exécution -> code %d, attendu %d", got, exitIO)
+	}
+	if got := exitCode(run([]string{"status"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("status sans -socket -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
+
+// TestStatusSocketPathTooLong valide le refus explicite d'un chemin de socket trop long pour la plateforme.
+func TestStatusSocketPathTooLong(t *testing.T) {
+	long := filepath.Join(t.TempDir(), strings.Repeat("x", maxSocketPath), "s.sock")
+	_, err := startStatusSocket(long, newDashboard(&searchStats{}, runParams{}, time.Now()))
+	if err == nil || !strings.Contains(err.Error(), "trop long") {
+		t.Errorf("startStatusSocket(%d octets) = %v, attendu un chemin trop long", len(long), err)
+	}
+	if _, err := queryStatus(long); err == nil || !strings.Contains(err.Error(), "trop long") {
+		t.Errorf("queryStatus(%d octets) = %v, attendu un chemin trop long", len(long), err)
+	}
+}
+
+// TestStatusSnapshot valide l'instantané des résultats partiels demandé par status -snapshot.
+func TestStatusSnapshot(t *testing.T) {
+	path := shortSocketPath(t)
+	stats := &searchStats{totalPairs: 100}
+	stats.pairsTested.Add(40)
+	dash := newDashboard(stats, runParams{Limit: 30}, time.Now())
+	dash.retain = 2
+	dash.keepResult(primes.Result{P: 3, Q: 2, N: 25})
+	dash.keepResult(primes.Result{P: 5, Q: 2, N: 41, Twin: true})
+	ln, err := startStatusSocket(path, dash)
+	if err != nil {
+		t.Fatalf("startStatusSocket: %v", err)
+	}
+	defer ln.Close()
+
+	file := filepath.Join(filepath.Dir(path), "snap.json")
+	var out bytes.Buffer
+	if err := run([]string{"status", "-socket", path, "-snapshot", file, "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("status -snapshot: %v", err)
+	}
+	if !strings.Contains(out.String(), "2 résultat(s) et progression écrits dans "+file) {
+		t.Errorf("sortie = %q", out.String())
+	}
+	// L'instantané est un fichier de résultats relu par diff, complété par la progression.
+	results, _, err := readResults(file)
+	if err != nil || len(results) != 2 || results[1] != (jsonResult{P: 5, Q: 2, N: 41, Twin: true}) {
+		t.Errorf("readResults = %v, %v", results, err)
+	}
+	data, _ := os.ReadFile(file)
+	if !strings.Contains(string(data), `"pairsTested":40`) {
+		t.Errorf("progression absente de l'instantané: %s", data)
+	}
+
+	// Au-delà de retain, l'instantané ne garde que les derniers résultats et compte les autres.
+	dash.keepResult(primes.Result{P: 7, Q: 2, N: 57})
+	out.Reset()
+	if err := run([]string{"status", "-socket", path, "-snapshot", file, "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("status -snapshot: %v", err)
+	}
+	if !strings.Contains(out.String(), "1 résultat(s) plus ancien(s) omis") {
+		t.Errorf("sortie = %q", out.String())
+	}
+	if data, _ := os.ReadFile(file); !strings.Contains(string(data), `"omitted":1`) {
+		t.Errorf("omis absents de l'instantané: %s", data)
+	}
+
+	// L'exécution continue de servir l'état; un fichier impossible à écrire est une erreur d'E/S.
+	if _, err := queryStatus(path); err != nil {
+		t.Errorf("queryStatus après instantané: %v", err)
+	}
+	bad := filepath.Join(filepath.Dir(path), "absent", "snap.json")
+	if got := exitCode(run([]string{"status", "-socket", path, "-snapshot", bad}, io.Discard, io.Discard)); got != exitIO {
+		t.Errorf("instantané impossible -> code %d, attendu %d", got, exitIO)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Fichier: stream.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Sous-commande stream: test de candidats lus sur l'entrée standard, un par
+ * ligne, par le pool de workers (primes.SearchStream). Une ligne est soit une
+ * paire "p,q" (ou "p q"), évaluée par la forme, soit une valeur de n seule.
+ * Par défaut, seuls les candidats premiers sont réécrits sur la sortie
+ * standard, dans l'ordre de l'entrée: stream se compose ainsi en filtre avec
+ * d'autres outils qui génèrent les candidats. -all écrit un verdict par ligne.
+ */
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// parseStreamLine analyse une ligne de l'entrée de stream: "p,q", "p q" ou n seul.
+func parseStreamLine(text string, line int) (primes.StreamCandidate, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	values := make([]int64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || v < 0 {
+			return primes.StreamCandidate{}, fmt.Errorf("%w: ligne %d: valeur invalide %q", errInvalidInput, line, f)
+		}
+		values[i] = v
+	}
+	switch len(values) {
+	case 1:
+		return primes.StreamCandidate{N: values[0]}, nil
+	case 2:
+		return primes.StreamCandidate{P: values[0], Q: values[1], Pair: true}, nil
+	}
+	return primes.StreamCandidate{}, fmt.Errorf("%w: ligne %d: %d valeurs (attendu n ou p,q)", errInvalidInput, line, len(values))
+}
+
+// formatStreamVerdict présente un verdict: n seul, ou p,q,n pour une paire, suivi du verdict
+// (prime ou composite) si all.
+func formatStreamVerdict(v primes.StreamVerdict, all bool) string {
+	s := strconv.FormatInt(v.N, 10)
+	if v.Pair {
+		s = fmt.Sprintf("%d,%d,%d", v.P, v.Q, v.N)
+	}
+	if !all {
+		return s
+	}
+	if v.Prime {
+		return s + "," + verdictPrime
+	}
+	return s + "," + verdictComposite
+}
+
+// runStream implémente la sous-commande stream: les candidats sont lus sur stdin jusqu'à sa fin.
+// Une ligne invalide arrête la lecture avec une erreur enveloppant errInvalidInput, après les
+// verdicts des lignes précédentes.
+func runStream(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("stream", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	formPtr := fs.String("form", primes.DefaultForm.Name(), tr(msgFlagForm, strings.Join(primes.FormNames(), ", ")))
+	primeTestPtr := fs.String("primetest", "miller", tr(msgFlagPrimeTest))
+	filterPtr := fs.String("filter", "", tr(msgFlagFilter, strings.Join(primes.FilterNames(), ", ")))
+	workersPtr := fs.Int("workers", defaultWorkers(), tr(msgFlagWorkers))
+	allPtr := fs.Bool("all", false, tr(msgFlagStreamAll))
+	fs.String("lang", "", tr(msgFlagLang, tr(msgDefaultLangLabel)))
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tr(msgStreamUsage))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errInvalidFlags, err)
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: stream: argument inattendu %q (les candidats sont lus sur l'entrée standard)", errInvalidFlags, fs.Arg(0))
+	}
+	form, ok := primes.LookupForm(*formPtr)
+	if !ok {
+		return fmt.Errorf("%w: -form=%q (attendu l'une de %v)", errInvalidFlags, *formPtr, primes.FormNames())
+	}
+	if !slices.Contains(primes.PrimalityTestNames(), *primeTestPtr) {
+		return fmt.Errorf("%w: -primetest=%q (attendu l'un de %v)", errInvalidFlags, *primeTestPtr, primes.PrimalityTestNames())
+	}
+	var filter primes.Filter
+	if *filterPtr != "" {
+		if filter, ok = primes.LookupFilter(*filterPtr); !ok {
+			return fmt.Errorf("%w: -filter=%q (attendu l'un de %v)", errInvalidFlags, *filterPtr, primes.FilterNames())
+		}
+	}
+	if *workersPtr < 1 {
+		return fmt.Errorf("%w: -workers=%d (attendu >= 1)", errInvalidFlags, *workersPtr)
+	}
+	opts := primes.Options{PrimeTest: *primeTestPtr, Form: form, Filter: filter, Workers: *workersPtr}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// La lecture alimente le flux depuis sa propre goroutine. Elle s'arrête à la fin de l'entrée, à la
+	// première ligne invalide (readErr) ou à l'annulation de ctx.
+	in := make(chan primes.StreamCandidate)
+	var readErr error
+	go func() {
+		defer close(in)
+		sc := bufio.NewScanner(stdin)
+		for line := 1; sc.Scan(); line++ {
+			text := strings.TrimSpace(sc.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			c, err := parseStreamLine(text, line)
+			if err != nil {
+				readErr = err
+				return
+			}
+			select {
+			case in <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			readErr = fmt.Errorf("%w: entrée standard: %v", errIO, err)
+		}
+	}()
+
+	out := &errWriter{w: stdout}
+	total, found := 0, 0
+	err := primes.SearchStream(ctx, opts, in, func(v primes.StreamVerdict) error {
+		total++
+		if v.Prime {
+			found++
+		}
+		if v.Prime || *allPtr {
+			fmt.Fprintln(out, formatStreamVerdict(v, *allPtr))
+		}
+		return writeError(out)
+	})
+	if err == nil {
+		// Le flux s'est terminé avec la fermeture de in: la lecture est finie et readErr est fixée.
+		for range in {
+		}
+		err = readErr
+	}
+	fmt.Fprint(stderr, tr(msgStreamSummary, countInt(total), countInt(found)))
+	if errors.Is(err, context.Canceled) {
+		return errInterrupted
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+/*
+ * Fichier: stream_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la sous-commande stream.
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRunStream valide le filtrage des candidats premiers, l'ordre de l'entrée, -all et les codes
+// de sortie.
+func TestRunStream(t *testing.T) {
+	const input = "# Candidats\n5,3\n7 5\n\n61\n62\n3,2\n"
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-workers", "3"}, "5,3,61\n7,5,149\n61\n"},
+		{[]string{"-workers", "3", "-all"}, "5,3,61,prime\n7,5,149,prime\n61,prime\n62,composite\n3,2,25,composite\n"},
+		{[]string{"-form", "p^2+q^4", "-all"}, "5,3,106,composite\n7,5,674,composite\n61,prime\n62,composite\n3,2,25,composite\n"},
+	} {
+		var out bytes.Buffer
+		if err := runStream(tc.args, strings.NewReader(input), &out, io.Discard); err != nil {
+			t.Fatalf("%v: %v", tc.args, err)
+		}
+		if out.String() != tc.want {
+			t.Errorf("%v: sortie = %q, attendu %q", tc.args, out.String(), tc.want)
+		}
+	}
+
+	// Une ligne invalide arrête le flux après les verdicts des lignes précédentes.
+	var out bytes.Buffer
+	err := runStream(nil, strings.NewReader("5,3\nx\n7,5\n"), &out, io.Discard)
+	if exitCode(err) != exitInvalidInput {
+		t.Errorf("ligne invalide: %v, attendu le code %d", err, exitInvalidInput)
+	}
+	if out.String() != "5,3,61\n" {
+		t.Errorf("sortie avant la ligne invalide = %q", out.String())
+	}
+
+	for _, tc := range []struct {
+		args  []string
+		input string
+		code  int
+	}{
+		{[]string{"-form", "inconnue"}, "", exitInvalidFlags},
+		{[]string{"-workers", "0"}, "", exitInvalidFlags},
+		{[]string{"61"}, "", exitInvalidFlags},
+		{nil, "1,2,3\n", exitInvalidInput},
+		{nil, "-7\n", exitInvalidInput},
+		{nil, "3037000500,3\n", exitOverflow},
+	} {
+		if err := runStream(tc.args, strings.NewReader(tc.input), io.Discard, io.Discard); exitCode(err) != tc.code {
+			t.Errorf("%v %q: %v, attendu le code %d", tc.args, tc.input, err, tc.code)
+		}
+	}
+}
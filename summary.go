@@ -0,0 +1,217 @@
+/*
+ * Fichier: summary.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Bilan de l'exécution pour l'intégration continue (options -summary-out et
+ * -summary-junit): comptes, durées et état des vérifications, écrits à la fin
+ * de l'exécution, y compris en cas d'échec, en JSON et au format XML de JUnit.
+ * Les campagnes de vérification nocturnes alimentent ainsi leurs tableaux de
+ * bord sans analyser les messages d'état ni le journal.
+ */
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// summaryFormat est la version du format de -summary-out, incrémentée à chaque changement incompatible.
+const summaryFormat = 1
+
+// États d'une exécution dans le bilan.
+const (
+	summaryPassed      = "passed"
+	summaryFailed      = "failed"
+	summaryInterrupted = "interrupted"
+)
+
+// runSummary est le bilan d'une exécution (-summary-out).
+type runSummary struct {
+	Format     int            `json:"format"`
+	Args       []string       `json:"args"`
+	Start      time.Time      `json:"start"`
+	ElapsedSec float64        `json:"elapsed_s"`
+	Status     string         `json:"status"` // passed, failed ou interrupted.
+	ExitCode   int            `json:"exit_code"`
+	Error      string         `json:"error,omitempty"`
+	Params     *runParams     `json:"params,omitempty"` // Absents si l'exécution échoue avant le crible.
+	Counts     *summaryCounts `json:"counts,omitempty"` // Absents si la recherche n'atteint pas sa finalisation.
+	Checks     []summaryCheck `json:"checks"`
+}
+
+// summaryCounts sont les comptes et durées de la recherche.
+type summaryCounts struct {
+	Results        int      `json:"results"`
+	Kept           int      `json:"kept"` // Résultats retenus par -where (égal à results sans -where).
+	Twins          int      `json:"twins,omitempty"`
+	Duplicates     int      `json:"duplicates,omitempty"`
+	Overflowed     int64    `json:"overflowed,omitempty"`
+	Stalls         int      `json:"stalls,omitempty"`  // Blocages signalés par -stall-timeout.
+	Skipped        int      `json:"skipped,omitempty"` // Paires abandonnées (-skip-stalled): résultats incomplets.
+	PairsTested    int64    `json:"pairs_tested"`
+	PairsTotal     int64    `json:"pairs_total"`
+	SearchSec      float64  `json:"search_s"`
+	PairsPerSec    float64  `json:"pairs_per_s"`
+	FirstResultSec *float64 `json:"first_result_s,omitempty"` // Avec -timing, s'il y a un résultat.
+}
+
+// summaryCheck est l'état d'une vérification: -verify, -spot-check ou -compare.
+type summaryCheck struct {
+	Name          string `json:"name"`
+	Passed        bool   `json:"passed"`
+	Checked       int64  `json:"checked"`
+	Disagreements int64  `json:"disagreements"`
+	Detail        string `json:"detail,omitempty"`
+}
+
+// newRunSummary commence le bilan d'une exécution lancée à start avec les arguments args.
+func newRunSummary(args []string, start time.Time) *runSummary {
+	s := &runSummary{Format: summaryFormat, Args: slices.Clone(args), Start: start.UTC(), Checks: []summaryCheck{}}
+	if s.Args == nil {
+		s.Args = []string{}
+	}
+	return s
+}
+
+// addCheck ajoute l'état d'une vérification au bilan.
+func (s *runSummary) addCheck(name string, checked, disagreements int64, detail string) {
+	s.Checks = append(s.Checks, summaryCheck{Name: name, Passed: disagreements == 0, Checked: checked, Disagreements: disagreements, Detail: detail})
+}
+
+// finish complète le bilan avec l'erreur finale de l'exécution, à l'instant now.
+func (s *runSummary) finish(err error, now time.Time) {
+	s.ElapsedSec = now.Sub(s.Start).Seconds()
+	s.ExitCode = exitCode(err)
+	switch {
+	case err == nil:
+		s.Status = summaryPassed
+	case errors.Is(err, errInterrupted):
+		s.Status = summaryInterrupted
+	default:
+		s.Status = summaryFailed
+	}
+	if err != nil {
+		s.Error = err.Error()
+	}
+}
+
+// writeSummary écrit le bilan en JSON dans path.
+func writeSummary(path string, s *runSummary) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
+
+// Éléments du format XML de JUnit, tel que lu par les serveurs d'intégration continue.
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       float64         `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitCase     `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// junit convertit le bilan en une suite JUnit: un cas « search » pour l'exécution elle-même (en
+// échec pour une erreur autre qu'une vérification, ignoré si elle est interrompue), puis un cas
+// par vérification.
+func (s *runSummary) junit() junitSuites {
+	suite := junitSuite{Name: "PrimeNumber", Time: s.ElapsedSec, Timestamp: s.Start.Format(time.RFC3339)}
+	search := junitCase{Name: "search", ClassName: "PrimeNumber", Time: s.ElapsedSec}
+	if s.Counts != nil {
+		search.Time = s.Counts.SearchSec
+		search.SystemOut = fmt.Sprintf("results=%d kept=%d pairs_tested=%d pairs_total=%d pairs_per_s=%.0f",
+			s.Counts.Results, s.Counts.Kept, s.Counts.PairsTested, s.Counts.PairsTotal, s.Counts.PairsPerSec)
+	}
+	verificationFailed := false
+	for _, c := range s.Checks {
+		verificationFailed = verificationFailed || !c.Passed
+	}
+	switch {
+	case s.Status == summaryInterrupted:
+		search.Skipped = &junitMessage{Message: s.Error}
+	case s.Status == summaryFailed && !(verificationFailed && s.ExitCode == exitVerification):
+		search.Failure = &junitMessage{Message: s.Error}
+	}
+	suite.Cases = append(suite.Cases, search)
+	for _, c := range s.Checks {
+		tc := junitCase{Name: c.Name, ClassName: "PrimeNumber.verification",
+			SystemOut: fmt.Sprintf("checked=%d disagreements=%d", c.Checked, c.Disagreements)}
+		if !c.Passed {
+			tc.Failure = &junitMessage{Message: c.Detail}
+			if tc.Failure.Message == "" {
+				tc.Failure.Message = s.Error
+			}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	for _, tc := range suite.Cases {
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		if tc.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+	if p := s.Params; p != nil {
+		suite.Properties = []junitProperty{
+			{Name: "form", Value: p.Form},
+			{Name: "limit", Value: fmt.Sprint(p.Limit)},
+			{Name: "prime_test", Value: p.PrimeTest},
+			{Name: "workers", Value: fmt.Sprint(p.Workers)},
+		}
+	}
+	suite.Properties = append(suite.Properties, junitProperty{Name: "exit_code", Value: fmt.Sprint(s.ExitCode)})
+	return junitSuites{Suites: []junitSuite{suite}}
+}
+
+// writeSummaryJUnit écrit le bilan au format XML de JUnit dans path.
+func writeSummaryJUnit(path string, s *runSummary) error {
+	data, err := xml.MarshalIndent(s.junit(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("%w: %v", errIO, err)
+	}
+	return nil
+}
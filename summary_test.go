@@ -0,0 +1,110 @@
+/*
+ * Fichier: summary_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du bilan d'intégration continue (-summary-out, -summary-junit).
+ */
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readSummary relit le bilan JSON de path.
+func readSummary(t *testing.T, path string) runSummary {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s runSummary
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("bilan illisible: %v\n%s", err, data)
+	}
+	return s
+}
+
+// TestRunSummary vérifie le bilan JSON et JUnit d'une exécution réussie avec -verify et
+// -spot-check, puis celui d'une exécution en échec après le début de la recherche.
+func TestRunSummary(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath, junitPath := filepath.Join(dir, "summary.json"), filepath.Join(dir, "summary.xml")
+	var out strings.Builder
+	args := []string{"-limit", "100", "-verify", "-spot-check", "1", "-format", "ndjson", "-summary-out", jsonPath, "-summary-junit", junitPath, "-lang", "fr"}
+	if err := run(args, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	results := len(strings.Split(resultLines(out.String()), "\n"))
+	s := readSummary(t, jsonPath)
+	if s.Status != summaryPassed || s.ExitCode != exitOK || s.Error != "" || len(s.Args) != len(args) {
+		t.Errorf("bilan %+v, attendu une exécution réussie", s)
+	}
+	if s.Params == nil || s.Params.Limit != 100 || s.Counts == nil || s.Counts.Results != results || s.Counts.Kept != results {
+		t.Fatalf("paramètres %+v et comptes %+v, attendu limite 100 et %d résultats", s.Params, s.Counts, results)
+	}
+	if s.Counts.PairsTested != s.Counts.PairsTotal || s.Counts.PairsTested == 0 {
+		t.Errorf("%d paires testées sur %d", s.Counts.PairsTested, s.Counts.PairsTotal)
+	}
+	if len(s.Checks) != 2 || s.Checks[0].Name != "verify" || s.Checks[1].Name != "spot-check" ||
+		!s.Checks[0].Passed || s.Checks[1].Checked != int64(results) {
+		t.Errorf("vérifications %+v", s.Checks)
+	}
+
+	data, err := os.ReadFile(junitPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("JUnit illisible: %v\n%s", err, data)
+	}
+	if len(suites.Suites) != 1 || suites.Suites[0].Tests != 3 || suites.Suites[0].Failures != 0 || suites.Suites[0].Cases[0].Name != "search" {
+		t.Errorf("JUnit:\n%s", data)
+	}
+
+	// Fichier de résultats impossible à créer: le bilan est écrit avec l'échec et son code.
+	err = run([]string{"-limit", "100", "-o", filepath.Join(dir, "absent", "r.txt"), "-summary-out", jsonPath, "-summary-junit", junitPath}, io.Discard, io.Discard)
+	if !errors.Is(err, errIO) {
+		t.Fatalf("-o dans un répertoire absent: %v", err)
+	}
+	if s := readSummary(t, jsonPath); s.Status != summaryFailed || s.ExitCode != exitIO || s.Error == "" {
+		t.Errorf("bilan de l'échec %+v", s)
+	}
+	if data, _ := os.ReadFile(junitPath); !strings.Contains(string(data), "<failure message=") {
+		t.Errorf("JUnit de l'échec sans <failure>:\n%s", data)
+	}
+
+	// Bilan impossible à écrire: erreur d'entrée-sortie.
+	if err := run([]string{"-limit", "100", "-summary-out", filepath.Join(dir, "absent", "s.json")}, io.Discard, io.Discard); !errors.Is(err, errIO) {
+		t.Errorf("bilan dans un répertoire absent: %v, attendu errIO", err)
+	}
+}
+
+// TestSummaryJUnit vérifie la répartition des cas JUnit: interruption ignorée, désaccord d'une
+// vérification en échec sans faire échouer le cas de la recherche.
+func TestSummaryJUnit(t *testing.T) {
+	start := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	s := newRunSummary(nil, start)
+	s.addCheck("compare", 10, 2, "")
+	s.finish(errVerification, start)
+	suite := s.junit().Suites[0]
+	if suite.Tests != 2 || suite.Failures != 1 || suite.Cases[0].Failure != nil || suite.Cases[1].Failure == nil || suite.Cases[1].Failure.Message == "" {
+		t.Errorf("désaccord: %+v", suite)
+	}
+
+	s = newRunSummary(nil, start)
+	s.finish(errInterrupted, start)
+	if suite := s.junit().Suites[0]; s.Status != summaryInterrupted || suite.Skipped != 1 || suite.Failures != 0 {
+		t.Errorf("interruption: %+v", suite)
+	}
+}
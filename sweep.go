@@ -0,0 +1,103 @@
+/*
+ * Fichier: sweep.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Balayage de plusieurs limites en une seule exécution (option -sweep): la
+ * recherche est faite une fois, jusqu'à la plus grande limite, et chaque
+ * résultat est compté pour toutes les limites x >= max(p, q). On obtient ainsi
+ * le tableau des comptes N(x) par limite, la donnée utile aux tracés
+ * asymptotiques, sans refaire ni le crible ni la recherche pour chaque x.
+ */
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// parseSweepCutoff analyse une limite du balayage: entier, notation scientifique (1e6) ou puissance (10^6).
+func parseSweepCutoff(s string) (int, error) {
+	if base, exp, ok := strings.Cut(s, "^"); ok {
+		b, errB := strconv.Atoi(base)
+		e, errE := strconv.Atoi(exp)
+		if errB != nil || errE != nil || b < 2 || e < 0 {
+			return 0, fmt.Errorf("valeur invalide %q", s)
+		}
+		x := 1
+		for range e {
+			if x > math.MaxInt/b {
+				return 0, fmt.Errorf("valeur trop grande %q", s)
+			}
+			x *= b
+		}
+		return x, nil
+	}
+	x, err := parseCountArg(s)
+	if err != nil {
+		return 0, err
+	}
+	if x > math.MaxInt {
+		return 0, fmt.Errorf("valeur trop grande %q", s)
+	}
+	return int(x), nil
+}
+
+// parseSweep analyse la liste des limites séparées par des virgules et la retourne triée, sans doublons.
+func parseSweep(s string) ([]int, error) {
+	var cutoffs []int
+	for _, field := range strings.Split(s, ",") {
+		x, err := parseSweepCutoff(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		if x < 2 {
+			return nil, fmt.Errorf("limite %d < 2", x)
+		}
+		cutoffs = append(cutoffs, x)
+	}
+	slices.Sort(cutoffs)
+	return slices.Compact(cutoffs), nil
+}
+
+// sweepCounts compte les résultats par limite du balayage.
+type sweepCounts struct {
+	cutoffs []int   // Limites croissantes.
+	counts  []int64 // counts[i]: résultats dont max(p, q) est dans ]cutoffs[i-1], cutoffs[i]].
+}
+
+// newSweepCounts prépare le comptage pour les limites croissantes cutoffs.
+func newSweepCounts(cutoffs []int) *sweepCounts {
+	return &sweepCounts{cutoffs: cutoffs, counts: make([]int64, len(cutoffs))}
+}
+
+// add compte un résultat dans la plus petite limite qui contient p et q.
+func (s *sweepCounts) add(res primes.Result) {
+	if i := sort.SearchInts(s.cutoffs, max(res.P, res.Q)); i < len(s.cutoffs) {
+		s.counts[i]++
+	}
+}
+
+// write écrit le tableau x, π(x), N(x) et la densité N(x)/π(x)² des paires retenues; primeList
+// (croissante, jusqu'à la plus grande limite) fournit π(x).
+func (s *sweepCounts) write(w io.Writer, primeList []int) {
+	fmt.Fprintf(w, "%-15s | %-12s | %-12s | %s\n", "x", "π(x)", "N(x)", "N(x)/π(x)²")
+	var total int64
+	for i, x := range s.cutoffs {
+		total += s.counts[i]
+		pi := sort.SearchInts(primeList, x+1)
+		density := 0.0
+		if pi > 0 {
+			density = float64(total) / (float64(pi) * float64(pi))
+		}
+		fmt.Fprintf(w, "%-15d | %-12d | %-12d | %.6g\n", x, pi, total, density)
+	}
+}
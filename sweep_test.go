@@ -0,0 +1,61 @@
+/*
+ * Fichier: sweep_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du balayage de plusieurs limites (option -sweep).
+ */
+package main
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestParseSweep valide les notations acceptées, le tri et le rejet des limites invalides.
+func TestParseSweep(t *testing.T) {
+	got, err := parseSweep("10^4, 1e3,100,10^3")
+	if err != nil || !slices.Equal(got, []int{100, 1000, 10000}) {
+		t.Errorf("parseSweep = %v (%v), attendu [100 1000 10000]", got, err)
+	}
+	for _, s := range []string{"", "10,abc", "1", "10^", "1^5", "2^100", "-5"} {
+		if _, err := parseSweep(s); err == nil {
+			t.Errorf("parseSweep(%q): erreur attendue", s)
+		}
+	}
+}
+
+// TestRunSweep valide que chaque N(x) du balayage égale le nombre de résultats d'une recherche à la limite x.
+func TestRunSweep(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-sweep", "10,30,10^2", "-workers", "2", "-lang", "fr"}, &out, io.Discard); err != nil {
+		t.Fatalf("run -sweep: %v", err)
+	}
+	for _, want := range []string{
+		"10              | 4            | 4            | 0.25\n",
+		"30              | 10           | 37           | 0.37\n",
+		"100             | 25           | 171          | 0.2736\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("tableau sans %q:\n%s", want, out.String())
+		}
+	}
+	// Contre-vérification par des recherches séparées.
+	for limit, expected := range map[string]int{"10": 4, "30": 37, "100": 171} {
+		var single bytes.Buffer
+		if err := run([]string{"-limit", limit, "-format", "json", "-manifest=false"}, &single, io.Discard); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Count(single.String(), `"n":`); got != expected {
+			t.Errorf("-limit %s: %d résultats, attendu %d", limit, got, expected)
+		}
+	}
+
+	if got := exitCode(run([]string{"-sweep", "10,100", "-limit", "50"}, io.Discard, io.Discard)); got != exitInvalidFlags {
+		t.Errorf("-sweep avec -limit -> code %d, attendu %d", got, exitInvalidFlags)
+	}
+}
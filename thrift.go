@@ -0,0 +1,267 @@
+/*
+ * Fichier: thrift.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Protocole compact de Thrift, réduit à ce qu'exigent les métadonnées du
+ * format Parquet (parquet.go): écriture champ par champ, et lecture générique
+ * d'une structure en table de champs, sans schéma ni code généré.
+ */
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Types des champs du protocole compact.
+const (
+	thriftBoolTrue  = 1
+	thriftBoolFalse = 2
+	thriftByte      = 3
+	thriftI16       = 4
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftDouble    = 7
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftSet       = 10
+	thriftMap       = 11
+	thriftStruct    = 12
+)
+
+// maxThriftDepth borne l'imbrication des structures lues.
+const maxThriftDepth = 32
+
+// thriftWriter écrit une structure Thrift. Les identifiants de champ d'une structure doivent
+// être croissants, comme ceux des schémas Parquet.
+type thriftWriter struct {
+	buf  []byte
+	last []int64 // Identifiant du dernier champ de chaque structure ouverte.
+}
+
+// field écrit l'en-tête du champ id de type typ: écart avec le champ précédent s'il tient sur
+// quatre bits, identifiant complet sinon.
+func (w *thriftWriter) field(id int64, typ byte) {
+	if len(w.last) == 0 {
+		w.last = []int64{0}
+	}
+	top := &w.last[len(w.last)-1]
+	if d := id - *top; d > 0 && d <= 15 {
+		w.buf = append(w.buf, byte(d)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.buf = binary.AppendUvarint(w.buf, zigzag(id))
+	}
+	*top = id
+}
+
+func (w *thriftWriter) i32(id, v int64) {
+	w.field(id, thriftI32)
+	w.buf = binary.AppendUvarint(w.buf, zigzag(v))
+}
+
+func (w *thriftWriter) i64(id, v int64) {
+	w.field(id, thriftI64)
+	w.buf = binary.AppendUvarint(w.buf, zigzag(v))
+}
+
+func (w *thriftWriter) binary(id int64, s string) {
+	w.field(id, thriftBinary)
+	w.listBinary(s)
+}
+
+// beginStruct ouvre le champ structure id, fermé par endStruct.
+func (w *thriftWriter) beginStruct(id int64) {
+	w.field(id, thriftStruct)
+	w.beginElem()
+}
+
+// beginElem ouvre une structure élément d'une liste, fermée par endStruct.
+func (w *thriftWriter) beginElem() {
+	if len(w.last) == 0 {
+		w.last = []int64{0}
+	}
+	w.last = append(w.last, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf = append(w.buf, 0)
+	w.last = w.last[:len(w.last)-1]
+}
+
+// listHeader ouvre le champ liste id de n éléments de type elem, écrits ensuite un à un.
+func (w *thriftWriter) listHeader(id int64, n int, elem byte) {
+	w.field(id, thriftList)
+	if n < 15 {
+		w.buf = append(w.buf, byte(n)<<4|elem)
+	} else {
+		w.buf = append(w.buf, 0xf0|elem)
+		w.buf = binary.AppendUvarint(w.buf, uint64(n))
+	}
+}
+
+func (w *thriftWriter) listI32(v int64) { w.buf = binary.AppendUvarint(w.buf, zigzag(v)) }
+
+func (w *thriftWriter) listBinary(s string) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// finish termine la structure de plus haut niveau et retourne son codage.
+func (w *thriftWriter) finish() []byte {
+	return append(w.buf, 0)
+}
+
+// thriftFields est une structure lue: la valeur de chaque champ par identifiant (int64 pour les
+// entiers, bool, float64, []byte, thriftFields pour une structure, []any pour une liste).
+type thriftFields map[int16]any
+
+func (f thriftFields) int(id int16) int64 {
+	v, _ := f[id].(int64)
+	return v
+}
+
+func (f thriftFields) bytes(id int16) []byte {
+	v, _ := f[id].([]byte)
+	return v
+}
+
+func (f thriftFields) list(id int16) []any {
+	v, _ := f[id].([]any)
+	return v
+}
+
+func (f thriftFields) structure(id int16) thriftFields {
+	v, _ := f[id].(thriftFields)
+	return v
+}
+
+// errThriftCorrupt signale une structure Thrift tronquée ou mal formée.
+var errThriftCorrupt = errors.New("structure Thrift mal formée")
+
+// readThriftStruct lit une structure au début de data et retourne ses champs et sa taille.
+func readThriftStruct(data []byte) (thriftFields, int, error) {
+	r := thriftReader{data: data}
+	f := r.structure(0)
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	return f, r.pos, nil
+}
+
+// thriftReader lit le protocole compact; la première erreur est retenue et les lectures
+// suivantes retournent des valeurs nulles.
+type thriftReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *thriftReader) byte() byte {
+	if r.err != nil || r.pos >= len(r.data) {
+		r.err = errThriftCorrupt
+		return 0
+	}
+	r.pos++
+	return r.data[r.pos-1]
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.err = errThriftCorrupt
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+// take retourne les n octets suivants.
+func (r *thriftReader) take(n uint64) []byte {
+	if r.err != nil || n > uint64(len(r.data)-r.pos) {
+		r.err = errThriftCorrupt
+		return nil
+	}
+	r.pos += int(n)
+	return r.data[r.pos-int(n) : r.pos]
+}
+
+func (r *thriftReader) structure(depth int) thriftFields {
+	if depth > maxThriftDepth {
+		r.err = errThriftCorrupt
+		return nil
+	}
+	f := thriftFields{}
+	var last int64
+	for r.err == nil {
+		b := r.byte()
+		if b == 0 {
+			break
+		}
+		typ, delta := b&0x0f, int64(b>>4)
+		id := last + delta
+		if delta == 0 {
+			id = unzigzag(r.uvarint())
+		}
+		last = id
+		if typ == thriftBoolTrue || typ == thriftBoolFalse {
+			f[int16(id)] = typ == thriftBoolTrue
+		} else {
+			f[int16(id)] = r.value(typ, depth)
+		}
+	}
+	return f
+}
+
+// value lit une valeur de type typ (élément de liste ou champ autre que booléen).
+func (r *thriftReader) value(typ byte, depth int) any {
+	switch typ {
+	case thriftBoolTrue, thriftBoolFalse:
+		return r.byte() == thriftBoolTrue
+	case thriftByte:
+		return int64(int8(r.byte()))
+	case thriftI16, thriftI32, thriftI64:
+		return unzigzag(r.uvarint())
+	case thriftDouble:
+		if b := r.take(8); b != nil {
+			return math.Float64frombits(binary.LittleEndian.Uint64(b))
+		}
+	case thriftBinary:
+		return r.take(r.uvarint())
+	case thriftList, thriftSet:
+		h := r.byte()
+		size, elem := uint64(h>>4), h&0x0f
+		if size == 15 {
+			size = r.uvarint()
+		}
+		if size > uint64(len(r.data)-r.pos) { // Chaque élément occupe au moins un octet.
+			r.err = errThriftCorrupt
+			return nil
+		}
+		list := make([]any, 0, size)
+		for range size {
+			list = append(list, r.value(elem, depth+1))
+		}
+		return list
+	case thriftMap:
+		// Les tables (key_value_metadata) ne sont pas exploitées: elles sont lues puis ignorées.
+		if size := r.uvarint(); size > 0 {
+			kv := r.byte()
+			for range min(size, uint64(len(r.data))) {
+				r.value(kv>>4, depth+1)
+				r.value(kv&0x0f, depth+1)
+			}
+		}
+	case thriftStruct:
+		return r.structure(depth + 1)
+	default:
+		r.err = errThriftCorrupt
+	}
+	return nil
+}
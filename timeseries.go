@@ -0,0 +1,130 @@
+/*
+ * Fichier: timeseries.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Série temporelle du rythme de découverte (option -timeseries): à intervalle
+ * régulier (-timeseries-interval) puis une dernière fois à la fin, l'heure, le
+ * temps écoulé, le nombre de paires testées et le nombre de résultats sont
+ * ajoutés à un fichier CSV ou JSON, pour tracer l'évolution du débit et du
+ * rythme de découverte au cours d'une longue exécution.
+ */
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// timeSeriesFormats sont les formats acceptés par -timeseries-format.
+var timeSeriesFormats = []string{"csv", "json"}
+
+// timeSample est un point de la série temporelle.
+type timeSample struct {
+	Time        time.Time `json:"time"`
+	ElapsedSec  float64   `json:"elapsed_s"`
+	PairsTested int64     `json:"pairs_tested"`
+	PrimesFound int64     `json:"primes_found"`
+}
+
+// timeSeries écrit les points de la série dans un fichier, à partir des compteurs de l'exécution.
+type timeSeries struct {
+	f      *os.File
+	w      *bufio.Writer
+	out    *errWriter
+	format string
+	stats  *searchStats
+	start  time.Time
+
+	mu    sync.Mutex
+	count int
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// openTimeSeries crée le fichier path et écrit l'en-tête du format donné ("csv" ou "json").
+func openTimeSeries(path, format string, stats *searchStats, start time.Time) (*timeSeries, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errIO, err)
+	}
+	ts := &timeSeries{f: f, w: bufio.NewWriter(f), format: format, stats: stats, start: start}
+	ts.out = &errWriter{w: ts.w}
+	if format == "csv" {
+		fmt.Fprintln(ts.out, "time,elapsed_s,pairs_tested,primes_found")
+	} else {
+		fmt.Fprint(ts.out, "[")
+	}
+	return ts, nil
+}
+
+// sample ajoute un point lu dans les compteurs à l'instant now.
+func (ts *timeSeries) sample(now time.Time) {
+	s := timeSample{
+		Time:        now.UTC(),
+		ElapsedSec:  now.Sub(ts.start).Seconds(),
+		PairsTested: ts.stats.pairsTested.Load(),
+		PrimesFound: ts.stats.primesFound.Load(),
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.format == "csv" {
+		fmt.Fprintf(ts.out, "%s,%.3f,%d,%d\n", s.Time.Format(time.RFC3339Nano), s.ElapsedSec, s.PairsTested, s.PrimesFound)
+	} else {
+		data, _ := json.Marshal(s)
+		if ts.count > 0 {
+			fmt.Fprint(ts.out, ",")
+		}
+		fmt.Fprintf(ts.out, "\n%s", data)
+	}
+	ts.count++
+	ts.w.Flush() // Le fichier reste exploitable pendant l'exécution.
+}
+
+// run lance l'échantillonnage toutes les interval, jusqu'à l'appel de close.
+func (ts *timeSeries) run(interval time.Duration) {
+	ts.stop = make(chan struct{})
+	ts.wg.Add(1)
+	go func() {
+		defer ts.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				ts.sample(now)
+			case <-ts.stop:
+				return
+			}
+		}
+	}()
+}
+
+// close arrête l'échantillonnage, ajoute le point final, termine le document et ferme le fichier.
+func (ts *timeSeries) close() error {
+	if ts.stop != nil {
+		close(ts.stop)
+		ts.wg.Wait()
+	}
+	ts.sample(time.Now())
+	if ts.format == "json" {
+		fmt.Fprint(ts.out, "\n]\n")
+	}
+	if err := ts.w.Flush(); err != nil && ts.out.err == nil {
+		ts.out.err = err
+	}
+	cerr := ts.f.Close()
+	if ts.out.err != nil {
+		return fmt.Errorf("%w: %v", errIO, ts.out.err)
+	}
+	if cerr != nil {
+		return fmt.Errorf("%w: %v", errIO, cerr)
+	}
+	return nil
+}
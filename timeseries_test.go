@@ -0,0 +1,83 @@
+/*
+ * Fichier: timeseries_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests de la série temporelle du rythme de découverte (option -timeseries).
+ */
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestTimeSeriesCSV valide l'en-tête, l'échantillonnage périodique et le point final au format CSV.
+func TestTimeSeriesCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "serie.csv")
+	stats := &searchStats{}
+	ts, err := openTimeSeries(path, "csv", stats, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.run(time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	stats.pairsTested.Store(100)
+	stats.primesFound.Store(37)
+	if err := ts.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV invalide: %v", err)
+	}
+	if len(records) < 3 || records[0][0] != "time" || len(records[0]) != 4 {
+		t.Fatalf("enregistrements = %v, attendu un en-tête et plusieurs points", records)
+	}
+	if last := records[len(records)-1]; last[2] != "100" || last[3] != "37" {
+		t.Errorf("point final = %v, attendu 100 paires et 37 résultats", last)
+	}
+}
+
+// TestRunTimeSeries valide la série JSON de bout en bout et le rejet des options invalides.
+func TestRunTimeSeries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "serie.json")
+	if err := run([]string{"-limit", "30", "-timeseries", path, "-timeseries-format", "json"}, io.Discard, io.Discard); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var samples []timeSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		t.Fatalf("JSON invalide: %v\n%s", err, data)
+	}
+	if len(samples) == 0 {
+		t.Fatal("aucun point")
+	}
+	if last := samples[len(samples)-1]; last.PairsTested != 100 || last.PrimesFound != 37 || last.ElapsedSec < 0 {
+		t.Errorf("point final = %+v, attendu 100 paires et 37 résultats", last)
+	}
+
+	for _, args := range [][]string{{"-timeseries-format", "xml"}, {"-timeseries-interval", "0s"}} {
+		if got := exitCode(run(args, io.Discard, io.Discard)); got != exitInvalidFlags {
+			t.Errorf("%v -> code %d, attendu %d", args, got, exitInvalidFlags)
+		}
+	}
+	if got := exitCode(run([]string{"-limit", "30", "-timeseries", filepath.Join(t.TempDir(), "absent", "serie.csv")}, io.Discard, io.Discard)); got != exitIO {
+		t.Errorf("répertoire absent -> code %d, attendu %d", got, exitIO)
+	}
+}
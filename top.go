@@ -0,0 +1,48 @@
+/*
+ * Fichier: top.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Classement de -top K -by champ[:asc|desc]: seuls les K plus grands (desc,
+ * par défaut) ou plus petits (asc) résultats selon p, q, n ou twin sont
+ * écrits, en fin de recherche, au lieu de la liste complète.
+ */
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// parseTopBy lit la valeur de -by et retourne l'ordre du classement: less(a, b) indique que a
+// est classé avant b. Les égalités sont départagées par n, p puis q croissants, pour un
+// classement indépendant de l'ordre d'arrivée des résultats.
+func parseTopBy(value string) (func(a, b primes.Result) bool, error) {
+	name, order, _ := strings.Cut(value, ":")
+	field, ok := whereFields[name]
+	if !ok {
+		return nil, fmt.Errorf("champ %q inconnu (attendu p, q, n ou twin)", name)
+	}
+	var descending bool
+	switch order {
+	case "", "desc":
+		descending = true
+	case "asc":
+	default:
+		return nil, fmt.Errorf("ordre %q inconnu (attendu asc ou desc)", order)
+	}
+	return func(a, b primes.Result) bool {
+		c := cmp.Compare(field(a), field(b))
+		if descending {
+			c = -c
+		}
+		if c == 0 {
+			c = cmp.Or(cmp.Compare(a.N, b.N), cmp.Compare(a.P, b.P), cmp.Compare(a.Q, b.Q))
+		}
+		return c < 0
+	}, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Fichier: top_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du classement de -top.
+ */
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestParseTopBy valide les champs et ordres de -by, et le départage des égalités.
+func TestParseTopBy(t *testing.T) {
+	a, b := primes.Result{P: 3, Q: 5, N: 109}, primes.Result{P: 5, Q: 2, N: 41}
+	testCases := []struct {
+		by     string
+		aFirst bool
+	}{
+		{"n", true},
+		{"n:asc", false},
+		{"p:desc", false},
+		{"p:asc", true},
+		{"twin", false}, // Égalité: n croissant.
+	}
+	for _, tc := range testCases {
+		less, err := parseTopBy(tc.by)
+		if err != nil {
+			t.Errorf("parseTopBy(%q) = %v", tc.by, err)
+			continue
+		}
+		if got := less(a, b); got != tc.aFirst {
+			t.Errorf("-by %s: %v avant %v = %v, attendu %v", tc.by, a, b, got, tc.aFirst)
+		}
+	}
+	for _, by := range []string{"m", "n:haut", ""} {
+		if _, err := parseTopBy(by); err == nil {
+			t.Errorf("parseTopBy(%q) accepté", by)
+		}
+	}
+}
+
+// TestRunTop vérifie que seuls les K premiers résultats sont écrits, dans l'ordre du classement.
+func TestRunTop(t *testing.T) {
+	var out strings.Builder
+	if err := run([]string{"-limit", "60", "-top", "3", "-by", "p:asc", "-format", "ndjson", "-manifest=false"}, &out, io.Discard); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	expected := "{\"p\":3,\"q\":5,\"n\":109}\n{\"p\":3,\"q\":19,\"n\":1453}\n{\"p\":3,\"q\":29,\"n\":3373}\n"
+	if out.String() != expected {
+		t.Errorf("sortie = %q, attendu %q", out.String(), expected)
+	}
+	for _, args := range [][]string{{"-top", "-1"}, {"-by", "n"}, {"-top", "3", "-by", "x"}, {"-top", "3", "-explain", "results"}} {
+		if err := run(args, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+			t.Errorf("run(%v) = %v, attendu des options invalides", args, err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ * Fichier: tui.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Interface terminal interactive (option -tui) construite avec bubbletea:
+ * tableau des derniers résultats, barres d'utilisation par worker, courbe
+ * (sparkline) du débit et raccourcis clavier pour suspendre, reprendre ou
+ * arrêter la recherche.
+ */
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+const (
+	// tuiBarWidth est la largeur, en caractères, des barres d'utilisation.
+	tuiBarWidth = 30
+	// tuiSparklineLen est le nombre d'échantillons de débit affichés.
+	tuiSparklineLen = 60
+	// tuiSampleInterval est l'intervalle minimal entre deux échantillons de débit.
+	tuiSampleInterval = time.Second
+)
+
+// sparkRunes sont les niveaux utilisés pour dessiner la courbe de débit.
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// Messages reçus par le modèle depuis la goroutine de recherche.
+type (
+	tuiProgressMsg struct {
+		progress primes.Progress
+		at       time.Time
+	}
+	tuiResultMsg primes.Result
+	tuiDoneMsg   struct{}
+)
+
+// tuiReporter transmet l'avancement et les résultats de la recherche au modèle
+// (primes.ProgressReporter). La fin est signalée par l'appelant (tuiDoneMsg), y compris quand la
+// recherche échoue avant de commencer.
+type tuiReporter struct {
+	primes.NopReporter
+	ui *tea.Program
+}
+
+// OnBatchDone implémente primes.ProgressReporter.
+func (r tuiReporter) OnBatchDone(pr primes.Progress) {
+	r.ui.Send(tuiProgressMsg{progress: pr, at: time.Now()})
+}
+
+// OnResult implémente primes.ProgressReporter.
+func (r tuiReporter) OnResult(res primes.Result) { r.ui.Send(tuiResultMsg(res)) }
+
+// tuiModel est l'état de l'interface terminal.
+type tuiModel struct {
+	ctl       *primes.Control
+	params    runParams
+	startTime time.Time
+
+	progress    primes.Progress
+	sample      primes.Progress // Dernier échantillon utilisé pour le débit et l'utilisation.
+	sampleAt    time.Time
+	utilization []float64
+	rates       []float64
+	results     []primes.Result
+
+	done     bool
+	stopping bool
+}
+
+// newTUI crée le programme bubbletea pilotant la recherche via ctl.
+func newTUI(ctl *primes.Control, params runParams, startTime time.Time) *tea.Program {
+	m := &tuiModel{ctl: ctl, params: params, startTime: startTime, sampleAt: startTime}
+	return tea.NewProgram(m, tea.WithAltScreen())
+}
+
+func (m *tuiModel) Init() tea.Cmd { return nil }
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "p", " ":
+			if m.ctl.Paused() {
+				m.ctl.Resume()
+			} else {
+				m.ctl.Pause()
+			}
+		case "r":
+			m.ctl.Resume()
+		case "q", "ctrl+c", "esc":
+			if m.done {
+				return m, tea.Quit
+			}
+			// Les workers terminent les tâches déjà distribuées; on quitte à la réception de tuiDoneMsg.
+			m.stopping = true
+			m.ctl.Stop()
+		}
+	case tuiProgressMsg:
+		m.progress = msg.progress
+		m.addSample(msg.progress, msg.at)
+	case tuiResultMsg:
+		m.results = append(m.results, primes.Result(msg))
+		if len(m.results) > maxRecentResults {
+			m.results = m.results[len(m.results)-maxRecentResults:]
+		}
+	case tuiDoneMsg:
+		m.done = true
+		if m.stopping {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// addSample met à jour le débit et l'utilisation des workers au plus une fois par tuiSampleInterval.
+func (m *tuiModel) addSample(pr primes.Progress, at time.Time) {
+	elapsed := at.Sub(m.sampleAt)
+	if elapsed < tuiSampleInterval {
+		return
+	}
+
+	m.rates = append(m.rates, float64(pr.Tested-m.sample.Tested)/elapsed.Seconds())
+	if len(m.rates) > tuiSparklineLen {
+		m.rates = m.rates[len(m.rates)-tuiSparklineLen:]
+	}
+
+	m.utilization = make([]float64, len(pr.Workers))
+	for i, ws := range pr.Workers {
+		busy := ws.Busy
+		if i < len(m.sample.Workers) {
+			busy -= m.sample.Workers[i].Busy
+		}
+		m.utilization[i] = min(1, float64(busy)/float64(elapsed))
+	}
+
+	m.sample, m.sampleAt = pr, at
+}
+
+func (m *tuiModel) View() string {
+	var b strings.Builder
+
+	state := tr(msgTUIRunning)
+	switch {
+	case m.done:
+		state = tr(msgTUIDone)
+	case m.stopping:
+		state = tr(msgTUIStopping)
+	case m.ctl.Paused():
+		state = tr(msgTUIPaused)
+	}
+	b.WriteString(tr(msgTUITitle, m.params.Limit, m.params.Workers, m.params.PrimeTest, state))
+
+	pct := 0.0
+	if m.progress.Total > 0 {
+		pct = float64(m.progress.Tested) / float64(m.progress.Total)
+	}
+	b.WriteString(tr(msgTUIProgress, bar(pct, tuiBarWidth), 100*pct, m.progress.Tested, m.progress.Total))
+	b.WriteString(tr(msgTUIFound, m.progress.Found, time.Since(m.startTime).Round(time.Second)))
+
+	rate := 0.0
+	if len(m.rates) > 0 {
+		rate = m.rates[len(m.rates)-1]
+	}
+	b.WriteString(tr(msgTUIRate, sparkline(m.rates), rate))
+
+	b.WriteString(tr(msgTUIUtilization))
+	for i, u := range m.utilization {
+		fmt.Fprintf(&b, "  #%-3d %s %3.0f %%\n", i, bar(u, tuiBarWidth), 100*u)
+	}
+
+	fmt.Fprintf(&b, "\n%-10s | %-10s | %-25s\n", "p", "q", "n = p^2 + 4q^2")
+	for i := len(m.results) - 1; i >= 0; i-- {
+		r := m.results[i]
+		fmt.Fprintf(&b, "%-10d | %-10d | %-25d\n", r.P, r.Q, r.N)
+	}
+
+	if m.done {
+		b.WriteString(tr(msgTUIKeysDone))
+	} else {
+		b.WriteString(tr(msgTUIKeysRunning))
+	}
+	return b.String()
+}
+
+// bar dessine une barre horizontale remplie à la fraction donnée (entre 0 et 1).
+func bar(fraction float64, width int) string {
+	filled := int(fraction * float64(width))
+	filled = max(0, min(width, filled))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat(" ", width-filled) + "]"
+}
+
+// sparkline dessine une courbe compacte des valeurs, normalisée sur leur maximum.
+func sparkline(values []float64) string {
+	peak := 0.0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if peak > 0 {
+			level = int(v / peak * float64(len(sparkRunes)-1))
+		}
+		b.WriteRune(sparkRunes[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+/*
+ * Fichier: tui_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du modèle de l'interface terminal (sans terminal réel).
+ */
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestTUIKeys valide les raccourcis de pause, reprise et arrêt.
+func TestTUIKeys(t *testing.T) {
+	ctl := primes.NewControl()
+	m := &tuiModel{ctl: ctl, startTime: time.Now()}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !ctl.Paused() || !strings.Contains(m.View(), "en pause") {
+		t.Errorf("'p' n'a pas suspendu la recherche")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if ctl.Paused() {
+		t.Errorf("'r' n'a pas repris la recherche")
+	}
+
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd != nil {
+		t.Errorf("'q' ne doit pas quitter avant la fin effective de la recherche")
+	}
+	if !ctl.Stopped() {
+		t.Errorf("'q' n'a pas arrêté la recherche")
+	}
+	if _, cmd := m.Update(tuiDoneMsg{}); cmd == nil {
+		t.Errorf("la fin de la recherche après 'q' doit quitter l'interface")
+	}
+}
+
+// TestTUISample valide le calcul du débit et de l'utilisation des workers.
+func TestTUISample(t *testing.T) {
+	start := time.Now()
+	m := &tuiModel{ctl: primes.NewControl(), startTime: start, sampleAt: start}
+
+	// Échantillon trop rapproché: ignoré.
+	m.addSample(primes.Progress{Tested: 10}, start.Add(tuiSampleInterval/2))
+	if len(m.rates) != 0 {
+		t.Fatalf("échantillon ignoré attendu, %d enregistrés", len(m.rates))
+	}
+
+	pr := primes.Progress{Tested: 2000, Workers: []primes.WorkerStats{{Busy: time.Second}, {Busy: 500 * time.Millisecond}}}
+	m.addSample(pr, start.Add(2*time.Second))
+	if len(m.rates) != 1 || m.rates[0] != 1000 {
+		t.Errorf("débit = %v, attendu [1000]", m.rates)
+	}
+	if len(m.utilization) != 2 || m.utilization[0] != 0.5 || m.utilization[1] != 0.25 {
+		t.Errorf("utilisation = %v, attendu [0.5 0.25]", m.utilization)
+	}
+}
+
+// TestSparklineAndBar valide le rendu des éléments graphiques.
+func TestSparklineAndBar(t *testing.T) {
+	if got := sparkline([]float64{0, 7, 14}); got != "▁▄█" {
+		t.Errorf("sparkline = %q, attendu %q", got, "▁▄█")
+	}
+	if got := bar(0.5, 4); got != "[██  ]" {
+		t.Errorf("bar(0.5, 4) = %q, attendu %q", got, "[██  ]")
+	}
+	if got := bar(2, 2); got != "[██]" {
+		t.Errorf("bar(2, 2) = %q, attendu %q", got, "[██]")
+	}
+}
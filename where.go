@@ -0,0 +1,328 @@
+/*
+ * Fichier: where.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Langage d'expressions de -where, qui restreint les résultats écrits sur la
+ * sortie et dans les destinations -sink, par exemple "n > 1e9 && p % 4 == 1".
+ * Les champs d'un résultat (p, q, n, twin) et les littéraux sont des entiers
+ * (1e9 est accepté s'il est entier); les opérateurs et leurs priorités sont
+ * ceux de Go: || puis && puis comparaisons, + -, * / %, et enfin ! et -
+ * unaires. Une comparaison vaut 1 ou 0; une valeur non nulle est vraie. Une
+ * division par zéro ou un débordement rend l'expression fausse pour ce
+ * résultat.
+ */
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// whereFields sont les champs d'un résultat utilisables dans -where.
+var whereFields = map[string]func(primes.Result) int64{
+	"p": func(r primes.Result) int64 { return int64(r.P) },
+	"q": func(r primes.Result) int64 { return int64(r.Q) },
+	"n": func(r primes.Result) int64 { return r.N },
+	"twin": func(r primes.Result) int64 {
+		if r.Twin {
+			return 1
+		}
+		return 0
+	},
+}
+
+// whereNode est une expression compilée: sa valeur pour un résultat, ok valant false en cas de
+// division par zéro ou de débordement.
+type whereNode func(primes.Result) (v int64, ok bool)
+
+// whereExpr est une expression -where compilée.
+type whereExpr struct {
+	src  string
+	eval whereNode
+}
+
+// match indique si res satisfait l'expression.
+func (e *whereExpr) match(res primes.Result) bool {
+	v, ok := e.eval(res)
+	return ok && v != 0
+}
+
+func (e *whereExpr) String() string { return e.src }
+
+// parseWhere compile une expression -where.
+func parseWhere(src string) (*whereExpr, error) {
+	tokens, err := tokenizeWhere(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &whereParser{tokens: tokens}
+	eval, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != whereEOF {
+		return nil, fmt.Errorf("position %d: %q inattendu", tok.pos+1, tok.text)
+	}
+	return &whereExpr{src: src, eval: eval}, nil
+}
+
+// whereTokenKind est la nature d'un lexème de -where.
+type whereTokenKind int
+
+const (
+	whereEOF whereTokenKind = iota
+	whereNumber
+	whereIdent
+	whereOp
+)
+
+// whereToken est un lexème de -where; pos est son décalage en octets dans l'expression.
+type whereToken struct {
+	kind  whereTokenKind
+	text  string
+	value int64
+	pos   int
+}
+
+// whereOps sont les opérateurs reconnus, les plus longs d'abord.
+var whereOps = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%", "!", "(", ")"}
+
+// tokenizeWhere découpe src en lexèmes, terminés par whereEOF.
+func tokenizeWhere(src string) ([]whereToken, error) {
+	var tokens []whereToken
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			j := i
+			for j < len(src) && (strings.ContainsRune("0123456789._eE", rune(src[j])) ||
+				(src[j] == '+' || src[j] == '-') && (src[j-1] == 'e' || src[j-1] == 'E')) {
+				j++
+			}
+			v, err := parseWhereNumber(src[i:j])
+			if err != nil {
+				return nil, fmt.Errorf("position %d: %v", i+1, err)
+			}
+			tokens = append(tokens, whereToken{kind: whereNumber, text: src[i:j], value: v, pos: i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(src) && (unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j])) || src[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, whereToken{kind: whereIdent, text: src[i:j], pos: i})
+			i = j
+		default:
+			op := ""
+			for _, o := range whereOps {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("position %d: caractère %q inattendu", i+1, c)
+			}
+			tokens = append(tokens, whereToken{kind: whereOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, whereToken{kind: whereEOF, text: "fin", pos: len(src)}), nil
+}
+
+// parseWhereNumber lit un littéral entier, éventuellement en notation scientifique (1e9, 2.5e3).
+func parseWhereNumber(text string) (int64, error) {
+	if v, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil || f != math.Trunc(f) || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("nombre %q invalide (entier attendu)", text)
+	}
+	return int64(f), nil
+}
+
+// whereParser analyse les lexèmes par descente récursive, avec priorité des opérateurs binaires.
+type whereParser struct {
+	tokens []whereToken
+	pos    int
+}
+
+func (p *whereParser) peek() whereToken { return p.tokens[p.pos] }
+
+func (p *whereParser) next() whereToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != whereEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// whereLevels sont les opérateurs binaires par priorité croissante.
+var whereLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseBinary analyse une suite d'opérandes reliés par les opérateurs de priorité level ou plus.
+func (p *whereParser) parseBinary(level int) (whereNode, error) {
+	if level == len(whereLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != whereOp || !slices.Contains(whereLevels[level], tok.text) {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = whereBinary(tok.text, left, right)
+	}
+}
+
+// parseUnary analyse un opérande, précédé d'éventuels ! ou - unaires.
+func (p *whereParser) parseUnary() (whereNode, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == whereOp && (tok.text == "!" || tok.text == "-"):
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if tok.text == "!" {
+			return func(r primes.Result) (int64, bool) {
+				v, ok := operand(r)
+				return boolInt(v == 0), ok
+			}, nil
+		}
+		return func(r primes.Result) (int64, bool) {
+			v, ok := operand(r)
+			return -v, ok && v != math.MinInt64
+		}, nil
+	case tok.kind == whereOp && tok.text == "(":
+		inner, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.text != ")" || closing.kind != whereOp {
+			return nil, fmt.Errorf("position %d: \")\" attendu, %q trouvé", closing.pos+1, closing.text)
+		}
+		return inner, nil
+	case tok.kind == whereNumber:
+		v := tok.value
+		return func(primes.Result) (int64, bool) { return v, true }, nil
+	case tok.kind == whereIdent:
+		field, ok := whereFields[tok.text]
+		if !ok {
+			return nil, fmt.Errorf("position %d: champ %q inconnu (attendu p, q, n ou twin)", tok.pos+1, tok.text)
+		}
+		return func(r primes.Result) (int64, bool) { return field(r), true }, nil
+	}
+	return nil, fmt.Errorf("position %d: opérande attendu, %q trouvé", tok.pos+1, tok.text)
+}
+
+// whereBinary compose deux opérandes par l'opérateur op. && et || n'évaluent leur second
+// opérande que si nécessaire.
+func whereBinary(op string, left, right whereNode) whereNode {
+	switch op {
+	case "&&", "||":
+		return func(r primes.Result) (int64, bool) {
+			l, ok := left(r)
+			if !ok || (l != 0) == (op == "||") {
+				return boolInt(l != 0), ok
+			}
+			v, ok := right(r)
+			return boolInt(v != 0), ok
+		}
+	}
+	apply := whereArithmetic[op]
+	return func(r primes.Result) (int64, bool) {
+		l, ok := left(r)
+		if !ok {
+			return 0, false
+		}
+		v, ok := right(r)
+		if !ok {
+			return 0, false
+		}
+		return apply(l, v)
+	}
+}
+
+// whereArithmetic sont les opérateurs binaires hors && et ||; ok vaut false en cas de division
+// par zéro ou de débordement.
+var whereArithmetic = map[string]func(a, b int64) (int64, bool){
+	"==": func(a, b int64) (int64, bool) { return boolInt(a == b), true },
+	"!=": func(a, b int64) (int64, bool) { return boolInt(a != b), true },
+	"<":  func(a, b int64) (int64, bool) { return boolInt(a < b), true },
+	"<=": func(a, b int64) (int64, bool) { return boolInt(a <= b), true },
+	">":  func(a, b int64) (int64, bool) { return boolInt(a > b), true },
+	">=": func(a, b int64) (int64, bool) { return boolInt(a >= b), true },
+	"+": func(a, b int64) (int64, bool) {
+		s := a + b
+		return s, (s > a) == (b > 0)
+	},
+	"-": func(a, b int64) (int64, bool) {
+		d := a - b
+		return d, (d < a) == (b > 0)
+	},
+	"*": func(a, b int64) (int64, bool) {
+		hi, lo := bits.Mul64(uint64(absInt64(a)), uint64(absInt64(b)))
+		if hi != 0 || lo > math.MaxInt64 || a == math.MinInt64 || b == math.MinInt64 {
+			return 0, false
+		}
+		return a * b, true
+	},
+	"/": func(a, b int64) (int64, bool) {
+		if b == 0 || (a == math.MinInt64 && b == -1) {
+			return 0, false
+		}
+		return a / b, true
+	},
+	"%": func(a, b int64) (int64, bool) {
+		if b == 0 {
+			return 0, false
+		}
+		if b == -1 {
+			return 0, true
+		}
+		return a % b, true
+	},
+}
+
+// boolInt convertit une condition en 1 ou 0.
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// absInt64 retourne |a| (inchangé pour math.MinInt64, traité à part par l'appelant).
+func absInt64(a int64) int64 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,94 @@
+/*
+ * Fichier: where_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests du langage d'expressions de -where.
+ */
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// TestWhereMatch valide l'évaluation des expressions: priorités, court-circuit, littéraux en
+// notation scientifique, division par zéro et débordement.
+func TestWhereMatch(t *testing.T) {
+	res := primes.Result{P: 5, Q: 53, N: 11261}
+	twin := primes.Result{P: 3, Q: 5, N: 109, Twin: true}
+	testCases := []struct {
+		expr     string
+		res      primes.Result
+		expected bool
+	}{
+		{"n > 1e4 && p % 4 == 1", res, true},
+		{"n > 1e4 && p % 4 == 3", res, false},
+		{"p*p + 4*q*q == n", res, true},
+		{"1 + 2 * 3 == 7", res, true},
+		{"(1 + 2) * 3 == 9", res, true},
+		{"-p + 10 == 5", res, true},
+		{"!(q < 50)", res, true},
+		{"twin", twin, true},
+		{"twin || n < 100", res, false},
+		{"p == 5 || n / 0 > 1", res, true},
+		{"n / (q - 53) > 0", res, false},
+		{"n % 0 == 0 || p == 5", res, false},
+		{"n * n * n * n * n > 0", res, false},
+		{"1_000 < n", res, true},
+		{"2.5e1 == 25", res, true},
+	}
+	for _, tc := range testCases {
+		e, err := parseWhere(tc.expr)
+		if err != nil {
+			t.Errorf("parseWhere(%q) = %v", tc.expr, err)
+			continue
+		}
+		if got := e.match(tc.res); got != tc.expected {
+			t.Errorf("%q sur %+v = %v, attendu %v", tc.expr, tc.res, got, tc.expected)
+		}
+	}
+}
+
+// TestParseWhereErrors vérifie que les expressions invalides sont refusées avec leur position.
+func TestParseWhereErrors(t *testing.T) {
+	testCases := []struct{ expr, expected string }{
+		{"", "position 1"},
+		{"n >", "position 4"},
+		{"m > 1", `champ "m" inconnu`},
+		{"n > 1.5", `nombre "1.5" invalide`},
+		{"(n > 1", `")" attendu`},
+		{"n > 1)", `position 6: ")" inattendu`},
+		{"n = 1", `caractère '='`},
+	}
+	for _, tc := range testCases {
+		_, err := parseWhere(tc.expr)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("parseWhere(%q) = %v, attendu une erreur contenant %q", tc.expr, err, tc.expected)
+		}
+	}
+}
+
+// TestRunWhere vérifie que -where restreint les résultats écrits, pas le décompte du résumé.
+func TestRunWhere(t *testing.T) {
+	var out strings.Builder
+	args := []string{"-lang", "fr", "-limit", "60", "-manifest=false", "-where", "n > 1e4 && p % 4 == 1"}
+	if err := run(args, &out, io.Discard); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	for _, expected := range []string{"11\u00a0261 ", "12\u00a0917 ", "88 nombres premiers spéciaux trouvés", "Résultats écrits: 2 "} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("sortie sans %q:\n%s", expected, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "109 ") {
+		t.Errorf("résultat n=109 écrit malgré -where:\n%s", out.String())
+	}
+	if err := run([]string{"-where", "n >"}, io.Discard, io.Discard); exitCode(err) != exitInvalidFlags {
+		t.Errorf("run(-where \"n >\") = %v, attendu des options invalides", err)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Fichier: workerstats.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Statistiques par worker du résumé: lots et paires traités, résultats et
+ * temps d'occupation de chaque worker, puis écart entre le plus et le moins
+ * occupé, pour rendre visible un déséquilibre de charge du pool et évaluer
+ * les changements d'ordonnancement sur des données.
+ */
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+)
+
+// formatWorkerStats met en forme l'activité des workers pour une recherche de durée elapsed.
+func formatWorkerStats(workers []primes.WorkerStats, elapsed time.Duration) string {
+	var b strings.Builder
+	b.WriteString(tr(msgWorkerStatsTitle))
+	var jobs int64
+	var busy time.Duration
+	for _, ws := range workers {
+		jobs += ws.Jobs
+		busy += ws.Busy
+	}
+	for i, ws := range workers {
+		b.WriteString(tr(msgWorkerStatsLine, i, ws.Batches, ws.Jobs, percentOf(ws.Jobs, jobs), ws.Found,
+			ws.Busy.Round(time.Millisecond), percentOf(int64(ws.Busy), int64(elapsed))))
+	}
+	if len(workers) > 1 && busy > 0 {
+		cmpBusy := func(x, y primes.WorkerStats) int { return cmp.Compare(x.Busy, y.Busy) }
+		least, most := slices.MinFunc(workers, cmpBusy).Busy, slices.MaxFunc(workers, cmpBusy).Busy
+		mean := float64(busy) / float64(len(workers))
+		b.WriteString(tr(msgWorkerImbalance, least.Round(time.Millisecond), most.Round(time.Millisecond), float64(most)/mean))
+	}
+	return b.String()
+}
+
+// percentOf retourne part en pourcentage de total (0 si total est nul).
+func percentOf(part, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
@@ -0,0 +1,64 @@
+/*
+ * Fichier: workerstats_test.go
+ * Auteur: [Votre Nom/Organisation]
+ * Date: 16 octobre 2026
+ *
+ * Description:
+ * Tests des statistiques par worker du résumé.
+ */
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/PrimeNumber/primes"
+	"golang.org/x/text/language"
+)
+
+// TestFormatWorkerStats vérifie les lignes par worker et la mesure du déséquilibre.
+func TestFormatWorkerStats(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	got := formatWorkerStats([]primes.WorkerStats{
+		{Batches: 10, Jobs: 600, Found: 5, Busy: 1500 * time.Millisecond},
+		{Batches: 7, Jobs: 400, Found: 3, Busy: 500 * time.Millisecond},
+	}, 2*time.Second)
+	for _, expected := range []string{
+		"Activité par worker:\n",
+		"  worker 0: 10 lots, 600 paires (60.0 %), 5 résultats, occupé 1.5s (75 % de la recherche)\n",
+		"  worker 1: 7 lots, 400 paires (40.0 %), 3 résultats, occupé 500ms (25 % de la recherche)\n",
+		"  Déséquilibre: temps d'occupation de 500ms à 1.5s, worker le plus occupé à 1.50× la moyenne.\n",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("sortie sans %q:\n%s", expected, got)
+		}
+	}
+	if got := formatWorkerStats([]primes.WorkerStats{{}, {}}, 0); strings.Contains(got, "Déséquilibre") {
+		t.Errorf("déséquilibre affiché sans temps d'occupation:\n%s", got)
+	}
+}
+
+// TestChannelBuffers vérifie que les tampons retenus, adaptatifs ou explicites, sont annoncés et
+// qu'un tampon minimal ne change pas les résultats.
+func TestChannelBuffers(t *testing.T) {
+	setLanguage(language.French)
+	defer setLanguage(defaultLanguage)
+	var adaptive, status, tiny, plain strings.Builder
+	if err := run([]string{"-limit", "100", "-workers", "2", "-batch", "8", "-format", "ndjson", "-manifest=false"}, &plain, &adaptive); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if want := "Tampons des canaux: tâches 128 lots (adaptatif), résultats 100 (adaptatif)\n"; !strings.Contains(adaptive.String(), want) {
+		t.Errorf("journal sans %q:\n%s", want, adaptive.String())
+	}
+	if err := run([]string{"-limit", "100", "-workers", "2", "-batch", "8", "-format", "ndjson", "-manifest=false", "-jobs-buffer", "1", "-results-buffer", "1"}, &tiny, &status); err != nil {
+		t.Fatalf("run avec tampons minimaux: %v", err)
+	}
+	if want := "Tampons des canaux: tâches 1 lots (-jobs-buffer), résultats 1 (-results-buffer)\n"; !strings.Contains(status.String(), want) {
+		t.Errorf("journal sans %q:\n%s", want, status.String())
+	}
+	if got, want := resultLines(tiny.String()), resultLines(plain.String()); len(got) != len(want) {
+		t.Errorf("%d résultats avec tampons minimaux, attendu %d", len(got), len(want))
+	}
+}